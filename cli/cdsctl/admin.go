@@ -13,6 +13,7 @@ var adminCmd = cli.Command{
 
 func adminCommands() []*cobra.Command {
 	return []*cobra.Command{
+		adminAudit(),
 		adminDatabase(),
 		adminServices(),
 		adminCdn(),
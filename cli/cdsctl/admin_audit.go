@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var adminAuditCmd = cli.Command{
+	Name:  "audit",
+	Short: "Search the CDS audit trail",
+	Long: `Search the audit logs of security sensitive actions (RBAC, regions, hatcheries, organizations, consumers, project keys).
+
+Use --format json to get the data before and after each action.`,
+	Example: `cdsctl admin audit --action rbac.import --since 2022-01-01T00:00:00Z
+cdsctl admin audit --username my-user --format json`,
+	Flags: []cli.Flag{
+		{Name: "action", Usage: "Filter by action, ex: region.delete"},
+		{Name: "target-type", Usage: "Filter by target type: rbac, region, hatchery, organization, consumer, project_key"},
+		{Name: "target", Usage: "Filter by target name"},
+		{Name: "project", Usage: "Filter by project key"},
+		{Name: "username", Usage: "Filter by username of the actor"},
+		{Name: "since", Usage: "Only logs created after given date (RFC3339)"},
+		{Name: "until", Usage: "Only logs created before given date (RFC3339)"},
+		{Name: "limit", Usage: "Maximum number of logs", Default: "100"},
+		{Name: "offset", Usage: "Number of logs to skip", Default: "0"},
+	},
+}
+
+func adminAudit() *cobra.Command {
+	return cli.NewListCommand(adminAuditCmd, adminAuditRun, nil)
+}
+
+func adminAuditRun(v cli.Values) (cli.ListResult, error) {
+	filter := sdk.AuditLogFilter{
+		Action:     v.GetString("action"),
+		TargetType: v.GetString("target-type"),
+		TargetName: v.GetString("target"),
+		ProjectKey: v.GetString("project"),
+		Username:   v.GetString("username"),
+	}
+
+	for k, dest := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if s := v.GetString(k); s != "" {
+			t, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				return nil, cli.NewError("invalid %s value %q, expected RFC3339 date", k, s)
+			}
+			*dest = t
+		}
+	}
+
+	var err error
+	filter.Limit, err = v.GetInt64("limit")
+	if err != nil {
+		return nil, err
+	}
+	filter.Offset, err = v.GetInt64("offset")
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := client.AdminAuditLogs(context.Background(), filter)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(logs), nil
+}
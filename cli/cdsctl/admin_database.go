@@ -25,6 +25,10 @@ func adminDatabase() *cobra.Command {
 		cli.NewCommand(adminDatabaseSignatureRoll, adminDatabaseSignatureRollFunc, nil),
 		cli.NewCommand(adminDatabaseEncryptionResume, adminDatabaseEncryptionResumeFunc, nil),
 		cli.NewCommand(adminDatabaseEncryptionRoll, adminDatabaseEncryptionRollFunc, nil),
+		cli.NewListCommand(adminDatabaseRollJobStart, adminDatabaseRollJobStartFunc, nil),
+		cli.NewListCommand(adminDatabaseRollJobList, adminDatabaseRollJobListFunc, nil),
+		cli.NewGetCommand(adminDatabaseRollJobShow, adminDatabaseRollJobShowFunc, nil),
+		cli.NewCommand(adminDatabaseRollJobCancel, adminDatabaseRollJobCancelFunc, nil),
 	})
 }
 
@@ -233,3 +237,128 @@ func adminDatabaseEncryptionRollFunc(args cli.Values) error {
 	}
 	return nil
 }
+
+var adminDatabaseRollJobStart = cli.Command{
+	Name:  "roll-job-start",
+	Short: "Start a server side job that rolls all signed or encrypted data in database",
+	Example: `
+$ cdsctl admin database roll-job-start api encryption
+$ cdsctl admin database roll-job-start cdn signature --batch-size 500 --delay 100
+	`,
+	Args: []cli.Arg{
+		{
+			Name: argServiceName,
+			IsValid: func(s string) bool {
+				return s == sdk.TypeCDN || s == sdk.TypeAPI
+			},
+		},
+		{
+			Name: "type",
+			IsValid: func(s string) bool {
+				return s == sdk.DatabaseRollJobTypeSignature || s == sdk.DatabaseRollJobTypeEncryption
+			},
+		},
+	},
+	VariadicArgs: cli.Arg{
+		Name:       "entity",
+		AllowEmpty: true,
+	},
+	Flags: []cli.Flag{
+		{
+			Name:    "batch-size",
+			Usage:   "Number of tuples rolled in each batch",
+			Default: strconv.Itoa(sdk.DefaultDatabaseRollJobBatchSize),
+		},
+		{
+			Name:    "delay",
+			Usage:   "Pause in milliseconds between two batches",
+			Default: "0",
+		},
+	},
+}
+
+func adminDatabaseRollJobStartFunc(args cli.Values) (cli.ListResult, error) {
+	batchSize, err := args.GetInt64("batch-size")
+	if err != nil {
+		return nil, err
+	}
+	delay, err := args.GetInt64("delay")
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := client.AdminDatabaseRollJobCreate(args.GetString(argServiceName), sdk.DatabaseRollJobRequest{
+		Type:      args.GetString("type"),
+		Entities:  args.GetStringSlice("entity"),
+		BatchSize: batchSize,
+		Delay:     delay,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(jobs), nil
+}
+
+var adminDatabaseRollJobList = cli.Command{
+	Name:  "roll-job-list",
+	Short: "List database roll jobs and their progress",
+	Args: []cli.Arg{
+		{
+			Name: argServiceName,
+			IsValid: func(s string) bool {
+				return s == sdk.TypeCDN || s == sdk.TypeAPI
+			},
+		},
+	},
+}
+
+func adminDatabaseRollJobListFunc(args cli.Values) (cli.ListResult, error) {
+	jobs, err := client.AdminDatabaseRollJobList(args.GetString(argServiceName))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(jobs), nil
+}
+
+var adminDatabaseRollJobShow = cli.Command{
+	Name:  "roll-job-show",
+	Short: "Show a database roll job",
+	Args: []cli.Arg{
+		{
+			Name: argServiceName,
+			IsValid: func(s string) bool {
+				return s == sdk.TypeCDN || s == sdk.TypeAPI
+			},
+		},
+		{Name: "id"},
+	},
+}
+
+func adminDatabaseRollJobShowFunc(args cli.Values) (interface{}, error) {
+	id, err := args.GetInt64("id")
+	if err != nil {
+		return nil, err
+	}
+	return client.AdminDatabaseRollJobGet(args.GetString(argServiceName), id)
+}
+
+var adminDatabaseRollJobCancel = cli.Command{
+	Name:  "roll-job-cancel",
+	Short: "Cancel a database roll job",
+	Args: []cli.Arg{
+		{
+			Name: argServiceName,
+			IsValid: func(s string) bool {
+				return s == sdk.TypeCDN || s == sdk.TypeAPI
+			},
+		},
+		{Name: "id"},
+	},
+}
+
+func adminDatabaseRollJobCancelFunc(args cli.Values) error {
+	id, err := args.GetInt64("id")
+	if err != nil {
+		return err
+	}
+	return client.AdminDatabaseRollJobCancel(args.GetString(argServiceName), id)
+}
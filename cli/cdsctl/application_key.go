@@ -20,6 +20,7 @@ func applicationKey() *cobra.Command {
 		cli.NewCommand(applicationKeyCreateCmd, applicationCreateKeyRun, nil, withAllCommandModifiers()...),
 		cli.NewListCommand(applicationKeyListCmd, applicationListKeyRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(applicationKeyDeleteCmd, applicationDeleteKeyRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(applicationKeyRotateCmd, applicationRotateKeyRun, nil, withAllCommandModifiers()...),
 	})
 }
 
@@ -82,3 +83,29 @@ var applicationKeyDeleteCmd = cli.Command{
 func applicationDeleteKeyRun(v cli.Values) error {
 	return client.ApplicationKeysDelete(v.GetString(_ProjectKey), v.GetString(_ApplicationName), v.GetString("key-name"))
 }
+
+var applicationKeyRotateCmd = cli.Command{
+	Name:  "rotate",
+	Short: "Rotate a CDS application key, the previous key stays valid during the grace period",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _ApplicationName},
+	},
+	Args: []cli.Arg{
+		{Name: "key-name"},
+	},
+	Flags: keyRotationFlags,
+}
+
+func applicationRotateKeyRun(v cli.Values) error {
+	req, err := keyRotationRequestFromValues(v)
+	if err != nil {
+		return err
+	}
+	r, err := client.ApplicationKeyRotate(v.GetString(_ProjectKey), v.GetString(_ApplicationName), v.GetString("key-name"), req)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Application key %s rotated with success, previous key expires at %s\n", r.KeyName, r.ExpireAt)
+	return nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -103,6 +104,26 @@ var authConsumerNewCmd = cli.Command{
 			Name:  "service-region",
 			Usage: "Region where the service will be started",
 		},
+		{
+			Name:  "projects",
+			Type:  cli.FlagSlice,
+			Usage: "Restrict the consumer to projects or workflows (ex: MYPROJ or MYPROJ/my-workflow)",
+		},
+		{
+			Name:  "read-only",
+			Type:  cli.FlagBool,
+			Usage: "Restricted projects can only be read",
+		},
+		{
+			Name:  "run-only",
+			Type:  cli.FlagBool,
+			Usage: "Restricted projects can only be read and run",
+		},
+		{
+			Name:  "allowed-cidrs",
+			Type:  cli.FlagSlice,
+			Usage: "Restrict the consumer to IP ranges (ex: 10.0.0.0/8)",
+		},
 	},
 }
 
@@ -211,6 +232,23 @@ func authConsumerNewRun(v cli.Values) error {
 		},
 	}
 
+	consumer.AuthConsumerUser.Restrictions.AllowedCIDRs = v.GetStringSlice("allowed-cidrs")
+	for _, p := range v.GetStringSlice("projects") {
+		key, workflow, _ := strings.Cut(p, "/")
+		r := consumer.AuthConsumerUser.Restrictions.ProjectRestriction(key)
+		if r == nil {
+			consumer.AuthConsumerUser.Restrictions.Projects = append(consumer.AuthConsumerUser.Restrictions.Projects, sdk.AuthConsumerProjectRestriction{
+				ProjectKey: key,
+				ReadOnly:   v.GetBool("read-only"),
+				RunOnly:    v.GetBool("run-only"),
+			})
+			r = &consumer.AuthConsumerUser.Restrictions.Projects[len(consumer.AuthConsumerUser.Restrictions.Projects)-1]
+		}
+		if workflow != "" {
+			r.Workflows = append(r.Workflows, workflow)
+		}
+	}
+
 	if svcName != "" {
 		consumer.AuthConsumerUser.ServiceName = &svcName
 	}
@@ -2,11 +2,15 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strings"
 
+	"github.com/rockbears/yaml"
 	"github.com/spf13/cobra"
 
 	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/cdsclient"
 )
 
@@ -18,6 +22,7 @@ var experimentalRbacCmd = cli.Command{
 func experimentalRbac() *cobra.Command {
 	return cli.NewCommand(experimentalRbacCmd, nil, []*cobra.Command{
 		cli.NewCommand(rbacImportCmd, rbacImportFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(rbacExplainCmd, rbacExplainFunc, nil, withAllCommandModifiers()...),
 	})
 }
 
@@ -48,3 +53,123 @@ func rbacImportFunc(v cli.Values) error {
 	_, err = client.RBACImport(context.Background(), f, mods...)
 	return err
 }
+
+var rbacExplainCmd = cli.Command{
+	Name:  "explain",
+	Short: "Explain why a user is allowed or not on a route or for a role",
+	Long: `Explain the permission decision and the rbac rules that grant or would grant it.
+Without username or consumer, your own permissions are explained.
+Use --what-if to evaluate a rbac rule from a yaml file as if it was imported.`,
+	Example: `cdsctl experimental rbac explain --method DELETE --path /v2/project/MYPROJ/vcs/my-vcs
+cdsctl experimental rbac explain --username john --role manage-vcs --project MYPROJ
+cdsctl experimental rbac explain --role execute --region my-region --what-if file.yml`,
+	Ctx: []cli.Arg{},
+	Flags: []cli.Flag{
+		{Name: "username", Usage: "User to explain"},
+		{Name: "consumer", Usage: "Consumer ID to explain"},
+		{Name: "method", Usage: "HTTP method of the route", Default: "GET"},
+		{Name: "path", Usage: "Path of the route"},
+		{Name: "role", Usage: "Role to explain"},
+		{Name: "project", Usage: "Project key"},
+		{Name: "repository", Usage: "Repository name"},
+		{Name: "entity", Usage: "Entity name"},
+		{Name: "region", Usage: "Region name"},
+		{Name: "hatchery", Usage: "Hatchery name"},
+		{Name: "what-if", Usage: "Rbac rule yaml file to simulate"},
+	},
+}
+
+func rbacExplainFunc(v cli.Values) error {
+	req := sdk.RBACExplainRequest{
+		Username:   v.GetString("username"),
+		ConsumerID: v.GetString("consumer"),
+		Path:       v.GetString("path"),
+		Role:       v.GetString("role"),
+		Project:    v.GetString("project"),
+		Repository: v.GetString("repository"),
+		Entity:     v.GetString("entity"),
+		Region:     v.GetString("region"),
+		Hatchery:   v.GetString("hatchery"),
+	}
+	if req.Path != "" {
+		req.Method = strings.ToUpper(v.GetString("method"))
+	}
+	if filename := v.GetString("what-if"); filename != "" {
+		btes, err := os.ReadFile(filename)
+		if err != nil {
+			return cli.WrapError(err, "unable to read file %s", filename)
+		}
+		var rbacRule sdk.RBAC
+		if err := yaml.Unmarshal(btes, &rbacRule); err != nil {
+			return cli.WrapError(err, "unable to parse file %s", filename)
+		}
+		req.WhatIf = &rbacRule
+	}
+
+	explanation, err := client.RBACExplain(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	decision := "denied"
+	if explanation.Allowed {
+		decision = "allowed"
+	}
+	if explanation.Route != "" {
+		fmt.Printf("User %s is %s on %s\n", explanation.Username, decision, explanation.Route)
+	} else {
+		fmt.Printf("User %s is %s\n", explanation.Username, decision)
+	}
+	if explanation.Admin && !explanation.Allowed {
+		fmt.Println("User is an administrator and can bypass permissions")
+	}
+	for _, r := range explanation.Requirements {
+		fmt.Printf("\n%s\n", rbacExplainRequirementString(r))
+		if r.Message != "" {
+			fmt.Printf("  %s\n", r.Message)
+		}
+		if r.Type != "" && len(r.Grants) == 0 {
+			fmt.Println("  no rbac rule found")
+		}
+		for _, g := range r.Grants {
+			status := "granted"
+			if !g.Granted {
+				status = "not granted: " + g.Reason
+			}
+			name := g.RBAC
+			if g.WhatIf {
+				name += " (what-if)"
+			}
+			fmt.Printf("  - %s role %s %s", name, g.Role, status)
+			if len(g.Via) > 0 {
+				fmt.Printf(" via %s", strings.Join(g.Via, ", "))
+			}
+			fmt.Println()
+		}
+	}
+	return nil
+}
+
+func rbacExplainRequirementString(r sdk.RBACExplainRequirement) string {
+	status := "[DENIED]"
+	if r.Allowed {
+		status = "[ALLOWED]"
+	}
+	s := status
+	if r.Checker != "" {
+		s += " " + r.Checker
+	}
+	if r.Type == "" {
+		return s
+	}
+	s += fmt.Sprintf(" %s role %s", r.Type, strings.Join(r.Roles, " or "))
+	for _, t := range []struct{ name, value string }{
+		{"project", r.Project}, {"repository", r.Repository}, {"entity", r.Entity},
+		{"region", r.Region}, {"hatchery", r.Hatchery},
+	} {
+		if t.value != "" {
+			s += fmt.Sprintf(" %s=%s", t.name, t.value)
+		}
+	}
+	return s
+}
@@ -20,6 +20,10 @@ func projectKey() *cobra.Command {
 		cli.NewCommand(projectKeyCreateCmd, projectCreateKeyRun, nil, withAllCommandModifiers()...),
 		cli.NewListCommand(projectKeyListCmd, projectListKeyRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(projectKeyDeleteCmd, projectDeleteKeyRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectKeyRotateCmd, projectRotateKeyRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(projectKeyRotationListCmd, projectListKeyRotationRun, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(projectKeyRotationPolicyShowCmd, projectShowKeyRotationPolicyRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectKeyRotationPolicySetCmd, projectSetKeyRotationPolicyRun, nil, withAllCommandModifiers()...),
 	})
 }
 
@@ -79,3 +83,118 @@ var projectKeyDeleteCmd = cli.Command{
 func projectDeleteKeyRun(v cli.Values) error {
 	return client.ProjectKeysDelete(v.GetString(_ProjectKey), v.GetString("key-name"))
 }
+
+var keyRotationFlags = []cli.Flag{
+	{
+		Name:    "grace-period",
+		Usage:   "Number of hours during which the previous key stays valid",
+		Default: "24",
+	},
+	{
+		Name:    "push-to-vcs",
+		Usage:   "Push the new public key as deploy key on repositories using it",
+		Default: "false",
+		Type:    cli.FlagBool,
+	},
+}
+
+func keyRotationRequestFromValues(v cli.Values) (sdk.KeyRotationRequest, error) {
+	grace, err := v.GetInt64("grace-period")
+	if err != nil {
+		return sdk.KeyRotationRequest{}, err
+	}
+	return sdk.KeyRotationRequest{
+		GracePeriod: grace,
+		PushToVCS:   v.GetBool("push-to-vcs"),
+	}, nil
+}
+
+var projectKeyRotateCmd = cli.Command{
+	Name:  "rotate",
+	Short: "Rotate a CDS project key, the previous key stays valid during the grace period",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "key-name"},
+	},
+	Flags: keyRotationFlags,
+}
+
+func projectRotateKeyRun(v cli.Values) error {
+	req, err := keyRotationRequestFromValues(v)
+	if err != nil {
+		return err
+	}
+	r, err := client.ProjectKeyRotate(v.GetString(_ProjectKey), v.GetString("key-name"), req)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Project key %s rotated with success, previous key expires at %s\n", r.KeyName, r.ExpireAt)
+	return nil
+}
+
+var projectKeyRotationListCmd = cli.Command{
+	Name:  "rotation-list",
+	Short: "List CDS project key rotations",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func projectListKeyRotationRun(v cli.Values) (cli.ListResult, error) {
+	rs, err := client.ProjectKeyRotationsList(v.GetString(_ProjectKey))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(rs), nil
+}
+
+var projectKeyRotationPolicyShowCmd = cli.Command{
+	Name:  "rotation-policy",
+	Short: "Show CDS project key rotation policy",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func projectShowKeyRotationPolicyRun(v cli.Values) (interface{}, error) {
+	return client.ProjectKeyRotationPolicyGet(v.GetString(_ProjectKey))
+}
+
+var projectKeyRotationPolicySetCmd = cli.Command{
+	Name:  "rotation-policy-set",
+	Short: "Set CDS project key rotation policy",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "interval-days"},
+	},
+	Flags: append([]cli.Flag{
+		{
+			Name:    "disable",
+			Usage:   "Disable scheduled key rotation",
+			Default: "false",
+			Type:    cli.FlagBool,
+		},
+	}, keyRotationFlags...),
+}
+
+func projectSetKeyRotationPolicyRun(v cli.Values) error {
+	interval, err := v.GetInt64("interval-days")
+	if err != nil {
+		return err
+	}
+	req, err := keyRotationRequestFromValues(v)
+	if err != nil {
+		return err
+	}
+	policy := &sdk.KeyRotationPolicy{
+		Enabled:     !v.GetBool("disable"),
+		Interval:    interval,
+		GracePeriod: req.GracePeriod,
+		PushToVCS:   req.PushToVCS,
+	}
+	return client.ProjectKeyRotationPolicyUpdate(v.GetString(_ProjectKey), policy)
+}
@@ -31,6 +31,7 @@ func queue() *cobra.Command {
 	return cli.NewListCommand(queueCmd, queueRun, []*cobra.Command{
 		cli.NewCommand(queueUICmd, queueUIRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(queueStopAllCmd, queueStopAllRun, nil, withAllCommandModifiers()...),
+		queueQuota(),
 	})
 }
 
@@ -142,10 +143,13 @@ type jobCLI struct {
 	Duration     time.Duration `cli:"-"`
 	BookedBy     string        `cli:"booked_by"`
 	TriggeredBy  string        `cli:"triggered_by"`
+	Position     string        `cli:"position"`
+	ETA          string        `cli:"eta"`
+	Reason       string        `cli:"reason"`
 }
 
 func getJobQueue(status ...string) ([]jobCLI, error) {
-	jobs, err := client.QueueWorkflowNodeJobRun(cdsclient.Status(status...))
+	jobs, err := client.QueueWorkflowNodeJobRun(cdsclient.Status(status...), cdsclient.WithQueryParameter("withQueueInfo", "true"))
 	if err != nil {
 		return nil, err
 	}
@@ -170,6 +174,23 @@ func getJobQueue(status ...string) ([]jobCLI, error) {
 			BookedBy:     jr.BookedBy.Name,
 			TriggeredBy:  getVarsInPbj("cds.triggered_by.username", jr.Parameters),
 		}
+		if info := jr.QueueInfo; info != nil {
+			jobsUI[k].Position = fmt.Sprintf("%d/%d", info.Position, info.Total)
+			if info.Position == 0 {
+				jobsUI[k].Position = fmt.Sprintf("-/%d", info.Total)
+			}
+			if info.ETA != nil {
+				eta := time.Until(*info.ETA)
+				if eta < 0 {
+					eta = 0
+				}
+				jobsUI[k].ETA = sdk.Round(eta, time.Second).String()
+			}
+			jobsUI[k].Reason = info.Reason
+			if info.ReasonDetails != "" {
+				jobsUI[k].Reason += ": " + info.ReasonDetails
+			}
+		}
 	}
 
 	return jobsUI, nil
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var queueQuotaCmd = cli.Command{
+	Name:  "quota",
+	Short: "Manage queue fair-share quotas",
+}
+
+func queueQuota() *cobra.Command {
+	return cli.NewCommand(queueQuotaCmd, nil, []*cobra.Command{
+		cli.NewListCommand(queueQuotaUsageCmd, queueQuotaUsageRun, nil),
+		cli.NewListCommand(queueQuotaListCmd, queueQuotaListRun, nil),
+		cli.NewCommand(queueQuotaSetCmd, queueQuotaSetRun, nil),
+		cli.NewDeleteCommand(queueQuotaDeleteCmd, queueQuotaDeleteRun, nil),
+	})
+}
+
+var queueQuotaUsageCmd = cli.Command{
+	Name:    "usage",
+	Short:   "Show the current usage of the queue by project and organization",
+	Example: "cdsctl queue quota usage",
+}
+
+func queueQuotaUsageRun(_ cli.Values) (cli.ListResult, error) {
+	usages, err := client.QueueQuotaUsages(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(usages), nil
+}
+
+var queueQuotaListCmd = cli.Command{
+	Name:    "list",
+	Aliases: []string{"ls"},
+	Short:   "List all queue quotas (admin only)",
+	Example: "cdsctl queue quota list",
+}
+
+func queueQuotaListRun(_ cli.Values) (cli.ListResult, error) {
+	quotas, err := client.AdminQueueQuotaList(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(quotas), nil
+}
+
+var queueQuotaSetCmd = cli.Command{
+	Name:    "set",
+	Short:   "Set the queue quota of a project or an organization (admin only)",
+	Example: "cdsctl queue quota set --project MYPROJ --weight 2 --max-per-model 10",
+	Flags: []cli.Flag{
+		{
+			Name:  "project",
+			Usage: "Key of the project",
+		},
+		{
+			Name:  "organization",
+			Usage: "Name of the organization",
+		},
+		{
+			Name:    "weight",
+			Usage:   "Weight of the project or organization in the queue",
+			Default: "1",
+		},
+		{
+			Name:    "max-per-model",
+			Usage:   "Max concurrent building jobs on a same worker model, 0 for no limit",
+			Default: "0",
+		},
+	},
+}
+
+func queueQuotaSetRun(v cli.Values) error {
+	weight, err := v.GetInt64("weight")
+	if err != nil {
+		return err
+	}
+	maxPerModel, err := v.GetInt64("max-per-model")
+	if err != nil {
+		return err
+	}
+	q := sdk.QueueQuota{
+		ProjectKey:                v.GetString("project"),
+		Organization:              v.GetString("organization"),
+		Weight:                    weight,
+		MaxConcurrentJobsPerModel: maxPerModel,
+	}
+	if err := q.IsValid(); err != nil {
+		return err
+	}
+
+	quotas, err := client.AdminQueueQuotaList(context.Background())
+	if err != nil {
+		return err
+	}
+	for i := range quotas {
+		if quotas[i].ProjectKey == q.ProjectKey && quotas[i].Organization == q.Organization {
+			q.ID = quotas[i].ID
+			if err := client.AdminQueueQuotaUpdate(context.Background(), &q); err != nil {
+				return err
+			}
+			fmt.Printf("Queue quota %d updated\n", q.ID)
+			return nil
+		}
+	}
+
+	if err := client.AdminQueueQuotaCreate(context.Background(), &q); err != nil {
+		return err
+	}
+	fmt.Printf("Queue quota %d created\n", q.ID)
+	return nil
+}
+
+var queueQuotaDeleteCmd = cli.Command{
+	Name:    "delete",
+	Aliases: []string{"remove", "rm"},
+	Short:   "Delete a queue quota (admin only)",
+	Example: "cdsctl queue quota delete <id>",
+	Args: []cli.Arg{
+		{Name: "id"},
+	},
+}
+
+func queueQuotaDeleteRun(v cli.Values) error {
+	id, err := v.GetInt64("id")
+	if err != nil {
+		return err
+	}
+	return client.AdminQueueQuotaDelete(context.Background(), id)
+}
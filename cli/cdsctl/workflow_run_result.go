@@ -267,7 +267,12 @@ func toCLIRunResult(results []sdk.WorkflowRunResult) ([]RunResultCli, error) {
 			} else {
 				artiType = artiResult.RepoType
 			}
-
+		case sdk.WorkflowRunResultTypeCodeQuality:
+			codeQualityResult, err := r.GetCodeQuality()
+			if err != nil {
+				return nil, err
+			}
+			name = codeQualityResult.Name
 		}
 
 		cliresults = append(cliresults, RunResultCli{
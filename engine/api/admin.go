@@ -73,6 +73,9 @@ func (api *API) postAdminOrganizationHandler() service.Handler {
 		if err := organization.Insert(ctx, tx, &org); err != nil {
 			return err
 		}
+		if err := auditLog(ctx, tx, sdk.AuditActionOrganizationAdd, sdk.AuditTargetOrganization, org.Name, "", nil, org); err != nil {
+			return err
+		}
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
@@ -109,6 +112,9 @@ func (api *API) deleteAdminOrganizationsHandler() service.Handler {
 		if err := organization.Delete(tx, orga.ID); err != nil {
 			return err
 		}
+		if err := auditLog(ctx, tx, sdk.AuditActionOrganizationDelete, sdk.AuditTargetOrganization, orga.Name, "", orga, nil); err != nil {
+			return err
+		}
 		return sdk.WithStack(tx.Commit())
 	}
 }
@@ -309,6 +315,87 @@ func (api *API) postAdminDatabaseRollEncryptedEntityByPrimaryKey() service.Handl
 	return database.AdminDatabaseRollEncryptedEntityByPrimaryKey(api.mustDB, gorpmapping.Mapper)
 }
 
+func (api *API) postAdminDatabaseRollJob() service.Handler {
+	return database.AdminDatabaseRollJobCreate(api.mustDB, gorpmapping.Mapper)
+}
+
+func (api *API) getAdminDatabaseRollJobs() service.Handler {
+	return database.AdminDatabaseRollJobList(api.mustDB)
+}
+
+func (api *API) getAdminDatabaseRollJob() service.Handler {
+	return database.AdminDatabaseRollJobGet(api.mustDB)
+}
+
+func (api *API) postAdminDatabaseRollJobCancel() service.Handler {
+	return database.AdminDatabaseRollJobCancel(api.mustDB)
+}
+
+func (api *API) getAdminQueueQuotas() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		quotas, err := workflow.LoadQueueQuotas(api.mustDB())
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, quotas, http.StatusOK)
+	}
+}
+
+func (api *API) postAdminQueueQuota() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var q sdk.QueueQuota
+		if err := service.UnmarshalBody(r, &q); err != nil {
+			return err
+		}
+		if err := workflow.InsertQueueQuota(api.mustDB(), &q); err != nil {
+			return err
+		}
+		return service.WriteJSON(w, q, http.StatusOK)
+	}
+}
+
+func (api *API) putAdminQueueQuota() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+
+		var q sdk.QueueQuota
+		if err := service.UnmarshalBody(r, &q); err != nil {
+			return err
+		}
+
+		old, err := workflow.LoadQueueQuotaByID(api.mustDB(), id)
+		if err != nil {
+			return err
+		}
+		if q.ProjectKey != old.ProjectKey || q.Organization != old.Organization {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "project and organization of a queue quota can't be changed")
+		}
+
+		q.ID = old.ID
+		q.Created = old.Created
+		if err := workflow.UpdateQueueQuota(api.mustDB(), &q); err != nil {
+			return err
+		}
+		return service.WriteJSON(w, q, http.StatusOK)
+	}
+}
+
+func (api *API) deleteAdminQueueQuota() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+		if _, err := workflow.LoadQueueQuotaByID(api.mustDB(), id); err != nil {
+			return err
+		}
+		return workflow.DeleteQueueQuota(api.mustDB(), id)
+	}
+}
+
 func (api *API) getAdminFeatureFlipping() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		all, err := featureflipping.LoadAll(ctx, gorpmapping.Mapper, api.mustDB())
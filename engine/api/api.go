@@ -35,6 +35,7 @@ import (
 	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/integration"
 	"github.com/ovh/cds/engine/api/mail"
+	"github.com/ovh/cds/engine/api/mergequeue"
 	"github.com/ovh/cds/engine/api/metrics"
 	"github.com/ovh/cds/engine/api/migrate"
 	"github.com/ovh/cds/engine/api/notification"
@@ -122,6 +123,7 @@ type Configuration struct {
 			SignupDisabled       bool   `toml:"signupDisabled" default:"false" json:"signupDisabled"`
 			SignupAllowedDomains string `toml:"signupAllowedDomains" default:"" comment:"Allow signup from selected domains only - comma separated. Example: your-domain.com,another-domain.com" commented:"true" json:"signupAllowedDomains"`
 			Organization         string `toml:"organization" default:"default" comment:"Organization assigned to user created by local authentication" json:"organization"`
+			MFASupportEnabled    bool   `toml:"mfaSupportEnabled" default:"false" comment:"Allow local users to enroll TOTP and WebAuthn second factors" json:"mfa_support_enabled"`
 		} `toml:"local" json:"local"`
 		CorporateSSO struct {
 			MFASupportEnabled bool   `json:"mfa_support_enabled" default:"false" toml:"mfaSupportEnabled"`
@@ -163,6 +165,12 @@ type Configuration struct {
 			ClientSecret   string `toml:"clientSecret" json:"-" comment:"OIDC Client Secret"`
 			Organization   string `toml:"organization" default:"default" comment:"Organization assigned to user created by openid authentication" json:"organization"`
 		} `toml:"oidc" json:"oidc" comment:"#######\n CDS <-> Open ID Connect Auth. Documentation on https://ovh.github.io/cds/docs/integrations/openid-connect/ \n######"`
+		JobIDToken struct {
+			Enabled          bool            `toml:"enabled" default:"false" json:"enabled"`
+			DefaultAudience  string          `toml:"defaultAudience" default:"" comment:"Audience of the token when the job does not request one, if empty the API URL will be used" json:"defaultAudience"`
+			AllowedAudiences sdk.StringSlice `toml:"allowedAudiences" comment:"The list of audiences that a job can request, let empty to authorize all audiences" json:"allowedAudiences"`
+			Duration         int64           `toml:"duration" default:"300" comment:"The duration of a job ID token (in seconds)" json:"duration"`
+		} `toml:"jobIDToken" json:"jobIDToken" comment:"#######\n CDS as an OpenID Connect issuer. Jobs can request short-lived ID tokens to federate with cloud providers, the issuer is the API URL \n######"`
 	} `toml:"auth" comment:"##############################\n CDS Authentication Settings# \n#############################" json:"auth"`
 	SMTP struct {
 		Disable               bool   `toml:"disable" default:"true" json:"disable" comment:"Set to false to enable the internal SMTP client. If false, emails will be displayed in CDS API Log."`
@@ -220,6 +228,11 @@ type Configuration struct {
 		DisablePurgeDeletion   bool   `toml:"disablePurgeDeletion" comment:"Allow you to disable the deletion part of the purge. Workflow run will only be marked as delete" json:"disablePurgeDeletion" default:"false"`
 	} `toml:"workflow" comment:"######################\n 'Workflow' global configuration \n######################" json:"workflow"`
 	EventBus event.Config `toml:"events" comment:"######################\n Event bus configuration \n######################" json:"events" mapstructure:"events"`
+	Metrics  struct {
+		MaxLabelValues int      `toml:"maxLabelValues" default:"500" comment:"Maximum number of distinct values for each label of the workflow metrics (project_key, workflow, worker_model, region), extra values are recorded as 'other'. 0 means unlimited" json:"maxLabelValues"`
+		DisabledLabels []string `toml:"disabledLabels" comment:"Labels removed from the workflow metrics to reduce their cardinality. Example: [\"workflow\",\"region\"]" json:"disabledLabels"`
+	} `toml:"metrics" comment:"######################\n Workflow metrics exposed on /mon/metrics: job queue wait, job duration, workflow run duration and status counters \n######################" json:"metrics"`
+	Audit audit.Config `toml:"audit" comment:"######################\n Audit trail configuration, audit logs can be streamed to an external syslog or SIEM endpoint \n######################" json:"audit" mapstructure:"audit"`
 }
 
 // DefaultValues is the struc for API Default configuration default values
@@ -289,6 +302,10 @@ type API struct {
 		RunResultToSynchronized    *stats.Int64Measure
 		RunResultSynchronized      *stats.Int64Measure
 		RunResultSynchronizedError *stats.Int64Measure
+		JobQueueWait               *stats.Float64Measure
+		JobDuration                *stats.Float64Measure
+		WorkflowRunDuration        *stats.Float64Measure
+		WorkflowLabels             *metrics.LabelLimiter
 	}
 	AuthenticationDrivers map[sdk.AuthConsumerType]sdk.AuthDriver
 }
@@ -653,6 +670,7 @@ func (a *API) Serve(ctx context.Context) error {
 		a.AuthenticationDrivers[sdk.ConsumerLocal] = local.NewDriver(
 			ctx,
 			a.Config.Auth.Local.SignupDisabled,
+			a.Config.Auth.Local.MFASupportEnabled,
 			a.Config.URL.UI,
 			a.Config.Auth.Local.SignupAllowedDomains,
 			a.Config.Auth.Local.Organization,
@@ -769,6 +787,11 @@ func (a *API) Serve(ctx context.Context) error {
 	a.GoRoutines.Run(ctx, "auditCleanerRoutine", func(ctx context.Context) {
 		auditCleanerRoutine(ctx, a.DBConnectionFactory.GetDBMap(gorpmapping.Mapper))
 	})
+	if a.Config.Audit.Syslog.Enabled {
+		a.GoRoutines.RunWithRestart(ctx, "audit.StreamLogs", func(ctx context.Context) {
+			audit.StreamLogs(ctx, a.DBConnectionFactory.GetDBMap(gorpmapping.Mapper), a.Config.Audit.Syslog)
+		})
+	}
 	a.GoRoutines.RunWithRestart(ctx, "repositoriesmanager.ReceiveEvents", func(ctx context.Context) {
 		repositoriesmanager.ReceiveEvents(ctx, a.DBConnectionFactory.GetDBMap(gorpmapping.Mapper), a.Cache)
 	})
@@ -790,6 +813,18 @@ func (a *API) Serve(ctx context.Context) error {
 	a.GoRoutines.RunWithRestart(ctx, "api.cleanRepositoryAnalysis", func(ctx context.Context) {
 		a.cleanRepositoryAnalysis(ctx, 1*time.Hour)
 	})
+	a.GoRoutines.RunWithRestart(ctx, "database.RollJobsRoutine", func(ctx context.Context) {
+		database.RollJobsRoutine(ctx, a.mustDB, gorpmapping.Mapper, time.Minute)
+	})
+	a.GoRoutines.RunWithRestart(ctx, "api.keyRotationRoutine", func(ctx context.Context) {
+		a.keyRotationRoutine(ctx, time.Minute)
+	})
+	a.GoRoutines.RunWithRestart(ctx, "mergequeue.Routine", func(ctx context.Context) {
+		mergequeue.Routine(ctx, a.mustDB, a.Cache, a.Config.URL.UI, 10*time.Second)
+	})
+	a.GoRoutines.RunWithRestart(ctx, "workflow.FailoverJobRunRegionsRoutine", func(ctx context.Context) {
+		workflow.FailoverJobRunRegionsRoutine(ctx, a.mustDB, a.Cache, 30*time.Second)
+	})
 	a.GoRoutines.RunWithRestart(ctx, "workflow.ResyncWorkflowRunResultsRoutine", func(ctx context.Context) {
 		workflow.ResyncWorkflowRunResultsRoutine(ctx, a.mustDB, a.Cache, 5*time.Second)
 	})
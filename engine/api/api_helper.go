@@ -109,6 +109,14 @@ func isHooks(ctx context.Context) bool {
 	return c.AuthConsumerUser.Service != nil && c.AuthConsumerUser.Service.Type == sdk.TypeHooks
 }
 
+func isVCS(ctx context.Context) bool {
+	c := getUserConsumer(ctx)
+	if c == nil {
+		return false
+	}
+	return c.AuthConsumerUser.Service != nil && c.AuthConsumerUser.Service.Type == sdk.TypeVCS
+}
+
 func isMFA(ctx context.Context) bool {
 	s := getAuthSession(ctx)
 	if s == nil {
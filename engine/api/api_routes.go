@@ -45,6 +45,14 @@ func (api *API) InitRouter() {
 	r.Handle("/auth/consumer/local/verify", ScopeNone(), r.POST(api.postAuthLocalVerifyHandler, service.OverrideAuth(service.NoAuthMiddleware)))
 	r.Handle("/auth/consumer/local/askReset", ScopeNone(), r.POST(api.postAuthLocalAskResetHandler, service.OverrideAuth(service.NoAuthMiddleware), MaintenanceAware()))
 	r.Handle("/auth/consumer/local/reset", ScopeNone(), r.POST(api.postAuthLocalResetHandler, service.OverrideAuth(service.NoAuthMiddleware), MaintenanceAware()))
+	r.Handle("/auth/consumer/local/signin/webauthn", ScopeNone(), r.POST(api.postAuthLocalSigninWebAuthnHandler, service.OverrideAuth(service.NoAuthMiddleware), MaintenanceAware()))
+	r.Handle("/auth/consumer/local/mfa", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getAuthLocalFactorsHandler))
+	r.Handle("/auth/consumer/local/mfa/totp", Scope(sdk.AuthConsumerScopeUser), r.POST(api.postAuthLocalTOTPHandler))
+	r.Handle("/auth/consumer/local/mfa/totp/{factorID}/verify", Scope(sdk.AuthConsumerScopeUser), r.POST(api.postAuthLocalTOTPVerifyHandler))
+	r.Handle("/auth/consumer/local/mfa/webauthn/register/begin", Scope(sdk.AuthConsumerScopeUser), r.POST(api.postAuthLocalWebAuthnRegisterBeginHandler))
+	r.Handle("/auth/consumer/local/mfa/webauthn/register/finish", Scope(sdk.AuthConsumerScopeUser), r.POST(api.postAuthLocalWebAuthnRegisterFinishHandler))
+	r.Handle("/auth/consumer/local/mfa/recovery", Scope(sdk.AuthConsumerScopeUser), r.POST(api.postAuthLocalRecoveryCodesHandler))
+	r.Handle("/auth/consumer/local/mfa/{factorID}", Scope(sdk.AuthConsumerScopeUser), r.DELETE(api.deleteAuthLocalFactorHandler))
 	r.Handle("/auth/consumer/builtin/signin", ScopeNone(), r.POST(api.postAuthBuiltinSigninHandler, service.OverrideAuth(service.NoAuthMiddleware), MaintenanceAware()))
 	r.Handle("/auth/consumer/worker/signin", ScopeNone(), r.POST(api.postRegisterWorkerHandler, service.OverrideAuth(service.NoAuthMiddleware), MaintenanceAware()))
 	r.Handle("/auth/consumer/worker/signout", ScopeNone(), r.POST(api.postUnregisterWorkerHandler, MaintenanceAware()))
@@ -70,6 +78,7 @@ func (api *API) InitRouter() {
 	r.Handle("/actionBuiltin/{permActionBuiltinName}/usage", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getActionBuiltinUsageHandler))
 
 	// Admin
+	r.Handle("/admin/user/{username}/mfa", Scope(sdk.AuthConsumerScopeAdmin), r.DELETE(api.deleteAdminUserLocalFactorsHandler, service.OverrideAuth(api.authAdminMiddleware)))
 	r.Handle("/admin/maintenance", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postMaintenanceHandler, service.OverrideAuth(api.authAdminMiddleware)))
 	r.Handle("/admin/cds/migration", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminMigrationsHandler, service.OverrideAuth(api.authAdminMiddleware)))
 	r.Handle("/admin/cds/migration/{id}/cancel", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postAdminMigrationCancelHandler, service.OverrideAuth(api.authAdminMiddleware)))
@@ -102,13 +111,28 @@ func (api *API) InitRouter() {
 	r.Handle("/admin/database/encryption", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminDatabaseEncryptedEntities, service.OverrideAuth(api.authAdminMiddleware)))
 	r.Handle("/admin/database/encryption/{entity}", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminDatabaseEncryptedTuplesByEntity, service.OverrideAuth(api.authAdminMiddleware)))
 	r.Handle("/admin/database/encryption/{entity}/roll/{pk}", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postAdminDatabaseRollEncryptedEntityByPrimaryKey, service.OverrideAuth(api.authAdminMiddleware)))
+	r.Handle("/admin/database/roll", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminDatabaseRollJobs, service.OverrideAuth(api.authAdminMiddleware)), r.POST(api.postAdminDatabaseRollJob, service.OverrideAuth(api.authAdminMiddleware)))
+	r.Handle("/admin/database/roll/{id}", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminDatabaseRollJob, service.OverrideAuth(api.authAdminMiddleware)))
+	r.Handle("/admin/database/roll/{id}/cancel", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postAdminDatabaseRollJobCancel, service.OverrideAuth(api.authAdminMiddleware)))
 
 	// Organization
 	r.Handle("/admin/organization", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminOrganizationsHandler, service.OverrideAuth(api.authAdminMiddleware)), r.POST(api.postAdminOrganizationHandler, service.OverrideAuth(api.authAdminMiddleware)))
 	r.Handle("/admin/organization/{organizationIdentifier}", Scope(sdk.AuthConsumerScopeAdmin), r.DELETE(api.deleteAdminOrganizationsHandler, service.OverrideAuth(api.authAdminMiddleware)))
 	r.Handle("/admin/organization/{organizationIdentifier}/migrate-user", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postOrganizationMigrateUserHandler, service.OverrideAuth(api.authAdminMiddleware)))
 
+	// Audit trail
+	r.Handle("/admin/audit", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminAuditLogsHandler, service.OverrideAuth(api.authAdminMiddleware)))
+
+	// SCIM provisioning
+	r.Handle("/scim/v2/ServiceProviderConfig", Scope(sdk.AuthConsumerScopeSCIM), r.GET(api.getSCIMServiceProviderConfigHandler, service.OverrideAuth(api.authAdminMiddleware), service.OverrideErrorWriter(writeSCIMError)))
+	r.Handle("/scim/v2/Users", Scope(sdk.AuthConsumerScopeSCIM), r.GET(api.getSCIMUsersHandler, service.OverrideAuth(api.authAdminMiddleware), service.OverrideErrorWriter(writeSCIMError)), r.POST(api.postSCIMUserHandler, service.OverrideAuth(api.authAdminMiddleware), service.OverrideErrorWriter(writeSCIMError)))
+	r.Handle("/scim/v2/Users/{id}", Scope(sdk.AuthConsumerScopeSCIM), r.GET(api.getSCIMUserHandler, service.OverrideAuth(api.authAdminMiddleware), service.OverrideErrorWriter(writeSCIMError)), r.PUT(api.putSCIMUserHandler, service.OverrideAuth(api.authAdminMiddleware), service.OverrideErrorWriter(writeSCIMError)), r.PATCH(api.patchSCIMUserHandler, service.OverrideAuth(api.authAdminMiddleware), service.OverrideErrorWriter(writeSCIMError)), r.DELETE(api.deleteSCIMUserHandler, service.OverrideAuth(api.authAdminMiddleware), service.OverrideErrorWriter(writeSCIMError)))
+	r.Handle("/scim/v2/Groups", Scope(sdk.AuthConsumerScopeSCIM), r.GET(api.getSCIMGroupsHandler, service.OverrideAuth(api.authAdminMiddleware), service.OverrideErrorWriter(writeSCIMError)), r.POST(api.postSCIMGroupHandler, service.OverrideAuth(api.authAdminMiddleware), service.OverrideErrorWriter(writeSCIMError)))
+	r.Handle("/scim/v2/Groups/{id}", Scope(sdk.AuthConsumerScopeSCIM), r.GET(api.getSCIMGroupHandler, service.OverrideAuth(api.authAdminMiddleware), service.OverrideErrorWriter(writeSCIMError)), r.PUT(api.putSCIMGroupHandler, service.OverrideAuth(api.authAdminMiddleware), service.OverrideErrorWriter(writeSCIMError)), r.PATCH(api.patchSCIMGroupHandler, service.OverrideAuth(api.authAdminMiddleware), service.OverrideErrorWriter(writeSCIMError)), r.DELETE(api.deleteSCIMGroupHandler, service.OverrideAuth(api.authAdminMiddleware), service.OverrideErrorWriter(writeSCIMError)))
+
 	// Feature flipping
+	r.Handle("/admin/queue/quota", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminQueueQuotas, service.OverrideAuth(api.authAdminMiddleware)), r.POST(api.postAdminQueueQuota, service.OverrideAuth(api.authAdminMiddleware)))
+	r.Handle("/admin/queue/quota/{id}", Scope(sdk.AuthConsumerScopeAdmin), r.PUT(api.putAdminQueueQuota, service.OverrideAuth(api.authAdminMiddleware)), r.DELETE(api.deleteAdminQueueQuota, service.OverrideAuth(api.authAdminMiddleware)))
 	r.Handle("/admin/features", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminFeatureFlipping, service.OverrideAuth(api.authAdminMiddleware)), r.POST(api.postAdminFeatureFlipping, service.OverrideAuth(api.authAdminMiddleware)))
 	r.Handle("/admin/features/{name}", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminFeatureFlippingByName, service.OverrideAuth(api.authAdminMiddleware)), r.PUT(api.putAdminFeatureFlipping, service.OverrideAuth(api.authAdminMiddleware)), r.DELETE(api.deleteAdminFeatureFlipping, service.OverrideAuth(api.authAdminMiddleware)))
 
@@ -146,6 +170,10 @@ func (api *API) InitRouter() {
 
 	r.Handle("/help", ScopeNone(), r.GET(api.getHelpHandler, service.OverrideAuth(service.NoAuthMiddleware)))
 
+	// OpenID Connect issuer for job ID tokens
+	r.Handle(sdk.OIDCDiscoveryPath, ScopeNone(), r.GET(api.getOIDCDiscoveryHandler, service.OverrideAuth(service.NoAuthMiddleware)))
+	r.Handle(sdk.OIDCJWKSPath, ScopeNone(), r.GET(api.getOIDCJWKSHandler, service.OverrideAuth(service.NoAuthMiddleware)))
+
 	r.Handle("/ui/navbar", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getNavbarHandler))
 	r.Handle("/ui/project/{permProjectKey}/application/{applicationName}/overview", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getApplicationOverviewHandler))
 
@@ -172,9 +200,16 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{permProjectKey}/integrations", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectIntegrationsHandler), r.POST(api.postProjectIntegrationHandler))
 	r.Handle("/project/{permProjectKeyWithHooksAllowed}/integrations/{integrationName}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectIntegrationHandler), r.PUT(api.putProjectIntegrationHandler), r.DELETE(api.deleteProjectIntegrationHandler))
 	r.Handle("/project/{permProjectKey}/integrations/{integrationName}/workerhooks", Scopes(sdk.AuthConsumerScopeProject, sdk.AuthConsumerScopeRunExecution), r.GET(api.getProjectIntegrationWorkerHookHandler), r.POST(api.postProjectIntegrationWorkerHookHandler))
+	r.Handle("/project/{permProjectKey}/mergequeue", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getMergeQueuesHandler), r.POST(api.postMergeQueueHandler))
+	r.Handle("/project/{permProjectKey}/mergequeue/{id}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getMergeQueueHandler), r.PUT(api.putMergeQueueHandler), r.DELETE(api.deleteMergeQueueHandler))
+	r.Handle("/project/{permProjectKey}/mergequeue/{id}/pullrequest", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postMergeQueuePullRequestHandler))
+	r.Handle("/project/{permProjectKey}/mergequeue/{id}/pullrequest/{pullRequestID}", Scope(sdk.AuthConsumerScopeProject), r.DELETE(api.deleteMergeQueuePullRequestHandler))
 	r.Handle("/project/{permProjectKey}/notifications", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectNotificationsHandler, DEPRECATED))
 	r.Handle("/project/{permProjectKey}/keys", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getKeysInProjectHandler), r.POST(api.addKeyInProjectHandler))
+	r.Handle("/project/{permProjectKey}/keys/rotation", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getKeyRotationsInProjectHandler))
+	r.Handle("/project/{permProjectKey}/keys/rotation/policy", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getKeyRotationPolicyInProjectHandler), r.PUT(api.putKeyRotationPolicyInProjectHandler))
 	r.Handle("/project/{permProjectKey}/keys/{name}", Scope(sdk.AuthConsumerScopeProject), r.DELETE(api.deleteKeyInProjectHandler))
+	r.Handle("/project/{permProjectKey}/keys/{name}/rotate", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postRotateKeyInProjectHandler))
 
 	// Import Application
 	r.Handle("/project/{permProjectKey}/import/application", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postApplicationImportHandler))
@@ -187,6 +222,7 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/metrics/{metricName}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getApplicationMetricHandler))
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/keys", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getKeysInApplicationHandler), r.POST(api.addKeyInApplicationHandler))
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/keys/{name}", Scope(sdk.AuthConsumerScopeProject), r.DELETE(api.deleteKeyInApplicationHandler))
+	r.Handle("/project/{permProjectKey}/application/{applicationName}/keys/{name}/rotate", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postRotateKeyInApplicationHandler))
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/vcsinfos", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getApplicationVCSInfosHandler))
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/clone", Scope(sdk.AuthConsumerScopeProject), r.POST(api.cloneApplicationHandler))
 	r.Handle("/project/{permProjectKey}/application/{applicationName}/variable", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getVariablesInApplicationHandler))
@@ -315,6 +351,7 @@ func (api *API) InitRouter() {
 
 	//Workflow queue
 	r.Handle("/queue/workflows", Scope(sdk.AuthConsumerScopeRunExecution), r.GET(api.getWorkflowJobQueueHandler, MaintenanceAware()))
+	r.Handle("/queue/workflows/quota", Scope(sdk.AuthConsumerScopeRunExecution), r.GET(api.getQueueQuotaUsagesHandler, MaintenanceAware()))
 	r.Handle("/queue/workflows/count", Scope(sdk.AuthConsumerScopeRunExecution), r.GET(api.countWorkflowJobQueueHandler, MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/take", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postTakeWorkflowJobHandler, MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/cache/{tag}/links", Scope(sdk.AuthConsumerScopeRunExecution), r.GET(api.getWorkerCacheLinkHandler, MaintenanceAware()))
@@ -331,6 +368,8 @@ func (api *API) InitRouter() {
 	r.Handle("/queue/workflows/{permJobID}/tag", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobTagsHandler, MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/step", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobStepStatusHandler, MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/version", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobSetVersionHandler, MaintenanceAware()))
+	r.Handle("/queue/workflows/{permJobID}/mirror/access", Scope(sdk.AuthConsumerScopeRunExecution), r.GET(api.getWorkflowJobGitMirrorAccessHandler))
+	r.Handle("/queue/workflows/{permJobID}/oidc/token", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobIDTokenHandler))
 
 	r.Handle("/variable/type", ScopeNone(), r.GET(api.getVariableTypeHandler))
 	r.Handle("/parameter/type", ScopeNone(), r.GET(api.getParameterTypeHandler))
@@ -367,6 +406,7 @@ func (api *API) InitRouter() {
 	r.Handle("/config/vcs", ScopeNone(), r.GET(api.configVCShandler))
 	r.Handle("/config/vcsgerrit", ScopeNone(), r.GET(api.configVCSGerritHandler))
 	r.Handle("/config/cdn", ScopeNone(), r.GET(api.configCDNHandler))
+	r.Handle("/config/repositories", ScopeNone(), r.GET(api.configRepositoriesHandler))
 	r.Handle("/config/api", ScopeNone(), r.GET(api.configAPIHandler))
 
 	// Users
@@ -377,7 +417,8 @@ func (api *API) InitRouter() {
 	r.Handle("/user/timeline/filter", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getTimelineFilterHandler), r.POST(api.postTimelineFilterHandler))
 	r.Handle("/user/{permUsernamePublic}", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getUserHandler), r.PUT(api.putUserHandler), r.DELETE(api.deleteUserHandler))
 	r.Handle("/user/{permUsernamePublic}/group", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getUserGroupsHandler))
-	r.Handle("/user/{permUsername}/contact", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getUserContactsHandler))
+	r.Handle("/user/{permUsername}/contact", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getUserContactsHandler), r.POST(api.postUserContactHandler))
+	r.Handle("/user/{permUsername}/contact/{contactID}", Scope(sdk.AuthConsumerScopeUser), r.DELETE(api.deleteUserContactHandler))
 	r.Handle("/user/{permUsername}/auth/consumer", Scope(sdk.AuthConsumerScopeAccessToken), r.GET(api.getConsumersByUserHandler), r.POST(api.postConsumerByUserHandler))
 	r.Handle("/user/{permUsername}/auth/consumer/{permConsumerID}", Scope(sdk.AuthConsumerScopeAccessToken), r.DELETE(api.deleteConsumerByUserHandler))
 	r.Handle("/user/{permUsername}/auth/consumer/{permConsumerID}/regen", Scope(sdk.AuthConsumerScopeAccessToken), r.POST(api.postConsumerRegenByUserHandler))
@@ -415,6 +456,7 @@ func (api *API) InitRouter() {
 	r.Handle("/workflow/search", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getSearchWorkflowHandler))
 	r.Handle("/workflow/hook", Scope(sdk.AuthConsumerScopeHooks), r.GET(api.getWorkflowHooksHandler))
 	r.Handle("/workflow/hook/executions", Scope(sdk.AuthConsumerScopeHooks), r.GET(api.getWorkflowHookExecutionsHandler))
+	r.Handle("/workflow/hook/chatops", Scope(sdk.AuthConsumerScopeHooks), r.POST(api.postWorkflowHookChatOpsHandler))
 	r.Handle("/workflow/hook/model/{model}", ScopeNone(), r.GET(api.getWorkflowHookModelHandler), r.POST(api.postWorkflowHookModelHandler, service.OverrideAuth(api.authAdminMiddleware)), r.PUT(api.putWorkflowHookModelHandler, service.OverrideAuth(api.authAdminMiddleware)))
 
 	// SSE
@@ -422,6 +464,7 @@ func (api *API) InitRouter() {
 
 	// Engine µServices
 	r.Handle("/services/heartbeat", Scope(sdk.AuthConsumerScopeService), r.POST(api.postServiceHearbeatHandler))
+	r.Handle("/services/git/mirror/access", Scope(sdk.AuthConsumerScopeService), r.GET(api.getServiceGitMirrorAccessHandler))
 	r.Handle("/services/{type}", Scope(sdk.AuthConsumerScopeService), r.GET(api.getServiceHandler))
 
 	// Templates
@@ -447,6 +490,7 @@ func (api *API) InitRouter() {
 	r.Handle("/v2/organization/{organizationIdentifier}", nil, r.GETv2(api.getOrganizationHandler), r.DELETEv2(api.deleteOrganizationHandler))
 
 	r.Handle("/v2/rbac/import", nil, r.POSTv2(api.postImportRbacHandler))
+	r.Handle("/v2/rbac/explain", nil, r.POSTv2(api.postRbacExplainHandler))
 
 	r.Handle("/v2/region", nil, r.POSTv2(api.postRegionHandler), r.GETv2(api.getRegionsHandler))
 	r.Handle("/v2/region/{regionIdentifier}", nil, r.GETv2(api.getRegionHandler), r.DELETEv2(api.deleteRegionHandler))
@@ -455,6 +499,8 @@ func (api *API) InitRouter() {
 	r.Handle("/v2/project/repositories", Scope(sdk.AuthConsumerScopeHooks), r.GETv2(api.getAllRepositoriesHandler))
 	r.Handle("/v2/project/repositories/{repositoryIdentifier}/hook", Scope(sdk.AuthConsumerScopeHooks), r.GETv2(api.getRepositoryHookHandler))
 
+	r.Handle("/v2/project/{projectKey}/keys", nil, r.GETv2(api.getProjectKeysV2Handler), r.POSTv2(api.postProjectKeyV2Handler))
+	r.Handle("/v2/project/{projectKey}/keys/{keyName}", nil, r.GETv2(api.getProjectKeyV2Handler), r.DELETEv2(api.deleteProjectKeyV2Handler))
 	r.Handle("/v2/project/{projectKey}/vcs", nil, r.POSTv2(api.postVCSProjectHandler), r.GETv2(api.getVCSProjectAllHandler))
 	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}", nil, r.PUTv2(api.putVCSProjectHandler), r.DELETEv2(api.deleteVCSProjectHandler), r.GETv2(api.getVCSProjectHandler))
 	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository", nil, r.POSTv2(api.postProjectRepositoryHandler), r.GETv2(api.getVCSProjectRepositoryAllHandler))
@@ -466,6 +512,7 @@ func (api *API) InitRouter() {
 	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/workermodel", nil, r.GETv2(api.getWorkerModelsV2Handler))
 	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/workermodel/template", nil, r.GETv2(api.getWorkerModelTemplatesHandler))
 	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/workermodel/{workerModelName}", nil, r.GETv2(api.getWorkerModelV2Handler))
+	r.Handle("/v2/project/{projectKey}/workflow/{workflowName}/run", nil, r.POSTv2(api.postWorkflowRunV2Handler))
 	r.Handle("/v2/user/gpgkey/{gpgKeyID}", nil, r.GETv2(api.getUserGPGKeyHandler))
 	r.Handle("/v2/user/{user}/gpgkey", nil, r.GETv2(api.getUserGPGKeysHandler), r.POSTv2(api.postUserGPGGKeyHandler))
 	r.Handle("/v2/user/{user}/gpgkey/{gpgKeyID}", nil, r.DELETEv2(api.deleteUserGPGKey))
@@ -34,8 +34,7 @@ func InsertKey(db gorpmapper.SqlExecutorWithTx, key *sdk.ApplicationKey) error {
 	return nil
 }
 
-// UpdateKey a new application key in database.
-// This function should be use only for migration purpose and should be removed
+// UpdateKey updates an application key, the private key is encrypted again with the key name.
 func UpdateKey(ctx context.Context, db gorpmapper.SqlExecutorWithTx, key *sdk.ApplicationKey) error {
 	var dbAppKey = dbApplicationKey{ApplicationKey: *key}
 	if err := gorpmapping.UpdateAndSign(ctx, db, &dbAppKey); err != nil {
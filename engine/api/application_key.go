@@ -131,3 +131,32 @@ func (api *API) addKeyInApplicationHandler() service.Handler {
 		return service.WriteJSON(w, newKey, http.StatusOK)
 	}
 }
+
+func (api *API) postRotateKeyInApplicationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		appName := vars["applicationName"]
+		keyName := vars["name"]
+
+		var req sdk.KeyRotationRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if err := req.IsValid(); err != nil {
+			return err
+		}
+
+		app, err := application.LoadByName(ctx, api.mustDB(), key, appName)
+		if err != nil {
+			return err
+		}
+
+		rotation, err := api.rotateApplicationKey(ctx, key, *app, keyName, req, getUserConsumer(ctx).GetUsername())
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, rotation, http.StatusOK)
+	}
+}
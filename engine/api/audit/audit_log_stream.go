@@ -0,0 +1,192 @@
+package audit
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Config is the configuration of the audit trail.
+type Config struct {
+	Syslog SyslogConfig `toml:"syslog" json:"syslog" mapstructure:"syslog"`
+}
+
+// SyslogConfig is the configuration of the audit logs streaming to an external syslog or SIEM endpoint.
+type SyslogConfig struct {
+	Enabled  bool   `toml:"enabled" default:"false" json:"enabled"`
+	Protocol string `toml:"protocol" default:"tcp" comment:"tcp, tcp+tls or udp" json:"protocol"`
+	Address  string `toml:"address" default:"" comment:"Address of the syslog endpoint, ex: siem.my-company.com:6514" json:"address"`
+	AppName  string `toml:"appName" default:"cds-audit" json:"appName"`
+	// InsecureSkipVerify is only used with tcp+tls protocol
+	InsecureSkipVerify bool `toml:"insecureSkipVerify" default:"false" json:"insecureSkipVerify"`
+}
+
+const (
+	streamBatchSize = 100
+	// syslog priority for facility "security/authorization messages" (10) and severity "notice" (5)
+	syslogPriority = 10*8 + 5
+)
+
+// SyslogWriter sends audit logs as RFC 5424 messages with their JSON representation as content.
+type SyslogWriter struct {
+	cfg      SyslogConfig
+	hostname string
+	conn     net.Conn
+}
+
+// NewSyslogWriter returns a writer for given configuration, the connection is opened on first write.
+func NewSyslogWriter(cfg SyslogConfig) *SyslogWriter {
+	hostname, _ := os.Hostname()
+	if hostname == "" {
+		hostname = "-"
+	}
+	if cfg.AppName == "" {
+		cfg.AppName = "cds-audit"
+	}
+	return &SyslogWriter{cfg: cfg, hostname: hostname}
+}
+
+func (s *SyslogWriter) connect() error {
+	if s.conn != nil {
+		return nil
+	}
+	var conn net.Conn
+	var err error
+	switch s.cfg.Protocol {
+	case "udp":
+		conn, err = net.DialTimeout("udp", s.cfg.Address, 10*time.Second)
+	case "tcp+tls":
+		conn, err = tls.DialWithDialer(&net.Dialer{Timeout: 10 * time.Second}, "tcp", s.cfg.Address, &tls.Config{
+			InsecureSkipVerify: s.cfg.InsecureSkipVerify, // nolint
+		})
+	case "", "tcp":
+		conn, err = net.DialTimeout("tcp", s.cfg.Address, 10*time.Second)
+	default:
+		return sdk.WithStack(fmt.Errorf("unsupported syslog protocol %q", s.cfg.Protocol))
+	}
+	if err != nil {
+		return sdk.WrapError(err, "unable to connect to syslog endpoint %s", s.cfg.Address)
+	}
+	s.conn = conn
+	return nil
+}
+
+// Format returns the syslog message for given audit log.
+func (s *SyslogWriter) Format(l sdk.AuditLog) (string, error) {
+	btes, err := json.Marshal(l)
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+	return fmt.Sprintf("<%d>1 %s %s %s - %s - %s",
+		syslogPriority, l.Created.UTC().Format(time.RFC3339Nano), s.hostname, s.cfg.AppName, strings.ReplaceAll(l.Action, " ", "_"), btes), nil
+}
+
+// Write sends given audit log, the connection is reset on error.
+func (s *SyslogWriter) Write(l sdk.AuditLog) error {
+	msg, err := s.Format(l)
+	if err != nil {
+		return err
+	}
+	if err := s.connect(); err != nil {
+		return err
+	}
+	if s.cfg.Protocol != "udp" {
+		// RFC 6587 octet counting framing
+		msg = fmt.Sprintf("%d %s", len(msg), msg)
+	}
+	if _, err := s.conn.Write([]byte(msg)); err != nil {
+		s.Close()
+		return sdk.WrapError(err, "unable to send audit log %d", l.ID)
+	}
+	return nil
+}
+
+// Close the connection to the syslog endpoint.
+func (s *SyslogWriter) Close() {
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+}
+
+// StreamLogs sends the audit logs that were not streamed yet to the syslog endpoint.
+func StreamLogs(ctx context.Context, DBFunc func() *gorp.DbMap, cfg SyslogConfig) {
+	w := NewSyslogWriter(cfg)
+	defer w.Close()
+
+	tick := time.NewTicker(10 * time.Second)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "audit.StreamLogs> Exiting: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			for {
+				n, err := streamLogsBatch(ctx, DBFunc(), w)
+				if err != nil {
+					log.Error(ctx, "audit.StreamLogs> %v", err)
+					break
+				}
+				if n < streamBatchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+func streamLogsBatch(ctx context.Context, db *gorp.DbMap, w *SyslogWriter) (int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	logs, err := LoadLogsToStream(ctx, tx, streamBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	ids := make([]int64, 0, len(logs))
+	for _, l := range logs {
+		if err := w.Write(l); err != nil {
+			// Mark what was already sent to avoid duplicates
+			if len(ids) > 0 {
+				if err := MarkLogsStreamed(tx, ids); err != nil {
+					return 0, err
+				}
+				if err := tx.Commit(); err != nil {
+					return 0, sdk.WithStack(err)
+				}
+			}
+			return 0, err
+		}
+		ids = append(ids, l.ID)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if err := MarkLogsStreamed(tx, ids); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, sdk.WithStack(err)
+	}
+	return len(ids), nil
+}
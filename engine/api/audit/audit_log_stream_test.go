@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestSyslogWriter(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		size, err := r.ReadString(' ')
+		if err != nil {
+			return
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(size))
+		buf := make([]byte, n)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return
+		}
+		received <- string(buf)
+	}()
+
+	w := NewSyslogWriter(SyslogConfig{Protocol: "tcp", Address: lis.Addr().String()})
+	defer w.Close()
+
+	l := sdk.AuditLog{
+		ID:         1,
+		Created:    time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+		Action:     sdk.AuditActionRegionDelete,
+		TargetType: sdk.AuditTargetRegion,
+		TargetName: "eu-west",
+		Username:   "admin",
+		IPAddress:  "10.0.0.1",
+		DataBefore: `{"name":"eu-west"}`,
+	}
+	require.NoError(t, w.Write(l))
+
+	var msg string
+	select {
+	case msg = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+	}
+
+	require.True(t, strings.HasPrefix(msg, "<85>1 2022-01-02T03:04:05Z "), msg)
+	require.Contains(t, msg, " cds-audit - region.delete - ")
+
+	var res sdk.AuditLog
+	require.NoError(t, json.Unmarshal([]byte(msg[strings.Index(msg, "{"):]), &res))
+	require.Equal(t, l, res)
+}
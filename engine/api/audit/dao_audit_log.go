@@ -0,0 +1,108 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+// InsertLog inserts a new entry in the audit trail.
+func InsertLog(ctx context.Context, db gorpmapper.SqlExecutorWithTx, l *sdk.AuditLog) error {
+	dbData := &dbAuditLog{AuditLog: *l}
+	if err := gorpmapping.InsertAndSign(ctx, db, dbData); err != nil {
+		return err
+	}
+	*l = dbData.AuditLog
+	return nil
+}
+
+func getAllLogs(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) ([]sdk.AuditLog, error) {
+	var res []dbAuditLog
+	if err := gorpmapping.GetAll(ctx, db, q, &res); err != nil {
+		return nil, err
+	}
+
+	logs := make([]sdk.AuditLog, 0, len(res))
+	for _, l := range res {
+		isValid, err := gorpmapping.CheckSignature(l, l.Signature)
+		if err != nil {
+			return nil, sdk.WrapError(err, "error when checking signature for audit_log %d", l.ID)
+		}
+		if !isValid {
+			log.Error(ctx, "audit.getAllLogs> audit_log %d data corrupted", l.ID)
+			continue
+		}
+		logs = append(logs, l.AuditLog)
+	}
+	return logs, nil
+}
+
+// LoadLogs returns the audit logs matching given filter, most recent first.
+func LoadLogs(ctx context.Context, db gorp.SqlExecutor, filter sdk.AuditLogFilter) ([]sdk.AuditLog, error) {
+	var clauses []string
+	var args []interface{}
+	addClause := func(clause string, arg interface{}) {
+		args = append(args, arg)
+		clauses = append(clauses, fmt.Sprintf(clause, len(args)))
+	}
+	if filter.Action != "" {
+		addClause("action = $%d", filter.Action)
+	}
+	if filter.TargetType != "" {
+		addClause("target_type = $%d", filter.TargetType)
+	}
+	if filter.TargetName != "" {
+		addClause("target_name = $%d", filter.TargetName)
+	}
+	if filter.ProjectKey != "" {
+		addClause("project_key = $%d", filter.ProjectKey)
+	}
+	if filter.Username != "" {
+		addClause("username = $%d", filter.Username)
+	}
+	if !filter.Since.IsZero() {
+		addClause("created >= $%d", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		addClause("created < $%d", filter.Until)
+	}
+
+	query := "SELECT * FROM audit_log"
+	if len(clauses) > 0 {
+		query += " WHERE " + strings.Join(clauses, " AND ")
+	}
+	query += " ORDER BY created DESC, id DESC"
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", filter.Offset)
+	}
+	return getAllLogs(ctx, db, gorpmapping.NewQuery(query).Args(args...))
+}
+
+// LoadLogsToStream locks and returns the oldest audit logs that were not sent to the external endpoint.
+// Locked rows are skipped so that several API instances can stream concurrently.
+func LoadLogsToStream(ctx context.Context, db gorpmapper.SqlExecutorWithTx, limit int) ([]sdk.AuditLog, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM audit_log
+		WHERE streamed = false
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED`).Args(limit)
+	return getAllLogs(ctx, db, query)
+}
+
+// MarkLogsStreamed flags given audit logs as sent to the external endpoint.
+func MarkLogsStreamed(db gorpmapper.SqlExecutorWithTx, ids []int64) error {
+	_, err := db.Exec("UPDATE audit_log SET streamed = true WHERE id = ANY($1)", pq.Int64Array(ids))
+	return sdk.WrapError(err, "unable to mark audit logs as streamed")
+}
@@ -0,0 +1,25 @@
+package audit
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(dbAuditLog{}, "audit_log", true, "id"))
+}
+
+type dbAuditLog struct {
+	sdk.AuditLog
+	Streamed bool `db:"streamed"`
+	gorpmapper.SignedEntity
+}
+
+func (a dbAuditLog) Canonical() gorpmapper.CanonicalForms {
+	_ = []interface{}{a.ID, a.Created, a.Action, a.TargetType, a.TargetName, a.ProjectKey, a.UserID, a.Username, a.ConsumerID, a.ConsumerName, a.IPAddress, a.DataBefore, a.DataAfter}
+	return []gorpmapper.CanonicalForm{
+		"{{.ID}}{{printDate .Created}}{{.Action}}{{.TargetType}}{{.TargetName}}{{.ProjectKey}}{{.UserID}}{{.Username}}{{.ConsumerID}}{{.ConsumerName}}{{.IPAddress}}{{.DataBefore}}{{.DataAfter}}",
+		"{{.ID}}{{.Action}}{{.TargetType}}{{.TargetName}}{{.ProjectKey}}{{.UserID}}{{.ConsumerID}}{{.IPAddress}}{{.DataBefore}}{{.DataAfter}}",
+	}
+}
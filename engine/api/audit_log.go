@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ovh/cds/engine/api/audit"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	cdslog "github.com/ovh/cds/sdk/log"
+)
+
+// auditLog inserts an entry in the audit trail for the authentified consumer of given context.
+// Before and after values are stored as JSON, they must not contain any secret.
+func auditLog(ctx context.Context, db gorpmapper.SqlExecutorWithTx, action, targetType, targetName, projectKey string, before, after interface{}) error {
+	l := sdk.AuditLog{
+		Created:    time.Now(),
+		Action:     action,
+		TargetType: targetType,
+		TargetName: targetName,
+		ProjectKey: projectKey,
+	}
+
+	if consumer := getUserConsumer(ctx); consumer != nil {
+		l.ConsumerID = consumer.ID
+		l.ConsumerName = consumer.Name
+		l.UserID = consumer.AuthConsumerUser.AuthentifiedUserID
+		l.Username = consumer.GetUsername()
+	} else if consumer := getHatcheryConsumer(ctx); consumer != nil {
+		l.ConsumerID = consumer.ID
+		l.ConsumerName = consumer.Name
+	}
+	if ip, ok := ctx.Value(cdslog.IPAddress).(string); ok {
+		l.IPAddress = ip
+	}
+
+	for _, d := range []struct {
+		value interface{}
+		dest  *string
+	}{{before, &l.DataBefore}, {after, &l.DataAfter}} {
+		if d.value == nil {
+			continue
+		}
+		btes, err := json.Marshal(d.value)
+		if err != nil {
+			return sdk.WrapError(err, "unable to marshal audit data")
+		}
+		*d.dest = string(btes)
+	}
+
+	return audit.InsertLog(ctx, db, &l)
+}
+
+// maxAuditLogsLimit is the maximum number of audit logs returned by a request.
+const maxAuditLogsLimit = 1000
+
+func (api *API) getAdminAuditLogsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		filter := sdk.AuditLogFilter{
+			Action:     FormString(r, "action"),
+			TargetType: FormString(r, "targetType"),
+			TargetName: FormString(r, "target"),
+			ProjectKey: FormString(r, "project"),
+			Username:   FormString(r, "username"),
+			Limit:      100,
+		}
+
+		for k, dest := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+			if v := FormString(r, k); v != "" {
+				t, err := time.Parse(time.RFC3339, v)
+				if err != nil {
+					return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid %s value %q, expected RFC3339 date", k, v)
+				}
+				*dest = t
+			}
+		}
+
+		if limit := service.FormInt64(r, "limit"); limit > 0 {
+			filter.Limit = limit
+		}
+		if filter.Limit > maxAuditLogsLimit {
+			filter.Limit = maxAuditLogsLimit
+		}
+		filter.Offset = service.FormInt64(r, "offset")
+
+		logs, err := audit.LoadLogs(ctx, api.mustDB(), filter)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, logs, http.StatusOK)
+	}
+}
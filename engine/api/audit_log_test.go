@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_getAdminAuditLogsHandler(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	admin, jwt := assets.InsertAdminUser(t, db)
+
+	orga := sdk.Organization{Name: sdk.RandomString(10)}
+	uri := api.Router.GetRoute("POST", api.postAdminOrganizationHandler, nil)
+	req := assets.NewJWTAuthentifiedRequest(t, jwt, "POST", uri, &orga)
+	w := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 201, w.Code)
+
+	uri = api.Router.GetRoute("DELETE", api.deleteAdminOrganizationsHandler, map[string]string{"organizationIdentifier": orga.Name})
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "DELETE", uri, nil)
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 204, w.Code)
+
+	uri = api.Router.GetRoute("GET", api.getAdminAuditLogsHandler, nil)
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "GET", uri+"?targetType="+sdk.AuditTargetOrganization+"&target="+orga.Name, nil)
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+
+	var logs []sdk.AuditLog
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &logs))
+	require.Len(t, logs, 2)
+
+	// Most recent first
+	require.Equal(t, sdk.AuditActionOrganizationDelete, logs[0].Action)
+	require.Empty(t, logs[0].DataAfter)
+	require.NotEmpty(t, logs[0].DataBefore)
+	require.Equal(t, sdk.AuditActionOrganizationAdd, logs[1].Action)
+	require.Empty(t, logs[1].DataBefore)
+	require.Contains(t, logs[1].DataAfter, orga.Name)
+	for _, l := range logs {
+		require.Equal(t, admin.Username, l.Username)
+		require.Equal(t, admin.ID, l.UserID)
+		require.NotEmpty(t, l.ConsumerID)
+	}
+
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "GET", uri+"?target="+orga.Name+"&action="+sdk.AuditActionOrganizationAdd, nil)
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &logs))
+	require.Len(t, logs, 1)
+
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "GET", uri+"?since=yesterday", nil)
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 400, w.Code)
+}
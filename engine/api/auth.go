@@ -260,6 +260,15 @@ func (api *API) postAuthSigninHandler() service.Handler {
 			}
 		}
 
+		// A user deactivated by the provisioning can't signin
+		deactivated, err := user.IsDeactivated(ctx, tx, u.ID)
+		if err != nil {
+			return err
+		}
+		if deactivated {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "user %s is deactivated", u.Username)
+		}
+
 		if err := api.userSetOrganization(ctx, tx, u, userInfo.Organization); err != nil {
 			return err
 		}
@@ -94,6 +94,7 @@ func (api *API) postConsumerByUserHandler() service.Handler {
 			ServiceType:                  reqData.AuthConsumerUser.ServiceType,
 			ServiceRegion:                reqData.AuthConsumerUser.ServiceRegion,
 			ServiceIgnoreJobWithNoRegion: reqData.AuthConsumerUser.ServiceIgnoreJobWithNoRegion,
+			Restrictions:                 reqData.AuthConsumerUser.Restrictions,
 		}
 		newConsumer, token, err := builtin.NewConsumer(ctx, tx, consumerOpts, consumer)
 		if err != nil {
@@ -103,6 +104,10 @@ func (api *API) postConsumerByUserHandler() service.Handler {
 			return err
 		}
 
+		if err := auditLog(ctx, tx, sdk.AuditActionConsumerAdd, sdk.AuditTargetConsumer, newConsumer.Name, "", nil, newConsumer); err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
@@ -139,6 +144,10 @@ func (api *API) deleteConsumerByUserHandler() service.Handler {
 			return err
 		}
 
+		if err := auditLog(ctx, tx, sdk.AuditActionConsumerDelete, sdk.AuditTargetConsumer, consumer.Name, "", consumer, nil); err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
@@ -190,6 +199,7 @@ func (api *API) postConsumerRegenByUserHandler() service.Handler {
 			return sdk.NewError(sdk.ErrWrongRequest, errors.New("invalid duration"))
 		}
 
+		before := append(sdk.AuthConsumerValidityPeriods{}, consumer.ValidityPeriods...)
 		if err := authentication.ConsumerRegen(ctx, tx, consumer,
 			overlapDuration,
 			newDuration,
@@ -214,6 +224,10 @@ func (api *API) postConsumerRegenByUserHandler() service.Handler {
 			}
 		}
 
+		if err := auditLog(ctx, tx, sdk.AuditActionConsumerRegen, sdk.AuditTargetConsumer, consumer.Name, "", before, consumer.ValidityPeriods); err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
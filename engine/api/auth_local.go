@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/go-gorp/gorp"
 	"github.com/rockbears/log"
 
 	"github.com/ovh/cds/engine/api/authentication"
@@ -225,27 +226,33 @@ func (api *API) postAuthLocalSigninHandler() service.Handler {
 			return err
 		}
 
-		// Try to load a local consumer for user
-		consumer, err := authentication.LoadUserConsumerByTypeAndUserID(ctx, tx, sdk.ConsumerLocal, usr.ID, authentication.LoadUserConsumerOptions.WithAuthentifiedUser)
+		consumer, err := checkLocalPassword(ctx, tx, reqData)
 		if err != nil {
-			return sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+			return err
 		}
 
-		// Check given password with consumer password
-		if hash, ok := consumer.AuthConsumerUser.Data["hash"]; !ok {
-			return sdk.WithStack(sdk.ErrUnauthorized)
-		} else {
-			password, err := reqData.StringE("password")
-			if err != nil {
-				return err
-			}
-			if err := local.CompareHashAndPassword([]byte(hash), password); err != nil {
-				return sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
-			}
+		// A user deactivated by the provisioning can't signin
+		deactivated, err := user.IsDeactivated(ctx, tx, usr.ID)
+		if err != nil {
+			return err
+		}
+		if deactivated {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "user %s is deactivated", usr.Username)
+		}
+
+		// Check the second factor if the user enrolled one
+		withMFA, err := api.checkLocalSecondFactor(ctx, tx, driver.(*local.AuthDriver), usr.ID, reqData)
+		if err != nil {
+			return err
 		}
 
 		// Generate a new session for consumer
-		session, err := authentication.NewSession(ctx, tx, &consumer.AuthConsumer, driver.GetSessionDuration())
+		var session *sdk.AuthSession
+		if withMFA {
+			session, err = authentication.NewSessionWithMFA(ctx, tx, api.Cache, consumer, driver.GetSessionDuration())
+		} else {
+			session, err = authentication.NewSession(ctx, tx, &consumer.AuthConsumer, driver.GetSessionDuration())
+		}
 		if err != nil {
 			return err
 		}
@@ -281,6 +288,39 @@ func (api *API) postAuthLocalSigninHandler() service.Handler {
 	}
 }
 
+// checkLocalPassword loads the local consumer for given username and checks the given password.
+func checkLocalPassword(ctx context.Context, db gorp.SqlExecutor, req sdk.AuthConsumerSigninRequest) (*sdk.AuthUserConsumer, error) {
+	username, err := req.StringE("username")
+	if err != nil {
+		return nil, sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+	}
+	usr, err := user.LoadByUsername(ctx, db, username)
+	if err != nil {
+		return nil, sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+	}
+
+	// Try to load a local consumer for user
+	consumer, err := authentication.LoadUserConsumerByTypeAndUserID(ctx, db, sdk.ConsumerLocal, usr.ID, authentication.LoadUserConsumerOptions.WithAuthentifiedUser)
+	if err != nil {
+		return nil, sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+	}
+
+	// Check given password with consumer password
+	hash, ok := consumer.AuthConsumerUser.Data["hash"]
+	if !ok {
+		return nil, sdk.WithStack(sdk.ErrUnauthorized)
+	}
+	password, err := req.StringE("password")
+	if err != nil {
+		return nil, err
+	}
+	if err := local.CompareHashAndPassword([]byte(hash), password); err != nil {
+		return nil, sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+	}
+
+	return consumer, nil
+}
+
 func (api *API) postAuthLocalVerifyHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		driver, okDriver := api.AuthenticationDrivers[sdk.ConsumerLocal]
@@ -473,6 +513,16 @@ func (api *API) postAuthLocalAskResetHandler() service.Handler {
 			return err
 		}
 
+		// A user deactivated by the provisioning can't reset its password, return ok to prevent email exploration
+		deactivated, err := user.IsDeactivated(ctx, tx, contact.UserID)
+		if err != nil {
+			return err
+		}
+		if deactivated {
+			log.Warn(ctx, "api.postAuthLocalAskResetHandler> user %s is deactivated", contact.UserID)
+			return service.WriteJSON(w, nil, http.StatusOK)
+		}
+
 		existingLocalConsumer, err := authentication.LoadUserConsumerByTypeAndUserID(ctx, tx, sdk.ConsumerLocal, contact.UserID)
 		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
 			return err
@@ -552,6 +602,15 @@ func (api *API) postAuthLocalResetHandler() service.Handler {
 			return sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
 		}
 
+		// A user deactivated by the provisioning can't reset its password
+		deactivated, err := user.IsDeactivated(ctx, tx, consumer.AuthConsumerUser.AuthentifiedUserID)
+		if err != nil {
+			return err
+		}
+		if deactivated {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "user %s is deactivated", consumer.AuthConsumerUser.AuthentifiedUserID)
+		}
+
 		// Generate password hash to store in consumer
 		password, err := reqData.StringE("password")
 		if err != nil {
@@ -566,13 +625,24 @@ func (api *API) postAuthLocalResetHandler() service.Handler {
 		now := time.Now()
 		consumer.LastAuthentication = &now
 
+		// The reset token only proves the access to the mailbox, the second factor is also required if the user enrolled one
+		withMFA, err := api.checkLocalSecondFactor(ctx, tx, localDriver, consumer.AuthConsumerUser.AuthentifiedUserID, reqData)
+		if err != nil {
+			return err
+		}
+
 		consumer.AuthConsumerUser.Data["hash"] = string(hash)
 		if err := authentication.UpdateUserConsumer(ctx, tx, consumer); err != nil {
 			return err
 		}
 
 		// Generate a new session for consumer
-		session, err := authentication.NewSession(ctx, tx, &consumer.AuthConsumer, driver.GetSessionDuration())
+		var session *sdk.AuthSession
+		if withMFA {
+			session, err = authentication.NewSessionWithMFA(ctx, tx, api.Cache, consumer, driver.GetSessionDuration())
+		} else {
+			session, err = authentication.NewSession(ctx, tx, &consumer.AuthConsumer, driver.GetSessionDuration())
+		}
 		if err != nil {
 			return err
 		}
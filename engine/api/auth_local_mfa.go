@@ -0,0 +1,622 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/authentication/local"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	webAuthnCeremonyRegister = "register"
+	webAuthnCeremonySignin   = "signin"
+	webAuthnTimeout          = int64(5 * time.Minute / time.Millisecond)
+)
+
+// localMFADriver returns the local auth driver if second factors are enabled.
+func (api *API) localMFADriver() (*local.AuthDriver, error) {
+	driver, okDriver := api.AuthenticationDrivers[sdk.ConsumerLocal]
+	if !okDriver || !driver.GetManifest().SupportMFA {
+		return nil, sdk.NewErrorFrom(sdk.ErrForbidden, "multi factor authentication is not enabled for local authentication")
+	}
+	return driver.(*local.AuthDriver), nil
+}
+
+// checkCanManageLocalFactors returns an error if the current session can't add or remove factors.
+// Once a user has a verified factor, changes require a session opened with a second factor.
+func checkCanManageLocalFactors(ctx context.Context, factors []local.Factor) error {
+	for _, f := range factors {
+		if f.Verified && !isMFA(ctx) {
+			return sdk.WithStack(sdk.ErrMFARequired)
+		}
+	}
+	return nil
+}
+
+func (api *API) getAuthLocalFactorsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if _, err := api.localMFADriver(); err != nil {
+			return err
+		}
+		consumer := getUserConsumer(ctx)
+
+		factors, err := local.LoadFactorsByUserID(ctx, api.mustDB(), consumer.AuthConsumerUser.AuthentifiedUserID)
+		if err != nil {
+			return err
+		}
+
+		res := make([]sdk.AuthLocalFactor, len(factors))
+		for i := range factors {
+			res[i] = factors[i].AuthLocalFactor
+		}
+
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
+func (api *API) deleteAuthLocalFactorHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if _, err := api.localMFADriver(); err != nil {
+			return err
+		}
+		consumer := getUserConsumer(ctx)
+		factorID := mux.Vars(r)["factorID"]
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		factors, err := local.LoadFactorsByUserID(ctx, tx, consumer.AuthConsumerUser.AuthentifiedUserID)
+		if err != nil {
+			return err
+		}
+		if err := checkCanManageLocalFactors(ctx, factors); err != nil {
+			return err
+		}
+
+		f, err := local.LoadFactorByUserIDAndID(ctx, tx, consumer.AuthConsumerUser.AuthentifiedUserID, factorID)
+		if err != nil {
+			return err
+		}
+		if err := local.DeleteFactorByID(tx, f.ID); err != nil {
+			return err
+		}
+
+		// Recovery codes are useless without another factor
+		if err := deleteUnusedRecoveryCodes(ctx, tx, consumer.AuthConsumerUser.AuthentifiedUserID); err != nil {
+			return err
+		}
+
+		return sdk.WithStack(tx.Commit())
+	}
+}
+
+func (api *API) postAuthLocalTOTPHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if _, err := api.localMFADriver(); err != nil {
+			return err
+		}
+		consumer := getUserConsumer(ctx)
+
+		var req struct {
+			Name string `json:"name"`
+		}
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		factors, err := local.LoadFactorsByUserID(ctx, tx, consumer.AuthConsumerUser.AuthentifiedUserID)
+		if err != nil {
+			return err
+		}
+		if err := checkCanManageLocalFactors(ctx, factors); err != nil {
+			return err
+		}
+
+		secret, err := local.NewTOTPSecret()
+		if err != nil {
+			return err
+		}
+		f := local.Factor{
+			AuthLocalFactor: sdk.AuthLocalFactor{
+				AuthentifiedUserID: consumer.AuthConsumerUser.AuthentifiedUserID,
+				Type:               sdk.AuthLocalFactorTOTP,
+				Name:               req.Name,
+			},
+			Data: sdk.AuthLocalFactorData{TOTPSecret: secret},
+		}
+		if err := local.InsertFactor(ctx, tx, &f); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, sdk.AuthLocalTOTPEnrollment{
+			FactorID: f.ID,
+			Secret:   secret,
+			URI:      local.TOTPURI("CDS", consumer.GetUsername(), secret),
+		}, http.StatusOK)
+	}
+}
+
+func (api *API) postAuthLocalTOTPVerifyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if _, err := api.localMFADriver(); err != nil {
+			return err
+		}
+		consumer := getUserConsumer(ctx)
+		factorID := mux.Vars(r)["factorID"]
+
+		var req struct {
+			Code string `json:"code"`
+		}
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		f, err := local.LoadFactorByUserIDAndID(ctx, tx, consumer.AuthConsumerUser.AuthentifiedUserID, factorID)
+		if err != nil {
+			return err
+		}
+		if f.Type != sdk.AuthLocalFactorTOTP || f.Verified {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "factor %s can't be verified", f.ID)
+		}
+
+		counter, err := local.CheckTOTPCode(f.Data.TOTPSecret, req.Code, f.Data.TOTPLastCounter, time.Now())
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		f.Verified = true
+		f.LastUsed = &now
+		f.Data.TOTPLastCounter = counter
+		if err := local.UpdateFactor(ctx, tx, f); err != nil {
+			return err
+		}
+
+		codes, err := ensureRecoveryCodes(ctx, tx, consumer.AuthConsumerUser.AuthentifiedUserID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, sdk.AuthLocalRecoveryCodes{Codes: codes}, http.StatusOK)
+	}
+}
+
+func (api *API) postAuthLocalWebAuthnRegisterBeginHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		localDriver, err := api.localMFADriver()
+		if err != nil {
+			return err
+		}
+		consumer := getUserConsumer(ctx)
+		userID := consumer.AuthConsumerUser.AuthentifiedUserID
+
+		factors, err := local.LoadFactorsByUserID(ctx, api.mustDB(), userID)
+		if err != nil {
+			return err
+		}
+		if err := checkCanManageLocalFactors(ctx, factors); err != nil {
+			return err
+		}
+
+		challenge, err := local.NewWebAuthnChallenge(api.Cache, webAuthnCeremonyRegister, userID)
+		if err != nil {
+			return err
+		}
+
+		rpID, _ := localDriver.WebAuthnRelyingParty()
+		opts := sdk.WebAuthnCreationOptions{
+			Challenge:    challenge,
+			RelyingParty: sdk.WebAuthnRelyingParty{ID: rpID, Name: "CDS"},
+			User: sdk.WebAuthnUser{
+				ID:          base64.RawURLEncoding.EncodeToString([]byte(userID)),
+				Name:        consumer.GetUsername(),
+				DisplayName: consumer.GetFullname(),
+			},
+			PubKeyCredParams:   local.WebAuthnCredentialParameters,
+			Timeout:            webAuthnTimeout,
+			Attestation:        "none",
+			ExcludeCredentials: webAuthnCredentialDescriptors(factors),
+		}
+
+		return service.WriteJSON(w, opts, http.StatusOK)
+	}
+}
+
+func (api *API) postAuthLocalWebAuthnRegisterFinishHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		localDriver, err := api.localMFADriver()
+		if err != nil {
+			return err
+		}
+		consumer := getUserConsumer(ctx)
+		userID := consumer.AuthConsumerUser.AuthentifiedUserID
+
+		var cred sdk.WebAuthnCredential
+		if err := service.UnmarshalBody(r, &cred); err != nil {
+			return err
+		}
+
+		challenge, err := local.ConsumeWebAuthnChallenge(api.Cache, webAuthnCeremonyRegister, userID)
+		if err != nil {
+			return err
+		}
+		rpID, origin := localDriver.WebAuthnRelyingParty()
+		credentialID, publicKey, signCount, err := local.VerifyWebAuthnRegistration(rpID, origin, challenge, cred)
+		if err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		factors, err := local.LoadFactorsByUserID(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if err := checkCanManageLocalFactors(ctx, factors); err != nil {
+			return err
+		}
+		if _, ok := findWebAuthnFactor(factors, credentialID); ok {
+			return sdk.NewErrorFrom(sdk.ErrConflictData, "credential already registered")
+		}
+
+		f := local.Factor{
+			AuthLocalFactor: sdk.AuthLocalFactor{
+				AuthentifiedUserID: userID,
+				Type:               sdk.AuthLocalFactorWebAuthn,
+				Name:               cred.Name,
+				Verified:           true,
+			},
+			Data: sdk.AuthLocalFactorData{
+				CredentialID: credentialID,
+				PublicKey:    publicKey,
+				SignCount:    signCount,
+			},
+		}
+		if err := local.InsertFactor(ctx, tx, &f); err != nil {
+			return err
+		}
+
+		codes, err := ensureRecoveryCodes(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, sdk.AuthLocalRecoveryCodes{Codes: codes}, http.StatusOK)
+	}
+}
+
+func (api *API) postAuthLocalRecoveryCodesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if _, err := api.localMFADriver(); err != nil {
+			return err
+		}
+		if !isMFA(ctx) {
+			return sdk.WithStack(sdk.ErrMFARequired)
+		}
+		userID := getUserConsumer(ctx).AuthConsumerUser.AuthentifiedUserID
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		factors, err := local.LoadFactorsByUserID(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		for _, f := range factors {
+			if f.Type == sdk.AuthLocalFactorRecoveryCodes {
+				if err := local.DeleteFactorByID(tx, f.ID); err != nil {
+					return err
+				}
+			}
+		}
+
+		codes, err := ensureRecoveryCodes(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, sdk.AuthLocalRecoveryCodes{Codes: codes}, http.StatusOK)
+	}
+}
+
+// postAuthLocalSigninWebAuthnHandler checks the user password, or the reset token, and returns the options to sign
+// a WebAuthn challenge. The signed assertion should then be given to the local signin or reset handler.
+func (api *API) postAuthLocalSigninWebAuthnHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		localDriver, err := api.localMFADriver()
+		if err != nil {
+			return err
+		}
+
+		var reqData sdk.AuthConsumerSigninRequest
+		if err := service.UnmarshalBody(r, &reqData); err != nil {
+			return err
+		}
+
+		var consumer *sdk.AuthUserConsumer
+		if token, _ := reqData.StringE("token"); token != "" {
+			consumerID, err := local.CheckResetConsumerToken(api.Cache, token)
+			if err != nil {
+				return err
+			}
+			consumer, err = authentication.LoadUserConsumerByID(ctx, api.mustDB(), consumerID)
+			if err != nil {
+				return sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+			}
+		} else {
+			if err := localDriver.CheckSigninRequest(reqData); err != nil {
+				return err
+			}
+			consumer, err = checkLocalPassword(ctx, api.mustDB(), reqData)
+			if err != nil {
+				return err
+			}
+		}
+
+		factors, err := local.LoadFactorsByUserID(ctx, api.mustDB(), consumer.AuthConsumerUser.AuthentifiedUserID)
+		if err != nil {
+			return err
+		}
+		descriptors := webAuthnCredentialDescriptors(factors)
+		if len(descriptors) == 0 {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "no WebAuthn credential registered")
+		}
+
+		challenge, err := local.NewWebAuthnChallenge(api.Cache, webAuthnCeremonySignin, consumer.AuthConsumerUser.AuthentifiedUserID)
+		if err != nil {
+			return err
+		}
+
+		rpID, _ := localDriver.WebAuthnRelyingParty()
+		return service.WriteJSON(w, sdk.WebAuthnRequestOptions{
+			Challenge:        challenge,
+			RelyingPartyID:   rpID,
+			Timeout:          webAuthnTimeout,
+			UserVerification: "discouraged",
+			AllowCredentials: descriptors,
+		}, http.StatusOK)
+	}
+}
+
+func (api *API) deleteAdminUserLocalFactorsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		username := mux.Vars(r)["username"]
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		u, err := user.LoadByUsername(ctx, tx, username)
+		if err != nil {
+			return err
+		}
+
+		factors, err := local.LoadFactorsByUserID(ctx, tx, u.ID)
+		if err != nil {
+			return err
+		}
+		if err := local.DeleteFactorsByUserID(tx, u.ID); err != nil {
+			return err
+		}
+
+		before := make([]sdk.AuthLocalFactor, len(factors))
+		for i := range factors {
+			before[i] = factors[i].AuthLocalFactor
+		}
+		if err := auditLog(ctx, tx, sdk.AuditActionUserMFAReset, sdk.AuditTargetUser, u.Username, "", before, nil); err != nil {
+			return err
+		}
+
+		return sdk.WithStack(tx.Commit())
+	}
+}
+
+// checkLocalSecondFactor checks the second factor given in signin request if the user enrolled one.
+// It returns true if the signin was done with a second factor.
+func (api *API) checkLocalSecondFactor(ctx context.Context, tx gorpmapper.SqlExecutorWithTx, localDriver *local.AuthDriver, userID string, req sdk.AuthConsumerSigninRequest) (bool, error) {
+	if !localDriver.GetManifest().SupportMFA {
+		return false, nil
+	}
+
+	factors, err := local.LoadFactorsByUserID(ctx, tx, userID)
+	if err != nil {
+		return false, err
+	}
+	var enrolled bool
+	for _, f := range factors {
+		enrolled = enrolled || (f.Verified && f.Type != sdk.AuthLocalFactorRecoveryCodes)
+	}
+	if !enrolled {
+		return false, nil
+	}
+
+	now := time.Now()
+
+	if code, _ := req.StringE("totp"); code != "" {
+		// Codes are short, limit the attempts to prevent brute force
+		if err := local.AddSecondFactorAttempt(api.Cache, userID); err != nil {
+			return false, err
+		}
+		for i := range factors {
+			f := &factors[i]
+			if !f.Verified || f.Type != sdk.AuthLocalFactorTOTP {
+				continue
+			}
+			counter, err := local.CheckTOTPCode(f.Data.TOTPSecret, code, f.Data.TOTPLastCounter, now)
+			if err != nil {
+				continue
+			}
+			f.Data.TOTPLastCounter = counter
+			f.LastUsed = &now
+			if err := local.ResetSecondFactorAttempts(api.Cache, userID); err != nil {
+				return false, err
+			}
+			return true, local.UpdateFactor(ctx, tx, f)
+		}
+		for i := range factors {
+			f := &factors[i]
+			if f.Type != sdk.AuthLocalFactorRecoveryCodes {
+				continue
+			}
+			remaining, err := local.UseRecoveryCode(f.Data.RecoveryCodeHashes, code)
+			if err != nil {
+				continue
+			}
+			f.Data.RecoveryCodeHashes = remaining
+			f.LastUsed = &now
+			if err := local.ResetSecondFactorAttempts(api.Cache, userID); err != nil {
+				return false, err
+			}
+			return true, local.UpdateFactor(ctx, tx, f)
+		}
+		return false, sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid second factor code")
+	}
+
+	if raw, ok := req["webauthn"]; ok && raw != nil {
+		btes, err := json.Marshal(raw)
+		if err != nil {
+			return false, sdk.WithStack(err)
+		}
+		var cred sdk.WebAuthnCredential
+		if err := sdk.JSONUnmarshal(btes, &cred); err != nil {
+			return false, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid WebAuthn assertion")
+		}
+		credentialID, err := base64.RawURLEncoding.DecodeString(cred.RawID)
+		if err != nil {
+			return false, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid WebAuthn credential id")
+		}
+		f, ok := findWebAuthnFactor(factors, credentialID)
+		if !ok {
+			return false, sdk.NewErrorFrom(sdk.ErrUnauthorized, "unknown WebAuthn credential")
+		}
+		challenge, err := local.ConsumeWebAuthnChallenge(api.Cache, webAuthnCeremonySignin, userID)
+		if err != nil {
+			return false, err
+		}
+		rpID, origin := localDriver.WebAuthnRelyingParty()
+		signCount, err := local.VerifyWebAuthnAssertion(rpID, origin, challenge, f.Data.PublicKey, f.Data.SignCount, cred)
+		if err != nil {
+			return false, err
+		}
+		f.Data.SignCount = signCount
+		f.LastUsed = &now
+		return true, local.UpdateFactor(ctx, tx, f)
+	}
+
+	return false, sdk.WithStack(sdk.ErrMFARequired)
+}
+
+// ensureRecoveryCodes generates recovery codes for given user if none exists and returns them.
+func ensureRecoveryCodes(ctx context.Context, tx gorpmapper.SqlExecutorWithTx, userID string) ([]string, error) {
+	factors, err := local.LoadFactorsByUserID(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range factors {
+		if f.Type == sdk.AuthLocalFactorRecoveryCodes {
+			return nil, nil
+		}
+	}
+
+	codes, hashes, err := local.NewRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	f := local.Factor{
+		AuthLocalFactor: sdk.AuthLocalFactor{
+			AuthentifiedUserID: userID,
+			Type:               sdk.AuthLocalFactorRecoveryCodes,
+			Verified:           true,
+		},
+		Data: sdk.AuthLocalFactorData{RecoveryCodeHashes: hashes},
+	}
+	if err := local.InsertFactor(ctx, tx, &f); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func deleteUnusedRecoveryCodes(ctx context.Context, tx gorp.SqlExecutor, userID string) error {
+	factors, err := local.LoadFactorsByUserID(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+	for _, f := range factors {
+		if f.Verified && f.Type != sdk.AuthLocalFactorRecoveryCodes {
+			return nil
+		}
+	}
+	return local.DeleteFactorsByUserID(tx, userID)
+}
+
+func webAuthnCredentialDescriptors(factors []local.Factor) []sdk.WebAuthnCredentialDescriptor {
+	var res []sdk.WebAuthnCredentialDescriptor
+	for _, f := range factors {
+		if f.Type == sdk.AuthLocalFactorWebAuthn {
+			res = append(res, sdk.WebAuthnCredentialDescriptor{
+				Type: "public-key",
+				ID:   base64.RawURLEncoding.EncodeToString(f.Data.CredentialID),
+			})
+		}
+	}
+	return res
+}
+
+func findWebAuthnFactor(factors []local.Factor, credentialID []byte) (*local.Factor, bool) {
+	for i := range factors {
+		if factors[i].Type == sdk.AuthLocalFactorWebAuthn && string(factors[i].Data.CredentialID) == string(credentialID) {
+			return &factors[i], true
+		}
+	}
+	return nil, false
+}
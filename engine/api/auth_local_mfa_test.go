@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/authentication/local"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_authLocalTOTP(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+	api.AuthenticationDrivers[sdk.ConsumerLocal] = local.NewDriver(context.TODO(), false, true, "http://localhost:8080", "", "")
+
+	u, jwtRaw := assets.InsertLambdaUser(t, db)
+
+	// Set a password for the local consumer
+	password := "my-very-strong-password-" + sdk.RandomString(10)
+	hash, err := local.HashPassword(password)
+	require.NoError(t, err)
+	consumer, err := authentication.LoadUserConsumerByTypeAndUserID(context.TODO(), db, sdk.ConsumerLocal, u.ID)
+	require.NoError(t, err)
+	consumer.AuthConsumerUser.Data = map[string]string{"hash": string(hash)}
+	require.NoError(t, authentication.UpdateUserConsumer(context.TODO(), db, consumer))
+
+	// Enroll a TOTP factor
+	uri := api.Router.GetRoute(http.MethodPost, api.postAuthLocalTOTPHandler, nil)
+	req := assets.NewJWTAuthentifiedRequest(t, jwtRaw, http.MethodPost, uri, map[string]string{"name": "phone"})
+	rec := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+	var enrollment sdk.AuthLocalTOTPEnrollment
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &enrollment))
+
+	code, err := local.TOTPCode(enrollment.Secret, uint64(time.Now().Unix()/30))
+	require.NoError(t, err)
+	uri = api.Router.GetRoute(http.MethodPost, api.postAuthLocalTOTPVerifyHandler, map[string]string{"factorID": enrollment.FactorID})
+	req = assets.NewJWTAuthentifiedRequest(t, jwtRaw, http.MethodPost, uri, map[string]string{"code": code})
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+	var recovery sdk.AuthLocalRecoveryCodes
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &recovery))
+	require.Len(t, recovery.Codes, 10)
+
+	// Signin without second factor should fail
+	uri = api.Router.GetRoute(http.MethodPost, api.postAuthLocalSigninHandler, nil)
+	req = assets.NewRequest(t, http.MethodPost, uri, sdk.AuthConsumerSigninRequest{"username": u.Username, "password": password})
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 403, rec.Code)
+
+	// Signin with a recovery code
+	req = assets.NewRequest(t, http.MethodPost, uri, sdk.AuthConsumerSigninRequest{"username": u.Username, "password": password, "totp": recovery.Codes[0]})
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+	sessions, err := authentication.LoadSessionsByConsumerIDs(context.TODO(), db, []string{consumer.ID})
+	require.NoError(t, err)
+	var withMFA bool
+	for _, s := range sessions {
+		withMFA = withMFA || s.MFA
+	}
+	require.True(t, withMFA)
+
+	// A recovery code can be used only once
+	req = assets.NewRequest(t, http.MethodPost, uri, sdk.AuthConsumerSigninRequest{"username": u.Username, "password": password, "totp": recovery.Codes[0]})
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 401, rec.Code)
+
+	// Password reset also requires the second factor
+	resetToken, err := local.NewResetConsumerToken(api.Cache, consumer.ID)
+	require.NoError(t, err)
+	uri = api.Router.GetRoute(http.MethodPost, api.postAuthLocalResetHandler, nil)
+	newPassword := "my-new-very-strong-password-" + sdk.RandomString(10)
+	req = assets.NewRequest(t, http.MethodPost, uri, sdk.AuthConsumerSigninRequest{"token": resetToken, "password": newPassword})
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 403, rec.Code)
+	req = assets.NewRequest(t, http.MethodPost, uri, sdk.AuthConsumerSigninRequest{"token": resetToken, "password": newPassword, "totp": recovery.Codes[1]})
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+
+	// Invalid second factor codes are limited
+	uri = api.Router.GetRoute(http.MethodPost, api.postAuthLocalSigninHandler, nil)
+	for i := 0; i < 5; i++ {
+		req = assets.NewRequest(t, http.MethodPost, uri, sdk.AuthConsumerSigninRequest{"username": u.Username, "password": newPassword, "totp": "000000"})
+		rec = httptest.NewRecorder()
+		api.Router.Mux.ServeHTTP(rec, req)
+		require.Equal(t, 401, rec.Code)
+	}
+	req = assets.NewRequest(t, http.MethodPost, uri, sdk.AuthConsumerSigninRequest{"username": u.Username, "password": newPassword, "totp": recovery.Codes[2]})
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 429, rec.Code)
+	require.NoError(t, local.ResetSecondFactorAttempts(api.Cache, u.ID))
+
+	// Admin can reset factors
+	_, jwtAdmin := assets.InsertAdminUser(t, db)
+	uri = api.Router.GetRoute(http.MethodDelete, api.deleteAdminUserLocalFactorsHandler, map[string]string{"username": u.Username})
+	req = assets.NewJWTAuthentifiedRequest(t, jwtAdmin, http.MethodDelete, uri, nil)
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 204, rec.Code)
+
+	factors, err := local.LoadFactorsByUserID(context.TODO(), db, u.ID)
+	require.NoError(t, err)
+	require.Empty(t, factors)
+}
+
+func Test_authLocalDeactivatedUser(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+	api.AuthenticationDrivers[sdk.ConsumerLocal] = local.NewDriver(context.TODO(), false, true, "http://localhost:8080", "", "")
+
+	u, _ := assets.InsertLambdaUser(t, db)
+	password := "my-very-strong-password-" + sdk.RandomString(10)
+	hash, err := local.HashPassword(password)
+	require.NoError(t, err)
+	consumer, err := authentication.LoadUserConsumerByTypeAndUserID(context.TODO(), db, sdk.ConsumerLocal, u.ID)
+	require.NoError(t, err)
+	consumer.AuthConsumerUser.Data = map[string]string{"hash": string(hash)}
+	require.NoError(t, authentication.UpdateUserConsumer(context.TODO(), db, consumer))
+	require.NoError(t, user.InsertDeactivation(context.TODO(), db, u.ID))
+
+	// Signin is forbidden
+	uri := api.Router.GetRoute(http.MethodPost, api.postAuthLocalSigninHandler, nil)
+	req := assets.NewRequest(t, http.MethodPost, uri, sdk.AuthConsumerSigninRequest{"username": u.Username, "password": password})
+	rec := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 403, rec.Code)
+
+	// Reset is forbidden
+	resetToken, err := local.NewResetConsumerToken(api.Cache, consumer.ID)
+	require.NoError(t, err)
+	uri = api.Router.GetRoute(http.MethodPost, api.postAuthLocalResetHandler, nil)
+	req = assets.NewRequest(t, http.MethodPost, uri, sdk.AuthConsumerSigninRequest{"token": resetToken, "password": "my-new-very-strong-password-" + sdk.RandomString(10)})
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 403, rec.Code)
+
+	// No local consumer is created when asking for a reset
+	other := sdk.AuthentifiedUser{Username: sdk.RandomString(10), Fullname: sdk.RandomString(10), Ring: sdk.UserRingUser}
+	require.NoError(t, user.Insert(context.TODO(), db, &other))
+	email := other.Username + "@localhost.local"
+	require.NoError(t, user.InsertContact(context.TODO(), db, &sdk.UserContact{UserID: other.ID, Type: sdk.UserContactTypeEmail, Value: email, Primary: true}))
+	require.NoError(t, user.InsertDeactivation(context.TODO(), db, other.ID))
+
+	uri = api.Router.GetRoute(http.MethodPost, api.postAuthLocalAskResetHandler, nil)
+	req = assets.NewRequest(t, http.MethodPost, uri, sdk.AuthConsumerSigninRequest{"email": email})
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+	_, err = authentication.LoadUserConsumerByTypeAndUserID(context.TODO(), db, sdk.ConsumerLocal, other.ID)
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+}
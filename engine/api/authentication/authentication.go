@@ -90,3 +90,12 @@ func VerifyJWS(signature string, content interface{}) error {
 	}
 	return lastError
 }
+
+// GetVerifyKeys returns the public keys of all the signers, the most recent first.
+func GetVerifyKeys() []*rsa.PublicKey {
+	keys := make([]*rsa.PublicKey, 0, len(signers))
+	for i := len(signers) - 1; i >= 0; i-- {
+		keys = append(keys, signers[i].GetVerifyKey())
+	}
+	return keys
+}
@@ -75,6 +75,7 @@ type NewConsumerOptions struct {
 	ServiceType                  *string
 	ServiceRegion                *string
 	ServiceIgnoreJobWithNoRegion *bool
+	Restrictions                 sdk.AuthConsumerRestrictions
 }
 
 func NewConsumer(ctx context.Context, db gorpmapper.SqlExecutorWithTx, opts NewConsumerOptions, parentConsumer *sdk.AuthUserConsumer) (*sdk.AuthUserConsumer, string, error) {
@@ -106,6 +107,15 @@ func NewConsumer(ctx context.Context, db gorpmapper.SqlExecutorWithTx, opts NewC
 		return nil, "", err
 	}
 
+	// A child consumer inherits parent restrictions or should be more restricted
+	parentRestrictions := parentConsumer.AuthConsumerUser.Restrictions
+	if !opts.Restrictions.IsRestricted() && len(opts.Restrictions.AllowedCIDRs) == 0 {
+		opts.Restrictions = parentRestrictions
+	}
+	if !opts.Restrictions.IsSubsetOf(parentRestrictions) {
+		return nil, "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given restrictions, a consumer can't be less restricted than its parent")
+	}
+
 	c := sdk.AuthUserConsumer{
 		AuthConsumer: sdk.AuthConsumer{
 			Name:            opts.Name,
@@ -123,6 +133,7 @@ func NewConsumer(ctx context.Context, db gorpmapper.SqlExecutorWithTx, opts NewC
 			ServiceType:                  opts.ServiceType,
 			ServiceRegion:                opts.ServiceRegion,
 			ServiceIgnoreJobWithNoRegion: opts.ServiceIgnoreJobWithNoRegion,
+			Restrictions:                 opts.Restrictions,
 		},
 	}
 
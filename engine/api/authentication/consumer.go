@@ -200,13 +200,14 @@ func ConsumerRestoreInvalidatedGroupForUser(ctx context.Context, db gorpmapper.S
 		cs[i].AuthConsumerUser.GroupIDs = append(cs[i].AuthConsumerUser.GroupIDs, groupID)
 
 		// If the consumer was disabled because there was no group left inside, it can be re-enable
-		cs[i].Disabled = false
+		// except if the user was deactivated
+		cs[i].Disabled = cs[i].Warnings.Contains(sdk.WarningUserDeactivated)
 
 		// Clean warnings, removes warning for current group and last group removed warning if exists
 		filteredWarnings := make(sdk.AuthConsumerWarnings, 0, len(cs[i].Warnings))
 		for _, w := range cs[i].Warnings {
 			if (w.Type == sdk.WarningGroupInvalid && w.GroupID != groupID) ||
-				w.Type == sdk.WarningGroupRemoved {
+				w.Type == sdk.WarningGroupRemoved || w.Type == sdk.WarningUserDeactivated {
 				filteredWarnings = append(filteredWarnings, w)
 			}
 		}
@@ -275,12 +276,13 @@ func ConsumerRestoreInvalidatedGroupsForUser(ctx context.Context, db gorpmapper.
 		cs[i].AuthConsumerUser.InvalidGroupIDs = nil
 
 		// If the consumer was disabled because there was no group left inside, it can be re-enable
-		cs[i].Disabled = false
+		// except if the user was deactivated
+		cs[i].Disabled = cs[i].Warnings.Contains(sdk.WarningUserDeactivated)
 
 		// Clean warnings, removes warning for invalid groups and last group removed warning if exists
 		filteredWarnings := make(sdk.AuthConsumerWarnings, 0, len(cs[i].Warnings))
 		for _, w := range cs[i].Warnings {
-			if w.Type == sdk.WarningGroupRemoved {
+			if w.Type == sdk.WarningGroupRemoved || w.Type == sdk.WarningUserDeactivated {
 				filteredWarnings = append(filteredWarnings, w)
 			}
 		}
@@ -293,3 +295,62 @@ func ConsumerRestoreInvalidatedGroupsForUser(ctx context.Context, db gorpmapper.
 
 	return nil
 }
+
+// ConsumerDeactivateForUser disables all user's consumers, set warning and removes their sessions.
+func ConsumerDeactivateForUser(ctx context.Context, db gorpmapper.SqlExecutorWithTx, userID string) error {
+	cs, err := LoadUserConsumersByUserID(ctx, db, userID)
+	if err != nil {
+		return err
+	}
+	for i := range cs {
+		if cs[i].Warnings.Contains(sdk.WarningUserDeactivated) {
+			continue
+		}
+		cs[i].Disabled = true
+		cs[i].Warnings = append(cs[i].Warnings, sdk.NewConsumerWarningUserDeactivated())
+		if err := UpdateUserConsumer(ctx, db, &cs[i]); err != nil {
+			return err
+		}
+	}
+
+	sessions, err := LoadSessionsByConsumerIDs(ctx, db, sdk.AuthConsumersToIDs(cs))
+	if err != nil {
+		return err
+	}
+	for i := range sessions {
+		if err := DeleteSessionByID(db, sessions[i].ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ConsumerReactivateForUser re-enables user's consumers that were disabled by a user deactivation.
+// A consumer without any group left stays disabled.
+func ConsumerReactivateForUser(ctx context.Context, db gorpmapper.SqlExecutorWithTx, userID string) error {
+	cs, err := LoadUserConsumersByUserID(ctx, db, userID)
+	if err != nil {
+		return err
+	}
+	for i := range cs {
+		if !cs[i].Warnings.Contains(sdk.WarningUserDeactivated) {
+			continue
+		}
+
+		filteredWarnings := make(sdk.AuthConsumerWarnings, 0, len(cs[i].Warnings))
+		for _, w := range cs[i].Warnings {
+			if w.Type != sdk.WarningUserDeactivated {
+				filteredWarnings = append(filteredWarnings, w)
+			}
+		}
+		cs[i].Warnings = filteredWarnings
+		cs[i].Disabled = cs[i].Warnings.Contains(sdk.WarningLastGroupRemoved)
+
+		if err := UpdateUserConsumer(ctx, db, &cs[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -58,8 +58,9 @@ type authConsumerUserData struct {
 }
 
 func (c authConsumerUserData) Canonical() gorpmapper.CanonicalForms {
-	_ = []interface{}{c.ID, c.AuthConsumerID, c.AuthentifiedUserID, c.Data, c.GroupIDs, c.ScopeDetails} // Checks that fields exists at compilation
+	_ = []interface{}{c.ID, c.AuthConsumerID, c.AuthentifiedUserID, c.Data, c.GroupIDs, c.ScopeDetails, c.Restrictions} // Checks that fields exists at compilation
 	return []gorpmapper.CanonicalForm{
+		"{{.ID}}{{.AuthConsumerID}}{{.AuthentifiedUserID}}{{print .Data}}{{print .GroupIDs}}{{print .ScopeDetails}}{{print .Restrictions}}",
 		"{{.ID}}{{.AuthConsumerID}}{{.AuthentifiedUserID}}{{print .Data}}{{print .GroupIDs}}{{print .ScopeDetails}}",
 	}
 }
@@ -0,0 +1,95 @@
+package local
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+// Factor is a second factor with its secret data.
+type Factor struct {
+	sdk.AuthLocalFactor
+	Data sdk.AuthLocalFactorData
+}
+
+func getFactors(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) ([]Factor, error) {
+	var fs []authLocalFactor
+	if err := gorpmapping.GetAll(ctx, db, q, &fs, gorpmapping.GetOptions.WithDecryption); err != nil {
+		return nil, sdk.WrapError(err, "cannot get local auth factors")
+	}
+
+	res := make([]Factor, 0, len(fs))
+	for i := range fs {
+		isValid, err := gorpmapping.CheckSignature(fs[i], fs[i].Signature)
+		if err != nil {
+			return nil, err
+		}
+		if !isValid {
+			log.Error(ctx, "local.getFactors> auth local factor %s data corrupted", fs[i].ID)
+			continue
+		}
+		res = append(res, Factor{AuthLocalFactor: fs[i].AuthLocalFactor, Data: fs[i].Data})
+	}
+	return res, nil
+}
+
+// LoadFactorsByUserID returns all second factors for given user.
+func LoadFactorsByUserID(ctx context.Context, db gorp.SqlExecutor, userID string) ([]Factor, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM auth_local_factor WHERE authentified_user_id = $1 ORDER BY created").Args(userID)
+	return getFactors(ctx, db, query)
+}
+
+// LoadFactorByUserIDAndID returns a second factor for given user and id.
+func LoadFactorByUserIDAndID(ctx context.Context, db gorp.SqlExecutor, userID, id string) (*Factor, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM auth_local_factor WHERE authentified_user_id = $1 AND id = $2").Args(userID, id)
+	fs, err := getFactors(ctx, db, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(fs) == 0 {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &fs[0], nil
+}
+
+// InsertFactor in database.
+func InsertFactor(ctx context.Context, db gorpmapper.SqlExecutorWithTx, f *Factor) error {
+	if f.ID == "" {
+		f.ID = sdk.UUID()
+	}
+	f.Created = time.Now()
+	dbF := authLocalFactor{AuthLocalFactor: f.AuthLocalFactor, Data: f.Data}
+	if err := gorpmapping.InsertAndSign(ctx, db, &dbF); err != nil {
+		return sdk.WrapError(err, "unable to insert auth local factor")
+	}
+	f.AuthLocalFactor = dbF.AuthLocalFactor
+	return nil
+}
+
+// UpdateFactor in database.
+func UpdateFactor(ctx context.Context, db gorpmapper.SqlExecutorWithTx, f *Factor) error {
+	dbF := authLocalFactor{AuthLocalFactor: f.AuthLocalFactor, Data: f.Data}
+	if err := gorpmapping.UpdateAndSign(ctx, db, &dbF); err != nil {
+		return sdk.WrapError(err, "unable to update auth local factor %s", f.ID)
+	}
+	f.AuthLocalFactor = dbF.AuthLocalFactor
+	return nil
+}
+
+// DeleteFactorByID removes a second factor in database for given id.
+func DeleteFactorByID(db gorp.SqlExecutor, id string) error {
+	_, err := db.Exec("DELETE FROM auth_local_factor WHERE id = $1", id)
+	return sdk.WrapError(err, "unable to delete auth local factor with id %s", id)
+}
+
+// DeleteFactorsByUserID removes all second factors for given user.
+func DeleteFactorsByUserID(db gorp.SqlExecutor, userID string) error {
+	_, err := db.Exec("DELETE FROM auth_local_factor WHERE authentified_user_id = $1", userID)
+	return sdk.WrapError(err, "unable to delete auth local factors for user %s", userID)
+}
@@ -2,6 +2,7 @@ package local
 
 import (
 	"context"
+	"net/url"
 	"strings"
 	"time"
 
@@ -11,25 +12,42 @@ import (
 var _ sdk.AuthDriver = new(AuthDriver)
 
 // NewDriver returns a new initialized driver for local authentication.
-func NewDriver(ctx context.Context, signupDisabled bool, uiURL, allowedDomains string, orga string) sdk.AuthDriver {
+func NewDriver(ctx context.Context, signupDisabled, mfaSupportEnabled bool, uiURL, allowedDomains string, orga string) sdk.AuthDriver {
 	var domains []string
 
 	if allowedDomains != "" {
 		domains = strings.Split(allowedDomains, ",")
 	}
 
-	return &AuthDriver{
-		signupDisabled: signupDisabled,
-		allowedDomains: domains,
-		organization:   orga,
+	d := &AuthDriver{
+		signupDisabled:    signupDisabled,
+		mfaSupportEnabled: mfaSupportEnabled,
+		allowedDomains:    domains,
+		organization:      orga,
 	}
+
+	// WebAuthn relying party is the UI host, credentials are bound to it
+	if u, err := url.Parse(uiURL); err == nil && u.Host != "" {
+		d.rpID = u.Hostname()
+		d.origin = u.Scheme + "://" + u.Host
+	}
+
+	return d
 }
 
 // AuthDriver for local authentication.
 type AuthDriver struct {
-	signupDisabled bool
-	allowedDomains []string
-	organization   string
+	signupDisabled    bool
+	mfaSupportEnabled bool
+	allowedDomains    []string
+	organization      string
+	rpID              string
+	origin            string
+}
+
+// WebAuthnRelyingParty returns the relying party id and the origin expected for WebAuthn ceremonies.
+func (d AuthDriver) WebAuthnRelyingParty() (string, string) {
+	return d.rpID, d.origin
 }
 
 // GetManifest .
@@ -37,6 +55,7 @@ func (d AuthDriver) GetManifest() sdk.AuthDriverManifest {
 	return sdk.AuthDriverManifest{
 		Type:           sdk.ConsumerLocal,
 		SignupDisabled: d.signupDisabled,
+		SupportMFA:     d.mfaSupportEnabled,
 	}
 }
 
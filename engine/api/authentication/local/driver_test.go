@@ -66,7 +66,7 @@ func TestIsAllowedDomain(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			d := NewDriver(context.TODO(), false, "http://localhost:8080", tt.args.allowedDomains, "")
+			d := NewDriver(context.TODO(), false, false, "http://localhost:8080", tt.args.allowedDomains, "")
 			l := d.(*AuthDriver)
 			if got := l.isAllowedDomain(tt.args.email); got != tt.want {
 				t.Errorf("IsAllowedDomain() = %v, want %v", got, tt.want)
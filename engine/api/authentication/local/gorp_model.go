@@ -18,8 +18,22 @@ func (u userRegistration) Canonical() gorpmapper.CanonicalForms {
 	}
 }
 
+type authLocalFactor struct {
+	sdk.AuthLocalFactor
+	Data sdk.AuthLocalFactorData `db:"data" gorpmapping:"encrypted,ID,AuthentifiedUserID"`
+	gorpmapper.SignedEntity
+}
+
+func (f authLocalFactor) Canonical() gorpmapper.CanonicalForms {
+	_ = []interface{}{f.ID, f.AuthentifiedUserID, f.Type, f.Verified} // Checks that fields exists at compilation
+	return []gorpmapper.CanonicalForm{
+		"{{.ID}}{{.AuthentifiedUserID}}{{.Type}}{{.Verified}}",
+	}
+}
+
 func init() {
 	gorpmapping.Register(
 		gorpmapping.New(userRegistration{}, "user_registration", false, "id"),
+		gorpmapping.New(authLocalFactor{}, "auth_local_factor", false, "id"),
 	)
 }
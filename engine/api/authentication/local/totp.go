@@ -0,0 +1,156 @@
+package local
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	totpPeriod       = 30
+	totpDigits       = 6
+	totpSkew         = 1
+	totpSecretLength = 20
+
+	recoveryCodesCount  = 10
+	recoveryCodesLength = 10
+
+	secondFactorMaxAttempts      = 5
+	secondFactorAttemptsDuration = 15 * time.Minute
+)
+
+// incrSecondFactorAttempts atomically increments the attempts counter given as key and sets its expiration on first attempt.
+var incrSecondFactorAttempts = fmt.Sprintf(`local n = redis.call("INCR", KEYS[1])
+if n == 1 then redis.call("EXPIRE", KEYS[1], %d) end
+return n`, int(secondFactorAttemptsDuration.Seconds()))
+
+var base32NoPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewTOTPSecret returns a new random base32 encoded TOTP secret.
+func NewTOTPSecret() (string, error) {
+	b := make([]byte, totpSecretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", sdk.WithStack(err)
+	}
+	return base32NoPadding.EncodeToString(b), nil
+}
+
+// TOTPURI returns the otpauth uri used to enroll the secret in an authenticator app.
+func TOTPURI(issuer, username, secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprintf("%d", totpDigits))
+	v.Set("period", fmt.Sprintf("%d", totpPeriod))
+	return "otpauth://totp/" + url.PathEscape(issuer+":"+username) + "?" + v.Encode()
+}
+
+// TOTPCode computes the RFC 6238 code for given secret and counter.
+func TOTPCode(secret string, counter uint64) (string, error) {
+	key, err := base32NoPadding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid TOTP secret"))
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:]) // nolint
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000), nil
+}
+
+// CheckTOTPCode checks given code against the secret at given time with a window of one period.
+// It returns the matching counter that should be greater than last used counter to prevent replay.
+func CheckTOTPCode(secret, code string, lastCounter uint64, t time.Time) (uint64, error) {
+	code = strings.TrimSpace(code)
+	current := uint64(t.Unix() / totpPeriod)
+	for i := -totpSkew; i <= totpSkew; i++ {
+		counter := uint64(int64(current) + int64(i))
+		if counter <= lastCounter {
+			continue
+		}
+		expected, err := TOTPCode(secret, counter)
+		if err != nil {
+			return 0, err
+		}
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return counter, nil
+		}
+	}
+	return 0, sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid TOTP code")
+}
+
+// NewRecoveryCodes returns new random recovery codes with their bcrypt hashes.
+func NewRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodesCount)
+	hashes := make([]string, recoveryCodesCount)
+	for i := range codes {
+		b := make([]byte, recoveryCodesLength)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, sdk.WithStack(err)
+		}
+		codes[i] = strings.ToLower(base32NoPadding.EncodeToString(b)[:recoveryCodesLength])
+		hash, err := bcrypt.GenerateFromPassword([]byte(codes[i]), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, nil, sdk.WrapError(err, "cannot generate hash for recovery code")
+		}
+		hashes[i] = string(hash)
+	}
+	return codes, hashes, nil
+}
+
+// UseRecoveryCode checks given code against the hashes and returns the remaining hashes if it matches.
+func UseRecoveryCode(hashes []string, code string) ([]string, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	for i := range hashes {
+		if bcrypt.CompareHashAndPassword([]byte(hashes[i]), []byte(code)) == nil {
+			remaining := append([]string{}, hashes[:i]...)
+			return append(remaining, hashes[i+1:]...), nil
+		}
+	}
+	return nil, sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid recovery code")
+}
+
+func secondFactorAttemptsKey(userID string) string {
+	return cache.Key("authentication:local:mfa:attempts", userID)
+}
+
+// AddSecondFactorAttempt atomically records a second factor code attempt for the user and returns an error if too
+// many codes were given during the attempts period. It is called before the code is checked so that concurrent
+// attempts can't exceed the limit, the attempts are reset when a valid code is given.
+func AddSecondFactorAttempt(store cache.Store, userID string) error {
+	res, err := store.Eval(incrSecondFactorAttempts, secondFactorAttemptsKey(userID))
+	if err != nil {
+		return err
+	}
+	attempts, err := strconv.Atoi(res)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if attempts > secondFactorMaxAttempts {
+		return sdk.WithStack(sdk.ErrMFATooManyAttempts)
+	}
+	return nil
+}
+
+// ResetSecondFactorAttempts clears the invalid second factor codes recorded for the user.
+func ResetSecondFactorAttempts(store cache.Store, userID string) error {
+	return store.Delete(secondFactorAttemptsKey(userID))
+}
@@ -0,0 +1,61 @@
+package local
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// Test vectors from RFC 6238 truncated to 6 digits
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	for ts, expected := range map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	} {
+		code, err := TOTPCode(secret, uint64(ts/totpPeriod))
+		require.NoError(t, err)
+		require.Equal(t, expected, code, "at %d", ts)
+	}
+}
+
+func TestCheckTOTPCode(t *testing.T) {
+	secret, err := NewTOTPSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+	counter := uint64(now.Unix() / totpPeriod)
+	previous, err := TOTPCode(secret, counter-1)
+	require.NoError(t, err)
+
+	c, err := CheckTOTPCode(secret, previous, 0, now)
+	require.NoError(t, err)
+	require.Equal(t, counter-1, c)
+
+	// Replay is rejected
+	_, err = CheckTOTPCode(secret, previous, c, now)
+	require.Error(t, err)
+
+	// Code outside the window is rejected
+	old, err := TOTPCode(secret, counter-3)
+	require.NoError(t, err)
+	_, err = CheckTOTPCode(secret, old, 0, now)
+	require.Error(t, err)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := NewRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodesCount)
+
+	remaining, err := UseRecoveryCode(hashes, codes[3])
+	require.NoError(t, err)
+	require.Len(t, remaining, recoveryCodesCount-1)
+
+	_, err = UseRecoveryCode(remaining, codes[3])
+	require.Error(t, err)
+}
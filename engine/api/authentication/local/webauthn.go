@@ -0,0 +1,135 @@
+package local
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+const webAuthnChallengeDuration = 5 * time.Minute
+
+// WebAuthnCredentialParameters are the public key algorithms supported for WebAuthn credentials.
+var WebAuthnCredentialParameters = []sdk.WebAuthnCredentialParameter{
+	{Type: "public-key", Alg: int(webauthncose.AlgES256)},
+	{Type: "public-key", Alg: int(webauthncose.AlgRS256)},
+}
+
+// NewWebAuthnChallenge generates a random challenge for given ceremony and user, and stores it in cache.
+func NewWebAuthnChallenge(store cache.Store, ceremony, userID string) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", sdk.WithStack(err)
+	}
+	challenge := base64.RawURLEncoding.EncodeToString(b)
+	cacheKey := cache.Key("authentication:local:webauthn", ceremony, userID)
+	if err := store.SetWithDuration(cacheKey, challenge, webAuthnChallengeDuration); err != nil {
+		return "", err
+	}
+	return challenge, nil
+}
+
+// ConsumeWebAuthnChallenge returns the challenge stored for given ceremony and user, a challenge can be used only once.
+func ConsumeWebAuthnChallenge(store cache.Store, ceremony, userID string) (string, error) {
+	cacheKey := cache.Key("authentication:local:webauthn", ceremony, userID)
+	var challenge string
+	if ok, _ := store.Get(cacheKey, &challenge); !ok || challenge == "" {
+		return "", sdk.NewErrorFrom(sdk.ErrUnauthorized, "missing or expired WebAuthn challenge")
+	}
+	_ = store.Delete(cacheKey)
+	return challenge, nil
+}
+
+// marshalWebAuthnCredential returns the credential as expected by the WebAuthn library, clients can send
+// padded or unpadded base64url values but the library only supports unpadded ones.
+func marshalWebAuthnCredential(cred sdk.WebAuthnCredential) ([]byte, error) {
+	trim := func(s string) string { return strings.TrimRight(s, "=") }
+	cred.ID, cred.RawID = trim(cred.ID), trim(cred.RawID)
+	cred.Response.ClientDataJSON = trim(cred.Response.ClientDataJSON)
+	cred.Response.AttestationObject = trim(cred.Response.AttestationObject)
+	cred.Response.AuthenticatorData = trim(cred.Response.AuthenticatorData)
+	cred.Response.Signature = trim(cred.Response.Signature)
+	cred.Response.UserHandle = trim(cred.Response.UserHandle)
+	btes, err := json.Marshal(cred)
+	return btes, sdk.WithStack(err)
+}
+
+// VerifyWebAuthnRegistration checks a credential returned by navigator.credentials.create() and returns
+// its id, COSE public key and signature counter. Attestation statements are verified for the formats
+// supported by the WebAuthn library, without trust anchors.
+func VerifyWebAuthnRegistration(rpID, origin, challenge string, cred sdk.WebAuthnCredential) ([]byte, []byte, uint32, error) {
+	btes, err := marshalWebAuthnCredential(cred)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	parsed, err := protocol.ParseCredentialCreationResponseBody(bytes.NewReader(btes))
+	if err != nil {
+		return nil, nil, 0, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid WebAuthn credential"))
+	}
+	if err := parsed.Verify(challenge, false, rpID, []string{origin}); err != nil {
+		return nil, nil, 0, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid WebAuthn credential"))
+	}
+
+	authData := parsed.Response.AttestationObject.AuthData
+	if err := checkWebAuthnPublicKey(authData.AttData.CredentialPublicKey); err != nil {
+		return nil, nil, 0, err
+	}
+
+	return authData.AttData.CredentialID, authData.AttData.CredentialPublicKey, authData.Counter, nil
+}
+
+// VerifyWebAuthnAssertion checks a credential returned by navigator.credentials.get() with the stored
+// public key and returns the new signature counter.
+func VerifyWebAuthnAssertion(rpID, origin, challenge string, publicKey []byte, signCount uint32, cred sdk.WebAuthnCredential) (uint32, error) {
+	btes, err := marshalWebAuthnCredential(cred)
+	if err != nil {
+		return 0, err
+	}
+	parsed, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(btes))
+	if err != nil {
+		return 0, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid WebAuthn credential"))
+	}
+	if err := parsed.Verify(challenge, rpID, []string{origin}, "", false, publicKey); err != nil {
+		return 0, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid WebAuthn credential"))
+	}
+
+	// A counter that doesn't increase could mean that the authenticator was cloned
+	counter := parsed.Response.AuthenticatorData.Counter
+	if (counter != 0 || signCount != 0) && counter <= signCount {
+		return 0, sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid WebAuthn signature counter")
+	}
+
+	return counter, nil
+}
+
+// checkWebAuthnPublicKey rejects COSE public keys that don't use one of the WebAuthnCredentialParameters algorithms.
+func checkWebAuthnPublicKey(raw []byte) error {
+	invalid := sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid or unsupported WebAuthn public key")
+	key, err := webauthncose.ParsePublicKey(raw)
+	if err != nil {
+		return invalid
+	}
+	var alg int64
+	switch k := key.(type) {
+	case webauthncose.EC2PublicKeyData:
+		alg = k.Algorithm
+	case webauthncose.RSAPublicKeyData:
+		alg = k.Algorithm
+	default:
+		return invalid
+	}
+	for _, p := range WebAuthnCredentialParameters {
+		if int64(p.Alg) == alg {
+			return nil
+		}
+	}
+	return invalid
+}
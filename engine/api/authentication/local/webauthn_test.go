@@ -0,0 +1,103 @@
+package local
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestWebAuthn(t *testing.T) {
+	rpID, origin := "cds.local", "https://cds.local"
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	b64 := base64.RawURLEncoding.EncodeToString
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	credentialID := []byte("my-credential")
+	cose, err := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{KeyType: int64(webauthncose.EllipticKey), Algorithm: int64(webauthncose.AlgES256)},
+		Curve:         1,
+		XCoord:        key.X.FillBytes(make([]byte, 32)),
+		YCoord:        key.Y.FillBytes(make([]byte, 32)),
+	})
+	require.NoError(t, err)
+
+	clientData := func(typ, challenge string) []byte {
+		btes, _ := json.Marshal(protocol.CollectedClientData{Type: protocol.CeremonyType(typ), Challenge: challenge, Origin: origin})
+		return btes
+	}
+	authData := func(flags byte, counter uint32, attested bool) []byte {
+		b := append([]byte{}, rpIDHash[:]...)
+		b = append(b, flags, 0, 0, 0, 0)
+		binary.BigEndian.PutUint32(b[33:], counter)
+		if attested {
+			b = append(b, make([]byte, 16)...)
+			b = append(b, 0, byte(len(credentialID)))
+			b = append(b, credentialID...)
+			b = append(b, cose...)
+		}
+		return b
+	}
+
+	// Registration
+	var reg sdk.WebAuthnCredential
+	reg.ID, reg.RawID, reg.Type = b64(credentialID), b64(credentialID), "public-key"
+	reg.Response.ClientDataJSON = b64(clientData("webauthn.create", "challenge1"))
+	attestation, err := webauthncbor.Marshal(map[string]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[string]interface{}{},
+		"authData": authData(byte(protocol.FlagUserPresent|protocol.FlagAttestedCredentialData), 0, true),
+	})
+	require.NoError(t, err)
+	reg.Response.AttestationObject = b64(attestation)
+	_, _, _, err = VerifyWebAuthnRegistration(rpID, origin, "other-challenge", reg)
+	require.Error(t, err)
+	_, _, _, err = VerifyWebAuthnRegistration("other.local", origin, "challenge1", reg)
+	require.Error(t, err)
+	id, publicKey, counter, err := VerifyWebAuthnRegistration(rpID, origin, "challenge1", reg)
+	require.NoError(t, err)
+	require.Equal(t, credentialID, id)
+	require.Equal(t, cose, publicKey)
+	require.Equal(t, uint32(0), counter)
+
+	// Assertion
+	assertion := func(counter uint32) sdk.WebAuthnCredential {
+		var a sdk.WebAuthnCredential
+		a.ID, a.RawID, a.Type = b64(credentialID), b64(credentialID), "public-key"
+		cd := clientData("webauthn.get", "challenge2")
+		ad := authData(byte(protocol.FlagUserPresent), counter, false)
+		cdHash := sha256.Sum256(cd)
+		digest := sha256.Sum256(append(append([]byte{}, ad...), cdHash[:]...))
+		sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		require.NoError(t, err)
+		a.Response.ClientDataJSON = b64(cd)
+		a.Response.AuthenticatorData = b64(ad)
+		a.Response.Signature = b64(sig)
+		return a
+	}
+	counter, err = VerifyWebAuthnAssertion(rpID, origin, "challenge2", publicKey, 0, assertion(5))
+	require.NoError(t, err)
+	require.Equal(t, uint32(5), counter)
+
+	// Counter must increase
+	_, err = VerifyWebAuthnAssertion(rpID, origin, "challenge2", publicKey, 5, assertion(5))
+	require.Error(t, err)
+
+	// Signature must match the data
+	a := assertion(6)
+	a.Response.ClientDataJSON = b64(clientData("webauthn.get", "challenge3"))
+	_, err = VerifyWebAuthnAssertion(rpID, origin, "challenge3", publicKey, 5, a)
+	require.Error(t, err)
+}
@@ -88,6 +88,16 @@ func (api *API) configCDNHandler() service.Handler {
 	}
 }
 
+func (api *API) configRepositoriesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		mirrorURL, err := services.GetRepositoriesMirrorPublicHTTPAdress(ctx, api.mustDB())
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, sdk.RepositoriesConfig{MirrorURL: mirrorURL}, http.StatusOK)
+	}
+}
+
 func (api *API) configAPIHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		return service.WriteJSON(w, sdk.APIConfig{
@@ -97,6 +97,22 @@ func PublishApplicationKeyDelete(ctx context.Context, projKey string, app sdk.Ap
 	publishApplicationEvent(ctx, e, projKey, app.Name, u)
 }
 
+func PublishApplicationKeyRotate(ctx context.Context, projKey string, appName string, k sdk.ApplicationKey, r sdk.KeyRotation, u sdk.Identifiable) {
+	k.Private = sdk.PasswordPlaceholder
+	e := sdk.EventApplicationKeyRotate{
+		Key:      k,
+		Rotation: r,
+	}
+	publishApplicationEvent(ctx, e, projKey, appName, u)
+}
+
+func PublishApplicationKeyRetire(ctx context.Context, projKey string, appName string, r sdk.KeyRotation) {
+	e := sdk.EventApplicationKeyRetire{
+		Rotation: r,
+	}
+	publishApplicationEvent(ctx, e, projKey, appName, nil)
+}
+
 // PublishApplicationRepositoryAdd publishes an envet when adding a repository to an application
 func PublishApplicationRepositoryAdd(ctx context.Context, projKey string, app sdk.Application, u sdk.Identifiable) {
 	e := sdk.EventApplicationRepositoryAdd{
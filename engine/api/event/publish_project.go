@@ -135,6 +135,32 @@ func PublishDeleteProjectKey(ctx context.Context, p *sdk.Project, k sdk.ProjectK
 	PublishProjectEvent(ctx, e, p.Key, u)
 }
 
+// PublishRotateProjectKey publishes an event on rotating a project key
+func PublishRotateProjectKey(ctx context.Context, projectKey string, k sdk.ProjectKey, r sdk.KeyRotation, u sdk.Identifiable) {
+	k.Private = sdk.PasswordPlaceholder
+	e := sdk.EventProjectKeyRotate{
+		Key:      k,
+		Rotation: r,
+	}
+	PublishProjectEvent(ctx, e, projectKey, u)
+}
+
+// PublishRetireProjectKey publishes an event on retiring a rotated project key
+func PublishRetireProjectKey(ctx context.Context, projectKey string, r sdk.KeyRotation) {
+	e := sdk.EventProjectKeyRetire{
+		Rotation: r,
+	}
+	PublishProjectEvent(ctx, e, projectKey, nil)
+}
+
+// PublishUpdateKeyRotationPolicy publishes an event on updating the key rotation policy of a project
+func PublishUpdateKeyRotationPolicy(ctx context.Context, projectKey string, policy sdk.KeyRotationPolicy, u sdk.Identifiable) {
+	e := sdk.EventProjectKeyRotationPolicyUpdate{
+		Policy: policy,
+	}
+	PublishProjectEvent(ctx, e, projectKey, u)
+}
+
 // PublishAddVCSServer publishes an event on adding a project server
 func PublishAddVCSServer(ctx context.Context, p *sdk.Project, vcsServerName string, u sdk.Identifiable) {
 	e := sdk.EventProjectVCSServerAdd{
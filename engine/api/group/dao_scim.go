@@ -0,0 +1,74 @@
+package group
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadAllBySCIMFilter returns the groups that match given SCIM filter ordered by id, from given offset.
+// The total count of groups that match the filter is also returned.
+func LoadAllBySCIMFilter(ctx context.Context, db gorp.SqlExecutor, filter sdk.SCIMFilter, offset, limit int, opts ...LoadOptionFunc) (sdk.Groups, int64, error) {
+	where, args := scimFilterCondition(filter)
+
+	total, err := db.SelectInt(`SELECT COUNT(id) FROM "group" WHERE `+where, args...)
+	if err != nil {
+		return nil, 0, sdk.WrapError(err, "cannot count groups")
+	}
+
+	query := gorpmapping.NewQuery(fmt.Sprintf(`
+    SELECT *
+    FROM "group"
+    WHERE %s
+    ORDER BY id
+    OFFSET $%d LIMIT $%d
+  `, where, len(args)+1, len(args)+2)).Args(append(args, offset, limit)...)
+	gs, err := getAll(ctx, db, query, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return gs, total, nil
+}
+
+// scimFilterCondition returns the SQL condition on table group for given filter, unknown attributes only match with
+// operator ne.
+func scimFilterCondition(filter sdk.SCIMFilter) (string, []interface{}) {
+	var args []interface{}
+	arg := func(v string) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d::text", len(args))
+	}
+
+	conds := []string{"true"}
+	for _, e := range filter {
+		var cond string
+		switch strings.ToLower(e.Attribute) {
+		case "id":
+			cond = user.SCIMValueCondition(e, `"group".id::text`, arg)
+		case "displayname":
+			cond = user.SCIMValueCondition(e, `"group".name`, arg)
+		case "members", "members.value":
+			cond = user.SCIMMultiValuedCondition(e, `
+        SELECT 1 FROM group_authentified_user
+        WHERE group_authentified_user.group_id = "group".id`, "group_authentified_user.authentified_user_id", arg)
+		case "members.display":
+			cond = user.SCIMMultiValuedCondition(e, `
+        SELECT 1 FROM group_authentified_user
+        JOIN authentified_user ON authentified_user.id = group_authentified_user.authentified_user_id
+        WHERE group_authentified_user.group_id = "group".id`, "authentified_user.username", arg)
+		default:
+			cond = "false"
+			if e.Operator == "ne" {
+				cond = "true"
+			}
+		}
+		conds = append(conds, "("+cond+")")
+	}
+	return strings.Join(conds, " AND "), args
+}
@@ -0,0 +1,54 @@
+package group_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestLoadAllBySCIMFilter(t *testing.T) {
+	db, _ := test.SetupPG(t)
+
+	prefix := sdk.RandomString(10)
+	g1 := sdk.Group{Name: prefix + "-first"}
+	require.NoError(t, group.Insert(context.TODO(), db, &g1))
+	g2 := sdk.Group{Name: prefix + "-second"}
+	require.NoError(t, group.Insert(context.TODO(), db, &g2))
+	u, _ := assets.InsertLambdaUser(t, db, &g2)
+
+	filter, err := sdk.ParseSCIMFilter(`displayName sw "` + prefix + `"`)
+	require.NoError(t, err)
+	gs, total, err := group.LoadAllBySCIMFilter(context.TODO(), db, filter, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	require.Len(t, gs, 2)
+	require.Equal(t, g1.ID, gs[0].ID)
+	require.Equal(t, g2.ID, gs[1].ID)
+
+	// Only the page is returned but the total counts all the groups that match
+	gs, total, err = group.LoadAllBySCIMFilter(context.TODO(), db, filter, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	require.Len(t, gs, 1)
+	require.Equal(t, g2.ID, gs[0].ID)
+
+	filter, err = sdk.ParseSCIMFilter(`displayName sw "` + prefix + `" and members eq "` + u.ID + `"`)
+	require.NoError(t, err)
+	gs, total, err = group.LoadAllBySCIMFilter(context.TODO(), db, filter, 0, 10, group.LoadOptions.WithMembers)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Equal(t, g2.ID, gs[0].ID)
+	require.Len(t, gs[0].Members, 1)
+
+	filter, err = sdk.ParseSCIMFilter(`displayName sw "` + prefix + `" and externalId eq "first"`)
+	require.NoError(t, err)
+	_, total, err = group.LoadAllBySCIMFilter(context.TODO(), db, filter, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), total)
+}
@@ -2,6 +2,8 @@ package hatchery
 
 import (
 	"context"
+	"time"
+
 	"github.com/go-gorp/gorp"
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/engine/gorpmapper"
@@ -51,3 +53,26 @@ func UpsertStatus(ctx context.Context, db gorpmapper.SqlExecutorWithTx, hatchery
 	s.ID = hatchStatus.ID
 	return updateHatcheryStatus(ctx, db, s)
 }
+
+// RegionHealthTimeout is the delay without heartbeat after which a hatchery is no more considered in its region health.
+const RegionHealthTimeout = 2 * time.Minute
+
+// LoadHealthyRegions returns the regions where at least one hatchery sent a heartbeat since given time.
+// Both hatchery services and hatcheries linked to a region by their permission are checked.
+func LoadHealthyRegions(_ context.Context, db gorp.SqlExecutor, since time.Time) ([]string, error) {
+	var res []string
+	query := `
+	SELECT DISTINCT region.name
+	FROM hatchery
+	JOIN rbac_hatchery ON rbac_hatchery.hatchery_id = hatchery.id
+	JOIN region ON region.id = rbac_hatchery.region_id
+	WHERE hatchery.last_heartbeat > $1
+	UNION
+	SELECT DISTINCT service.region
+	FROM service
+	WHERE service.type = $2 AND service.region IS NOT NULL AND service.region <> '' AND service.last_heartbeat > $1`
+	if _, err := db.Select(&res, query, since, sdk.TypeHatchery); err != nil {
+		return nil, sdk.WrapError(err, "unable to load healthy regions")
+	}
+	return res, nil
+}
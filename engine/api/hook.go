@@ -19,6 +19,7 @@ import (
 
 func (api *API) getHookPollingVCSEvents() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		ctx = repositoriesmanager.ContextWithBackgroundPriority(ctx)
 		vars := mux.Vars(r)
 		uuid := vars["uuid"]
 		vcsServerParam := vars["vcsServer"]
@@ -0,0 +1,528 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/application"
+	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/engine/api/keys"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+// keyRotationTriggeredByPolicy is the author of rotations triggered by a project's key rotation policy.
+const keyRotationTriggeredByPolicy = "policy"
+
+// keyRotationRepositories returns the repositories of given applications that are cloned with the key.
+func keyRotationRepositories(apps []sdk.Application, keyName string) sdk.KeyRotationRepositories {
+	res := sdk.KeyRotationRepositories{}
+	for _, app := range apps {
+		if app.VCSServer == "" || app.RepositoryFullname == "" ||
+			app.RepositoryStrategy.ConnectionType != "ssh" || app.RepositoryStrategy.SSHKey != keyName {
+			continue
+		}
+		repo := sdk.KeyRotationRepository{VCSServer: app.VCSServer, Repository: app.RepositoryFullname}
+		var found bool
+		for _, r := range res {
+			if r == repo {
+				found = true
+				break
+			}
+		}
+		if !found {
+			res = append(res, repo)
+		}
+	}
+	return res
+}
+
+// pushKeyRotationDeployKeys adds the new public key as deploy key on repositories. CDS uses deploy keys to clone
+// and to push tags so they are not read only. It is called outside of the rotation's transaction to not hold its locks
+// during the calls to the VCS, keys already added are removed if it fails.
+func (api *API) pushKeyRotationDeployKeys(ctx context.Context, projectKey, keyName, public string, repos sdk.KeyRotationRepositories) error {
+	for i, r := range repos {
+		err := api.keyRotationDeployKey(ctx, projectKey, r, func(client sdk.VCSAuthorizedClientService) error {
+			_, err := client.CreateDeployKey(ctx, r.Repository, sdk.VCSDeployKey{
+				Title: fmt.Sprintf("CDS %s/%s", projectKey, keyName),
+				Key:   public,
+			})
+			return err
+		})
+		if err != nil {
+			if msg := api.removeKeyRotationDeployKeys(ctx, projectKey, public, repos[:i]); msg != "" {
+				log.Error(ctx, "%s", msg)
+			}
+			return sdk.NewErrorFrom(err, "unable to add deploy key on repository %s", r.Repository)
+		}
+	}
+	return nil
+}
+
+// removeKeyRotationDeployKeys removes a public key from repositories, errors are returned as a message.
+func (api *API) removeKeyRotationDeployKeys(ctx context.Context, projectKey, public string, repos sdk.KeyRotationRepositories) string {
+	var msgs []string
+	for _, r := range repos {
+		err := api.keyRotationDeployKey(ctx, projectKey, r, func(client sdk.VCSAuthorizedClientService) error {
+			return client.DeleteDeployKey(ctx, r.Repository, sdk.VCSDeployKey{Key: public})
+		})
+		if err != nil {
+			log.ErrorWithStackTrace(ctx, err)
+			msgs = append(msgs, fmt.Sprintf("unable to remove deploy key from %s: %v", r.Repository, sdk.ExtractHTTPError(err).Message))
+		}
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// keyRotationDeployKey calls the VCS of a repository in its own transaction.
+func (api *API) keyRotationDeployKey(ctx context.Context, projectKey string, r sdk.KeyRotationRepository, f func(sdk.VCSAuthorizedClientService) error) error {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	client, err := repositoriesmanager.AuthorizedClient(ctx, tx, api.Cache, projectKey, r.VCSServer)
+	if err != nil {
+		return err
+	}
+	if err := f(client); err != nil {
+		return err
+	}
+	return sdk.WithStack(tx.Commit())
+}
+
+func newKeyRotation(req sdk.KeyRotationRequest, keyName string, keyType sdk.KeyType, previousPublic, triggeredBy string) sdk.KeyRotation {
+	grace := req.GracePeriod
+	if grace == 0 {
+		grace = sdk.DefaultKeyRotationGracePeriod
+	}
+	return sdk.KeyRotation{
+		KeyName:        keyName,
+		KeyType:        keyType,
+		PreviousPublic: previousPublic,
+		Repositories:   sdk.KeyRotationRepositories{},
+		Status:         sdk.KeyRotationStatusGrace,
+		TriggeredBy:    triggeredBy,
+		ExpireAt:       time.Now().Add(time.Duration(grace) * time.Hour),
+	}
+}
+
+// keyRotationIdentity returns the consumer that triggered a rotation, nil for a rotation triggered by a policy. The
+// consumer is not returned as is to not give a nil pointer in a non nil sdk.Identifiable to the event publishers.
+func keyRotationIdentity(ctx context.Context) sdk.Identifiable {
+	if consumer := getUserConsumer(ctx); consumer != nil {
+		return consumer
+	}
+	return nil
+}
+
+// rotateProjectKey generates a successor for a project key. The previous key is renamed with suffix .previous and
+// kept until the end of the grace period.
+func (api *API) rotateProjectKey(ctx context.Context, p sdk.Project, keyName string, req sdk.KeyRotationRequest, triggeredBy string) (*sdk.KeyRotation, error) {
+	if strings.HasSuffix(keyName, sdk.KeyRotationPreviousSuffix) {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "key %s is already rotated", keyName)
+	}
+
+	ks, err := project.LoadAllKeys(ctx, api.mustDB(), p.ID)
+	if err != nil {
+		return nil, err
+	}
+	var keyType sdk.KeyType
+	for _, k := range ks {
+		if k.Name == keyName+sdk.KeyRotationPreviousSuffix {
+			return nil, sdk.NewErrorFrom(sdk.ErrConflictData, "a rotation of key %s is already in grace period", keyName)
+		}
+		if k.Name == keyName {
+			keyType = k.Type
+		}
+	}
+	if keyType == "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "key %s not found", keyName)
+	}
+
+	k, err := keys.GenerateKey(keyName, keyType)
+	if err != nil {
+		return nil, err
+	}
+
+	repos := sdk.KeyRotationRepositories{}
+	if req.PushToVCS && keyType == sdk.KeyTypeSSH {
+		apps, err := application.LoadAll(ctx, api.mustDB(), p.Key)
+		if err != nil {
+			return nil, err
+		}
+		repos = keyRotationRepositories(apps, keyName)
+		if err := api.pushKeyRotationDeployKeys(ctx, p.Key, keyName, k.Public, repos); err != nil {
+			return nil, err
+		}
+	}
+
+	newKey, rotation, err := api.insertProjectKeyRotation(ctx, p, k, req, repos, triggeredBy)
+	if err != nil {
+		if msg := api.removeKeyRotationDeployKeys(ctx, p.Key, k.Public, repos); msg != "" {
+			log.Error(ctx, "%s", msg)
+		}
+		return nil, err
+	}
+
+	event.PublishRotateProjectKey(ctx, p.Key, *newKey, *rotation, keyRotationIdentity(ctx))
+	return rotation, nil
+}
+
+func (api *API) insertProjectKeyRotation(ctx context.Context, p sdk.Project, k sdk.Key, req sdk.KeyRotationRequest, repos sdk.KeyRotationRepositories, triggeredBy string) (*sdk.ProjectKey, *sdk.KeyRotation, error) {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return nil, nil, sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	ks, err := project.LoadAllKeysWithPrivateContent(ctx, tx, p.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var previous *sdk.ProjectKey
+	for i := range ks {
+		if ks[i].Name == k.Name+sdk.KeyRotationPreviousSuffix {
+			return nil, nil, sdk.NewErrorFrom(sdk.ErrConflictData, "a rotation of key %s is already in grace period", k.Name)
+		}
+		if ks[i].Name == k.Name {
+			previous = &ks[i]
+		}
+	}
+	if previous == nil {
+		return nil, nil, sdk.NewErrorFrom(sdk.ErrNotFound, "key %s not found", k.Name)
+	}
+
+	newKey := sdk.ProjectKey{
+		Name:      k.Name,
+		Type:      k.Type,
+		Public:    k.Public,
+		Private:   k.Private,
+		KeyID:     k.KeyID,
+		ProjectID: p.ID,
+	}
+
+	previousKey := *previous
+	previous.Name += sdk.KeyRotationPreviousSuffix
+	if err := project.UpdateKey(ctx, tx, previous); err != nil {
+		return nil, nil, err
+	}
+	if err := project.InsertKey(tx, &newKey); err != nil {
+		return nil, nil, err
+	}
+
+	rotation := newKeyRotation(req, k.Name, previous.Type, previous.Public, triggeredBy)
+	rotation.ProjectID = p.ID
+	rotation.Repositories = repos
+	if err := project.InsertKeyRotation(tx, &rotation); err != nil {
+		return nil, nil, err
+	}
+
+	previousKey.Private, newKey.Private = "", "" // never store private key in audit trail
+	if err := auditLog(ctx, tx, sdk.AuditActionProjectKeyRotate, sdk.AuditTargetProjectKey, k.Name, p.Key, previousKey, newKey); err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, sdk.WithStack(err)
+	}
+	return &newKey, &rotation, nil
+}
+
+// rotateApplicationKey generates a successor for an application key, see rotateProjectKey.
+func (api *API) rotateApplicationKey(ctx context.Context, projectKey string, app sdk.Application, keyName string, req sdk.KeyRotationRequest, triggeredBy string) (*sdk.KeyRotation, error) {
+	if strings.HasSuffix(keyName, sdk.KeyRotationPreviousSuffix) {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "key %s is already rotated", keyName)
+	}
+
+	ks, err := application.LoadAllKeys(ctx, api.mustDB(), app.ID)
+	if err != nil {
+		return nil, err
+	}
+	var keyType sdk.KeyType
+	for _, k := range ks {
+		if k.Name == keyName+sdk.KeyRotationPreviousSuffix {
+			return nil, sdk.NewErrorFrom(sdk.ErrConflictData, "a rotation of key %s is already in grace period", keyName)
+		}
+		if k.Name == keyName {
+			keyType = k.Type
+		}
+	}
+	if keyType == "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "key %s not found", keyName)
+	}
+
+	k, err := keys.GenerateKey(keyName, keyType)
+	if err != nil {
+		return nil, err
+	}
+
+	repos := sdk.KeyRotationRepositories{}
+	if req.PushToVCS && keyType == sdk.KeyTypeSSH {
+		repos = keyRotationRepositories([]sdk.Application{app}, keyName)
+		if err := api.pushKeyRotationDeployKeys(ctx, projectKey, keyName, k.Public, repos); err != nil {
+			return nil, err
+		}
+	}
+
+	newKey, rotation, err := api.insertApplicationKeyRotation(ctx, projectKey, app, k, req, repos, triggeredBy)
+	if err != nil {
+		if msg := api.removeKeyRotationDeployKeys(ctx, projectKey, k.Public, repos); msg != "" {
+			log.Error(ctx, "%s", msg)
+		}
+		return nil, err
+	}
+
+	event.PublishApplicationKeyRotate(ctx, projectKey, app.Name, *newKey, *rotation, keyRotationIdentity(ctx))
+	return rotation, nil
+}
+
+func (api *API) insertApplicationKeyRotation(ctx context.Context, projectKey string, app sdk.Application, k sdk.Key, req sdk.KeyRotationRequest, repos sdk.KeyRotationRepositories, triggeredBy string) (*sdk.ApplicationKey, *sdk.KeyRotation, error) {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return nil, nil, sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	ks, err := application.LoadAllKeysWithPrivateContent(ctx, tx, app.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var previous *sdk.ApplicationKey
+	for i := range ks {
+		if ks[i].Name == k.Name+sdk.KeyRotationPreviousSuffix {
+			return nil, nil, sdk.NewErrorFrom(sdk.ErrConflictData, "a rotation of key %s is already in grace period", k.Name)
+		}
+		if ks[i].Name == k.Name {
+			previous = &ks[i]
+		}
+	}
+	if previous == nil {
+		return nil, nil, sdk.NewErrorFrom(sdk.ErrNotFound, "key %s not found", k.Name)
+	}
+
+	newKey := sdk.ApplicationKey{
+		Name:          k.Name,
+		Type:          k.Type,
+		Public:        k.Public,
+		Private:       k.Private,
+		KeyID:         k.KeyID,
+		ApplicationID: app.ID,
+	}
+
+	previousKey := *previous
+	previous.Name += sdk.KeyRotationPreviousSuffix
+	if err := application.UpdateKey(ctx, tx, previous); err != nil {
+		return nil, nil, err
+	}
+	if err := application.InsertKey(tx, &newKey); err != nil {
+		return nil, nil, err
+	}
+
+	rotation := newKeyRotation(req, k.Name, previous.Type, previous.Public, triggeredBy)
+	rotation.ProjectID = app.ProjectID
+	rotation.ApplicationID = app.ID
+	rotation.Repositories = repos
+	if err := project.InsertKeyRotation(tx, &rotation); err != nil {
+		return nil, nil, err
+	}
+
+	previousKey.Private, newKey.Private = "", "" // never store private key in audit trail
+	if err := auditLog(ctx, tx, sdk.AuditActionAppKeyRotate, sdk.AuditTargetAppKey, app.Name+"/"+k.Name, projectKey, previousKey, newKey); err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, sdk.WithStack(err)
+	}
+	return &newKey, &rotation, nil
+}
+
+// retireKeyRotation deletes the previous key of a rotation at the end of its grace period. The previous deploy keys are
+// removed from repositories once the rotation is retired, errors are reported in the rotation.
+func (api *API) retireKeyRotation(ctx context.Context, id int64) error {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	r, err := project.LoadKeyRotationForUpdate(ctx, tx, id)
+	if sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if r.Status != sdk.KeyRotationStatusGrace {
+		return nil
+	}
+
+	p, err := project.LoadByID(tx, r.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	previousName := r.KeyName + sdk.KeyRotationPreviousSuffix
+	var appName string
+	if r.ApplicationID == 0 {
+		if err := project.DeleteProjectKey(tx, p.ID, previousName); err != nil {
+			return err
+		}
+	} else {
+		app, err := application.LoadByID(ctx, tx, r.ApplicationID)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if app != nil {
+			appName = app.Name
+			if err := application.DeleteKey(tx, app.ID, previousName); err != nil {
+				return err
+			}
+		}
+	}
+
+	now := time.Now()
+	r.Retired = &now
+	r.Status = sdk.KeyRotationStatusRetired
+	if err := project.UpdateKeyRotation(tx, r); err != nil {
+		return err
+	}
+
+	action, target, targetName := sdk.AuditActionProjectKeyRetire, sdk.AuditTargetProjectKey, previousName
+	if r.ApplicationID != 0 {
+		action, target, targetName = sdk.AuditActionAppKeyRetire, sdk.AuditTargetAppKey, appName+"/"+previousName
+	}
+	if err := auditLog(ctx, tx, action, target, targetName, p.Key, nil, r); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	if r.Error = api.removeKeyRotationDeployKeys(ctx, p.Key, r.PreviousPublic, r.Repositories); r.Error != "" {
+		if err := project.UpdateKeyRotation(api.mustDB(), r); err != nil {
+			return err
+		}
+	}
+
+	if r.ApplicationID == 0 {
+		event.PublishRetireProjectKey(ctx, p.Key, *r)
+	} else if appName != "" {
+		event.PublishApplicationKeyRetire(ctx, p.Key, appName, *r)
+	}
+	return nil
+}
+
+// applyKeyRotationPolicy rotates the keys of a project and its applications that are older than the policy interval.
+// The age of a key is computed from its last rotation or from the activation of the policy.
+func (api *API) applyKeyRotationPolicy(ctx context.Context, policy sdk.KeyRotationPolicy) error {
+	lockKey := cache.Key("api:keyRotationPolicy", strconv.FormatInt(policy.ProjectID, 10))
+	b, err := api.Cache.Lock(lockKey, 5*time.Minute, 0, 1)
+	if err != nil {
+		return err
+	}
+	if !b {
+		return nil
+	}
+	defer func() {
+		_ = api.Cache.Unlock(lockKey)
+	}()
+
+	p, err := project.LoadByID(api.mustDB(), policy.ProjectID, project.LoadOptions.WithKeys)
+	if err != nil {
+		return err
+	}
+	apps, err := application.LoadAll(ctx, api.mustDB(), p.Key, application.LoadOptions.WithKeys)
+	if err != nil {
+		return err
+	}
+	rotations, err := project.LoadKeyRotationsByProjectID(ctx, api.mustDB(), p.ID)
+	if err != nil {
+		return err
+	}
+
+	needRotation := func(appID int64, keyName string) bool {
+		if strings.HasSuffix(keyName, sdk.KeyRotationPreviousSuffix) {
+			return false
+		}
+		last := policy.Created
+		for _, r := range rotations {
+			if r.ApplicationID != appID || r.KeyName != keyName {
+				continue
+			}
+			if r.Status == sdk.KeyRotationStatusGrace {
+				return false
+			}
+			if r.Created.After(last) {
+				last = r.Created
+			}
+		}
+		return time.Since(last) >= time.Duration(policy.Interval)*24*time.Hour
+	}
+
+	req := sdk.KeyRotationRequest{GracePeriod: policy.GracePeriod, PushToVCS: policy.PushToVCS}
+	for _, k := range p.Keys {
+		if !needRotation(0, k.Name) {
+			continue
+		}
+		if _, err := api.rotateProjectKey(ctx, *p, k.Name, req, keyRotationTriggeredByPolicy); err != nil {
+			log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to rotate key %s on project %s", k.Name, p.Key))
+		}
+	}
+	for _, app := range apps {
+		for _, k := range app.Keys {
+			if !needRotation(app.ID, k.Name) {
+				continue
+			}
+			if _, err := api.rotateApplicationKey(ctx, p.Key, app, k.Name, req, keyRotationTriggeredByPolicy); err != nil {
+				log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to rotate key %s on application %s/%s", k.Name, p.Key, app.Name))
+			}
+		}
+	}
+	return nil
+}
+
+// keyRotationRoutine retires expired rotated keys and applies key rotation policies.
+func (api *API) keyRotationRoutine(ctx context.Context, delay time.Duration) {
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			rotations, err := project.LoadExpiredKeyRotations(ctx, api.mustDB())
+			if err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+				continue
+			}
+			for _, r := range rotations {
+				if err := api.retireKeyRotation(ctx, r.ID); err != nil {
+					log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to retire key rotation %d", r.ID))
+				}
+			}
+
+			policies, err := project.LoadEnabledKeyRotationPolicies(ctx, api.mustDB())
+			if err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+				continue
+			}
+			for _, policy := range policies {
+				if err := api.applyKeyRotationPolicy(ctx, policy); err != nil {
+					log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to apply key rotation policy on project %d", policy.ProjectID))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/application"
+	"github.com/ovh/cds/engine/api/mergequeue"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// mergeQueueHistorySize is the number of pull requests and batches returned with a merge queue.
+const mergeQueueHistorySize = 50
+
+// loadMergeQueueTargets sets the application and workflow of a merge queue from their names.
+func (api *API) loadMergeQueueTargets(ctx context.Context, db gorp.SqlExecutor, p sdk.Project, q *sdk.MergeQueue) error {
+	app, err := application.LoadByName(ctx, db, p.Key, q.ApplicationName)
+	if err != nil {
+		return sdk.NewErrorFrom(err, "unable to load application %q", q.ApplicationName)
+	}
+	if app.VCSServer == "" || app.RepositoryFullname == "" {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "application %s is not attached to a repository", app.Name)
+	}
+	wf, err := workflow.Load(ctx, db, api.Cache, p, q.WorkflowName, workflow.LoadOptions{Minimal: true})
+	if err != nil {
+		return sdk.NewErrorFrom(err, "unable to load workflow %q", q.WorkflowName)
+	}
+	q.ProjectID = p.ID
+	q.ApplicationID = app.ID
+	q.WorkflowID = wf.ID
+	if q.MaxBatchSize == 0 {
+		q.MaxBatchSize = 1
+	}
+	if q.MergeMethod == "" {
+		q.MergeMethod = sdk.MergeQueueMethodMerge
+	}
+	return nil
+}
+
+func (api *API) getMergeQueuesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		p, err := project.Load(ctx, api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		qs, err := mergequeue.LoadAllByProjectID(ctx, api.mustDB(), p.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, qs, http.StatusOK)
+	}
+}
+
+func (api *API) postMergeQueueHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		var q sdk.MergeQueue
+		if err := service.UnmarshalBody(r, &q); err != nil {
+			return err
+		}
+
+		p, err := project.Load(ctx, api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+		if err := api.loadMergeQueueTargets(ctx, api.mustDB(), *p, &q); err != nil {
+			return err
+		}
+		// Validation runs are started on behalf of the creator of the queue, the queue is disabled if they lose the
+		// right to run its workflow
+		q.AuthConsumerID = getUserConsumer(ctx).ID
+
+		if err := mergequeue.Insert(api.mustDB(), &q); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, q, http.StatusCreated)
+	}
+}
+
+func (api *API) getMergeQueueHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+
+		p, err := project.Load(ctx, api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		q, err := mergequeue.LoadByID(ctx, api.mustDB(), p.ID, id)
+		if err != nil {
+			return err
+		}
+		q.Entries, err = mergequeue.LoadLastEntries(ctx, api.mustDB(), q.ID, mergeQueueHistorySize)
+		if err != nil {
+			return err
+		}
+		q.Batches, err = mergequeue.LoadLastBatches(ctx, api.mustDB(), q.ID, mergeQueueHistorySize)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, q, http.StatusOK)
+	}
+}
+
+func (api *API) putMergeQueueHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+
+		var q sdk.MergeQueue
+		if err := service.UnmarshalBody(r, &q); err != nil {
+			return err
+		}
+
+		p, err := project.Load(ctx, api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+		old, err := mergequeue.LoadByID(ctx, api.mustDB(), p.ID, id)
+		if err != nil {
+			return err
+		}
+		if err := api.loadMergeQueueTargets(ctx, api.mustDB(), *p, &q); err != nil {
+			return err
+		}
+		q.ID = old.ID
+		q.Created = old.Created
+		q.AuthConsumerID = getUserConsumer(ctx).ID
+
+		if err := mergequeue.Update(api.mustDB(), &q); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, q, http.StatusOK)
+	}
+}
+
+func (api *API) deleteMergeQueueHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+
+		p, err := project.Load(ctx, api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+		q, err := mergequeue.LoadByID(ctx, api.mustDB(), p.ID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := mergequeue.Delete(api.mustDB(), *q); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+func (api *API) postMergeQueuePullRequestHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+
+		var req sdk.MergeQueueEnqueueRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		p, err := project.Load(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+		q, err := mergequeue.LoadByID(ctx, tx, p.ID, id)
+		if err != nil {
+			return err
+		}
+		app, err := application.LoadByID(ctx, tx, q.ApplicationID)
+		if err != nil {
+			return err
+		}
+		client, err := repositoriesmanager.AuthorizedClient(ctx, tx, api.Cache, p.Key, app.VCSServer)
+		if err != nil {
+			return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrNoReposManagerClientAuth, "cannot get vcs server %s for project %s", app.VCSServer, p.Key))
+		}
+		pr, err := client.PullRequest(ctx, app.RepositoryFullname, strconv.FormatInt(req.PullRequestID, 10))
+		if err != nil {
+			return err
+		}
+
+		e, err := mergequeue.Enqueue(ctx, tx, *q, pr, getUserConsumer(ctx).GetUsername())
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, e, http.StatusOK)
+	}
+}
+
+func (api *API) deleteMergeQueuePullRequestHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+		prID, err := requestVarInt(r, "pullRequestID")
+		if err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		p, err := project.Load(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+		q, err := mergequeue.LoadByID(ctx, tx, p.ID, id)
+		if err != nil {
+			return err
+		}
+		e, err := mergequeue.LoadActiveEntryByPullRequestID(ctx, tx, q.ID, prID)
+		if err != nil {
+			return err
+		}
+		if err := mergequeue.Dequeue(ctx, tx, e, "removed by "+getUserConsumer(ctx).GetUsername()); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, e, http.StatusOK)
+	}
+}
@@ -0,0 +1,269 @@
+package mergequeue
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// Insert creates a merge queue.
+func Insert(db gorp.SqlExecutor, q *sdk.MergeQueue) error {
+	if err := q.IsValid(); err != nil {
+		return err
+	}
+	q.Created = time.Now()
+	q.LastModified = q.Created
+	dbQ := dbMergeQueue(*q)
+	if err := gorpmapping.Insert(db, &dbQ); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrConflictData) {
+			return sdk.NewErrorFrom(sdk.ErrAlreadyExist, "a merge queue already exists for branch %s of this application", q.Branch)
+		}
+		return err
+	}
+	q.ID = dbQ.ID
+	return nil
+}
+
+// Update updates a merge queue.
+func Update(db gorp.SqlExecutor, q *sdk.MergeQueue) error {
+	if err := q.IsValid(); err != nil {
+		return err
+	}
+	q.LastModified = time.Now()
+	dbQ := dbMergeQueue(*q)
+	return gorpmapping.Update(db, &dbQ)
+}
+
+// Delete removes a merge queue with its pull requests and batches.
+func Delete(db gorp.SqlExecutor, q sdk.MergeQueue) error {
+	dbQ := dbMergeQueue(q)
+	return gorpmapping.Delete(db, &dbQ)
+}
+
+func get(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) (*sdk.MergeQueue, error) {
+	var q dbMergeQueue
+	found, err := gorpmapping.Get(ctx, db, query, &q)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	res := sdk.MergeQueue(q)
+	return &res, nil
+}
+
+func getAll(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.MergeQueue, error) {
+	var res []dbMergeQueue
+	if err := gorpmapping.GetAll(ctx, db, query, &res); err != nil {
+		return nil, err
+	}
+	qs := make([]sdk.MergeQueue, len(res))
+	for i := range res {
+		qs[i] = sdk.MergeQueue(res[i])
+	}
+	return qs, nil
+}
+
+// LoadAllByProjectID returns the merge queues of a project with the names of their application and workflow.
+func LoadAllByProjectID(ctx context.Context, db gorp.SqlExecutor, projectID int64) ([]sdk.MergeQueue, error) {
+	qs, err := getAll(ctx, db, gorpmapping.NewQuery(`
+		SELECT *
+		FROM merge_queue
+		WHERE project_id = $1
+		ORDER BY id
+	`).Args(projectID))
+	if err != nil {
+		return nil, err
+	}
+	for i := range qs {
+		if err := loadNames(db, &qs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return qs, nil
+}
+
+// LoadByID returns a merge queue of a project with the names of its application and workflow.
+func LoadByID(ctx context.Context, db gorp.SqlExecutor, projectID, id int64) (*sdk.MergeQueue, error) {
+	q, err := get(ctx, db, gorpmapping.NewQuery(`
+		SELECT *
+		FROM merge_queue
+		WHERE project_id = $1 AND id = $2
+	`).Args(projectID, id))
+	if err != nil {
+		return nil, err
+	}
+	if err := loadNames(db, q); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// LoadForUpdate locks a merge queue, returns ErrNotFound if it is locked or doesn't exist.
+func LoadForUpdate(ctx context.Context, db gorp.SqlExecutor, id int64) (*sdk.MergeQueue, error) {
+	return get(ctx, db, gorpmapping.NewQuery(`
+		SELECT *
+		FROM merge_queue
+		WHERE id = $1
+		FOR UPDATE SKIP LOCKED
+	`).Args(id))
+}
+
+// LoadAllEnabled returns all the enabled merge queues.
+func LoadAllEnabled(ctx context.Context, db gorp.SqlExecutor) ([]sdk.MergeQueue, error) {
+	return getAll(ctx, db, gorpmapping.NewQuery(`
+		SELECT *
+		FROM merge_queue
+		WHERE enabled = true
+		ORDER BY id
+	`))
+}
+
+func loadNames(db gorp.SqlExecutor, q *sdk.MergeQueue) error {
+	query := `
+		SELECT application.name, workflow.name
+		FROM application, workflow
+		WHERE application.id = $1 AND workflow.id = $2`
+	if err := db.QueryRow(query, q.ApplicationID, q.WorkflowID).Scan(&q.ApplicationName, &q.WorkflowName); err != nil {
+		return sdk.WrapError(err, "unable to load application and workflow names of merge queue %d", q.ID)
+	}
+	return nil
+}
+
+// InsertEntry adds a pull request to a merge queue.
+func InsertEntry(db gorp.SqlExecutor, e *sdk.MergeQueueEntry) error {
+	e.Created = time.Now()
+	e.LastModified = e.Created
+	dbE := dbMergeQueueEntry(*e)
+	if err := gorpmapping.Insert(db, &dbE); err != nil {
+		return err
+	}
+	e.ID = dbE.ID
+	return nil
+}
+
+// UpdateEntry updates a pull request of a merge queue.
+func UpdateEntry(db gorp.SqlExecutor, e *sdk.MergeQueueEntry) error {
+	e.LastModified = time.Now()
+	dbE := dbMergeQueueEntry(*e)
+	return gorpmapping.Update(db, &dbE)
+}
+
+func getEntries(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.MergeQueueEntry, error) {
+	var res []dbMergeQueueEntry
+	if err := gorpmapping.GetAll(ctx, db, query, &res); err != nil {
+		return nil, err
+	}
+	es := make([]sdk.MergeQueueEntry, len(res))
+	for i := range res {
+		es[i] = sdk.MergeQueueEntry(res[i])
+	}
+	return es, nil
+}
+
+// LoadEntriesByStatus returns the pull requests of a merge queue with given status, in queue order.
+func LoadEntriesByStatus(ctx context.Context, db gorp.SqlExecutor, queueID int64, status ...string) ([]sdk.MergeQueueEntry, error) {
+	return getEntries(ctx, db, gorpmapping.NewQuery(`
+		SELECT *
+		FROM merge_queue_entry
+		WHERE merge_queue_id = $1 AND status = ANY($2)
+		ORDER BY id
+	`).Args(queueID, pq.StringArray(status)))
+}
+
+// LoadLastEntries returns the last pull requests of a merge queue, active ones first.
+func LoadLastEntries(ctx context.Context, db gorp.SqlExecutor, queueID int64, limit int) ([]sdk.MergeQueueEntry, error) {
+	return getEntries(ctx, db, gorpmapping.NewQuery(`
+		SELECT *
+		FROM merge_queue_entry
+		WHERE merge_queue_id = $1
+		ORDER BY status = ANY($2) DESC, id DESC
+		LIMIT $3
+	`).Args(queueID, pq.StringArray{sdk.MergeQueueEntryStatusQueued, sdk.MergeQueueEntryStatusBatched}, limit))
+}
+
+// LoadEntriesByBatchID returns the pull requests of a batch, in queue order.
+func LoadEntriesByBatchID(ctx context.Context, db gorp.SqlExecutor, batchID int64) ([]sdk.MergeQueueEntry, error) {
+	return getEntries(ctx, db, gorpmapping.NewQuery(`
+		SELECT *
+		FROM merge_queue_entry
+		WHERE batch_id = $1 AND status = $2
+		ORDER BY id
+	`).Args(batchID, sdk.MergeQueueEntryStatusBatched))
+}
+
+// LoadActiveEntryByPullRequestID returns the pull request of a merge queue if it is waiting to be merged.
+func LoadActiveEntryByPullRequestID(ctx context.Context, db gorp.SqlExecutor, queueID, pullRequestID int64) (*sdk.MergeQueueEntry, error) {
+	var e dbMergeQueueEntry
+	found, err := gorpmapping.Get(ctx, db, gorpmapping.NewQuery(`
+		SELECT *
+		FROM merge_queue_entry
+		WHERE merge_queue_id = $1 AND pull_request_id = $2 AND status = ANY($3)
+	`).Args(queueID, pullRequestID, pq.StringArray{sdk.MergeQueueEntryStatusQueued, sdk.MergeQueueEntryStatusBatched}), &e)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	res := sdk.MergeQueueEntry(e)
+	return &res, nil
+}
+
+// InsertBatch creates a batch of pull requests.
+func InsertBatch(db gorp.SqlExecutor, b *sdk.MergeQueueBatch) error {
+	b.Created = time.Now()
+	b.LastModified = b.Created
+	dbB := dbMergeQueueBatch(*b)
+	if err := gorpmapping.Insert(db, &dbB); err != nil {
+		return err
+	}
+	b.ID = dbB.ID
+	return nil
+}
+
+// UpdateBatch updates a batch of pull requests.
+func UpdateBatch(db gorp.SqlExecutor, b *sdk.MergeQueueBatch) error {
+	b.LastModified = time.Now()
+	dbB := dbMergeQueueBatch(*b)
+	return gorpmapping.Update(db, &dbB)
+}
+
+func getBatches(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.MergeQueueBatch, error) {
+	var res []dbMergeQueueBatch
+	if err := gorpmapping.GetAll(ctx, db, query, &res); err != nil {
+		return nil, err
+	}
+	bs := make([]sdk.MergeQueueBatch, len(res))
+	for i := range res {
+		bs[i] = sdk.MergeQueueBatch(res[i])
+	}
+	return bs, nil
+}
+
+// LoadActiveBatches returns the batches of a merge queue that are not terminated, in validation order.
+func LoadActiveBatches(ctx context.Context, db gorp.SqlExecutor, queueID int64) ([]sdk.MergeQueueBatch, error) {
+	return getBatches(ctx, db, gorpmapping.NewQuery(`
+		SELECT *
+		FROM merge_queue_batch
+		WHERE merge_queue_id = $1 AND status = ANY($2)
+		ORDER BY id
+	`).Args(queueID, pq.StringArray{sdk.MergeQueueBatchStatusPending, sdk.MergeQueueBatchStatusMerging, sdk.MergeQueueBatchStatusValidating}))
+}
+
+// LoadLastBatches returns the last batches of a merge queue.
+func LoadLastBatches(ctx context.Context, db gorp.SqlExecutor, queueID int64, limit int) ([]sdk.MergeQueueBatch, error) {
+	return getBatches(ctx, db, gorpmapping.NewQuery(`
+		SELECT *
+		FROM merge_queue_batch
+		WHERE merge_queue_id = $1
+		ORDER BY id DESC
+		LIMIT $2
+	`).Args(queueID, limit))
+}
@@ -0,0 +1,18 @@
+package mergequeue
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+type dbMergeQueue sdk.MergeQueue
+
+type dbMergeQueueEntry sdk.MergeQueueEntry
+
+type dbMergeQueueBatch sdk.MergeQueueBatch
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(dbMergeQueue{}, "merge_queue", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbMergeQueueEntry{}, "merge_queue_entry", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbMergeQueueBatch{}, "merge_queue_batch", true, "id"))
+}
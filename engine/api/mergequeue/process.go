@@ -0,0 +1,636 @@
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/application"
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/operation"
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+// Routine processes the enabled merge queues until the context is cancelled.
+func Routine(ctx context.Context, DBFunc func() *gorp.DbMap, store cache.Store, uiURL string, delay time.Duration) {
+	tick := time.NewTicker(delay)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "Exiting mergequeue.Routine: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			db := DBFunc()
+			if db == nil {
+				continue
+			}
+			qs, err := LoadAllEnabled(ctx, db)
+			if err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+				continue
+			}
+			for i := range qs {
+				if err := Process(ctx, db, store, uiURL, qs[i]); err != nil {
+					ctx := sdk.ContextWithStacktrace(ctx, err)
+					log.Error(ctx, "mergequeue.Routine> unable to process merge queue %d: %v", qs[i].ID, err)
+				}
+			}
+		}
+	}
+}
+
+// processor holds what is needed to process a merge queue.
+type processor struct {
+	db     *gorp.DbMap
+	store  cache.Store
+	uiURL  string
+	queue  sdk.MergeQueue
+	proj   *sdk.Project
+	app    *sdk.Application
+	client sdk.VCSAuthorizedClientService
+}
+
+// Process enqueues the labeled pull requests of a merge queue and moves its current batch to its next step. Batches
+// are validated one after the other, each one on the latest state of the target branch.
+func Process(ctx context.Context, db *gorp.DbMap, store cache.Store, uiURL string, q sdk.MergeQueue) error {
+	lockKey := cache.Key("api:mergequeue", strconv.FormatInt(q.ID, 10))
+	locked, err := store.Lock(lockKey, 5*time.Minute, 0, 1)
+	if err != nil {
+		return err
+	}
+	if !locked {
+		return nil
+	}
+	defer store.Unlock(lockKey) // nolint
+
+	p := processor{db: db, store: store, uiURL: uiURL, queue: q}
+	p.proj, err = project.LoadByID(db, q.ProjectID, project.LoadOptions.WithClearKeys)
+	if err != nil {
+		return err
+	}
+	p.app, err = application.LoadByIDWithClearVCSStrategyPassword(ctx, db, q.ApplicationID)
+	if err != nil {
+		return err
+	}
+	if p.app.VCSServer == "" || p.app.RepositoryFullname == "" {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "application %s is not attached to a repository", p.app.Name)
+	}
+
+	// The VCS client may refresh its token, it is saved before the queue is processed. Each step of the queue then
+	// saves its changes in its own transaction, once its calls to the VCS are done.
+	tx, err := db.Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+	p.client, err = repositoriesmanager.AuthorizedClient(ctx, tx, store, p.proj.Key, p.app.VCSServer)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+	return p.process(ctx)
+}
+
+func (p *processor) process(ctx context.Context) error {
+	db, q := p.db, p.queue
+	if q.Label != "" {
+		if err := p.enqueueLabeledPullRequests(ctx); err != nil {
+			return err
+		}
+	}
+
+	batches, err := LoadActiveBatches(ctx, db, q.ID)
+	if err != nil {
+		return err
+	}
+	if len(batches) == 0 {
+		b, err := p.newBatch(ctx)
+		if err != nil || b == nil {
+			return err
+		}
+		batches = append(batches, *b)
+	}
+
+	b := &batches[0]
+	switch b.Status {
+	case sdk.MergeQueueBatchStatusPending:
+		return p.merge(ctx, b)
+	case sdk.MergeQueueBatchStatusMerging:
+		return p.checkMerge(ctx, b)
+	case sdk.MergeQueueBatchStatusValidating:
+		return p.checkValidation(ctx, b)
+	}
+	return nil
+}
+
+// Enqueue adds an open pull request of the target branch of a merge queue, nothing is done if it is already queued.
+func Enqueue(ctx context.Context, db gorp.SqlExecutor, q sdk.MergeQueue, pr sdk.VCSPullRequest, enqueuedBy string) (*sdk.MergeQueueEntry, error) {
+	if pr.Merged || pr.Closed {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "pull request %d is not open", pr.ID)
+	}
+	if pr.Base.Branch.DisplayID != q.Branch {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "pull request %d targets branch %s instead of %s", pr.ID, pr.Base.Branch.DisplayID, q.Branch)
+	}
+	e, err := LoadActiveEntryByPullRequestID(ctx, db, q.ID, int64(pr.ID))
+	if err == nil {
+		return e, nil
+	}
+	if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil, err
+	}
+
+	e = &sdk.MergeQueueEntry{
+		MergeQueueID:   q.ID,
+		PullRequestID:  int64(pr.ID),
+		PullRequestURL: pr.URL,
+		Title:          pr.Title,
+		HeadBranch:     pr.Head.Branch.DisplayID,
+		HeadCommit:     headCommit(pr),
+		Status:         sdk.MergeQueueEntryStatusQueued,
+		EnqueuedBy:     enqueuedBy,
+	}
+	if err := InsertEntry(db, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Dequeue removes a pull request from a merge queue, its batch is cancelled and its other pull requests are validated
+// again in a new batch.
+func Dequeue(ctx context.Context, db gorp.SqlExecutor, e *sdk.MergeQueueEntry, message string) error {
+	batchID := e.BatchID
+	e.Status = sdk.MergeQueueEntryStatusRemoved
+	e.Message = message
+	if err := UpdateEntry(db, e); err != nil {
+		return err
+	}
+	if batchID == 0 {
+		return nil
+	}
+
+	batches, err := LoadActiveBatches(ctx, db, e.MergeQueueID)
+	if err != nil {
+		return err
+	}
+	for i := range batches {
+		if batches[i].ID != batchID {
+			continue
+		}
+		others, err := LoadEntriesByBatchID(ctx, db, batchID)
+		if err != nil {
+			return err
+		}
+		return retryBatch(db, &batches[i], others, fmt.Sprintf("pull request %d removed from the queue", e.PullRequestID))
+	}
+	return nil
+}
+
+func headCommit(pr sdk.VCSPullRequest) string {
+	if pr.Head.Branch.LatestCommit != "" {
+		return pr.Head.Branch.LatestCommit
+	}
+	return pr.Head.Commit.Hash
+}
+
+func (p *processor) enqueueLabeledPullRequests(ctx context.Context) error {
+	prs, err := p.client.PullRequests(ctx, p.app.RepositoryFullname, sdk.VCSRequestModifierWithState(sdk.VCSPullRequestStateOpen))
+	if err != nil {
+		return err
+	}
+	for _, pr := range prs {
+		if pr.Base.Branch.DisplayID != p.queue.Branch || !sdk.IsInArray(p.queue.Label, pr.Labels) {
+			continue
+		}
+		if _, err := Enqueue(ctx, p.db, p.queue, pr, "label:"+p.queue.Label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newBatch creates a batch with the oldest queued pull requests, returns nil if the queue is empty.
+func (p *processor) newBatch(ctx context.Context) (*sdk.MergeQueueBatch, error) {
+	entries, err := LoadEntriesByStatus(ctx, p.db, p.queue.ID, sdk.MergeQueueEntryStatusQueued)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	if int64(len(entries)) > p.queue.MaxBatchSize {
+		entries = entries[:p.queue.MaxBatchSize]
+	}
+
+	tx, err := p.db.Begin()
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+	b, err := insertBatch(tx, p.queue.ID, entries)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return b, nil
+}
+
+func insertBatch(db gorp.SqlExecutor, queueID int64, entries []sdk.MergeQueueEntry) (*sdk.MergeQueueBatch, error) {
+	b := &sdk.MergeQueueBatch{
+		MergeQueueID: queueID,
+		Status:       sdk.MergeQueueBatchStatusPending,
+	}
+	if err := InsertBatch(db, b); err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		entries[i].Status = sdk.MergeQueueEntryStatusBatched
+		entries[i].BatchID = b.ID
+		if err := UpdateEntry(db, &entries[i]); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// requeueEntries puts pull requests back in the queue, they are validated in the next batch.
+func requeueEntries(db gorp.SqlExecutor, entries []sdk.MergeQueueEntry) error {
+	for i := range entries {
+		entries[i].Status = sdk.MergeQueueEntryStatusQueued
+		entries[i].BatchID = 0
+		if err := UpdateEntry(db, &entries[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retryBatch cancels a batch and validates its remaining pull requests in a new batch.
+func retryBatch(db gorp.SqlExecutor, b *sdk.MergeQueueBatch, entries []sdk.MergeQueueEntry, message string) error {
+	b.Status = sdk.MergeQueueBatchStatusCancelled
+	b.Message = message
+	if err := UpdateBatch(db, b); err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	_, err := insertBatch(db, b.MergeQueueID, entries)
+	return err
+}
+
+// merge refreshes the pull requests of a pending batch and asks the repositories service to merge them on the
+// speculative branch of the queue.
+func (p *processor) merge(ctx context.Context, b *sdk.MergeQueueBatch) error {
+	entries, err := LoadEntriesByBatchID(ctx, p.db, b.ID)
+	if err != nil {
+		return err
+	}
+
+	sources := make([]sdk.OperationMergeSource, 0, len(entries))
+	for i := range entries {
+		e := &entries[i]
+		pr, err := p.client.PullRequest(ctx, p.app.RepositoryFullname, strconv.FormatInt(e.PullRequestID, 10))
+		if err != nil {
+			return err
+		}
+		if pr.Merged || pr.Closed || pr.Base.Branch.DisplayID != p.queue.Branch {
+			e.Status = sdk.MergeQueueEntryStatusRemoved
+			e.Message = "pull request closed or retargeted"
+			continue
+		}
+		// Commits pushed while the pull request was queued are validated
+		if c := headCommit(pr); c != "" {
+			e.HeadCommit = c
+		}
+		sources = append(sources, sdk.OperationMergeSource{
+			Branch:  e.HeadBranch,
+			Commit:  e.HeadCommit,
+			Message: fmt.Sprintf("Merge pull request #%d from %s", e.PullRequestID, e.HeadBranch),
+		})
+	}
+	if len(sources) == 0 {
+		b.Status = sdk.MergeQueueBatchStatusCancelled
+		b.Message = "no pull request left in the batch"
+		return p.saveBatch(b, entries)
+	}
+
+	repo, err := p.client.RepoByFullname(ctx, p.app.RepositoryFullname)
+	if err != nil {
+		return sdk.WrapError(err, "cannot get repo %s", p.app.RepositoryFullname)
+	}
+	ope := sdk.Operation{
+		VCSServer:          p.app.VCSServer,
+		RepoFullName:       p.app.RepositoryFullname,
+		RepositoryStrategy: p.app.RepositoryStrategy,
+		URL:                repo.HTTPCloneURL,
+		Setup: sdk.OperationSetup{
+			Merge: sdk.OperationMerge{
+				FromBranch: p.queue.SpeculativeBranch(),
+				ToBranch:   p.queue.Branch,
+				Sources:    sources,
+			},
+		},
+	}
+	if ope.RepositoryStrategy.ConnectionType == "ssh" {
+		ope.URL = repo.SSHCloneURL
+	}
+	if err := operation.PostRepositoryOperation(ctx, p.db, *p.proj, &ope, nil); err != nil {
+		return err
+	}
+
+	b.OperationUUID = ope.UUID
+	b.Status = sdk.MergeQueueBatchStatusMerging
+	return p.saveBatch(b, entries)
+}
+
+// saveBatch updates a batch and its pull requests in a transaction.
+func (p *processor) saveBatch(b *sdk.MergeQueueBatch, entries []sdk.MergeQueueEntry) error {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+	for i := range entries {
+		if err := UpdateEntry(tx, &entries[i]); err != nil {
+			return err
+		}
+	}
+	if err := UpdateBatch(tx, b); err != nil {
+		return err
+	}
+	return sdk.WithStack(tx.Commit())
+}
+
+// checkMerge starts the validation of a batch once its speculative merge is pushed. Pull requests that can't be
+// merged are removed from the queue.
+func (p *processor) checkMerge(ctx context.Context, b *sdk.MergeQueueBatch) error {
+	ope, err := operation.GetRepositoryOperation(ctx, p.db, b.OperationUUID)
+	if err != nil {
+		return err
+	}
+	switch ope.Status {
+	case sdk.OperationStatusDone:
+	case sdk.OperationStatusError:
+		msg := "speculative merge failed"
+		if ope.Error != nil {
+			msg += ": " + ope.Error.Message
+		}
+		return p.failBatch(ctx, b, msg)
+	default:
+		return nil
+	}
+
+	entries, err := LoadEntriesByBatchID(ctx, p.db, b.ID)
+	if err != nil {
+		return err
+	}
+	merged := make([]sdk.MergeQueueEntry, 0, len(entries))
+	var conflicts []sdk.MergeQueueEntry
+	for i := range entries {
+		if !sdk.IsInArray(entries[i].HeadCommit, ope.Setup.Merge.Result.Conflicts) {
+			merged = append(merged, entries[i])
+			continue
+		}
+		entries[i].Status = sdk.MergeQueueEntryStatusFailed
+		entries[i].Message = fmt.Sprintf("Pull request #%d conflicts with branch %s or with the pull requests ahead in the merge queue.", entries[i].PullRequestID, p.queue.Branch)
+		conflicts = append(conflicts, entries[i])
+	}
+	if len(merged) == 0 || ope.Setup.Merge.Result.Commit == "" {
+		b.Status = sdk.MergeQueueBatchStatusFail
+		b.Message = "all the pull requests of the batch are in conflict"
+		if err := p.saveBatch(b, conflicts); err != nil {
+			return err
+		}
+		p.commentFailedEntries(ctx, conflicts)
+		return nil
+	}
+
+	wf, err := workflow.LoadByID(ctx, p.db, p.store, *p.proj, p.queue.WorkflowID, workflow.LoadOptions{})
+	if err != nil {
+		return err
+	}
+	if err := p.checkConsumer(ctx, wf); err != nil {
+		return err
+	}
+	prIDs := make([]string, len(merged))
+	for i := range merged {
+		prIDs[i] = strconv.FormatInt(merged[i].PullRequestID, 10)
+	}
+	wr, err := workflow.CreateRun(p.db, wf, sdk.WorkflowRunPostHandlerOption{
+		Manual: &sdk.WorkflowNodeRunManual{
+			Payload: map[string]string{
+				"git.branch":                    p.queue.SpeculativeBranch(),
+				"git.hash":                      ope.Setup.Merge.Result.Commit,
+				"git.repository":                p.app.RepositoryFullname,
+				"cds.merge_queue.branch":        p.queue.Branch,
+				"cds.merge_queue.batch":         strconv.FormatInt(b.ID, 10),
+				"cds.merge_queue.pull_requests": strings.Join(prIDs, ","),
+			},
+		},
+		AuthConsumerID: p.queue.AuthConsumerID,
+	})
+	if err != nil {
+		return err
+	}
+
+	b.BaseCommit = ope.Setup.Merge.Result.BaseCommit
+	b.Commit = ope.Setup.Merge.Result.Commit
+	b.WorkflowRunID = wr.ID
+	b.WorkflowRunNumber = wr.Number
+	b.Status = sdk.MergeQueueBatchStatusValidating
+	if err := p.saveBatch(b, conflicts); err != nil {
+		return err
+	}
+	p.commentFailedEntries(ctx, conflicts)
+	return nil
+}
+
+// checkConsumer checks that the consumer of the queue can still run its workflow, the queue is disabled otherwise.
+// It is enabled again when updated by a user allowed to run the workflow.
+func (p *processor) checkConsumer(ctx context.Context, wf *sdk.Workflow) error {
+	c, err := authentication.LoadUserConsumerByID(ctx, p.db, p.queue.AuthConsumerID,
+		authentication.LoadUserConsumerOptions.WithAuthentifiedUser,
+		authentication.LoadUserConsumerOptions.WithConsumerGroups)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	if c != nil && !c.Disabled && permission.AccessToWorkflowNode(ctx, p.db, wf, &wf.WorkflowData.Node, *c, sdk.PermissionReadExecute) {
+		return nil
+	}
+
+	p.queue.Enabled = false
+	if err := Update(p.db, &p.queue); err != nil {
+		return err
+	}
+	return sdk.NewErrorFrom(sdk.ErrNoPermExecution, "merge queue disabled, its consumer can't run workflow %s anymore", wf.Name)
+}
+
+// checkValidation merges the pull requests of a batch once its workflow run is successful, a failing batch is
+// bisected until the failing pull request is found.
+func (p *processor) checkValidation(ctx context.Context, b *sdk.MergeQueueBatch) error {
+	wr, err := workflow.LoadRunByID(ctx, p.db, b.WorkflowRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return p.failBatch(ctx, b, "validation workflow run not found")
+		}
+		return err
+	}
+	if !sdk.StatusIsTerminated(wr.Status) {
+		return nil
+	}
+
+	entries, err := LoadEntriesByBatchID(ctx, p.db, b.ID)
+	if err != nil {
+		return err
+	}
+	runURL := fmt.Sprintf("%s/project/%s/workflow/%s/run/%d", p.uiURL, p.proj.Key, p.queue.WorkflowName, b.WorkflowRunNumber)
+
+	if wr.Status != sdk.StatusSuccess {
+		if len(entries) == 1 {
+			msg := fmt.Sprintf("Pull request #%d breaks branch %s, validation failed: %s", entries[0].PullRequestID, p.queue.Branch, runURL)
+			return p.failBatch(ctx, b, msg)
+		}
+		return p.bisect(b, entries)
+	}
+
+	// Pull requests are merged only if the target branch is still the one that was validated
+	branch, err := p.client.Branch(ctx, p.app.RepositoryFullname, sdk.VCSBranchFilters{BranchName: p.queue.Branch})
+	if err != nil {
+		return err
+	}
+	if branch == nil || branch.LatestCommit != b.BaseCommit {
+		tx, err := p.db.Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+		if err := retryBatch(tx, b, entries, fmt.Sprintf("branch %s moved during the validation", p.queue.Branch)); err != nil {
+			return err
+		}
+		return sdk.WithStack(tx.Commit())
+	}
+
+	return p.mergeBatch(ctx, b, entries, runURL)
+}
+
+// bisect fails a batch and validates the first half of its pull requests in a new batch, the second half is queued
+// again to be validated after it.
+func (p *processor) bisect(b *sdk.MergeQueueBatch, entries []sdk.MergeQueueEntry) error {
+	first, second := sdk.BisectMergeQueueEntries(entries)
+	tx, err := p.db.Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+	b.Status = sdk.MergeQueueBatchStatusFail
+	b.Message = fmt.Sprintf("validation failed, bisecting %d pull requests", len(entries))
+	if err := UpdateBatch(tx, b); err != nil {
+		return err
+	}
+	if _, err := insertBatch(tx, b.MergeQueueID, first); err != nil {
+		return err
+	}
+	if err := requeueEntries(tx, second); err != nil {
+		return err
+	}
+	return sdk.WithStack(tx.Commit())
+}
+
+// mergeBatch merges the pull requests of a validated batch. Each merged pull request is saved as soon as the VCS
+// merged it, if a pull request can't be merged the next ones are queued again to be validated without it.
+func (p *processor) mergeBatch(ctx context.Context, b *sdk.MergeQueueBatch, entries []sdk.MergeQueueEntry, runURL string) error {
+	for i := range entries {
+		e := &entries[i]
+		err := p.client.PullRequestMerge(ctx, p.app.RepositoryFullname, strconv.FormatInt(e.PullRequestID, 10), sdk.VCSPullRequestMergeRequest{
+			Method: p.queue.MergeMethod,
+			Commit: e.HeadCommit,
+		})
+		if err != nil {
+			ctx := sdk.ContextWithStacktrace(ctx, err)
+			log.Error(ctx, "mergequeue> unable to merge pull request %d of merge queue %d: %v", e.PullRequestID, p.queue.ID, err)
+			e.Status = sdk.MergeQueueEntryStatusFailed
+			e.Message = fmt.Sprintf("Pull request #%d validated by %s can't be merged: %v", e.PullRequestID, runURL, sdk.ExtractHTTPError(err).Message)
+
+			tx, err := p.db.Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			if err := UpdateEntry(tx, e); err != nil {
+				return err
+			}
+			if err := requeueEntries(tx, entries[i+1:]); err != nil {
+				return err
+			}
+			b.Status = sdk.MergeQueueBatchStatusFail
+			b.Message = fmt.Sprintf("%d pull requests merged, pull request %d can't be merged", i, e.PullRequestID)
+			if err := UpdateBatch(tx, b); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			p.commentFailedEntries(ctx, []sdk.MergeQueueEntry{*e})
+			return nil
+		}
+		e.Status = sdk.MergeQueueEntryStatusMerged
+		e.Message = ""
+		if err := UpdateEntry(p.db, e); err != nil {
+			return err
+		}
+	}
+
+	b.Status = sdk.MergeQueueBatchStatusSuccess
+	return UpdateBatch(p.db, b)
+}
+
+// failBatch terminates a batch and removes its pull requests from the queue.
+func (p *processor) failBatch(ctx context.Context, b *sdk.MergeQueueBatch, message string) error {
+	entries, err := LoadEntriesByBatchID(ctx, p.db, b.ID)
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		entries[i].Status = sdk.MergeQueueEntryStatusFailed
+		entries[i].Message = message
+	}
+	b.Status = sdk.MergeQueueBatchStatusFail
+	b.Message = message
+	if err := p.saveBatch(b, entries); err != nil {
+		return err
+	}
+	p.commentFailedEntries(ctx, entries)
+	return nil
+}
+
+// commentFailedEntries explains on the pull requests why they were removed from the queue.
+func (p *processor) commentFailedEntries(ctx context.Context, entries []sdk.MergeQueueEntry) {
+	for _, e := range entries {
+		comment := sdk.VCSPullRequestCommentRequest{
+			VCSPullRequest: sdk.VCSPullRequest{ID: int(e.PullRequestID)},
+			Message:        "Removed from the CDS merge queue of branch " + p.queue.Branch + ". " + e.Message,
+		}
+		if err := p.client.PullRequestComment(ctx, p.app.RepositoryFullname, comment); err != nil {
+			ctx := sdk.ContextWithStacktrace(ctx, err)
+			log.Error(ctx, "mergequeue> unable to comment pull request %d: %v", e.PullRequestID, err)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/application"
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/bootstrap"
+	apiTest "github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/test"
+	"github.com/ovh/cds/sdk"
+)
+
+type fakeVCSClient struct {
+	sdk.VCSAuthorizedClientService
+	mergeErrors map[string]error
+	merged      []string
+	comments    []int
+}
+
+func (c *fakeVCSClient) PullRequestMerge(_ context.Context, _ string, id string, _ sdk.VCSPullRequestMergeRequest) error {
+	if err := c.mergeErrors[id]; err != nil {
+		return err
+	}
+	c.merged = append(c.merged, id)
+	return nil
+}
+
+func (c *fakeVCSClient) PullRequestComment(_ context.Context, _ string, req sdk.VCSPullRequestCommentRequest) error {
+	c.comments = append(c.comments, req.ID)
+	return nil
+}
+
+// newTestProcessor creates a merge queue with a batch of given size.
+func newTestProcessor(t *testing.T, batchSize int) (*processor, *test.FakeTransaction, *sdk.Workflow, *sdk.MergeQueueBatch, []sdk.MergeQueueEntry) {
+	db, cache := apiTest.SetupPG(t, bootstrap.InitiliazeDB)
+	ctx := context.TODO()
+
+	proj := assets.InsertTestProject(t, db, cache, sdk.RandomString(10), sdk.RandomString(10))
+	app := &sdk.Application{
+		Name:               sdk.RandomString(10),
+		VCSServer:          "github",
+		RepositoryFullname: "ovh/cds",
+	}
+	require.NoError(t, application.Insert(db, *proj, app))
+	wf := assets.InsertTestWorkflow(t, db, cache, proj, sdk.RandomString(10))
+
+	q := sdk.MergeQueue{
+		ProjectID:      proj.ID,
+		ApplicationID:  app.ID,
+		WorkflowID:     wf.ID,
+		Branch:         "main",
+		MaxBatchSize:   int64(batchSize),
+		MergeMethod:    sdk.MergeQueueMethodMerge,
+		Enabled:        true,
+		AuthConsumerID: sdk.UUID(),
+	}
+	require.NoError(t, Insert(db, &q))
+
+	p := &processor{
+		db:     db.DbMap,
+		store:  cache,
+		queue:  q,
+		proj:   proj,
+		app:    app,
+		client: &fakeVCSClient{mergeErrors: map[string]error{}},
+	}
+	for i := 1; i <= batchSize; i++ {
+		pr := sdk.VCSPullRequest{ID: i, Title: fmt.Sprintf("pr %d", i)}
+		pr.Base.Branch.DisplayID = q.Branch
+		pr.Head.Branch.DisplayID = fmt.Sprintf("feat/%d", i)
+		pr.Head.Branch.LatestCommit = fmt.Sprintf("commit-%d", i)
+		_, err := Enqueue(ctx, db, q, pr, "test")
+		require.NoError(t, err)
+	}
+
+	b, err := p.newBatch(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, b)
+	entries, err := LoadEntriesByBatchID(ctx, db, b.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, batchSize)
+
+	return p, db, wf, b, entries
+}
+
+func entriesStatus(t *testing.T, p *processor) map[int64]string {
+	entries, err := LoadLastEntries(context.TODO(), p.db, p.queue.ID, 100)
+	require.NoError(t, err)
+	res := make(map[int64]string, len(entries))
+	for _, e := range entries {
+		res[e.PullRequestID] = e.Status
+	}
+	return res
+}
+
+func TestBisect(t *testing.T) {
+	p, db, _, b, entries := newTestProcessor(t, 3)
+	ctx := context.TODO()
+
+	require.NoError(t, p.bisect(b, entries))
+
+	batches, err := LoadActiveBatches(ctx, db, p.queue.ID)
+	require.NoError(t, err)
+	require.Len(t, batches, 1, "the second half should wait behind the first one")
+	require.Equal(t, sdk.MergeQueueBatchStatusPending, batches[0].Status)
+
+	first, err := LoadEntriesByBatchID(ctx, db, batches[0].ID)
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+	require.Equal(t, map[int64]string{
+		1: sdk.MergeQueueEntryStatusBatched,
+		2: sdk.MergeQueueEntryStatusBatched,
+		3: sdk.MergeQueueEntryStatusQueued,
+	}, entriesStatus(t, p))
+
+	// The second half is validated once the first one is terminated
+	batches[0].Status = sdk.MergeQueueBatchStatusSuccess
+	require.NoError(t, UpdateBatch(db, &batches[0]))
+	next, err := p.newBatch(ctx)
+	require.NoError(t, err)
+	second, err := LoadEntriesByBatchID(ctx, db, next.ID)
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	require.Equal(t, int64(3), second[0].PullRequestID)
+}
+
+func TestMergeBatch(t *testing.T) {
+	p, db, _, b, entries := newTestProcessor(t, 3)
+	client := p.client.(*fakeVCSClient)
+
+	require.NoError(t, p.mergeBatch(context.TODO(), b, entries, "run-url"))
+
+	require.Equal(t, []string{"1", "2", "3"}, client.merged)
+	require.Empty(t, client.comments)
+	require.Equal(t, map[int64]string{
+		1: sdk.MergeQueueEntryStatusMerged,
+		2: sdk.MergeQueueEntryStatusMerged,
+		3: sdk.MergeQueueEntryStatusMerged,
+	}, entriesStatus(t, p))
+	batches, err := LoadLastBatches(context.TODO(), db, p.queue.ID, 1)
+	require.NoError(t, err)
+	require.Equal(t, sdk.MergeQueueBatchStatusSuccess, batches[0].Status)
+}
+
+func TestMergeBatchPartialFailure(t *testing.T) {
+	p, db, _, b, entries := newTestProcessor(t, 3)
+	client := p.client.(*fakeVCSClient)
+	client.mergeErrors["2"] = fmt.Errorf("not mergeable")
+
+	require.NoError(t, p.mergeBatch(context.TODO(), b, entries, "run-url"))
+
+	require.Equal(t, []string{"1"}, client.merged)
+	require.Equal(t, []int{2}, client.comments)
+	require.Equal(t, map[int64]string{
+		1: sdk.MergeQueueEntryStatusMerged,
+		2: sdk.MergeQueueEntryStatusFailed,
+		3: sdk.MergeQueueEntryStatusQueued,
+	}, entriesStatus(t, p))
+
+	batches, err := LoadActiveBatches(context.TODO(), db, p.queue.ID)
+	require.NoError(t, err)
+	require.Empty(t, batches)
+	last, err := LoadLastBatches(context.TODO(), db, p.queue.ID, 1)
+	require.NoError(t, err)
+	require.Equal(t, sdk.MergeQueueBatchStatusFail, last[0].Status)
+}
+
+func TestFailBatch(t *testing.T) {
+	p, db, _, b, _ := newTestProcessor(t, 2)
+	client := p.client.(*fakeVCSClient)
+
+	require.NoError(t, p.failBatch(context.TODO(), b, "validation failed"))
+
+	require.Equal(t, []int{1, 2}, client.comments)
+	require.Equal(t, map[int64]string{
+		1: sdk.MergeQueueEntryStatusFailed,
+		2: sdk.MergeQueueEntryStatusFailed,
+	}, entriesStatus(t, p))
+	batches, err := LoadActiveBatches(context.TODO(), db, p.queue.ID)
+	require.NoError(t, err)
+	require.Empty(t, batches)
+}
+
+func TestDequeue(t *testing.T) {
+	p, db, _, b, entries := newTestProcessor(t, 3)
+	ctx := context.TODO()
+
+	require.NoError(t, Dequeue(ctx, db, &entries[1], "removed by test"))
+
+	batches, err := LoadActiveBatches(ctx, db, p.queue.ID)
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	require.NotEqual(t, b.ID, batches[0].ID)
+	others, err := LoadEntriesByBatchID(ctx, db, batches[0].ID)
+	require.NoError(t, err)
+	require.Len(t, others, 2)
+	require.Equal(t, sdk.MergeQueueEntryStatusRemoved, entriesStatus(t, p)[2])
+}
+
+func TestCheckConsumer(t *testing.T) {
+	p, db, wf, _, _ := newTestProcessor(t, 1)
+	ctx := context.TODO()
+
+	admin, _ := assets.InsertAdminUser(t, db)
+	adminConsumer, err := authentication.LoadUserConsumerByTypeAndUserID(ctx, db, sdk.ConsumerLocal, admin.ID)
+	require.NoError(t, err)
+	p.queue.AuthConsumerID = adminConsumer.ID
+	require.NoError(t, p.checkConsumer(ctx, wf))
+
+	lambda, _ := assets.InsertLambdaUser(t, db)
+	lambdaConsumer, err := authentication.LoadUserConsumerByTypeAndUserID(ctx, db, sdk.ConsumerLocal, lambda.ID)
+	require.NoError(t, err)
+	p.queue.AuthConsumerID = lambdaConsumer.ID
+	err = p.checkConsumer(ctx, wf)
+	require.Error(t, err)
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNoPermExecution))
+
+	q, err := LoadByID(ctx, db, p.queue.ProjectID, p.queue.ID)
+	require.NoError(t, err)
+	require.False(t, q.Enabled)
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"sync"
+
+	"go.opencensus.io/tag"
+
+	"github.com/ovh/cds/sdk/telemetry"
+)
+
+// OtherLabelValue is recorded instead of the real label value when the maximum number
+// of distinct values for a label is reached.
+const OtherLabelValue = "other"
+
+// LabelLimiter bounds the cardinality of metrics labels. Each label can be disabled or
+// limited to a maximum number of distinct values, the first values seen are kept.
+type LabelLimiter struct {
+	mutex    sync.Mutex
+	max      int
+	disabled map[string]struct{}
+	values   map[string]map[string]struct{}
+}
+
+// NewLabelLimiter returns a limiter that keeps at most max distinct values for each label,
+// 0 means unlimited. Given disabled labels are never recorded.
+func NewLabelLimiter(max int, disabledLabels []string) *LabelLimiter {
+	l := &LabelLimiter{
+		max:      max,
+		disabled: make(map[string]struct{}, len(disabledLabels)),
+		values:   make(map[string]map[string]struct{}),
+	}
+	for _, d := range disabledLabels {
+		l.disabled[d] = struct{}{}
+	}
+	return l
+}
+
+// IsDisabled returns true if given label should not be recorded.
+func (l *LabelLimiter) IsDisabled(label string) bool {
+	_, ok := l.disabled[label]
+	return ok
+}
+
+// TagKeys returns the tag keys for given labels, without the disabled ones.
+func (l *LabelLimiter) TagKeys(labels ...string) []tag.Key {
+	keys := make([]tag.Key, 0, len(labels))
+	for _, label := range labels {
+		if l.IsDisabled(label) {
+			continue
+		}
+		keys = append(keys, telemetry.MustNewKey(label))
+	}
+	return keys
+}
+
+// Value returns the value to record for given label.
+func (l *LabelLimiter) Value(label, value string) string {
+	if l.max <= 0 {
+		return value
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	values, ok := l.values[label]
+	if !ok {
+		values = make(map[string]struct{})
+		l.values[label] = values
+	}
+	if _, ok := values[value]; ok {
+		return value
+	}
+	if len(values) >= l.max {
+		return OtherLabelValue
+	}
+	values[value] = struct{}{}
+	return value
+}
+
+// Mutators returns the tag mutators for given labels and values, disabled labels are skipped.
+func (l *LabelLimiter) Mutators(labelValues map[string]string) []tag.Mutator {
+	mutators := make([]tag.Mutator, 0, len(labelValues))
+	for label, value := range labelValues {
+		if l.IsDisabled(label) {
+			continue
+		}
+		if value == "" {
+			value = "none"
+		}
+		mutators = append(mutators, tag.Upsert(telemetry.MustNewKey(label), l.Value(label, value)))
+	}
+	return mutators
+}
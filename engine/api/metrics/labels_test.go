@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk/telemetry"
+)
+
+func TestLabelLimiter(t *testing.T) {
+	l := NewLabelLimiter(2, []string{telemetry.TagRegion})
+
+	require.Equal(t, "PROJ1", l.Value(telemetry.TagProjectKey, "PROJ1"))
+	require.Equal(t, "PROJ2", l.Value(telemetry.TagProjectKey, "PROJ2"))
+	require.Equal(t, OtherLabelValue, l.Value(telemetry.TagProjectKey, "PROJ3"))
+	require.Equal(t, "PROJ1", l.Value(telemetry.TagProjectKey, "PROJ1"))
+	require.Equal(t, "build", l.Value(telemetry.TagWorkflow, "build"))
+
+	keys := l.TagKeys(telemetry.TagProjectKey, telemetry.TagRegion)
+	require.Len(t, keys, 1)
+	require.Equal(t, telemetry.TagProjectKey, keys[0].Name())
+
+	require.Len(t, l.Mutators(map[string]string{
+		telemetry.TagProjectKey: "PROJ1",
+		telemetry.TagRegion:     "eu",
+	}), 1)
+
+	unlimited := NewLabelLimiter(0, nil)
+	for _, v := range []string{"a", "b", "c"} {
+		require.Equal(t, v, unlimited.Value(telemetry.TagWorkerModel, v))
+	}
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/jws"
+)
+
+func (api *API) oidcIssuer() string {
+	return strings.TrimSuffix(api.Config.URL.API, "/")
+}
+
+func (api *API) getOIDCDiscoveryHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if !api.Config.Auth.JobIDToken.Enabled {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+
+		issuer := api.oidcIssuer()
+		doc := sdk.OIDCDiscoveryDocument{
+			Issuer:                           issuer,
+			JWKSURI:                          issuer + sdk.OIDCJWKSPath,
+			SubjectTypesSupported:            []string{"public"},
+			ResponseTypesSupported:           []string{"id_token"},
+			IDTokenSigningAlgValuesSupported: []string{string(jose.RS256)},
+			ScopesSupported:                  []string{"openid"},
+			ClaimsSupported:                  sdk.JobIDTokenClaimsSupported,
+		}
+		return service.WriteJSON(w, doc, http.StatusOK)
+	}
+}
+
+func (api *API) getOIDCJWKSHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if !api.Config.Auth.JobIDToken.Enabled {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+
+		// All the signing keys are exposed to allow verification of tokens issued before a key rotation
+		set, err := jws.NewPublicJSONWebKeySet(authentication.GetVerifyKeys()...)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, set, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowJobIDTokenHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+		if !api.Config.Auth.JobIDToken.Enabled {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "job ID tokens are not enabled on this CDS instance")
+		}
+
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return err
+		}
+
+		var req sdk.JobIDTokenRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+
+		audience := req.Audience
+		if audience == "" {
+			audience = api.Config.Auth.JobIDToken.DefaultAudience
+		}
+		if audience == "" {
+			audience = api.oidcIssuer()
+		}
+		allowed := api.Config.Auth.JobIDToken.AllowedAudiences
+		if len(allowed) > 0 && !allowed.Contains(audience) {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "audience %q is not allowed", audience)
+		}
+
+		jobRun, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, id)
+		if err != nil {
+			return err
+		}
+		if jobRun.Status != sdk.StatusBuilding {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "cannot issue an ID token for a job with status %s", jobRun.Status)
+		}
+
+		duration := time.Duration(api.Config.Auth.JobIDToken.Duration) * time.Second
+		if duration <= 0 {
+			duration = 5 * time.Minute
+		}
+		now := time.Now()
+
+		claims := sdk.NewJobIDTokenClaims(*jobRun)
+		claims.Issuer = api.oidcIssuer()
+		claims.Audience = audience
+		claims.ID = sdk.UUID()
+		claims.IssuedAt = now.Unix()
+		claims.NotBefore = now.Unix()
+		claims.ExpiresAt = now.Add(duration).Unix()
+
+		signer, err := jws.NewJWTSigner(authentication.GetSigningKey())
+		if err != nil {
+			return err
+		}
+		token, err := jws.Sign(signer, claims)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, sdk.JobIDToken{
+			Token:     token,
+			ExpiresAt: claims.ExpiresAt,
+		}, http.StatusOK)
+	}
+}
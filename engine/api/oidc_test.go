@@ -0,0 +1,105 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/jws"
+)
+
+func Test_postWorkflowJobIDTokenHandler(t *testing.T) {
+	api, db, router := newTestAPI(t)
+	api.Config.URL.API = "https://cds.local/api"
+	api.Config.Auth.JobIDToken.Enabled = true
+	api.Config.Auth.JobIDToken.AllowedAudiences = []string{"sts.amazonaws.com"}
+	api.Config.Auth.JobIDToken.Duration = 60
+
+	s, _, _ := assets.InitCDNService(t, db)
+	defer func() {
+		_ = services.Delete(db, s)
+	}()
+
+	ctx := testRunWorkflow(t, api, router)
+	testRegisterWorker(t, api, db, router, &ctx)
+
+	// Take the job
+	uri := router.GetRoute("POST", api.postTakeWorkflowJobHandler, map[string]string{
+		"permJobID": fmt.Sprintf("%d", ctx.job.ID),
+	})
+	require.NotEmpty(t, uri)
+	req := assets.NewJWTAuthentifiedRequest(t, ctx.workerToken, "POST", uri, nil)
+	rec := httptest.NewRecorder()
+	router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+
+	// Audience that is not allowed
+	uri = router.GetRoute("POST", api.postWorkflowJobIDTokenHandler, map[string]string{
+		"permJobID": fmt.Sprintf("%d", ctx.job.ID),
+	})
+	require.NotEmpty(t, uri)
+	req = assets.NewJWTAuthentifiedRequest(t, ctx.workerToken, "POST", uri, sdk.JobIDTokenRequest{Audience: "unknown"})
+	rec = httptest.NewRecorder()
+	router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 403, rec.Code)
+
+	req = assets.NewJWTAuthentifiedRequest(t, ctx.workerToken, "POST", uri, sdk.JobIDTokenRequest{Audience: "sts.amazonaws.com"})
+	rec = httptest.NewRecorder()
+	router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+	var token sdk.JobIDToken
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &token))
+
+	// Get the key set and check the token signature
+	uri = router.GetRoute("GET", api.getOIDCJWKSHandler, nil)
+	require.NotEmpty(t, uri)
+	req = assets.NewRequest(t, "GET", uri, nil)
+	rec = httptest.NewRecorder()
+	router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+	var set jose.JSONWebKeySet
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &set))
+
+	object, err := jose.ParseSigned(token.Token)
+	require.NoError(t, err)
+	keys := set.Key(object.Signatures[0].Header.KeyID)
+	require.Len(t, keys, 1)
+
+	var claims sdk.JobIDTokenClaims
+	require.NoError(t, jws.Verify(keys[0].Key, token.Token, &claims))
+	require.Equal(t, "https://cds.local/api", claims.Issuer)
+	require.Equal(t, "sts.amazonaws.com", claims.Audience)
+	require.Equal(t, ctx.project.Key, claims.ProjectKey)
+	require.Equal(t, ctx.workflow.Name, claims.Workflow)
+	require.Equal(t, ctx.job.ID, claims.JobRunID)
+	require.Equal(t, token.ExpiresAt, claims.ExpiresAt)
+}
+
+func Test_getOIDCDiscoveryHandler(t *testing.T) {
+	api, _, router := newTestAPI(t)
+	api.Config.URL.API = "https://cds.local/api/"
+
+	uri := router.GetRoute("GET", api.getOIDCDiscoveryHandler, nil)
+	require.NotEmpty(t, uri)
+	req := assets.NewRequest(t, "GET", uri, nil)
+	rec := httptest.NewRecorder()
+	router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 404, rec.Code)
+
+	api.Config.Auth.JobIDToken.Enabled = true
+	rec = httptest.NewRecorder()
+	router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+
+	var doc sdk.OIDCDiscoveryDocument
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	require.Equal(t, "https://cds.local/api", doc.Issuer)
+	require.Equal(t, "https://cds.local/api/.well-known/jwks.json", doc.JWKSURI)
+}
@@ -30,6 +30,7 @@ type pipelineAction struct {
 	ActionID        int64     `db:"action_id"`
 	Args            *string   `db:"args"`
 	Enabled         bool      `db:"enabled"`
+	PriorityClass   string    `db:"priority_class"`
 	LastModified    time.Time `db:"last_modified"`
 }
 
@@ -22,6 +22,16 @@ func InsertKey(db gorpmapper.SqlExecutorWithTx, key *sdk.ProjectKey) error {
 	return nil
 }
 
+// UpdateKey updates a project key, the private key is encrypted again with the key name.
+func UpdateKey(ctx context.Context, db gorpmapper.SqlExecutorWithTx, key *sdk.ProjectKey) error {
+	var dbProjKey = dbProjectKey{ProjectKey: *key}
+	if err := gorpmapping.UpdateAndSign(ctx, db, &dbProjKey); err != nil {
+		return err
+	}
+	*key = dbProjKey.ProjectKey
+	return nil
+}
+
 func getAllKeys(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.ProjectKey, error) {
 	var res []dbProjectKey
 	keys := make([]sdk.ProjectKey, 0, len(res))
@@ -102,6 +112,34 @@ func LoadKey(ctx context.Context, db gorp.SqlExecutor, id int64, keyName string)
 	return &k.ProjectKey, nil
 }
 
+// LoadKeyByProjectIDAndName load the key with its private part given its project and its name
+func LoadKeyByProjectIDAndName(ctx context.Context, db gorp.SqlExecutor, projectID int64, keyName string) (*sdk.ProjectKey, error) {
+	query := gorpmapping.NewQuery(`
+	SELECT *
+	FROM project_key
+	WHERE project_id = $1
+	AND name = $2
+	AND builtin = false
+	`).Args(projectID, keyName)
+	var k dbProjectKey
+	found, err := gorpmapping.Get(ctx, db, query, &k, gorpmapping.GetOptions.WithDecryption)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	isValid, err := gorpmapping.CheckSignature(k, k.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if !isValid {
+		log.Error(ctx, "project.LoadKeyByProjectIDAndName> project key %d data corrupted", k.ID)
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &k.ProjectKey, nil
+}
+
 // DeleteProjectKey Delete the given key from the given project
 func DeleteProjectKey(db gorp.SqlExecutor, projectID int64, keyName string) error {
 	_, err := db.Exec("DELETE FROM project_key WHERE project_id = $1 AND name = $2", projectID, keyName)
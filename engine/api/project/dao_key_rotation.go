@@ -0,0 +1,131 @@
+package project
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// InsertKeyRotation inserts a key rotation.
+func InsertKeyRotation(db gorp.SqlExecutor, r *sdk.KeyRotation) error {
+	r.Created = time.Now()
+	dbR := dbKeyRotation(*r)
+	if err := gorpmapping.Insert(db, &dbR); err != nil {
+		return err
+	}
+	*r = sdk.KeyRotation(dbR)
+	return nil
+}
+
+// UpdateKeyRotation updates a key rotation.
+func UpdateKeyRotation(db gorp.SqlExecutor, r *sdk.KeyRotation) error {
+	dbR := dbKeyRotation(*r)
+	return gorpmapping.Update(db, &dbR)
+}
+
+func getKeyRotations(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.KeyRotation, error) {
+	var res []dbKeyRotation
+	if err := gorpmapping.GetAll(ctx, db, query, &res); err != nil {
+		return nil, err
+	}
+	rs := make([]sdk.KeyRotation, len(res))
+	for i := range res {
+		rs[i] = sdk.KeyRotation(res[i])
+	}
+	return rs, nil
+}
+
+// LoadKeyRotationsByProjectID returns the key rotations of a project and its applications, last first.
+func LoadKeyRotationsByProjectID(ctx context.Context, db gorp.SqlExecutor, projectID int64) ([]sdk.KeyRotation, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM key_rotation
+		WHERE project_id = $1
+		ORDER BY created DESC
+	`).Args(projectID)
+	return getKeyRotations(ctx, db, query)
+}
+
+// LoadExpiredKeyRotations returns the rotations in grace period that have expired.
+func LoadExpiredKeyRotations(ctx context.Context, db gorp.SqlExecutor) ([]sdk.KeyRotation, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM key_rotation
+		WHERE status = $1 AND expire_at < $2
+		ORDER BY expire_at
+	`).Args(sdk.KeyRotationStatusGrace, time.Now())
+	return getKeyRotations(ctx, db, query)
+}
+
+// LoadKeyRotationForUpdate locks a key rotation, returns ErrNotFound if it is locked or doesn't exist.
+func LoadKeyRotationForUpdate(ctx context.Context, db gorp.SqlExecutor, id int64) (*sdk.KeyRotation, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM key_rotation
+		WHERE id = $1
+		FOR UPDATE SKIP LOCKED
+	`).Args(id)
+	var r dbKeyRotation
+	found, err := gorpmapping.Get(ctx, db, query, &r)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	res := sdk.KeyRotation(r)
+	return &res, nil
+}
+
+// LoadKeyRotationPolicy returns the key rotation policy of a project.
+func LoadKeyRotationPolicy(ctx context.Context, db gorp.SqlExecutor, projectID int64) (*sdk.KeyRotationPolicy, error) {
+	query := gorpmapping.NewQuery(`SELECT * FROM key_rotation_policy WHERE project_id = $1`).Args(projectID)
+	var p dbKeyRotationPolicy
+	found, err := gorpmapping.Get(ctx, db, query, &p)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	res := sdk.KeyRotationPolicy(p)
+	return &res, nil
+}
+
+// LoadEnabledKeyRotationPolicies returns all enabled key rotation policies.
+func LoadEnabledKeyRotationPolicies(ctx context.Context, db gorp.SqlExecutor) ([]sdk.KeyRotationPolicy, error) {
+	query := gorpmapping.NewQuery(`SELECT * FROM key_rotation_policy WHERE enabled = true`)
+	var res []dbKeyRotationPolicy
+	if err := gorpmapping.GetAll(ctx, db, query, &res); err != nil {
+		return nil, err
+	}
+	ps := make([]sdk.KeyRotationPolicy, len(res))
+	for i := range res {
+		ps[i] = sdk.KeyRotationPolicy(res[i])
+	}
+	return ps, nil
+}
+
+// UpsertKeyRotationPolicy inserts or updates the key rotation policy of a project.
+func UpsertKeyRotationPolicy(ctx context.Context, db gorp.SqlExecutor, p *sdk.KeyRotationPolicy) error {
+	existing, err := LoadKeyRotationPolicy(ctx, db, p.ProjectID)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	if existing == nil {
+		p.Created = time.Now()
+		dbP := dbKeyRotationPolicy(*p)
+		return gorpmapping.Insert(db, &dbP)
+	}
+	// Enabling a policy resets the start of the rotation interval
+	p.Created = existing.Created
+	if p.Enabled && !existing.Enabled {
+		p.Created = time.Now()
+	}
+	dbP := dbKeyRotationPolicy(*p)
+	return gorpmapping.Update(db, &dbP)
+}
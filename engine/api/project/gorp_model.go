@@ -27,6 +27,10 @@ func (e dbProjectKey) Canonical() gorpmapper.CanonicalForms {
 
 type dbLabel sdk.Label
 
+type dbKeyRotation sdk.KeyRotation
+
+type dbKeyRotationPolicy sdk.KeyRotationPolicy
+
 type dbProjectVariable struct {
 	gorpmapper.SignedEntity
 	ID          int64  `db:"id"`
@@ -88,6 +92,8 @@ func init() {
 	gorpmapping.Register(gorpmapping.New(dbProjectVariableAudit{}, "project_variable_audit", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbProjectKey{}, "project_key", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbLabel{}, "project_label", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbKeyRotation{}, "key_rotation", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbKeyRotationPolicy{}, "key_rotation_policy", false, "project_id"))
 	gorpmapping.Register(gorpmapping.New(dbProjectVariable{}, "project_variable", true, "id"))
 }
 
@@ -56,6 +56,10 @@ func (api *API) deleteKeyInProjectHandler() service.Handler {
 				if err := project.DeleteProjectKey(tx, p.ID, keyName); err != nil {
 					return sdk.WrapError(err, "Cannot delete key %s", k.Name)
 				}
+				k.Private = "" // never store private key in audit trail
+				if err := auditLog(ctx, tx, sdk.AuditActionProjectKeyDelete, sdk.AuditTargetProjectKey, k.Name, p.Key, k, nil); err != nil {
+					return err
+				}
 				break
 			}
 		}
@@ -114,6 +118,12 @@ func (api *API) addKeyInProjectHandler() service.Handler {
 			return sdk.WrapError(err, "Cannot insert project key")
 		}
 
+		auditedKey := newKey
+		auditedKey.Private = "" // never store private key in audit trail
+		if err := auditLog(ctx, tx, sdk.AuditActionProjectKeyAdd, sdk.AuditTargetProjectKey, newKey.Name, p.Key, nil, auditedKey); err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
@@ -123,3 +133,125 @@ func (api *API) addKeyInProjectHandler() service.Handler {
 		return service.WriteJSON(w, newKey, http.StatusOK)
 	}
 }
+
+func (api *API) postRotateKeyInProjectHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		keyName := vars["name"]
+
+		var req sdk.KeyRotationRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if err := req.IsValid(); err != nil {
+			return err
+		}
+
+		p, err := project.Load(ctx, api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		rotation, err := api.rotateProjectKey(ctx, *p, keyName, req, getUserConsumer(ctx).GetUsername())
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, rotation, http.StatusOK)
+	}
+}
+
+func (api *API) getKeyRotationsInProjectHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		p, err := project.Load(ctx, api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		rotations, err := project.LoadKeyRotationsByProjectID(ctx, api.mustDB(), p.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, rotations, http.StatusOK)
+	}
+}
+
+func (api *API) getKeyRotationPolicyInProjectHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		p, err := project.Load(ctx, api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+
+		policy, err := project.LoadKeyRotationPolicy(ctx, api.mustDB(), p.ID)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if policy == nil {
+			policy = &sdk.KeyRotationPolicy{
+				ProjectID:   p.ID,
+				GracePeriod: sdk.DefaultKeyRotationGracePeriod,
+			}
+		}
+
+		return service.WriteJSON(w, policy, http.StatusOK)
+	}
+}
+
+func (api *API) putKeyRotationPolicyInProjectHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		var policy sdk.KeyRotationPolicy
+		if err := service.UnmarshalBody(r, &policy); err != nil {
+			return err
+		}
+		if err := policy.IsValid(); err != nil {
+			return err
+		}
+
+		p, err := project.Load(ctx, api.mustDB(), key)
+		if err != nil {
+			return err
+		}
+		policy.ProjectID = p.ID
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		old, err := project.LoadKeyRotationPolicy(ctx, tx, p.ID)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if err := project.UpsertKeyRotationPolicy(ctx, tx, &policy); err != nil {
+			return err
+		}
+		var before interface{}
+		if old != nil {
+			before = old
+		}
+		if err := auditLog(ctx, tx, sdk.AuditActionProjectKeyPolicy, sdk.AuditTargetProjectKey, p.Key, p.Key, before, policy); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		event.PublishUpdateKeyRotationPolicy(ctx, p.Key, policy, getUserConsumer(ctx))
+
+		return service.WriteJSON(w, policy, http.StatusOK)
+	}
+}
@@ -2,10 +2,12 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ovh/cds/engine/api/keys"
 	"github.com/ovh/cds/engine/api/project"
@@ -13,6 +15,7 @@ import (
 	"github.com/ovh/cds/engine/api/test/assets"
 	"github.com/ovh/cds/sdk"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_getKeysInProjectHandler(t *testing.T) {
@@ -142,3 +145,106 @@ func Test_addKeyInProjectHandler(t *testing.T) {
 
 	assert.Equal(t, proj.ID, key.ProjectID)
 }
+
+func Test_postRotateKeyInProjectHandler(t *testing.T) {
+	api, db, router := newTestAPI(t)
+
+	u, pass := assets.InsertAdminUser(t, db)
+
+	pkey := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, api.Cache, pkey, pkey)
+
+	k := &sdk.ProjectKey{
+		Name:      "proj-mykey",
+		ProjectID: proj.ID,
+	}
+	kssh, err := keys.GenerateSSHKey(k.Name)
+	require.NoError(t, err)
+	k.KeyID = kssh.KeyID
+	k.Public = kssh.Public
+	k.Private = kssh.Private
+	k.Type = kssh.Type
+	require.NoError(t, project.InsertKey(db, k))
+
+	vars := map[string]string{
+		"permProjectKey": proj.Key,
+		"name":           k.Name,
+	}
+	uri := router.GetRoute("POST", api.postRotateKeyInProjectHandler, vars)
+	req := assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, sdk.KeyRotationRequest{GracePeriod: 1})
+	w := httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+
+	var rotation sdk.KeyRotation
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rotation))
+	require.Equal(t, sdk.KeyRotationStatusGrace, rotation.Status)
+	require.Equal(t, k.Public, rotation.PreviousPublic)
+
+	ks, err := project.LoadAllKeys(context.TODO(), db, proj.ID)
+	require.NoError(t, err)
+	require.Len(t, ks, 2)
+
+	// A second rotation is refused during the grace period
+	req = assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, sdk.KeyRotationRequest{GracePeriod: 1})
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 409, w.Code)
+
+	require.NoError(t, api.retireKeyRotation(context.TODO(), rotation.ID))
+
+	ks, err = project.LoadAllKeys(context.TODO(), db, proj.ID)
+	require.NoError(t, err)
+	require.Len(t, ks, 1)
+	require.Equal(t, k.Name, ks[0].Name)
+	require.NotEqual(t, k.Public, ks[0].Public)
+
+	rotations, err := project.LoadKeyRotationsByProjectID(context.TODO(), db, proj.ID)
+	require.NoError(t, err)
+	require.Len(t, rotations, 1)
+	require.Equal(t, sdk.KeyRotationStatusRetired, rotations[0].Status)
+}
+
+func Test_applyKeyRotationPolicy(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	pkey := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, api.Cache, pkey, pkey)
+
+	k := &sdk.ProjectKey{
+		Name:      "proj-mykey",
+		ProjectID: proj.ID,
+	}
+	kssh, err := keys.GenerateSSHKey(k.Name)
+	require.NoError(t, err)
+	k.KeyID = kssh.KeyID
+	k.Public = kssh.Public
+	k.Private = kssh.Private
+	k.Type = kssh.Type
+	require.NoError(t, project.InsertKey(db, k))
+
+	policy := sdk.KeyRotationPolicy{
+		ProjectID:   proj.ID,
+		Enabled:     true,
+		Interval:    1,
+		GracePeriod: 1,
+	}
+	require.NoError(t, project.UpsertKeyRotationPolicy(context.TODO(), db, &policy))
+	policy.Created = policy.Created.Add(-48 * time.Hour)
+
+	// The routine context has no consumer
+	require.NoError(t, api.applyKeyRotationPolicy(context.TODO(), policy))
+
+	rotations, err := project.LoadKeyRotationsByProjectID(context.TODO(), db, proj.ID)
+	require.NoError(t, err)
+	require.Len(t, rotations, 1)
+	require.Equal(t, k.Name, rotations[0].KeyName)
+	require.Equal(t, keyRotationTriggeredByPolicy, rotations[0].TriggeredBy)
+	require.Equal(t, k.Public, rotations[0].PreviousPublic)
+
+	// Keys in grace period are not rotated again
+	require.NoError(t, api.applyKeyRotationPolicy(context.TODO(), policy))
+	rotations, err = project.LoadKeyRotationsByProjectID(context.TODO(), db, proj.ID)
+	require.NoError(t, err)
+	require.Len(t, rotations, 1)
+}
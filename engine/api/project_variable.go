@@ -186,6 +186,11 @@ func (api *API) updateVariableInProjectHandler() service.Handler {
 		if err != nil {
 			return err
 		}
+		if newVar.Type == sdk.SecretRefVariable {
+			if _, err := sdk.ParseSecretRef(newVar.Value); err != nil {
+				return err
+			}
+		}
 		if err := project.UpdateVariable(tx, p.ID, &newVar, previousVar, getUserConsumer(ctx)); err != nil {
 			return sdk.WrapError(err, "updateVariableInProject: Cannot update variable %s in project %s", varName, p.Name)
 		}
@@ -227,9 +232,14 @@ func (api *API) addVariableInProjectHandler() service.Handler {
 		}
 		defer tx.Rollback() // nolint
 
-		if !sdk.IsInArray(newVar.Type, sdk.AvailableVariableType) {
+		if !sdk.IsInArray(newVar.Type, sdk.AvailableVariableType) && newVar.Type != sdk.SecretRefVariable {
 			return sdk.WithStack(sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid variable type %s", newVar.Type))
 		}
+		if newVar.Type == sdk.SecretRefVariable {
+			if _, err := sdk.ParseSecretRef(newVar.Value); err != nil {
+				return err
+			}
+		}
 
 		if err := project.InsertVariable(tx, p.ID, &newVar, getUserConsumer(ctx)); err != nil {
 			return err
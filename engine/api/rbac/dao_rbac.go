@@ -17,6 +17,12 @@ func LoadRBACByName(ctx context.Context, db gorp.SqlExecutor, name string, opts
 	return get(ctx, db, gorpmapping.NewQuery(query).Args(name), opts...)
 }
 
+// LoadAll returns all the permissions.
+func LoadAll(ctx context.Context, db gorp.SqlExecutor, opts ...LoadOptionFunc) ([]sdk.RBAC, error) {
+	query := `SELECT * FROM rbac ORDER BY name`
+	return getAll(ctx, db, gorpmapping.NewQuery(query), opts...)
+}
+
 // Insert a RBAC permission in database
 func Insert(ctx context.Context, db gorpmapper.SqlExecutorWithTx, rb *sdk.RBAC) error {
 	if err := sdk.IsValidRBAC(rb); err != nil {
@@ -116,3 +122,29 @@ func get(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query, opts ...
 	r = rbacDB.RBAC
 	return &r, nil
 }
+
+func getAll(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query, opts ...LoadOptionFunc) ([]sdk.RBAC, error) {
+	var rbacsDB []rbac
+	if err := gorpmapping.GetAll(ctx, db, q, &rbacsDB); err != nil {
+		return nil, err
+	}
+	rs := make([]sdk.RBAC, 0, len(rbacsDB))
+	for i := range rbacsDB {
+		rbacDB := &rbacsDB[i]
+		isValid, err := gorpmapping.CheckSignature(*rbacDB, rbacDB.Signature)
+		if err != nil {
+			return nil, sdk.WrapError(err, "error when checking signature for rbac %s", rbacDB.ID)
+		}
+		if !isValid {
+			log.Error(ctx, "rbac.getAll> rbac %s (%s) data corrupted", rbacDB.Name, rbacDB.ID)
+			continue
+		}
+		for _, f := range opts {
+			if err := f(ctx, db, rbacDB); err != nil {
+				return nil, err
+			}
+		}
+		rs = append(rs, rbacDB.RBAC)
+	}
+	return rs, nil
+}
@@ -51,48 +51,92 @@ func loadAllRBACProjectKeys(ctx context.Context, db gorp.SqlExecutor, rbacProjec
 	return getAllRBACProjectKeys(ctx, db, query)
 }
 
+// HasRoleOnProjectAndUserID returns true if the user has the role on the whole project.
+// Permissions restricted to some repositories or entities are ignored.
 func HasRoleOnProjectAndUserID(ctx context.Context, db gorp.SqlExecutor, role string, userID string, projectKey string) (bool, error) {
-	ctx, next := telemetry.Span(ctx, "rbac.HasRoleOnProjectAndUserID")
+	return HasRoleOnProjectTargetAndUserID(ctx, db, role, userID, projectKey, "", "")
+}
+
+// HasRoleOnProjectTargetAndUserID returns true if the user has the role on given repository and entity of the project.
+// Empty repository or entity names only match permissions that are not restricted on it.
+func HasRoleOnProjectTargetAndUserID(ctx context.Context, db gorp.SqlExecutor, role string, userID string, projectKey string, repository, entity string) (bool, error) {
+	ctx, next := telemetry.Span(ctx, "rbac.HasRoleOnProjectTargetAndUserID")
 	defer next()
-	projectKeys, err := LoadProjectKeysByRoleAndUserID(ctx, db, role, userID)
-	if err != nil {
-		return false, err
-	}
-	return sdk.IsInArray(projectKey, projectKeys), nil
+
+	return hasRoleOnProjectAndUserID(ctx, db, role, userID, projectKey, func(rp rbacProject) bool {
+		return rp.MatchTarget(repository, entity)
+	})
 }
 
-func LoadProjectKeysByRoleAndUserID(ctx context.Context, db gorp.SqlExecutor, role string, userID string) ([]string, error) {
-	// Get rbac_project_groups
-	rbacProjectGroups, err := loadRBACProjectGroupsByUserID(ctx, db, userID)
+// HasRoleOnProjectRepositoryAndUserID returns true if the user has the role on at least some entities of given repository.
+func HasRoleOnProjectRepositoryAndUserID(ctx context.Context, db gorp.SqlExecutor, role string, userID string, projectKey string, repository string) (bool, error) {
+	ctx, next := telemetry.Span(ctx, "rbac.HasRoleOnProjectRepositoryAndUserID")
+	defer next()
+
+	return hasRoleOnProjectAndUserID(ctx, db, role, userID, projectKey, func(rp rbacProject) bool {
+		return rp.MatchRepository(repository)
+	})
+}
+
+func hasRoleOnProjectAndUserID(ctx context.Context, db gorp.SqlExecutor, role string, userID string, projectKey string, match func(rbacProject) bool) (bool, error) {
+	rbacProjects, err := loadRBACProjectsByRoleAndUserID(ctx, db, role, userID)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
-	// Get rbac_project_users
-	rbacProjectUsers, err := loadRBACProjectUsersByUserID(ctx, db, userID)
+	rbacProjectIDs := make([]int64, 0, len(rbacProjects))
+	for _, rp := range rbacProjects {
+		if match(rp) {
+			rbacProjectIDs = append(rbacProjectIDs, rp.ID)
+		}
+	}
+	if len(rbacProjectIDs) == 0 {
+		return false, nil
+	}
+	rbacProjectKeys, err := loadAllRBACProjectKeys(ctx, db, rbacProjectIDs)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
-
-	// Deduplicate rbac_project.id
-	rbacProjectIDs := make(sdk.Int64Slice, 0)
-	for _, rpg := range rbacProjectGroups {
-		rbacProjectIDs = append(rbacProjectIDs, rpg.RbacProjectID)
+	for _, rpk := range rbacProjectKeys {
+		if rpk.ProjectKey == projectKey {
+			return true, nil
+		}
 	}
-	for _, rpu := range rbacProjectUsers {
-		rbacProjectIDs = append(rbacProjectIDs, rpu.RbacProjectID)
+	return false, nil
+}
+
+// HasOneRoleOnProjectTargetAndUserID returns the first of given roles that the user has on given repository and entity
+// of the project, or an empty string if he has none of them.
+func HasOneRoleOnProjectTargetAndUserID(ctx context.Context, db gorp.SqlExecutor, userID string, projectKey string, repository, entity string, roles ...string) (string, error) {
+	for _, role := range roles {
+		hasRole, err := HasRoleOnProjectTargetAndUserID(ctx, db, role, userID, projectKey, repository, entity)
+		if err != nil {
+			return "", err
+		}
+		if hasRole {
+			return role, nil
+		}
 	}
-	rbacProjectIDs.Unique()
+	return "", nil
+}
 
+// LoadProjectKeysByRoleAndUserID returns the keys of the projects on which the user has the role.
+// Permissions restricted to some repositories or entities are ignored.
+func LoadProjectKeysByRoleAndUserID(ctx context.Context, db gorp.SqlExecutor, role string, userID string) ([]string, error) {
 	// Get rbac_project
-	rbacProjects, err := loadRBACProjectsByRoleAndIDs(ctx, db, role, rbacProjectIDs)
+	rbacProjects, err := loadRBACProjectsByRoleAndUserID(ctx, db, role, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get rbac_project_keys
-	rbacProjectIDs = make([]int64, 0, len(rbacProjects))
+	rbacProjectIDs := make([]int64, 0, len(rbacProjects))
 	for _, rp := range rbacProjects {
-		rbacProjectIDs = append(rbacProjectIDs, rp.ID)
+		if rp.MatchTarget("", "") {
+			rbacProjectIDs = append(rbacProjectIDs, rp.ID)
+		}
+	}
+	if len(rbacProjectIDs) == 0 {
+		return []string{}, nil
 	}
 	rbacProjectKeys, err := loadAllRBACProjectKeys(ctx, db, rbacProjectIDs)
 	if err != nil {
@@ -110,3 +154,28 @@ func LoadProjectKeysByRoleAndUserID(ctx context.Context, db gorp.SqlExecutor, ro
 	}
 	return projectKeys, nil
 }
+
+func loadRBACProjectsByRoleAndUserID(ctx context.Context, db gorp.SqlExecutor, role string, userID string) ([]rbacProject, error) {
+	// Get rbac_project_groups
+	rbacProjectGroups, err := loadRBACProjectGroupsByUserID(ctx, db, userID)
+	if err != nil {
+		return nil, err
+	}
+	// Get rbac_project_users
+	rbacProjectUsers, err := loadRBACProjectUsersByUserID(ctx, db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deduplicate rbac_project.id
+	rbacProjectIDs := make(sdk.Int64Slice, 0)
+	for _, rpg := range rbacProjectGroups {
+		rbacProjectIDs = append(rbacProjectIDs, rpg.RbacProjectID)
+	}
+	for _, rpu := range rbacProjectUsers {
+		rbacProjectIDs = append(rbacProjectIDs, rpu.RbacProjectID)
+	}
+	rbacProjectIDs.Unique()
+
+	return loadRBACProjectsByRoleAndIDs(ctx, db, role, rbacProjectIDs)
+}
@@ -200,3 +200,62 @@ projects:
 	require.Equal(t, "manage", rbacDBUpdate.Projects[0].Role)
 
 }
+
+func TestHasRoleOnProjectTarget(t *testing.T) {
+	db, cache := test.SetupPG(t)
+
+	_, err := db.Exec("DELETE FROM rbac")
+	require.NoError(t, err)
+
+	key1 := sdk.RandomString(10)
+	proj1 := assets.InsertTestProject(t, db, cache, key1, key1)
+
+	user1, _ := assets.InsertLambdaUser(t, db)
+
+	perm := fmt.Sprintf(`name: perm-test
+projects:
+  - role: manage-vcs
+    projects: [%s]
+    users: [%s]
+  - role: manage-worker-model
+    projects: [%s]
+    users: [%s]
+    repositories: [my-org/*]
+    entities: [docker-*]
+`, proj1.Key, user1.Username, proj1.Key, user1.Username)
+
+	var r sdk.RBAC
+	require.NoError(t, yaml.Unmarshal([]byte(perm), &r))
+	require.NoError(t, rbac.FillWithIDs(context.TODO(), db, &r))
+	require.NoError(t, rbac.Insert(context.Background(), db, &r))
+
+	has, err := rbac.HasRoleOnProjectAndUserID(context.TODO(), db, sdk.ProjectRoleManageVCS, user1.ID, proj1.Key)
+	require.NoError(t, err)
+	require.True(t, has)
+
+	// Restricted permission doesn't apply on the whole project
+	has, err = rbac.HasRoleOnProjectAndUserID(context.TODO(), db, sdk.ProjectRoleManageWorkerModel, user1.ID, proj1.Key)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	has, err = rbac.HasRoleOnProjectTargetAndUserID(context.TODO(), db, sdk.ProjectRoleManageWorkerModel, user1.ID, proj1.Key, "my-org/my-repo", "docker-debian")
+	require.NoError(t, err)
+	require.True(t, has)
+
+	has, err = rbac.HasRoleOnProjectTargetAndUserID(context.TODO(), db, sdk.ProjectRoleManageWorkerModel, user1.ID, proj1.Key, "other-org/my-repo", "docker-debian")
+	require.NoError(t, err)
+	require.False(t, has)
+
+	has, err = rbac.HasRoleOnProjectRepositoryAndUserID(context.TODO(), db, sdk.ProjectRoleManageWorkerModel, user1.ID, proj1.Key, "my-org/my-repo")
+	require.NoError(t, err)
+	require.True(t, has)
+
+	role, err := rbac.HasOneRoleOnProjectTargetAndUserID(context.TODO(), db, user1.ID, proj1.Key, "my-org/my-repo", "docker-debian", sdk.ProjectRoleManage, sdk.ProjectRoleManageWorkerModel)
+	require.NoError(t, err)
+	require.Equal(t, sdk.ProjectRoleManageWorkerModel, role)
+
+	// Restricted permission doesn't give access to the project
+	keys, err := rbac.LoadProjectKeysByRoleAndUserID(context.TODO(), db, sdk.ProjectRoleManageWorkerModel, user1.ID)
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
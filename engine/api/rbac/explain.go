@@ -0,0 +1,139 @@
+package rbac
+
+import (
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// ExplainSubject is the user for whom permissions are explained.
+type ExplainSubject struct {
+	UserID         string
+	Groups         []sdk.Group
+	OrganizationID string
+}
+
+// ExplainTarget is the resource of a requirement with resolved identifiers.
+type ExplainTarget struct {
+	ProjectKey string
+	Repository string
+	Entity     string
+	RegionID   string
+	HatcheryID string
+}
+
+// Explain evaluates the requirement against given permissions and fills its grants. A permission named
+// whatIf is flagged as simulated.
+func Explain(rbacs []sdk.RBAC, whatIf string, s ExplainSubject, t ExplainTarget, req *sdk.RBACExplainRequirement) {
+	req.Allowed = false
+	req.Grants = nil
+	for _, r := range rbacs {
+		for _, role := range req.Roles {
+			var grants []sdk.RBACExplainGrant
+			switch req.Type {
+			case sdk.RBACTypeGlobal:
+				grants = explainGlobal(r, role, s)
+			case sdk.RBACTypeProject:
+				grants = explainProject(r, role, s, t)
+			case sdk.RBACTypeRegion:
+				grants = explainRegion(r, role, s, t)
+			case sdk.RBACTypeHatchery:
+				grants = explainHatchery(r, role, t)
+			}
+			for i := range grants {
+				grants[i].RBAC = r.Name
+				grants[i].Role = role
+				grants[i].WhatIf = whatIf != "" && r.Name == whatIf
+				req.Allowed = req.Allowed || grants[i].Granted
+			}
+			req.Grants = append(req.Grants, grants...)
+		}
+	}
+}
+
+func explainSubject(s ExplainSubject, userIDs []string, groupIDs []int64) []string {
+	var via []string
+	for _, id := range userIDs {
+		if id == s.UserID {
+			via = append(via, "user")
+		}
+	}
+	for _, id := range groupIDs {
+		for _, g := range s.Groups {
+			if g.ID == id {
+				via = append(via, "group:"+g.Name)
+			}
+		}
+	}
+	return via
+}
+
+func newExplainGrant(via []string) sdk.RBACExplainGrant {
+	g := sdk.RBACExplainGrant{Via: via, Granted: len(via) > 0}
+	if !g.Granted {
+		g.Reason = "user is not in permission users or groups"
+	}
+	return g
+}
+
+func explainGlobal(r sdk.RBAC, role string, s ExplainSubject) []sdk.RBACExplainGrant {
+	var res []sdk.RBACExplainGrant
+	for _, g := range r.Globals {
+		if g.Role == role {
+			res = append(res, newExplainGrant(explainSubject(s, g.RBACUsersIDs, g.RBACGroupsIDs)))
+		}
+	}
+	return res
+}
+
+func explainProject(r sdk.RBAC, role string, s ExplainSubject, t ExplainTarget) []sdk.RBACExplainGrant {
+	var res []sdk.RBACExplainGrant
+	for _, p := range r.Projects {
+		if p.Role != role || !sdk.IsInArray(t.ProjectKey, p.RBACProjectKeys) {
+			continue
+		}
+		g := newExplainGrant(explainSubject(s, p.RBACUsersIDs, p.RBACGroupsIDs))
+		if !p.MatchTarget(t.Repository, t.Entity) {
+			g.Granted = false
+			g.Reason = fmt.Sprintf("permission is restricted to repositories %v and entities %v", p.RBACRepositories, p.RBACEntities)
+		}
+		res = append(res, g)
+	}
+	return res
+}
+
+func explainRegion(r sdk.RBAC, role string, s ExplainSubject, t ExplainTarget) []sdk.RBACExplainGrant {
+	var res []sdk.RBACExplainGrant
+	for _, rg := range r.Regions {
+		if rg.Role != role || rg.RegionID != t.RegionID {
+			continue
+		}
+		via := explainSubject(s, rg.RBACUsersIDs, rg.RBACGroupsIDs)
+		if rg.AllUsers {
+			via = append(via, "all users")
+		}
+		g := newExplainGrant(via)
+		if g.Granted && !sdk.IsInArray(s.OrganizationID, rg.RBACOrganizationIDs) {
+			g.Granted = false
+			g.Reason = "user organization is not allowed"
+		}
+		res = append(res, g)
+	}
+	return res
+}
+
+func explainHatchery(r sdk.RBAC, role string, t ExplainTarget) []sdk.RBACExplainGrant {
+	var res []sdk.RBACExplainGrant
+	for _, h := range r.Hatcheries {
+		if h.Role != role || h.HatcheryID != t.HatcheryID {
+			continue
+		}
+		g := sdk.RBACExplainGrant{Granted: true, Via: []string{"hatchery"}}
+		if t.RegionID != "" && h.RegionID != t.RegionID {
+			g.Granted = false
+			g.Reason = "hatchery is allowed on another region"
+		}
+		res = append(res, g)
+	}
+	return res
+}
@@ -0,0 +1,58 @@
+package rbac_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/rbac"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestExplain(t *testing.T) {
+	rbacs := []sdk.RBAC{
+		{
+			Name: "perm-groups",
+			Projects: []sdk.RBACProject{
+				{Role: sdk.ProjectRoleRead, RBACProjectKeys: []string{"PROJ"}, RBACGroupsIDs: []int64{1}},
+				{Role: sdk.ProjectRoleManageVCS, RBACProjectKeys: []string{"PROJ"}, RBACUsersIDs: []string{"u1"}, RBACRepositories: []string{"my-org/*"}},
+			},
+			Regions: []sdk.RBACRegion{
+				{Role: sdk.RegionRoleExecute, RegionID: "r1", AllUsers: true, RBACOrganizationIDs: []string{"o2"}},
+			},
+		},
+		{
+			Name: "perm-other",
+			Projects: []sdk.RBACProject{
+				{Role: sdk.ProjectRoleRead, RBACProjectKeys: []string{"PROJ"}, RBACUsersIDs: []string{"u2"}},
+			},
+		},
+	}
+	s := rbac.ExplainSubject{UserID: "u1", Groups: []sdk.Group{{ID: 1, Name: "grp1"}}, OrganizationID: "o1"}
+
+	req := sdk.RBACExplainRequirement{Type: sdk.RBACTypeProject, Roles: []string{sdk.ProjectRoleRead}}
+	rbac.Explain(rbacs, "", s, rbac.ExplainTarget{ProjectKey: "PROJ"}, &req)
+	require.True(t, req.Allowed)
+	require.Len(t, req.Grants, 2)
+	require.Equal(t, "perm-groups", req.Grants[0].RBAC)
+	require.True(t, req.Grants[0].Granted)
+	require.Equal(t, []string{"group:grp1"}, req.Grants[0].Via)
+	require.Equal(t, "perm-other", req.Grants[1].RBAC)
+	require.False(t, req.Grants[1].Granted)
+
+	req = sdk.RBACExplainRequirement{Type: sdk.RBACTypeProject, Roles: []string{sdk.ProjectRoleManageVCS}}
+	rbac.Explain(rbacs, "", s, rbac.ExplainTarget{ProjectKey: "PROJ", Repository: "other-org/repo"}, &req)
+	require.False(t, req.Allowed)
+	require.Len(t, req.Grants, 1)
+	require.NotEmpty(t, req.Grants[0].Reason)
+
+	rbac.Explain(rbacs, "perm-groups", s, rbac.ExplainTarget{ProjectKey: "PROJ", Repository: "my-org/repo"}, &req)
+	require.True(t, req.Allowed)
+	require.True(t, req.Grants[0].WhatIf)
+
+	req = sdk.RBACExplainRequirement{Type: sdk.RBACTypeRegion, Roles: []string{sdk.RegionRoleExecute}}
+	rbac.Explain(rbacs, "", s, rbac.ExplainTarget{RegionID: "r1"}, &req)
+	require.False(t, req.Allowed)
+	require.Equal(t, []string{"all users"}, req.Grants[0].Via)
+	require.Equal(t, "user organization is not allowed", req.Grants[0].Reason)
+}
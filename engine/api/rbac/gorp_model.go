@@ -68,8 +68,9 @@ type rbacProject struct {
 }
 
 func (rp rbacProject) Canonical() gorpmapper.CanonicalForms {
-	_ = []interface{}{rp.ID, rp.RbacID, rp.Role, rp.All}
+	_ = []interface{}{rp.ID, rp.RbacID, rp.Role, rp.All, rp.RBACRepositories, rp.RBACEntities}
 	return []gorpmapper.CanonicalForm{
+		"{{.ID}}{{.RbacID}}{{.Role}}{{.All}}{{.RBACRepositories}}{{.RBACEntities}}",
 		"{{.ID}}{{.RbacID}}{{.Role}}{{.All}}",
 	}
 }
@@ -254,7 +254,21 @@ func deprecatedAuthorizedClient(ctx context.Context, db gorpmapper.SqlExecutorWi
 	return vcs, nil
 }
 
+type contextKey string
+
+const contextKeyPriority contextKey = "vcs-priority"
+
+// ContextWithBackgroundPriority returns a context for calls to the repository managers that are not awaited by a user
+// (polling, repository analysis). They are served after the interactive calls when the budget of a repository manager
+// is low.
+func ContextWithBackgroundPriority(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKeyPriority, sdk.VCSPriorityBackground)
+}
+
 func (c *vcsClient) setAuthHeader(ctx context.Context, req *http.Request) {
+	if priority, ok := ctx.Value(contextKeyPriority).(string); ok {
+		req.Header.Set(sdk.HeaderXVCSPriority, priority)
+	}
 	if c.vcsProject != nil {
 		log.Debug(ctx, "requesting vcs via vcs project")
 		req.Header.Set(sdk.HeaderXVCSType, base64.StdEncoding.EncodeToString([]byte(c.vcsProject.Type)))
@@ -291,6 +305,8 @@ func (c *vcsClient) doStreamRequest(ctx context.Context, method, path string, in
 			err = sdk.NewError(sdk.ErrNotFound, err)
 		case http.StatusForbidden:
 			err = sdk.NewError(sdk.ErrForbidden, err)
+		case http.StatusTooManyRequests:
+			err = sdk.NewError(sdk.ErrRateLimitReached, err)
 		default:
 			err = sdk.NewError(sdk.ErrUnknownError, err)
 		}
@@ -320,6 +336,8 @@ func (c *vcsClient) doJSONRequest(ctx context.Context, method, path string, in i
 			err = sdk.NewError(sdk.ErrNotFound, err)
 		case http.StatusForbidden:
 			err = sdk.NewError(sdk.ErrForbidden, err)
+		case http.StatusTooManyRequests:
+			err = sdk.NewError(sdk.ErrRateLimitReached, err)
 		default:
 			err = sdk.NewError(sdk.ErrUnknownError, err)
 		}
@@ -478,6 +496,15 @@ func (c *vcsClient) CommitsBetweenRefs(ctx context.Context, fullname, base, head
 	return commits, nil
 }
 
+func (c *vcsClient) ChangedFiles(ctx context.Context, fullname, base, head string) ([]string, error) {
+	var files []string
+	path := fmt.Sprintf("/vcs/%s/repos/%s/changes?base=%s&head=%s", c.name, fullname, url.QueryEscape(base), url.QueryEscape(head))
+	if _, err := c.doJSONRequest(ctx, "GET", path, nil, &files); err != nil {
+		return nil, sdk.NewErrorFrom(err, "unable to get changed files on repository %s from %s", fullname, c.name)
+	}
+	return files, nil
+}
+
 func (c *vcsClient) Commit(ctx context.Context, fullname, hash string) (sdk.VCSCommit, error) {
 	commit := sdk.VCSCommit{}
 	path := fmt.Sprintf("/vcs/%s/repos/%s/commits/%s", c.name, fullname, hash)
@@ -532,6 +559,14 @@ func (c *vcsClient) PullRequestCreate(ctx context.Context, fullname string, pr s
 	return pr, nil
 }
 
+func (c *vcsClient) PullRequestMerge(ctx context.Context, fullname string, id string, req sdk.VCSPullRequestMergeRequest) error {
+	path := fmt.Sprintf("/vcs/%s/repos/%s/pullrequests/%s/merge", c.name, fullname, id)
+	if _, err := c.doJSONRequest(ctx, "POST", path, req, nil); err != nil {
+		return sdk.NewErrorFrom(err, "unable to merge pullrequest %s on repository %s from %s", id, fullname, c.name)
+	}
+	return nil
+}
+
 func (c *vcsClient) CreateHook(ctx context.Context, fullname string, hook *sdk.VCSHook) error {
 	path := fmt.Sprintf("/vcs/%s/repos/%s/hooks", c.name, fullname)
 	if _, err := c.doJSONRequest(ctx, "POST", path, hook, hook); err != nil {
@@ -565,6 +600,21 @@ func (c *vcsClient) DeleteHook(ctx context.Context, fullname string, hook sdk.VC
 	return sdk.NewErrorFrom(err, "unable to delete hook on repository %s from %s", fullname, c.name)
 }
 
+func (c *vcsClient) CreateDeployKey(ctx context.Context, fullname string, key sdk.VCSDeployKey) (sdk.VCSDeployKey, error) {
+	var res sdk.VCSDeployKey
+	path := fmt.Sprintf("/vcs/%s/repos/%s/keys", c.name, fullname)
+	if _, err := c.doJSONRequest(ctx, "POST", path, key, &res); err != nil {
+		return res, sdk.NewErrorFrom(err, "unable to create deploy key on repository %s from %s", fullname, c.name)
+	}
+	return res, nil
+}
+
+func (c *vcsClient) DeleteDeployKey(ctx context.Context, fullname string, key sdk.VCSDeployKey) error {
+	path := fmt.Sprintf("/vcs/%s/repos/%s/keys/delete", c.name, fullname)
+	_, err := c.doJSONRequest(ctx, "POST", path, key, nil)
+	return sdk.NewErrorFrom(err, "unable to delete deploy key on repository %s from %s", fullname, c.name)
+}
+
 func (c *vcsClient) GetEvents(ctx context.Context, fullname string, dateRef time.Time) ([]interface{}, time.Duration, error) {
 	res := struct {
 		Events []interface{} `json:"events"`
@@ -819,6 +869,8 @@ func GetPollingInfos(ctx context.Context, c sdk.VCSAuthorizedClientService, prj
 			res.PollingSupported = true
 		case client.vcsProject.Type == "gitlab":
 			res.PollingSupported = false
+		case client.vcsProject.Type == sdk.VCSTypeGit:
+			res.PollingSupported = true
 		}
 
 		return res, nil
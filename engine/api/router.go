@@ -323,6 +323,11 @@ func (r *Router) handle(uri string, scope HandlerScope, handlers ...*service.Han
 			return
 		}
 
+		writeError := service.WriteError
+		if rc.OverrideErrorWriter != nil {
+			writeError = rc.OverrideErrorWriter
+		}
+
 		// Make the request context inherit from the context of the router
 		tags := telemetry.ContextGetTags(r.Background, telemetry.TagServiceType, telemetry.TagServiceName)
 		ctx, err = tag.New(ctx, tags...)
@@ -404,7 +409,7 @@ func (r *Router) handle(uri string, scope HandlerScope, handlers ...*service.Han
 			ctx, err = m(ctx, responseWriter, req, rc)
 			if err != nil {
 				telemetry.Record(r.Background, Errors, 1)
-				service.WriteError(ctx, responseWriter, req, err)
+				writeError(ctx, responseWriter, req, err)
 				deferFunc(ctx)
 				return
 			}
@@ -419,7 +424,7 @@ func (r *Router) handle(uri string, scope HandlerScope, handlers ...*service.Han
 			ctx, err = authMiddleware(ctx, responseWriter, req, rc)
 			if err != nil {
 				telemetry.Record(r.Background, Errors, 1)
-				service.WriteError(ctx, responseWriter, req, err)
+				writeError(ctx, responseWriter, req, err)
 				deferFunc(ctx)
 				return
 			}
@@ -430,7 +435,7 @@ func (r *Router) handle(uri string, scope HandlerScope, handlers ...*service.Han
 			ctx, err = m(ctx, responseWriter, req, rc)
 			if err != nil {
 				telemetry.Record(r.Background, Errors, 1)
-				service.WriteError(ctx, responseWriter, req, err)
+				writeError(ctx, responseWriter, req, err)
 				deferFunc(ctx)
 				return
 			}
@@ -442,7 +447,7 @@ func (r *Router) handle(uri string, scope HandlerScope, handlers ...*service.Han
 		if err := rc.Handler(ctx, responseWriter.wrappedResponseWriter(), req); err != nil {
 			telemetry.Record(r.Background, Errors, 1)
 			telemetry.End(ctx, responseWriter, req) // nolint
-			service.WriteError(ctx, responseWriter, req, err)
+			writeError(ctx, responseWriter, req, err)
 			end()
 			deferFunc(ctx)
 			return
@@ -568,6 +573,18 @@ func (r *Router) PUT(h service.HandlerFunc, cfg ...service.HandlerConfigParam) *
 	return &rc
 }
 
+// PATCH will set given handler only for PATCH request
+func (r *Router) PATCH(h service.HandlerFunc, cfg ...service.HandlerConfigParam) *service.HandlerConfig {
+	var rc service.HandlerConfig
+	rc.Handler = h()
+	rc.Method = "PATCH"
+	rc.PermissionLevel = sdk.PermissionReadWriteExecute
+	for _, c := range cfg {
+		c(&rc)
+	}
+	return &rc
+}
+
 // DELETE will set given handler only for DELETE request
 func (r *Router) DELETE(h service.HandlerFunc, cfg ...service.HandlerConfigParam) *service.HandlerConfig {
 	var rc service.HandlerConfig
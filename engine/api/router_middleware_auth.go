@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"net/http"
+	"strings"
 	"time"
 
 	jwt "github.com/golang-jwt/jwt"
@@ -261,6 +262,16 @@ func (api *API) handleAuthMiddlewareUserConsumer(ctx context.Context, w http.Res
 		}
 	}
 
+	// Check that consumer restrictions allow current request
+	var forwardedFor string
+	if api.Router.Config.HeaderXForwardedFor != "" {
+		forwardedFor = req.Header.Get(api.Router.Config.HeaderXForwardedFor)
+	}
+	clientIP := sdk.RequestClientIP(req.RemoteAddr, forwardedFor, api.Router.Config.TrustedProxies)
+	if err := api.checkConsumerRestrictions(ctx, consumer, clientIP, mux.Vars(req), rc); err != nil {
+		return ctx, err
+	}
+
 	// Check that permission are valid for current route and consumer
 	if err := api.checkPermission(ctx, w, mux.Vars(req), rc.PermissionLevel); err != nil {
 		return ctx, err
@@ -269,6 +280,72 @@ func (api *API) handleAuthMiddlewareUserConsumer(ctx context.Context, w http.Res
 	return ctx, nil
 }
 
+// checkConsumerRestrictions returns an error if the consumer is not allowed from given client IP address or if the route
+// targets a project or a workflow that is not in consumer restrictions.
+func (api *API) checkConsumerRestrictions(ctx context.Context, consumer *sdk.AuthUserConsumer, clientIP string, vars map[string]string, rc *service.HandlerConfig) error {
+	restrictions := consumer.AuthConsumerUser.Restrictions
+	if !restrictions.IsIPAllowed(clientIP) {
+		return sdk.WrapError(sdk.ErrUnauthorized, "consumer (%s) is not allowed from address %s", consumer.ID, clientIP)
+	}
+	if !restrictions.IsRestricted() {
+		return nil
+	}
+
+	isRead := rc.Method == http.MethodGet
+	var projectKey string
+	for _, k := range []string{"permProjectKey", "permProjectKeyWithHooksAllowed", "key", "projectKey"} {
+		if vars[k] != "" {
+			projectKey = vars[k]
+			break
+		}
+	}
+	var workflowName string
+	for _, k := range []string{"permWorkflowName", "permWorkflowNameAdvanced", "workflowName"} {
+		if vars[k] != "" {
+			workflowName = vars[k]
+			break
+		}
+	}
+
+	// Routes that don't target a project can only be read, except signout
+	if projectKey == "" {
+		if isRead || strings.HasSuffix(rc.CleanURL, "/auth/consumer/signout") {
+			return nil
+		}
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "consumer is restricted to some projects")
+	}
+
+	r := restrictions.ProjectRestriction(projectKey)
+	if r == nil {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "consumer is not allowed on project %s", projectKey)
+	}
+	if workflowName != "" && !r.MatchWorkflow(workflowName) {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "consumer is not allowed on workflow %s/%s", projectKey, workflowName)
+	}
+	if isRead {
+		return nil
+	}
+	if workflowName == "" && len(r.Workflows) > 0 {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "consumer is restricted to some workflows of project %s", projectKey)
+	}
+	if r.ReadOnly {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "consumer is read only on project %s", projectKey)
+	}
+	if r.RunOnly {
+		// v1 run routes requires the execute permission, v2 run routes are checked with projectTriggerRun
+		isRun := rc.PermissionLevel == sdk.PermissionReadExecute
+		for _, c := range rc.RbacCheckers {
+			if sdk.GetFuncName(c) == sdk.GetFuncName(api.projectTriggerRun) {
+				isRun = true
+			}
+		}
+		if !isRun {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "consumer can only manage runs on project %s", projectKey)
+		}
+	}
+	return nil
+}
+
 func (api *API) xsrfMiddleware(ctx context.Context, w http.ResponseWriter, req *http.Request, rc *service.HandlerConfig) (context.Context, error) {
 	ctx, end := telemetry.Span(ctx, "router.xsrfMiddleware")
 	defer end()
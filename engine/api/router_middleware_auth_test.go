@@ -395,3 +395,41 @@ func Test_authMiddlewareWithServiceOrWorker(t *testing.T) {
 	_, err = api.authMaintainerMiddleware(ctx, w, req, config)
 	require.Error(t, err, "an error should be returned because the consumer is linked to a worker")
 }
+
+func Test_checkConsumerRestrictions(t *testing.T) {
+	api := &API{}
+	consumer := &sdk.AuthUserConsumer{
+		AuthConsumerUser: sdk.AuthUserConsumerData{
+			Restrictions: sdk.AuthConsumerRestrictions{
+				AllowedCIDRs: []string{"10.0.0.0/8"},
+				Projects: []sdk.AuthConsumerProjectRestriction{
+					{ProjectKey: "PROJ1", Workflows: []string{"deploy-*"}, RunOnly: true},
+					{ProjectKey: "PROJ2", ReadOnly: true},
+				},
+			},
+		},
+	}
+	ctx := context.TODO()
+	clientIP := "10.1.2.3"
+
+	get := &service.HandlerConfig{Method: http.MethodGet, PermissionLevel: sdk.PermissionRead}
+	run := &service.HandlerConfig{Method: http.MethodPost, PermissionLevel: sdk.PermissionReadExecute}
+	edit := &service.HandlerConfig{Method: http.MethodPut, PermissionLevel: sdk.PermissionReadWriteExecute}
+	runV2 := &service.HandlerConfig{Method: http.MethodPost, RbacCheckers: service.RBAC(api.projectTriggerRun)}
+	editV2 := &service.HandlerConfig{Method: http.MethodPost, RbacCheckers: service.RBAC(api.projectManageSecret)}
+
+	require.NoError(t, api.checkConsumerRestrictions(ctx, consumer, clientIP, nil, get))
+	require.Error(t, api.checkConsumerRestrictions(ctx, consumer, clientIP, nil, edit))
+	require.NoError(t, api.checkConsumerRestrictions(ctx, consumer, clientIP, map[string]string{"key": "PROJ1", "permWorkflowNameAdvanced": "deploy-prod"}, run))
+	require.Error(t, api.checkConsumerRestrictions(ctx, consumer, clientIP, map[string]string{"key": "PROJ1", "permWorkflowNameAdvanced": "deploy-prod"}, edit))
+	require.Error(t, api.checkConsumerRestrictions(ctx, consumer, clientIP, map[string]string{"key": "PROJ1", "permWorkflowNameAdvanced": "build"}, get))
+	require.NoError(t, api.checkConsumerRestrictions(ctx, consumer, clientIP, map[string]string{"projectKey": "PROJ1", "workflowName": "deploy-prod"}, runV2))
+	require.Error(t, api.checkConsumerRestrictions(ctx, consumer, clientIP, map[string]string{"projectKey": "PROJ1", "workflowName": "deploy-prod"}, editV2))
+	require.Error(t, api.checkConsumerRestrictions(ctx, consumer, clientIP, map[string]string{"permProjectKey": "PROJ1"}, run))
+	require.NoError(t, api.checkConsumerRestrictions(ctx, consumer, clientIP, map[string]string{"permProjectKey": "PROJ2"}, get))
+	require.Error(t, api.checkConsumerRestrictions(ctx, consumer, clientIP, map[string]string{"permProjectKey": "PROJ2"}, run))
+	require.Error(t, api.checkConsumerRestrictions(ctx, consumer, clientIP, map[string]string{"projectKey": "PROJ3"}, get))
+
+	clientIP = "192.168.1.1"
+	require.Error(t, api.checkConsumerRestrictions(ctx, consumer, clientIP, nil, get))
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"net/url"
 
 	"github.com/go-gorp/gorp"
 	"github.com/rockbears/log"
@@ -32,6 +33,80 @@ func hasRoleOnProject(ctx context.Context, auth *sdk.AuthUserConsumer, store cac
 	return nil
 }
 
+// hasOneRoleOnProjectTarget returns nil if the consumer has one of the given roles on the project's repository and entity.
+func hasOneRoleOnProjectTarget(ctx context.Context, auth *sdk.AuthUserConsumer, db gorp.SqlExecutor, projectKey, repository, entity string, roles ...string) error {
+	if auth == nil {
+		return sdk.WithStack(sdk.ErrForbidden)
+	}
+
+	role, err := rbac.HasOneRoleOnProjectTargetAndUserID(ctx, db, auth.AuthConsumerUser.AuthentifiedUser.ID, projectKey, repository, entity, roles...)
+	if err != nil {
+		return err
+	}
+	if role == "" {
+		ctx = context.WithValue(ctx, cdslog.RbacRole, roles[0])
+		log.Info(ctx, "hasRole:%t", false)
+		return sdk.WithStack(sdk.ErrForbidden)
+	}
+
+	ctx = context.WithValue(ctx, cdslog.RbacRole, role)
+	log.Info(ctx, "hasRole:%t", true)
+	return nil
+}
+
+// repositoryNameFromVars returns the name of the repository targeted by the route if any.
+func (api *API) repositoryNameFromVars(ctx context.Context, vars map[string]string) (string, error) {
+	if vars["vcsIdentifier"] == "" || vars["repositoryIdentifier"] == "" {
+		return "", nil
+	}
+	vcsIdentifier, err := url.PathUnescape(vars["vcsIdentifier"])
+	if err != nil {
+		return "", sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+	repositoryIdentifier, err := url.PathUnescape(vars["repositoryIdentifier"])
+	if err != nil {
+		return "", sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+	vcsProject, err := api.getVCSByIdentifier(ctx, vars["projectKey"], vcsIdentifier)
+	if err != nil {
+		return "", err
+	}
+	repo, err := api.getRepositoryByIdentifier(ctx, vcsProject.ID, repositoryIdentifier)
+	if err != nil {
+		return "", err
+	}
+	return repo.Name, nil
+}
+
+// projectManageVCS return nil if the current AuthUserConsumer can manage VCS servers on current project KEY
+func (api *API) projectManageVCS(ctx context.Context, auth *sdk.AuthUserConsumer, store cache.Store, db gorp.SqlExecutor, vars map[string]string) error {
+	return hasOneRoleOnProjectTarget(ctx, auth, db, vars["projectKey"], "", "", sdk.ProjectRoleManageVCS, sdk.ProjectRoleManage)
+}
+
+// projectManageRepository return nil if the current AuthUserConsumer can manage the repository given in route or all repositories of the project
+func (api *API) projectManageRepository(ctx context.Context, auth *sdk.AuthUserConsumer, store cache.Store, db gorp.SqlExecutor, vars map[string]string) error {
+	repoName, err := api.repositoryNameFromVars(ctx, vars)
+	if err != nil {
+		return err
+	}
+	return hasOneRoleOnProjectTarget(ctx, auth, db, vars["projectKey"], repoName, "", sdk.ProjectRoleManageRepository, sdk.ProjectRoleManage)
+}
+
+// projectTriggerRun return nil if the current AuthUserConsumer can trigger the workflow given in route
+func (api *API) projectTriggerRun(ctx context.Context, auth *sdk.AuthUserConsumer, store cache.Store, db gorp.SqlExecutor, vars map[string]string) error {
+	return hasOneRoleOnProjectTarget(ctx, auth, db, vars["projectKey"], "", vars["workflowName"], sdk.ProjectRoleTriggerRun, sdk.ProjectRoleManage)
+}
+
+// projectManageSecret return nil if the current AuthUserConsumer can manage secrets and keys on current project KEY
+func (api *API) projectManageSecret(ctx context.Context, auth *sdk.AuthUserConsumer, store cache.Store, db gorp.SqlExecutor, vars map[string]string) error {
+	return hasOneRoleOnProjectTarget(ctx, auth, db, vars["projectKey"], "", "", sdk.ProjectRoleManageSecret, sdk.ProjectRoleManage)
+}
+
+// projectViewSecret return nil if the current AuthUserConsumer can view secrets values on current project KEY
+func (api *API) projectViewSecret(ctx context.Context, auth *sdk.AuthUserConsumer, store cache.Store, db gorp.SqlExecutor, vars map[string]string) error {
+	return hasOneRoleOnProjectTarget(ctx, auth, db, vars["projectKey"], "", "", sdk.ProjectRoleViewSecret, sdk.ProjectRoleManageSecret, sdk.ProjectRoleManage)
+}
+
 // ProjectManage return nil if the current AuthUserConsumer have the ProjectRoleManage on current project KEY
 func (api *API) projectManage(ctx context.Context, auth *sdk.AuthUserConsumer, store cache.Store, db gorp.SqlExecutor, vars map[string]string) error {
 	projectKey := vars["projectKey"]
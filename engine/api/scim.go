@@ -0,0 +1,1040 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// SCIM 2.0 provisioning endpoints, see RFC 7644. Users and groups are identified by their CDS ids.
+// A user's active attribute maps to its deactivation: a deactivated user can't signin and all its consumers are disabled.
+
+const scimMaxResults = 1000
+
+func (api *API) getSCIMServiceProviderConfigHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		return service.WriteJSON(w, sdk.SCIMServiceProviderConfig{
+			Schemas: []string{sdk.SCIMSchemaServiceProviderConfig},
+			Patch:   sdk.SCIMSupported{Supported: true},
+			Filter:  sdk.SCIMSupported{Supported: true, MaxResults: scimMaxResults},
+			AuthenticationSchemes: []map[string]interface{}{{
+				"type":        "oauthbearertoken",
+				"name":        "OAuth Bearer Token",
+				"description": "Session token of a builtin consumer with scope " + string(sdk.AuthConsumerScopeSCIM),
+				"primary":     true,
+			}},
+		}, http.StatusOK)
+	}
+}
+
+func (api *API) getSCIMUsersHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		filter, err := sdk.ParseSCIMFilter(FormString(r, "filter"))
+		if err != nil {
+			return err
+		}
+
+		startIndex, count := scimPagination(r)
+
+		users, total, err := user.LoadAllBySCIMFilter(ctx, api.mustDB(), filter, startIndex-1, count, user.LoadOptions.WithContacts)
+		if err != nil {
+			return err
+		}
+		scimUsers, err := api.newSCIMUsers(ctx, api.mustDB(), users...)
+		if err != nil {
+			return err
+		}
+
+		resources := make([]interface{}, len(scimUsers))
+		for i := range scimUsers {
+			resources[i] = scimUsers[i]
+		}
+
+		return service.WriteJSON(w, newSCIMListResponse(startIndex, int(total), resources), http.StatusOK)
+	}
+}
+
+func (api *API) getSCIMUserHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		u, err := user.LoadByID(ctx, api.mustDB(), mux.Vars(r)["id"], user.LoadOptions.WithContacts)
+		if err != nil {
+			return err
+		}
+		scimUsers, err := api.newSCIMUsers(ctx, api.mustDB(), *u)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, scimUsers[0], http.StatusOK)
+	}
+}
+
+func (api *API) postSCIMUserHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var req sdk.SCIMUser
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if err := req.IsValid(); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		existingUser, err := user.LoadByUsername(ctx, tx, req.UserName)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrUserNotFound) {
+			return err
+		}
+		if existingUser != nil {
+			return sdk.NewErrorFrom(sdk.ErrConflictData, "a user already exists for username %s", req.UserName)
+		}
+		existingContact, err := user.LoadContactByTypeAndValue(ctx, tx, sdk.UserContactTypeEmail, req.PrimaryEmail())
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if existingContact != nil {
+			return sdk.NewErrorFrom(sdk.ErrConflictData, "a user already exists for email %s", req.PrimaryEmail())
+		}
+
+		u := sdk.AuthentifiedUser{
+			Ring:     sdk.UserRingUser,
+			Username: req.UserName,
+			Fullname: req.Fullname(),
+		}
+		if err := user.Insert(ctx, tx, &u); err != nil {
+			return err
+		}
+		if err := user.InsertContact(ctx, tx, &sdk.UserContact{
+			Primary:  true,
+			Type:     sdk.UserContactTypeEmail,
+			UserID:   u.ID,
+			Value:    req.PrimaryEmail(),
+			Verified: true,
+		}); err != nil {
+			return err
+		}
+		if !api.Config.Auth.DisableAddUserInDefaultGroup {
+			if err := group.CheckUserInDefaultGroup(ctx, tx, u.ID); err != nil {
+				return err
+			}
+		}
+		if !req.IsActive() {
+			if err := api.scimSetUserActive(ctx, tx, &u, false); err != nil {
+				return err
+			}
+		}
+
+		if err := auditLog(ctx, tx, sdk.AuditActionUserAdd, sdk.AuditTargetUser, u.Username, "", nil, req); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return api.writeSCIMUser(ctx, w, u.ID, http.StatusCreated)
+	}
+}
+
+func (api *API) putSCIMUserHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var req sdk.SCIMUser
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		return api.scimUpdateUser(ctx, w, mux.Vars(r)["id"], func(current *sdk.SCIMUser) error {
+			req.ID = current.ID
+			req.Groups = current.Groups
+			*current = req
+			return nil
+		})
+	}
+}
+
+func (api *API) patchSCIMUserHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var req sdk.SCIMPatchRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		return api.scimUpdateUser(ctx, w, mux.Vars(r)["id"], func(current *sdk.SCIMUser) error {
+			for _, op := range req.Operations {
+				if err := applySCIMUserOperation(current, op); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+}
+
+func (api *API) deleteSCIMUserHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		u, err := user.LoadByID(ctx, tx, mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+		if err := api.scimCheckUserCanBeDisabled(ctx, tx, u); err != nil {
+			return err
+		}
+		if err := checkUserIsNotLastGroupAdmin(ctx, tx, u.ID); err != nil {
+			return err
+		}
+
+		// Consumers and sessions are removed with the user
+		if err := user.DeleteByID(tx, u.ID); err != nil {
+			return sdk.WrapError(err, "cannot delete user")
+		}
+
+		if err := auditLog(ctx, tx, sdk.AuditActionUserDelete, sdk.AuditTargetUser, u.Username, "", u, nil); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return nil
+	}
+}
+
+func (api *API) getSCIMGroupsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		filter, err := sdk.ParseSCIMFilter(FormString(r, "filter"))
+		if err != nil {
+			return err
+		}
+
+		startIndex, count := scimPagination(r)
+
+		groups, total, err := group.LoadAllBySCIMFilter(ctx, api.mustDB(), filter, startIndex-1, count, group.LoadOptions.WithMembers)
+		if err != nil {
+			return err
+		}
+
+		resources := make([]interface{}, len(groups))
+		for i := range groups {
+			resources[i] = api.newSCIMGroup(groups[i])
+		}
+
+		return service.WriteJSON(w, newSCIMListResponse(startIndex, int(total), resources), http.StatusOK)
+	}
+}
+
+func (api *API) getSCIMGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		g, err := loadSCIMGroup(ctx, api.mustDB(), mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, api.newSCIMGroup(*g), http.StatusOK)
+	}
+}
+
+func (api *API) postSCIMGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var req sdk.SCIMGroup
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		g := sdk.Group{Name: req.DisplayName}
+		if err := g.IsValid(); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		existingGroup, err := group.LoadByName(ctx, tx, g.Name)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if existingGroup != nil {
+			return sdk.NewErrorFrom(sdk.ErrConflictData, "a group already exists for name %s", g.Name)
+		}
+
+		// Provisioned groups have no admin, members are managed by the identity provider
+		if err := group.Insert(ctx, tx, &g); err != nil {
+			return err
+		}
+		for _, m := range req.Members {
+			if err := scimAddGroupMember(ctx, tx, &g, m.Value); err != nil {
+				return err
+			}
+		}
+		if err := group.EnsureOrganization(ctx, tx, &g); err != nil {
+			return err
+		}
+
+		if err := auditLog(ctx, tx, sdk.AuditActionGroupAdd, sdk.AuditTargetGroup, g.Name, "", nil, req); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return api.writeSCIMGroup(ctx, w, g.ID, http.StatusCreated)
+	}
+}
+
+func (api *API) putSCIMGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var req sdk.SCIMGroup
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		return api.scimUpdateGroup(ctx, w, mux.Vars(r)["id"], func(current *sdk.SCIMGroup) error {
+			req.ID = current.ID
+			*current = req
+			return nil
+		})
+	}
+}
+
+func (api *API) patchSCIMGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var req sdk.SCIMPatchRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		return api.scimUpdateGroup(ctx, w, mux.Vars(r)["id"], func(current *sdk.SCIMGroup) error {
+			for _, op := range req.Operations {
+				if err := applySCIMGroupOperation(current, op); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+}
+
+func (api *API) deleteSCIMGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		g, err := loadSCIMGroup(ctx, tx, mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+		if group.IsDefaultGroupID(g.ID) {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "cannot delete the default group")
+		}
+
+		// Remove the group from all consumers
+		if err := authentication.ConsumerRemoveGroup(ctx, tx, g); err != nil {
+			return err
+		}
+		if err := group.Delete(ctx, tx, g); err != nil {
+			return sdk.WrapError(err, "cannot delete group")
+		}
+
+		if err := auditLog(ctx, tx, sdk.AuditActionGroupDelete, sdk.AuditTargetGroup, g.Name, "", api.newSCIMGroup(*g), nil); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return nil
+	}
+}
+
+// scimUpdateUser applies given update func on the SCIM representation of the user then saves the changes.
+func (api *API) scimUpdateUser(ctx context.Context, w http.ResponseWriter, id string, update func(current *sdk.SCIMUser) error) error {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	u, err := user.LoadByID(ctx, tx, id, user.LoadOptions.WithContacts)
+	if err != nil {
+		return err
+	}
+	scimUsers, err := api.newSCIMUsers(ctx, tx, *u)
+	if err != nil {
+		return err
+	}
+	before := scimUsers[0]
+	after := scimUsers[0]
+	after.Emails = append([]sdk.SCIMMultiValued(nil), before.Emails...)
+	if err := update(&after); err != nil {
+		return err
+	}
+	if err := after.IsValid(); err != nil {
+		return err
+	}
+
+	if after.UserName != u.Username || after.Fullname() != u.Fullname {
+		if after.UserName != u.Username {
+			existingUser, err := user.LoadByUsername(ctx, tx, after.UserName)
+			if err != nil && !sdk.ErrorIs(err, sdk.ErrUserNotFound) {
+				return err
+			}
+			if existingUser != nil {
+				return sdk.NewErrorFrom(sdk.ErrConflictData, "a user already exists for username %s", after.UserName)
+			}
+		}
+		u.Username = after.UserName
+		u.Fullname = after.Fullname()
+		if err := user.Update(ctx, tx, u); err != nil {
+			return err
+		}
+	}
+
+	if err := scimSetUserPrimaryEmail(ctx, tx, u, after.PrimaryEmail()); err != nil {
+		return err
+	}
+
+	if before.IsActive() != after.IsActive() {
+		if err := api.scimSetUserActive(ctx, tx, u, after.IsActive()); err != nil {
+			return err
+		}
+	}
+
+	if err := auditLog(ctx, tx, sdk.AuditActionUserUpdate, sdk.AuditTargetUser, u.Username, "", before, after); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	return api.writeSCIMUser(ctx, w, u.ID, http.StatusOK)
+}
+
+// scimUpdateGroup applies given update func on the SCIM representation of the group then saves the changes.
+func (api *API) scimUpdateGroup(ctx context.Context, w http.ResponseWriter, id string, update func(current *sdk.SCIMGroup) error) error {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	g, err := loadSCIMGroup(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	before := api.newSCIMGroup(*g)
+	after := api.newSCIMGroup(*g)
+	if err := update(&after); err != nil {
+		return err
+	}
+
+	if after.DisplayName != g.Name {
+		newGroup := sdk.Group{ID: g.ID, Name: after.DisplayName}
+		if err := newGroup.IsValid(); err != nil {
+			return err
+		}
+		existingGroup, err := group.LoadByName(ctx, tx, newGroup.Name)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if existingGroup != nil {
+			return sdk.NewErrorFrom(sdk.ErrConflictData, "a group already exists for name %s", newGroup.Name)
+		}
+		g.Name = newGroup.Name
+		if err := group.Update(ctx, tx, g); err != nil {
+			return err
+		}
+	}
+
+	currentMembers := make(map[string]struct{}, len(before.Members))
+	for _, m := range before.Members {
+		currentMembers[m.Value] = struct{}{}
+	}
+	expectedMembers := make(map[string]struct{}, len(after.Members))
+	for _, m := range after.Members {
+		expectedMembers[m.Value] = struct{}{}
+		if _, ok := currentMembers[m.Value]; !ok {
+			if err := scimAddGroupMember(ctx, tx, g, m.Value); err != nil {
+				return err
+			}
+		}
+	}
+	for _, m := range before.Members {
+		if _, ok := expectedMembers[m.Value]; !ok {
+			if err := scimRemoveGroupMember(ctx, tx, g, m.Value); err != nil {
+				return err
+			}
+		}
+	}
+	if err := group.EnsureOrganization(ctx, tx, g); err != nil {
+		return err
+	}
+
+	if err := auditLog(ctx, tx, sdk.AuditActionGroupUpdate, sdk.AuditTargetGroup, g.Name, "", before, after); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	return api.writeSCIMGroup(ctx, w, g.ID, http.StatusOK)
+}
+
+// scimSetUserActive deactivates or reactivates given user. When deactivated all user's consumers are disabled
+// and their sessions are removed.
+func (api *API) scimSetUserActive(ctx context.Context, db gorpmapper.SqlExecutorWithTx, u *sdk.AuthentifiedUser, active bool) error {
+	deactivated, err := user.IsDeactivated(ctx, db, u.ID)
+	if err != nil {
+		return err
+	}
+	if active == !deactivated {
+		return nil
+	}
+
+	if active {
+		if err := user.DeleteDeactivation(db, u.ID); err != nil {
+			return err
+		}
+		return authentication.ConsumerReactivateForUser(ctx, db, u.ID)
+	}
+
+	if err := api.scimCheckUserCanBeDisabled(ctx, db, u); err != nil {
+		return err
+	}
+	if err := user.InsertDeactivation(ctx, db, u.ID); err != nil {
+		return err
+	}
+	return authentication.ConsumerDeactivateForUser(ctx, db, u.ID)
+}
+
+func (api *API) scimCheckUserCanBeDisabled(ctx context.Context, db gorp.SqlExecutor, u *sdk.AuthentifiedUser) error {
+	// Prevent the identity provider from removing its own access
+	if consumer := getUserConsumer(ctx); consumer != nil && consumer.AuthConsumerUser.AuthentifiedUserID == u.ID {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "cannot deactivate the owner of the current consumer")
+	}
+	if u.Ring == sdk.UserRingAdmin {
+		count, err := user.CountAdmin(db)
+		if err != nil {
+			return err
+		}
+		if count < 2 {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "can't deactivate the last admin")
+		}
+	}
+	return nil
+}
+
+func scimSetUserPrimaryEmail(ctx context.Context, db gorpmapper.SqlExecutorWithTx, u *sdk.AuthentifiedUser, email string) error {
+	primary := u.Contacts.Filter(sdk.UserContactTypeEmail).Primary()
+	if primary != nil && primary.Value == email {
+		return nil
+	}
+
+	existingContact, err := user.LoadContactByTypeAndValue(ctx, db, sdk.UserContactTypeEmail, email)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	if existingContact != nil && existingContact.UserID != u.ID {
+		return sdk.NewErrorFrom(sdk.ErrConflictData, "a user already exists for email %s", email)
+	}
+
+	switch {
+	case existingContact != nil:
+		// The email is a secondary contact of the user, use it as primary
+		if primary != nil {
+			primary.Primary = false
+			if err := user.UpdateContact(ctx, db, primary); err != nil {
+				return err
+			}
+		}
+		existingContact.Primary = true
+		return user.UpdateContact(ctx, db, existingContact)
+	case primary != nil:
+		primary.Value = email
+		return user.UpdateContact(ctx, db, primary)
+	default:
+		return user.InsertContact(ctx, db, &sdk.UserContact{
+			Primary:  true,
+			Type:     sdk.UserContactTypeEmail,
+			UserID:   u.ID,
+			Value:    email,
+			Verified: true,
+		})
+	}
+}
+
+func scimAddGroupMember(ctx context.Context, db gorpmapper.SqlExecutorWithTx, g *sdk.Group, userID string) error {
+	u, err := user.LoadByID(ctx, db, userID, user.LoadOptions.WithOrganization)
+	if err != nil {
+		return err
+	}
+
+	link, err := group.LoadLinkGroupUserForGroupIDAndUserID(ctx, db, g.ID, u.ID)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	if link != nil {
+		return nil
+	}
+
+	if g.Organization != "" && u.Organization != "" && u.Organization != g.Organization {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "given user with organization %q don't match group organization %q", u.Organization, g.Organization)
+	}
+
+	if err := group.InsertLinkGroupUser(ctx, db, &group.LinkGroupUser{
+		GroupID:            g.ID,
+		AuthentifiedUserID: u.ID,
+	}); err != nil {
+		return sdk.WrapError(err, "cannot add user %s in group %s", u.Username, g.Name)
+	}
+
+	// Restore invalid group for existing user's consumer
+	return authentication.ConsumerRestoreInvalidatedGroupForUser(ctx, db, g.ID, u.ID)
+}
+
+func scimRemoveGroupMember(ctx context.Context, db gorpmapper.SqlExecutorWithTx, g *sdk.Group, userID string) error {
+	u, err := user.LoadByID(ctx, db, userID)
+	if err != nil {
+		return err
+	}
+	link, err := group.LoadLinkGroupUserForGroupIDAndUserID(ctx, db, g.ID, u.ID)
+	if err != nil {
+		return err
+	}
+	if err := group.DeleteLinkGroupUser(db, link); err != nil {
+		return err
+	}
+	return authentication.ConsumerInvalidateGroupForUser(ctx, db, g, u)
+}
+
+func loadSCIMGroup(ctx context.Context, db gorp.SqlExecutor, id string) (*sdk.Group, error) {
+	groupID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "invalid group id %q", id)
+	}
+	return group.LoadByID(ctx, db, groupID, group.LoadOptions.WithMembers)
+}
+
+func (api *API) writeSCIMUser(ctx context.Context, w http.ResponseWriter, id string, status int) error {
+	u, err := user.LoadByID(ctx, api.mustDB(), id, user.LoadOptions.WithContacts)
+	if err != nil {
+		return err
+	}
+	scimUsers, err := api.newSCIMUsers(ctx, api.mustDB(), *u)
+	if err != nil {
+		return err
+	}
+	return service.WriteJSON(w, scimUsers[0], status)
+}
+
+func (api *API) writeSCIMGroup(ctx context.Context, w http.ResponseWriter, id int64, status int) error {
+	g, err := group.LoadByID(ctx, api.mustDB(), id, group.LoadOptions.WithMembers)
+	if err != nil {
+		return err
+	}
+	return service.WriteJSON(w, api.newSCIMGroup(*g), status)
+}
+
+// newSCIMUsers returns the SCIM representation of given users, contacts should be loaded.
+func (api *API) newSCIMUsers(ctx context.Context, db gorp.SqlExecutor, users ...sdk.AuthentifiedUser) ([]sdk.SCIMUser, error) {
+	userIDs := sdk.AuthentifiedUsers(users).IDs()
+	links, err := group.LoadLinksGroupUserForUserIDs(ctx, db, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := group.LoadAllByIDs(ctx, db, links.ToGroupIDs())
+	if err != nil {
+		return nil, err
+	}
+	mGroups := groups.ToMap()
+	deactivatedIDs, err := user.LoadDeactivatedUserIDs(ctx, db, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]sdk.SCIMUser, len(users))
+	for i, u := range users {
+		created := u.Created
+		active := !deactivatedIDs.Contains(u.ID)
+		res[i] = sdk.SCIMUser{
+			Schemas:     []string{sdk.SCIMSchemaUser},
+			ID:          u.ID,
+			UserName:    u.Username,
+			Name:        &sdk.SCIMName{Formatted: u.Fullname},
+			DisplayName: u.Fullname,
+			Active:      &active,
+			Meta: &sdk.SCIMMeta{
+				ResourceType: "User",
+				Created:      &created,
+				Location:     api.Config.URL.API + "/scim/v2/Users/" + u.ID,
+			},
+		}
+		for _, c := range u.Contacts.Filter(sdk.UserContactTypeEmail) {
+			res[i].Emails = append(res[i].Emails, sdk.SCIMMultiValued{Value: c.Value, Type: "work", Primary: c.Primary})
+		}
+		for _, l := range links {
+			if l.AuthentifiedUserID != u.ID {
+				continue
+			}
+			if g, ok := mGroups[l.GroupID]; ok {
+				res[i].Groups = append(res[i].Groups, sdk.SCIMMultiValued{
+					Value:   strconv.FormatInt(g.ID, 10),
+					Display: g.Name,
+					Ref:     api.Config.URL.API + "/scim/v2/Groups/" + strconv.FormatInt(g.ID, 10),
+				})
+			}
+		}
+	}
+	return res, nil
+}
+
+// newSCIMGroup returns the SCIM representation of given group, members should be loaded.
+func (api *API) newSCIMGroup(g sdk.Group) sdk.SCIMGroup {
+	id := strconv.FormatInt(g.ID, 10)
+	res := sdk.SCIMGroup{
+		Schemas:     []string{sdk.SCIMSchemaGroup},
+		ID:          id,
+		DisplayName: g.Name,
+		Meta: &sdk.SCIMMeta{
+			ResourceType: "Group",
+			Location:     api.Config.URL.API + "/scim/v2/Groups/" + id,
+		},
+	}
+	for _, m := range g.Members {
+		res.Members = append(res.Members, sdk.SCIMMultiValued{
+			Value:   m.ID,
+			Display: m.Username,
+			Ref:     api.Config.URL.API + "/scim/v2/Users/" + m.ID,
+		})
+	}
+	return res
+}
+
+// scimPagination returns the 1-based index of the first resource and the maximum count of resources requested.
+func scimPagination(r *http.Request) (int, int) {
+	startIndex := service.FormInt(r, "startIndex")
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	count := scimMaxResults
+	if c := r.FormValue("count"); c != "" {
+		count = service.FormInt(r, "count")
+	}
+	if count > scimMaxResults {
+		count = scimMaxResults
+	}
+	if count < 0 {
+		count = 0
+	}
+	return startIndex, count
+}
+
+// newSCIMListResponse returns a page of resources, total is the count of resources that match the request.
+func newSCIMListResponse(startIndex, total int, resources []interface{}) sdk.SCIMListResponse {
+	if resources == nil {
+		resources = []interface{}{}
+	}
+	return sdk.SCIMListResponse{
+		Schemas:      []string{sdk.SCIMSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// writeSCIMError returns errors in the SCIM error schema, see RFC 7644 section 3.12.
+func writeSCIMError(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	httpErr := service.LogError(ctx, err)
+
+	res := sdk.SCIMError{
+		Schemas: []string{sdk.SCIMSchemaError},
+		Status:  strconv.Itoa(httpErr.Status),
+		Detail:  httpErr.Error(),
+	}
+	switch httpErr.Status {
+	case http.StatusBadRequest:
+		// Only query parameters are given to GET requests, filter is the one that can be invalid
+		res.SCIMType = "invalidValue"
+		if r.Method == http.MethodGet {
+			res.SCIMType = "invalidFilter"
+		}
+	case http.StatusConflict:
+		res.SCIMType = "uniqueness"
+	}
+
+	// safely ignore error returned by WriteJSON
+	_ = service.WriteJSON(w, res, httpErr.Status)
+}
+
+func scimValues(vs []sdk.SCIMMultiValued) []string {
+	res := make([]string, len(vs))
+	for i := range vs {
+		res[i] = vs[i].Value
+	}
+	return res
+}
+
+func scimMultiValuedMatch(f sdk.SCIMFilter, v sdk.SCIMMultiValued) bool {
+	return f.Match(func(attr string) []string {
+		switch attr {
+		case "value":
+			return []string{v.Value}
+		case "type":
+			return []string{v.Type}
+		case "display":
+			return []string{v.Display}
+		case "primary":
+			return []string{strconv.FormatBool(v.Primary)}
+		}
+		return nil
+	})
+}
+
+// scimOperationAttributes returns the patch operations for each attribute when the operation has no path.
+func scimOperationAttributes(op sdk.SCIMPatchOperation) ([]sdk.SCIMPatchOperation, error) {
+	if op.Path != "" {
+		return []sdk.SCIMPatchOperation{op}, nil
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(op.Value, &values); err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid value for %s operation without path", op.Op)
+	}
+	ops := make([]sdk.SCIMPatchOperation, 0, len(values))
+	for k, v := range values {
+		ops = append(ops, sdk.SCIMPatchOperation{Op: op.Op, Path: k, Value: v})
+	}
+	return ops, nil
+}
+
+func unmarshalSCIMValue(op sdk.SCIMPatchOperation, v interface{}) error {
+	if err := json.Unmarshal(op.Value, v); err != nil {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid value for path %s", op.Path)
+	}
+	return nil
+}
+
+func applySCIMUserOperation(u *sdk.SCIMUser, op sdk.SCIMPatchOperation) error {
+	if err := op.IsValid(); err != nil {
+		return err
+	}
+	ops, err := scimOperationAttributes(op)
+	if err != nil {
+		return err
+	}
+	for _, op := range ops {
+		path, err := sdk.ParseSCIMPath(op.Path)
+		if err != nil {
+			return err
+		}
+		remove := strings.EqualFold(op.Op, sdk.SCIMPatchOpRemove)
+
+		switch path.Attribute {
+		case "active":
+			if remove {
+				continue
+			}
+			var active bool
+			if err := json.Unmarshal(op.Value, &active); err != nil {
+				// Some identity providers send booleans as strings
+				var s string
+				if err := unmarshalSCIMValue(op, &s); err != nil {
+					return err
+				}
+				active, err = strconv.ParseBool(s)
+				if err != nil {
+					return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid value for path %s", op.Path)
+				}
+			}
+			u.Active = &active
+		case "username":
+			if remove {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "userName can't be removed")
+			}
+			if err := unmarshalSCIMValue(op, &u.UserName); err != nil {
+				return err
+			}
+		case "displayname":
+			u.DisplayName = ""
+			if !remove {
+				if err := unmarshalSCIMValue(op, &u.DisplayName); err != nil {
+					return err
+				}
+			}
+		case "name":
+			if remove {
+				u.Name = nil
+				continue
+			}
+			var name sdk.SCIMName
+			if u.Name != nil {
+				name = *u.Name
+			}
+			switch path.SubAttribute {
+			case "":
+				if err := unmarshalSCIMValue(op, &name); err != nil {
+					return err
+				}
+			case "formatted":
+				if err := unmarshalSCIMValue(op, &name.Formatted); err != nil {
+					return err
+				}
+			case "givenname":
+				if err := unmarshalSCIMValue(op, &name.GivenName); err != nil {
+					return err
+				}
+				name.Formatted = ""
+			case "familyname":
+				if err := unmarshalSCIMValue(op, &name.FamilyName); err != nil {
+					return err
+				}
+				name.Formatted = ""
+			}
+			u.Name = &name
+			// Display name is computed from current name, it should be updated from the new name
+			u.DisplayName = ""
+		case "emails":
+			if err := applySCIMMultiValuedOperation(&u.Emails, op, path); err != nil {
+				return err
+			}
+		}
+		// Other attributes like externalId or extensions are not stored by CDS
+	}
+	return nil
+}
+
+func applySCIMGroupOperation(g *sdk.SCIMGroup, op sdk.SCIMPatchOperation) error {
+	if err := op.IsValid(); err != nil {
+		return err
+	}
+	ops, err := scimOperationAttributes(op)
+	if err != nil {
+		return err
+	}
+	for _, op := range ops {
+		path, err := sdk.ParseSCIMPath(op.Path)
+		if err != nil {
+			return err
+		}
+		switch path.Attribute {
+		case "displayname":
+			if strings.EqualFold(op.Op, sdk.SCIMPatchOpRemove) {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "displayName can't be removed")
+			}
+			if err := unmarshalSCIMValue(op, &g.DisplayName); err != nil {
+				return err
+			}
+		case "members":
+			if err := applySCIMMultiValuedOperation(&g.Members, op, path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applySCIMMultiValuedOperation applies an operation on a multi-valued attribute, elements are identified by their value.
+func applySCIMMultiValuedOperation(vs *[]sdk.SCIMMultiValued, op sdk.SCIMPatchOperation, path sdk.SCIMPath) error {
+	// Update a sub attribute of filtered elements, ex: emails[type eq "work"].value
+	if path.Filter != nil && path.SubAttribute != "" {
+		if path.SubAttribute != "value" {
+			return nil
+		}
+		var value string
+		if !strings.EqualFold(op.Op, sdk.SCIMPatchOpRemove) {
+			if err := unmarshalSCIMValue(op, &value); err != nil {
+				return err
+			}
+		}
+		var found bool
+		filtered := make([]sdk.SCIMMultiValued, 0, len(*vs))
+		for _, v := range *vs {
+			if scimMultiValuedMatch(path.Filter, v) {
+				found = true
+				if value == "" {
+					continue
+				}
+				v.Value = value
+			}
+			filtered = append(filtered, v)
+		}
+		if !found && value != "" {
+			filtered = append(filtered, sdk.SCIMMultiValued{Value: value, Primary: len(filtered) == 0})
+		}
+		*vs = filtered
+		return nil
+	}
+
+	var values []sdk.SCIMMultiValued
+	if len(op.Value) > 0 {
+		if err := unmarshalSCIMValue(op, &values); err != nil {
+			return err
+		}
+	}
+
+	switch strings.ToLower(op.Op) {
+	case sdk.SCIMPatchOpReplace:
+		if path.Filter == nil {
+			*vs = values
+			return nil
+		}
+		// Replace filtered elements by given values
+		filtered := make([]sdk.SCIMMultiValued, 0, len(*vs))
+		for _, v := range *vs {
+			if !scimMultiValuedMatch(path.Filter, v) {
+				filtered = append(filtered, v)
+			}
+		}
+		*vs = append(filtered, values...)
+	case sdk.SCIMPatchOpAdd:
+		for _, value := range values {
+			var exists bool
+			for _, v := range *vs {
+				exists = exists || v.Value == value.Value
+			}
+			if !exists {
+				*vs = append(*vs, value)
+			}
+		}
+	case sdk.SCIMPatchOpRemove:
+		filtered := make([]sdk.SCIMMultiValued, 0, len(*vs))
+		for _, v := range *vs {
+			remove := path.Filter == nil && len(values) == 0
+			if path.Filter != nil {
+				remove = scimMultiValuedMatch(path.Filter, v)
+			}
+			for _, value := range values {
+				remove = remove || v.Value == value.Value
+			}
+			if !remove {
+				filtered = append(filtered, v)
+			}
+		}
+		*vs = filtered
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_applySCIMUserOperation(t *testing.T) {
+	active := true
+	u := sdk.SCIMUser{
+		UserName:    "john.doe",
+		DisplayName: "John Doe",
+		Active:      &active,
+		Emails:      []sdk.SCIMMultiValued{{Value: "john.doe@corp.com", Type: "work", Primary: true}},
+	}
+
+	require.NoError(t, applySCIMUserOperation(&u, sdk.SCIMPatchOperation{Op: "Replace", Path: "active", Value: json.RawMessage(`"False"`)}))
+	require.False(t, u.IsActive())
+
+	require.NoError(t, applySCIMUserOperation(&u, sdk.SCIMPatchOperation{Op: "replace", Value: json.RawMessage(`{"active":true,"displayName":"Johnny"}`)}))
+	require.True(t, u.IsActive())
+	require.Equal(t, "Johnny", u.Fullname())
+
+	require.NoError(t, applySCIMUserOperation(&u, sdk.SCIMPatchOperation{Op: "replace", Path: `emails[type eq "work"].value`, Value: json.RawMessage(`"johnny@corp.com"`)}))
+	require.Equal(t, "johnny@corp.com", u.PrimaryEmail())
+
+	require.Error(t, applySCIMUserOperation(&u, sdk.SCIMPatchOperation{Op: "move", Path: "active"}))
+	require.Error(t, applySCIMUserOperation(&u, sdk.SCIMPatchOperation{Op: "remove", Path: "userName"}))
+}
+
+func Test_applySCIMGroupOperation(t *testing.T) {
+	g := sdk.SCIMGroup{
+		DisplayName: "my-group",
+		Members:     []sdk.SCIMMultiValued{{Value: "a"}, {Value: "b"}},
+	}
+
+	require.NoError(t, applySCIMGroupOperation(&g, sdk.SCIMPatchOperation{Op: "add", Path: "members", Value: json.RawMessage(`[{"value":"b"},{"value":"c"}]`)}))
+	require.Equal(t, []string{"a", "b", "c"}, scimValues(g.Members))
+
+	require.NoError(t, applySCIMGroupOperation(&g, sdk.SCIMPatchOperation{Op: "remove", Path: `members[value eq "a"]`}))
+	require.Equal(t, []string{"b", "c"}, scimValues(g.Members))
+
+	require.NoError(t, applySCIMGroupOperation(&g, sdk.SCIMPatchOperation{Op: "Remove", Path: "members", Value: json.RawMessage(`[{"value":"c"}]`)}))
+	require.Equal(t, []string{"b"}, scimValues(g.Members))
+
+	require.NoError(t, applySCIMGroupOperation(&g, sdk.SCIMPatchOperation{Op: "replace", Value: json.RawMessage(`{"displayName":"other-group","members":[{"value":"d"}]}`)}))
+	require.Equal(t, "other-group", g.DisplayName)
+	require.Equal(t, []string{"d"}, scimValues(g.Members))
+
+	require.NoError(t, applySCIMGroupOperation(&g, sdk.SCIMPatchOperation{Op: "remove", Path: "members"}))
+	require.Empty(t, g.Members)
+}
+
+func Test_SCIMProvisioning(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	_, jwt := assets.InsertAdminUser(t, db)
+
+	// Create a user
+	username := sdk.RandomString(10)
+	uri := api.Router.GetRoute("POST", api.postSCIMUserHandler, nil)
+	req := assets.NewJWTAuthentifiedRequest(t, jwt, "POST", uri, sdk.SCIMUser{
+		Schemas:  []string{sdk.SCIMSchemaUser},
+		UserName: username,
+		Name:     &sdk.SCIMName{GivenName: "John", FamilyName: "Doe"},
+		Emails:   []sdk.SCIMMultiValued{{Value: username + "@corp.com", Primary: true}},
+	})
+	w := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 201, w.Code)
+	var u sdk.SCIMUser
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &u))
+	require.NotEmpty(t, u.ID)
+	require.Equal(t, "John Doe", u.DisplayName)
+	require.True(t, u.IsActive())
+
+	// Same username should conflict
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "POST", uri, sdk.SCIMUser{
+		UserName: username,
+		Emails:   []sdk.SCIMMultiValued{{Value: "other-" + username + "@corp.com"}},
+	})
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 409, w.Code)
+	var scimErr sdk.SCIMError
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &scimErr))
+	require.Equal(t, []string{sdk.SCIMSchemaError}, scimErr.Schemas)
+	require.Equal(t, "409", scimErr.Status)
+	require.Equal(t, "uniqueness", scimErr.SCIMType)
+
+	// Filter users
+	uri = api.Router.GetRoute("GET", api.getSCIMUsersHandler, nil)
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "GET", uri+"?filter="+url.QueryEscape(`userName eq "`+username+`"`), nil)
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+	var list sdk.SCIMListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Equal(t, 1, list.TotalResults)
+	require.Len(t, list.Resources, 1)
+
+	// Count only
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "GET", uri+"?count=0&filter="+url.QueryEscape(`userName eq "`+username+`"`), nil)
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Equal(t, 1, list.TotalResults)
+	require.Empty(t, list.Resources)
+
+	// Create a group with the user as member
+	groupName := sdk.RandomString(10)
+	uri = api.Router.GetRoute("POST", api.postSCIMGroupHandler, nil)
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "POST", uri, sdk.SCIMGroup{
+		DisplayName: groupName,
+		Members:     []sdk.SCIMMultiValued{{Value: u.ID}},
+	})
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 201, w.Code)
+	var g sdk.SCIMGroup
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &g))
+	require.Len(t, g.Members, 1)
+
+	// Remove the member
+	uri = api.Router.GetRoute("PATCH", api.patchSCIMGroupHandler, map[string]string{"id": g.ID})
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "PATCH", uri, sdk.SCIMPatchRequest{
+		Schemas:    []string{sdk.SCIMSchemaPatchOp},
+		Operations: []sdk.SCIMPatchOperation{{Op: "remove", Path: `members[value eq "` + u.ID + `"]`}},
+	})
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &g))
+	require.Empty(t, g.Members)
+
+	// Create a consumer for the user then deactivate it
+	consumer, err := authentication.NewConsumerExternal(context.TODO(), db, u.ID, sdk.ConsumerLocal, sdk.AuthDriverUserInfo{Username: username})
+	require.NoError(t, err)
+
+	uri = api.Router.GetRoute("PATCH", api.patchSCIMUserHandler, map[string]string{"id": u.ID})
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "PATCH", uri, sdk.SCIMPatchRequest{
+		Schemas:    []string{sdk.SCIMSchemaPatchOp},
+		Operations: []sdk.SCIMPatchOperation{{Op: "replace", Path: "active", Value: json.RawMessage("false")}},
+	})
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &u))
+	require.False(t, u.IsActive())
+
+	deactivated, err := user.IsDeactivated(context.TODO(), db, u.ID)
+	require.NoError(t, err)
+	require.True(t, deactivated)
+	consumer, err = authentication.LoadUserConsumerByID(context.TODO(), db, consumer.ID)
+	require.NoError(t, err)
+	require.True(t, consumer.Disabled)
+	require.True(t, consumer.Warnings.Contains(sdk.WarningUserDeactivated))
+
+	// Reactivate the user
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "PATCH", uri, sdk.SCIMPatchRequest{
+		Schemas:    []string{sdk.SCIMSchemaPatchOp},
+		Operations: []sdk.SCIMPatchOperation{{Op: "replace", Value: json.RawMessage(`{"active":true}`)}},
+	})
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+	consumer, err = authentication.LoadUserConsumerByID(context.TODO(), db, consumer.ID)
+	require.NoError(t, err)
+	require.False(t, consumer.Disabled)
+	require.Empty(t, consumer.Warnings)
+
+	// Delete the user
+	uri = api.Router.GetRoute("DELETE", api.deleteSCIMUserHandler, map[string]string{"id": u.ID})
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "DELETE", uri, nil)
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 204, w.Code)
+
+	_, err = user.LoadByID(context.TODO(), db, u.ID)
+	require.Error(t, err)
+}
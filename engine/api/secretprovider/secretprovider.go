@@ -0,0 +1,178 @@
+package secretprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+var httpClient = cdsclient.NewHTTPClient(10*time.Second, false)
+
+// Provider reads secrets from an external secret store.
+type Provider interface {
+	Get(ctx context.Context, path string) (map[string]string, error)
+}
+
+// New returns a provider for given SecretProvider integration config.
+func New(config sdk.IntegrationConfig) (Provider, error) {
+	url := strings.TrimSuffix(config[sdk.SecretProviderConfigURL].Value, "/")
+	if url == "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing secret provider url")
+	}
+	token := config[sdk.SecretProviderConfigToken].Value
+	switch config[sdk.SecretProviderConfigType].Value {
+	case sdk.SecretProviderTypeVault, "":
+		mount := strings.Trim(config[sdk.SecretProviderConfigMount].Value, "/")
+		if mount == "" {
+			mount = "secret"
+		}
+		return &vault{url: url, token: token, namespace: config[sdk.SecretProviderConfigNamespace].Value, mount: mount}, nil
+	case sdk.SecretProviderTypeHTTP:
+		return &httpAPI{url: url, token: token}, nil
+	}
+	return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unsupported secret provider type %q", config[sdk.SecretProviderConfigType].Value)
+}
+
+// Resolve returns the value of the key referenced by given secret reference.
+func Resolve(ctx context.Context, p Provider, ref sdk.SecretRef) (string, error) {
+	values, err := p.Get(ctx, ref.Path)
+	if err != nil {
+		return "", err
+	}
+	v, has := values[ref.Key]
+	if !has {
+		return "", sdk.NewErrorFrom(sdk.ErrNotFound, "key %q not found in secret %s of %s", ref.Key, ref.Path, ref.Integration)
+	}
+	return v, nil
+}
+
+// vault reads secrets from a HashiCorp Vault KV v2 engine.
+type vault struct {
+	url       string
+	token     string
+	namespace string
+	mount     string
+}
+
+func (v *vault) Get(ctx context.Context, path string) (map[string]string, error) {
+	headers := map[string]string{"X-Vault-Token": v.token}
+	if v.namespace != "" {
+		headers["X-Vault-Namespace"] = v.namespace
+	}
+	var res struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := get(ctx, fmt.Sprintf("%s/v1/%s/data/%s", v.url, v.mount, path), headers, &res); err != nil {
+		return nil, err
+	}
+	return stringValues(res.Data.Data), nil
+}
+
+// httpAPI reads secrets from a generic HTTP API that returns a flat JSON object for a path.
+type httpAPI struct {
+	url   string
+	token string
+}
+
+func (h *httpAPI) Get(ctx context.Context, path string) (map[string]string, error) {
+	headers := map[string]string{}
+	if h.token != "" {
+		headers["Authorization"] = "Bearer " + h.token
+	}
+	var res map[string]interface{}
+	if err := get(ctx, h.url+"/"+path, headers, &res); err != nil {
+		return nil, err
+	}
+	return stringValues(res), nil
+}
+
+func get(ctx context.Context, url string, headers map[string]string, res interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return sdk.WrapError(err, "unable to reach secret provider")
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return sdk.NewErrorFrom(sdk.ErrNotFound, "secret not found in secret provider")
+	case resp.StatusCode >= 400:
+		// Don't return the body, it could contain sensitive data
+		return sdk.NewErrorFrom(sdk.ErrUnknownError, "secret provider returned status %d", resp.StatusCode)
+	}
+	return sdk.WrapError(json.Unmarshal(body, res), "unable to read secret provider response")
+}
+
+func stringValues(data map[string]interface{}) map[string]string {
+	res := make(map[string]string, len(data))
+	for k, v := range data {
+		switch x := v.(type) {
+		case string:
+			res[k] = x
+		default:
+			btes, _ := json.Marshal(x)
+			res[k] = string(btes)
+		}
+	}
+	return res
+}
+
+// ResolveVariables replaces secret-ref variables by password variables with the values read from the project's
+// secret providers. Other variables are returned as is.
+func ResolveVariables(ctx context.Context, db gorp.SqlExecutor, projectKey string, vars []sdk.Variable) ([]sdk.Variable, error) {
+	providers := make(map[string]Provider)
+	res := make([]sdk.Variable, 0, len(vars))
+	for _, v := range vars {
+		if v.Type != sdk.SecretRefVariable {
+			res = append(res, v)
+			continue
+		}
+		ref, err := sdk.ParseSecretRef(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		p, has := providers[ref.Integration]
+		if !has {
+			pi, err := integration.LoadProjectIntegrationByNameWithClearPassword(ctx, db, projectKey, ref.Integration)
+			if err != nil {
+				return nil, sdk.WrapError(err, "unable to load secret provider %s for %s", ref.Integration, v.Name)
+			}
+			if pi.Model.Name != sdk.SecretProviderIntegrationModel {
+				return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "integration %s is not a secret provider", ref.Integration)
+			}
+			p, err = New(pi.Config)
+			if err != nil {
+				return nil, err
+			}
+			providers[ref.Integration] = p
+		}
+		value, err := Resolve(ctx, p, ref)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to resolve secret %s", v.Name)
+		}
+		res = append(res, sdk.Variable{Name: v.Name, Type: sdk.SecretVariable, Value: value})
+	}
+	return res, nil
+}
@@ -0,0 +1,85 @@
+package secretprovider_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/secretprovider"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestVault(t *testing.T) {
+	// Vault KV v2 compatible stand-in
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "my-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.Header.Get("X-Vault-Namespace") != "my-ns" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/kv/data/app/prod":
+			w.Write([]byte(`{"data":{"data":{"db_password":"s3cr3t","port":5432},"metadata":{"version":2}}}`)) // nolint
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	p, err := secretprovider.New(sdk.IntegrationConfig{
+		sdk.SecretProviderConfigType:      {Value: sdk.SecretProviderTypeVault},
+		sdk.SecretProviderConfigURL:       {Value: srv.URL + "/"},
+		sdk.SecretProviderConfigToken:     {Value: "my-token"},
+		sdk.SecretProviderConfigNamespace: {Value: "my-ns"},
+		sdk.SecretProviderConfigMount:     {Value: "kv"},
+	})
+	require.NoError(t, err)
+
+	v, err := secretprovider.Resolve(context.TODO(), p, sdk.SecretRef{Integration: "vault", Path: "app/prod", Key: "db_password"})
+	require.NoError(t, err)
+	require.Equal(t, "s3cr3t", v)
+
+	v, err = secretprovider.Resolve(context.TODO(), p, sdk.SecretRef{Integration: "vault", Path: "app/prod", Key: "port"})
+	require.NoError(t, err)
+	require.Equal(t, "5432", v)
+
+	_, err = secretprovider.Resolve(context.TODO(), p, sdk.SecretRef{Integration: "vault", Path: "app/prod", Key: "unknown"})
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+
+	_, err = secretprovider.Resolve(context.TODO(), p, sdk.SecretRef{Integration: "vault", Path: "app/dev", Key: "db_password"})
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+}
+
+func TestHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer my-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"api_key":"abcdef"}`)) // nolint
+	}))
+	defer srv.Close()
+
+	p, err := secretprovider.New(sdk.IntegrationConfig{
+		sdk.SecretProviderConfigType:  {Value: sdk.SecretProviderTypeHTTP},
+		sdk.SecretProviderConfigURL:   {Value: srv.URL},
+		sdk.SecretProviderConfigToken: {Value: "my-token"},
+	})
+	require.NoError(t, err)
+
+	v, err := secretprovider.Resolve(context.TODO(), p, sdk.SecretRef{Integration: "api", Path: "team/app", Key: "api_key"})
+	require.NoError(t, err)
+	require.Equal(t, "abcdef", v)
+
+	_, err = secretprovider.New(sdk.IntegrationConfig{
+		sdk.SecretProviderConfigType: {Value: "unknown"},
+		sdk.SecretProviderConfigURL:  {Value: srv.URL},
+	})
+	require.Error(t, err)
+}
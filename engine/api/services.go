@@ -12,12 +12,15 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/rockbears/log"
 
+	"github.com/ovh/cds/engine/api/authentication"
 	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/engine/api/vcs"
 	"github.com/ovh/cds/engine/api/worker"
 	"github.com/ovh/cds/engine/gorpmapper"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/vcs/git"
 )
 
 func (api *API) getServiceHandler() service.Handler {
@@ -54,6 +57,37 @@ func (api *API) getServiceHandler() service.Handler {
 }
 
 // This has to be called by the signin handler
+// getServiceGitMirrorAccessHandler returns the urls of the git VCS servers signed for the session of the VCS service,
+// the repositories service only serves the mirrors of the repositories of these servers to the VCS service.
+func (api *API) getServiceGitMirrorAccessHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if !isVCS(ctx) {
+			return sdk.WrapError(sdk.ErrForbidden, "only VCS can call this route")
+		}
+		session := getAuthSession(ctx)
+		if session == nil {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		vcsServers, err := vcs.LoadAllVCSByType(ctx, api.mustDB(), sdk.VCSTypeGit)
+		if err != nil {
+			return err
+		}
+		access := git.MirrorAccess{SessionID: session.ID}
+		for _, v := range vcsServers {
+			if sdk.CheckGitServerURL(v.URL) == nil && !sdk.IsInArray(v.URL, access.ServerURLs) {
+				access.ServerURLs = append(access.ServerURLs, v.URL)
+			}
+		}
+
+		token, err := authentication.SignJWS(access, time.Now(), time.Hour)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, sdk.GitMirrorAccessToken{Token: token}, http.StatusOK)
+	}
+}
+
 func (api *API) serviceRegister(ctx context.Context, tx gorpmapper.SqlExecutorWithTx, data *sdk.Service) error {
 	consumer := getUserConsumer(ctx)
 	data.LastHeartbeat = time.Now()
@@ -45,3 +45,25 @@ func GetCDNPublicHTTPAdress(ctx context.Context, db gorp.SqlExecutor) (string, e
 	}
 	return "", sdk.NewErrorFrom(sdk.ErrNotFound, "unable to find any http configuration in CDN Uservice")
 }
+
+// GetRepositoriesMirrorPublicHTTPAdress returns the public url of the git mirrors of the repositories services, an
+// empty string is returned if no service serves mirrors.
+func GetRepositoriesMirrorPublicHTTPAdress(ctx context.Context, db gorp.SqlExecutor) (string, error) {
+	srvs, err := LoadAllByType(ctx, db, sdk.TypeRepositories)
+	if err != nil {
+		return "", err
+	}
+	for _, svr := range srvs {
+		mirror, ok := svr.Config["mirror"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if basedir, _ := mirror["basedir"].(string); basedir == "" {
+			continue
+		}
+		if addr, _ := mirror["public_http"].(string); addr != "" {
+			return addr, nil
+		}
+	}
+	return "", nil
+}
@@ -282,9 +282,17 @@ func (api *API) initMetrics(ctx context.Context) error {
 		telemetry.NewViewLast("cds/run_results_to_synchronized_error", api.Metrics.RunResultSynchronizedError, tagsService),
 	)
 
+	if err != nil {
+		return err
+	}
+
+	if err := api.initWorkflowMetrics(ctx); err != nil {
+		return err
+	}
+
 	api.computeMetrics(ctx)
 
-	return err
+	return nil
 }
 
 func (api *API) computeMetrics(ctx context.Context) {
@@ -5,6 +5,7 @@ import (
 	"github.com/ovh/cds/engine/api/organization"
 	"net/http"
 
+	"github.com/go-gorp/gorp"
 	"github.com/gorilla/mux"
 	"github.com/lib/pq"
 
@@ -226,37 +227,9 @@ func (api *API) deleteUserHandler() service.Handler {
 			}
 		}
 
-		// We can't delete a user if it's the last admin in a group
-		var adminGroupIDs []int64
-		gus, err := group.LoadLinksGroupUserForUserIDs(ctx, tx, []string{u.ID})
-		if err != nil {
+		if err := checkUserIsNotLastGroupAdmin(ctx, tx, u.ID); err != nil {
 			return err
 		}
-		for i := range gus {
-			if gus[i].Admin {
-				adminGroupIDs = append(adminGroupIDs, gus[i].ID)
-			}
-		}
-		if len(adminGroupIDs) > 0 {
-			gus, err := group.LoadLinksGroupUserForGroupIDs(ctx, tx, adminGroupIDs)
-			if err != nil {
-				return err
-			}
-			adminLeftCount := make(map[int64]int)
-			for _, id := range adminGroupIDs {
-				adminLeftCount[id] = 0
-			}
-			for i := range gus {
-				if gus[i].AuthentifiedUserID != u.ID && gus[i].Admin {
-					adminLeftCount[gus[i].GroupID] += 1
-				}
-			}
-			for _, count := range adminLeftCount {
-				if count < 1 {
-					return sdk.NewErrorFrom(sdk.ErrForbidden, "cannot remove user because it is the last admin of a group")
-				}
-			}
-		}
 
 		if err := user.DeleteByID(tx, u.ID); err != nil {
 			return sdk.WrapError(err, "cannot delete user")
@@ -269,3 +242,40 @@ func (api *API) deleteUserHandler() service.Handler {
 		return service.WriteJSON(w, nil, http.StatusOK)
 	}
 }
+
+// checkUserIsNotLastGroupAdmin returns an error if the user is the last admin of a group, it can't be removed.
+func checkUserIsNotLastGroupAdmin(ctx context.Context, db gorp.SqlExecutor, userID string) error {
+	var adminGroupIDs []int64
+	gus, err := group.LoadLinksGroupUserForUserIDs(ctx, db, []string{userID})
+	if err != nil {
+		return err
+	}
+	for i := range gus {
+		if gus[i].Admin {
+			adminGroupIDs = append(adminGroupIDs, gus[i].GroupID)
+		}
+	}
+	if len(adminGroupIDs) == 0 {
+		return nil
+	}
+
+	gus, err = group.LoadLinksGroupUserForGroupIDs(ctx, db, adminGroupIDs)
+	if err != nil {
+		return err
+	}
+	adminLeftCount := make(map[int64]int)
+	for _, id := range adminGroupIDs {
+		adminLeftCount[id] = 0
+	}
+	for i := range gus {
+		if gus[i].AuthentifiedUserID != userID && gus[i].Admin {
+			adminLeftCount[gus[i].GroupID] += 1
+		}
+	}
+	for _, count := range adminLeftCount {
+		if count < 1 {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "cannot remove user because it is the last admin of a group")
+		}
+	}
+	return nil
+}
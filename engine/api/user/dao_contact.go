@@ -109,3 +109,9 @@ func UpdateContact(ctx context.Context, db gorpmapper.SqlExecutorWithTx, c *sdk.
 	*c = dbc.UserContact
 	return nil
 }
+
+// DeleteContact in database.
+func DeleteContact(db gorpmapper.SqlExecutorWithTx, c sdk.UserContact) error {
+	dbc := userContact{UserContact: c}
+	return sdk.WrapError(gorpmapping.Delete(db, &dbc), "unable to delete contact %d", c.ID)
+}
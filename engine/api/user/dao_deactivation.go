@@ -0,0 +1,79 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+func getDeactivations(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) ([]UserDeactivation, error) {
+	ds := []UserDeactivation{}
+
+	if err := gorpmapping.GetAll(ctx, db, q, &ds); err != nil {
+		return nil, sdk.WrapError(err, "cannot get user deactivations")
+	}
+
+	verifiedDeactivations := make([]UserDeactivation, 0, len(ds))
+	for i := range ds {
+		isValid, err := gorpmapping.CheckSignature(ds[i], ds[i].Signature)
+		if err != nil {
+			return nil, err
+		}
+		if !isValid {
+			log.Error(ctx, "authentified user deactivation %s data corrupted", ds[i].AuthentifiedUserID)
+			continue
+		}
+		verifiedDeactivations = append(verifiedDeactivations, ds[i])
+	}
+
+	return verifiedDeactivations, nil
+}
+
+// LoadDeactivatedUserIDs returns the ids of deactivated users in given ones.
+func LoadDeactivatedUserIDs(ctx context.Context, db gorp.SqlExecutor, userIDs []string) (sdk.StringSlice, error) {
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM authentified_user_deactivation
+    WHERE authentified_user_id = ANY($1)
+  `).Args(pq.StringArray(userIDs))
+	ds, err := getDeactivations(ctx, db, query)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(sdk.StringSlice, len(ds))
+	for i := range ds {
+		ids[i] = ds[i].AuthentifiedUserID
+	}
+	return ids, nil
+}
+
+// IsDeactivated returns true if given user was deactivated.
+func IsDeactivated(ctx context.Context, db gorp.SqlExecutor, userID string) (bool, error) {
+	ids, err := LoadDeactivatedUserIDs(ctx, db, []string{userID})
+	if err != nil {
+		return false, err
+	}
+	return len(ids) > 0, nil
+}
+
+// InsertDeactivation marks given user as deactivated.
+func InsertDeactivation(ctx context.Context, db gorpmapper.SqlExecutorWithTx, userID string) error {
+	d := UserDeactivation{
+		AuthentifiedUserID: userID,
+		Created:            time.Now(),
+	}
+	return sdk.WrapError(gorpmapping.InsertAndSign(ctx, db, &d), "unable to insert authentified user deactivation")
+}
+
+// DeleteDeactivation removes the deactivation of given user.
+func DeleteDeactivation(db gorp.SqlExecutor, userID string) error {
+	_, err := db.Exec("DELETE FROM authentified_user_deactivation WHERE authentified_user_id = $1", userID)
+	return sdk.WithStack(err)
+}
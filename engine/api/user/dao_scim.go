@@ -0,0 +1,130 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadAllBySCIMFilter returns the users that match given SCIM filter ordered by creation date, from given offset.
+// The total count of users that match the filter is also returned.
+func LoadAllBySCIMFilter(ctx context.Context, db gorp.SqlExecutor, filter sdk.SCIMFilter, offset, limit int, opts ...LoadOptionFunc) (sdk.AuthentifiedUsers, int64, error) {
+	where, args := scimFilterCondition(filter)
+
+	total, err := db.SelectInt("SELECT COUNT(id) FROM authentified_user WHERE "+where, args...)
+	if err != nil {
+		return nil, 0, sdk.WrapError(err, "cannot count authentified users")
+	}
+
+	query := gorpmapping.NewQuery(fmt.Sprintf(`
+    SELECT *
+    FROM authentified_user
+    WHERE %s
+    ORDER BY created, id
+    OFFSET $%d LIMIT $%d
+  `, where, len(args)+1, len(args)+2)).Args(append(args, offset, limit)...)
+	us, err := getAll(ctx, db, query, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return us, total, nil
+}
+
+// scimFilterCondition returns the SQL condition on table authentified_user for given filter. Like for SCIMFilter.Match,
+// attributes that are not stored by CDS only match with operator ne.
+func scimFilterCondition(filter sdk.SCIMFilter) (string, []interface{}) {
+	var args []interface{}
+	arg := func(v string) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d::text", len(args))
+	}
+
+	conds := []string{"true"}
+	for _, e := range filter {
+		var cond string
+		switch strings.ToLower(e.Attribute) {
+		case "id":
+			cond = SCIMValueCondition(e, "authentified_user.id", arg)
+		case "username":
+			cond = SCIMValueCondition(e, "authentified_user.username", arg)
+		case "displayname", "name.formatted":
+			cond = SCIMValueCondition(e, "authentified_user.fullname", arg)
+		case "active":
+			cond = scimActiveCondition(e)
+		case "emails", "emails.value":
+			cond = SCIMMultiValuedCondition(e, `
+        SELECT 1 FROM user_contact
+        WHERE user_contact.user_id = authentified_user.id AND user_contact.type = 'email'`, "user_contact.value", arg)
+		case "groups", "groups.value":
+			cond = SCIMMultiValuedCondition(e, `
+        SELECT 1 FROM group_authentified_user
+        WHERE group_authentified_user.authentified_user_id = authentified_user.id`, "group_authentified_user.group_id::text", arg)
+		case "groups.display":
+			cond = SCIMMultiValuedCondition(e, `
+        SELECT 1 FROM group_authentified_user
+        JOIN "group" ON "group".id = group_authentified_user.group_id
+        WHERE group_authentified_user.authentified_user_id = authentified_user.id`, `"group".name`, arg)
+		default:
+			cond = "false"
+			if e.Operator == "ne" {
+				cond = "true"
+			}
+		}
+		conds = append(conds, "("+cond+")")
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// scimActiveCondition returns the condition on the deactivation of the user, the operator is applied on the
+// values true and false.
+func scimActiveCondition(e sdk.SCIMFilterExpression) string {
+	deactivated := `EXISTS (
+        SELECT 1 FROM authentified_user_deactivation
+        WHERE authentified_user_deactivation.authentified_user_id = authentified_user.id)`
+	matchActive := sdk.SCIMFilter{e}.Match(func(string) []string { return []string{"true"} })
+	matchInactive := sdk.SCIMFilter{e}.Match(func(string) []string { return []string{"false"} })
+	switch {
+	case matchActive && matchInactive:
+		return "true"
+	case matchActive:
+		return "NOT " + deactivated
+	case matchInactive:
+		return deactivated
+	}
+	return "false"
+}
+
+// SCIMMultiValuedCondition returns a condition that is true if one of the values returned by given query matches the
+// expression, or if none of them is equal to its value with operator ne. It is also used to filter groups.
+func SCIMMultiValuedCondition(e sdk.SCIMFilterExpression, query, column string, arg func(string) string) string {
+	if e.Operator == "ne" {
+		e.Operator = "eq"
+		return "NOT EXISTS (" + query + " AND " + SCIMValueCondition(e, column, arg) + ")"
+	}
+	return "EXISTS (" + query + " AND " + SCIMValueCondition(e, column, arg) + ")"
+}
+
+// SCIMValueCondition compares the column with the value of the expression case insensitively, given arg function
+// adds the value to the query arguments and returns its placeholder.
+func SCIMValueCondition(e sdk.SCIMFilterExpression, column string, arg func(string) string) string {
+	switch e.Operator {
+	case "pr":
+		return column + " <> ''"
+	case "ne":
+		return "lower(" + column + ") <> lower(" + arg(e.Value) + ")"
+	case "co":
+		return "strpos(lower(" + column + "), lower(" + arg(e.Value) + ")) > 0"
+	case "sw":
+		v := arg(e.Value)
+		return "left(lower(" + column + "), char_length(" + v + ")) = lower(" + v + ")"
+	case "ew":
+		v := arg(e.Value)
+		return "right(lower(" + column + "), char_length(" + v + ")) = lower(" + v + ")"
+	}
+	return "lower(" + column + ") = lower(" + arg(e.Value) + ")"
+}
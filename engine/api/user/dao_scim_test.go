@@ -0,0 +1,51 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestLoadAllBySCIMFilter(t *testing.T) {
+	db, _ := test.SetupPG(t)
+
+	prefix := sdk.RandomString(10)
+	u1 := sdk.AuthentifiedUser{Username: prefix + "-first", Fullname: "John Doe", Ring: sdk.UserRingUser}
+	require.NoError(t, user.Insert(context.TODO(), db, &u1))
+	u2 := sdk.AuthentifiedUser{Username: prefix + "-second", Fullname: "Jane Doe", Ring: sdk.UserRingUser}
+	require.NoError(t, user.Insert(context.TODO(), db, &u2))
+
+	filter, err := sdk.ParseSCIMFilter(`userName sw "` + prefix + `"`)
+	require.NoError(t, err)
+	us, total, err := user.LoadAllBySCIMFilter(context.TODO(), db, filter, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	require.Len(t, us, 2)
+	require.ElementsMatch(t, []string{u1.ID, u2.ID}, []string{us[0].ID, us[1].ID})
+	first := us[0].ID
+
+	// Only the page is returned but the total counts all the users that match
+	us, total, err = user.LoadAllBySCIMFilter(context.TODO(), db, filter, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	require.Len(t, us, 1)
+	require.NotEqual(t, first, us[0].ID)
+
+	filter, err = sdk.ParseSCIMFilter(`userName sw "` + prefix + `" and displayName eq "JANE DOE"`)
+	require.NoError(t, err)
+	us, total, err = user.LoadAllBySCIMFilter(context.TODO(), db, filter, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Equal(t, u2.ID, us[0].ID)
+
+	filter, err = sdk.ParseSCIMFilter(`userName sw "` + prefix + `" and title eq "manager"`)
+	require.NoError(t, err)
+	_, total, err = user.LoadAllBySCIMFilter(context.TODO(), db, filter, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), total)
+}
@@ -1,6 +1,8 @@
 package user
 
 import (
+	"time"
+
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/engine/gorpmapper"
 	"github.com/ovh/cds/sdk"
@@ -70,10 +72,25 @@ func (o UserOrganization) Canonical() gorpmapper.CanonicalForms {
 	}
 }
 
+// UserDeactivation struct for database entity of authentified_user_deactivation table.
+type UserDeactivation struct {
+	AuthentifiedUserID string    `db:"authentified_user_id"`
+	Created            time.Time `db:"created"`
+	gorpmapper.SignedEntity
+}
+
+func (d UserDeactivation) Canonical() gorpmapper.CanonicalForms {
+	_ = []interface{}{d.AuthentifiedUserID} // Checks that fields exists at compilation
+	return []gorpmapper.CanonicalForm{
+		"{{.AuthentifiedUserID}}",
+	}
+}
+
 func init() {
 	gorpmapping.Register(gorpmapping.New(authentifiedUser{}, "authentified_user", false, "id"))
 	gorpmapping.Register(gorpmapping.New(userContact{}, "user_contact", true, "id"))
 	gorpmapping.Register(gorpmapping.New(UserOrganization{}, "authentified_user_organization", false, "id"))
 	gorpmapping.Register(gorpmapping.New(OrganizationOld{}, "authentified_user_organization_old", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbGpgKey{}, "user_gpg_key", false, "id"))
+	gorpmapping.Register(gorpmapping.New(UserDeactivation{}, "authentified_user_deactivation", false, "authentified_user_id"))
 }
@@ -2,11 +2,16 @@ package api
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/cache"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 )
@@ -29,3 +34,111 @@ func (api *API) getUserContactsHandler() service.Handler {
 		return service.WriteJSON(w, contacts, http.StatusOK)
 	}
 }
+
+// userContactVerificationDuration is the time given to a user to post the verification command of a contact.
+const userContactVerificationDuration = time.Hour
+
+func userContactVerificationCacheKey(code string) string {
+	return cache.Key("api:user:contact:verification", code)
+}
+
+// postUserContactHandler starts to link a user to its username on a repository manager, to authorize the commands
+// posted in pull request comments. The contact is added when the returned command is posted by this username, see
+// verifyChatOpsContact. Email contacts are added on signup.
+func (api *API) postUserContactHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		username := vars["permUsername"]
+
+		var contact sdk.UserContact
+		if err := service.UnmarshalBody(r, &contact); err != nil {
+			return err
+		}
+		vcsServer := strings.TrimPrefix(contact.Type, sdk.UserContactTypeVCS(""))
+		if vcsServer == "" || vcsServer == contact.Type || contact.Value == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "only repository manager contacts can be added, with type %s", sdk.UserContactTypeVCS("<vcs server>"))
+		}
+
+		var u *sdk.AuthentifiedUser
+		var err error
+		if username == "me" {
+			u, err = user.LoadByID(ctx, api.mustDB(), getUserConsumer(ctx).AuthConsumerUser.AuthentifiedUserID)
+		} else {
+			u, err = user.LoadByUsername(ctx, api.mustDB(), username)
+		}
+		if err != nil {
+			return sdk.WrapError(err, "cannot load user %s", username)
+		}
+
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return sdk.WithStack(err)
+		}
+		code := hex.EncodeToString(b)
+		pending := sdk.UserContact{
+			UserID: u.ID,
+			Type:   contact.Type,
+			Value:  contact.Value,
+		}
+		if err := api.Cache.SetWithDuration(userContactVerificationCacheKey(code), pending, userContactVerificationDuration); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, sdk.UserContactVerification{
+			Type:     pending.Type,
+			Value:    pending.Value,
+			Command:  sdk.ChatOpsCommand{Name: sdk.ChatOpsCommandVerify}.String() + " " + code,
+			ExpireAt: time.Now().Add(userContactVerificationDuration),
+		}, http.StatusAccepted)
+	}
+}
+
+func (api *API) deleteUserContactHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		username := vars["permUsername"]
+
+		contactID, err := requestVarInt(r, "contactID")
+		if err != nil {
+			return err
+		}
+
+		var u *sdk.AuthentifiedUser
+		if username == "me" {
+			u, err = user.LoadByID(ctx, api.mustDB(), getUserConsumer(ctx).AuthConsumerUser.AuthentifiedUserID)
+		} else {
+			u, err = user.LoadByUsername(ctx, api.mustDB(), username)
+		}
+		if err != nil {
+			return sdk.WrapError(err, "cannot load user %s", username)
+		}
+		contacts, err := user.LoadContactsByUserIDs(ctx, api.mustDB(), []string{u.ID})
+		if err != nil {
+			return err
+		}
+		var contact *sdk.UserContact
+		for i := range contacts {
+			if contacts[i].ID == contactID {
+				contact = &contacts[i]
+			}
+		}
+		if contact == nil {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+		if contact.Type == sdk.UserContactTypeEmail {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "email contacts can't be removed")
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		if err := user.DeleteContact(tx, *contact); err != nil {
+			return err
+		}
+
+		return sdk.WithStack(tx.Commit())
+	}
+}
@@ -101,6 +101,9 @@ func (api *API) postHatcheryHandler() ([]service.RbacChecker, service.Handler) {
 			if err := hatchery.Insert(ctx, tx, &h); err != nil {
 				return err
 			}
+			if err := auditLog(ctx, tx, sdk.AuditActionHatcheryAdd, sdk.AuditTargetHatchery, h.Name, "", nil, h); err != nil {
+				return err
+			}
 
 			c, err := authentication.NewConsumerHatchery(ctx, tx, h)
 			if err != nil {
@@ -164,6 +167,9 @@ func (api *API) deleteHatcheryHandler() ([]service.RbacChecker, service.Handler)
 			if err := hatchery.Delete(tx, reg.ID); err != nil {
 				return err
 			}
+			if err := auditLog(ctx, tx, sdk.AuditActionHatcheryDelete, sdk.AuditTargetHatchery, reg.Name, "", reg, nil); err != nil {
+				return err
+			}
 			return sdk.WithStack(tx.Commit())
 		}
 }
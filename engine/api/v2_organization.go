@@ -43,6 +43,9 @@ func (api *API) postOrganizationHandler() ([]service.RbacChecker, service.Handle
 			if err := organization.Insert(ctx, tx, &org); err != nil {
 				return err
 			}
+			if err := auditLog(ctx, tx, sdk.AuditActionOrganizationAdd, sdk.AuditTargetOrganization, org.Name, "", nil, org); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
@@ -95,6 +98,9 @@ func (api *API) deleteOrganizationHandler() ([]service.RbacChecker, service.Hand
 			if err := organization.Delete(tx, orga.ID); err != nil {
 				return err
 			}
+			if err := auditLog(ctx, tx, sdk.AuditActionOrganizationDelete, sdk.AuditTargetOrganization, orga.Name, "", orga, nil); err != nil {
+				return err
+			}
 			return sdk.WithStack(tx.Commit())
 		}
 }
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/engine/api/keys"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getProjectKeysV2Handler returns the keys of the project without their private part
+func (api *API) getProjectKeysV2Handler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			p, err := project.Load(ctx, api.mustDB(), pKey)
+			if err != nil {
+				return err
+			}
+
+			ks, err := project.LoadAllKeys(ctx, api.mustDB(), p.ID)
+			if err != nil {
+				return err
+			}
+
+			return service.WriteJSON(w, ks, http.StatusOK)
+		}
+}
+
+// getProjectKeyV2Handler returns the key given in route with its private part
+func (api *API) getProjectKeyV2Handler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectViewSecret),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+			keyName := vars["keyName"]
+
+			p, err := project.Load(ctx, api.mustDB(), pKey)
+			if err != nil {
+				return err
+			}
+
+			k, err := project.LoadKeyByProjectIDAndName(ctx, api.mustDB(), p.ID, keyName)
+			if err != nil {
+				return err
+			}
+
+			return service.WriteJSON(w, k, http.StatusOK)
+		}
+}
+
+func (api *API) postProjectKeyV2Handler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManageSecret),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			var newKey sdk.ProjectKey
+			if err := service.UnmarshalRequest(ctx, req, &newKey); err != nil {
+				return err
+			}
+			if !sdk.NamePatternRegex.MatchString(newKey.Name) {
+				return sdk.WrapError(sdk.ErrInvalidKeyPattern, "key name %s do not respect pattern %s", newKey.Name, sdk.NamePattern)
+			}
+			if !strings.HasPrefix(newKey.Name, "proj-") {
+				newKey.Name = "proj-" + newKey.Name
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+
+			p, err := project.Load(ctx, tx, pKey)
+			if err != nil {
+				return err
+			}
+			newKey.ProjectID = p.ID
+
+			k, err := keys.GenerateKey(newKey.Name, newKey.Type)
+			if err != nil {
+				return err
+			}
+			newKey.Private = k.Private
+			newKey.Public = k.Public
+			newKey.KeyID = k.KeyID
+
+			if err := project.InsertKey(tx, &newKey); err != nil {
+				return err
+			}
+
+			auditedKey := newKey
+			auditedKey.Private = "" // never store private key in audit trail
+			if err := auditLog(ctx, tx, sdk.AuditActionProjectKeyAdd, sdk.AuditTargetProjectKey, newKey.Name, p.Key, nil, auditedKey); err != nil {
+				return err
+			}
+
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+
+			event.PublishAddProjectKey(ctx, p, newKey, getUserConsumer(ctx))
+
+			newKey.Private = ""
+			return service.WriteMarshal(w, req, newKey, http.StatusCreated)
+		}
+}
+
+func (api *API) deleteProjectKeyV2Handler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManageSecret),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+			keyName := vars["keyName"]
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+
+			p, err := project.Load(ctx, tx, pKey)
+			if err != nil {
+				return err
+			}
+
+			k, err := project.LoadKeyByProjectIDAndName(ctx, tx, p.ID, keyName)
+			if err != nil {
+				return err
+			}
+			if err := project.DeleteProjectKey(tx, p.ID, k.Name); err != nil {
+				return err
+			}
+
+			k.Private = "" // never store private key in audit trail
+			if err := auditLog(ctx, tx, sdk.AuditActionProjectKeyDelete, sdk.AuditTargetProjectKey, k.Name, p.Key, k, nil); err != nil {
+				return err
+			}
+
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+
+			event.PublishDeleteProjectKey(ctx, p, *k, getUserConsumer(ctx))
+
+			return nil
+		}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_crudProjectKeyV2(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	manager, managerPass := assets.InsertLambdaUser(t, db)
+	assets.InsertRBAcProject(t, db, sdk.ProjectRoleManageSecret, proj.Key, *manager)
+	viewer, viewerPass := assets.InsertLambdaUser(t, db)
+	assets.InsertRBAcProject(t, db, sdk.ProjectRoleViewSecret, proj.Key, *viewer)
+
+	vars := map[string]string{"projectKey": proj.Key}
+	uriPost := api.Router.GetRouteV2("POST", api.postProjectKeyV2Handler, vars)
+	require.NotEmpty(t, uriPost)
+
+	// Viewing secrets doesn't allow to manage them
+	req := assets.NewAuthentifiedRequest(t, viewer, viewerPass, "POST", uriPost, sdk.ProjectKey{Name: "mykey", Type: sdk.KeyTypeSSH})
+	w := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 403, w.Code)
+
+	req = assets.NewAuthentifiedRequest(t, manager, managerPass, "POST", uriPost, sdk.ProjectKey{Name: "mykey", Type: sdk.KeyTypeSSH})
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 201, w.Code)
+	var created sdk.ProjectKey
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.Equal(t, "proj-mykey", created.Name)
+	require.Empty(t, created.Private)
+
+	vars["keyName"] = created.Name
+	uriGet := api.Router.GetRouteV2("GET", api.getProjectKeyV2Handler, vars)
+	require.NotEmpty(t, uriGet)
+	req = assets.NewAuthentifiedRequest(t, viewer, viewerPass, "GET", uriGet, nil)
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+	var k sdk.ProjectKey
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &k))
+	require.NotEmpty(t, k.Private)
+
+	uriDelete := api.Router.GetRouteV2("DELETE", api.deleteProjectKeyV2Handler, vars)
+	require.NotEmpty(t, uriDelete)
+	req = assets.NewAuthentifiedRequest(t, viewer, viewerPass, "DELETE", uriDelete, nil)
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 403, w.Code)
+
+	req = assets.NewAuthentifiedRequest(t, manager, managerPass, "DELETE", uriDelete, nil)
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+}
@@ -32,7 +32,7 @@ func (api *API) getRepositoryByIdentifier(ctx context.Context, vcsID string, rep
 
 // deleteProjectRepositoryHandler Delete a repository from a project
 func (api *API) deleteProjectRepositoryHandler() ([]service.RbacChecker, service.Handler) {
-	return service.RBAC(api.projectManage),
+	return service.RBAC(api.projectManageRepository),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
 			vars := mux.Vars(req)
 			pKey := vars["projectKey"]
@@ -87,7 +87,7 @@ func (api *API) deleteProjectRepositoryHandler() ([]service.RbacChecker, service
 
 // postProjectRepositoryHandler Attach a new repository to the given project
 func (api *API) postProjectRepositoryHandler() ([]service.RbacChecker, service.Handler) {
-	return service.RBAC(api.projectManage),
+	return service.RBAC(api.projectManageRepository),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
 			vars := mux.Vars(req)
 			pKey := vars["projectKey"]
@@ -200,7 +200,7 @@ func (api *API) getVCSProjectRepositoryAllHandler() ([]service.RbacChecker, serv
 }
 
 func (api *API) postRepositoryHookRegenKeyHandler() ([]service.RbacChecker, service.Handler) {
-	return service.RBAC(api.projectManage),
+	return service.RBAC(api.projectManageRepository),
 		func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 			vars := mux.Vars(r)
 			pKey := vars["projectKey"]
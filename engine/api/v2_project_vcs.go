@@ -30,7 +30,7 @@ func (api *API) getVCSByIdentifier(ctx context.Context, projectKey string, vcsId
 }
 
 func (api *API) postVCSProjectHandler() ([]service.RbacChecker, service.Handler) {
-	return service.RBAC(api.projectManage),
+	return service.RBAC(api.projectManageVCS),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
 			vars := mux.Vars(req)
 			pKey := vars["projectKey"]
@@ -80,7 +80,7 @@ func (api *API) postVCSProjectHandler() ([]service.RbacChecker, service.Handler)
 }
 
 func (api *API) putVCSProjectHandler() ([]service.RbacChecker, service.Handler) {
-	return service.RBAC(api.projectManage),
+	return service.RBAC(api.projectManageVCS),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
 			vars := mux.Vars(req)
 			pKey := vars["projectKey"]
@@ -133,7 +133,7 @@ func (api *API) putVCSProjectHandler() ([]service.RbacChecker, service.Handler)
 }
 
 func (api *API) deleteVCSProjectHandler() ([]service.RbacChecker, service.Handler) {
-	return service.RBAC(api.projectManage),
+	return service.RBAC(api.projectManageVCS),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
 			vars := mux.Vars(req)
 			pKey := vars["projectKey"]
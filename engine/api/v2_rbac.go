@@ -3,10 +3,19 @@ package api
 import (
 	"context"
 	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/sdk"
 
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/hatchery"
+	"github.com/ovh/cds/engine/api/organization"
 	"github.com/ovh/cds/engine/api/rbac"
+	"github.com/ovh/cds/engine/api/region"
+	"github.com/ovh/cds/engine/api/user"
 	"github.com/ovh/cds/engine/service"
 )
 
@@ -47,6 +56,9 @@ func (api *API) postImportRbacHandler() ([]service.RbacChecker, service.Handler)
 			if err := rbac.Insert(ctx, tx, &rbacRule); err != nil {
 				return err
 			}
+			if err := auditLog(ctx, tx, sdk.AuditActionRBACImport, sdk.AuditTargetRBAC, rbacRule.Name, "", existingRule, rbacRule); err != nil {
+				return err
+			}
 
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
@@ -54,3 +66,264 @@ func (api *API) postImportRbacHandler() ([]service.RbacChecker, service.Handler)
 			return service.WriteMarshal(w, req, nil, http.StatusCreated)
 		}
 }
+
+// postRbacExplainHandler explains the permission decision for a user on a route or for a role on a target.
+// Users can explain their own permissions, explaining someone else's requires the manage-permission role.
+func (api *API) postRbacExplainHandler() ([]service.RbacChecker, service.Handler) {
+	return nil,
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			consumer := getUserConsumer(ctx)
+
+			var explainReq sdk.RBACExplainRequest
+			if err := service.UnmarshalRequest(ctx, req, &explainReq); err != nil {
+				return err
+			}
+			if err := explainReq.IsValid(); err != nil {
+				return err
+			}
+
+			// Permissions are checked before loading the user to not disclose which users exist
+			if !isAdmin(ctx) && !api.rbacExplainIsCurrentUser(ctx, explainReq) {
+				if err := api.globalPermissionManage(ctx, consumer, api.Cache, api.mustDB(), nil); err != nil {
+					return err
+				}
+			}
+
+			u, err := api.rbacExplainUser(ctx, explainReq)
+			if err != nil {
+				return err
+			}
+
+			groups, err := group.LoadAllByUserID(ctx, api.mustDB(), u.ID)
+			if err != nil {
+				return err
+			}
+			subject := rbac.ExplainSubject{UserID: u.ID, Groups: groups}
+			if u.Organization != "" {
+				org, err := organization.LoadOrganizationByName(ctx, api.mustDB(), u.Organization)
+				if err != nil {
+					return err
+				}
+				subject.OrganizationID = org.ID
+			}
+
+			rbacs, err := rbac.LoadAll(ctx, api.mustDB(), rbac.LoadOptions.LoadRBACGlobal, rbac.LoadOptions.LoadRBACProject,
+				rbac.LoadOptions.LoadRBACRegion, rbac.LoadOptions.LoadRBACHatchery)
+			if err != nil {
+				return err
+			}
+			var whatIf string
+			if explainReq.WhatIf != nil {
+				if err := rbac.FillWithIDs(ctx, api.mustDB(), explainReq.WhatIf); err != nil {
+					return err
+				}
+				if err := sdk.IsValidRBAC(explainReq.WhatIf); err != nil {
+					return err
+				}
+				whatIf = explainReq.WhatIf.Name
+				var replaced bool
+				for i := range rbacs {
+					if rbacs[i].Name == whatIf {
+						rbacs[i] = *explainReq.WhatIf
+						replaced = true
+					}
+				}
+				if !replaced {
+					rbacs = append(rbacs, *explainReq.WhatIf)
+				}
+			}
+
+			explanation := sdk.RBACExplanation{
+				Username: u.Username,
+				Admin:    u.Ring == sdk.UserRingAdmin,
+			}
+			var requirements []sdk.RBACExplainRequirement
+			if explainReq.Path != "" {
+				explanation.Route, requirements, err = api.rbacExplainRouteRequirements(ctx, explainReq.Method, explainReq.Path)
+				if err != nil {
+					return err
+				}
+			} else {
+				requirements = []sdk.RBACExplainRequirement{rbacExplainRoleRequirement(explainReq)}
+			}
+
+			explanation.Allowed = true
+			for i := range requirements {
+				r := &requirements[i]
+				if r.Type != "" {
+					target, err := api.rbacExplainTarget(ctx, *r)
+					if err != nil {
+						return err
+					}
+					rbac.Explain(rbacs, whatIf, subject, target, r)
+				}
+				explanation.Allowed = explanation.Allowed && r.Allowed
+			}
+			explanation.Requirements = requirements
+
+			return service.WriteJSON(w, explanation, http.StatusOK)
+		}
+}
+
+// rbacExplainIsCurrentUser returns true if the permissions of the current user are explained. An unknown consumer is
+// handled like the consumer of another user.
+func (api *API) rbacExplainIsCurrentUser(ctx context.Context, explainReq sdk.RBACExplainRequest) bool {
+	consumer := getUserConsumer(ctx)
+	switch {
+	case explainReq.ConsumerID != "":
+		c, err := authentication.LoadUserConsumerByID(ctx, api.mustDB(), explainReq.ConsumerID)
+		return err == nil && c.AuthConsumerUser.AuthentifiedUserID == consumer.AuthConsumerUser.AuthentifiedUserID
+	case explainReq.Username != "":
+		return consumer.AuthConsumerUser.AuthentifiedUser != nil && explainReq.Username == consumer.AuthConsumerUser.AuthentifiedUser.Username
+	}
+	return true
+}
+
+func (api *API) rbacExplainUser(ctx context.Context, explainReq sdk.RBACExplainRequest) (*sdk.AuthentifiedUser, error) {
+	switch {
+	case explainReq.ConsumerID != "":
+		c, err := authentication.LoadUserConsumerByID(ctx, api.mustDB(), explainReq.ConsumerID)
+		if err != nil {
+			return nil, err
+		}
+		return user.LoadByID(ctx, api.mustDB(), c.AuthConsumerUser.AuthentifiedUserID, user.LoadOptions.WithOrganization)
+	case explainReq.Username != "":
+		return user.LoadByUsername(ctx, api.mustDB(), explainReq.Username, user.LoadOptions.WithOrganization)
+	}
+	return user.LoadByID(ctx, api.mustDB(), getUserConsumer(ctx).AuthConsumerUser.AuthentifiedUserID, user.LoadOptions.WithOrganization)
+}
+
+// rbacExplainRoleRequirement returns the requirement for a role, its type is given by the most specific target.
+func rbacExplainRoleRequirement(explainReq sdk.RBACExplainRequest) sdk.RBACExplainRequirement {
+	r := sdk.RBACExplainRequirement{
+		Roles:      []string{explainReq.Role},
+		Project:    explainReq.Project,
+		Repository: explainReq.Repository,
+		Entity:     explainReq.Entity,
+		Region:     explainReq.Region,
+		Hatchery:   explainReq.Hatchery,
+	}
+	switch {
+	case explainReq.Hatchery != "":
+		r.Type = sdk.RBACTypeHatchery
+	case explainReq.Region != "":
+		r.Type = sdk.RBACTypeRegion
+	case explainReq.Project != "":
+		r.Type = sdk.RBACTypeProject
+	default:
+		r.Type = sdk.RBACTypeGlobal
+	}
+	return r
+}
+
+// rbacExplainCheckers returns the requirements checked by each RBAC checker, targets are given by route vars.
+func (api *API) rbacExplainCheckers() map[string]sdk.RBACExplainRequirement {
+	global := func(role string) sdk.RBACExplainRequirement {
+		return sdk.RBACExplainRequirement{Type: sdk.RBACTypeGlobal, Roles: []string{role}}
+	}
+	project := func(roles ...string) sdk.RBACExplainRequirement {
+		return sdk.RBACExplainRequirement{Type: sdk.RBACTypeProject, Roles: roles}
+	}
+	return map[string]sdk.RBACExplainRequirement{
+		sdk.GetFuncName(api.globalPermissionManage):   global(sdk.GlobalRoleManagePermission),
+		sdk.GetFuncName(api.globalOrganizationManage): global(sdk.GlobalRoleManageOrganization),
+		sdk.GetFuncName(api.globalRegionManage):       global(sdk.GlobalRoleManageRegion),
+		sdk.GetFuncName(api.globalHatcheryManage):     global(sdk.GlobalRoleManageHatchery),
+		sdk.GetFuncName(api.projectRead):              project(sdk.ProjectRoleRead),
+		sdk.GetFuncName(api.workerModelRead):          project(sdk.ProjectRoleRead),
+		sdk.GetFuncName(api.projectManage):            project(sdk.ProjectRoleManage),
+		sdk.GetFuncName(api.projectManageVCS):         project(sdk.ProjectRoleManageVCS, sdk.ProjectRoleManage),
+		sdk.GetFuncName(api.projectManageRepository):  project(sdk.ProjectRoleManageRepository, sdk.ProjectRoleManage),
+		sdk.GetFuncName(api.projectTriggerRun):        project(sdk.ProjectRoleTriggerRun, sdk.ProjectRoleManage),
+		sdk.GetFuncName(api.projectManageSecret):      project(sdk.ProjectRoleManageSecret, sdk.ProjectRoleManage),
+		sdk.GetFuncName(api.projectViewSecret):        project(sdk.ProjectRoleViewSecret, sdk.ProjectRoleManageSecret, sdk.ProjectRoleManage),
+		sdk.GetFuncName(api.regionRead):               {Type: sdk.RBACTypeRegion, Roles: []string{sdk.RegionRoleList}},
+		sdk.GetFuncName(api.isCurrentUser):            {Message: "only allowed for the user given in route"},
+		sdk.GetFuncName(api.isHatchery):               {Message: "only allowed for hatcheries"},
+		sdk.GetFuncName(api.isHookService):            {Message: "only allowed for the hooks service"},
+	}
+}
+
+// rbacExplainRouteRequirements returns the requirements of the route matching given method and path.
+func (api *API) rbacExplainRouteRequirements(ctx context.Context, method, path string) (string, []sdk.RBACExplainRequirement, error) {
+	if !strings.HasPrefix(path, api.Router.Prefix+"/") {
+		path = api.Router.Prefix + path
+	}
+	routeReq, err := http.NewRequestWithContext(ctx, method, path, nil)
+	if err != nil {
+		return "", nil, sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+	var match mux.RouteMatch
+	if !api.Router.Mux.Match(routeReq, &match) || match.Route == nil {
+		return "", nil, sdk.NewErrorFrom(sdk.ErrNotFound, "no route found for %s %s", method, path)
+	}
+	uri, err := match.Route.GetPathTemplate()
+	if err != nil {
+		return "", nil, sdk.WithStack(err)
+	}
+	routerConfig, has := api.Router.mapRouterConfigs[uri]
+	if !has {
+		return "", nil, sdk.NewErrorFrom(sdk.ErrNotFound, "no route found for %s %s", method, path)
+	}
+	handlerConfig, has := routerConfig.Config[method]
+	if !has {
+		return "", nil, sdk.NewErrorFrom(sdk.ErrNotFound, "no handler found for %s %s", method, uri)
+	}
+
+	// Resolve targets from route vars like the checkers do
+	repository, err := api.repositoryNameFromVars(ctx, match.Vars)
+	if err != nil {
+		return "", nil, err
+	}
+
+	checkers := api.rbacExplainCheckers()
+	requirements := make([]sdk.RBACExplainRequirement, 0, len(handlerConfig.RbacCheckers))
+	for _, c := range handlerConfig.RbacCheckers {
+		name := sdk.GetFuncName(c)
+		r, has := checkers[name]
+		if !has {
+			r.Message = "unknown checker"
+		}
+		r.Checker = name
+		switch r.Type {
+		case sdk.RBACTypeProject:
+			r.Project = match.Vars["projectKey"]
+			switch name {
+			case sdk.GetFuncName(api.projectManageRepository):
+				r.Repository = repository
+			case sdk.GetFuncName(api.projectTriggerRun):
+				r.Entity = match.Vars["workflowName"]
+			}
+		case sdk.RBACTypeRegion:
+			r.Region = match.Vars["regionIdentifier"]
+		}
+		requirements = append(requirements, r)
+	}
+	return method + " " + uri, requirements, nil
+}
+
+// rbacExplainTarget resolves identifiers of the requirement's target.
+func (api *API) rbacExplainTarget(ctx context.Context, r sdk.RBACExplainRequirement) (rbac.ExplainTarget, error) {
+	t := rbac.ExplainTarget{
+		ProjectKey: r.Project,
+		Repository: r.Repository,
+		Entity:     r.Entity,
+		RegionID:   r.Region,
+		HatcheryID: r.Hatchery,
+	}
+	if t.RegionID != "" && !sdk.IsValidUUID(t.RegionID) {
+		reg, err := region.LoadRegionByName(ctx, api.mustDB(), t.RegionID)
+		if err != nil {
+			return t, err
+		}
+		t.RegionID = reg.ID
+	}
+	if t.HatcheryID != "" && !sdk.IsValidUUID(t.HatcheryID) {
+		h, err := hatchery.LoadHatcheryByName(ctx, api.mustDB(), t.HatcheryID)
+		if err != nil {
+			return t, err
+		}
+		t.HatcheryID = h.ID
+	}
+	return t, nil
+}
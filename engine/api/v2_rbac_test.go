@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/ovh/cds/engine/api/rbac"
 	"io"
@@ -67,3 +68,86 @@ globals:
 	require.Equal(t, u.ID, rbacDB.Projects[0].RBACUsersIDs[0])
 	require.Equal(t, g.ID, rbacDB.Projects[0].RBACGroupsIDs[0])
 }
+
+func Test_postRbacExplainHandler(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	_, err := db.Exec("DELETE FROM rbac")
+	require.NoError(t, err)
+
+	p := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	g := assets.InsertTestGroup(t, db, sdk.RandomString(10))
+	u, pass := assets.InsertLambdaUser(t, db, g)
+
+	perm := sdk.RBAC{
+		Name: "perm-explain",
+		Projects: []sdk.RBACProject{{
+			Role:            sdk.ProjectRoleRead,
+			RBACProjectKeys: []string{p.Key},
+			RBACGroupsIDs:   []int64{g.ID},
+		}},
+	}
+	require.NoError(t, rbac.Insert(context.TODO(), db, &perm))
+
+	uri := api.Router.GetRouteV2("POST", api.postRbacExplainHandler, nil)
+	test.NotEmpty(t, uri)
+
+	// Explain a route for the current user
+	req := assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, sdk.RBACExplainRequest{
+		Method: "POST",
+		Path:   "/v2/project/" + p.Key + "/vcs",
+	})
+	w := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+
+	var explanation sdk.RBACExplanation
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &explanation))
+	require.False(t, explanation.Allowed)
+	require.Len(t, explanation.Requirements, 1)
+	require.Equal(t, []string{sdk.ProjectRoleManageVCS, sdk.ProjectRoleManage}, explanation.Requirements[0].Roles)
+	require.Equal(t, p.Key, explanation.Requirements[0].Project)
+
+	// Simulate a new permission on the role
+	req = assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, sdk.RBACExplainRequest{
+		Role:    sdk.ProjectRoleManageVCS,
+		Project: p.Key,
+		WhatIf: &sdk.RBAC{
+			Name: "perm-explain",
+			Projects: []sdk.RBACProject{{
+				Role:            sdk.ProjectRoleManageVCS,
+				RBACProjectKeys: []string{p.Key},
+				RBACGroupsName:  []string{g.Name},
+			}},
+		},
+	})
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+
+	explanation = sdk.RBACExplanation{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &explanation))
+	require.True(t, explanation.Allowed)
+	require.Len(t, explanation.Requirements[0].Grants, 1)
+	require.True(t, explanation.Requirements[0].Grants[0].WhatIf)
+	require.Equal(t, []string{"group:" + g.Name}, explanation.Requirements[0].Grants[0].Via)
+
+	// Explaining another user's permissions is forbidden
+	admin, _ := assets.InsertAdminUser(t, db)
+	req = assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, sdk.RBACExplainRequest{
+		Username: admin.Username,
+		Role:     sdk.GlobalRoleManagePermission,
+	})
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 403, w.Code)
+
+	// Same response for an unknown user
+	req = assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, sdk.RBACExplainRequest{
+		Username: sdk.RandomString(10),
+		Role:     sdk.GlobalRoleManagePermission,
+	})
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 403, w.Code)
+}
@@ -44,6 +44,9 @@ func (api *API) postRegionHandler() ([]service.RbacChecker, service.Handler) {
 			if err := region.Insert(ctx, tx, &reg); err != nil {
 				return err
 			}
+			if err := auditLog(ctx, tx, sdk.AuditActionRegionAdd, sdk.AuditTargetRegion, reg.Name, "", nil, reg); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
@@ -117,6 +120,9 @@ func (api *API) deleteRegionHandler() ([]service.RbacChecker, service.Handler) {
 			if err := region.Delete(tx, reg.ID); err != nil {
 				return err
 			}
+			if err := auditLog(ctx, tx, sdk.AuditActionRegionDelete, sdk.AuditTargetRegion, reg.Name, "", reg, nil); err != nil {
+				return err
+			}
 			return sdk.WithStack(tx.Commit())
 		}
 }
@@ -234,6 +234,7 @@ func (api *API) repositoryAnalysisPoller(ctx context.Context, tick time.Duration
 }
 
 func (api *API) analyzeRepository(ctx context.Context, projectRepoID string, analysisID string) error {
+	ctx = repositoriesmanager.ContextWithBackgroundPriority(ctx)
 	ctx, next := telemetry.Span(ctx, "api.analyzeRepository.lock")
 	defer next()
 
@@ -274,7 +275,7 @@ func (api *API) analyzeRepository(ctx context.Context, projectRepoID string, ana
 
 	var keyID, analysisError string
 	switch vcsProjectWithSecret.Type {
-	case sdk.VCSTypeBitbucketServer, sdk.VCSTypeBitbucketCloud, sdk.VCSTypeGitlab, sdk.VCSTypeGerrit:
+	case sdk.VCSTypeBitbucketServer, sdk.VCSTypeBitbucketCloud, sdk.VCSTypeGitlab, sdk.VCSTypeGerrit, sdk.VCSTypeGit:
 		keyID, analysisError, err = api.analyzeCommitSignatureThroughOperation(ctx, analysis, *vcsProjectWithSecret, *repo)
 		if err != nil {
 			return api.stopAnalysis(ctx, analysis, err)
@@ -320,10 +321,16 @@ func (api *API) analyzeRepository(ctx context.Context, projectRepoID string, ana
 			analysis.Data.CDSUserID = cdsUser.ID
 			analysis.Data.CDSUserName = cdsUser.Username
 
-			// Check user right
-			b, err := rbac.HasRoleOnProjectAndUserID(ctx, api.mustDB(), sdk.ProjectRoleManage, cdsUser.ID, analysis.ProjectKey)
-			if err != nil {
-				return api.stopAnalysis(ctx, analysis, err)
+			// Check user right, rights on each entity are checked once files are read
+			var b bool
+			for _, role := range []string{sdk.ProjectRoleManage, sdk.ProjectRoleManageWorkerModel} {
+				b, err = rbac.HasRoleOnProjectRepositoryAndUserID(ctx, api.mustDB(), role, cdsUser.ID, analysis.ProjectKey, repo.Name)
+				if err != nil {
+					return api.stopAnalysis(ctx, analysis, err)
+				}
+				if b {
+					break
+				}
 			}
 			if !b {
 				analysis.Status = sdk.RepositoryAnalysisStatusSkipped
@@ -335,7 +342,7 @@ func (api *API) analyzeRepository(ctx context.Context, projectRepoID string, ana
 				case sdk.VCSTypeBitbucketServer, sdk.VCSTypeBitbucketCloud:
 					// get archive
 					filesContent, err = api.getCdsArchiveFileOnRepo(ctx, *repo, analysis, vcsProjectWithSecret.Name)
-				case sdk.VCSTypeGitlab, sdk.VCSTypeGithub, sdk.VCSTypeGitea:
+				case sdk.VCSTypeGitlab, sdk.VCSTypeGithub, sdk.VCSTypeGitea, sdk.VCSTypeGit:
 					analysis.Data.Entities = make([]sdk.ProjectRepositoryDataEntity, 0)
 					filesContent, err = api.getCdsFilesOnVCSDirectory(ctx, analysis, vcsProjectWithSecret.Name, repo.Name, analysis.Commit, ".cds")
 				case sdk.VCSTypeGerrit:
@@ -372,6 +379,22 @@ func (api *API) analyzeRepository(ctx context.Context, projectRepoID string, ana
 		return api.stopAnalysis(ctx, analysis, multiErr...)
 	}
 
+	// Worker models and templates are the only entities read from repositories
+	for _, e := range entities {
+		role, err := rbac.HasOneRoleOnProjectTargetAndUserID(ctx, tx, analysis.Data.CDSUserID, analysis.ProjectKey, repo.Name, e.Name, sdk.ProjectRoleManage, sdk.ProjectRoleManageWorkerModel)
+		if err != nil {
+			return api.stopAnalysis(ctx, analysis, err)
+		}
+		if role == "" {
+			analysis.Status = sdk.RepositoryAnalysisStatusSkipped
+			analysis.Data.Error = fmt.Sprintf("user %s doesn't have enough right on %s %s of project %s", analysis.Data.CDSUserID, e.Type, e.Name, analysis.ProjectKey)
+			if err := repository.UpdateAnalysis(ctx, tx, analysis); err != nil {
+				return sdk.WrapError(err, "unable to update analysis")
+			}
+			return sdk.WithStack(tx.Commit())
+		}
+	}
+
 	for i := range entities {
 		e := &entities[i]
 		existingEntity, err := entity.LoadByBranchTypeName(ctx, tx, e.ProjectRepositoryID, e.Branch, e.Type, e.Name)
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// postWorkflowRunV2Handler starts a new run of the workflow given in route, the permission to run the workflow is given
+// by the trigger-run project role instead of the groups of the workflow.
+func (api *API) postWorkflowRunV2Handler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectTriggerRun),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+			workflowName := vars["workflowName"]
+
+			consumer := getUserConsumer(ctx)
+			if consumer.AuthConsumerUser.Worker != nil {
+				return sdk.WrapError(sdk.ErrForbidden, "not authorized for worker")
+			}
+
+			var manual sdk.WorkflowNodeRunManual
+			if err := service.UnmarshalRequest(ctx, req, &manual); err != nil {
+				return err
+			}
+			if manual.OnlyFailedJobs || manual.Resync {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "only new runs can be started")
+			}
+
+			p, err := project.Load(ctx, api.mustDB(), pKey,
+				project.LoadOptions.WithVariables,
+				project.LoadOptions.WithIntegrations,
+			)
+			if err != nil {
+				return sdk.WrapError(err, "cannot load project")
+			}
+
+			wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *p, workflowName, workflow.LoadOptions{
+				DeepPipeline:          true,
+				WithAsCodeUpdateEvent: true,
+				WithIcon:              true,
+				WithIntegrations:      true,
+				WithTemplate:          true,
+			})
+			if err != nil {
+				return sdk.WrapError(err, "unable to load workflow %s", workflowName)
+			}
+
+			// The run is crafted asynchronously
+			run, err := workflow.CreateRun(api.mustDB(), wf, sdk.WorkflowRunPostHandlerOption{
+				Manual:         &manual,
+				AuthConsumerID: consumer.ID,
+			})
+			if err != nil {
+				return err
+			}
+
+			api.setWorkflowRunURLs(run)
+
+			return service.WriteMarshal(w, req, run, http.StatusAccepted)
+		}
+}
@@ -102,14 +102,19 @@ func LoadVCSByID(ctx context.Context, db gorp.SqlExecutor, projectKey string, vc
 }
 
 func LoadAllVCSGerrit(ctx context.Context, db gorp.SqlExecutor, opts ...gorpmapping.GetOptionFunc) ([]sdk.VCSProject, error) {
+	return LoadAllVCSByType(ctx, db, sdk.VCSTypeGerrit, opts...)
+}
+
+// LoadAllVCSByType returns the vcs of all the projects with given type.
+func LoadAllVCSByType(ctx context.Context, db gorp.SqlExecutor, vcsType string, opts ...gorpmapping.GetOptionFunc) ([]sdk.VCSProject, error) {
 	var res []dbVCSProject
 
-	query := gorpmapping.NewQuery(`SELECT vcs_project.* FROM vcs_project WHERE vcs_project.type = 'gerrit'`)
+	query := gorpmapping.NewQuery(`SELECT vcs_project.* FROM vcs_project WHERE vcs_project.type = $1`).Args(vcsType)
 
 	if err := gorpmapping.GetAll(ctx, db, query, &res, opts...); err != nil {
 		return nil, err
 	}
-	vcsGerritProjects := make([]sdk.VCSProject, 0, len(res))
+	vcsProjects := make([]sdk.VCSProject, 0, len(res))
 
 	for _, res := range res {
 		isValid, err := gorpmapping.CheckSignature(res, res.Signature)
@@ -120,7 +125,7 @@ func LoadAllVCSGerrit(ctx context.Context, db gorp.SqlExecutor, opts ...gorpmapp
 			log.Error(ctx, "vcs_project %d data corrupted", res.ID)
 			continue
 		}
-		vcsGerritProjects = append(vcsGerritProjects, res.VCSProject)
+		vcsProjects = append(vcsProjects, res.VCSProject)
 	}
-	return vcsGerritProjects, nil
+	return vcsProjects, nil
 }
@@ -20,10 +20,13 @@ import (
 	"github.com/ovh/cds/engine/api/workflow"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/telemetry"
 )
 
 func (api *API) postRegisterWorkerHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		start := time.Now()
+
 		// First get the jwt token to checks where this registration is coming from
 		jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
 		if jwt == "" {
@@ -103,6 +106,18 @@ func (api *API) postRegisterWorkerHandler() service.Handler {
 			return sdk.WithStack(err)
 		}
 
+		if runNodeJob != nil {
+			if traceID, ok := telemetry.TraceIDFromHeaders(runNodeJob.Header); ok {
+				telemetry.ExportSpan(ctx, telemetry.WorkflowSpanData(traceID,
+					telemetry.WorkflowSpanID(traceID, telemetry.WorkflowSpanRegister, runNodeJob.ID),
+					telemetry.WorkflowSpanID(traceID, telemetry.WorkflowSpanJobQueue, runNodeJob.ID),
+					telemetry.WorkflowSpanRegister, start, time.Now(), false, map[string]interface{}{
+						telemetry.TagWorker:             wk.Name,
+						telemetry.TagWorkflowNodeJobRun: runNodeJob.ID,
+					}))
+			}
+		}
+
 		jwt, err = authentication.NewSessionJWT(workerSession, "")
 		if err != nil {
 			return sdk.NewErrorWithStack(
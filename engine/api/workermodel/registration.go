@@ -42,7 +42,7 @@ func ComputeRegistrationNeeds(db gorp.SqlExecutor, allBinaryReqs sdk.Requirement
 			nbModelReq++
 		case sdk.HostnameRequirement:
 			nbHostnameReq++
-		case sdk.RegionRequirement:
+		case sdk.RegionRequirement, sdk.RegionPreferenceRequirement:
 			nbRegionReq++
 		}
 	}
@@ -64,6 +64,10 @@ func insertNodeContextData(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.Node) er
 		}
 	}
 
+	if err := n.Context.PathFilter.IsValid(); err != nil {
+		return err
+	}
+
 	var errC error
 	tempContext.Conditions, errC = gorpmapping.JSONToNullString(n.Context.Conditions)
 	if errC != nil {
@@ -3,6 +3,7 @@ package workflow
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -24,8 +25,10 @@ type QueueFilter struct {
 	Since     *time.Time
 	Until     *time.Time
 	Limit     *int
-	Statuses  []string
-	Regions   []string
+	// LimitByProject is the maximum count of jobs loaded for each project and priority class, oldest first
+	LimitByProject *int
+	Statuses       []string
+	Regions        []string
 }
 
 func NewQueueFilter() QueueFilter {
@@ -101,7 +104,7 @@ func LoadNodeJobRunQueue(ctx context.Context, db gorp.SqlExecutor, store cache.S
 		pq.StringArray(filter.Regions),   // $5
 	)
 
-	return loadNodeJobRunQueue(ctx, db, store, query, filter.Limit)
+	return loadNodeJobRunQueue(ctx, db, store, query, filter)
 }
 
 // LoadNodeJobRunQueueByGroupIDs load all workflow_node_run_job accessible
@@ -179,15 +182,29 @@ func LoadNodeJobRunQueueByGroupIDs(ctx context.Context, db gorp.SqlExecutor, sto
 		filter.Rights,                          // $7
 		pq.StringArray(filter.Regions),         // $8
 	)
-	return loadNodeJobRunQueue(ctx, db, store, query, filter.Limit)
+	return loadNodeJobRunQueue(ctx, db, store, query, filter)
 }
 
-func loadNodeJobRunQueue(ctx context.Context, db gorp.SqlExecutor, store cache.Store, query gorpmapping.Query, limit *int) ([]sdk.WorkflowNodeJobRun, error) {
+func loadNodeJobRunQueue(ctx context.Context, db gorp.SqlExecutor, store cache.Store, query gorpmapping.Query, filter QueueFilter) ([]sdk.WorkflowNodeJobRun, error) {
 	ctx, end := telemetry.Span(ctx, "workflow.loadNodeJobRunQueue")
 	defer end()
 
-	if limit != nil && *limit > 0 {
-		query = query.Limit(*limit)
+	if filter.LimitByProject != nil && *filter.LimitByProject > 0 {
+		query = gorpmapping.NewQuery(fmt.Sprintf(`
+	WITH queue AS (%s),
+	ranked_queue AS (
+		SELECT id, ROW_NUMBER() OVER (PARTITION BY project_id, job->>'priority_class' ORDER BY queued, id) AS project_rank
+		FROM queue
+	)
+	SELECT queue.*
+	FROM queue
+	JOIN ranked_queue ON ranked_queue.id = queue.id
+	WHERE ranked_queue.project_rank <= $%d
+	ORDER BY queue.queued ASC
+	`, query.Query.Query, len(query.Arguments)+1)).Args(append(query.Arguments, *filter.LimitByProject)...)
+	}
+	if filter.Limit != nil && *filter.Limit > 0 {
+		query = query.Limit(*filter.Limit)
 	}
 
 	var sqlJobs []JobRun
@@ -67,6 +67,12 @@ func InsertNotification(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.WorkflowNot
 	n.ID = 0
 	n.NodeIDs = nil
 
+	if n.Settings.Template != nil {
+		if err := n.Settings.Template.IsValid(); err != nil {
+			return err
+		}
+	}
+
 	for _, s := range n.SourceNodeRefs {
 		nodeFoundRef := w.WorkflowData.NodeByName(s)
 		if nodeFoundRef == nil || nodeFoundRef.ID == 0 {
@@ -0,0 +1,275 @@
+package workflow
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+// InsertQueueQuota creates a new queue quota.
+func InsertQueueQuota(db gorp.SqlExecutor, q *sdk.QueueQuota) error {
+	if err := q.IsValid(); err != nil {
+		return err
+	}
+	query := `INSERT INTO queue_quota (project_key, organization, weight, max_concurrent_jobs_per_model)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created, last_modified`
+	if err := db.QueryRow(query, q.ProjectKey, q.Organization, q.Weight, q.MaxConcurrentJobsPerModel).Scan(&q.ID, &q.Created, &q.LastModified); err != nil {
+		if e, ok := err.(*pq.Error); ok && e.Code == gorpmapper.ViolateUniqueKeyPGCode {
+			return sdk.NewErrorFrom(sdk.ErrAlreadyExist, "a queue quota already exists for this project or organization")
+		}
+		return sdk.WrapError(err, "unable to insert queue quota")
+	}
+	return nil
+}
+
+// UpdateQueueQuota updates the weight and the max concurrent jobs of a queue quota.
+func UpdateQueueQuota(db gorp.SqlExecutor, q *sdk.QueueQuota) error {
+	if err := q.IsValid(); err != nil {
+		return err
+	}
+	q.LastModified = time.Now()
+	query := `UPDATE queue_quota SET weight = $2, max_concurrent_jobs_per_model = $3, last_modified = $4 WHERE id = $1`
+	if _, err := db.Exec(query, q.ID, q.Weight, q.MaxConcurrentJobsPerModel, q.LastModified); err != nil {
+		return sdk.WrapError(err, "unable to update queue quota %d", q.ID)
+	}
+	return nil
+}
+
+// DeleteQueueQuota removes a queue quota.
+func DeleteQueueQuota(db gorp.SqlExecutor, id int64) error {
+	_, err := db.Exec(`DELETE FROM queue_quota WHERE id = $1`, id)
+	return sdk.WrapError(err, "unable to delete queue quota %d", id)
+}
+
+// LoadQueueQuotas returns all the queue quotas.
+func LoadQueueQuotas(db gorp.SqlExecutor) ([]sdk.QueueQuota, error) {
+	var res []sdk.QueueQuota
+	if _, err := db.Select(&res, `SELECT * FROM queue_quota ORDER BY organization, project_key`); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return res, nil
+}
+
+// LoadQueueQuotaByID returns a queue quota.
+func LoadQueueQuotaByID(db gorp.SqlExecutor, id int64) (*sdk.QueueQuota, error) {
+	var res sdk.QueueQuota
+	if err := db.SelectOne(&res, `SELECT * FROM queue_quota WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.WithStack(sdk.ErrNotFound)
+		}
+		return nil, sdk.WithStack(err)
+	}
+	return &res, nil
+}
+
+// queueProjectOrganization is the organization used to find the quota of a project: the first organization of
+// its groups.
+const queueProjectOrganization = `COALESCE((
+			SELECT MIN(group_organization.organization)
+			FROM project_group
+			JOIN group_organization ON group_organization.group_id = project_group.group_id
+			WHERE project_group.project_id = project.id
+		), '')`
+
+// LoadQueueQuotaByProjectID returns the quota of a project, else the quota of its organization.
+func LoadQueueQuotaByProjectID(db gorp.SqlExecutor, projectID int64) (*sdk.QueueQuota, error) {
+	var res sdk.QueueQuota
+	query := `
+	SELECT queue_quota.*
+	FROM queue_quota, project
+	WHERE project.id = $1
+	AND (
+		queue_quota.project_key = project.projectkey
+		OR
+		(queue_quota.project_key = '' AND queue_quota.organization <> '' AND queue_quota.organization = ` + queueProjectOrganization + `)
+	)
+	ORDER BY queue_quota.project_key DESC
+	LIMIT 1`
+	if err := db.SelectOne(&res, query, projectID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.WithStack(sdk.ErrNotFound)
+		}
+		return nil, sdk.WithStack(err)
+	}
+	return &res, nil
+}
+
+// CheckQueueQuota returns an error if one more job with given model would exceed the max concurrent jobs per model
+// of the share of given quota.
+func CheckQueueQuota(db gorp.SqlExecutor, q sdk.QueueQuota, model string) error {
+	if q.MaxConcurrentJobsPerModel <= 0 || model == "" {
+		return nil
+	}
+
+	// A project with its own quota is not part of the share of its organization
+	query := `
+	SELECT COUNT(*)
+	FROM workflow_node_run_job
+	JOIN project ON project.id = workflow_node_run_job.project_id
+	WHERE workflow_node_run_job.status = $1
+	AND COALESCE(workflow_node_run_job.model, '') = $2
+	AND project.projectkey = $3`
+	args := []interface{}{sdk.StatusBuilding, model, q.ProjectKey}
+	if q.ProjectKey == "" {
+		query = `
+	SELECT COUNT(*)
+	FROM workflow_node_run_job
+	JOIN project ON project.id = workflow_node_run_job.project_id
+	WHERE workflow_node_run_job.status = $1
+	AND COALESCE(workflow_node_run_job.model, '') = $2
+	AND ` + queueProjectOrganization + ` = $3
+	AND NOT EXISTS (SELECT 1 FROM queue_quota WHERE queue_quota.project_key = project.projectkey)`
+		args = []interface{}{sdk.StatusBuilding, model, q.Organization}
+	}
+	building, err := db.SelectInt(query, args...)
+	if err != nil {
+		return sdk.WrapError(err, "unable to count building jobs")
+	}
+	if building >= q.MaxConcurrentJobsPerModel {
+		return sdk.NewErrorFrom(sdk.ErrQueueQuotaReached, "%d jobs with model %s are already building", building, model)
+	}
+	return nil
+}
+
+type queueProjectUsage struct {
+	ProjectID    int64  `db:"project_id"`
+	ProjectKey   string `db:"projectkey"`
+	Organization string `db:"organization"`
+	Status       string `db:"status"`
+	Model        string `db:"model"`
+	Count        int64  `db:"count"`
+}
+
+// queueShares contains the share of each project and the usage of each share.
+type queueShares struct {
+	keys   map[int64]string
+	shares map[string]sdk.QueueShare
+	usages map[string]*sdk.QueueQuotaUsage
+}
+
+func (s queueShares) key(j sdk.WorkflowNodeJobRun) string {
+	return s.keys[j.ProjectID]
+}
+
+// loadQueueShares computes the shares of the queue. A project uses its own quota, else the quota of its organization,
+// else a default share with a weight of 1.
+func loadQueueShares(ctx context.Context, db gorp.SqlExecutor) (*queueShares, error) {
+	quotas, err := LoadQueueQuotas(db)
+	if err != nil {
+		return nil, err
+	}
+	projectQuotas := make(map[string]sdk.QueueQuota)
+	organizationQuotas := make(map[string]sdk.QueueQuota)
+	for _, q := range quotas {
+		if q.ProjectKey != "" {
+			projectQuotas[q.ProjectKey] = q
+		} else {
+			organizationQuotas[q.Organization] = q
+		}
+	}
+
+	var projectUsages []queueProjectUsage
+	query := `
+	SELECT workflow_node_run_job.project_id, project.projectkey,
+		` + queueProjectOrganization + ` AS organization,
+		workflow_node_run_job.status,
+		CASE WHEN workflow_node_run_job.status = $1 THEN '' ELSE COALESCE(workflow_node_run_job.model, '') END AS model,
+		COUNT(*) AS count
+	FROM workflow_node_run_job
+	JOIN project ON project.id = workflow_node_run_job.project_id
+	WHERE workflow_node_run_job.status = ANY($2)
+	GROUP BY 1, 2, 3, 4, 5`
+	if _, err := db.Select(&projectUsages, query, sdk.StatusWaiting, pq.StringArray{sdk.StatusWaiting, sdk.StatusBuilding}); err != nil {
+		return nil, sdk.WrapError(err, "unable to load queue usage")
+	}
+
+	res := queueShares{
+		keys:   make(map[int64]string),
+		shares: make(map[string]sdk.QueueShare),
+		usages: make(map[string]*sdk.QueueQuotaUsage),
+	}
+	getUsage := func(projectKey, organization string) (string, *sdk.QueueQuotaUsage) {
+		usage := sdk.QueueQuotaUsage{ProjectKey: projectKey, Weight: 1}
+		quota, ok := projectQuotas[projectKey]
+		if !ok {
+			if q, okOrg := organizationQuotas[organization]; okOrg && organization != "" {
+				quota, ok = q, true
+				usage = sdk.QueueQuotaUsage{Organization: organization}
+			}
+		}
+		if ok {
+			usage.Weight = quota.Weight
+			usage.MaxConcurrentJobsPerModel = quota.MaxConcurrentJobsPerModel
+		}
+		key := "project/" + usage.ProjectKey
+		if usage.Organization != "" {
+			key = "organization/" + usage.Organization
+		}
+		if u, ok := res.usages[key]; ok {
+			return key, u
+		}
+		usage.BuildingByModel = make(map[string]int64)
+		res.usages[key] = &usage
+		return key, &usage
+	}
+
+	// Quotas are always part of the usage, even without jobs
+	for _, q := range quotas {
+		getUsage(q.ProjectKey, q.Organization)
+	}
+	for _, u := range projectUsages {
+		key, usage := getUsage(u.ProjectKey, u.Organization)
+		res.keys[u.ProjectID] = key
+		if u.Status == sdk.StatusWaiting {
+			usage.Waiting += u.Count
+			continue
+		}
+		usage.Building += u.Count
+		usage.BuildingByModel[u.Model] += u.Count
+	}
+	for key, u := range res.usages {
+		res.shares[key] = sdk.QueueShare{
+			Weight:                    u.Weight,
+			MaxConcurrentJobsPerModel: u.MaxConcurrentJobsPerModel,
+			BuildingByModel:           u.BuildingByModel,
+		}
+	}
+	return &res, nil
+}
+
+// LoadQueueQuotaUsages returns the current usage of the queue for each quota and for each project with jobs.
+func LoadQueueQuotaUsages(ctx context.Context, db gorp.SqlExecutor) ([]sdk.QueueQuotaUsage, error) {
+	s, err := loadQueueShares(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]sdk.QueueQuotaUsage, 0, len(s.usages))
+	for _, u := range s.usages {
+		res = append(res, *u)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Organization != res[j].Organization {
+			return res[i].Organization < res[j].Organization
+		}
+		return res[i].ProjectKey < res[j].ProjectKey
+	})
+	return res, nil
+}
+
+// FairShareQueue returns the waiting jobs in the order they should be given to the hatcheries, given the queue quotas
+// and the priority class of the jobs.
+func FairShareQueue(ctx context.Context, db gorp.SqlExecutor, jobs []sdk.WorkflowNodeJobRun) ([]sdk.WorkflowNodeJobRun, error) {
+	s, err := loadQueueShares(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return sdk.FairShareQueue(jobs, s.key, s.shares), nil
+}
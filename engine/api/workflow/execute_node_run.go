@@ -543,6 +543,10 @@ jobLoop:
 				wjob.Region = &jobRequirements[i].Value
 				break
 			}
+			if jobRequirements[i].Type == sdk.RegionPreferenceRequirement {
+				wjob.Region = preferredRegion(ctx, db, jobRequirements[i].Value, 0)
+				break
+			}
 		}
 
 		if !stage.Enabled || !wjob.Job.Enabled {
@@ -38,8 +38,9 @@ func processWorkflowDataRun(ctx context.Context, db gorpmapper.SqlExecutorWithTx
 	wr.Header.Set(sdk.WorkflowHeader, wr.Workflow.Name)
 	wr.Header.Set(sdk.ProjectKeyHeader, proj.Key)
 
-	// Push data in header to allow tracing
-	if telemetry.Current(ctx).SpanContext().IsSampled() {
+	// Push data in header to allow tracing, the whole workflow run shares the trace of its first processing. The span
+	// of the processing is sampled with the configured sampling probability, or like its parent.
+	if _, has := wr.Header.Get(telemetry.TraceIDHeader); !has && telemetry.Current(ctx).SpanContext().IsSampled() {
 		wr.Header.Set(telemetry.SampledHeader, "1")
 		wr.Header.Set(telemetry.TraceIDHeader, fmt.Sprintf("%v", telemetry.Current(ctx).SpanContext().TraceID))
 	}
@@ -268,6 +268,13 @@ func processNode(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cac
 		setValuesGitInBuildParameters(nr, *vcsInf)
 	}
 
+	// CHANGED FILES
+	if isRoot && app.VCSServer != "" {
+		computeChangedFiles(ctx, db, store, proj.Key, app, nr)
+	} else if !isRoot && needVCSInfo {
+		removeChangedFiles(nr)
+	}
+
 	// CONDITION
 	if !checkCondition(ctx, wr, n.Context.Conditions, nr.BuildParameters) {
 		log.Debug(ctx, "Conditions failed on processNode %d/%d", wr.ID, n.ID)
@@ -276,6 +283,12 @@ func processNode(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cac
 		return nil, false, nil
 	}
 
+	// PATH FILTERS
+	if !checkPathFilters(n, hookEvent, nr.BuildParameters) {
+		log.Debug(ctx, "Path filters failed on processNode %d/%d", wr.ID, n.ID)
+		return nil, false, nil
+	}
+
 	// Resync vcsInfos if we dont call func getVCSInfos
 	if !needVCSInfo {
 		vcsInf = &vcsInfos{}
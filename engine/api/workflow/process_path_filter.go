@@ -0,0 +1,83 @@
+package workflow
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	tagGitHashBefore   = "git.hash.before"
+	tagGitChangedFiles = "git.changed_files"
+)
+
+// computeChangedFiles sets the files changed between git.hash.before and git.hash in git.changed_files, one file per
+// line. The variable is not set if changed files can't be computed, for example on a new branch or a manual run.
+func computeChangedFiles(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cache.Store, projectKey string, app sdk.Application, nr *sdk.WorkflowNodeRun) {
+	before := sdk.ParameterValue(nr.BuildParameters, tagGitHashBefore)
+	hash := sdk.ParameterValue(nr.BuildParameters, tagGitHash)
+	if before == "" || hash == "" || before == hash || strings.Trim(before, "0") == "" {
+		return
+	}
+
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, projectKey, app.VCSServer)
+	if err != nil {
+		log.Warn(ctx, "computeChangedFiles> unable to get vcs client %s: %v", app.VCSServer, err)
+		return
+	}
+	// Changed files are computed while processing the workflow, they use the background budget of the VCS server
+	files, err := client.ChangedFiles(repositoriesmanager.ContextWithBackgroundPriority(ctx), app.RepositoryFullname, before, hash)
+	if err != nil {
+		log.Warn(ctx, "computeChangedFiles> unable to get files changed between %s and %s on %s: %v", before, hash, app.RepositoryFullname, err)
+		return
+	}
+	sdk.ParameterAddOrSetValue(&nr.BuildParameters, tagGitChangedFiles, sdk.StringParameter, strings.Join(files, "\n"))
+}
+
+// removeChangedFiles removes changed files inherited from a parent on another repository.
+func removeChangedFiles(nr *sdk.WorkflowNodeRun) {
+	params := nr.BuildParameters[:0]
+	for _, p := range nr.BuildParameters {
+		if p.Name != tagGitChangedFiles {
+			params = append(params, p)
+		}
+	}
+	nr.BuildParameters = params
+}
+
+// checkPathFilters returns false if the changed files don't match the path filter of the node or of the hook that
+// triggers it. Filters are ignored when changed files are unknown.
+func checkPathFilters(n *sdk.Node, hookEvent *sdk.WorkflowNodeRunHookEvent, params []sdk.Parameter) bool {
+	changed := sdk.ParameterFind(params, tagGitChangedFiles)
+	if changed == nil {
+		return true
+	}
+	var files []string
+	if changed.Value != "" {
+		files = strings.Split(changed.Value, "\n")
+	}
+
+	filters := []sdk.PathFilter{n.Context.PathFilter}
+	if hookEvent != nil {
+		for _, h := range n.Hooks {
+			if h.UUID != hookEvent.WorkflowNodeHookUUID {
+				continue
+			}
+			include, _ := h.GetConfigValue(sdk.HookConfigPathFilter)
+			exclude, _ := h.GetConfigValue(sdk.HookConfigPathExcludeFilter)
+			filters = append(filters, sdk.NewPathFilter(include, exclude))
+		}
+	}
+	for _, f := range filters {
+		if !f.IsEmpty() && !f.Match(files) {
+			return false
+		}
+	}
+	return true
+}
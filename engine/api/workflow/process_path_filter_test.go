@@ -0,0 +1,42 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestCheckPathFilters(t *testing.T) {
+	changed := func(files string) []sdk.Parameter {
+		var params []sdk.Parameter
+		sdk.ParameterAddOrSetValue(&params, tagGitChangedFiles, sdk.StringParameter, files)
+		return params
+	}
+	n := &sdk.Node{
+		Context: &sdk.NodeContext{PathFilter: sdk.PathFilter{Include: []string{"src/**"}, Exclude: []string{"src/docs/**"}}},
+		Hooks: []sdk.NodeHook{
+			{UUID: "hook-api", Config: sdk.WorkflowNodeHookConfig{sdk.HookConfigPathFilter: {Value: "src/api/**"}}},
+			{UUID: "hook-other", Config: sdk.WorkflowNodeHookConfig{sdk.HookConfigPathFilter: {Value: "other/**"}}},
+		},
+	}
+	hookAPI := &sdk.WorkflowNodeRunHookEvent{WorkflowNodeHookUUID: "hook-api"}
+
+	// Filters are ignored when changed files are unknown
+	require.True(t, checkPathFilters(n, nil, nil))
+	require.True(t, checkPathFilters(n, hookAPI, nil))
+
+	require.True(t, checkPathFilters(n, nil, changed("README.md\nsrc/ui/main.go")))
+	// The node is skipped if no changed file matches its filter
+	require.False(t, checkPathFilters(n, nil, changed("README.md")))
+	require.False(t, checkPathFilters(n, nil, changed("src/docs/index.md")))
+	require.False(t, checkPathFilters(n, nil, changed("")))
+
+	// The filter of the hook that triggers the node is also applied, not the ones of the other hooks
+	require.True(t, checkPathFilters(n, hookAPI, changed("src/api/main.go")))
+	require.False(t, checkPathFilters(n, hookAPI, changed("src/ui/main.go")))
+
+	// A node without filter is never skipped
+	require.True(t, checkPathFilters(&sdk.Node{Context: &sdk.NodeContext{}}, nil, changed("README.md")))
+}
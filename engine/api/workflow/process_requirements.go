@@ -195,6 +195,16 @@ func processNodeJobRunRequirements(ctx context.Context, db gorp.SqlExecutor, j s
 		errm.Append(sdk.NewErrorFrom(sdk.ErrInvalidJobRequirement, "Cannot have multiple region requirements %v", regionRequirementMap))
 	}
 
+	regionPreferences := requirements.FilterByType(sdk.RegionPreferenceRequirement)
+	if len(regionPreferences) > 1 || (len(regionPreferences) > 0 && len(regionRequirementMap) > 0) {
+		errm.Append(sdk.NewErrorFrom(sdk.ErrInvalidJobRequirement, "Cannot have multiple region-preference requirements or both region and region-preference requirements"))
+	}
+	for _, r := range regionPreferences {
+		if _, err := sdk.ParseRegionPreference(r.Value); err != nil {
+			errm.Append(err)
+		}
+	}
+
 	if errm.IsEmpty() {
 		return requirements, containsService, modelType, nil
 	}
@@ -0,0 +1,191 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/hatchery"
+	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/engine/api/workermodel"
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+const queueStatsTTL = 24 * time.Hour
+
+// queueInfoWindow is the count of waiting jobs loaded for each project and priority class to compute the positions in
+// the queue, jobs after it in their project get no position.
+const queueInfoWindow = 100
+
+// addQueueStatsScript atomically adds a sample to the stats of a worker model stored as JSON, like sdk.QueueStats.Add.
+var addQueueStatsScript = fmt.Sprintf(`local stats = {spawn_delay = 0, job_duration = 0, samples = 0}
+local current = redis.call("GET", KEYS[1])
+if current then stats = cjson.decode(current) end
+local spawn_delay, job_duration = tonumber(ARGV[1]), tonumber(ARGV[2])
+if stats.samples > 0 then
+	spawn_delay = stats.spawn_delay * (1 - %[1]g) + spawn_delay * %[1]g
+	job_duration = stats.job_duration * (1 - %[1]g) + job_duration * %[1]g
+end
+local samples = stats.samples + 1
+redis.call("SET", KEYS[1], string.format('{"spawn_delay":%%d,"job_duration":%%d,"samples":%%d}', spawn_delay, job_duration, samples), "EX", %[2]d)
+return samples`, sdk.QueueStatsSmoothing, int(queueStatsTTL.Seconds()))
+
+func queueStatsKey(model string) string {
+	return cache.Key("api:queue:stats", model)
+}
+
+// AddQueueStats records the spawn delay and the duration of a finished job in the stats of its worker model. It must
+// be called once the job is committed.
+func AddQueueStats(ctx context.Context, store cache.Store, job sdk.WorkflowNodeJobRun) {
+	if store == nil || job.Start.IsZero() || job.Done.IsZero() {
+		return
+	}
+	if job.Status != sdk.StatusSuccess && job.Status != sdk.StatusFail {
+		return
+	}
+	spawnDelay, jobDuration := job.Start.Sub(job.Queued), job.Done.Sub(job.Start)
+	if _, err := store.EvalWithArgs(addQueueStatsScript, []string{queueStatsKey(job.Model)}, int64(spawnDelay), int64(jobDuration)); err != nil {
+		log.Error(ctx, "unable to add queue stats for model %q: %v", job.Model, err)
+	}
+}
+
+type queueInfoKey struct {
+	model  string
+	region string
+}
+
+// ComputeQueueInfos sets the position, the estimated start and the waiting reason on the waiting jobs. The position
+// of a job is computed among the waiting jobs for the same worker model and region, in the fair-share order. Only the
+// oldest jobs of each project and priority class are loaded, the total of a position counts the loaded jobs.
+func ComputeQueueInfos(ctx context.Context, db gorp.SqlExecutor, store cache.Store, jobs []sdk.WorkflowNodeJobRun) error {
+	filter := NewQueueFilter()
+	window := queueInfoWindow
+	filter.LimitByProject = &window
+	all, err := LoadNodeJobRunQueue(ctx, db, store, filter)
+	if err != nil {
+		return err
+	}
+	shares, err := loadQueueShares(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	loaded := make(map[int64]bool, len(all))
+	for _, j := range all {
+		loaded[j.ID] = true
+	}
+	positions := make(map[int64]int, len(all))
+	totals := make(map[queueInfoKey]int)
+	for _, j := range sdk.FairShareQueue(all, shares.key, shares.shares) {
+		k := queueInfoKey{model: sdk.QueueJobModel(j), region: sdk.QueueJobRegion(j)}
+		totals[k]++
+		positions[j.ID] = totals[k]
+	}
+
+	building := make(map[string]int)
+	for _, u := range shares.usages {
+		for m, c := range u.BuildingByModel {
+			building[m] += int(c)
+		}
+	}
+
+	since := time.Now().Add(-hatchery.RegionHealthTimeout)
+	healthyRegions, err := hatchery.LoadHealthyRegions(ctx, db, since)
+	if err != nil {
+		return err
+	}
+	hatcheries, err := services.LoadAllByType(ctx, db, sdk.TypeHatchery)
+	if err != nil {
+		return err
+	}
+	var hatcheryWithoutRegion bool
+	for _, h := range hatcheries {
+		if h.LastHeartbeat.After(since) && (h.IgnoreJobWithNoRegion == nil || !*h.IgnoreJobWithNoRegion) {
+			hatcheryWithoutRegion = true
+			break
+		}
+	}
+
+	models, err := workermodel.LoadAll(ctx, db, nil, workermodel.LoadOptions.Default)
+	if err != nil {
+		return err
+	}
+
+	stats := make(map[string]*sdk.QueueStats)
+	now := time.Now()
+	for i := range jobs {
+		j := &jobs[i]
+		if j.Status != sdk.StatusWaiting {
+			continue
+		}
+		k := queueInfoKey{model: sdk.QueueJobModel(*j), region: sdk.QueueJobRegion(*j)}
+		position, scheduled := positions[j.ID]
+		info := sdk.WorkflowNodeJobRunQueueInfo{
+			Position: position,
+			Total:    totals[k],
+			Model:    k.model,
+			Region:   k.region,
+			Reason:   sdk.QueueWaitingReasonQueued,
+		}
+
+		reqs := j.Job.Action.Requirements
+		var matchingModels, healthyModels []sdk.Model
+		for _, m := range models {
+			if m.MatchRequirements(reqs) {
+				matchingModels = append(matchingModels, m)
+				if !m.Disabled && m.NbSpawnErr == 0 {
+					healthyModels = append(healthyModels, m)
+				}
+			}
+		}
+		hasModelRequirement := len(reqs.FilterByType(sdk.ModelRequirement)) > 0
+
+		switch {
+		case j.BookedBy.Name != "":
+			info.Reason = sdk.QueueWaitingReasonBooked
+			info.ReasonDetails = fmt.Sprintf("booked by hatchery %s", j.BookedBy.Name)
+		case k.region != "" && !sdk.IsInArray(k.region, healthyRegions):
+			info.Reason = sdk.QueueWaitingReasonNoHatchery
+			info.ReasonDetails = fmt.Sprintf("no healthy hatchery in region %s", k.region)
+		case k.region == "" && !hatcheryWithoutRegion:
+			info.Reason = sdk.QueueWaitingReasonNoHatchery
+			info.ReasonDetails = "no healthy hatchery for jobs without region"
+		case len(matchingModels) == 0 && (hasModelRequirement || len(models) > 0):
+			info.Reason = sdk.QueueWaitingReasonRequirementsUnmatched
+			info.ReasonDetails = "no worker model matches the job requirements"
+		case hasModelRequirement && len(healthyModels) == 0:
+			info.Reason = sdk.QueueWaitingReasonModelError
+			m := matchingModels[0]
+			if m.Disabled {
+				info.ReasonDetails = fmt.Sprintf("worker model %s is disabled", m.Name)
+			} else if m.LastSpawnErr != nil {
+				info.ReasonDetails = fmt.Sprintf("worker model %s is in error: %s", m.Name, *m.LastSpawnErr)
+			}
+		case !scheduled && loaded[j.ID]:
+			info.Reason = sdk.QueueWaitingReasonQuotaReached
+			info.ReasonDetails = fmt.Sprintf("max concurrent jobs reached for worker model %s", k.model)
+		}
+
+		if scheduled {
+			s, ok := stats[k.model]
+			if !ok {
+				s = new(sdk.QueueStats)
+				if _, err := store.Get(queueStatsKey(k.model), s); err != nil {
+					log.Error(ctx, "unable to get queue stats for model %q: %v", k.model, err)
+				}
+				stats[k.model] = s
+			}
+			if s.Samples > 0 {
+				eta := now.Add(s.EstimatedWait(position, building[k.model]))
+				info.ETA = &eta
+			}
+		}
+
+		j.QueueInfo = &info
+	}
+	return nil
+}
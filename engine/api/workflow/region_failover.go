@@ -0,0 +1,119 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/hatchery"
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+// preferredRegion returns the region where a job with given region-preference requirement should be visible after being
+// queued for given duration. It returns nil if the requirement is invalid.
+func preferredRegion(ctx context.Context, db gorp.SqlExecutor, value string, queued time.Duration) *string {
+	pref, err := sdk.ParseRegionPreference(value)
+	if err != nil {
+		return nil
+	}
+	healthyRegions, err := hatchery.LoadHealthyRegions(ctx, db, time.Now().Add(-hatchery.RegionHealthTimeout))
+	if err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+	}
+	region := pref.Region(queued, healthyRegions)
+	return &region
+}
+
+// FailoverJobRunRegions moves the waiting jobs with a region preference to their next region if they were not booked by
+// a hatchery of their current region within the failover delay, or if there is no more healthy hatchery in this region.
+func FailoverJobRunRegions(ctx context.Context, db gorp.SqlExecutor, store cache.Store) error {
+	healthyRegions, err := hatchery.LoadHealthyRegions(ctx, db, time.Now().Add(-hatchery.RegionHealthTimeout))
+	if err != nil {
+		return err
+	}
+
+	jobs, err := LoadNodeJobRunQueue(ctx, db, store, NewQueueFilter())
+	if err != nil {
+		return err
+	}
+
+	for _, j := range jobs {
+		prefs := j.Job.Action.Requirements.FilterByType(sdk.RegionPreferenceRequirement)
+		if len(prefs) == 0 || j.BookedBy.ID != 0 {
+			continue
+		}
+		pref, err := sdk.ParseRegionPreference(prefs[0].Value)
+		if err != nil {
+			continue
+		}
+
+		region := pref.Region(time.Since(j.Queued), healthyRegions)
+		if j.Region != nil && *j.Region == region {
+			continue
+		}
+
+		moved, err := updateNodeJobRunRegion(db, j.ID, j.Region, region)
+		if err != nil {
+			return err
+		}
+		if !moved {
+			continue
+		}
+
+		var from string
+		if j.Region != nil {
+			from = *j.Region
+		}
+		log.Info(ctx, "FailoverJobRunRegions> job %d moved from region %q to region %q", j.ID, from, region)
+
+		if err := AddSpawnInfosNodeJobRun(db, j.WorkflowNodeRunID, j.ID, []sdk.SpawnInfo{{
+			APITime: time.Now(),
+			Message: sdk.SpawnMsg{ID: sdk.MsgSpawnInfoRegionFailover.ID, Args: []interface{}{from, region}},
+		}}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateNodeJobRunRegion changes the region of a waiting job if it was not changed by someone else.
+func updateNodeJobRunRegion(db gorp.SqlExecutor, id int64, from *string, to string) (bool, error) {
+	query := `UPDATE workflow_node_run_job SET region = $1 WHERE id = $2 AND status = $3 AND region IS NOT DISTINCT FROM $4`
+	res, err := db.Exec(query, to, id, sdk.StatusWaiting, from)
+	if err != nil {
+		return false, sdk.WrapError(err, "unable to update region of job %d", id)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, sdk.WithStack(err)
+	}
+	return n == 1, nil
+}
+
+// FailoverJobRunRegionsRoutine checks periodically the region of waiting jobs with a region preference.
+func FailoverJobRunRegionsRoutine(ctx context.Context, DBFunc func() *gorp.DbMap, store cache.Store, delay time.Duration) {
+	tick := time.NewTicker(delay)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "Exiting FailoverJobRunRegionsRoutine: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			db := DBFunc()
+			if db == nil {
+				continue
+			}
+			if err := FailoverJobRunRegions(ctx, db, store); err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+			}
+		}
+	}
+}
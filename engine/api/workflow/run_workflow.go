@@ -215,11 +215,21 @@ func CheckRegion(ctx context.Context, db gorp.SqlExecutor, proj sdk.Project, wf
 
 func checkJobRegion(ctx context.Context, db gorp.SqlExecutor, projKey, projOrg, wName string, jobRequirements sdk.RequirementList) error {
 	for _, req := range jobRequirements {
-		if req.Type != sdk.RegionRequirement {
-			continue
-		}
-		if err := isRegionEnable(ctx, db, projKey, projOrg, wName, req.Value); err != nil {
-			return err
+		switch req.Type {
+		case sdk.RegionRequirement:
+			if err := isRegionEnable(ctx, db, projKey, projOrg, wName, req.Value); err != nil {
+				return err
+			}
+		case sdk.RegionPreferenceRequirement:
+			pref, err := sdk.ParseRegionPreference(req.Value)
+			if err != nil {
+				return err
+			}
+			for _, region := range pref.Regions {
+				if err := isRegionEnable(ctx, db, projKey, projOrg, wName, region); err != nil {
+					return err
+				}
+			}
 		}
 	}
 	return nil
@@ -298,6 +298,15 @@ func TestManualRun2(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Len(t, jobs, 3)
+
+	// Only the oldest jobs of the project are loaded
+	window := 2
+	filter.LimitByProject = &window
+	oldest, err := workflow.LoadNodeJobRunQueueByGroupIDs(ctx, db, cache, filter, sdk.Groups(append(u.Groups, proj.ProjectGroups[0].Group)).ToIDs())
+	require.NoError(t, err)
+	require.Len(t, oldest, 2)
+	assert.Equal(t, jobs[0].ID, oldest[0].ID)
+	assert.Equal(t, jobs[1].ID, oldest[1].ID)
 }
 
 func TestManualRun3(t *testing.T) {
@@ -278,10 +278,18 @@ func (e *VCSEventMessenger) sendVCSEventStatus(ctx context.Context, db gorp.SqlE
 				Revision:   revision,
 				Report:     report,
 				URL:        url,
+				Labels:     notif.Settings.Template.GerritLabels,
 			}
 		}
 	}
 
+	if sdk.StatusIsTerminated(nodeRun.Status) {
+		checkRun := computeVCSCheckRun(ctx, db, projectKey, wr, *nodeRun)
+		if !checkRun.IsEmpty() {
+			eventWNR.CheckRun = &checkRun
+		}
+	}
+
 	payload, _ := json.Marshal(eventWNR)
 
 	evt := sdk.Event{
@@ -305,6 +313,40 @@ func (e *VCSEventMessenger) sendVCSEventStatus(ctx context.Context, db gorp.SqlE
 	return nil
 }
 
+// computeVCSCheckRun builds the check run of a terminated node run from its tests results and code quality run results
+func computeVCSCheckRun(ctx context.Context, db gorp.SqlExecutor, projectKey string, wr sdk.WorkflowRun, nodeRun sdk.WorkflowNodeRun) sdk.VCSCheckRun {
+	tests := nodeRun.Tests
+	if tests == nil {
+		nr, err := LoadNodeRun(db, projectKey, wr.Workflow.Name, nodeRun.ID, LoadRunOptions{WithTests: true})
+		if err != nil {
+			log.Error(ctx, "computeVCSCheckRun> unable to load tests of node run %d: %v", nodeRun.ID, err)
+		} else {
+			tests = nr.Tests
+		}
+	}
+
+	var annotations []sdk.VCSCheckRunAnnotation
+	results, err := LoadRunResultsByNodeRunID(ctx, db, nodeRun.ID)
+	if err != nil {
+		log.Error(ctx, "computeVCSCheckRun> unable to load run results of node run %d: %v", nodeRun.ID, err)
+	}
+	for i := range results {
+		if results[i].Type != sdk.WorkflowRunResultTypeCodeQuality {
+			continue
+		}
+		codeQuality, err := results[i].GetCodeQuality()
+		if err != nil {
+			log.Error(ctx, "computeVCSCheckRun> unable to read run result %s: %v", results[i].ID, err)
+			continue
+		}
+		annotations = append(annotations, codeQuality.Annotations...)
+	}
+
+	name := sdk.VCSCommitStatusDescription(projectKey, wr.Workflow.Name, sdk.EventRunWorkflowNode{NodeName: nodeRun.WorkflowNodeName})
+	url := sdk.ParameterValue(nodeRun.BuildParameters, "cds.ui.pipeline.run")
+	return sdk.NewVCSCheckRun(name, nodeRun.Status, url, tests, annotations)
+}
+
 func (e *VCSEventMessenger) sendVCSPullRequestComment(ctx context.Context, db gorp.SqlExecutor, wr sdk.WorkflowRun, nodeRun *sdk.WorkflowNodeRun, notif *sdk.WorkflowNotification, vcsServerName string) error {
 	if notif == nil || notif.Settings.Template == nil || (notif.Settings.Template.DisableComment != nil && *notif.Settings.Template.DisableComment) {
 		return nil
@@ -102,6 +102,12 @@ func CanUploadRunResult(ctx context.Context, db *gorp.DbMap, store cache.Store,
 				return false, err
 			}
 			fileName = refArt.Name
+		case sdk.WorkflowRunResultTypeCodeQuality:
+			refCodeQuality, err := runResult.GetCodeQuality()
+			if err != nil {
+				return false, err
+			}
+			fileName = refCodeQuality.Name
 		}
 
 		if fileName != runResultCheck.Name {
@@ -159,6 +165,12 @@ func AddResult(ctx context.Context, db *gorp.DbMap, store cache.Store, wr *sdk.W
 		if err != nil {
 			return err
 		}
+	case sdk.WorkflowRunResultTypeCodeQuality:
+		var err error
+		cacheKey, err = verifyAddResultCodeQuality(store, runResult)
+		if err != nil {
+			return err
+		}
 	default:
 		return sdk.WrapError(sdk.ErrInvalidData, "unknown result type %s", runResult.Type)
 	}
@@ -362,6 +374,26 @@ func verifyAddResultStaticFile(store cache.Store, runResult *sdk.WorkflowRunResu
 	return cacheKey, nil
 }
 
+func verifyAddResultCodeQuality(store cache.Store, runResult *sdk.WorkflowRunResult) (string, error) {
+	codeQualityRunResult, err := runResult.GetCodeQuality()
+	if err != nil {
+		return "", err
+	}
+	if err := codeQualityRunResult.IsValid(); err != nil {
+		return "", err
+	}
+
+	cacheKey := GetRunResultKey(runResult.WorkflowRunID, runResult.Type, codeQualityRunResult.Name)
+	b, err := store.Exist(cacheKey)
+	if err != nil {
+		return cacheKey, err
+	}
+	if !b {
+		return cacheKey, sdk.WrapError(sdk.ErrForbidden, "unable to upload an unchecked code quality report")
+	}
+	return cacheKey, nil
+}
+
 func insertResult(tx gorpmapper.SqlExecutorWithTx, runResult *sdk.WorkflowRunResult) error {
 	runResult.ID = sdk.UUID()
 	runResult.Created = time.Now()
@@ -7,6 +7,7 @@ import (
 	"regexp"
 
 	"github.com/gorilla/mux"
+	"github.com/rockbears/log"
 
 	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
@@ -74,11 +75,6 @@ func (api *API) releaseApplicationWorkflowHandler() service.Handler {
 			return sdk.WithStack(err)
 		}
 
-		release, errRelease := client.Release(ctx, app.RepositoryFullname, req.TagName, req.ReleaseTitle, req.ReleaseContent)
-		if errRelease != nil {
-			return sdk.WithStack(errRelease)
-		}
-
 		results, err := workflow.LoadRunResultsByRunIDAndType(ctx, api.mustDB(), workflowRun.ID, sdk.WorkflowRunResultTypeArtifact)
 		if err != nil {
 			return err
@@ -103,6 +99,26 @@ func (api *API) releaseApplicationWorkflowHandler() service.Handler {
 			}
 		}
 
+		if req.GenerateReleaseNotes {
+			assets := make([]string, 0, len(resultToUpload))
+			for _, r := range resultToUpload {
+				assets = append(assets, r.Name)
+			}
+			notes, err := api.generateReleaseNotes(ctx, client, proj, app.RepositoryFullname, req, assets)
+			if err != nil {
+				return err
+			}
+			if req.ReleaseContent != "" {
+				req.ReleaseContent += "\n\n"
+			}
+			req.ReleaseContent += notes
+		}
+
+		release, errRelease := client.Release(ctx, app.RepositoryFullname, req.TagName, req.ReleaseTitle, req.ReleaseContent)
+		if errRelease != nil {
+			return sdk.WithStack(errRelease)
+		}
+
 		if len(resultToUpload) == 0 {
 			return nil
 		}
@@ -122,17 +138,54 @@ func (api *API) releaseApplicationWorkflowHandler() service.Handler {
 				}
 				if err := client.UploadReleaseFile(ctx, app.RepositoryFullname, fmt.Sprintf("%d", release.ID), release.UploadURL, r.Name, reader, int(r.Size)); err != nil {
 					lastErr = err
-					if attempt >= 5 {
+					// Forges without release API can't attach files, retrying is useless
+					if attempt >= 5 || sdk.ErrorIs(err, sdk.ErrNotImplemented) {
 						break
 					}
 					continue
 				}
+				lastErr = nil
 				break
 			}
 			if lastErr != nil {
-				return err
+				return lastErr
 			}
 		}
 		return nil
 	}
 }
+
+// generateReleaseNotes renders the release notes of the commits between the previous tag and the released one, with
+// the template given by the project variable if it exists.
+func (api *API) generateReleaseNotes(ctx context.Context, client sdk.VCSAuthorizedClientService, proj *sdk.Project, repo string, req sdk.WorkflowNodeRunRelease, assets []string) (string, error) {
+	previousTag := req.PreviousTag
+	if previousTag == "" {
+		tags, err := client.Tags(ctx, repo)
+		if err != nil {
+			return "", sdk.WrapError(err, "cannot get tags of repository %s", repo)
+		}
+		previousTag = sdk.PreviousReleaseTag(tags, req.TagName)
+	}
+
+	var commits []sdk.VCSCommit
+	if previousTag != "" {
+		var err error
+		commits, err = client.CommitsBetweenRefs(ctx, repo, previousTag, req.TagName)
+		if err != nil {
+			return "", sdk.WrapError(err, "cannot get commits between %s and %s on repository %s", previousTag, req.TagName, repo)
+		}
+	} else {
+		log.Info(ctx, "generateReleaseNotes> no previous tag found for %s on repository %s", req.TagName, repo)
+	}
+
+	var tmpl string
+	v, err := project.LoadVariable(api.mustDB(), proj.ID, sdk.ReleaseNotesTemplateVariable)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return "", err
+	}
+	if v != nil {
+		tmpl = v.Value
+	}
+
+	return sdk.NewReleaseNotes(req.TagName, previousTag, commits, assets).Render(tmpl)
+}
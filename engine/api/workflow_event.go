@@ -22,6 +22,10 @@ func (api *API) WorkflowSendEvent(ctx context.Context, proj sdk.Project, report
 	if report == nil {
 		return
 	}
+
+	api.recordWorkflowMetrics(ctx, proj, report)
+	api.exportWorkflowTraces(ctx, proj, report)
+
 	for _, wr := range report.Workflows() {
 		event.PublishWorkflowRun(ctx, wr, proj.Key)
 	}
@@ -71,6 +75,8 @@ func (api *API) WorkflowSendEvent(ctx context.Context, proj sdk.Project, report
 	}
 
 	for _, jobrun := range report.Jobs() {
+		workflow.AddQueueStats(ctx, api.Cache, jobrun)
+
 		noderun, err := workflow.LoadNodeRunByID(ctx, db, jobrun.WorkflowNodeRunID, workflow.LoadRunOptions{})
 		if err != nil {
 			ctx := sdk.ContextWithStacktrace(ctx, err)
@@ -0,0 +1,310 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-gorp/gorp"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// postWorkflowHookChatOpsHandler executes a command posted in a pull request comment, on the workflow of the
+// repository webhook that received the comment. The result is posted as a reply on the pull request.
+func (api *API) postWorkflowHookChatOpsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if !isHooks(ctx) {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		var cmd sdk.ChatOpsCommand
+		if err := service.UnmarshalBody(r, &cmd); err != nil {
+			return err
+		}
+		if err := cmd.IsValid(); err != nil {
+			return err
+		}
+
+		hook, err := workflow.LoadHookByUUID(api.mustDB(), cmd.HookUUID)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load hook for uuid %s", cmd.HookUUID)
+		}
+		if !hook.IsRepositoryWebHook() {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "hook %s is not a repository webhook", cmd.HookUUID)
+		}
+		projectKey := hook.Config[sdk.HookConfigProject].Value
+		vcsServer := hook.Config[sdk.HookConfigVCSServer].Value
+		if hook.Config[sdk.HookConfigRepoFullName].Value != cmd.Repository {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "hook %s is not linked to repository %s", cmd.HookUUID, cmd.Repository)
+		}
+
+		proj, err := project.Load(ctx, api.mustDB(), projectKey, project.LoadOptions.WithIntegrations)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", projectKey)
+		}
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, *proj, hook.Config[sdk.HookConfigWorkflow].Value, workflow.LoadOptions{})
+		if err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		client, err := repositoriesmanager.AuthorizedClient(ctx, tx, api.Cache, proj.Key, vcsServer)
+		if err != nil {
+			return err
+		}
+		pr, err := client.PullRequest(ctx, cmd.Repository, strconv.FormatInt(cmd.PullRequestID, 10))
+		if err != nil {
+			return err
+		}
+
+		msg, cmdErr := api.executeChatOpsCommand(ctx, *proj, wf, vcsServer, pr, cmd)
+		if cmdErr != nil {
+			msg = fmt.Sprintf("Unable to execute `%s` on workflow %s: %s", cmd, wf.Name, sdk.ExtractHTTPError(cmdErr).Error())
+		}
+		if msg != "" {
+			if err := client.PullRequestComment(ctx, cmd.Repository, sdk.VCSPullRequestCommentRequest{
+				VCSPullRequest: pr,
+				Message:        fmt.Sprintf("@%s %s", cmd.Author, msg),
+			}); err != nil {
+				log.Error(ctx, "postWorkflowHookChatOpsHandler> unable to comment pull request %s #%d: %v", cmd.Repository, cmd.PullRequestID, err)
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+		return cmdErr
+	}
+}
+
+// executeChatOpsCommand returns the message to reply to the author of the command, it is empty if the command has
+// nothing to do on the workflow.
+func (api *API) executeChatOpsCommand(ctx context.Context, proj sdk.Project, wf *sdk.Workflow, vcsServer string, pr sdk.VCSPullRequest, cmd sdk.ChatOpsCommand) (string, error) {
+	if cmd.Name == sdk.ChatOpsCommandVerify {
+		return api.verifyChatOpsContact(ctx, vcsServer, cmd)
+	}
+
+	consumer, err := loadChatOpsConsumer(ctx, api.mustDB(), vcsServer, cmd.Author)
+	if err != nil {
+		return "", err
+	}
+
+	if cmd.Name == sdk.ChatOpsCommandRun {
+		if !permission.AccessToWorkflowNode(ctx, api.mustDB(), wf, &wf.WorkflowData.Node, *consumer, sdk.PermissionReadExecute) {
+			return "", sdk.WithStack(sdk.ErrNoPermExecution)
+		}
+		// Git parameters are set from the pull request, they can't be overridden by the command
+		payload := make(map[string]string, len(cmd.Params)+3)
+		for k, v := range cmd.Params {
+			payload[k] = v
+		}
+		payload["git.branch"] = pr.Head.Branch.DisplayID
+		payload["git.hash"] = pr.Head.Branch.LatestCommit
+		payload["git.repository"] = pr.Head.Repo
+		wr, err := workflow.CreateRun(api.mustDB(), wf, sdk.WorkflowRunPostHandlerOption{
+			Manual:         &sdk.WorkflowNodeRunManual{Payload: payload},
+			AuthConsumerID: consumer.ID,
+		})
+		if err != nil {
+			return "", err
+		}
+		api.setWorkflowRunURLs(wr)
+		return fmt.Sprintf("workflow %s #%d started on %s: %s", wf.Name, wr.Number, pr.Head.Branch.DisplayID, wr.URLs.UIURL), nil
+	}
+
+	// Retry and approve continue the last run of the pull request branch
+	runs, _, _, _, err := workflow.LoadRunsSummaries(ctx, api.mustDB(), proj.Key, wf.Name, 0, 1, map[string]string{"git.branch": pr.Head.Branch.DisplayID})
+	if err != nil {
+		return "", err
+	}
+	if len(runs) == 0 {
+		log.Info(ctx, "executeChatOpsCommand> no run of workflow %s/%s for branch %s", proj.Key, wf.Name, pr.Head.Branch.DisplayID)
+		return "", nil
+	}
+	wr, err := workflow.LoadRun(ctx, api.mustDB(), proj.Key, wf.Name, runs[0].Number, workflow.LoadRunOptions{})
+	if err != nil {
+		return "", err
+	}
+	api.setWorkflowRunURLs(wr)
+	if wr.ReadOnly {
+		return "", sdk.NewErrorFrom(sdk.ErrForbidden, "workflow run #%d is read only", wr.Number)
+	}
+	if !sdk.StatusIsTerminated(wr.Status) {
+		return fmt.Sprintf("workflow %s #%d is still %s: %s", wf.Name, wr.Number, wr.Status, wr.URLs.UIURL), nil
+	}
+
+	var node *sdk.Node
+	var onlyFailedJobs bool
+	switch cmd.Name {
+	case sdk.ChatOpsCommandRetry:
+		node = failedNode(wr)
+		if node == nil {
+			return fmt.Sprintf("workflow %s #%d has no failed pipeline: %s", wf.Name, wr.Number, wr.URLs.UIURL), nil
+		}
+		onlyFailedJobs = true
+	case sdk.ChatOpsCommandApprove:
+		node = waitingManualNode(wr)
+		if node == nil {
+			return fmt.Sprintf("workflow %s #%d has no pipeline waiting for approval: %s", wf.Name, wr.Number, wr.URLs.UIURL), nil
+		}
+	}
+
+	if !permission.AccessToWorkflowNode(ctx, api.mustDB(), &wr.Workflow, node, *consumer, sdk.PermissionReadExecute) {
+		return "", sdk.WrapError(sdk.ErrNoPermExecution, "not enough right on node %s", node.Name)
+	}
+
+	opts := sdk.WorkflowRunPostHandlerOption{
+		Number:         &wr.Number,
+		FromNodeIDs:    []int64{node.ID},
+		Manual:         &sdk.WorkflowNodeRunManual{OnlyFailedJobs: onlyFailedJobs},
+		AuthConsumerID: consumer.ID,
+	}
+	wr.Status = sdk.StatusWaiting
+	api.GoRoutines.Exec(context.Background(), fmt.Sprintf("api.initWorkflowRun-%d", wr.ID), func(ctx context.Context) {
+		api.initWorkflowRun(ctx, proj.Key, &wr.Workflow, wr, opts)
+	})
+
+	return fmt.Sprintf("pipeline %s of workflow %s #%d restarted: %s", node.Name, wf.Name, wr.Number, wr.URLs.UIURL), nil
+}
+
+// verifyChatOpsContact links the author of a verify command to the user that requested the verification code. By
+// posting the code, the author proves that the username on the repository manager belongs to this user.
+func (api *API) verifyChatOpsContact(ctx context.Context, vcsServer string, cmd sdk.ChatOpsCommand) (string, error) {
+	contactType := sdk.UserContactTypeVCS(vcsServer)
+	cacheKey := userContactVerificationCacheKey(cmd.Code)
+	var pending sdk.UserContact
+	found, err := api.Cache.Get(cacheKey, &pending)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		// The command is received by each repository webhook of the repository, the code is consumed by the first one
+		if contact, err := user.LoadContactByTypeAndValue(ctx, api.mustDB(), contactType, cmd.Author); err == nil && contact.Verified {
+			return "", nil
+		}
+		return "", sdk.NewErrorFrom(sdk.ErrForbidden, "invalid or expired verification code")
+	}
+	if pending.Type != contactType || pending.Value != cmd.Author {
+		return "", sdk.NewErrorFrom(sdk.ErrForbidden, "verification code was not requested for %s user %s", vcsServer, cmd.Author)
+	}
+	if err := api.Cache.Delete(cacheKey); err != nil {
+		return "", err
+	}
+
+	u, err := user.LoadByID(ctx, api.mustDB(), pending.UserID)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	// A username can only be linked to one user, the previous link is replaced
+	existing, err := user.LoadContactByTypeAndValue(ctx, tx, contactType, cmd.Author)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return "", err
+	}
+	if existing != nil {
+		if err := user.DeleteContact(tx, *existing); err != nil {
+			return "", err
+		}
+	}
+	pending.Verified = true
+	if err := user.InsertContact(ctx, tx, &pending); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", sdk.WithStack(err)
+	}
+	return fmt.Sprintf("%s user %s is now linked to CDS user %s", vcsServer, cmd.Author, u.Username), nil
+}
+
+// loadChatOpsConsumer returns the consumer of the user linked to the author of a command by a verified repository
+// manager contact. Commands are executed with the permissions of the user signin consumer.
+func loadChatOpsConsumer(ctx context.Context, db gorp.SqlExecutor, vcsServer, author string) (*sdk.AuthUserConsumer, error) {
+	contact, err := user.LoadContactByTypeAndValue(ctx, db, sdk.UserContactTypeVCS(vcsServer), author)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil, sdk.NewErrorFrom(sdk.ErrForbidden, "%s user %s is not linked to a CDS user", vcsServer, author)
+		}
+		return nil, err
+	}
+	if !contact.Verified {
+		return nil, sdk.NewErrorFrom(sdk.ErrForbidden, "%s user %s is not verified, post the command given when adding the contact", vcsServer, author)
+	}
+	consumers, err := authentication.LoadUserConsumersByUserID(ctx, db, contact.UserID,
+		authentication.LoadUserConsumerOptions.WithAuthentifiedUser,
+		authentication.LoadUserConsumerOptions.WithConsumerGroups)
+	if err != nil {
+		return nil, err
+	}
+	for i := range consumers {
+		if consumers[i].Type != sdk.ConsumerBuiltin && !consumers[i].Disabled {
+			return &consumers[i], nil
+		}
+	}
+	return nil, sdk.NewErrorFrom(sdk.ErrForbidden, "CDS user linked to %s user %s has no active consumer", vcsServer, author)
+}
+
+// failedNode returns the first node of the run whose last execution failed.
+func failedNode(wr *sdk.WorkflowRun) *sdk.Node {
+	for _, n := range wr.Workflow.WorkflowData.Array() {
+		nrs := wr.WorkflowNodeRuns[n.ID]
+		if len(nrs) > 0 && (nrs[0].Status == sdk.StatusFail || nrs[0].Status == sdk.StatusStopped) {
+			return n
+		}
+	}
+	return nil
+}
+
+// waitingManualNode returns the first node of the run that only runs manually and whose ancestors are successful.
+func waitingManualNode(wr *sdk.WorkflowRun) *sdk.Node {
+	for _, n := range wr.Workflow.WorkflowData.Array() {
+		if len(wr.WorkflowNodeRuns[n.ID]) > 0 || !isManualNode(n) {
+			continue
+		}
+		ancestorsOK := true
+		for _, id := range n.Ancestors(wr.Workflow.WorkflowData) {
+			nrs := wr.WorkflowNodeRuns[id]
+			if len(nrs) == 0 || nrs[0].Status != sdk.StatusSuccess {
+				ancestorsOK = false
+				break
+			}
+		}
+		if ancestorsOK {
+			return n
+		}
+	}
+	return nil
+}
+
+func isManualNode(n *sdk.Node) bool {
+	if n.Context == nil {
+		return false
+	}
+	for _, c := range n.Context.Conditions.PlainConditions {
+		if c.Variable == "cds.manual" && c.Operator == sdk.WorkflowConditionsOperatorEquals && c.Value == "true" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_verifyChatOpsContact(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	u, jwtRaw := assets.InsertLambdaUser(t, db)
+	vcsServer := sdk.RandomString(10)
+	author := sdk.RandomString(10)
+
+	uri := api.Router.GetRoute(http.MethodPost, api.postUserContactHandler, map[string]string{"permUsername": u.Username})
+	req := assets.NewJWTAuthentifiedRequest(t, jwtRaw, http.MethodPost, uri, sdk.UserContact{Type: sdk.UserContactTypeVCS(vcsServer), Value: author})
+	rec := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 202, rec.Code)
+	var verification sdk.UserContactVerification
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &verification))
+	code := strings.TrimPrefix(verification.Command, "/cds verify ")
+
+	// The contact is not usable before its verification
+	_, err := loadChatOpsConsumer(context.TODO(), db, vcsServer, author)
+	require.Error(t, err)
+
+	// The code can only be used by the claimed username
+	cmd := sdk.ChatOpsCommand{Name: sdk.ChatOpsCommandVerify, Author: "someone-else", Code: code}
+	_, err = api.verifyChatOpsContact(context.TODO(), vcsServer, cmd)
+	require.Error(t, err)
+
+	cmd.Author = author
+	msg, err := api.verifyChatOpsContact(context.TODO(), vcsServer, cmd)
+	require.NoError(t, err)
+	require.Contains(t, msg, u.Username)
+
+	consumer, err := loadChatOpsConsumer(context.TODO(), db, vcsServer, author)
+	require.NoError(t, err)
+	require.Equal(t, u.ID, consumer.AuthConsumerUser.AuthentifiedUserID)
+
+	// A code can be used only once
+	cmd.Author = "someone-else"
+	_, err = api.verifyChatOpsContact(context.TODO(), vcsServer, cmd)
+	require.Error(t, err)
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rockbears/log"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/ovh/cds/engine/api/metrics"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/telemetry"
+)
+
+func (api *API) initWorkflowMetrics(ctx context.Context) error {
+	api.Metrics.WorkflowLabels = metrics.NewLabelLimiter(api.Config.Metrics.MaxLabelValues, api.Config.Metrics.DisabledLabels)
+	api.Metrics.JobQueueWait = stats.Float64(
+		fmt.Sprintf("cds/cds-api/%s/job_queue_wait", api.Name()),
+		"time spent by jobs in the queue before being taken by a worker",
+		stats.UnitSeconds)
+	api.Metrics.JobDuration = stats.Float64(
+		fmt.Sprintf("cds/cds-api/%s/job_duration", api.Name()),
+		"duration of jobs, from their start to their end",
+		stats.UnitSeconds)
+	api.Metrics.WorkflowRunDuration = stats.Float64(
+		fmt.Sprintf("cds/cds-api/%s/workflow_run_duration", api.Name()),
+		"duration of workflow runs, from their start to their last execution",
+		stats.UnitSeconds)
+
+	return telemetry.RegisterView(ctx, api.workflowMetricsViews()...)
+}
+
+func (api *API) workflowMetricsViews() []*view.View {
+	labels := api.Metrics.WorkflowLabels
+	jobTags := labels.TagKeys(telemetry.TagProjectKey, telemetry.TagWorkflow, telemetry.TagWorkerModel, telemetry.TagRegion)
+	jobStatusTags := labels.TagKeys(telemetry.TagProjectKey, telemetry.TagWorkflow, telemetry.TagWorkerModel, telemetry.TagRegion, telemetry.TagStatus)
+	runStatusTags := labels.TagKeys(telemetry.TagProjectKey, telemetry.TagWorkflow, telemetry.TagStatus)
+
+	return []*view.View{
+		{
+			Name:        "cds/workflow/job_queue_wait_seconds",
+			Description: api.Metrics.JobQueueWait.Description(),
+			Measure:     api.Metrics.JobQueueWait,
+			TagKeys:     jobTags,
+			Aggregation: telemetry.DefaultLongDurationDistribution,
+		},
+		{
+			Name:        "cds/workflow/job_duration_seconds",
+			Description: api.Metrics.JobDuration.Description(),
+			Measure:     api.Metrics.JobDuration,
+			TagKeys:     jobStatusTags,
+			Aggregation: telemetry.DefaultLongDurationDistribution,
+		},
+		{
+			Name:        "cds/workflow/jobs_total",
+			Description: "number of terminated jobs",
+			Measure:     api.Metrics.JobDuration,
+			TagKeys:     jobStatusTags,
+			Aggregation: view.Count(),
+		},
+		{
+			Name:        "cds/workflow/run_duration_seconds",
+			Description: api.Metrics.WorkflowRunDuration.Description(),
+			Measure:     api.Metrics.WorkflowRunDuration,
+			TagKeys:     runStatusTags,
+			Aggregation: telemetry.DefaultLongDurationDistribution,
+		},
+		{
+			Name:        "cds/workflow/runs_total",
+			Description: "number of terminated workflow runs",
+			Measure:     api.Metrics.WorkflowRunDuration,
+			TagKeys:     runStatusTags,
+			Aggregation: view.Count(),
+		},
+	}
+}
+
+// recordWorkflowMetrics records queue wait and duration metrics for the jobs and the workflow runs of given report.
+func (api *API) recordWorkflowMetrics(ctx context.Context, proj sdk.Project, report *workflow.ProcessorReport) {
+	labels := api.Metrics.WorkflowLabels
+	if labels == nil || report == nil {
+		return
+	}
+
+	for _, j := range report.Jobs() {
+		var region string
+		if j.Region != nil {
+			region = *j.Region
+		}
+		mutators := labels.Mutators(map[string]string{
+			telemetry.TagProjectKey:  proj.Key,
+			telemetry.TagWorkflow:    sdk.ParameterValue(j.Parameters, "cds.workflow"),
+			telemetry.TagWorkerModel: j.Model,
+			telemetry.TagRegion:      region,
+		})
+
+		switch {
+		case j.Status == sdk.StatusBuilding && !j.Start.IsZero():
+			api.recordWorkflowMeasure(ctx, mutators, api.Metrics.JobQueueWait, j.Start.Sub(j.Queued).Seconds())
+		case sdk.StatusIsTerminated(j.Status) && !j.Start.IsZero() && !j.Done.IsZero():
+			mutators = append(mutators, tag.Upsert(telemetry.MustNewKey(telemetry.TagStatus), j.Status))
+			api.recordWorkflowMeasure(ctx, mutators, api.Metrics.JobDuration, j.Done.Sub(j.Start).Seconds())
+		}
+	}
+
+	for _, wr := range report.Workflows() {
+		if !sdk.StatusIsTerminated(wr.Status) {
+			continue
+		}
+		mutators := labels.Mutators(map[string]string{
+			telemetry.TagProjectKey: proj.Key,
+			telemetry.TagWorkflow:   wr.Workflow.Name,
+		})
+		mutators = append(mutators, tag.Upsert(telemetry.MustNewKey(telemetry.TagStatus), wr.Status))
+		api.recordWorkflowMeasure(ctx, mutators, api.Metrics.WorkflowRunDuration, wr.LastExecution.Sub(wr.Start).Seconds())
+	}
+}
+
+func (api *API) recordWorkflowMeasure(ctx context.Context, mutators []tag.Mutator, m *stats.Float64Measure, v float64) {
+	ctx, err := tag.New(ctx, mutators...)
+	if err != nil {
+		log.Error(ctx, "recordWorkflowMeasure> unable to tag context: %v", err)
+		return
+	}
+	telemetry.RecordFloat64(ctx, m, v)
+}
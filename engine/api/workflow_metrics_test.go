@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_recordWorkflowMetrics(t *testing.T) {
+	api := &API{}
+	api.Config.Name = "test-" + sdk.RandomString(10)
+	api.Config.Metrics.MaxLabelValues = 1
+	api.Config.Metrics.DisabledLabels = []string{"region"}
+	require.NoError(t, api.initWorkflowMetrics(context.TODO()))
+
+	views := api.workflowMetricsViews()
+	require.NoError(t, view.Register(views...))
+	t.Cleanup(func() { view.Unregister(views...) })
+
+	now := time.Now()
+	region := "eu"
+	report := new(workflow.ProcessorReport)
+	report.Add(context.TODO(),
+		sdk.WorkflowNodeJobRun{
+			Status:     sdk.StatusBuilding,
+			Queued:     now.Add(-time.Minute),
+			Start:      now,
+			Model:      "my-model",
+			Region:     &region,
+			Parameters: []sdk.Parameter{{Name: "cds.workflow", Value: "build"}},
+		},
+		sdk.WorkflowNodeJobRun{
+			Status:     sdk.StatusFail,
+			Queued:     now.Add(-time.Minute),
+			Start:      now,
+			Done:       now.Add(time.Minute),
+			Model:      "another-model",
+			Parameters: []sdk.Parameter{{Name: "cds.workflow", Value: "build"}},
+		},
+		sdk.WorkflowRun{
+			Status:        sdk.StatusFail,
+			Start:         now.Add(-time.Minute),
+			LastExecution: now.Add(time.Minute),
+			Workflow:      sdk.Workflow{Name: "build"},
+		},
+	)
+	api.recordWorkflowMetrics(context.TODO(), sdk.Project{Key: "PROJ"}, report)
+
+	rows, err := view.RetrieveData("cds/workflow/job_queue_wait_seconds")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Len(t, rows[0].Tags, 3)
+	require.Equal(t, 60.0, rows[0].Data.(*view.DistributionData).Mean)
+
+	rows, err = view.RetrieveData("cds/workflow/jobs_total")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	for _, tag := range rows[0].Tags {
+		switch tag.Key.Name() {
+		case "worker_model":
+			// The limit of one value per label was reached by the first job
+			require.Equal(t, "other", tag.Value)
+		case "status":
+			require.Equal(t, sdk.StatusFail, tag.Value)
+		}
+	}
+
+	rows, err = view.RetrieveData("cds/workflow/runs_total")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, int64(1), rows[0].Data.(*view.CountData).Value)
+}
@@ -20,18 +20,25 @@ import (
 	"github.com/ovh/cds/engine/api/notification"
 	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/secretprovider"
 	"github.com/ovh/cds/engine/api/services"
 	"github.com/ovh/cds/engine/api/worker"
 	"github.com/ovh/cds/engine/api/workermodel"
 	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/cache"
 	"github.com/ovh/cds/engine/gorpmapper"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/jws"
 	"github.com/ovh/cds/sdk/telemetry"
+	"github.com/ovh/cds/sdk/vcs/git"
 	"github.com/rockbears/log"
 )
 
+// fairShareQueueWindow is the count of waiting jobs loaded for each project and priority class when a hatchery polls
+// the queue without limit.
+const fairShareQueueWindow = 100
+
 func (api *API) postTakeWorkflowJobHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		id, err := requestVarInt(r, "permJobID")
@@ -123,12 +130,57 @@ func (api *API) postTakeWorkflowJobHandler() service.Handler {
 }
 
 func (api *API) takeJob(ctx context.Context, p *sdk.Project, id int64, workerModel string, wnjri *sdk.WorkflowNodeJobRunData, wk *sdk.Worker, hatcheryName string) (*workflow.ProcessorReport, error) {
+	// Secrets are loaded before taking the job, references are resolved from external secret providers outside of
+	// the transaction to not hold its locks during the calls to the providers
+	jobRun, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, id)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load job %d", id)
+	}
+	noderun, err := workflow.LoadNodeRunByID(ctx, api.mustDB(), jobRun.WorkflowNodeRunID, workflow.LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get node run")
+	}
+	workflowRun, err := workflow.LoadRunByID(ctx, api.mustDB(), noderun.WorkflowRunID, workflow.LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load workflow run")
+	}
+	secrets, countMatchedSecrets, err := api.loadJobSecrets(ctx, p, jobRun, workflowRun, noderun)
+	if err != nil {
+		return nil, err
+	}
+
+	// Jobs of a share with a max concurrent jobs per model are taken one at a time, so the count of building jobs
+	// checked in the transaction can't be exceeded by concurrent takes
+	quota, err := workflow.LoadQueueQuotaByProjectID(api.mustDB(), jobRun.ProjectID)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil, err
+	}
+	if quota != nil && quota.MaxConcurrentJobsPerModel > 0 && workerModel != "" {
+		lockKey := cache.Key("api:queueQuota", strconv.FormatInt(quota.ID, 10), workerModel)
+		locked, err := api.Cache.Lock(lockKey, 30*time.Second, 100, 100)
+		if err != nil {
+			return nil, err
+		}
+		if !locked {
+			return nil, sdk.NewErrorFrom(sdk.ErrQueueQuotaReached, "cannot lock the queue quota for model %s", workerModel)
+		}
+		defer func() {
+			_ = api.Cache.Unlock(lockKey)
+		}()
+	}
+
 	tx, err := api.mustDB().Begin()
 	if err != nil {
 		return nil, sdk.WrapError(err, "cannot start transaction")
 	}
 	defer tx.Rollback() // nolint
 
+	if quota != nil {
+		if err := workflow.CheckQueueQuota(tx, *quota, workerModel); err != nil {
+			return nil, err
+		}
+	}
+
 	// Prepare spawn infos
 	infos := []sdk.SpawnInfo{{
 		RemoteTime: getRemoteTime(ctx),
@@ -156,7 +208,7 @@ func (api *API) takeJob(ctx context.Context, p *sdk.Project, id int64, workerMod
 	wnjri.SigningKey = base64.StdEncoding.EncodeToString(workerKey)
 
 	// Load the node run
-	noderun, err := workflow.LoadNodeRunByID(ctx, tx, job.WorkflowNodeRunID, workflow.LoadRunOptions{})
+	noderun, err = workflow.LoadNodeRunByID(ctx, tx, job.WorkflowNodeRunID, workflow.LoadRunOptions{})
 	if err != nil {
 		return nil, sdk.WrapError(err, "cannot get node run")
 	}
@@ -169,38 +221,17 @@ func (api *API) takeJob(ctx context.Context, p *sdk.Project, id int64, workerMod
 		report.Add(ctx, *noderun)
 	}
 
-	// Load workflow run
-	workflowRun, err := workflow.LoadRunByID(ctx, tx, noderun.WorkflowRunID, workflow.LoadRunOptions{})
-	if err != nil {
-		return nil, sdk.WrapError(err, "unable to load workflow run")
-	}
-
-	secrets, err := workflow.LoadDecryptSecrets(ctx, tx, workflowRun, noderun)
-	if err != nil {
-		return nil, sdk.WrapError(err, "cannot load secrets")
-	}
-
 	// Feed the worker
 	wnjri.ProjectKey = p.Key
 	wnjri.NodeJobRun = *job
 	wnjri.Number = noderun.Number
 	wnjri.SubNumber = noderun.SubNumber
-	wnjri.Secrets = make([]sdk.Variable, 0, len(secrets))
+	wnjri.Secrets = secrets
 	wnjri.RunID = workflowRun.ID
 	wnjri.WorkflowID = workflowRun.WorkflowID
 	wnjri.WorkflowName = workflowRun.Workflow.Name
 	wnjri.NodeRunName = noderun.WorkflowNodeName
 
-	secretsReqs := job.Job.Action.Requirements.FilterByType(sdk.SecretRequirement).Values()
-	secretsReqsRegs := make([]*regexp.Regexp, 0, len(secretsReqs))
-	for i := range secretsReqs {
-		r, err := regexp.Compile(secretsReqs[i])
-		if err != nil {
-			return nil, sdk.WithStack(err)
-		}
-		secretsReqsRegs = append(secretsReqsRegs, r)
-	}
-
 	// Filter project's secrets depending of the region requirement that was set on job
 	skipProjectSecrets := job.Region != nil && sdk.IsInArray(*job.Region, api.Config.Secrets.SkipProjectSecretsOnRegion)
 	if skipProjectSecrets {
@@ -212,7 +243,45 @@ func (api *API) takeJob(ctx context.Context, p *sdk.Project, id int64, workerMod
 		}
 	}
 
+	if skipProjectSecrets && len(job.Job.Action.Requirements.FilterByType(sdk.SecretRequirement)) > 0 {
+		if err := workflow.AddSpawnInfosNodeJobRun(tx, job.WorkflowNodeRunID, job.ID, []sdk.SpawnInfo{{
+			RemoteTime: getRemoteTime(ctx),
+			Message:    sdk.SpawnMsg{ID: sdk.MsgSpawnInfoManualSecretInjection.ID, Args: []interface{}{fmt.Sprintf("%d", countMatchedSecrets)}},
+		}}); err != nil {
+			return nil, sdk.WrapError(err, "cannot save spawn info job %d", job.ID)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	return report, nil
+}
+
+// loadJobSecrets returns the secrets given to the worker for a job and the count of project's secrets that matched the
+// secret requirements of the job when project's secrets are skipped for its region.
+func (api *API) loadJobSecrets(ctx context.Context, p *sdk.Project, job *sdk.WorkflowNodeJobRun, workflowRun *sdk.WorkflowRun, noderun *sdk.WorkflowNodeRun) ([]sdk.Variable, int, error) {
+	secrets, err := workflow.LoadDecryptSecrets(ctx, api.mustDB(), workflowRun, noderun)
+	if err != nil {
+		return nil, 0, sdk.WrapError(err, "cannot load secrets")
+	}
+
+	secretsReqs := job.Job.Action.Requirements.FilterByType(sdk.SecretRequirement).Values()
+	secretsReqsRegs := make([]*regexp.Regexp, 0, len(secretsReqs))
+	for i := range secretsReqs {
+		r, err := regexp.Compile(secretsReqs[i])
+		if err != nil {
+			return nil, 0, sdk.WithStack(err)
+		}
+		secretsReqsRegs = append(secretsReqsRegs, r)
+	}
+
+	skipProjectSecrets := job.Region != nil && sdk.IsInArray(*job.Region, api.Config.Secrets.SkipProjectSecretsOnRegion)
+
 	var countMatchedSecrets int
+	var secretRefs []sdk.Variable
+	res := make([]sdk.Variable, 0, len(secrets))
 	for i := range secrets {
 		if skipProjectSecrets && secrets[i].Context == workflow.SecretProjContext {
 			var inRequirements bool
@@ -227,23 +296,23 @@ func (api *API) takeJob(ctx context.Context, p *sdk.Project, id int64, workerMod
 			}
 			countMatchedSecrets++
 		}
-		wnjri.Secrets = append(wnjri.Secrets, secrets[i].ToVariable())
-	}
-
-	if skipProjectSecrets && len(secretsReqs) > 0 {
-		if err := workflow.AddSpawnInfosNodeJobRun(tx, job.WorkflowNodeRunID, job.ID, []sdk.SpawnInfo{{
-			RemoteTime: getRemoteTime(ctx),
-			Message:    sdk.SpawnMsg{ID: sdk.MsgSpawnInfoManualSecretInjection.ID, Args: []interface{}{fmt.Sprintf("%d", countMatchedSecrets)}},
-		}}); err != nil {
-			return nil, sdk.WrapError(err, "cannot save spawn info job %d", job.ID)
+		if secrets[i].Type == sdk.SecretRefVariable {
+			secretRefs = append(secretRefs, secrets[i].ToVariable())
+			continue
 		}
+		res = append(res, secrets[i].ToVariable())
 	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, sdk.WithStack(err)
+	// Secret references are resolved from external secret providers, values are only sent to the worker
+	if len(secretRefs) > 0 {
+		resolved, err := secretprovider.ResolveVariables(ctx, api.mustDB(), p.Key, secretRefs)
+		if err != nil {
+			return nil, 0, err
+		}
+		res = append(res, resolved...)
 	}
 
-	return report, nil
+	return res, countMatchedSecrets, nil
 }
 
 func (api *API) postBookWorkflowJobHandler() service.Handler {
@@ -262,14 +331,25 @@ func (api *API) postBookWorkflowJobHandler() service.Handler {
 			return err
 		}
 
-		if _, err := workflow.BookNodeJobRun(ctx, api.Cache, id, s); err != nil {
-			return sdk.WrapError(err, "job already booked")
-		}
-
 		jobRun, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, id)
 		if err != nil {
 			return err
 		}
+
+		// Do not book a job that could not be taken because of the queue quota of its project
+		quota, err := workflow.LoadQueueQuotaByProjectID(api.mustDB(), jobRun.ProjectID)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if quota != nil {
+			if err := workflow.CheckQueueQuota(api.mustDB(), *quota, sdk.QueueJobModel(*jobRun)); err != nil {
+				return err
+			}
+		}
+
+		if _, err := workflow.BookNodeJobRun(ctx, api.Cache, id, s); err != nil {
+			return sdk.WrapError(err, "job already booked")
+		}
 		wnr, err := workflow.LoadNodeRunByID(ctx, api.mustDB(), jobRun.WorkflowNodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
 		if err != nil {
 			return err
@@ -681,6 +761,48 @@ func (api *API) getWorkerCacheLinkHandler() service.Handler {
 	}
 }
 
+// getWorkflowJobGitMirrorAccessHandler returns the list of the repositories of the job signed for the worker session,
+// the repositories service only serves the mirrors of these repositories to the worker.
+func (api *API) getWorkflowJobGitMirrorAccessHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+		session := getAuthSession(ctx)
+		if session == nil {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return err
+		}
+		jobRun, err := workflow.LoadNodeJobRun(ctx, api.mustDBWithCtx(ctx), api.Cache, id)
+		if err != nil {
+			return err
+		}
+		if jobRun.Status != sdk.StatusBuilding {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "cannot give access to git mirrors for a job with status %s", jobRun.Status)
+		}
+
+		access := git.MirrorAccess{SessionID: session.ID}
+		for _, name := range []string{"git.url", "git.http_url"} {
+			if u := sdk.ParameterValue(jobRun.Parameters, name); u != "" && git.CheckRemoteURL(u) == nil {
+				access.Upstreams = append(access.Upstreams, u)
+			}
+		}
+		if len(access.Upstreams) == 0 {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "job %d has no repository", id)
+		}
+
+		token, err := authentication.SignJWS(access, time.Now(), time.Hour)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, sdk.GitMirrorAccessToken{Token: token}, http.StatusOK)
+	}
+}
+
 func (api *API) postWorkflowJobStepStatusHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		if isWorker := isWorker(ctx); !isWorker {
@@ -877,6 +999,18 @@ func (api *API) getWorkflowJobQueueHandler() service.Handler {
 			filter.ModelType = []string{modelType}
 		}
 
+		// Waiting jobs are given to the hatcheries in the fair-share order, the limit is applied after sorting the queue.
+		// Only the oldest jobs of each project and priority class can be at the head of the queue, so the others are not loaded.
+		fairShare := isS && len(status) == 1 && status[0] == sdk.StatusWaiting
+		if fairShare {
+			window := fairShareQueueWindow
+			if limit > 0 {
+				window = limit
+			}
+			filter.Limit = nil
+			filter.LimitByProject = &window
+		}
+
 		// If the consumer is a hatchery or a non maintainer user, filter the job by its groups
 		if isS || !isMaintainer(ctx) {
 			jobs, err = workflow.LoadNodeJobRunQueueByGroupIDs(ctx, api.mustDB(), api.Cache, filter, getUserConsumer(ctx).GetGroupIDs())
@@ -887,10 +1021,39 @@ func (api *API) getWorkflowJobQueueHandler() service.Handler {
 			return sdk.WrapError(err, "unable to load queue")
 		}
 
+		if fairShare {
+			jobs, err = workflow.FairShareQueue(ctx, api.mustDB(), jobs)
+			if err != nil {
+				return err
+			}
+			if limit > 0 && len(jobs) > limit {
+				jobs = jobs[:limit]
+			}
+		}
+
+		if QueryBool(r, "withQueueInfo") {
+			if err := workflow.ComputeQueueInfos(ctx, api.mustDB(), api.Cache, jobs); err != nil {
+				return err
+			}
+		}
+
 		return service.WriteJSON(w, jobs, http.StatusOK)
 	}
 }
 
+func (api *API) getQueueQuotaUsagesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if !isMaintainer(ctx) {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+		usages, err := workflow.LoadQueueQuotaUsages(ctx, api.mustDB())
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, usages, http.StatusOK)
+	}
+}
+
 func getModelType(ctx context.Context, r *http.Request) (string, error) {
 	modelType := FormString(r, "modelType")
 	if modelType != "" {
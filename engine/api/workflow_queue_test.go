@@ -692,6 +692,47 @@ func Test_postTakeWorkflowInvalidJobHandler(t *testing.T) {
 	require.Equal(t, 200, rec2.Code)
 }
 
+func Test_postTakeWorkflowJobWithQueueQuotaHandler(t *testing.T) {
+	api, db, router := newTestAPI(t)
+
+	ctx := testRunWorkflow(t, api, router)
+
+	mockVCSSservice, _, _ := assets.InitCDNService(t, db)
+	t.Cleanup(func() {
+		_ = services.Delete(db, mockVCSSservice) // nolint
+	})
+
+	testRegisterWorker(t, api, db, router, &ctx)
+
+	quota := sdk.QueueQuota{ProjectKey: ctx.project.Key, Weight: 1, MaxConcurrentJobsPerModel: 1}
+	require.NoError(t, workflow.InsertQueueQuota(db, &quota))
+
+	uri := router.GetRoute("POST", api.postTakeWorkflowJobHandler, map[string]string{
+		"permJobID": fmt.Sprintf("%d", ctx.job.ID),
+	})
+	req := assets.NewJWTAuthentifiedRequest(t, ctx.workerToken, "POST", uri, nil)
+	rec := httptest.NewRecorder()
+	router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+
+	// The job is building, no other job with the same model can be taken
+	q, err := workflow.LoadQueueQuotaByProjectID(db, ctx.project.ID)
+	require.NoError(t, err)
+	require.Equal(t, quota.ID, q.ID)
+	err = workflow.CheckQueueQuota(db, *q, ctx.model.Name)
+	require.True(t, sdk.ErrorIs(err, sdk.ErrQueueQuotaReached))
+	require.NoError(t, workflow.CheckQueueQuota(db, *q, "other-model"))
+
+	// Nor booked
+	uri = router.GetRoute("POST", api.postBookWorkflowJobHandler, map[string]string{
+		"permJobID": fmt.Sprintf("%d", ctx.job.ID),
+	})
+	req = assets.NewJWTAuthentifiedRequest(t, ctx.hatcheryToken, "POST", uri, nil)
+	rec = httptest.NewRecorder()
+	router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 429, rec.Code)
+}
+
 func Test_postBookWorkflowJobHandler(t *testing.T) {
 	api, db, router := newTestAPI(t)
 
@@ -1179,8 +1179,8 @@ func saveWorkflowRunSecrets(ctx context.Context, db *gorp.DbMap, projID int64, w
 		return err
 	}
 
-	// Create a snapshot of project secrets and keys
-	pv := sdk.VariablesFilter(sdk.FromProjectVariables(p.Variables), sdk.SecretVariable, sdk.KeyVariable)
+	// Create a snapshot of project secrets and keys, secret references are resolved when a job is taken
+	pv := sdk.VariablesFilter(sdk.FromProjectVariables(p.Variables), sdk.SecretVariable, sdk.KeyVariable, sdk.SecretRefVariable)
 	pv = sdk.VariablesPrefix(pv, "cds.proj.")
 	for _, v := range pv {
 		wrSecret := sdk.WorkflowRunSecret{
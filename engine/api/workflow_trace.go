@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+
+	"go.opencensus.io/trace"
+
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/telemetry"
+)
+
+// exportWorkflowTraces exports the spans of the workflow runs, node runs, jobs and steps of given report.
+// A workflow run is one trace, spans are computed from the timestamps stored on each entity once it is terminated.
+func (api *API) exportWorkflowTraces(ctx context.Context, proj sdk.Project, report *workflow.ProcessorReport) {
+	ctx = telemetry.ContextWithTelemetry(api.Router.Background, ctx)
+	if telemetry.TraceExporter(ctx) == nil || report == nil {
+		return
+	}
+
+	for _, j := range report.Jobs() {
+		traceID, ok := telemetry.TraceIDFromHeaders(j.Header)
+		if !ok {
+			continue
+		}
+		nodeSpanID := telemetry.WorkflowSpanID(traceID, telemetry.WorkflowSpanNodeRun, j.WorkflowNodeRunID)
+		attributes := map[string]interface{}{
+			telemetry.TagProjectKey:         proj.Key,
+			telemetry.TagWorkflow:           sdk.ParameterValue(j.Parameters, "cds.workflow"),
+			telemetry.TagWorkflowNodeJobRun: j.ID,
+			telemetry.TagWorkerModel:        j.Model,
+			telemetry.TagWorker:             j.WorkerName,
+		}
+		if j.Region != nil {
+			attributes[telemetry.TagRegion] = *j.Region
+		}
+
+		switch {
+		case j.Status == sdk.StatusBuilding && !j.Start.IsZero():
+			telemetry.ExportSpan(ctx, telemetry.WorkflowSpanData(traceID,
+				telemetry.WorkflowSpanID(traceID, telemetry.WorkflowSpanJobQueue, j.ID), nodeSpanID,
+				telemetry.WorkflowSpanJobQueue, j.Queued, j.Start, false, attributes))
+		case sdk.StatusIsTerminated(j.Status) && !j.Start.IsZero() && !j.Done.IsZero():
+			jobSpanID := telemetry.WorkflowSpanID(traceID, telemetry.WorkflowSpanJob, j.ID)
+			attributes[telemetry.TagStatus] = j.Status
+			telemetry.ExportSpan(ctx, telemetry.WorkflowSpanData(traceID, jobSpanID, nodeSpanID,
+				j.Job.Action.Name, j.Start, j.Done, isWorkflowStatusFailed(j.Status), attributes))
+
+			for i, step := range j.Job.StepStatus {
+				if step.Start.IsZero() || step.Done.IsZero() {
+					continue
+				}
+				name := telemetry.WorkflowSpanStep
+				if step.StepOrder >= 0 && step.StepOrder < len(j.Job.Action.Actions) {
+					name = j.Job.Action.Actions[step.StepOrder].StepName
+					if name == "" {
+						name = j.Job.Action.Actions[step.StepOrder].Name
+					}
+				}
+				telemetry.ExportSpan(ctx, telemetry.WorkflowSpanData(traceID,
+					telemetry.WorkflowSpanID(traceID, telemetry.WorkflowSpanStep, j.ID, int64(i)), jobSpanID,
+					name, step.Start, step.Done, isWorkflowStatusFailed(step.Status), map[string]interface{}{
+						telemetry.TagStatus: step.Status,
+						"step_order":        int64(step.StepOrder),
+					}))
+			}
+		}
+	}
+
+	for _, nr := range report.Nodes() {
+		traceID, ok := telemetry.TraceIDFromHeaders(nr.Header)
+		if !ok || !sdk.StatusIsTerminated(nr.Status) || nr.Start.IsZero() || nr.Done.IsZero() {
+			continue
+		}
+		telemetry.ExportSpan(ctx, telemetry.WorkflowSpanData(traceID,
+			telemetry.WorkflowSpanID(traceID, telemetry.WorkflowSpanNodeRun, nr.ID),
+			telemetry.WorkflowSpanID(traceID, telemetry.WorkflowSpanRun, nr.WorkflowRunID, nr.SubNumber),
+			nr.WorkflowNodeName, nr.Start, nr.Done, isWorkflowStatusFailed(nr.Status), map[string]interface{}{
+				telemetry.TagProjectKey:      proj.Key,
+				telemetry.TagWorkflowNodeRun: nr.ID,
+				telemetry.TagStatus:          nr.Status,
+				"subnumber":                  nr.SubNumber,
+			}))
+	}
+
+	for _, wr := range report.Workflows() {
+		traceID, ok := telemetry.TraceIDFromHeaders(wr.Header)
+		if !ok || !sdk.StatusIsTerminated(wr.Status) {
+			continue
+		}
+		telemetry.ExportSpan(ctx, telemetry.WorkflowSpanData(traceID,
+			telemetry.WorkflowSpanID(traceID, telemetry.WorkflowSpanRun, wr.ID, wr.LastSubNumber), trace.SpanID{},
+			wr.Workflow.Name, wr.Start, wr.LastExecution, isWorkflowStatusFailed(wr.Status), map[string]interface{}{
+				telemetry.TagProjectKey:  proj.Key,
+				telemetry.TagWorkflow:    wr.Workflow.Name,
+				telemetry.TagWorkflowRun: wr.Number,
+				telemetry.TagStatus:      wr.Status,
+				"subnumber":              wr.LastSubNumber,
+			}))
+	}
+}
+
+func isWorkflowStatusFailed(status string) bool {
+	return status == sdk.StatusFail || status == sdk.StatusStopped
+}
@@ -33,6 +33,7 @@ type Store interface {
 	DeleteAll(key string) error
 	Exist(key string) (bool, error)
 	Eval(expr string, args ...string) (string, error)
+	EvalWithArgs(expr string, keys []string, args ...interface{}) (string, error)
 	HealthStore
 	LockStore
 	QueueStore
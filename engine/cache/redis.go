@@ -725,3 +725,12 @@ func (s *RedisStore) Eval(expr string, args ...string) (string, error) {
 	}
 	return fmt.Sprintf("%v", result), nil
 }
+
+// EvalWithArgs runs given script with given keys and arguments, available in the script as KEYS and ARGV.
+func (s *RedisStore) EvalWithArgs(expr string, keys []string, args ...interface{}) (string, error) {
+	result, err := s.Client.Eval(expr, keys, args...).Result()
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	return fmt.Sprintf("%v", result), nil
+}
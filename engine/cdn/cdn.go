@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-gorp/gorp"
 	"github.com/gorilla/mux"
@@ -165,6 +166,10 @@ func (s *Service) Start(ctx context.Context) error {
 		s.LogCache.Evict(ctx)
 	})
 
+	s.GoRoutines.RunWithRestart(ctx, "service.database-roll-jobs", func(ctx context.Context) {
+		database.RollJobsRoutine(ctx, s.mustDB, s.Mapper, time.Minute)
+	})
+
 	return nil
 }
 
@@ -40,3 +40,19 @@ func (s *Service) getAdminDatabaseEncryptedTuplesByEntity() service.Handler {
 func (s *Service) postAdminDatabaseRollEncryptedEntityByPrimaryKey() service.Handler {
 	return database.AdminDatabaseRollEncryptedEntityByPrimaryKey(s.mustDB, s.Mapper)
 }
+
+func (s *Service) postAdminDatabaseRollJob() service.Handler {
+	return database.AdminDatabaseRollJobCreate(s.mustDB, s.Mapper)
+}
+
+func (s *Service) getAdminDatabaseRollJobs() service.Handler {
+	return database.AdminDatabaseRollJobList(s.mustDB)
+}
+
+func (s *Service) getAdminDatabaseRollJob() service.Handler {
+	return database.AdminDatabaseRollJobGet(s.mustDB)
+}
+
+func (s *Service) postAdminDatabaseRollJobCancel() service.Handler {
+	return database.AdminDatabaseRollJobCancel(s.mustDB)
+}
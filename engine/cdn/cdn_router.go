@@ -56,6 +56,9 @@ func (s *Service) initRouter(ctx context.Context) {
 	r.Handle("/admin/database/encryption", nil, r.GET(s.getAdminDatabaseEncryptedEntities))
 	r.Handle("/admin/database/encryption/{entity}", nil, r.GET(s.getAdminDatabaseEncryptedTuplesByEntity))
 	r.Handle("/admin/database/encryption/{entity}/roll/{pk}", nil, r.POST(s.postAdminDatabaseRollEncryptedEntityByPrimaryKey))
+	r.Handle("/admin/database/roll", nil, r.GET(s.getAdminDatabaseRollJobs), r.POST(s.postAdminDatabaseRollJob))
+	r.Handle("/admin/database/roll/{id}", nil, r.GET(s.getAdminDatabaseRollJob))
+	r.Handle("/admin/database/roll/{id}/cancel", nil, r.POST(s.postAdminDatabaseRollJobCancel))
 
 	r.Handle("/admin/backend/{id}/resync/{type}", nil, r.POST(s.postAdminResyncBackendWithDatabaseHandler))
 
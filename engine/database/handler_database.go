@@ -3,6 +3,8 @@ package database
 import (
 	"context"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-gorp/gorp"
 	"github.com/gorilla/mux"
@@ -151,3 +153,120 @@ func AdminDatabaseRollEncryptedEntityByPrimaryKey(db DBFunc, mapper *gorpmapper.
 		return nil
 	}
 }
+
+func AdminDatabaseRollJobCreate(db DBFunc, mapper *gorpmapper.Mapper) service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var req sdk.DatabaseRollJobRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+
+		jobs, err := NewRollJobs(db(), mapper, req)
+		if err != nil {
+			return err
+		}
+
+		tx, err := db().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		active, err := LoadActiveRollJobIDs(tx)
+		if err != nil {
+			return err
+		}
+		for i := range jobs {
+			for _, id := range active {
+				j, err := LoadRollJobByID(tx, id)
+				if err != nil {
+					return err
+				}
+				if j.Type == jobs[i].Type && j.Entity == jobs[i].Entity {
+					return sdk.NewErrorFrom(sdk.ErrConflictData, "a %s roll job is already running on entity %s", j.Type, j.Entity)
+				}
+			}
+			if err := InsertRollJob(tx, &jobs[i]); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, jobs, http.StatusOK)
+	}
+}
+
+func AdminDatabaseRollJobList(db DBFunc) service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		jobs, err := LoadRollJobs(db())
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, jobs, http.StatusOK)
+	}
+}
+
+func AdminDatabaseRollJobGet(db DBFunc) service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+
+		j, err := LoadRollJobByID(db(), id)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, j, http.StatusOK)
+	}
+}
+
+func AdminDatabaseRollJobCancel(db DBFunc) service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+
+		tx, err := db().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		// Wait for the current batch to be committed
+		if _, err := tx.Exec(`SELECT id FROM database_roll_job WHERE id = $1 FOR UPDATE`, id); err != nil {
+			return sdk.WithStack(err)
+		}
+		j, err := LoadRollJobByID(tx, id)
+		if err != nil {
+			return err
+		}
+		if j.Status != sdk.DatabaseRollJobStatusPending && j.Status != sdk.DatabaseRollJobStatusRunning {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "roll job %d is already %s", j.ID, j.Status)
+		}
+		now := time.Now()
+		j.Status = sdk.DatabaseRollJobStatusCanceled
+		j.Done = &now
+		if err := UpdateRollJob(tx, j); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, j, http.StatusOK)
+	}
+}
+
+func requestVarInt(r *http.Request, name string) (int64, error) {
+	id, err := strconv.ParseInt(mux.Vars(r)[name], 10, 64)
+	if err != nil {
+		return 0, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid %s", name)
+	}
+	return id, nil
+}
@@ -0,0 +1,235 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+var activeRollJobStatus = pq.StringArray{sdk.DatabaseRollJobStatusPending, sdk.DatabaseRollJobStatusRunning}
+
+// InsertRollJob creates a new roll job.
+func InsertRollJob(db gorp.SqlExecutor, j *sdk.DatabaseRollJob) error {
+	query := `INSERT INTO database_roll_job (type, entity, status, batch_size, delay_ms, total)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, created, last_modified`
+	if err := db.QueryRow(query, j.Type, j.Entity, j.Status, j.BatchSize, j.Delay, j.Total).Scan(&j.ID, &j.Created, &j.LastModified); err != nil {
+		return sdk.WrapError(err, "unable to insert database roll job")
+	}
+	return nil
+}
+
+// UpdateRollJob updates the progress and the status of a roll job.
+func UpdateRollJob(db gorp.SqlExecutor, j *sdk.DatabaseRollJob) error {
+	j.LastModified = time.Now()
+	query := `UPDATE database_roll_job
+	SET status = $2, processed = $3, last_pk = $4, error = $5, last_modified = $6, done = $7
+	WHERE id = $1`
+	if _, err := db.Exec(query, j.ID, j.Status, j.Processed, j.LastPK, j.Error, j.LastModified, j.Done); err != nil {
+		return sdk.WrapError(err, "unable to update database roll job %d", j.ID)
+	}
+	return nil
+}
+
+// LoadRollJobs returns all roll jobs, the most recent first.
+func LoadRollJobs(db gorp.SqlExecutor) ([]sdk.DatabaseRollJob, error) {
+	var res []sdk.DatabaseRollJob
+	if _, err := db.Select(&res, `SELECT * FROM database_roll_job ORDER BY id DESC`); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return res, nil
+}
+
+// LoadRollJobByID returns a roll job.
+func LoadRollJobByID(db gorp.SqlExecutor, id int64) (*sdk.DatabaseRollJob, error) {
+	var res sdk.DatabaseRollJob
+	if err := db.SelectOne(&res, `SELECT * FROM database_roll_job WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.WithStack(sdk.ErrNotFound)
+		}
+		return nil, sdk.WithStack(err)
+	}
+	return &res, nil
+}
+
+// LoadActiveRollJobIDs returns the ids of the jobs that are not finished.
+func LoadActiveRollJobIDs(db gorp.SqlExecutor) ([]int64, error) {
+	var res []int64
+	if _, err := db.Select(&res, `SELECT id FROM database_roll_job WHERE status = ANY($1) ORDER BY id`, activeRollJobStatus); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return res, nil
+}
+
+// loadActiveRollJobForUpdate locks an active roll job. It returns nil if the job is finished or locked by another instance.
+func loadActiveRollJobForUpdate(db gorp.SqlExecutor, id int64) (*sdk.DatabaseRollJob, error) {
+	var res sdk.DatabaseRollJob
+	query := `SELECT * FROM database_roll_job WHERE id = $1 AND status = ANY($2) FOR UPDATE SKIP LOCKED`
+	if err := db.SelectOne(&res, query, id, activeRollJobStatus); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, sdk.WithStack(err)
+	}
+	return &res, nil
+}
+
+// NewRollJobs checks the request and returns the jobs to create, one per entity.
+func NewRollJobs(db gorp.SqlExecutor, mapper *gorpmapper.Mapper, req sdk.DatabaseRollJobRequest) ([]sdk.DatabaseRollJob, error) {
+	if err := req.IsValid(); err != nil {
+		return nil, err
+	}
+
+	var available []string
+	if req.Type == sdk.DatabaseRollJobTypeSignature {
+		available = mapper.ListSignedEntities()
+	} else {
+		available = mapper.ListEncryptedEntities()
+	}
+
+	entities := req.Entities
+	if len(entities) == 0 {
+		entities = available
+	}
+	if req.BatchSize == 0 {
+		req.BatchSize = sdk.DefaultDatabaseRollJobBatchSize
+	}
+
+	jobs := make([]sdk.DatabaseRollJob, 0, len(entities))
+	for _, e := range entities {
+		if !sdk.IsInArray(e, available) {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "entity %q is not a %s entity", e, req.Type)
+		}
+		total, err := mapper.CountTuplesByEntity(db, e)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, sdk.DatabaseRollJob{
+			Type:      req.Type,
+			Entity:    e,
+			Status:    sdk.DatabaseRollJobStatusPending,
+			BatchSize: req.BatchSize,
+			Delay:     req.Delay,
+			Total:     total,
+		})
+	}
+	return jobs, nil
+}
+
+// processRollJobBatch rolls the next batch of tuples of a job. The job row is locked during the batch so several
+// instances can run the routine, the progress is committed with the rolled tuples so the job can be resumed after
+// a restart. It returns nil if there is nothing more to do for now.
+func processRollJobBatch(ctx context.Context, db DBFunc, mapper *gorpmapper.Mapper, id int64) (*sdk.DatabaseRollJob, error) {
+	tx, err := db().Begin()
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	j, err := loadActiveRollJobForUpdate(tx, id)
+	if err != nil || j == nil {
+		return nil, err
+	}
+
+	pks, err := mapper.ListTuplesByEntityAfter(tx, j.Entity, j.LastPK, j.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pk := range pks {
+		var err error
+		if j.Type == sdk.DatabaseRollJobTypeSignature {
+			err = mapper.RollSignedTupleByPrimaryKey(ctx, tx, j.Entity, pk)
+		} else {
+			err = mapper.RollEncryptedTupleByPrimaryKey(ctx, tx, j.Entity, pk)
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, failRollJob(db(), id, fmt.Sprintf("unable to roll tuple %s: %v", pk, err))
+		}
+	}
+
+	j.Processed += int64(len(pks))
+	if len(pks) > 0 {
+		j.LastPK = &pks[len(pks)-1]
+	}
+	j.Status = sdk.DatabaseRollJobStatusRunning
+	if int64(len(pks)) < j.BatchSize {
+		now := time.Now()
+		j.Status = sdk.DatabaseRollJobStatusDone
+		j.Done = &now
+	}
+	if err := UpdateRollJob(tx, j); err != nil {
+		return nil, err
+	}
+
+	return j, sdk.WithStack(tx.Commit())
+}
+
+func failRollJob(db gorp.SqlExecutor, id int64, reason string) error {
+	j, err := LoadRollJobByID(db, id)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	j.Status = sdk.DatabaseRollJobStatusFail
+	j.Error = reason
+	j.Done = &now
+	return UpdateRollJob(db, j)
+}
+
+// RunRollJob processes all the batches of a job, waiting the job's delay between two batches.
+func RunRollJob(ctx context.Context, db DBFunc, mapper *gorpmapper.Mapper, id int64) error {
+	for {
+		j, err := processRollJobBatch(ctx, db, mapper, id)
+		if err != nil {
+			return err
+		}
+		if j == nil || j.Status != sdk.DatabaseRollJobStatusRunning {
+			if j != nil {
+				log.Info(ctx, "database roll job %d on %s %s is %s (%d/%d, %.0f%%)", j.ID, j.Type, j.Entity, j.Status, j.Processed, j.Total, j.Progress())
+			}
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(j.Delay) * time.Millisecond):
+		}
+	}
+}
+
+// RollJobsRoutine runs the pending roll jobs.
+func RollJobsRoutine(ctx context.Context, db DBFunc, mapper *gorpmapper.Mapper, delay time.Duration) {
+	tick := time.NewTicker(delay)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "RollJobsRoutine> exiting: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			ids, err := LoadActiveRollJobIDs(db())
+			if err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+				continue
+			}
+			for _, id := range ids {
+				if err := RunRollJob(ctx, db, mapper, id); err != nil {
+					log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to run database roll job %d", id))
+				}
+			}
+		}
+	}
+}
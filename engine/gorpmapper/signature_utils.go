@@ -61,6 +61,45 @@ func (m *Mapper) ListTuplesByEntity(db gorp.SqlExecutor, entity string) ([]strin
 	return res, nil
 }
 
+// CountTuplesByEntity returns the number of tuples of an entity.
+func (m *Mapper) CountTuplesByEntity(db gorp.SqlExecutor, entity string) (int64, error) {
+	e, ok := m.Mapping[entity]
+	if !ok {
+		return 0, sdk.WithStack(errors.New("unknown entity"))
+	}
+
+	res, err := db.SelectInt(fmt.Sprintf(`SELECT count(%s) FROM "%s"`, e.Keys[0], e.Name))
+	if err != nil {
+		return 0, sdk.WithStack(err)
+	}
+
+	return res, nil
+}
+
+// ListTuplesByEntityAfter returns the primary keys of an entity greater than the given one, ordered by primary key.
+// It allows to browse all the tuples of an entity by batch.
+func (m *Mapper) ListTuplesByEntityAfter(db gorp.SqlExecutor, entity string, after *string, limit int64) ([]string, error) {
+	e, ok := m.Mapping[entity]
+	if !ok {
+		return nil, sdk.WithStack(errors.New("unknown entity"))
+	}
+
+	var res []string
+	var err error
+	if after == nil {
+		query := fmt.Sprintf(`SELECT %s::text FROM "%s" ORDER BY %s LIMIT $1`, e.Keys[0], e.Name, e.Keys[0])
+		_, err = db.Select(&res, query, limit)
+	} else {
+		query := fmt.Sprintf(`SELECT %s::text FROM "%s" WHERE %s > $1 ORDER BY %s LIMIT $2`, e.Keys[0], e.Name, e.Keys[0], e.Keys[0])
+		_, err = db.Select(&res, query, *after, limit)
+	}
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	return res, nil
+}
+
 func (m *Mapper) ListTupleByCanonicalForm(db gorp.SqlExecutor, entity, signer string) ([]string, error) {
 	e, ok := m.Mapping[entity]
 	if !ok {
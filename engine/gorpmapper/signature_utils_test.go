@@ -104,3 +104,26 @@ func Test_RollSignedTupleByPrimaryKey(t *testing.T) {
 	err = m.RollSignedTupleByPrimaryKey(context.TODO(), db, "gorpmapper_test.testAuthentifiedUser", ids[0])
 	require.NoError(t, err)
 }
+
+func Test_ListTuplesByEntityAfter(t *testing.T) {
+	m := gorpmapper.New()
+	m.Register(m.NewTableMapping(testAuthentifiedUser{}, "authentified_user", false, "id"))
+
+	db, _ := test.SetupPGWithMapper(t, m, sdk.TypeAPI)
+
+	count, err := m.CountTuplesByEntity(db, "gorpmapper_test.testAuthentifiedUser")
+	require.NoError(t, err)
+
+	if count < 2 {
+		t.SkipNow()
+	}
+
+	first, err := m.ListTuplesByEntityAfter(db, "gorpmapper_test.testAuthentifiedUser", nil, 1)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+
+	next, err := m.ListTuplesByEntityAfter(db, "gorpmapper_test.testAuthentifiedUser", &first[0], 1)
+	require.NoError(t, err)
+	require.Len(t, next, 1)
+	require.NotEqual(t, first[0], next[0])
+}
@@ -386,6 +386,9 @@ func (h *HatcheryLocal) checkRequirement(r sdk.Requirement) (bool, error) {
 			return false, nil
 		}
 		return true, nil
+	case sdk.RegionPreferenceRequirement:
+		// The job region is checked on the common hatchery routine
+		return true, nil
 	case sdk.OSArchRequirement:
 		osarch := strings.Split(r.Value, "/")
 		if len(osarch) != 2 {
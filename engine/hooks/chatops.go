@@ -0,0 +1,95 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/rockbears/log"
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// getChatOpsCommands returns the commands posted in a pull request comment received by a repository webhook. The
+// boolean is false if the request is not a pull request comment or if the comment has no command.
+func getChatOpsCommands(ctx context.Context, t *sdk.TaskExecution) ([]sdk.ChatOpsCommand, bool) {
+	var repo, author, comment string
+	var prID int64
+
+	header := http.Header(t.WebHook.RequestHeader)
+	switch {
+	case header.Get(GithubHeader) == "issue_comment":
+		var request GithubIssueCommentEvent
+		if err := sdk.JSONUnmarshal(t.WebHook.RequestBody, &request); err != nil || request.Action != "created" ||
+			request.Issue.PullRequest == nil || request.Repository == nil {
+			return nil, false
+		}
+		repo, author, comment, prID = request.Repository.FullName, request.Comment.User.Login, request.Comment.Body, request.Issue.Number
+	case header.Get(GitlabHeader) == string(gitlab.EventTypeNote):
+		var request GitlabNoteEvent
+		if err := sdk.JSONUnmarshal(t.WebHook.RequestBody, &request); err != nil || request.ObjectAttributes.NoteableType != "MergeRequest" ||
+			request.MergeRequest == nil || request.Project == nil {
+			return nil, false
+		}
+		repo, author, comment, prID = request.Project.PathWithNamespace, request.User.Username, request.ObjectAttributes.Note, request.MergeRequest.IID
+	case header.Get(BitbucketHeader) == "pr:comment:added":
+		var request sdk.BitbucketServerWebhookEvent
+		if err := sdk.JSONUnmarshal(t.WebHook.RequestBody, &request); err != nil || request.PullRequest == nil ||
+			request.Comment == nil || request.Actor == nil {
+			return nil, false
+		}
+		toRepo := request.PullRequest.ToRef.Repository
+		repo = fmt.Sprintf("%s/%s", toRepo.Project.Key, toRepo.Slug)
+		author, comment, prID = request.Actor.Name, request.Comment.Text, int64(request.PullRequest.ID)
+	case header.Get(BitbucketHeader) == "pullrequest:comment_created":
+		var request BitbucketCloudPullRequestCommentEvent
+		if err := sdk.JSONUnmarshal(t.WebHook.RequestBody, &request); err != nil || request.Repository == nil || request.Actor == nil {
+			return nil, false
+		}
+		repo, author, comment, prID = request.Repository.FullName, request.Actor.Nickname, request.Comment.Content.Raw, request.PullRequest.ID
+	case header.Get(GiteaHeader) == "issue_comment":
+		var request GiteaIssueCommentPayload
+		if err := sdk.JSONUnmarshal(t.WebHook.RequestBody, &request); err != nil || request.Action != "created" || !request.IsPull {
+			return nil, false
+		}
+		repo, author, comment, prID = request.Repository.FullName, request.Comment.User.Login, request.Comment.Body, request.Issue.Number
+	default:
+		return nil, false
+	}
+	if prID == 0 {
+		return nil, false
+	}
+
+	cmds, err := sdk.ParseChatOpsCommands(comment)
+	if err != nil {
+		// The comment is consumed to not trigger the workflow with an invalid command
+		log.Warn(ctx, "getChatOpsCommands> invalid command from %s on %s #%d: %v", author, repo, prID, err)
+		return nil, true
+	}
+	if len(cmds) == 0 {
+		return nil, false
+	}
+	for i := range cmds {
+		cmds[i].HookUUID = t.UUID
+		cmds[i].Repository = repo
+		cmds[i].PullRequestID = prID
+		cmds[i].Author = author
+	}
+	return cmds, true
+}
+
+// doChatOpsCommands sends to CDS API the commands for the workflow of the task. A command that runs another workflow
+// is left to the repository webhook of this workflow.
+func (s *Service) doChatOpsCommands(ctx context.Context, t *sdk.TaskExecution, cmds []sdk.ChatOpsCommand) {
+	workflowName := t.Config[sdk.HookConfigWorkflow].Value
+	for _, cmd := range cmds {
+		if cmd.Name == sdk.ChatOpsCommandRun && cmd.Workflow != workflowName {
+			continue
+		}
+		if err := s.Client.WorkflowHookChatOps(ctx, cmd); err != nil {
+			ctx := sdk.ContextWithStacktrace(ctx, err)
+			log.Warn(ctx, "Hooks> %s > unable to execute %q on %s #%d: %v", t.UUID, cmd.String(), cmd.Repository, cmd.PullRequestID, err)
+		}
+	}
+}
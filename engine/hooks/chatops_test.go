@@ -0,0 +1,72 @@
+package hooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_getChatOpsCommands(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		event  string
+		body   string
+		cmds   []sdk.ChatOpsCommand
+		ok     bool
+	}{
+		{
+			name:   "github pull request comment",
+			header: GithubHeader,
+			event:  "issue_comment",
+			body:   `{"action":"created","issue":{"number":42,"pull_request":{"url":"https://api.github.com/repos/ovh/cds/pulls/42"}},"comment":{"body":"/cds retry","user":{"login":"octocat"}},"repository":{"full_name":"ovh/cds"}}`,
+			cmds:   []sdk.ChatOpsCommand{{HookUUID: "uuid", Repository: "ovh/cds", PullRequestID: 42, Author: "octocat", Name: sdk.ChatOpsCommandRetry}},
+			ok:     true,
+		},
+		{
+			name:   "github issue comment",
+			header: GithubHeader,
+			event:  "issue_comment",
+			body:   `{"action":"created","issue":{"number":42},"comment":{"body":"/cds retry","user":{"login":"octocat"}},"repository":{"full_name":"ovh/cds"}}`,
+		},
+		{
+			name:   "gitlab merge request comment",
+			header: GitlabHeader,
+			event:  "Note Hook",
+			body:   `{"object_kind":"note","user":{"username":"root"},"project":{"path_with_namespace":"ovh/cds"},"object_attributes":{"note":"/cds run deploy env=dev","noteable_type":"MergeRequest"},"merge_request":{"iid":7}}`,
+			cmds:   []sdk.ChatOpsCommand{{HookUUID: "uuid", Repository: "ovh/cds", PullRequestID: 7, Author: "root", Name: sdk.ChatOpsCommandRun, Workflow: "deploy", Params: map[string]string{"env": "dev"}}},
+			ok:     true,
+		},
+		{
+			name:   "gitea pull request comment without command",
+			header: GiteaHeader,
+			event:  "issue_comment",
+			body:   `{"action":"created","is_pull":true,"issue":{"number":3},"comment":{"body":"LGTM","user":{"login":"gitea"}},"repository":{"full_name":"ovh/cds"}}`,
+		},
+		{
+			name:   "bitbucket cloud invalid command",
+			header: BitbucketHeader,
+			event:  "pullrequest:comment_created",
+			body:   `{"actor":{"nickname":"bob"},"repository":{"full_name":"ovh/cds"},"pullrequest":{"id":5},"comment":{"content":{"raw":"/cds deploy"}}}`,
+			ok:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &sdk.TaskExecution{
+				UUID: "uuid",
+				Type: TypeRepoManagerWebHook,
+				WebHook: &sdk.WebHookExecution{
+					RequestBody:   []byte(tt.body),
+					RequestHeader: map[string][]string{tt.header: {tt.event}},
+				},
+			}
+			cmds, ok := getChatOpsCommands(context.TODO(), task)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.cmds, cmds)
+		})
+	}
+}
@@ -11,6 +11,20 @@ type BitbucketCloudWebHookEvent struct {
 	Repository *BitbucketCloudRepository `json:"repository,omitempty"`
 }
 
+// BitbucketCloudPullRequestCommentEvent is sent for the comments on pull requests.
+type BitbucketCloudPullRequestCommentEvent struct {
+	Actor       *BitbucketCloudActor      `json:"actor,omitempty"`
+	Repository  *BitbucketCloudRepository `json:"repository,omitempty"`
+	PullRequest struct {
+		ID int64 `json:"id"`
+	} `json:"pullrequest"`
+	Comment struct {
+		Content struct {
+			Raw string `json:"raw"`
+		} `json:"content"`
+	} `json:"comment"`
+}
+
 type BitbucketCloudChange struct {
 	Forced bool `json:"forced"`
 	Old    struct {
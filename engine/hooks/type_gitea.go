@@ -68,3 +68,21 @@ type GiteaEventPayload struct {
 		Username  string `json:"username"`
 	} `json:"sender"`
 }
+
+// GiteaIssueCommentPayload is sent for the comments on issues and pull requests.
+type GiteaIssueCommentPayload struct {
+	Action string `json:"action"`
+	IsPull bool   `json:"is_pull"`
+	Issue  struct {
+		Number int64 `json:"number"`
+	} `json:"issue"`
+	Comment struct {
+		Body string `json:"body"`
+		User struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"comment"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
@@ -24,6 +24,22 @@ type GithubWebHookEvent struct {
 	Sender     GithubSender      `json:"sender"`
 }
 
+// GithubIssueCommentEvent is sent for the comments on issues and pull requests.
+type GithubIssueCommentEvent struct {
+	Action string `json:"action"`
+	Issue  struct {
+		Number      int64 `json:"number"`
+		PullRequest *struct {
+			URL string `json:"url"`
+		} `json:"pull_request"`
+	} `json:"issue"`
+	Comment struct {
+		Body string       `json:"body"`
+		User GithubSender `json:"user"`
+	} `json:"comment"`
+	Repository *GithubRepository `json:"repository"`
+}
+
 type GithubSender struct {
 	Login             string `json:"login"`
 	ID                int    `json:"id"`
@@ -25,6 +25,22 @@ type GitlabEvent struct {
 	TotalCommitsCount int               `json:"total_commits_count"`
 }
 
+// GitlabNoteEvent is sent for the comments on commits, issues and merge requests.
+type GitlabNoteEvent struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project          *GitlabProject `json:"project"`
+	ObjectAttributes struct {
+		Note         string `json:"note"`
+		NoteableType string `json:"noteable_type"`
+	} `json:"object_attributes"`
+	MergeRequest *struct {
+		IID int64 `json:"iid"`
+	} `json:"merge_request"`
+}
+
 type GitlabCommit struct {
 	ID        string        `json:"id"`
 	Message   string        `json:"message"`
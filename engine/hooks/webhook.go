@@ -19,6 +19,11 @@ func (s *Service) doWebHookExecution(ctx context.Context, e *sdk.TaskExecution)
 	log.Debug(ctx, "Hooks> Processing webhook %s %s", e.UUID, e.Type)
 
 	if e.Type == TypeRepoManagerWebHook {
+		// Pull request comments with commands don't trigger the workflow
+		if cmds, ok := getChatOpsCommands(ctx, e); ok {
+			s.doChatOpsCommands(ctx, e, cmds)
+			return nil, nil
+		}
 		return s.executeRepositoryWebHook(ctx, e)
 	}
 	event, err := executeWebHook(e)
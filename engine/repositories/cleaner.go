@@ -30,6 +30,9 @@ func (s *Service) vacuumCleaner(ctx context.Context) error {
 			if err := s.vacuumStoreCleanerRun(ctx); err != nil {
 				log.Error(ctx, "vacuumCleaner> Error cleaning the store: %v", err)
 			}
+			if err := s.vacuumMirrorsCleanerRun(ctx); err != nil {
+				log.Error(ctx, "vacuumCleaner> Error cleaning the mirrors: %v", err)
+			}
 		case <-ctx.Done():
 			return ctx.Err()
 		}
@@ -104,3 +107,31 @@ func (s *Service) vacuumFileSystemCleanerFunc(ctx context.Context, repoUUID stri
 
 	return nil
 }
+
+// vacuumMirrorsCleanerRun removes the mirrors that were not used during the repositories retention.
+func (s *Service) vacuumMirrorsCleanerRun(ctx context.Context) error {
+	if !s.mirrorEnabled() {
+		return nil
+	}
+	entries, err := os.ReadDir(s.Cfg.Mirror.Basedir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	for _, e := range entries {
+		fi, err := e.Info()
+		if err != nil || !fi.IsDir() {
+			continue
+		}
+		if time.Since(fi.ModTime()) < 24*time.Hour*time.Duration(s.Cfg.RepositoriesRetention) {
+			continue
+		}
+		log.Info(ctx, "vacuumMirrorsCleanerRun> Removing mirror %s", e.Name())
+		if err := os.RemoveAll(filepath.Join(s.Cfg.Mirror.Basedir, e.Name())); err != nil {
+			log.Error(ctx, "vacuumMirrorsCleanerRun> unable to remove mirror %s: %v", e.Name(), err)
+		}
+	}
+	return nil
+}
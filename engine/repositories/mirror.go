@@ -0,0 +1,292 @@
+package repositories
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/authentication"
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/telemetry"
+	"github.com/ovh/cds/sdk/vcs/git"
+)
+
+var (
+	keyMirrorAccess = cache.Key("repositories", "mirror", "access")
+)
+
+func (s *Service) mirrorEnabled() bool {
+	return s.Cfg.Mirror.Basedir != ""
+}
+
+// mirror returns the bare mirror of a remote repository. The modification time of the mirror directory is updated on
+// each use, it is used by the cleaner to remove unused mirrors.
+func (s *Service) mirror(url string, auth *git.AuthOpts) git.Mirror {
+	h := sha256.Sum256([]byte(url))
+	m := git.Mirror{
+		URL:  url,
+		Dir:  filepath.Join(s.Cfg.Mirror.Basedir, hex.EncodeToString(h[:])),
+		Auth: auth,
+	}
+	now := time.Now()
+	_ = os.Chtimes(m.Dir, now, now)
+	return m
+}
+
+// cloneFromMirror fetches the mirror of the repository with the credentials of the operation, then makes a working
+// copy of the mirror in the basedir of the operation.
+func (s *Service) cloneFromMirror(ctx context.Context, op *sdk.Operation, r *sdk.OperationRepo) error {
+	auth := &git.AuthOpts{}
+	if op.RepositoryStrategy.ConnectionType == "ssh" {
+		auth.PrivateKey.Content = []byte(op.RepositoryStrategy.SSHKeyContent)
+	} else {
+		auth.Username = op.RepositoryStrategy.User
+		auth.Password = op.RepositoryStrategy.Password
+	}
+	if err := git.CheckRemoteURL(r.URL); err != nil {
+		return err
+	}
+	m := s.mirror(r.URL, auth)
+	if err := m.Fetch(ctx); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(r.Basedir); err != nil {
+		return sdk.WithStack(err)
+	}
+	return m.CloneTo(ctx, r.Basedir)
+}
+
+func (s *Service) jwtMiddleware(ctx context.Context, w http.ResponseWriter, req *http.Request, rc *service.HandlerConfig) (context.Context, error) {
+	ctx, end := telemetry.Span(ctx, "router.jwtMiddleware")
+	defer end()
+
+	v := authentication.NewVerifier(s.ParsedAPIPublicKey)
+	ctx, err := service.JWTMiddleware(ctx, w, req, rc, v.VerifyJWT)
+	if err != nil {
+		return ctx, err
+	}
+	if _, ok := ctx.Value(service.ContextJWT).(*jwt.Token); !ok {
+		return ctx, sdk.WithStack(sdk.ErrUnauthorized)
+	}
+	if !s.mirrorEnabled() {
+		return ctx, sdk.NewErrorFrom(sdk.ErrNotImplemented, "git mirrors are disabled")
+	}
+	return ctx, nil
+}
+
+func (s *Service) sessionID(ctx context.Context) string {
+	if sessionID, ok := ctx.Value(service.ContextSessionID).(string); ok {
+		return sessionID
+	}
+	return ""
+}
+
+// upstreamAuth reads the credentials of the remote repository from the request headers.
+func upstreamAuth(r *http.Request) (*git.AuthOpts, error) {
+	auth := &git.AuthOpts{}
+	if basic := r.Header.Get(git.HeaderMirrorUpstreamAuthorization); basic != "" {
+		btes, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(basic, "Basic "))
+		if err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid upstream authorization header")
+		}
+		t := strings.SplitN(string(btes), ":", 2)
+		if len(t) != 2 {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid upstream authorization header")
+		}
+		auth.Username, auth.Password = t[0], t[1]
+	}
+	if key := r.Header.Get(git.HeaderMirrorUpstreamSSHKey); key != "" {
+		btes, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid upstream ssh key header")
+		}
+		auth.PrivateKey.Content = btes
+	}
+	return auth, nil
+}
+
+// checkMirrorAccess checks that the remote repository is in the access list signed by CDS API for the job of the
+// worker session.
+func (s *Service) checkMirrorAccess(r *http.Request, sessionID, upstream string) error {
+	token := r.Header.Get(git.HeaderMirrorAccess)
+	if token == "" {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "missing git mirror access")
+	}
+	var access git.MirrorAccess
+	if err := authentication.NewVerifier(s.ParsedAPIPublicKey).VerifyJWS(token, &access); err != nil {
+		return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrForbidden, "invalid git mirror access"))
+	}
+	if !access.Allowed(sessionID, upstream) {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "the job has no access to the mirror of this repository")
+	}
+	return nil
+}
+
+func writePktLine(w io.Writer, s string) error {
+	_, err := fmt.Fprintf(w, "%04x%s", len(s)+4, s)
+	return sdk.WithStack(err)
+}
+
+// remoteMirror checks that the session has access to the remote repository of the request and that it can read it with
+// the credentials given in headers, then returns its mirror and its references.
+func (s *Service) remoteMirror(ctx context.Context, r *http.Request) (git.Mirror, git.RemoteRefs, error) {
+	upstream, err := git.ParseMirrorUpstreamID(muxVar(r, "upstream"))
+	if err != nil {
+		return git.Mirror{}, git.RemoteRefs{}, err
+	}
+	auth, err := upstreamAuth(r)
+	if err != nil {
+		return git.Mirror{}, git.RemoteRefs{}, err
+	}
+	sessionID := s.sessionID(ctx)
+	if sessionID == "" {
+		return git.Mirror{}, git.RemoteRefs{}, sdk.WithStack(sdk.ErrUnauthorized)
+	}
+	if err := s.checkMirrorAccess(r, sessionID, upstream); err != nil {
+		return git.Mirror{}, git.RemoteRefs{}, err
+	}
+
+	m := s.mirror(upstream, auth)
+	remoteRefs, err := m.LsRemote(ctx)
+	if err != nil {
+		log.Info(ctx, "remoteMirror> unable to list references of %s: %v", upstream, err)
+		return m, remoteRefs, sdk.NewErrorFrom(sdk.ErrForbidden, "unable to read remote repository")
+	}
+	return m, remoteRefs, nil
+}
+
+// getGitMirrorInfoRefsHandler checks that the worker can read the remote repository with its own credentials, updates
+// the mirror if its references differ from the remote ones, then advertises the references of the mirror.
+func (s *Service) getGitMirrorInfoRefsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if r.FormValue("service") != "git-upload-pack" {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "only git-upload-pack is supported")
+		}
+		m, remoteRefs, err := s.remoteMirror(ctx, r)
+		if err != nil {
+			return err
+		}
+		fetched, err := m.FetchIfOutdated(ctx, remoteRefs)
+		if err != nil {
+			return err
+		}
+		if fetched {
+			log.Info(ctx, "getGitMirrorInfoRefsHandler> mirror of %s updated", m.URL)
+		}
+
+		if err := s.Cache.SetWithDuration(cache.Key(keyMirrorAccess, s.sessionID(ctx), muxVar(r, "upstream")), true, time.Hour); err != nil {
+			return sdk.WrapError(err, "unable to store mirror access")
+		}
+
+		protocol := r.Header.Get("Git-Protocol")
+		w.Header().Set("Content-Type", "application/x-git-upload-pack-advertisement")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		if !strings.Contains(protocol, "version=2") {
+			if err := writePktLine(w, "# service=git-upload-pack\n"); err != nil {
+				return err
+			}
+			if _, err := io.WriteString(w, "0000"); err != nil {
+				return sdk.WithStack(err)
+			}
+		}
+		return m.UploadPack(ctx, protocol, nil, w, true)
+	}
+}
+
+// postGitMirrorUploadPackHandler serves a fetch from the mirror, the access to the mirror must have been granted to
+// the session by getGitMirrorInfoRefsHandler.
+func (s *Service) postGitMirrorUploadPackHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		upstream, err := git.ParseMirrorUpstreamID(muxVar(r, "upstream"))
+		if err != nil {
+			return err
+		}
+		sessionID := s.sessionID(ctx)
+		if sessionID == "" {
+			return sdk.WithStack(sdk.ErrUnauthorized)
+		}
+		if err := s.checkMirrorAccess(r, sessionID, upstream); err != nil {
+			return err
+		}
+		granted, err := s.Cache.Exist(cache.Key(keyMirrorAccess, sessionID, muxVar(r, "upstream")))
+		if err != nil {
+			return sdk.WrapError(err, "unable to check mirror access")
+		}
+		if !granted {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				return sdk.NewErrorWithStack(err, sdk.ErrWrongRequest)
+			}
+			defer gz.Close()
+			body = gz
+		}
+
+		w.Header().Set("Content-Type", "application/x-git-upload-pack-result")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		return s.mirror(upstream, nil).UploadPack(ctx, r.Header.Get("Git-Protocol"), body, w, false)
+	}
+}
+
+// getGitMirrorRefsHandler returns the references of a remote repository, read with the credentials of the client.
+func (s *Service) getGitMirrorRefsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		_, remoteRefs, err := s.remoteMirror(ctx, r)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, remoteRefs, http.StatusOK)
+	}
+}
+
+// postGitMirrorCommandHandler runs a read only git command on the mirror of a remote repository, the mirror is updated
+// before if its references differ from the remote ones. It is used by the VCS service to read plain git repositories.
+func (s *Service) postGitMirrorCommandHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var cmd git.MirrorCommand
+		if err := service.UnmarshalBody(r, &cmd); err != nil {
+			return err
+		}
+		if err := cmd.Check(); err != nil {
+			return err
+		}
+		m, remoteRefs, err := s.remoteMirror(ctx, r)
+		if err != nil {
+			return err
+		}
+		if _, err := m.FetchIfOutdated(ctx, remoteRefs); err != nil {
+			return err
+		}
+
+		// The output is buffered to return an error if the command fails
+		var out bytes.Buffer
+		if err := m.Git(ctx, &out, cmd.Args...); err != nil {
+			return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrNotFound, "git %s failed on the mirror of %s", cmd.Args[0], m.URL))
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+		_, err = io.Copy(w, &out)
+		return sdk.WithStack(err)
+	}
+}
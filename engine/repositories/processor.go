@@ -103,6 +103,17 @@ func (s *Service) do(ctx context.Context, op sdk.Operation) error {
 			op.Error = nil
 			op.Status = sdk.OperationStatusDone
 		}
+	// Speculative merge of branches
+	case op.Setup.Merge.FromBranch != "":
+		if err := s.processMerge(ctx, &op); err != nil {
+			ctx := sdk.ContextWithStacktrace(ctx, err)
+			log.Error(ctx, err.Error())
+			op.Error = sdk.ToOperationError(err)
+			op.Status = sdk.OperationStatusError
+		} else {
+			op.Error = nil
+			op.Status = sdk.OperationStatusDone
+		}
 	default:
 		op.Error = sdk.ToOperationError(sdk.NewErrorFrom(sdk.ErrUnknownError, "unrecognized setup"))
 		op.Status = sdk.OperationStatusError
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"os"
 	"strings"
 
 	"github.com/fsamin/go-repo"
@@ -28,6 +29,15 @@ func (s *Service) processGitClone(ctx context.Context, op *sdk.Operation) (gitRe
 	}
 
 	gitRepo, err = repo.New(ctx, r.Basedir, opts...)
+	if err != nil && s.mirrorEnabled() {
+		log.Info(ctx, "processGitClone> %s > cloning %s into %s from mirror", op.UUID, r.URL, r.Basedir)
+		if errMirror := s.cloneFromMirror(ctx, op, r); errMirror != nil {
+			log.Warn(ctx, "processGitClone> %s > unable to clone %s from mirror: %v", op.UUID, r.URL, errMirror)
+			_ = os.RemoveAll(r.Basedir)
+		} else {
+			gitRepo, err = repo.New(ctx, r.Basedir, opts...)
+		}
+	}
 	if err != nil {
 		log.Info(ctx, "processGitClone> %s > cloning %s into %s", op.UUID, r.URL, r.Basedir)
 		gitRepo, err = repo.Clone(ctx, r.Basedir, r.URL, opts...)
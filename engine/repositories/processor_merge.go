@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/sdk"
+	cdslog "github.com/ovh/cds/sdk/log"
+)
+
+// processMerge merges the sources of the operation on the head of the target branch and pushes the result to a new
+// branch. Sources in conflict with the target branch or with previous sources are skipped.
+func (s *Service) processMerge(ctx context.Context, op *sdk.Operation) (globalErr error) {
+	ctx = context.WithValue(ctx, cdslog.Operation, op.UUID)
+	ctx = context.WithValue(ctx, cdslog.Repository, op.RepoFullName)
+
+	var missingAuth bool
+	if op.RepositoryStrategy.ConnectionType == "ssh" {
+		missingAuth = op.RepositoryStrategy.SSHKey == "" || op.RepositoryStrategy.SSHKeyContent == ""
+	} else {
+		missingAuth = op.RepositoryStrategy.User == "" || op.RepositoryStrategy.Password == ""
+	}
+	if missingAuth {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "authentication data required to push on repository %s", op.URL)
+	}
+	if op.Setup.Merge.ToBranch == "" || len(op.Setup.Merge.Sources) == 0 {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "target branch and sources are required to merge")
+	}
+
+	gitRepo, path, _, err := s.processGitClone(ctx, op)
+	if err != nil {
+		return sdk.WrapError(err, "unable to process gitclone")
+	}
+
+	// In case of error, we have to clean the filesystem, to avoid pending local branches or merges
+	defer func() {
+		if globalErr != nil {
+			r := s.Repo(*op)
+			if err := s.cleanFS(ctx, r); err != nil {
+				log.Error(ctx, "unable to clean FS: %v", err)
+			}
+		}
+	}()
+
+	if err := gitRepo.FetchRemoteBranch(ctx, "origin", op.Setup.Merge.ToBranch); err != nil {
+		return sdk.NewErrorFrom(sdk.ErrNotFound, "cannot fetch branch %s: %v", op.Setup.Merge.ToBranch, err)
+	}
+	if err := gitRepo.CheckoutNewBranch(ctx, op.Setup.Merge.FromBranch); err != nil {
+		if !strings.Contains(err.Error(), "already exists") {
+			return sdk.WrapError(err, "cannot checkout new branch %s", op.Setup.Merge.FromBranch)
+		}
+		if err := gitRepo.Checkout(ctx, op.Setup.Merge.FromBranch); err != nil {
+			return sdk.WrapError(err, "cannot checkout existing branch %s", op.Setup.Merge.FromBranch)
+		}
+	}
+	if err := gitRepo.ResetHard(ctx, "origin/"+op.Setup.Merge.ToBranch); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	base, err := gitRepo.LatestCommit(ctx)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	op.Setup.Merge.Result.BaseCommit = base.LongHash
+	op.Setup.Merge.Result.Conflicts = nil
+
+	user := []string{"-c", "user.name=CDS", "-c", "user.email=cds@localhost"}
+	if op.User.Username != "" && op.User.Email != "" {
+		user = []string{"-c", "user.name=" + op.User.Username, "-c", "user.email=" + op.User.Email}
+	}
+	var merged int
+	for _, src := range op.Setup.Merge.Sources {
+		commit := src.Commit
+		if commit == "" {
+			commit = "origin/" + src.Branch
+		}
+		msg := src.Message
+		if msg == "" {
+			msg = "Merge " + src.Branch
+		}
+		args := append(append([]string{}, user...), "merge", "--no-ff", "-m", msg, commit)
+		if out, err := gitCommand(ctx, path, args...); err != nil {
+			log.Info(ctx, "processMerge> %s : unable to merge %s: %v: %s", op.UUID, commit, err, out)
+			if _, err := gitCommand(ctx, path, "merge", "--abort"); err != nil {
+				if err := gitRepo.ResetHard(ctx, "HEAD"); err != nil {
+					return sdk.WithStack(err)
+				}
+			}
+			op.Setup.Merge.Result.Conflicts = append(op.Setup.Merge.Result.Conflicts, src.Commit)
+			continue
+		}
+		merged++
+	}
+	if merged == 0 {
+		log.Info(ctx, "processMerge> %s : nothing merged on %s", op.UUID, op.Setup.Merge.ToBranch)
+		return nil
+	}
+
+	head, err := gitRepo.LatestCommit(ctx)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	op.Setup.Merge.Result.Commit = head.LongHash
+
+	if err := gitRepo.Push(ctx, "origin", op.Setup.Merge.FromBranch); err != nil {
+		if strings.Contains(err.Error(), "Pushing requires write access") {
+			return sdk.NewError(sdk.ErrForbidden, err)
+		}
+		err = sdk.WithStack(err)
+		ctx = sdk.ContextWithStacktrace(ctx, err)
+		log.Error(ctx, "unable to push branch %q on repository %q: %v", op.Setup.Merge.FromBranch, op.RepoFullName, err)
+		return err
+	}
+
+	log.Debug(ctx, "processMerge> %s : %d sources merged on %s", op.UUID, merged, op.Setup.Merge.FromBranch)
+	return nil
+}
+
+// gitCommand runs a local git command in the working copy of a repository.
+func gitCommand(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
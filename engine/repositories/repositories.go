@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -71,6 +73,11 @@ func (s *Service) CheckConfiguration(config interface{}) error {
 	if sConfig.Name == "" {
 		return fmt.Errorf("please enter a name in your repositories configuration")
 	}
+	if sConfig.Mirror.Basedir != "" {
+		if rel, err := filepath.Rel(sConfig.Basedir, sConfig.Mirror.Basedir); err == nil && !strings.HasPrefix(rel, "..") {
+			return fmt.Errorf("mirror basedir must not be inside the basedir of the repositories service")
+		}
+	}
 
 	return nil
 }
@@ -25,4 +25,9 @@ func (s *Service) initRouter(ctx context.Context) {
 
 	r.Handle("/operations", nil, r.POST(s.postOperationHandler))
 	r.Handle("/operations/{uuid}", nil, r.GET(s.getOperationsHandler))
+
+	r.Handle("/git/{upstream}/info/refs", nil, r.GET(s.getGitMirrorInfoRefsHandler, service.OverrideAuth(s.jwtMiddleware)))
+	r.Handle("/git/{upstream}/git-upload-pack", nil, r.POST(s.postGitMirrorUploadPackHandler, service.OverrideAuth(s.jwtMiddleware)))
+	r.Handle("/git/{upstream}/refs", nil, r.GET(s.getGitMirrorRefsHandler, service.OverrideAuth(s.jwtMiddleware)))
+	r.Handle("/git/{upstream}/command", nil, r.POST(s.postGitMirrorCommandHandler, service.OverrideAuth(s.jwtMiddleware)))
 }
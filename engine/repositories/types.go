@@ -37,6 +37,10 @@ type Configuration struct {
 			DbIndex  int    `toml:"dbindex" default:"0" json:"dbindex"`
 		} `toml:"redis" json:"redis"`
 	} `toml:"cache" comment:"######################\n CDS Repositories Cache Settings \n######################" json:"cache"`
+	Mirror struct {
+		Basedir    string `toml:"basedir" comment:"Root directory where the service will store bare mirrors of the repositories, shared by operations and served to workers and to the VCS service.\n Mirrors are disabled if empty. It must not be a subdirectory of the basedir of the service" json:"basedir"`
+		PublicHTTP string `toml:"publicHTTP" comment:"Public URL used by workers and by the VCS service to read repositories from the mirrors" json:"public_http"`
+	} `toml:"mirror" comment:"######################\n CDS Repositories Git Mirrors Settings \n######################" json:"mirror"`
 }
 
 // Repo retiens a sdk.OperationRepo from an sdk.Operation
@@ -77,3 +77,9 @@ func OverrideAuth(m Middleware) HandlerConfigParam {
 		rc.OverrideAuthMiddleware = m
 	}
 }
+
+func OverrideErrorWriter(f ErrorWriter) HandlerConfigParam {
+	return func(rc *HandlerConfig) {
+		rc.OverrideErrorWriter = f
+	}
+}
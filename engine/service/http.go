@@ -38,6 +38,9 @@ type AsynchronousHandler func(ctx context.Context, r *http.Request) error
 // Middleware defines the HTTP Middleware used in CDS engine
 type Middleware func(ctx context.Context, w http.ResponseWriter, req *http.Request, rc *HandlerConfig) (context.Context, error)
 
+// ErrorWriter defines how the errors of a handler and of its middlewares are returned, default is WriteError
+type ErrorWriter func(ctx context.Context, w http.ResponseWriter, r *http.Request, err error)
+
 // HandlerFunc defines the way to instantiate a handler
 type HandlerFunc func() Handler
 type HandlerFuncV2 func() ([]RbacChecker, Handler)
@@ -60,6 +63,7 @@ type HandlerConfig struct {
 	Handler                Handler
 	IsDeprecated           bool
 	OverrideAuthMiddleware Middleware
+	OverrideErrorWriter    ErrorWriter
 	MaintenanceAware       bool
 	AllowedScopes          []sdk.AuthConsumerScope
 	PermissionLevel        int
@@ -144,6 +148,14 @@ type ErrorResponse struct {
 
 // WriteError is a helper function to return error in a language the called understand
 func WriteError(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	httpErr := LogError(ctx, err)
+
+	// safely ignore error returned by WriteJSON
+	_ = WriteJSON(w, httpErr, httpErr.Status)
+}
+
+// LogError logs an error returned to a caller and returns its http representation.
+func LogError(ctx context.Context, err error) sdk.Error {
 	httpErr := sdk.ExtractHTTPError(err)
 
 	requestID := cdslog.ContextValue(ctx, cdslog.RequestID)
@@ -157,8 +169,7 @@ func WriteError(ctx context.Context, w http.ResponseWriter, r *http.Request, err
 		log.Error(ctx, "%s", err)
 	}
 
-	// safely ignore error returned by WriteJSON
-	_ = WriteJSON(w, httpErr, httpErr.Status)
+	return httpErr
 }
 
 // UnmarshalBody read the request body and tries to json.unmarshal it. It returns sdk.ErrWrongRequest in case of error.
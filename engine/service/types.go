@@ -25,9 +25,10 @@ type APIServiceConfiguration struct {
 }
 
 type HTTPRouterConfiguration struct {
-	Addr                string `toml:"addr" default:"" commented:"true" comment:"Listen HTTP address without port, example: 127.0.0.1" json:"addr"`
-	Port                int    `toml:"port" default:"8081" json:"port"`
-	HeaderXForwardedFor string `toml:"headerXForwardedFor" commented:"true" comment:"Forward source addr from given header, let empty to use request addr." default:"X-Forwarded-For" json:"header_w_forwarded_for"`
+	Addr                string   `toml:"addr" default:"" commented:"true" comment:"Listen HTTP address without port, example: 127.0.0.1" json:"addr"`
+	Port                int      `toml:"port" default:"8081" json:"port"`
+	HeaderXForwardedFor string   `toml:"headerXForwardedFor" commented:"true" comment:"Forward source addr from given header, let empty to use request addr." default:"X-Forwarded-For" json:"header_w_forwarded_for"`
+	TrustedProxies      []string `toml:"trustedProxies" commented:"true" comment:"CIDRs of the reverse proxies that set the forward header. The header is only trusted for consumer IP restrictions if the request comes from one of them, example: [\"10.0.0.0/8\"]" json:"trusted_proxies"`
 }
 
 // HatcheryCommonConfiguration is the base configuration for all hatcheries
@@ -0,0 +1,27 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "audit_log" (
+    id BIGSERIAL PRIMARY KEY,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    action VARCHAR(256) NOT NULL,
+    target_type VARCHAR(256) NOT NULL,
+    target_name TEXT NOT NULL,
+    project_key VARCHAR(256),
+    user_id VARCHAR(36),
+    username VARCHAR(256),
+    consumer_id VARCHAR(36),
+    consumer_name TEXT,
+    ip_address VARCHAR(256),
+    data_before TEXT,
+    data_after TEXT,
+    streamed BOOLEAN NOT NULL DEFAULT false,
+    sig BYTEA,
+    signer TEXT
+);
+SELECT create_index('audit_log', 'idx_audit_log_created', 'created');
+SELECT create_index('audit_log', 'idx_audit_log_action', 'action');
+SELECT create_index('audit_log', 'idx_audit_log_target', 'target_type,target_name');
+SELECT create_index('audit_log', 'idx_audit_log_username', 'username');
+CREATE INDEX idx_audit_log_not_streamed ON audit_log (id) WHERE streamed = false;
+
+-- +migrate Down
+DROP TABLE audit_log;
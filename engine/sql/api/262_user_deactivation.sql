@@ -0,0 +1,11 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "authentified_user_deactivation" (
+  authentified_user_id VARCHAR(36) PRIMARY KEY,
+  created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+  sig BYTEA,
+  signer TEXT
+);
+SELECT create_foreign_key_idx_cascade('FK_AUTHENTIFIED_USER_DEACTIVATION_AUTHENTIFIED_USER', 'authentified_user_deactivation', 'authentified_user', 'authentified_user_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "authentified_user_deactivation";
@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "auth_local_factor" (
+  id VARCHAR(36) PRIMARY KEY,
+  authentified_user_id VARCHAR(36) NOT NULL,
+  type VARCHAR(64) NOT NULL,
+  name VARCHAR(256) NOT NULL DEFAULT '',
+  created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+  last_used TIMESTAMP WITH TIME ZONE,
+  verified BOOLEAN NOT NULL DEFAULT FALSE,
+  data BYTEA,
+  sig BYTEA,
+  signer TEXT
+);
+SELECT create_foreign_key_idx_cascade('FK_AUTH_LOCAL_FACTOR_AUTHENTIFIED_USER', 'auth_local_factor', 'authentified_user', 'authentified_user_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "auth_local_factor";
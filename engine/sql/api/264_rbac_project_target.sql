@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE "rbac_project" ADD COLUMN IF NOT EXISTS "repositories" JSONB NOT NULL DEFAULT '[]'::jsonb;
+ALTER TABLE "rbac_project" ADD COLUMN IF NOT EXISTS "entities" JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+-- +migrate Down
+ALTER TABLE "rbac_project" DROP COLUMN IF EXISTS "repositories";
+ALTER TABLE "rbac_project" DROP COLUMN IF EXISTS "entities";
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE "auth_consumer_user" ADD COLUMN IF NOT EXISTS "restrictions" JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- +migrate Down
+ALTER TABLE "auth_consumer_user" DROP COLUMN IF EXISTS "restrictions";
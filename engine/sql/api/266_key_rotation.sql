@@ -0,0 +1,32 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "key_rotation" (
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL,
+    application_id BIGINT NOT NULL DEFAULT 0,
+    key_name VARCHAR(256) NOT NULL,
+    key_type VARCHAR(32) NOT NULL,
+    previous_public TEXT NOT NULL,
+    repositories JSONB NOT NULL DEFAULT '[]'::jsonb,
+    status VARCHAR(32) NOT NULL,
+    triggered_by VARCHAR(256) NOT NULL,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    expire_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    retired TIMESTAMP WITH TIME ZONE,
+    error TEXT
+);
+SELECT create_foreign_key_idx_cascade('FK_KEY_ROTATION_PROJECT', 'key_rotation', 'project', 'project_id', 'id');
+SELECT create_index('key_rotation', 'idx_key_rotation_status', 'status,expire_at');
+
+CREATE TABLE IF NOT EXISTS "key_rotation_policy" (
+    project_id BIGINT PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    interval_days BIGINT NOT NULL,
+    grace_period_hours BIGINT NOT NULL,
+    push_to_vcs BOOLEAN NOT NULL DEFAULT false,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_KEY_ROTATION_POLICY_PROJECT', 'key_rotation_policy', 'project', 'project_id', 'id');
+
+-- +migrate Down
+DROP TABLE key_rotation;
+DROP TABLE key_rotation_policy;
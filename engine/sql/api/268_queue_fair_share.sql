@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "queue_quota" (
+    id BIGSERIAL PRIMARY KEY,
+    project_key VARCHAR(256) NOT NULL DEFAULT '',
+    organization VARCHAR(100) NOT NULL DEFAULT '',
+    weight BIGINT NOT NULL DEFAULT 1,
+    max_concurrent_jobs_per_model BIGINT NOT NULL DEFAULT 0,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_modified TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_unique_index('queue_quota', 'idx_unq_queue_quota', 'project_key,organization');
+
+ALTER TABLE pipeline_action ADD COLUMN IF NOT EXISTS priority_class VARCHAR(32) NOT NULL DEFAULT '';
+
+-- +migrate Down
+DROP TABLE queue_quota;
+ALTER TABLE pipeline_action DROP COLUMN IF EXISTS priority_class;
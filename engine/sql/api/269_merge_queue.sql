@@ -0,0 +1,58 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "merge_queue" (
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL,
+    application_id BIGINT NOT NULL,
+    workflow_id BIGINT NOT NULL,
+    branch VARCHAR(256) NOT NULL,
+    label VARCHAR(256) NOT NULL DEFAULT '',
+    max_batch_size BIGINT NOT NULL DEFAULT 1,
+    merge_method VARCHAR(32) NOT NULL DEFAULT 'merge',
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    auth_consumer_id VARCHAR(36) NOT NULL,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_modified TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_MERGE_QUEUE_PROJECT', 'merge_queue', 'project', 'project_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_MERGE_QUEUE_APPLICATION', 'merge_queue', 'application', 'application_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_MERGE_QUEUE_WORKFLOW', 'merge_queue', 'workflow', 'workflow_id', 'id');
+SELECT create_unique_index('merge_queue', 'idx_unq_merge_queue_branch', 'application_id,branch');
+
+CREATE TABLE IF NOT EXISTS "merge_queue_batch" (
+    id BIGSERIAL PRIMARY KEY,
+    merge_queue_id BIGINT NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    base_commit VARCHAR(256) NOT NULL DEFAULT '',
+    merge_commit VARCHAR(256) NOT NULL DEFAULT '',
+    operation_uuid VARCHAR(36) NOT NULL DEFAULT '',
+    workflow_run_id BIGINT NOT NULL DEFAULT 0,
+    workflow_run_number BIGINT NOT NULL DEFAULT 0,
+    message TEXT NOT NULL DEFAULT '',
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_modified TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_MERGE_QUEUE_BATCH_QUEUE', 'merge_queue_batch', 'merge_queue', 'merge_queue_id', 'id');
+SELECT create_index('merge_queue_batch', 'idx_merge_queue_batch_status', 'merge_queue_id,status');
+
+CREATE TABLE IF NOT EXISTS "merge_queue_entry" (
+    id BIGSERIAL PRIMARY KEY,
+    merge_queue_id BIGINT NOT NULL,
+    pull_request_id BIGINT NOT NULL,
+    pull_request_url TEXT NOT NULL DEFAULT '',
+    title TEXT NOT NULL DEFAULT '',
+    head_branch VARCHAR(256) NOT NULL,
+    head_commit VARCHAR(256) NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    batch_id BIGINT NOT NULL DEFAULT 0,
+    enqueued_by VARCHAR(256) NOT NULL DEFAULT '',
+    message TEXT NOT NULL DEFAULT '',
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_modified TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_MERGE_QUEUE_ENTRY_QUEUE', 'merge_queue_entry', 'merge_queue', 'merge_queue_id', 'id');
+SELECT create_index('merge_queue_entry', 'idx_merge_queue_entry_status', 'merge_queue_id,status');
+
+-- +migrate Down
+DROP TABLE merge_queue_entry;
+DROP TABLE merge_queue_batch;
+DROP TABLE merge_queue;
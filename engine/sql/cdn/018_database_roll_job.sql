@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "database_roll_job" (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(32) NOT NULL,
+    entity VARCHAR(256) NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    batch_size BIGINT NOT NULL,
+    delay_ms BIGINT NOT NULL DEFAULT 0,
+    total BIGINT NOT NULL DEFAULT 0,
+    processed BIGINT NOT NULL DEFAULT 0,
+    last_pk TEXT,
+    error TEXT NOT NULL DEFAULT '',
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_modified TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    done TIMESTAMP WITH TIME ZONE
+);
+SELECT create_index('database_roll_job', 'idx_database_roll_job_status', 'status');
+
+-- +migrate Down
+DROP TABLE database_roll_job;
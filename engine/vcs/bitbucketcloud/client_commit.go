@@ -172,3 +172,37 @@ func (client *bitbucketcloudClient) CommitsBetweenRefs(ctx context.Context, repo
 
 	return commitsResult, nil
 }
+
+// ChangedFiles returns the paths of the files changed between base and head, renamed files are returned with their
+// previous and their new path.
+func (client *bitbucketcloudClient) ChangedFiles(ctx context.Context, repo, base, head string) ([]string, error) {
+	// The spec of a diff is source..destination, the changes of the source since its merge base with destination
+	path := fmt.Sprintf("/repositories/%s/diffstat/%s..%s", repo, head, base)
+	params := url.Values{}
+	var files []string
+	for nextPage := 1; ; nextPage++ {
+		if ctx.Err() != nil {
+			return nil, sdk.WithStack(ctx.Err())
+		}
+		if nextPage != 1 {
+			params.Set("page", fmt.Sprintf("%d", nextPage))
+		}
+
+		var response DiffStats
+		if err := client.do(ctx, "GET", "core", path, params, nil, &response); err != nil {
+			return nil, sdk.WrapError(err, "unable to get diffstat")
+		}
+		for _, v := range response.Values {
+			if v.New != nil {
+				files = append(files, v.New.Path)
+			}
+			if v.Old != nil && (v.New == nil || v.Old.Path != v.New.Path) {
+				files = append(files, v.Old.Path)
+			}
+		}
+		if response.Next == "" {
+			break
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,69 @@
+package bitbucketcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (client *bitbucketcloudClient) CreateDeployKey(ctx context.Context, repo string, key sdk.VCSDeployKey) (sdk.VCSDeployKey, error) {
+	b, err := json.Marshal(DeployKey{Key: key.Key, Label: key.Title})
+	if err != nil {
+		return key, sdk.WithStack(err)
+	}
+	res, err := client.post(ctx, fmt.Sprintf("/repositories/%s/deploy-keys", repo), "application/json", bytes.NewBuffer(b), nil)
+	if err != nil {
+		return key, sdk.WrapError(err, "bitbucketcloud.CreateDeployKey")
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return key, sdk.WithStack(err)
+	}
+	if res.StatusCode != 200 && res.StatusCode != 201 {
+		return key, sdk.WithStack(fmt.Errorf("unable to create deploy key on bitbucketcloud, status code: %d - body: %s", res.StatusCode, body))
+	}
+	var dk DeployKey
+	if err := sdk.JSONUnmarshal(body, &dk); err != nil {
+		return key, sdk.WrapError(err, "cannot unmarshal response")
+	}
+	key.ID = strconv.FormatInt(dk.ID, 10)
+	key.ReadOnly = true
+	return key, nil
+}
+
+func (client *bitbucketcloudClient) DeleteDeployKey(ctx context.Context, repo string, key sdk.VCSDeployKey) error {
+	if key.ID == "" {
+		next := fmt.Sprintf("/repositories/%s/deploy-keys", repo)
+		for next != "" && key.ID == "" {
+			status, body, _, err := client.get(ctx, next)
+			if err != nil {
+				return err
+			}
+			if status >= 400 {
+				return sdk.WithStack(fmt.Errorf("unable to list deploy keys on bitbucketcloud, status code: %d - body: %s", status, body))
+			}
+			var page DeployKeys
+			if err := sdk.JSONUnmarshal(body, &page); err != nil {
+				return sdk.WrapError(err, "cannot unmarshal response")
+			}
+			for _, k := range page.Values {
+				if (sdk.VCSDeployKey{Key: k.Key}).Match(key.Key) {
+					key.ID = strconv.FormatInt(k.ID, 10)
+					break
+				}
+			}
+			next = strings.TrimPrefix(page.Next, rootURL)
+		}
+		if key.ID == "" {
+			return nil
+		}
+	}
+	return client.delete(ctx, fmt.Sprintf("/repositories/%s/deploy-keys/%s", repo, key.ID))
+}
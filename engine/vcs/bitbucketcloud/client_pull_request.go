@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"strings"
 
 	"github.com/rockbears/log"
 
@@ -91,6 +92,12 @@ type BitbucketCloudPullRequestComment struct {
 	} `json:"content,omitempty"`
 }
 
+type BitbucketCloudPullRequestMerge struct {
+	Type          string `json:"type"`
+	Message       string `json:"message,omitempty"`
+	MergeStrategy string `json:"merge_strategy,omitempty"`
+}
+
 // PullRequestComment push a new comment on a pull request
 func (client *bitbucketcloudClient) PullRequestComment(ctx context.Context, repo string, prRequest sdk.VCSPullRequestCommentRequest) error {
 	if client.DisableStatus {
@@ -151,6 +158,49 @@ func (client *bitbucketcloudClient) PullRequestCreate(ctx context.Context, repo
 	return prResponse.ToVCSPullRequest(), nil
 }
 
+// PullRequestMerge merges a pull request with the given method
+func (client *bitbucketcloudClient) PullRequestMerge(ctx context.Context, repo string, id string, req sdk.VCSPullRequestMergeRequest) error {
+	if req.Commit != "" {
+		pr, err := client.PullRequest(ctx, repo, id)
+		if err != nil {
+			return err
+		}
+		// Bitbucket Cloud returns abbreviated hashes
+		if pr.Head.Branch.LatestCommit == "" || !strings.HasPrefix(req.Commit, pr.Head.Branch.LatestCommit) {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "head of pull request %s is not %s", id, req.Commit)
+		}
+	}
+
+	payload := BitbucketCloudPullRequestMerge{
+		Type:    "pullrequest",
+		Message: req.Message,
+	}
+	switch req.Method {
+	case sdk.MergeQueueMethodSquash:
+		payload.MergeStrategy = "squash"
+	case sdk.MergeQueueMethodRebase:
+		payload.MergeStrategy = "fast_forward"
+	default:
+		payload.MergeStrategy = "merge_commit"
+	}
+	values, _ := json.Marshal(payload)
+	path := fmt.Sprintf("/repositories/%s/pullrequests/%s/merge", repo, id)
+	res, err := client.post(ctx, path, "application/json", bytes.NewReader(values), &postOptions{skipDefaultBaseURL: false, asUser: true})
+	if err != nil {
+		return sdk.WrapError(err, "unable to merge pull request %s", id)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return sdk.WrapError(err, "unable to read body")
+	}
+	if res.StatusCode >= 400 {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to merge pull request %s: %v", id, errorAPI(body))
+	}
+	return nil
+}
+
 func (pullr PullRequest) ToVCSPullRequest() sdk.VCSPullRequest {
 	return sdk.VCSPullRequest{
 		ID: pullr.ID,
@@ -1,18 +1,90 @@
 package bitbucketcloud
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"mime/multipart"
+	"net/url"
 
 	"github.com/ovh/cds/sdk"
 )
 
-// Release Create a release
+// Release creates the tag as an annotated tag whose message holds the release title and notes, Bitbucket Cloud having
+// no release API. As the message of an existing tag can't be changed, the tag must not exist yet; it is created on the
+// latest commit of the main branch.
 func (client *bitbucketcloudClient) Release(ctx context.Context, fullname string, tagName string, title string, releaseNote string) (*sdk.VCSRelease, error) {
-	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+	path := fmt.Sprintf("/repositories/%s/refs/tags", fullname)
+	status, body, _, err := client.get(ctx, path+"/"+url.PathEscape(tagName))
+	if err != nil {
+		return nil, err
+	}
+	if status < 400 {
+		return nil, sdk.NewErrorFrom(sdk.ErrAlreadyExist, "tag %s already exists, Bitbucket Cloud has no release API so the release notes are published as the message of the tag created by the release", tagName)
+	}
+	if status != 404 {
+		return nil, sdk.NewError(sdk.ErrUnknownError, errorAPI(body))
+	}
+
+	mainBranch, err := client.Branch(ctx, fullname, sdk.VCSBranchFilters{Default: true})
+	if err != nil {
+		return nil, err
+	}
+
+	message := title
+	if releaseNote != "" {
+		message += "\n\n" + releaseNote
+	}
+	var req CreateTagRequest
+	req.Name = tagName
+	req.Target.Hash = mainBranch.LatestCommit
+	req.Message = message
+	b, err := json.Marshal(req)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Cannot marshal body %+v", req)
+	}
+	res, err := client.post(ctx, path, "application/json", bytes.NewBuffer(b), nil)
+	if err != nil {
+		return nil, sdk.WrapError(err, "bitbucketcloud.Release")
+	}
+	defer res.Body.Close()
+	body, err = io.ReadAll(res.Body)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	if res.StatusCode != 201 {
+		return nil, sdk.WithStack(fmt.Errorf("unable to create tag %s on bitbucketcloud, status code: %d - body: %s", tagName, res.StatusCode, body))
+	}
+
+	return &sdk.VCSRelease{}, nil
 }
 
-// UploadReleaseFile Attach a file into the release
+// UploadReleaseFile uploads the file to the downloads of the repository, Bitbucket Cloud having no release to attach
+// it to.
 func (client *bitbucketcloudClient) UploadReleaseFile(ctx context.Context, repo string, releaseName string, uploadURL string, artifactName string, r io.Reader, fileLength int) error {
-	return sdk.WithStack(sdk.ErrNotImplemented)
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	fw, err := w.CreateFormFile("files", artifactName)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if _, err := io.Copy(fw, r); err != nil {
+		return sdk.WithStack(err)
+	}
+	if err := w.Close(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	res, err := client.post(ctx, fmt.Sprintf("/repositories/%s/downloads", repo), w.FormDataContentType(), &buf, nil)
+	if err != nil {
+		return sdk.WrapError(err, "bitbucketcloud.UploadReleaseFile")
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 201 {
+		body, _ := io.ReadAll(res.Body)
+		return sdk.WithStack(fmt.Errorf("unable to upload file %s on bitbucketcloud, status code: %d - body: %s", artifactName, res.StatusCode, body))
+	}
+	return nil
 }
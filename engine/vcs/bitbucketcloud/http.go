@@ -13,13 +13,14 @@ import (
 
 	"github.com/rockbears/log"
 
+	"github.com/ovh/cds/engine/vcs/vcshttp"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/cdsclient"
 )
 
 //Github http var
 var (
-	httpClient = cdsclient.NewHTTPClient(time.Second*30, false)
+	httpClient = vcshttp.NewClient(context.Background(), cdsclient.NewHTTPClient(time.Second*30, false))
 )
 
 func (consumer *bitbucketcloudConsumer) postForm(url string, data url.Values, headers map[string][]string) (int, []byte, error) {
@@ -77,7 +78,7 @@ func (client *bitbucketcloudClient) setAuth(ctx context.Context, req *http.Reque
 }
 
 func (client *bitbucketcloudClient) post(ctx context.Context, path string, bodyType string, body io.Reader, opts *postOptions) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodPost, rootURL+path, body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rootURL+path, body)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +95,7 @@ func (client *bitbucketcloudClient) post(ctx context.Context, path string, bodyT
 }
 
 func (client *bitbucketcloudClient) put(ctx context.Context, path string, bodyType string, body io.Reader, opts *postOptions) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodPut, rootURL+path, body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, rootURL+path, body)
 	if err != nil {
 		return nil, err
 	}
@@ -116,7 +117,7 @@ func (client *bitbucketcloudClient) get(ctx context.Context, path string) (int,
 		return 0, nil, nil, sdk.WithStack(err)
 	}
 
-	req, err := http.NewRequest(http.MethodGet, callURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, callURL.String(), nil)
 	if err != nil {
 		return 0, nil, nil, sdk.WithStack(err)
 	}
@@ -154,7 +155,7 @@ func (client *bitbucketcloudClient) get(ctx context.Context, path string) (int,
 }
 
 func (client *bitbucketcloudClient) delete(ctx context.Context, path string) error {
-	req, err := http.NewRequest(http.MethodDelete, rootURL+path, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, rootURL+path, nil)
 	if err != nil {
 		return sdk.WithStack(err)
 	}
@@ -195,6 +196,7 @@ func (client *bitbucketcloudClient) do(ctx context.Context, method, api, path st
 		Close:      true,
 		Header:     http.Header{},
 	}
+	req = req.WithContext(ctx)
 
 	if len(values) > 0 {
 		buf := bytes.NewBuffer(values)
@@ -21,6 +21,19 @@ type WebhookCreate struct {
 	Events      []string `json:"events"`
 }
 
+// DeployKey represents a repository deploy key, deploy keys are always read only on bitbucket cloud
+type DeployKey struct {
+	ID    int64  `json:"id,omitempty"`
+	Key   string `json:"key"`
+	Label string `json:"label"`
+}
+
+// DeployKeys represents a page of deploy keys
+type DeployKeys struct {
+	Values []DeployKey `json:"values"`
+	Next   string      `json:"next"`
+}
+
 type Webhook struct {
 	ReadOnly    bool   `json:"read_only"`
 	Description string `json:"description"`
@@ -353,6 +366,22 @@ type Commits struct {
 	Previous string   `json:"previous,omitempty"`
 }
 
+// DiffStats is a page of the files changed between two commits
+type DiffStats struct {
+	Pagelen int `json:"pagelen"`
+	Page    int `json:"page"`
+	Values  []struct {
+		Status string        `json:"status"`
+		Old    *DiffStatFile `json:"old"`
+		New    *DiffStatFile `json:"new"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
+type DiffStatFile struct {
+	Path string `json:"path"`
+}
+
 type Commit struct {
 	Rendered struct {
 		Message struct {
@@ -413,6 +442,14 @@ type Tags struct {
 	Previous string `json:"previous,omitempty"`
 }
 
+type CreateTagRequest struct {
+	Name   string `json:"name"`
+	Target struct {
+		Hash string `json:"hash"`
+	} `json:"target"`
+	Message string `json:"message,omitempty"`
+}
+
 type Tag struct {
 	Name  string `json:"name"`
 	Links struct {
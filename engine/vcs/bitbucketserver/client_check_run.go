@@ -0,0 +1,90 @@
+package bitbucketserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// publishCodeInsights replaces the Code Insights report of a node run and its annotations on a commit.
+func (b *bitbucketClient) publishCodeInsights(ctx context.Context, data statusData, checkRun sdk.VCSCheckRun) error {
+	t := strings.SplitN(data.repoFullName, "/", 2)
+	if len(t) != 2 {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid repository %q", data.repoFullName)
+	}
+	path := fmt.Sprintf("/projects/%s/repos/%s/commits/%s/reports/%s", t[0], t[1], data.hash, url.PathEscape(data.key))
+
+	report := InsightReport{
+		Title:    sdk.StringFirstN(checkRun.Name, 450),
+		Details:  sdk.StringFirstN(checkRun.Title, 2000),
+		Result:   "PASS",
+		Reporter: "CDS",
+		Link:     data.url,
+	}
+	if data.status != sdk.StatusSuccess {
+		report.Result = "FAIL"
+	}
+	if checkRun.Tests.Total > 0 {
+		report.Data = append(report.Data,
+			InsightReportData{Title: "Tests", Type: "NUMBER", Value: checkRun.Tests.Total},
+			InsightReportData{Title: "Failed tests", Type: "NUMBER", Value: checkRun.Tests.TotalKO},
+			InsightReportData{Title: "Skipped tests", Type: "NUMBER", Value: checkRun.Tests.TotalSkipped},
+		)
+	}
+	if len(checkRun.FailedTests) > 0 {
+		names := make([]string, 0, len(checkRun.FailedTests))
+		for _, ft := range checkRun.FailedTests {
+			names = append(names, ft.Name)
+		}
+		report.Details = sdk.StringFirstN(checkRun.Title+"\nFailed tests: "+strings.Join(names, ", "), 2000)
+	}
+
+	values, err := json.Marshal(report)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if err := b.do(ctx, "PUT", "insights", path, nil, values, nil, nil); err != nil {
+		return sdk.WrapError(err, "unable to put insight report %s", data.key)
+	}
+
+	if err := b.do(ctx, "DELETE", "insights", path+"/annotations", nil, nil, nil, nil); err != nil {
+		return sdk.WrapError(err, "unable to delete annotations of insight report %s", data.key)
+	}
+	if len(checkRun.Annotations) == 0 {
+		return nil
+	}
+
+	annotations := InsightAnnotations{Annotations: make([]InsightAnnotation, 0, len(checkRun.Annotations))}
+	for _, a := range checkRun.Annotations {
+		severity := "LOW"
+		switch a.Level {
+		case sdk.VCSCheckRunAnnotationLevelFailure:
+			severity = "HIGH"
+		case sdk.VCSCheckRunAnnotationLevelWarning:
+			severity = "MEDIUM"
+		}
+		msg := a.Message
+		if a.Title != "" {
+			msg = a.Title + ": " + a.Message
+		}
+		annotations.Annotations = append(annotations.Annotations, InsightAnnotation{
+			Path:     a.Path,
+			Line:     a.StartLine,
+			Message:  sdk.StringFirstN(msg, 2000),
+			Severity: severity,
+			Type:     "CODE_SMELL",
+		})
+	}
+	values, err = json.Marshal(annotations)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if err := b.do(ctx, "POST", "insights", path+"/annotations", nil, values, nil, nil); err != nil {
+		return sdk.WrapError(err, "unable to post annotations of insight report %s", data.key)
+	}
+	return nil
+}
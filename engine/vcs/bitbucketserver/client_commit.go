@@ -190,3 +190,39 @@ func (b *bitbucketClient) CommitsBetweenRefs(ctx context.Context, repo, base, he
 	}
 	return commits, nil
 }
+
+// ChangedFiles returns the paths of the files changed between base and head, moved files are returned with their
+// previous and their new path.
+func (b *bitbucketClient) ChangedFiles(ctx context.Context, repo, base, head string) ([]string, error) {
+	project, slug, err := getRepo(repo)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	path := fmt.Sprintf("/projects/%s/repos/%s/compare/changes", project, slug)
+	params := url.Values{}
+	params.Add("from", head)
+	params.Add("to", base)
+
+	var files []string
+	var response ChangesResponse
+	for {
+		if response.NextPageStart != 0 {
+			params.Set("start", fmt.Sprintf("%d", response.NextPageStart))
+		}
+		response = ChangesResponse{}
+		if err := b.do(ctx, "GET", "core", path, params, nil, &response, nil); err != nil {
+			return nil, sdk.WrapError(err, "unable to get changes %s", path)
+		}
+		for _, v := range response.Values {
+			files = append(files, v.Path.ToString)
+			if v.SrcPath != nil && v.SrcPath.ToString != "" && v.SrcPath.ToString != v.Path.ToString {
+				files = append(files, v.SrcPath.ToString)
+			}
+		}
+		if response.IsLastPage {
+			break
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,68 @@
+package bitbucketserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/telemetry"
+)
+
+func (b *bitbucketClient) CreateDeployKey(ctx context.Context, repo string, key sdk.VCSDeployKey) (sdk.VCSDeployKey, error) {
+	ctx, end := telemetry.Span(ctx, "bitbucketserver.CreateDeployKey", telemetry.Tag(telemetry.TagRepository, repo))
+	defer end()
+	project, slug, err := getRepo(repo)
+	if err != nil {
+		return key, err
+	}
+
+	request := RepositoryAccessKey{
+		Key:        AccessKey{Text: key.Key, Label: key.Title},
+		Permission: "REPO_READ",
+	}
+	if !key.ReadOnly {
+		request.Permission = "REPO_WRITE"
+	}
+	values, err := json.Marshal(&request)
+	if err != nil {
+		return key, sdk.WithStack(err)
+	}
+	path := fmt.Sprintf("/projects/%s/repos/%s/ssh", project, slug)
+	if err := b.do(ctx, "POST", "keys", path, nil, values, &request, nil); err != nil {
+		return key, sdk.WrapError(err, "unable to add access key")
+	}
+	key.ID = strconv.FormatInt(request.Key.ID, 10)
+	return key, nil
+}
+
+func (b *bitbucketClient) DeleteDeployKey(ctx context.Context, repo string, key sdk.VCSDeployKey) error {
+	ctx, end := telemetry.Span(ctx, "bitbucketserver.DeleteDeployKey", telemetry.Tag(telemetry.TagRepository, repo))
+	defer end()
+	project, slug, err := getRepo(repo)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/projects/%s/repos/%s/ssh", project, slug)
+	if key.ID == "" {
+		var resp RepositoryAccessKeysResponse
+		if err := b.do(ctx, "GET", "keys", path, nil, nil, &resp, nil); err != nil {
+			return sdk.WrapError(err, "unable to get access keys")
+		}
+		for _, k := range resp.Values {
+			if (sdk.VCSDeployKey{Key: k.Key.Text}).Match(key.Key) {
+				key.ID = strconv.FormatInt(k.Key.ID, 10)
+				break
+			}
+		}
+		if key.ID == "" {
+			return nil
+		}
+	}
+	if err := b.do(ctx, "DELETE", "keys", path+"/"+key.ID, nil, nil, nil, nil); err != nil {
+		return sdk.WrapError(err, "unable to delete access key %s", key.ID)
+	}
+	return nil
+}
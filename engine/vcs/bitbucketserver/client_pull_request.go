@@ -178,6 +178,46 @@ func (b *bitbucketClient) PullRequestCreate(ctx context.Context, repo string, pr
 	return b.ToVCSPullRequest(ctx, repo, request)
 }
 
+// PullRequestMerge merges a pull request with the given strategy
+func (b *bitbucketClient) PullRequestMerge(ctx context.Context, repo string, id string, req sdk.VCSPullRequestMergeRequest) error {
+	project, slug, err := getRepo(repo)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+
+	path := fmt.Sprintf("/projects/%s/repos/%s/pull-requests/%s", project, slug, id)
+	var pr sdk.BitbucketServerPullRequest
+	if err := b.do(ctx, "GET", "core", path, nil, nil, &pr, nil); err != nil {
+		return sdk.WrapError(err, "unable to get pullrequest")
+	}
+	if req.Commit != "" && pr.FromRef.LatestCommit != req.Commit {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "head of pull request %s is not %s", id, req.Commit)
+	}
+
+	payload := map[string]string{}
+	if req.Message != "" {
+		payload["message"] = req.Message
+	}
+	switch req.Method {
+	case sdk.MergeQueueMethodSquash:
+		payload["strategyId"] = "squash"
+	case sdk.MergeQueueMethodRebase:
+		payload["strategyId"] = "rebase-no-ff"
+	default:
+		payload["strategyId"] = "no-ff"
+	}
+	values, err := json.Marshal(payload)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	params := url.Values{}
+	params.Set("version", fmt.Sprintf("%d", pr.Version))
+	if err := b.do(ctx, "POST", "core", path+"/merge", params, values, nil, &options{asUser: true}); err != nil {
+		return sdk.WrapError(err, "unable to merge pullrequest %s", id)
+	}
+	return nil
+}
+
 func (b *bitbucketClient) ToVCSPullRequest(ctx context.Context, repo string, pullRequest sdk.BitbucketServerPullRequest) (sdk.VCSPullRequest, error) {
 	pr := sdk.VCSPullRequest{
 		ID:     pullRequest.ID,
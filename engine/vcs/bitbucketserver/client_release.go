@@ -2,15 +2,60 @@ package bitbucketserver
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
+	"strings"
 
 	"github.com/ovh/cds/sdk"
 )
 
+// Release creates the tag as an annotated tag whose message holds the release title and notes, Bitbucket Server
+// having no release API. As the message of an existing tag can't be changed, the tag must not exist yet; it is created
+// on the latest commit of the default branch.
 func (b *bitbucketClient) Release(ctx context.Context, repo, tagName, releaseTitle, releaseDescription string) (*sdk.VCSRelease, error) {
-	return nil, fmt.Errorf("Not yet implemented")
+	t := strings.Split(repo, "/")
+	if len(t) != 2 {
+		return nil, sdk.ErrRepoNotFound
+	}
+
+	path := fmt.Sprintf("/projects/%s/repos/%s/tags", t[0], t[1])
+	var existing Tag
+	err := b.do(ctx, "GET", "core", path+"/"+url.PathEscape(tagName), nil, nil, &existing, nil)
+	if err == nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrAlreadyExist, "tag %s already exists, Bitbucket Server has no release API so the release notes are published as the message of the tag created by the release", tagName)
+	}
+	if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil, sdk.WrapError(err, "unable to get tag %s", tagName)
+	}
+
+	defaultBranch, err := b.GetDefaultBranch(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	message := releaseTitle
+	if releaseDescription != "" {
+		message += "\n\n" + releaseDescription
+	}
+	values, err := json.Marshal(CreateTagRequest{
+		Name:       tagName,
+		StartPoint: defaultBranch.LatestCommit,
+		Message:    message,
+	})
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	var tag Tag
+	if err := b.do(ctx, "POST", "core", path, nil, values, &tag, nil); err != nil {
+		return nil, sdk.WrapError(err, "unable to create tag %s", tagName)
+	}
+
+	return &sdk.VCSRelease{}, nil
 }
+
+// UploadReleaseFile always fails, Bitbucket Server has no release API to attach files to
 func (b *bitbucketClient) UploadReleaseFile(ctx context.Context, repo string, releaseName string, uploadURL string, artifactName string, r io.Reader, fileLength int) error {
-	return fmt.Errorf("Not yet implemented")
+	return sdk.NewErrorFrom(sdk.ErrNotImplemented, "Bitbucket Server has no release API, file %s can't be attached to the release", artifactName)
 }
@@ -15,12 +15,14 @@ import (
 )
 
 type statusData struct {
-	key         string
-	buildNumber int64
-	status      string
-	url         string
-	hash        string
-	description string
+	key          string
+	buildNumber  int64
+	status       string
+	url          string
+	hash         string
+	description  string
+	repoFullName string
+	checkRun     *sdk.VCSCheckRun
 }
 
 // DEPRECATED VCS
@@ -67,6 +69,12 @@ func (b *bitbucketClient) SetStatus(ctx context.Context, event sdk.Event, disabl
 	if err := b.do(ctx, "POST", "build-status", fmt.Sprintf("/commits/%s", statusData.hash), nil, values, nil, nil); err != nil {
 		return sdk.WrapError(err, "Unable to post build-status name:%s status:%s", status.Name, state)
 	}
+
+	if statusData.checkRun != nil && sdk.StatusIsTerminated(statusData.status) {
+		if err := b.publishCodeInsights(ctx, statusData, *statusData.checkRun); err != nil {
+			log.Warn(ctx, "bitbucketClient.SetStatus> unable to publish code insights, only the status is set: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -157,6 +165,8 @@ func processWorkflowNodeRunEvent(event sdk.Event, uiURL string, disableStatusDet
 	data.status = eventNR.Status
 	data.hash = eventNR.Hash
 	data.description = sdk.VCSCommitStatusDescription(event.ProjectKey, event.WorkflowName, eventNR)
+	data.repoFullName = eventNR.RepositoryFullName
+	data.checkRun = eventNR.CheckRun
 
 	return data, nil
 }
@@ -15,13 +15,14 @@ import (
 	"github.com/rockbears/log"
 
 	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/engine/vcs/vcshttp"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/cdsclient"
 	"github.com/ovh/cds/sdk/telemetry"
 )
 
 var (
-	httpClient = cdsclient.NewHTTPClient(time.Second*30, false)
+	httpClient = vcshttp.NewClient(context.Background(), cdsclient.NewHTTPClient(time.Second*30, false))
 )
 
 func requestString(method string, uri string, params map[string]string) string {
@@ -62,6 +63,8 @@ func (b *bitbucketClient) getFullAPIURL(api string) string {
 		url = fmt.Sprintf("%s/rest/api/1.0", b.consumer.URL)
 	case "build-status":
 		url = fmt.Sprintf("%s/rest/build-status/1.0", b.consumer.URL)
+	case "insights":
+		url = fmt.Sprintf("%s/rest/insights/1.0", b.consumer.URL)
 	}
 
 	return url
@@ -102,6 +105,7 @@ func (b *bitbucketClient) do(ctx context.Context, method, api, path string, para
 		Close:      true,
 		Header:     http.Header{},
 	}
+	req = req.WithContext(ctx)
 
 	log.Info(ctx, "%s %s", req.Method, req.URL.String())
 
@@ -224,6 +228,7 @@ func (b *bitbucketClient) stream(ctx context.Context, method, api, path string,
 		Close:      true,
 		Header:     http.Header{},
 	}
+	req = req.WithContext(ctx)
 
 	log.Info(ctx, "%s %s", req.Method, req.URL.String())
 
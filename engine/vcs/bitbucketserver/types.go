@@ -24,6 +24,25 @@ type GetWebHooksResponse struct {
 	Values []WebHook `json:"values"`
 }
 
+// AccessKey represents a SSH access key in bitbucket model
+type AccessKey struct {
+	ID    int64  `json:"id,omitempty"`
+	Text  string `json:"text"`
+	Label string `json:"label,omitempty"`
+}
+
+// RepositoryAccessKey represents an access key with its permission on a repository
+type RepositoryAccessKey struct {
+	Key        AccessKey `json:"key"`
+	Permission string    `json:"permission"`
+}
+
+// RepositoryAccessKeysResponse represent the response send by bitbucket when listing repository access keys
+type RepositoryAccessKeysResponse struct {
+	Values     []RepositoryAccessKey `json:"values"`
+	IsLastPage bool                  `json:"isLastPage"`
+}
+
 type Branch struct {
 	ID         string `json:"id"`
 	DisplayID  string `json:"displayId"`
@@ -46,6 +65,12 @@ type Tag struct {
 	Hash            string `json:"hash"`
 }
 
+type CreateTagRequest struct {
+	Name       string `json:"name"`
+	StartPoint string `json:"startPoint"`
+	Message    string `json:"message,omitempty"`
+}
+
 type TagResponse struct {
 	Values     []Tag `json:"values"`
 	Size       int   `json:"size"`
@@ -73,6 +98,20 @@ type Commit struct {
 	Message   string `json:"message"`
 }
 
+// ChangesResponse is a page of the files changed between two commits
+type ChangesResponse struct {
+	Values []struct {
+		Path    ChangePath  `json:"path"`
+		SrcPath *ChangePath `json:"srcPath,omitempty"`
+	} `json:"values"`
+	NextPageStart int  `json:"nextPageStart"`
+	IsLastPage    bool `json:"isLastPage"`
+}
+
+type ChangePath struct {
+	ToString string `json:"toString"`
+}
+
 type Status struct {
 	Description string `json:"description"`
 	Key         string `json:"key"`
@@ -82,6 +121,35 @@ type Status struct {
 	Timestamp   int64  `json:"dateAdded"`
 }
 
+// InsightReport is a Code Insights report on a commit
+// https://developer.atlassian.com/server/bitbucket/how-tos/code-insights/
+type InsightReport struct {
+	Title    string              `json:"title"`
+	Details  string              `json:"details,omitempty"`
+	Result   string              `json:"result,omitempty"`
+	Reporter string              `json:"reporter,omitempty"`
+	Link     string              `json:"link,omitempty"`
+	Data     []InsightReportData `json:"data,omitempty"`
+}
+
+type InsightReportData struct {
+	Title string      `json:"title"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+type InsightAnnotations struct {
+	Annotations []InsightAnnotation `json:"annotations"`
+}
+
+type InsightAnnotation struct {
+	Path     string `json:"path,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+	Type     string `json:"type,omitempty"`
+}
+
 type Lines struct {
 	Text string `json:"text"`
 }
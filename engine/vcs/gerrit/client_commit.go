@@ -2,6 +2,10 @@ package gerrit
 
 import (
 	"context"
+	"sort"
+	"strings"
+
+	"github.com/andygrunwald/go-gerrit"
 
 	"github.com/ovh/cds/sdk"
 )
@@ -20,3 +24,36 @@ func (c *gerritClient) Commit(ctx context.Context, repo, hash string) (sdk.VCSCo
 func (c *gerritClient) CommitsBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSCommit, error) {
 	return nil, nil
 }
+
+// ChangedFiles returns the paths of the files changed by the patch set of the head commit, against its first parent
+func (c *gerritClient) ChangedFiles(_ context.Context, repo, _, head string) ([]string, error) {
+	changes, _, err := c.client.Changes.QueryChanges(&gerrit.QueryChangeOptions{
+		QueryOptions: gerrit.QueryOptions{
+			Query: []string{"project:" + repo + " commit:" + head},
+		},
+	})
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	if changes == nil || len(*changes) == 0 {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "unable to find change of commit %s", head)
+	}
+
+	infos, _, err := c.client.Changes.ListFiles((*changes)[0].ID, head, nil)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	files := make([]string, 0, len(infos))
+	for name, info := range infos {
+		// Magic files like /COMMIT_MSG are not part of the repository
+		if strings.HasPrefix(name, "/") {
+			continue
+		}
+		files = append(files, name)
+		if info.OldPath != "" {
+			files = append(files, info.OldPath)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
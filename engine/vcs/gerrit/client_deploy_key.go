@@ -0,0 +1,15 @@
+package gerrit
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *gerritClient) CreateDeployKey(ctx context.Context, repo string, key sdk.VCSDeployKey) (sdk.VCSDeployKey, error) {
+	return key, sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+func (c *gerritClient) DeleteDeployKey(ctx context.Context, repo string, key sdk.VCSDeployKey) error {
+	return sdk.WithStack(sdk.ErrNotImplemented)
+}
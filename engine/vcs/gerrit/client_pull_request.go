@@ -85,6 +85,23 @@ func (c *gerritClient) PullRequestCreate(_ context.Context, _ string, _ sdk.VCSP
 	return sdk.VCSPullRequest{}, nil
 }
 
+// PullRequestMerge submits a change, the submit type of the project is used
+func (c *gerritClient) PullRequestMerge(_ context.Context, _ string, id string, req sdk.VCSPullRequestMergeRequest) error {
+	if req.Commit != "" {
+		change, _, err := c.client.Changes.GetChange(id, &gerrit.ChangeOptions{AdditionalFields: []string{"CURRENT_REVISION"}})
+		if err != nil {
+			return sdk.WrapError(err, "unable to get change %s", id)
+		}
+		if change.CurrentRevision != req.Commit {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "current revision of change %s is not %s", id, req.Commit)
+		}
+	}
+	if _, _, err := c.client.Changes.SubmitChange(id, &gerrit.SubmitInput{WaitForMerge: true}); err != nil {
+		return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to submit change %s", id))
+	}
+	return nil
+}
+
 func (c *gerritClient) toVCSPullRequest(change gerrit.ChangeInfo) sdk.VCSPullRequest {
 	pr := sdk.VCSPullRequest{
 		ChangeID: change.ID,
@@ -38,6 +38,10 @@ func (c *gerritClient) SetStatus(ctx context.Context, event sdk.Event, disableSt
 		Labels:  c.buildLabel(eventNR),
 		Notify:  "OWNER", // Send notification to the owner
 	}
+	if eventNR.CheckRun != nil && sdk.StatusIsTerminated(eventNR.Status) {
+		ri.Message += "\n" + eventNR.CheckRun.Title
+		ri.RobotComments = c.buildRobotComments(eventNR)
+	}
 
 	// Check if we already send the message
 	changeDetail, _, err := c.client.Changes.GetChangeDetail(eventNR.GerritChange.ID, nil)
@@ -83,8 +87,47 @@ func (c *gerritClient) buildMessage(eventNR sdk.EventRunWorkflowNode) string {
 	return message
 }
 
+// buildRobotComments converts the failure and warning annotations of the check run to robot comments
+// https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#robot-comment-input
+func (c *gerritClient) buildRobotComments(eventNR sdk.EventRunWorkflowNode) map[string][]gerrit.RobotCommentInput {
+	comments := make(map[string][]gerrit.RobotCommentInput)
+	for _, a := range eventNR.CheckRun.Annotations {
+		if a.Level == sdk.VCSCheckRunAnnotationLevelNotice || a.Path == "" {
+			continue
+		}
+		message := a.Message
+		if a.Rule != "" {
+			message = fmt.Sprintf("[%s] %s", a.Rule, a.Message)
+		}
+		comments[a.Path] = append(comments[a.Path], gerrit.RobotCommentInput{
+			CommentInput: gerrit.CommentInput{
+				Line:    a.StartLine,
+				Message: message,
+			},
+			RobotID:    "CDS",
+			RobotRunID: fmt.Sprintf("%d", eventNR.ID),
+			URL:        eventNR.CheckRun.URL,
+		})
+	}
+	if len(comments) == 0 {
+		return nil
+	}
+	return comments
+}
+
 func (c *gerritClient) buildLabel(eventNR sdk.EventRunWorkflowNode) map[string]string {
 	labels := make(map[string]string)
+	if len(eventNR.GerritChange.Labels) > 0 {
+		for _, l := range eventNR.GerritChange.Labels {
+			if score, ok := l.Score(eventNR.Status); ok {
+				labels[l.Name] = fmt.Sprintf("%+d", score)
+			}
+		}
+		if len(labels) == 0 {
+			return nil
+		}
+		return labels
+	}
 	switch eventNR.Status {
 	case sdk.StatusSuccess:
 		labels["Verified"] = "1"
@@ -2,9 +2,11 @@ package gerrit
 
 import (
 	"context"
+	"net/http"
 
 	ger "github.com/andygrunwald/go-gerrit"
 
+	"github.com/ovh/cds/engine/vcs/vcshttp"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -22,7 +24,7 @@ func (g *gerritConsumer) AuthorizeToken(ctx context.Context, state, code string)
 
 //GetAuthorized returns an authorized client
 func (g *gerritConsumer) GetAuthorizedClient(ctx context.Context, vcsAuth sdk.VCSAuth) (sdk.VCSAuthorizedClient, error) {
-	client, err := ger.NewClient(g.URL, nil)
+	client, err := ger.NewClient(g.URL, vcshttp.NewClient(ctx, &http.Client{}))
 	if err != nil {
 		return nil, sdk.WrapError(err, "unable to create gerrit client")
 	}
@@ -0,0 +1,47 @@
+package git
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Branches returns the branches of the repository, sorted by name
+func (g *gitClient) Branches(ctx context.Context, fullname string, filters sdk.VCSBranchesFilter) ([]sdk.VCSBranch, error) {
+	refs, err := g.lsRemote(ctx, fullname)
+	if err != nil {
+		return nil, err
+	}
+	branches := make([]sdk.VCSBranch, 0, len(refs.Branches()))
+	for _, ref := range refs.Branches() {
+		name := strings.TrimPrefix(ref.Name, "refs/heads/")
+		branches = append(branches, sdk.VCSBranch{
+			ID:           ref.Name,
+			DisplayID:    name,
+			LatestCommit: ref.Hash,
+			Default:      name == refs.Head,
+		})
+	}
+	sort.Slice(branches, func(i, j int) bool { return branches[i].DisplayID < branches[j].DisplayID })
+	if filters.Limit > 0 && int64(len(branches)) > filters.Limit {
+		branches = branches[:filters.Limit]
+	}
+	return branches, nil
+}
+
+// Branch returns the branch with given name, or the default branch
+func (g *gitClient) Branch(ctx context.Context, fullname string, filters sdk.VCSBranchFilters) (*sdk.VCSBranch, error) {
+	branches, err := g.Branches(ctx, fullname, sdk.VCSBranchesFilter{})
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimPrefix(filters.BranchName, "refs/heads/")
+	for i := range branches {
+		if (filters.Default && branches[i].Default) || (!filters.Default && branches[i].DisplayID == name) {
+			return &branches[i], nil
+		}
+	}
+	return nil, sdk.WithStack(sdk.ErrNoBranch)
+}
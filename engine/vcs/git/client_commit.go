@@ -0,0 +1,113 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// logFormat separates the fields of a commit with a unit separator and the commits with a record separator
+const logFormat = "--format=%H%x1f%an%x1f%ae%x1f%at%x1f%B%x1e"
+
+// maxCommitsWithoutSince limits the commits returned for a branch when no starting commit is given
+const maxCommitsWithoutSince = 100
+
+// Commits returns the commits list on a branch between a commit SHA (since) until another commit SHA (until)
+func (g *gitClient) Commits(ctx context.Context, repo, branch, since, until string) ([]sdk.VCSCommit, error) {
+	if until == "" {
+		if branch == "" {
+			return nil, sdk.WithStack(sdk.ErrNoBranch)
+		}
+		until = "refs/heads/" + strings.TrimPrefix(branch, "refs/heads/")
+	}
+	args := []string{"log", logFormat}
+	if since != "" {
+		args = append(args, since+".."+until)
+	} else {
+		args = append(args, "--max-count="+strconv.Itoa(maxCommitsWithoutSince), until)
+	}
+	return g.log(ctx, repo, args...)
+}
+
+// Commit returns a commit of the repository
+func (g *gitClient) Commit(ctx context.Context, repo, hash string) (sdk.VCSCommit, error) {
+	commits, err := g.log(ctx, repo, "log", logFormat, "--max-count=1", hash)
+	if err != nil {
+		return sdk.VCSCommit{}, err
+	}
+	if len(commits) == 0 {
+		return sdk.VCSCommit{}, sdk.NewErrorFrom(sdk.ErrNotFound, "commit %s not found", hash)
+	}
+	return commits[0], nil
+}
+
+// CommitsBetweenRefs returns the commits reachable from head and not from base
+func (g *gitClient) CommitsBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSCommit, error) {
+	return g.log(ctx, repo, "log", logFormat, base+".."+head)
+}
+
+func (g *gitClient) log(ctx context.Context, repo string, args ...string) ([]sdk.VCSCommit, error) {
+	if err := checkRevision(args[len(args)-1]); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := g.git(ctx, repo, &out, append(args, "--")...); err != nil {
+		return nil, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrNotFound, "unable to read commits of repository %s", repo))
+	}
+	return parseLog(out.String()), nil
+}
+
+// checkRevision prevents a revision to be read as an option by git
+func checkRevision(rev string) error {
+	if rev == "" || strings.HasPrefix(rev, "-") || strings.HasPrefix(rev, ".") {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid revision %q", rev)
+	}
+	return nil
+}
+
+func parseLog(out string) []sdk.VCSCommit {
+	var commits []sdk.VCSCommit
+	for _, record := range strings.Split(out, "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x1f", 5)
+		if len(fields) != 5 {
+			continue
+		}
+		ts, _ := strconv.ParseInt(fields[3], 10, 64)
+		commits = append(commits, sdk.VCSCommit{
+			Hash: fields[0],
+			Author: sdk.VCSAuthor{
+				Name:        fields[1],
+				DisplayName: fields[1],
+				Email:       fields[2],
+			},
+			Timestamp: ts * 1000,
+			Message:   strings.TrimSpace(fields[4]),
+		})
+	}
+	return commits
+}
+
+// ChangedFiles returns the paths of the files changed between base and head, renamed files are returned with their
+// previous and their new path
+func (g *gitClient) ChangedFiles(ctx context.Context, repo, base, head string) ([]string, error) {
+	if err := checkRevision(base); err != nil {
+		return nil, err
+	}
+	if err := checkRevision(head); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := g.git(ctx, repo, &out, "diff", "--name-only", "--no-renames", "-z", base, head, "--"); err != nil {
+		return nil, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrNotFound, "unable to diff %s and %s on repository %s", base, head, repo))
+	}
+	var files []string
+	for _, f := range strings.Split(out.String(), "\x00") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,15 @@
+package git
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (g *gitClient) CreateDeployKey(ctx context.Context, repo string, key sdk.VCSDeployKey) (sdk.VCSDeployKey, error) {
+	return sdk.VCSDeployKey{}, errNotSupported("deploy keys")
+}
+
+func (g *gitClient) DeleteDeployKey(ctx context.Context, repo string, key sdk.VCSDeployKey) error {
+	return errNotSupported("deploy keys")
+}
@@ -0,0 +1,218 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	refEventPush   = "push"
+	refEventCreate = "create"
+	refEventDelete = "delete"
+
+	// pollingInterval is the delay between two polls of a repository
+	pollingInterval = 60 * time.Second
+	// refEventsRetention is the duration the branch events are kept for the pollers
+	refEventsRetention = 24 * time.Hour
+)
+
+// refEvent is a change of a branch, seen between two polls of a repository
+type refEvent struct {
+	Type   string    `json:"type"`
+	Ref    string    `json:"ref"`
+	Before string    `json:"before,omitempty"`
+	After  string    `json:"after,omitempty"`
+	Date   time.Time `json:"date"`
+}
+
+// refsState contains the branches of a repository at the last poll and the changes seen by the previous polls
+type refsState struct {
+	Refs   map[string]string `json:"refs"`
+	Events []refEvent        `json:"events"`
+}
+
+func (g *gitClient) refsStateKey(repo string) (string, error) {
+	u, err := g.repoURL(repo)
+	if err != nil {
+		return "", err
+	}
+	return cache.Key("vcs", "git", "refs", u), nil
+}
+
+// GetEvents lists the branches of the repository and returns the changes since given date. The changes are computed
+// against the branches seen by the previous poll, so the first poll of a repository returns no event.
+func (g *gitClient) GetEvents(ctx context.Context, repo string, dateRef time.Time) ([]interface{}, time.Duration, error) {
+	refs, err := g.lsRemote(ctx, repo)
+	if err != nil {
+		return nil, pollingInterval, err
+	}
+	key, err := g.refsStateKey(repo)
+	if err != nil {
+		return nil, pollingInterval, err
+	}
+
+	var state refsState
+	found, err := g.consumer.cache.Get(key, &state)
+	if err != nil {
+		log.Error(ctx, "cannot get from cache %s: %v", key, err)
+	}
+
+	now := time.Now()
+	current := make(map[string]string)
+	for _, b := range refs.Branches() {
+		current[b.Name] = b.Hash
+	}
+	if found {
+		for ref, hash := range current {
+			before, ok := state.Refs[ref]
+			switch {
+			case !ok:
+				state.Events = append(state.Events, refEvent{Type: refEventCreate, Ref: ref, After: hash, Date: now})
+			case before != hash:
+				state.Events = append(state.Events, refEvent{Type: refEventPush, Ref: ref, Before: before, After: hash, Date: now})
+			}
+		}
+		for ref, hash := range state.Refs {
+			if _, ok := current[ref]; !ok {
+				state.Events = append(state.Events, refEvent{Type: refEventDelete, Ref: ref, Before: hash, Date: now})
+			}
+		}
+	}
+	state.Refs = current
+
+	events := make([]refEvent, 0, len(state.Events))
+	for _, e := range state.Events {
+		if now.Sub(e.Date) < refEventsRetention {
+			events = append(events, e)
+		}
+	}
+	state.Events = events
+	if err := g.consumer.cache.SetWithDuration(key, state, refEventsRetention); err != nil {
+		log.Error(ctx, "cannot set in cache %s: %v", key, err)
+	}
+
+	var res []interface{}
+	for _, e := range state.Events {
+		if e.Date.After(dateRef) {
+			res = append(res, e)
+		}
+	}
+	return res, pollingInterval, nil
+}
+
+// lastRefEvents returns the last event of each branch
+func lastRefEvents(iEvents []interface{}) (map[string]refEvent, error) {
+	res := make(map[string]refEvent)
+	for _, i := range iEvents {
+		// Events may have been serialized between the calls
+		btes, err := json.Marshal(i)
+		if err != nil {
+			return nil, sdk.WithStack(err)
+		}
+		var e refEvent
+		if err := sdk.JSONUnmarshal(btes, &e); err != nil {
+			return nil, err
+		}
+		if l, ok := res[e.Ref]; !ok || !e.Date.Before(l.Date) {
+			res[e.Ref] = e
+		}
+	}
+	return res, nil
+}
+
+func (g *gitClient) toPushEvent(ctx context.Context, repo string, e refEvent) (sdk.VCSPushEvent, error) {
+	c, err := g.Commit(ctx, repo, e.After)
+	if err != nil {
+		return sdk.VCSPushEvent{}, err
+	}
+	cloneURL, err := g.repoURL(repo)
+	if err != nil {
+		return sdk.VCSPushEvent{}, err
+	}
+	return sdk.VCSPushEvent{
+		Repo: repo,
+		Branch: sdk.VCSBranch{
+			ID:           e.Ref,
+			DisplayID:    strings.TrimPrefix(e.Ref, "refs/heads/"),
+			LatestCommit: e.After,
+		},
+		Commit:   c,
+		CloneURL: cloneURL,
+	}, nil
+}
+
+// PushEvents returns the last commit of each created or updated branch
+func (g *gitClient) PushEvents(ctx context.Context, repo string, iEvents []interface{}) ([]sdk.VCSPushEvent, error) {
+	events, err := lastRefEvents(iEvents)
+	if err != nil {
+		return nil, err
+	}
+	res := []sdk.VCSPushEvent{}
+	for _, e := range events {
+		if e.Type == refEventDelete {
+			continue
+		}
+		pushEvent, err := g.toPushEvent(ctx, repo, e)
+		if err != nil {
+			log.Warn(ctx, "gitClient.PushEvents> unable to find commit %s in %s: %v", e.After, repo, err)
+			continue
+		}
+		res = append(res, pushEvent)
+	}
+	return res, nil
+}
+
+// CreateEvents returns the created branches
+func (g *gitClient) CreateEvents(ctx context.Context, repo string, iEvents []interface{}) ([]sdk.VCSCreateEvent, error) {
+	events, err := lastRefEvents(iEvents)
+	if err != nil {
+		return nil, err
+	}
+	res := []sdk.VCSCreateEvent{}
+	for _, e := range events {
+		if e.Type != refEventCreate {
+			continue
+		}
+		pushEvent, err := g.toPushEvent(ctx, repo, e)
+		if err != nil {
+			log.Warn(ctx, "gitClient.CreateEvents> unable to find commit %s in %s: %v", e.After, repo, err)
+			continue
+		}
+		res = append(res, sdk.VCSCreateEvent(pushEvent))
+	}
+	return res, nil
+}
+
+// DeleteEvents returns the deleted branches
+func (g *gitClient) DeleteEvents(ctx context.Context, repo string, iEvents []interface{}) ([]sdk.VCSDeleteEvent, error) {
+	events, err := lastRefEvents(iEvents)
+	if err != nil {
+		return nil, err
+	}
+	res := []sdk.VCSDeleteEvent{}
+	for _, e := range events {
+		if e.Type != refEventDelete {
+			continue
+		}
+		res = append(res, sdk.VCSDeleteEvent{
+			Branch: sdk.VCSBranch{
+				ID:           e.Ref,
+				DisplayID:    strings.TrimPrefix(e.Ref, "refs/heads/"),
+				LatestCommit: e.Before,
+			},
+		})
+	}
+	return res, nil
+}
+
+// PullRequestEvents returns no event, pull requests are not supported by git vcs
+func (g *gitClient) PullRequestEvents(context.Context, string, []interface{}) ([]sdk.VCSPullRequestEvent, error) {
+	return []sdk.VCSPullRequestEvent{}, nil
+}
@@ -0,0 +1,113 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+var archiveContentTypes = map[string]string{
+	"tar":    "application/x-tar",
+	"tar.gz": "application/gzip",
+	"tgz":    "application/gzip",
+	"zip":    "application/zip",
+}
+
+// treeish returns the git object name of a path at given commit
+func treeish(commit, filePath string) string {
+	return commit + ":" + strings.Trim(path.Clean("/"+filePath), "/")
+}
+
+// ListContent returns the entries of a directory at given commit, an empty list if the directory doesn't exist
+func (g *gitClient) ListContent(ctx context.Context, repo string, commit, dir string) ([]sdk.VCSContent, error) {
+	if err := checkRevision(commit); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := g.git(ctx, repo, &out, "ls-tree", treeish(commit, dir)); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return []sdk.VCSContent{}, nil
+		}
+		return nil, err
+	}
+	res := make([]sdk.VCSContent, 0)
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		// <mode> SP <type> SP <object> TAB <file>
+		t := strings.SplitN(scanner.Text(), "\t", 2)
+		if len(t) != 2 {
+			continue
+		}
+		infos := strings.Fields(t[0])
+		if len(infos) != 3 {
+			continue
+		}
+		res = append(res, sdk.VCSContent{
+			Name:        t[1],
+			IsDirectory: infos[1] == "tree",
+			IsFile:      infos[1] == "blob",
+		})
+	}
+	return res, sdk.WithStack(scanner.Err())
+}
+
+// GetContent returns a file, base64 encoded, or a directory at given commit
+func (g *gitClient) GetContent(ctx context.Context, repo string, commit, filePath string) (sdk.VCSContent, error) {
+	if err := checkRevision(commit); err != nil {
+		return sdk.VCSContent{}, err
+	}
+	object := treeish(commit, filePath)
+	var objectType bytes.Buffer
+	if err := g.git(ctx, repo, &objectType, "cat-file", "-t", object); err != nil {
+		return sdk.VCSContent{}, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrNotFound, "file %s not found at %s", filePath, commit))
+	}
+	content := sdk.VCSContent{Name: path.Base(filePath)}
+	switch strings.TrimSpace(objectType.String()) {
+	case "tree":
+		content.IsDirectory = true
+	case "blob":
+		content.IsFile = true
+		var blob bytes.Buffer
+		if err := g.git(ctx, repo, &blob, "cat-file", "blob", object); err != nil {
+			return sdk.VCSContent{}, err
+		}
+		content.Content = base64.StdEncoding.EncodeToString(blob.Bytes())
+	}
+	return content, nil
+}
+
+// GetArchive returns an archive of a directory at given commit, in one of the formats supported by git archive
+func (g *gitClient) GetArchive(ctx context.Context, repo string, dir string, format string, commit string) (io.Reader, http.Header, error) {
+	contentType, ok := archiveContentTypes[format]
+	if !ok {
+		return nil, nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unsupported archive format %q", format)
+	}
+	if commit == "" {
+		refs, err := g.lsRemote(ctx, repo)
+		if err != nil {
+			return nil, nil, err
+		}
+		commit = "refs/heads/" + refs.Head
+	}
+	if err := checkRevision(commit); err != nil {
+		return nil, nil, err
+	}
+	args := []string{"archive", "--format=" + format, commit}
+	if d := strings.Trim(path.Clean("/"+dir), "/"); d != "" {
+		args = append(args, "--", d)
+	}
+	var out bytes.Buffer
+	if err := g.git(ctx, repo, &out, args...); err != nil {
+		return nil, nil, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrNotFound, "unable to get archive of %s at %s", dir, commit))
+	}
+	headers := http.Header{}
+	headers.Set("Content-Type", contentType)
+	return &out, headers, nil
+}
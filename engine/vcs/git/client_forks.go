@@ -0,0 +1,11 @@
+package git
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (g *gitClient) ListForks(ctx context.Context, repo string) ([]sdk.VCSRepo, error) {
+	return nil, errNotSupported("forks")
+}
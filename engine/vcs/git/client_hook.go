@@ -0,0 +1,25 @@
+package git
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Webhooks are not available on a plain git server, repositories are polled instead
+
+func (g *gitClient) CreateHook(ctx context.Context, repo string, hook *sdk.VCSHook) error {
+	return errNotSupported("webhooks")
+}
+
+func (g *gitClient) UpdateHook(ctx context.Context, repo string, hook *sdk.VCSHook) error {
+	return errNotSupported("webhooks")
+}
+
+func (g *gitClient) GetHook(ctx context.Context, repo, url string) (sdk.VCSHook, error) {
+	return sdk.VCSHook{}, errNotSupported("webhooks")
+}
+
+func (g *gitClient) DeleteHook(ctx context.Context, repo string, hook sdk.VCSHook) error {
+	return errNotSupported("webhooks")
+}
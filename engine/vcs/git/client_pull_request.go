@@ -0,0 +1,28 @@
+package git
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (g *gitClient) PullRequest(ctx context.Context, repo string, id string) (sdk.VCSPullRequest, error) {
+	return sdk.VCSPullRequest{}, errNotSupported("pull requests")
+}
+
+func (g *gitClient) PullRequests(ctx context.Context, repo string, opts sdk.VCSPullRequestOptions) ([]sdk.VCSPullRequest, error) {
+	return nil, errNotSupported("pull requests")
+}
+
+// PullRequestComment push a new comment on a pull request
+func (g *gitClient) PullRequestComment(ctx context.Context, repo string, prRequest sdk.VCSPullRequestCommentRequest) error {
+	return errNotSupported("pull requests")
+}
+
+func (g *gitClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
+	return sdk.VCSPullRequest{}, errNotSupported("pull requests")
+}
+
+func (g *gitClient) PullRequestMerge(ctx context.Context, repo string, id string, req sdk.VCSPullRequestMergeRequest) error {
+	return errNotSupported("pull requests")
+}
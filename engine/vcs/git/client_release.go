@@ -0,0 +1,16 @@
+package git
+
+import (
+	"context"
+	"io"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (g *gitClient) Release(ctx context.Context, repo, tagName, releaseTitle, releaseDescription string) (*sdk.VCSRelease, error) {
+	return nil, errNotSupported("releases")
+}
+
+func (g *gitClient) UploadReleaseFile(ctx context.Context, repo string, releaseName string, uploadURL string, artifactName string, r io.Reader, length int) error {
+	return errNotSupported("releases")
+}
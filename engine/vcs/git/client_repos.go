@@ -0,0 +1,39 @@
+package git
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Repos can't be listed on a plain git server, repositories have to be added by their name
+func (g *gitClient) Repos(ctx context.Context) ([]sdk.VCSRepo, error) {
+	return nil, errNotSupported("repositories listing")
+}
+
+// RepoByFullname checks that the repository is reachable and returns it
+func (g *gitClient) RepoByFullname(ctx context.Context, fullname string) (sdk.VCSRepo, error) {
+	if _, err := g.lsRemote(ctx, fullname); err != nil {
+		return sdk.VCSRepo{}, err
+	}
+	u, err := g.repoURL(fullname)
+	if err != nil {
+		return sdk.VCSRepo{}, err
+	}
+	repo := sdk.VCSRepo{
+		ID:       fullname,
+		Name:     repoSlug(fullname),
+		Slug:     repoSlug(fullname),
+		Fullname: fullname,
+	}
+	if isSSHURL(u) {
+		repo.SSHCloneURL = u
+	} else {
+		repo.HTTPCloneURL = u
+	}
+	return repo, nil
+}
+
+func (g *gitClient) GrantWritePermission(ctx context.Context, repo string) error {
+	return nil
+}
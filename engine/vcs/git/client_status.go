@@ -0,0 +1,20 @@
+package git
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// DEPRECATED VCS
+func (g *gitClient) IsDisableStatusDetails(ctx context.Context) bool {
+	return true
+}
+
+func (g *gitClient) SetStatus(ctx context.Context, event sdk.Event, disableStatusDetails bool) error {
+	return errNotSupported("commit statuses")
+}
+
+func (g *gitClient) ListStatuses(ctx context.Context, repo string, ref string) ([]sdk.VCSCommitStatus, error) {
+	return nil, errNotSupported("commit statuses")
+}
@@ -0,0 +1,29 @@
+package git
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Tags returns the tags of the repository, for an annotated tag the hash is the one of the tagged commit
+func (g *gitClient) Tags(ctx context.Context, fullname string) ([]sdk.VCSTag, error) {
+	refs, err := g.lsRemote(ctx, fullname)
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]sdk.VCSTag, 0, len(refs.Tags()))
+	for _, ref := range refs.Tags() {
+		tag := sdk.VCSTag{
+			Tag:  strings.TrimPrefix(ref.Name, "refs/tags/"),
+			Sha:  ref.Hash,
+			Hash: ref.Hash,
+		}
+		if ref.Peeled != "" {
+			tag.Hash = ref.Peeled
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
@@ -0,0 +1,115 @@
+package git
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/vcs"
+	"github.com/ovh/cds/sdk/vcs/git"
+)
+
+// gitClient is a plain git wrapper for CDS vcs. interface, backed by the git mirrors of the repositories service.
+// It runs in the vcs service like the other drivers because it answers the same synchronous calls (branches, commits,
+// files, events for the repository poller) behind the same routes and cache, but git is only run by the repositories
+// service that lists the references of the repositories and keeps their mirrors.
+type gitClient struct {
+	consumer   gitConsumer
+	username   string
+	token      string
+	privateKey string
+}
+
+// Mirrors reads remote repositories through a git mirror server.
+type Mirrors interface {
+	LsRemote(ctx context.Context, repo string, auth *git.AuthOpts) (git.RemoteRefs, error)
+	Git(ctx context.Context, repo string, auth *git.AuthOpts, stdout io.Writer, args ...string) error
+}
+
+// gitConsumer implements vcs.Server and it's used to instantiate a gitClient
+type gitConsumer struct {
+	URL     string `json:"url"`
+	cache   cache.Store
+	mirrors Mirrors
+}
+
+// New creates a new git Consumer
+func New(URL string, mirrors Mirrors, store cache.Store) sdk.VCSServer {
+	return &gitConsumer{
+		URL:     URL,
+		mirrors: mirrors,
+		cache:   store,
+	}
+}
+
+func errNotSupported(feature string) error {
+	return sdk.NewErrorFrom(sdk.ErrNotImplemented, "%s not supported by git vcs", feature)
+}
+
+// repoURL returns the URL of a repository, relative to the URL of the server
+func (g *gitClient) repoURL(repo string) (string, error) {
+	if repo == "" || strings.Contains(repo, "..") {
+		return "", sdk.NewErrorFrom(sdk.ErrRepoNotFound, "invalid repository name %q", repo)
+	}
+	u := g.consumer.URL + "/" + strings.TrimPrefix(repo, "/")
+	if err := git.CheckRemoteURL(u); err != nil {
+		return "", sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrRepoNotFound, "invalid repository name %q", repo))
+	}
+	return u, nil
+}
+
+func (g *gitClient) auth() *git.AuthOpts {
+	if g.username == "" && g.token == "" && g.privateKey == "" {
+		return nil
+	}
+	return &git.AuthOpts{
+		Username:   g.username,
+		Password:   g.token,
+		PrivateKey: vcs.SSHKey{Content: []byte(g.privateKey)},
+	}
+}
+
+// git runs a read only git command on the mirror of a repository, the mirror is updated by the repositories service if
+// its references differ from the remote ones
+func (g *gitClient) git(ctx context.Context, repo string, stdout io.Writer, args ...string) error {
+	u, err := g.repoURL(repo)
+	if err != nil {
+		return err
+	}
+	return g.consumer.mirrors.Git(ctx, u, g.auth(), stdout, args...)
+}
+
+func (g *gitClient) lsRemote(ctx context.Context, repo string) (git.RemoteRefs, error) {
+	u, err := g.repoURL(repo)
+	if err != nil {
+		return git.RemoteRefs{}, err
+	}
+	refs, err := g.consumer.mirrors.LsRemote(ctx, u, g.auth())
+	if err != nil {
+		return refs, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrRepoNotFound, "unable to list references of repository %s", repo))
+	}
+	return refs, nil
+}
+
+func (g *gitClient) GetAccessToken(_ context.Context) string {
+	return ""
+}
+
+func repoSlug(repo string) string {
+	return strings.TrimSuffix(path.Base(repo), ".git")
+}
+
+func isSSHURL(u string) bool {
+	if strings.HasPrefix(u, "ssh://") {
+		return true
+	}
+	if _, err := url.Parse(u); err != nil || !strings.Contains(u, "://") {
+		// scp-like syntax user@host:path
+		return strings.Contains(u, "@") && strings.Contains(u, ":")
+	}
+	return false
+}
@@ -0,0 +1,261 @@
+package git
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/vcs/git"
+)
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=John Doe", "GIT_AUTHOR_EMAIL=john.doe@example.com",
+		"GIT_COMMITTER_NAME=John Doe", "GIT_COMMITTER_EMAIL=john.doe@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return string(bytes.TrimSpace(out))
+}
+
+// testMirrors keeps the mirrors of the repositories in a local directory, like the repositories service does
+type testMirrors struct {
+	dir string
+}
+
+func (t testMirrors) mirror(repo string, auth *git.AuthOpts) git.Mirror {
+	return git.Mirror{URL: repo, Dir: filepath.Join(t.dir, git.MirrorUpstreamID(repo)), Auth: auth}
+}
+
+func (t testMirrors) LsRemote(ctx context.Context, repo string, auth *git.AuthOpts) (git.RemoteRefs, error) {
+	return t.mirror(repo, auth).LsRemote(ctx)
+}
+
+func (t testMirrors) Git(ctx context.Context, repo string, auth *git.AuthOpts, stdout io.Writer, args ...string) error {
+	if err := (git.MirrorCommand{Args: args}).Check(); err != nil {
+		return err
+	}
+	m := t.mirror(repo, auth)
+	refs, err := m.LsRemote(ctx)
+	if err != nil {
+		return sdk.NewErrorWithStack(err, sdk.ErrForbidden)
+	}
+	if _, err := m.FetchIfOutdated(ctx, refs); err != nil {
+		return err
+	}
+	var out bytes.Buffer
+	if err := m.Git(ctx, &out, args...); err != nil {
+		return sdk.NewErrorWithStack(err, sdk.ErrNotFound)
+	}
+	_, err = io.Copy(stdout, &out)
+	return err
+}
+
+// newTestClient creates a bare repository "team/project.git" with two commits on main, a feature branch and an
+// annotated tag, and returns a client on it
+func newTestClient(t *testing.T) (*gitClient, string, string) {
+	root := t.TempDir()
+	server := filepath.Join(root, "server")
+	require.NoError(t, os.MkdirAll(filepath.Join(server, "team"), os.FileMode(0755)))
+	runGit(t, root, "init", "--bare", "--quiet", "--initial-branch=main", filepath.Join(server, "team", "project.git"))
+
+	work := filepath.Join(root, "work")
+	runGit(t, root, "clone", "--quiet", filepath.Join(server, "team", "project.git"), work)
+	runGit(t, work, "checkout", "--quiet", "-b", "main")
+	require.NoError(t, os.MkdirAll(filepath.Join(work, ".cds"), os.FileMode(0755)))
+	require.NoError(t, os.WriteFile(filepath.Join(work, ".cds", "workflow.yml"), []byte("name: my-workflow\n"), os.FileMode(0644)))
+	runGit(t, work, "add", ".")
+	runGit(t, work, "commit", "--quiet", "-m", "first commit")
+	first := runGit(t, work, "rev-parse", "HEAD")
+	require.NoError(t, os.WriteFile(filepath.Join(work, "README.md"), []byte("# project\n"), os.FileMode(0644)))
+	runGit(t, work, "add", ".")
+	runGit(t, work, "commit", "--quiet", "-m", "second commit")
+	second := runGit(t, work, "rev-parse", "HEAD")
+	runGit(t, work, "tag", "-a", "v1.0.0", "-m", "release v1.0.0")
+	runGit(t, work, "push", "--quiet", "origin", "main", "v1.0.0")
+	runGit(t, work, "push", "--quiet", "origin", first+":refs/heads/feature")
+
+	// Local paths are not allowed as server url
+	_, err := New(server, nil, nil).GetAuthorizedClient(context.TODO(), sdk.VCSAuth{Type: sdk.VCSTypeGit, URL: server})
+	require.Error(t, err)
+
+	// Serve the repositories over TLS smart HTTP
+	gitPath, err := exec.LookPath("git")
+	require.NoError(t, err)
+	srv := httptest.NewTLSServer(&cgi.Handler{
+		Path: gitPath,
+		Args: []string{"http-backend"},
+		Env:  []string{"GIT_PROJECT_ROOT=" + server, "GIT_HTTP_EXPORT_ALL=1"},
+	})
+	t.Cleanup(srv.Close)
+	t.Setenv("GIT_SSL_NO_VERIFY", "1")
+
+	consumer := New(srv.URL, testMirrors{dir: filepath.Join(root, "mirrors")}, nil)
+	client, err := consumer.GetAuthorizedClient(context.TODO(), sdk.VCSAuth{Type: sdk.VCSTypeGit, URL: srv.URL})
+	require.NoError(t, err)
+	return client.(*gitClient), first, second
+}
+
+func TestBranchesAndTags(t *testing.T) {
+	client, first, second := newTestClient(t)
+	ctx := context.TODO()
+
+	repo, err := client.RepoByFullname(ctx, "team/project.git")
+	require.NoError(t, err)
+	require.Equal(t, "project", repo.Slug)
+
+	branches, err := client.Branches(ctx, "team/project.git", sdk.VCSBranchesFilter{})
+	require.NoError(t, err)
+	require.Len(t, branches, 2)
+	require.Equal(t, "feature", branches[0].DisplayID)
+	require.Equal(t, first, branches[0].LatestCommit)
+	require.False(t, branches[0].Default)
+	require.Equal(t, "main", branches[1].DisplayID)
+	require.Equal(t, "refs/heads/main", branches[1].ID)
+	require.Equal(t, second, branches[1].LatestCommit)
+	require.True(t, branches[1].Default)
+
+	b, err := client.Branch(ctx, "team/project.git", sdk.VCSBranchFilters{Default: true})
+	require.NoError(t, err)
+	require.Equal(t, "main", b.DisplayID)
+
+	_, err = client.Branch(ctx, "team/project.git", sdk.VCSBranchFilters{BranchName: "unknown"})
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNoBranch))
+
+	tags, err := client.Tags(ctx, "team/project.git")
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	require.Equal(t, "v1.0.0", tags[0].Tag)
+	require.Equal(t, second, tags[0].Hash)
+	require.NotEqual(t, second, tags[0].Sha)
+
+	_, err = client.Branches(ctx, "team/unknown.git", sdk.VCSBranchesFilter{})
+	require.True(t, sdk.ErrorIs(err, sdk.ErrRepoNotFound))
+}
+
+func TestCommits(t *testing.T) {
+	client, first, second := newTestClient(t)
+	ctx := context.TODO()
+
+	commits, err := client.Commits(ctx, "team/project.git", "main", "", "")
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	require.Equal(t, second, commits[0].Hash)
+	require.Equal(t, "second commit", commits[0].Message)
+	require.Equal(t, "John Doe", commits[0].Author.Name)
+	require.Equal(t, "john.doe@example.com", commits[0].Author.Email)
+	require.NotZero(t, commits[0].Timestamp)
+
+	commits, err = client.CommitsBetweenRefs(ctx, "team/project.git", first, second)
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	require.Equal(t, second, commits[0].Hash)
+
+	files, err := client.ChangedFiles(ctx, "team/project.git", first, second)
+	require.NoError(t, err)
+	require.Equal(t, []string{"README.md"}, files)
+	_, err = client.ChangedFiles(ctx, "team/project.git", "--output=/tmp/file", second)
+	require.True(t, sdk.ErrorIs(err, sdk.ErrWrongRequest))
+
+	c, err := client.Commit(ctx, "team/project.git", first)
+	require.NoError(t, err)
+	require.Equal(t, "first commit", c.Message)
+
+	_, err = client.Commit(ctx, "team/project.git", "--output=/tmp/file")
+	require.True(t, sdk.ErrorIs(err, sdk.ErrWrongRequest))
+}
+
+func TestFiles(t *testing.T) {
+	client, first, second := newTestClient(t)
+	ctx := context.TODO()
+
+	contents, err := client.ListContent(ctx, "team/project.git", second, "")
+	require.NoError(t, err)
+	require.Len(t, contents, 2)
+	require.Equal(t, ".cds", contents[0].Name)
+	require.True(t, contents[0].IsDirectory)
+	require.Equal(t, "README.md", contents[1].Name)
+	require.True(t, contents[1].IsFile)
+
+	contents, err = client.ListContent(ctx, "team/project.git", first, "unknown")
+	require.NoError(t, err)
+	require.Len(t, contents, 0)
+
+	content, err := client.GetContent(ctx, "team/project.git", first, ".cds/workflow.yml")
+	require.NoError(t, err)
+	require.True(t, content.IsFile)
+	btes, err := base64.StdEncoding.DecodeString(content.Content)
+	require.NoError(t, err)
+	require.Equal(t, "name: my-workflow\n", string(btes))
+
+	_, err = client.GetContent(ctx, "team/project.git", first, "README.md")
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+
+	reader, headers, err := client.GetArchive(ctx, "team/project.git", ".cds", "tar", second)
+	require.NoError(t, err)
+	require.Equal(t, "application/x-tar", headers.Get("Content-Type"))
+	var files []string
+	tr := tar.NewReader(reader)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if hdr.Typeflag == tar.TypeReg {
+			files = append(files, hdr.Name)
+		}
+	}
+	require.Equal(t, []string{".cds/workflow.yml"}, files)
+}
+
+func TestPushEvents(t *testing.T) {
+	client, first, second := newTestClient(t)
+	ctx := context.TODO()
+
+	now := time.Now()
+	events := []interface{}{
+		refEvent{Type: refEventPush, Ref: "refs/heads/main", Before: first, After: first, Date: now.Add(-time.Minute)},
+		// Events may have been serialized as maps by the api
+		map[string]interface{}{"type": refEventPush, "ref": "refs/heads/main", "before": first, "after": second, "date": now},
+		refEvent{Type: refEventCreate, Ref: "refs/heads/feature", After: first, Date: now},
+		refEvent{Type: refEventDelete, Ref: "refs/heads/old", Before: first, Date: now},
+	}
+
+	pushEvents, err := client.PushEvents(ctx, "team/project.git", events)
+	require.NoError(t, err)
+	require.Len(t, pushEvents, 2)
+	byBranch := map[string]sdk.VCSPushEvent{}
+	for _, e := range pushEvents {
+		byBranch[e.Branch.DisplayID] = e
+	}
+	require.Equal(t, second, byBranch["main"].Commit.Hash)
+	require.Equal(t, first, byBranch["feature"].Commit.Hash)
+
+	createEvents, err := client.CreateEvents(ctx, "team/project.git", events)
+	require.NoError(t, err)
+	require.Len(t, createEvents, 1)
+	require.Equal(t, "feature", createEvents[0].Branch.DisplayID)
+
+	deleteEvents, err := client.DeleteEvents(ctx, "team/project.git", events)
+	require.NoError(t, err)
+	require.Len(t, deleteEvents, 1)
+	require.Equal(t, "old", deleteEvents[0].Branch.DisplayID)
+
+	_, err = client.PullRequests(ctx, "team/project.git", sdk.VCSPullRequestOptions{})
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotImplemented))
+}
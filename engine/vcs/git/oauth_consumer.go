@@ -0,0 +1,31 @@
+package git
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// AuthorizeRedirect returns the request token, the Authorize URL
+func (g *gitConsumer) AuthorizeRedirect(_ context.Context) (string, string, error) {
+	return "", "", errNotSupported("oauth")
+}
+
+// AuthorizeToken returns the authorized token (and its secret)
+// from the request token and the verifier got on authorize url
+func (g *gitConsumer) AuthorizeToken(_ context.Context, token, verifier string) (string, string, error) {
+	return "", "", errNotSupported("oauth")
+}
+
+// GetAuthorizedClient returns an authorized client
+func (g *gitConsumer) GetAuthorizedClient(_ context.Context, vcsAuth sdk.VCSAuth) (sdk.VCSAuthorizedClient, error) {
+	if err := sdk.CheckGitServerURL(g.URL); err != nil {
+		return nil, err
+	}
+	return &gitClient{
+		consumer:   *g,
+		username:   vcsAuth.Username,
+		token:      vcsAuth.Token,
+		privateKey: vcsAuth.SSHPrivateKey,
+	}, nil
+}
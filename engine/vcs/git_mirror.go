@@ -0,0 +1,85 @@
+package vcs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/vcs/git"
+)
+
+// gitMirrorAccessRenewal is the delay after which the mirror access of the service is renewed, the access is signed
+// by the API for one hour and it only contains the git VCS servers known when it was signed.
+const gitMirrorAccessRenewal = 30 * time.Minute
+
+// gitMirrors reads the repositories of git VCS servers through the git mirrors of the repositories service.
+type gitMirrors struct {
+	s *Service
+}
+
+// client returns a client of the git mirrors with the mirror access of the service, renewed if expired or if forced.
+func (m *gitMirrors) client(ctx context.Context, renew bool) (git.MirrorClient, error) {
+	m.s.gitMirror.Lock()
+	defer m.s.gitMirror.Unlock()
+
+	if renew || m.s.gitMirror.access == "" || time.Now().After(m.s.gitMirror.expire) {
+		cfg, err := m.s.Client.ConfigRepositories()
+		if err != nil {
+			return git.MirrorClient{}, sdk.WrapError(err, "unable to get repositories configuration")
+		}
+		if cfg.MirrorURL == "" {
+			return git.MirrorClient{}, sdk.NewErrorFrom(sdk.ErrNotImplemented, "git mirrors of the repositories service are disabled")
+		}
+		token, err := m.s.Client.ServiceGitMirrorAccess(ctx)
+		if err != nil {
+			return git.MirrorClient{}, sdk.WrapError(err, "unable to get git mirror access")
+		}
+		m.s.gitMirror.url = cfg.MirrorURL
+		m.s.gitMirror.access = token.Token
+		m.s.gitMirror.expire = time.Now().Add(gitMirrorAccessRenewal)
+	}
+
+	return git.MirrorClient{
+		URL: m.s.gitMirror.url,
+		Headers: http.Header{
+			"Authorization":        []string{m.s.Client.GitMirrorAuthorization()},
+			git.HeaderMirrorAccess: []string{m.s.gitMirror.access},
+		},
+	}, nil
+}
+
+// do calls the git mirrors, the call is retried once with a renewed access if it is forbidden, as the repository may
+// belong to a git VCS server created after the access was signed.
+func (m *gitMirrors) do(ctx context.Context, f func(c git.MirrorClient) error) error {
+	c, err := m.client(ctx, false)
+	if err != nil {
+		return err
+	}
+	err = f(c)
+	if !sdk.ErrorIs(err, sdk.ErrForbidden) {
+		return err
+	}
+	c, err = m.client(ctx, true)
+	if err != nil {
+		return err
+	}
+	return f(c)
+}
+
+func (m *gitMirrors) LsRemote(ctx context.Context, repo string, auth *git.AuthOpts) (git.RemoteRefs, error) {
+	var refs git.RemoteRefs
+	err := m.do(ctx, func(c git.MirrorClient) error {
+		var err error
+		refs, err = c.LsRemote(ctx, repo, auth)
+		return err
+	})
+	return refs, err
+}
+
+func (m *gitMirrors) Git(ctx context.Context, repo string, auth *git.AuthOpts, stdout io.Writer, args ...string) error {
+	return m.do(ctx, func(c git.MirrorClient) error {
+		return c.Git(ctx, repo, auth, stdout, args...)
+	})
+}
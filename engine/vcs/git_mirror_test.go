@@ -0,0 +1,53 @@
+package vcs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient/mock_cdsclient"
+	"github.com/ovh/cds/sdk/vcs/git"
+)
+
+func TestGitMirrorsRenewForbiddenAccess(t *testing.T) {
+	upstream := "ssh://git@git.example.com/team/project.git"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/git/"+git.MirrorUpstreamID(upstream)+"/refs", r.URL.Path)
+		require.Equal(t, "Bearer my-session", r.Header.Get("Authorization"))
+		if r.Header.Get(git.HeaderMirrorAccess) != "new-access" {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(sdk.ErrForbidden)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(git.RemoteRefs{Head: "main", Refs: []git.RemoteRef{{Name: "refs/heads/main", Hash: "abcdef"}}})
+	}))
+	defer srv.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock_cdsclient.NewMockInterface(ctrl)
+	client.EXPECT().ConfigRepositories().Return(sdk.RepositoriesConfig{MirrorURL: srv.URL}, nil).Times(2)
+	gomock.InOrder(
+		client.EXPECT().ServiceGitMirrorAccess(gomock.Any()).Return(sdk.GitMirrorAccessToken{Token: "old-access"}, nil),
+		client.EXPECT().ServiceGitMirrorAccess(gomock.Any()).Return(sdk.GitMirrorAccessToken{Token: "new-access"}, nil),
+	)
+	client.EXPECT().GitMirrorAuthorization().Return("Bearer my-session").AnyTimes()
+
+	s := new(Service)
+	s.Client = client
+	refs, err := (&gitMirrors{s: s}).LsRemote(context.TODO(), upstream, nil)
+	require.NoError(t, err)
+	require.Equal(t, "main", refs.Head)
+	require.Equal(t, "abcdef", refs.Refs[0].Hash)
+
+	// The renewed access is kept for the next calls
+	refs, err = (&gitMirrors{s: s}).LsRemote(context.TODO(), upstream, nil)
+	require.NoError(t, err)
+	require.Len(t, refs.Refs, 1)
+}
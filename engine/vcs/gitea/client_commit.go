@@ -27,8 +27,55 @@ func (g *giteaClient) Commit(_ context.Context, repo, hash string) (sdk.VCSCommi
 	return g.toVCSCommit(giteaCommit), nil
 }
 
-func (g *giteaClient) CommitsBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSCommit, error) {
-	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+// commitsBetweenRefsMaxPages bounds the history walked from head when looking for base.
+const commitsBetweenRefsMaxPages = 20
+
+// CommitsBetweenRefs returns the commits reachable from head down to base excluded, oldest first. The version of the
+// Gitea API used has no compare endpoint so the history of head is walked until the commit of base is found.
+func (g *giteaClient) CommitsBetweenRefs(ctx context.Context, fullname, base, head string) ([]sdk.VCSCommit, error) {
+	owner, repo, err := getRepo(fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	baseCommits, _, err := g.client.ListRepoCommits(owner, repo, gg.ListCommitOptions{
+		ListOptions: gg.ListOptions{Page: 1, PageSize: 1},
+		SHA:         base,
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to get commit of %s on repository %s", base, fullname)
+	}
+	if len(baseCommits) == 0 {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "commit %s not found", base)
+	}
+	baseHash := baseCommits[0].SHA
+
+	var commits []sdk.VCSCommit
+	for page := 1; page <= commitsBetweenRefsMaxPages; page++ {
+		cs, _, err := g.client.ListRepoCommits(owner, repo, gg.ListCommitOptions{
+			ListOptions: gg.ListOptions{Page: page, PageSize: 50},
+			SHA:         head,
+		})
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to list commits of %s on repository %s", head, fullname)
+		}
+		for _, c := range cs {
+			if c.SHA == baseHash {
+				for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+					commits[i], commits[j] = commits[j], commits[i]
+				}
+				return commits, nil
+			}
+			if c.RepoCommit == nil {
+				continue
+			}
+			commits = append(commits, g.toVCSCommit(c))
+		}
+		if len(cs) < 50 {
+			break
+		}
+	}
+	return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "commit %s is not an ancestor of %s", base, head)
 }
 
 func (g *giteaClient) toVCSCommit(commit *gg.Commit) sdk.VCSCommit {
@@ -63,3 +110,7 @@ func (g *giteaClient) toVCSCommit(commit *gg.Commit) sdk.VCSCommit {
 	}
 	return vcsCommit
 }
+
+func (g *giteaClient) ChangedFiles(ctx context.Context, repo, base, head string) ([]string, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
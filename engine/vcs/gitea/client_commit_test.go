@@ -0,0 +1,51 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gg "code.gitea.io/sdk/gitea"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitsBetweenRefs(t *testing.T) {
+	history := []string{"c4", "c3", "base", "c1"}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/version":
+			_ = json.NewEncoder(w).Encode(map[string]string{"version": "1.17.0"})
+		case "/api/v1/repos/owner/repo/commits":
+			var commits []gg.Commit
+			switch r.URL.Query().Get("sha") {
+			case "v1.0.0":
+				commits = append(commits, gg.Commit{CommitMeta: &gg.CommitMeta{SHA: "base"}, RepoCommit: &gg.RepoCommit{}})
+			case "v1.1.0":
+				if r.URL.Query().Get("page") == "1" {
+					for _, sha := range history {
+						commits = append(commits, gg.Commit{CommitMeta: &gg.CommitMeta{SHA: sha}, RepoCommit: &gg.RepoCommit{Message: "commit " + sha}})
+					}
+				}
+			}
+			_ = json.NewEncoder(w).Encode(commits)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := gg.NewClient(srv.URL)
+	require.NoError(t, err)
+	client := &giteaClient{client: c}
+
+	commits, err := client.CommitsBetweenRefs(context.TODO(), "owner/repo", "v1.0.0", "v1.1.0")
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	require.Equal(t, "c3", commits[0].Hash)
+	require.Equal(t, "c4", commits[1].Hash)
+
+	_, err = client.CommitsBetweenRefs(context.TODO(), "owner/repo", "v1.1.0", "v1.0.0")
+	require.Error(t, err)
+}
@@ -0,0 +1,15 @@
+package gitea
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (g *giteaClient) CreateDeployKey(ctx context.Context, repo string, key sdk.VCSDeployKey) (sdk.VCSDeployKey, error) {
+	return key, sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+func (g *giteaClient) DeleteDeployKey(ctx context.Context, repo string, key sdk.VCSDeployKey) error {
+	return sdk.WithStack(sdk.ErrNotImplemented)
+}
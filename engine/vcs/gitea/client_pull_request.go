@@ -21,3 +21,7 @@ func (g *giteaClient) PullRequestComment(ctx context.Context, repo string, prReq
 func (g *giteaClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
 	return sdk.VCSPullRequest{}, sdk.WithStack(sdk.ErrNotImplemented)
 }
+
+func (g *giteaClient) PullRequestMerge(ctx context.Context, repo string, id string, req sdk.VCSPullRequestMergeRequest) error {
+	return sdk.WithStack(sdk.ErrNotImplemented)
+}
@@ -3,13 +3,46 @@ package gitea
 import (
 	"context"
 	"io"
+	"strconv"
+
+	"code.gitea.io/sdk/gitea"
 
 	"github.com/ovh/cds/sdk"
 )
 
-func (g *giteaClient) Release(ctx context.Context, repo, tagName, releaseTitle, releaseDescription string) (*sdk.VCSRelease, error) {
-	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+// Release creates a release on the given tag
+func (g *giteaClient) Release(ctx context.Context, fullname, tagName, releaseTitle, releaseDescription string) (*sdk.VCSRelease, error) {
+	owner, repo, err := getRepo(fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	release, _, err := g.client.CreateRelease(owner, repo, gitea.CreateReleaseOption{
+		TagName: tagName,
+		Title:   releaseTitle,
+		Note:    releaseDescription,
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to create release %s on repository %s", tagName, fullname)
+	}
+
+	return &sdk.VCSRelease{ID: release.ID}, nil
 }
-func (g *giteaClient) UploadReleaseFile(ctx context.Context, repo string, releaseName string, uploadURL string, artifactName string, r io.Reader, fileLength int) error {
-	return sdk.WithStack(sdk.ErrNotImplemented)
+
+// UploadReleaseFile attaches a file to the release, releaseName is the ID of the release returned by Release
+func (g *giteaClient) UploadReleaseFile(ctx context.Context, fullname string, releaseName string, _ string, artifactName string, r io.Reader, _ int) error {
+	owner, repo, err := getRepo(fullname)
+	if err != nil {
+		return err
+	}
+
+	releaseID, err := strconv.ParseInt(releaseName, 10, 64)
+	if err != nil {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid release id %q", releaseName)
+	}
+
+	if _, _, err := g.client.CreateReleaseAttachment(owner, repo, releaseID, r, artifactName); err != nil {
+		return sdk.WrapError(err, "unable to upload file %s on release %s", artifactName, releaseName)
+	}
+	return nil
 }
@@ -2,10 +2,38 @@ package gitea
 
 import (
 	"context"
+
+	"code.gitea.io/sdk/gitea"
+
 	"github.com/ovh/cds/sdk"
 )
 
 // Tags retrieve tags
 func (g *giteaClient) Tags(ctx context.Context, fullname string) ([]sdk.VCSTag, error) {
-	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+	owner, repo, err := getRepo(fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, _, err := g.client.ListRepoTags(owner, repo, gitea.ListRepoTagsOptions{ListOptions: gitea.ListOptions{
+		Page:     -1,
+		PageSize: 1000,
+	}})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to list tags of repository %s", fullname)
+	}
+
+	vcsTags := make([]sdk.VCSTag, 0, len(tags))
+	for _, t := range tags {
+		vcsTag := sdk.VCSTag{
+			Tag:     t.Name,
+			Sha:     t.ID,
+			Message: t.Message,
+		}
+		if t.Commit != nil {
+			vcsTag.Hash = t.Commit.SHA
+		}
+		vcsTags = append(vcsTags, vcsTag)
+	}
+	return vcsTags, nil
 }
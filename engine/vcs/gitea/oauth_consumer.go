@@ -2,9 +2,11 @@ package gitea
 
 import (
 	"context"
+	"net/http"
 
 	gg "code.gitea.io/sdk/gitea"
 
+	"github.com/ovh/cds/engine/vcs/vcshttp"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -20,8 +22,8 @@ func (g *giteaConsumer) AuthorizeToken(_ context.Context, token, verifier string
 }
 
 // GetAuthorizedClient returns an authorized client
-func (g *giteaConsumer) GetAuthorizedClient(_ context.Context, vcsAuth sdk.VCSAuth) (sdk.VCSAuthorizedClient, error) {
-	client, err := gg.NewClient(g.URL, gg.SetBasicAuth(g.username, g.token))
+func (g *giteaConsumer) GetAuthorizedClient(ctx context.Context, vcsAuth sdk.VCSAuth) (sdk.VCSAuthorizedClient, error) {
+	client, err := gg.NewClient(g.URL, gg.SetBasicAuth(g.username, g.token), gg.SetHTTPClient(vcshttp.NewClient(ctx, &http.Client{})))
 	if err != nil {
 		return nil, sdk.WithStack(err)
 	}
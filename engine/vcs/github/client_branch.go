@@ -3,11 +3,9 @@ package github
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/rockbears/log"
 
-	"github.com/ovh/cds/engine/cache"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -19,8 +17,6 @@ func (g *githubClient) Branches(ctx context.Context, fullname string, filters sd
 	if err != nil {
 		return nil, err
 	}
-	var noEtag bool
-	var attempt int
 
 	var nextPage = "/repos/" + fullname + "/branches"
 	for nextPage != "" {
@@ -31,15 +27,7 @@ func (g *githubClient) Branches(ctx context.Context, fullname string, filters sd
 			break
 		}
 
-		var opt getArgFunc
-		if noEtag {
-			opt = withoutETag
-		} else {
-			opt = withETag
-		}
-
-		attempt++
-		status, body, headers, err := g.get(ctx, nextPage, opt)
+		status, body, headers, err := g.get(ctx, nextPage)
 		if err != nil {
 			log.Warn(ctx, "githubClient.Branches> Error %s", err)
 			return nil, err
@@ -49,38 +37,15 @@ func (g *githubClient) Branches(ctx context.Context, fullname string, filters sd
 		}
 		nextBranches := []Branch{}
 
-		//Github may return 304 status because we are using conditional request with ETag based headers
-		if status == http.StatusNotModified {
-			//If repos aren't updated, lets get them from cache
-			k := cache.Key("vcs", "github", "branches", sdk.Hash512(g.OAuthToken+g.username), "/repos/"+fullname+"/branches")
-			_, err := g.Cache.Get(k, &branches)
-			if err != nil {
-				log.Error(ctx, "cannot get from cache %s: %v", k, err)
-			}
-			if len(branches) != 0 || attempt > 5 {
-				//We found branches, let's exit the loop
-				break
-			}
-			//If we did not found any branch in cache, let's retry (same nextPage) without etag
-			noEtag = true
-			continue
-		} else {
-			if err := sdk.JSONUnmarshal(body, &nextBranches); err != nil {
-				log.Warn(ctx, "githubClient.Branches> Unable to parse github branches: %s", err)
-				return nil, err
-			}
+		if err := sdk.JSONUnmarshal(body, &nextBranches); err != nil {
+			log.Warn(ctx, "githubClient.Branches> Unable to parse github branches: %s", err)
+			return nil, err
 		}
 
 		branches = append(branches, nextBranches...)
 		nextPage = getNextPage(headers)
 	}
 
-	//Put the body on cache for one hour and one minute
-	k := cache.Key("vcs", "github", "branches", sdk.Hash512(g.OAuthToken+g.username), "/repos/"+fullname+"/branches")
-	if err := g.Cache.SetWithTTL(k, branches, 61*60); err != nil {
-		log.Error(ctx, "cannot SetWithTTL: %s: %v", k, err)
-	}
-
 	branchesResult := []sdk.VCSBranch{}
 	for _, b := range branches {
 		branch := sdk.VCSBranch{
@@ -108,7 +73,6 @@ func (g *githubClient) Branch(ctx context.Context, fullname string, filters sdk.
 		filters.BranchName = repo.DefaultBranch
 	}
 
-	cacheBranchKey := cache.Key("vcs", "github", "branches", sdk.Hash512(g.OAuthToken+g.username), "/repos/"+fullname+"/branch/"+filters.BranchName)
 	repo, err := g.repoByFullname(ctx, fullname)
 	if err != nil {
 		return nil, err
@@ -117,53 +81,26 @@ func (g *githubClient) Branch(ctx context.Context, fullname string, filters sdk.
 	url := "/repos/" + fullname + "/branches/" + filters.BranchName
 	status, body, _, err := g.get(ctx, url)
 	if err != nil {
-		if err := g.Cache.Delete(cacheBranchKey); err != nil {
-			log.Error(ctx, "githubClient.Branch> unable to delete cache key %v: %v", cacheBranchKey, err)
-		}
 		return nil, err
 	}
 	if status == 404 {
 		return nil, sdk.WithStack(sdk.ErrNoBranch)
 	}
 	if status >= 400 {
-		if err := g.Cache.Delete(cacheBranchKey); err != nil {
-			log.Error(ctx, "githubClient.Branch> unable to delete cache key %v: %v", cacheBranchKey, err)
-		}
 		return nil, sdk.NewError(sdk.ErrUnknownError, errorAPI(body))
 	}
 
-	//Github may return 304 status because we are using conditional request with ETag based headers
 	var branch Branch
-	if status == http.StatusNotModified {
-		//If repos aren't updated, lets get them from cache
-		find, err := g.Cache.Get(cacheBranchKey, &branch)
-		if err != nil {
-			log.Error(ctx, "cannot get from cache %s: %v", cacheBranchKey, err)
-		}
-		if !find {
-			log.Error(ctx, "Unable to get branch (%s) from the cache", cacheBranchKey)
-		}
-	} else {
-		if err := sdk.JSONUnmarshal(body, &branch); err != nil {
-			log.Warn(ctx, "githubClient.Branch> Unable to parse github branch: %s", err)
-			return nil, err
-		}
+	if err := sdk.JSONUnmarshal(body, &branch); err != nil {
+		log.Warn(ctx, "githubClient.Branch> Unable to parse github branch: %s", err)
+		return nil, err
 	}
 
 	if branch.Name == "" {
 		log.Warn(ctx, "githubClient.Branch> Cannot find branch %v: %s", branch, filters.BranchName)
-		if err := g.Cache.Delete(cacheBranchKey); err != nil {
-			log.Error(ctx, "githubClient.Branch> unable to delete cache key %v: %v", cacheBranchKey, err)
-		}
 		return nil, fmt.Errorf("githubClient.Branch > Cannot find branch %s", filters.BranchName)
 	}
 
-	//Put the body on cache for one hour and one minute
-	k := cache.Key("vcs", "github", "branches", sdk.Hash512(g.OAuthToken+g.username), "/repos/"+fullname+"/branch/"+filters.BranchName)
-	if err := g.Cache.SetWithTTL(k, branch, 61*60); err != nil {
-		log.Error(ctx, "cannot SetWithTTL: %s: %v", k, err)
-	}
-
 	branchResult := &sdk.VCSBranch{
 		DisplayID:    branch.Name,
 		ID:           branch.Name,
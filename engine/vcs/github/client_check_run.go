@@ -0,0 +1,88 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// The checks API accepts at most 50 annotations per request
+const checkRunAnnotationsPerRequest = 50
+
+// createCheckRun publishes a completed check run with the report of a node run. Only GitHub Apps can create check
+// runs, the commit status remains the reference for other tokens.
+// https://docs.github.com/en/rest/checks/runs#create-a-check-run
+func (g *githubClient) createCheckRun(ctx context.Context, data statusData, checkRun sdk.VCSCheckRun) error {
+	cr := CheckRun{
+		Name:       data.context,
+		HeadSHA:    data.hash,
+		DetailsURL: data.urlPipeline,
+		Status:     "completed",
+		Conclusion: "success",
+		Output: CheckRunOutput{
+			Title:   checkRun.Title,
+			Summary: sdk.StringFirstN(checkRun.Summary, 65535),
+		},
+	}
+	if data.status != "success" {
+		cr.Conclusion = "failure"
+	}
+
+	annotations := make([]CheckRunAnnotation, 0, len(checkRun.Annotations))
+	for _, a := range checkRun.Annotations {
+		annotations = append(annotations, CheckRunAnnotation{
+			Path:            a.Path,
+			StartLine:       a.StartLine,
+			EndLine:         a.EndLine,
+			AnnotationLevel: a.Level,
+			Title:           a.Title,
+			Message:         a.Message,
+		})
+	}
+
+	path := fmt.Sprintf("/repos/%s/check-runs", data.repoFullName)
+	for i := 0; i == 0 || i < len(annotations); i += checkRunAnnotationsPerRequest {
+		end := i + checkRunAnnotationsPerRequest
+		if end > len(annotations) {
+			end = len(annotations)
+		}
+		cr.Output.Annotations = annotations[i:end]
+
+		b, err := json.Marshal(cr)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		var res *http.Response
+		if cr.ID == 0 {
+			res, err = g.post(ctx, path, "application/json", bytes.NewReader(b), nil, nil)
+		} else {
+			res, err = g.patch(ctx, fmt.Sprintf("%s/%d", path, cr.ID), "application/json", bytes.NewReader(b), nil)
+		}
+		if err != nil {
+			return sdk.WrapError(err, "unable to post check run")
+		}
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close() // nolint
+		if err != nil {
+			return sdk.WrapError(err, "unable to read body")
+		}
+		if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK {
+			return sdk.WithStack(fmt.Errorf("unable to create check run on github. Status code : %d - Body: %s", res.StatusCode, body))
+		}
+		if cr.ID == 0 {
+			var created CheckRun
+			if err := sdk.JSONUnmarshal(body, &created); err != nil {
+				return sdk.WrapError(err, "unable to unmarshal body")
+			}
+			cr.ID = created.ID
+			// Only the output is updated by next requests
+			cr = CheckRun{ID: cr.ID, Output: cr.Output}
+		}
+	}
+	return nil
+}
@@ -3,7 +3,6 @@ package github
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"net/url"
 	"reflect"
 	"strings"
@@ -238,23 +237,9 @@ func (g *githubClient) Commit(ctx context.Context, repo, hash string) (sdk.VCSCo
 	}
 	c := Commit{}
 
-	//Github may return 304 status because we are using conditional request with ETag based headers
-	if status == http.StatusNotModified {
-		//If repo isn't updated, lets get them from cache
-		k := cache.Key("vcs", "github", "commit", sdk.Hash512(g.OAuthToken+g.username), url)
-		if _, err := g.Cache.Get(k, &c); err != nil {
-			log.Error(ctx, "cannot get from cache %s: %v", k, err)
-		}
-	} else {
-		if err := sdk.JSONUnmarshal(body, &c); err != nil {
-			log.Warn(ctx, "githubClient.Commit> Unable to parse github commit: %s", err)
-			return sdk.VCSCommit{}, err
-		}
-		//Put the body on cache for one hour and one minute
-		k := cache.Key("vcs", "github", "commit", sdk.Hash512(g.OAuthToken+g.username), url)
-		if err := g.Cache.SetWithTTL(k, c, 61*60); err != nil {
-			log.Error(ctx, "cannot SetWithTTL: %s: %v", k, err)
-		}
+	if err := sdk.JSONUnmarshal(body, &c); err != nil {
+		log.Warn(ctx, "githubClient.Commit> Unable to parse github commit: %s", err)
+		return sdk.VCSCommit{}, err
 	}
 
 	commit := sdk.VCSCommit{
@@ -288,40 +273,58 @@ func (g *githubClient) CommitsBetweenRefs(ctx context.Context, repo, base, head
 	}
 
 	var diff DiffCommits
-	//Github may return 304 status because we are using conditional request with ETag based headers
-	if status == http.StatusNotModified {
-		//If repo isn't updated, lets get them from cache
-		k := cache.Key("vcs", "github", "commitdiff", sdk.Hash512(g.OAuthToken+g.username), url)
-		if _, err := g.Cache.Get(k, &commits); err != nil {
-			log.Error(ctx, "cannot get from cache %s: %v", k, err)
-		}
-	} else {
-		if err := sdk.JSONUnmarshal(body, &diff); err != nil {
-			log.Warn(ctx, "githubClient.CommitsBetweenRefs> Unable to parse github commit: %s", err)
-			return commits, err
-		}
+	if err := sdk.JSONUnmarshal(body, &diff); err != nil {
+		log.Warn(ctx, "githubClient.CommitsBetweenRefs> Unable to parse github commit: %s", err)
+		return commits, err
+	}
 
-		commits = make([]sdk.VCSCommit, len(diff.Commits))
-		for i, commit := range diff.Commits {
-			commits[i] = sdk.VCSCommit{
-				Timestamp: commit.Commit.Author.Date.Unix() * 1000,
-				Message:   commit.Commit.Message,
-				Hash:      commit.Sha,
-				Author: sdk.VCSAuthor{
-					DisplayName: commit.Commit.Author.Name,
-					Email:       commit.Commit.Author.Email,
-					Name:        commit.Author.Login,
-					Avatar:      commit.Author.AvatarURL,
-				},
-				URL: commit.HTMLURL,
-			}
-		}
-		//Put the body on cache for one hour and one minute
-		k := cache.Key("vcs", "github", "commitdiff", sdk.Hash512(g.OAuthToken+g.username), url)
-		if err := g.Cache.SetWithTTL(k, &commits, 61*60); err != nil {
-			log.Error(ctx, "cannot SetWithTTL: %s: %v", k, err)
+	commits = make([]sdk.VCSCommit, len(diff.Commits))
+	for i, commit := range diff.Commits {
+		commits[i] = sdk.VCSCommit{
+			Timestamp: commit.Commit.Author.Date.Unix() * 1000,
+			Message:   commit.Commit.Message,
+			Hash:      commit.Sha,
+			Author: sdk.VCSAuthor{
+				DisplayName: commit.Commit.Author.Name,
+				Email:       commit.Commit.Author.Email,
+				Name:        commit.Author.Login,
+				Avatar:      commit.Author.AvatarURL,
+			},
+			URL: commit.HTMLURL,
 		}
 	}
 
 	return commits, nil
 }
+
+// maxCompareFiles is the maximum number of files returned by the compare API, the list is truncated above.
+const maxCompareFiles = 300
+
+// ChangedFiles returns the paths of the files changed between base and head, renamed files are returned with their
+// previous and their new path.
+func (g *githubClient) ChangedFiles(ctx context.Context, repo, base, head string) ([]string, error) {
+	url := fmt.Sprintf("/repos/%s/compare/%s...%s", repo, base, head)
+	status, body, _, err := g.get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	if status >= 400 {
+		return nil, sdk.NewError(sdk.ErrRepoNotFound, errorAPI(body))
+	}
+
+	var diff DiffCommits
+	if err := sdk.JSONUnmarshal(body, &diff); err != nil {
+		return nil, sdk.WrapError(err, "unable to parse github compare")
+	}
+	if len(diff.Files) >= maxCompareFiles {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "too many files changed between %s and %s", base, head)
+	}
+	files := make([]string, 0, len(diff.Files))
+	for _, f := range diff.Files {
+		files = append(files, f.Filename)
+		if f.PreviousFilename != "" {
+			files = append(files, f.PreviousFilename)
+		}
+	}
+	return files, nil
+}
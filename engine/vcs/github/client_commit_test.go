@@ -1,10 +1,18 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
 )
 
 func Test_filterCommits(t *testing.T) {
@@ -44,6 +52,35 @@ func Test_filterCommits(t *testing.T) {
 
 }
 
+func TestChangedFiles(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "token my-token", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/repos/ovh/cds/compare/aaa...bbb":
+			fmt.Fprint(w, `{"files": [{"filename": "README.md"}, {"filename": "docs/new.md", "previous_filename": "docs/old.md"}]}`)
+		case "/repos/ovh/cds/compare/aaa...ccc":
+			fmt.Fprintf(w, `{"files": [%s{"filename": "README.md"}]}`, strings.Repeat(`{"filename": "README.md"},`, maxCompareFiles-1))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Not Found"}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := &githubClient{GitHubAPIURL: srv.URL, token: "my-token"}
+
+	files, err := client.ChangedFiles(context.TODO(), "ovh/cds", "aaa", "bbb")
+	require.NoError(t, err)
+	require.Equal(t, []string{"README.md", "docs/new.md", "docs/old.md"}, files)
+
+	// A truncated list of files is not returned
+	_, err = client.ChangedFiles(context.TODO(), "ovh/cds", "aaa", "ccc")
+	require.True(t, sdk.ErrorIs(err, sdk.ErrWrongRequest))
+
+	_, err = client.ChangedFiles(context.TODO(), "ovh/cds", "aaa", "ddd")
+	require.True(t, sdk.ErrorIs(err, sdk.ErrRepoNotFound))
+}
+
 func Test_findAncestors(t *testing.T) {
 	commits := []Commit{}
 	json.Unmarshal([]byte(data), &commits)
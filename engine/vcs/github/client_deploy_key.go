@@ -0,0 +1,72 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// DeployKey represents a github repository deploy key
+type DeployKey struct {
+	ID       int64  `json:"id,omitempty"`
+	Title    string `json:"title"`
+	Key      string `json:"key"`
+	ReadOnly bool   `json:"read_only"`
+}
+
+func (g *githubClient) CreateDeployKey(ctx context.Context, repo string, key sdk.VCSDeployKey) (sdk.VCSDeployKey, error) {
+	b, err := json.Marshal(DeployKey{Title: key.Title, Key: key.Key, ReadOnly: key.ReadOnly})
+	if err != nil {
+		return key, sdk.WithStack(err)
+	}
+	res, err := g.post(ctx, "/repos/"+repo+"/keys", "application/json", bytes.NewBuffer(b), nil, nil)
+	if err != nil {
+		return key, sdk.WrapError(err, "github.CreateDeployKey")
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return key, sdk.WithStack(err)
+	}
+	if res.StatusCode != http.StatusCreated {
+		return key, sdk.WithStack(fmt.Errorf("unable to create deploy key on github, status code: %d - %v", res.StatusCode, errorAPI(body)))
+	}
+	var dk DeployKey
+	if err := sdk.JSONUnmarshal(body, &dk); err != nil {
+		return key, sdk.WrapError(err, "cannot unmarshal response")
+	}
+	key.ID = strconv.FormatInt(dk.ID, 10)
+	return key, nil
+}
+
+func (g *githubClient) DeleteDeployKey(ctx context.Context, repo string, key sdk.VCSDeployKey) error {
+	if key.ID == "" {
+		status, body, _, err := g.get(ctx, "/repos/"+repo+"/keys", withoutETag)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		if status >= 400 {
+			return sdk.NewError(sdk.ErrUnknownError, errorAPI(body))
+		}
+		var keys []DeployKey
+		if err := sdk.JSONUnmarshal(body, &keys); err != nil {
+			return sdk.WrapError(err, "cannot unmarshal response")
+		}
+		for _, k := range keys {
+			if (sdk.VCSDeployKey{Key: k.Key}).Match(key.Key) {
+				key.ID = strconv.FormatInt(k.ID, 10)
+				break
+			}
+		}
+		if key.ID == "" {
+			return nil
+		}
+	}
+	return g.delete(ctx, "/repos/"+repo+"/keys/"+key.ID)
+}
@@ -19,7 +19,7 @@ var (
 	ErrNoNewEvents = fmt.Errorf("No new events")
 )
 
-//GetEvents calls Github et returns GithubEvents as []interface{}
+// GetEvents calls Github et returns GithubEvents as []interface{}
 func (g *githubClient) GetEvents(ctx context.Context, fullname string, dateRef time.Time) ([]interface{}, time.Duration, error) {
 	log.Debug(ctx, "githubClient.GetEvents> loading events for %s after %v", fullname, dateRef)
 	var events = []interface{}{}
@@ -38,10 +38,6 @@ func (g *githubClient) GetEvents(ctx context.Context, fullname string, dateRef t
 		return nil, interval, err
 	}
 
-	if status == http.StatusNotModified {
-		return nil, interval, ErrNoNewEvents
-	}
-
 	nextEvents := []Event{}
 	if err := sdk.JSONUnmarshal(body, &nextEvents); err != nil {
 		log.Warn(ctx, "githubClient.GetEvents> Unable to parse github events: %s", err)
@@ -101,7 +97,7 @@ func (g *githubClient) GetEvents(ctx context.Context, fullname string, dateRef t
 	return events, interval, nil
 }
 
-//PushEvents returns push events as commits
+// PushEvents returns push events as commits
 func (g *githubClient) PushEvents(ctx context.Context, fullname string, iEvents []interface{}) ([]sdk.VCSPushEvent, error) {
 	events := Events{}
 	//Cast all the events
@@ -160,7 +156,7 @@ func (g *githubClient) PushEvents(ctx context.Context, fullname string, iEvents
 	return res, nil
 }
 
-//CreateEvents checks create events from a event list
+// CreateEvents checks create events from a event list
 func (g *githubClient) CreateEvents(ctx context.Context, fullname string, iEvents []interface{}) ([]sdk.VCSCreateEvent, error) {
 	events := Events{}
 	//Cast all the events
@@ -206,7 +202,7 @@ func (g *githubClient) CreateEvents(ctx context.Context, fullname string, iEvent
 	return res, nil
 }
 
-//DeleteEvents checks delete events from a event list
+// DeleteEvents checks delete events from a event list
 func (g *githubClient) DeleteEvents(ctx context.Context, fullname string, iEvents []interface{}) ([]sdk.VCSDeleteEvent, error) {
 	events := Events{}
 	//Cast all the events
@@ -234,7 +230,7 @@ func (g *githubClient) DeleteEvents(ctx context.Context, fullname string, iEvent
 	return res, nil
 }
 
-//PullRequestEvents checks pull request events from a event list
+// PullRequestEvents checks pull request events from a event list
 func (g *githubClient) PullRequestEvents(ctx context.Context, fullname string, iEvents []interface{}) ([]sdk.VCSPullRequestEvent, error) {
 	events := Events{}
 	//Cast all the events
@@ -8,7 +8,6 @@ import (
 
 	"github.com/rockbears/log"
 
-	"github.com/ovh/cds/engine/cache"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -24,23 +23,9 @@ func (g *githubClient) ListContent(ctx context.Context, repo string, commit, dir
 	}
 	var c []Content
 
-	//Github may return 304 status because we are using conditional request with ETag based headers
-	if status == http.StatusNotModified {
-		//If repo isn't updated, lets get them from cache
-		k := cache.Key("vcs", "github", "content", sdk.Hash512(g.OAuthToken+g.username), url)
-		if _, err := g.Cache.Get(k, &c); err != nil {
-			log.Error(ctx, "cannot get from cache %s: %v", k, err)
-		}
-	} else {
-		if err := sdk.JSONUnmarshal(body, &c); err != nil {
-			log.Warn(ctx, "githubClient.ListContent> Unable to parse github content: %s", err)
-			return nil, err
-		}
-		//Put the body on cache for one hour and one minute
-		k := cache.Key("vcs", "github", "content", sdk.Hash512(g.OAuthToken+g.username), url)
-		if err := g.Cache.SetWithTTL(k, c, 61*60); err != nil {
-			log.Error(ctx, "cannot SetWithTTL: %s: %v", k, err)
-		}
+	if err := sdk.JSONUnmarshal(body, &c); err != nil {
+		log.Warn(ctx, "githubClient.ListContent> Unable to parse github content: %s", err)
+		return nil, err
 	}
 	contents := make([]sdk.VCSContent, 0, len(c))
 	for _, co := range c {
@@ -62,23 +47,9 @@ func (g *githubClient) GetContent(ctx context.Context, repo string, commit, file
 	}
 	var c Content
 
-	//Github may return 304 status because we are using conditional request with ETag based headers
-	if status == http.StatusNotModified {
-		//If repo isn't updated, lets get them from cache
-		k := cache.Key("vcs", "github", "content", sdk.Hash512(g.OAuthToken+g.username), url)
-		if _, err := g.Cache.Get(k, &c); err != nil {
-			log.Error(ctx, "cannot get from cache %s: %v", k, err)
-		}
-	} else {
-		if err := sdk.JSONUnmarshal(body, &c); err != nil {
-			log.Warn(ctx, "githubClient.ListContent> Unable to parse github content: %s", err)
-			return sdk.VCSContent{}, err
-		}
-		//Put the body on cache for one hour and one minute
-		k := cache.Key("vcs", "github", "content", sdk.Hash512(g.OAuthToken+g.username), url)
-		if err := g.Cache.SetWithTTL(k, c, 61*60); err != nil {
-			log.Error(ctx, "cannot SetWithTTL: %s: %v", k, err)
-		}
+	if err := sdk.JSONUnmarshal(body, &c); err != nil {
+		log.Warn(ctx, "githubClient.ListContent> Unable to parse github content: %s", err)
+		return sdk.VCSContent{}, err
 	}
 	return g.ToVCSContent(c), nil
 }
@@ -6,13 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/rockbears/log"
 
-	"github.com/ovh/cds/engine/cache"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -107,8 +105,6 @@ func (g *githubClient) UpdateHook(ctx context.Context, repo string, hook *sdk.VC
 
 func (g *githubClient) getHooks(ctx context.Context, fullname string) ([]Webhook, error) {
 	var webhooks = []Webhook{}
-	cacheKey := cache.Key("vcs", "github", "hooks", sdk.Hash512(g.OAuthToken+g.username), "/repos/"+fullname+"/hooks")
-	opts := []getArgFunc{withETag}
 
 	var nextPage = "/repos/" + fullname + "/hooks"
 	for nextPage != "" {
@@ -116,7 +112,7 @@ func (g *githubClient) getHooks(ctx context.Context, fullname string) ([]Webhook
 			break
 		}
 
-		status, body, headers, err := g.get(ctx, nextPage, opts...)
+		status, body, headers, err := g.get(ctx, nextPage)
 		if err != nil {
 			log.Warn(ctx, "githubClient.PullRequests> Error %s", err)
 			return nil, sdk.WithStack(err)
@@ -124,36 +120,14 @@ func (g *githubClient) getHooks(ctx context.Context, fullname string) ([]Webhook
 		if status >= 400 {
 			return nil, sdk.NewError(sdk.ErrUnknownError, errorAPI(body))
 		}
-		opts[0] = withETag
 		nextHooks := []Webhook{}
-
-		//Github may return 304 status because we are using conditional request with ETag based headers
-		if status == http.StatusNotModified {
-			//If repos aren't updated, lets get them from cache
-			find, err := g.Cache.Get(cacheKey, &webhooks)
-			if err != nil {
-				log.Error(ctx, "cannot get from cache %s: %v", cacheKey, err)
-			}
-			if !find {
-				opts[0] = withoutETag
-				log.Error(ctx, "Unable to get getHooks (%s) from the cache", strings.ReplaceAll(cacheKey, g.OAuthToken, ""))
-				continue
-			}
-			break
-		} else {
-			if err := sdk.JSONUnmarshal(body, &nextHooks); err != nil {
-				log.Warn(ctx, "githubClient.getHooks> Unable to parse github hooks: %s", err)
-				return nil, err
-			}
+		if err := sdk.JSONUnmarshal(body, &nextHooks); err != nil {
+			log.Warn(ctx, "githubClient.getHooks> Unable to parse github hooks: %s", err)
+			return nil, err
 		}
 		webhooks = append(webhooks, nextHooks...)
 		nextPage = getNextPage(headers)
 	}
-
-	//Put the body on cache for one hour and one minute
-	if err := g.Cache.SetWithTTL(cacheKey, webhooks, 61*60); err != nil {
-		log.Error(ctx, "cannot SetWithTTL: %s: %v", cacheKey, err)
-	}
 	return webhooks, nil
 }
 
@@ -182,10 +156,8 @@ func (g *githubClient) GetHook(ctx context.Context, fullname, webhookURL string)
 func (g *githubClient) getHookByID(ctx context.Context, fullname, id string) (Webhook, error) {
 	var webhook Webhook
 	url := "/repos/" + fullname + "/hooks/" + id
-	cacheKey := cache.Key("vcs", "github", "hooks", id, sdk.Hash512(g.OAuthToken+g.username), "/repos/"+fullname+"/hooks/"+id)
-	opts := []getArgFunc{withETag}
 
-	status, body, _, err := g.get(ctx, url, opts...)
+	status, body, _, err := g.get(ctx, url)
 	if err != nil {
 		log.Warn(ctx, "githubClient.PullRequests> Error %v", err)
 		return webhook, sdk.WithStack(err)
@@ -193,28 +165,9 @@ func (g *githubClient) getHookByID(ctx context.Context, fullname, id string) (We
 	if status >= 400 {
 		return webhook, sdk.NewError(sdk.ErrUnknownError, errorAPI(body))
 	}
-	opts[0] = withETag
-
-	//Github may return 304 status because we are using conditional request with ETag based headers
-	if status == http.StatusNotModified {
-		//If repos aren't updated, lets get them from cache
-		find, err := g.Cache.Get(cacheKey, &webhook)
-		if err != nil {
-			log.Error(ctx, "cannot get from cache %s: %v", cacheKey, err)
-		}
-		if !find {
-			return webhook, sdk.WithStack(fmt.Errorf("unable to get getHooks (%s) from the cache", strings.ReplaceAll(cacheKey, g.OAuthToken, "")))
-		}
-	} else {
-		if err := sdk.JSONUnmarshal(body, &webhook); err != nil {
-			log.Warn(ctx, "githubClient.getHookByID> Unable to parse github hook: %v", err)
-			return webhook, err
-		}
-	}
-
-	//Put the body on cache for one hour and one minute
-	if err := g.Cache.SetWithTTL(cacheKey, webhook, 61*60); err != nil {
-		log.Error(ctx, "cannot SetWithTTL: %s: %v", cacheKey, err)
+	if err := sdk.JSONUnmarshal(body, &webhook); err != nil {
+		log.Warn(ctx, "githubClient.getHookByID> Unable to parse github hook: %v", err)
+		return webhook, err
 	}
 	return webhook, nil
 }
@@ -8,74 +8,35 @@ import (
 	"io"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/rockbears/log"
 
-	"github.com/ovh/cds/engine/cache"
 	"github.com/ovh/cds/sdk"
 )
 
 func (g *githubClient) PullRequest(ctx context.Context, fullname string, id string) (sdk.VCSPullRequest, error) {
 	var pr PullRequest
-	cachePullRequestKey := cache.Key("vcs", "github", "pullrequests", sdk.Hash512(g.OAuthToken+g.username), fmt.Sprintf("/repos/%s/pulls/%s", fullname, id))
-	opts := []getArgFunc{withETag}
-
-	for {
-		url := fmt.Sprintf("/repos/%s/pulls/%s", fullname, id)
-
-		status, body, _, err := g.get(ctx, url, opts...)
-		if err != nil {
-			if err := g.Cache.Delete(cachePullRequestKey); err != nil {
-				log.Error(ctx, "githubclient.PullRequest > unable to delete cache key %v: %v", cachePullRequestKey, err)
-			}
-			return sdk.VCSPullRequest{}, err
-		}
-		if status >= 400 {
-			if err := g.Cache.Delete(cachePullRequestKey); err != nil {
-				log.Error(ctx, "githubclient.PullRequest > unable to delete cache key %v: %v", cachePullRequestKey, err)
-			}
-			if status > 500 {
-				return sdk.VCSPullRequest{}, sdk.NewError(sdk.ErrUnknownError, errorAPI(body))
-			} else {
-				return sdk.VCSPullRequest{}, sdk.NewError(sdk.ErrNotFound, errorAPI(body))
-			}
-
-		}
-
-		//Github may return 304 status because we are using conditional request with ETag based headers
-		if status == http.StatusNotModified {
-			//If repos aren't updated, lets get them from cache
-			find, err := g.Cache.Get(cachePullRequestKey, &pr)
-			if err != nil {
-				log.Error(ctx, "cannot get from cache %s: %v", cachePullRequestKey, err)
-			}
-			if !find {
-				opts = []getArgFunc{withoutETag}
-				log.Error(ctx, "Unable to get pullrequest (%s) from the cache", strings.ReplaceAll(cachePullRequestKey, g.OAuthToken, ""))
-				continue
-			}
+	url := fmt.Sprintf("/repos/%s/pulls/%s", fullname, id)
 
+	status, body, _, err := g.get(ctx, url)
+	if err != nil {
+		return sdk.VCSPullRequest{}, err
+	}
+	if status >= 400 {
+		if status > 500 {
+			return sdk.VCSPullRequest{}, sdk.NewError(sdk.ErrUnknownError, errorAPI(body))
 		} else {
-			if err := sdk.JSONUnmarshal(body, &pr); err != nil {
-				log.Warn(ctx, "githubClient.PullRequest> Unable to parse github pullrequest: %s", err)
-				return sdk.VCSPullRequest{}, sdk.WithStack(err)
-			}
-		}
-
-		if strconv.Itoa(pr.Number) != id {
-			log.Warn(ctx, "githubClient.PullRequest> Cannot find pullrequest %s", id)
-			if err := g.Cache.Delete(cachePullRequestKey); err != nil {
-				log.Error(ctx, "githubclient.PullRequest > unable to delete cache key %v: %v", cachePullRequestKey, err)
-			}
-			return sdk.VCSPullRequest{}, sdk.WithStack(fmt.Errorf("cannot find pullrequest %s", id))
+			return sdk.VCSPullRequest{}, sdk.NewError(sdk.ErrNotFound, errorAPI(body))
 		}
+	}
+	if err := sdk.JSONUnmarshal(body, &pr); err != nil {
+		log.Warn(ctx, "githubClient.PullRequest> Unable to parse github pullrequest: %s", err)
+		return sdk.VCSPullRequest{}, sdk.WithStack(err)
+	}
 
-		//Put the body on cache for one hour and one minute
-		if err := g.Cache.SetWithTTL(cachePullRequestKey, pr, 61*60); err != nil {
-			log.Error(ctx, "cannot SetWithTTL: %s: %v", cachePullRequestKey, err)
-		}
-		break
+	if strconv.Itoa(pr.Number) != id {
+		log.Warn(ctx, "githubClient.PullRequest> Cannot find pullrequest %s", id)
+		return sdk.VCSPullRequest{}, sdk.WithStack(fmt.Errorf("cannot find pullrequest %s", id))
 	}
 
 	return pr.ToVCSPullRequest(), nil
@@ -84,8 +45,6 @@ func (g *githubClient) PullRequest(ctx context.Context, fullname string, id stri
 // PullRequests fetch all the pull request for a repository
 func (g *githubClient) PullRequests(ctx context.Context, fullname string, opts sdk.VCSPullRequestOptions) ([]sdk.VCSPullRequest, error) {
 	var pullRequests = []PullRequest{}
-	cacheKey := cache.Key("vcs", "github", "pullrequests", sdk.Hash512(g.OAuthToken+g.username), "/repos/"+fullname+"/pulls")
-	githubOpts := []getArgFunc{withETag}
 
 	var nextPage = "/repos/" + fullname + "/pulls"
 	for nextPage != "" {
@@ -93,7 +52,7 @@ func (g *githubClient) PullRequests(ctx context.Context, fullname string, opts s
 			break
 		}
 
-		status, body, headers, err := g.get(ctx, nextPage, githubOpts...)
+		status, body, headers, err := g.get(ctx, nextPage)
 		if err != nil {
 			log.Warn(ctx, "githubClient.PullRequests> Error %s", err)
 			return nil, err
@@ -101,27 +60,10 @@ func (g *githubClient) PullRequests(ctx context.Context, fullname string, opts s
 		if status >= 400 {
 			return nil, sdk.NewError(sdk.ErrUnknownError, errorAPI(body))
 		}
-		githubOpts[0] = withETag
 		nextPullRequests := []PullRequest{}
-
-		//Github may return 304 status because we are using conditional request with ETag based headers
-		if status == http.StatusNotModified {
-			//If repos aren't updated, lets get them from cache
-			find, err := g.Cache.Get(cacheKey, &pullRequests)
-			if err != nil {
-				log.Error(ctx, "cannot get from cache %s: %v", cacheKey, err)
-			}
-			if !find {
-				githubOpts[0] = withoutETag
-				log.Error(ctx, "Unable to get pullrequest (%s) from the cache", strings.ReplaceAll(cacheKey, g.OAuthToken, ""))
-				continue
-			}
-			break
-		} else {
-			if err := sdk.JSONUnmarshal(body, &nextPullRequests); err != nil {
-				log.Warn(ctx, "githubClient.Branches> Unable to parse github branches: %s", err)
-				return nil, err
-			}
+		if err := sdk.JSONUnmarshal(body, &nextPullRequests); err != nil {
+			log.Warn(ctx, "githubClient.Branches> Unable to parse github branches: %s", err)
+			return nil, err
 		}
 
 		pullRequests = append(pullRequests, nextPullRequests...)
@@ -129,12 +71,6 @@ func (g *githubClient) PullRequests(ctx context.Context, fullname string, opts s
 		nextPage = getNextPage(headers)
 	}
 
-	//Put the body on cache for one hour and one minute
-	k := cache.Key("vcs", "github", "pullrequests", sdk.Hash512(g.OAuthToken+g.username), "/repos/"+fullname+"/pulls")
-	if err := g.Cache.SetWithTTL(k, pullRequests, 61*60); err != nil {
-		log.Error(ctx, "cannot SetWithTTL: %s: %v", k, err)
-	}
-
 	prResults := []sdk.VCSPullRequest{}
 	for _, pullr := range pullRequests {
 		// If a state is given we want to filter PRs
@@ -240,6 +176,10 @@ func (g *githubClient) PullRequestCreate(ctx context.Context, repo string, pr sd
 }
 
 func (pullr PullRequest) ToVCSPullRequest() sdk.VCSPullRequest {
+	labels := make([]string, 0, len(pullr.Labels))
+	for _, l := range pullr.Labels {
+		labels = append(labels, l.Name)
+	}
 	return sdk.VCSPullRequest{
 		ID: pullr.Number,
 		Base: sdk.VCSPushEvent{
@@ -289,5 +229,36 @@ func (pullr PullRequest) ToVCSPullRequest() sdk.VCSPullRequest {
 		Closed:  pullr.State == "closed",
 		Merged:  pullr.Merged,
 		Updated: pullr.UpdatedAt,
+		Labels:  labels,
+	}
+}
+
+// PullRequestMerge merges a pull request with the given method
+func (g *githubClient) PullRequestMerge(ctx context.Context, repo string, id string, req sdk.VCSPullRequestMergeRequest) error {
+	values, err := json.Marshal(PullRequestMerge{
+		CommitTitle: req.Message,
+		SHA:         req.Commit,
+		MergeMethod: req.Method,
+	})
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	path := fmt.Sprintf("/repos/%s/pulls/%s/merge", repo, id)
+	res, err := g.put(ctx, path, "application/json", bytes.NewReader(values), &postOptions{asUser: true})
+	if err != nil {
+		return sdk.WrapError(err, "unable to merge pull request %s", id)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return sdk.WrapError(err, "unable to read body")
+	}
+	switch res.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusMethodNotAllowed, http.StatusConflict:
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to merge pull request %s: %s", id, errorAPI(body))
 	}
+	return sdk.WithStack(fmt.Errorf("unable to merge pull request %s on github, status code: %d - %v", id, res.StatusCode, errorAPI(body)))
 }
@@ -4,21 +4,12 @@ import (
 	"context"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/rockbears/log"
 
 	"github.com/ovh/cds/sdk"
 )
 
-func isRateLimitReached() bool {
-	if RateLimitReset > 0 && RateLimitReset < int(time.Now().Unix()) {
-		log.Debug(context.Background(), "RateLimitReset not reached, it's ok to call github")
-		return false
-	}
-	return RateLimitRemaining < 100
-}
-
 // RateLimit Get your current rate limit status
 // https://developer.github.com/v3/rate_limit/#get-your-current-rate-limit-status
 func (g *githubClient) RateLimit(ctx context.Context) error {
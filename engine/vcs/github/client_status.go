@@ -6,12 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"strings"
 
 	"github.com/rockbears/log"
 
-	"github.com/ovh/cds/engine/cache"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -23,6 +21,7 @@ type statusData struct {
 	hash         string
 	urlPipeline  string
 	context      string
+	checkRun     *sdk.VCSCheckRun
 }
 
 // DEPRECATED VCS
@@ -100,6 +99,12 @@ func (g *githubClient) SetStatus(ctx context.Context, event sdk.Event, disableSt
 
 	log.Debug(ctx, "SetStatus> Status %d %s created at %v", s.ID, s.URL, s.CreatedAt)
 
+	if data.checkRun != nil && data.status != "pending" {
+		if err := g.createCheckRun(ctx, data, *data.checkRun); err != nil {
+			log.Warn(ctx, "github.SetStatus> unable to create check run, only the status is set: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -114,22 +119,8 @@ func (g *githubClient) ListStatuses(ctx context.Context, repo string, ref string
 	}
 	ss := []Status{}
 
-	//Github may return 304 status because we are using conditional request with ETag based headers
-	if status == http.StatusNotModified {
-		//If repo isn't updated, lets get them from cache
-		k := cache.Key("vcs", "github", "statuses", sdk.Hash512(g.OAuthToken+g.username), url)
-		if _, err := g.Cache.Get(k, &ss); err != nil {
-			log.Error(ctx, "cannot get from cache %s: %v", k, err)
-		}
-	} else {
-		if err := sdk.JSONUnmarshal(body, &ss); err != nil {
-			return []sdk.VCSCommitStatus{}, sdk.WrapError(err, "Unable to parse github commit: %s", ref)
-		}
-		//Put the body on cache for one hour and one minute
-		k := cache.Key("vcs", "github", "statuses", sdk.Hash512(g.OAuthToken+g.username), url)
-		if err := g.Cache.SetWithTTL(k, ss, 61*60); err != nil {
-			log.Error(ctx, "cannot SetWithTTL: %s: %v", k, err)
-		}
+	if err := sdk.JSONUnmarshal(body, &ss); err != nil {
+		return []sdk.VCSCommitStatus{}, sdk.WrapError(err, "Unable to parse github commit: %s", ref)
 	}
 
 	vcsStatuses := []sdk.VCSCommitStatus{}
@@ -201,5 +192,6 @@ func processEventWorkflowNodeRun(event sdk.Event, cdsUIURL string, disabledStatu
 
 	data.context = sdk.VCSCommitStatusDescription(event.ProjectKey, event.WorkflowName, eventNR)
 	data.desc = eventNR.NodeName + ": " + eventNR.Status
+	data.checkRun = eventNR.CheckRun
 	return data, nil
 }
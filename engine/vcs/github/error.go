@@ -6,7 +6,7 @@ import (
 	"github.com/ovh/cds/sdk"
 )
 
-//Error wraps github error format
+// Error wraps github error format
 type ghError struct {
 	ID   string `json:"error"`
 	Desc string `json:"error_description"`
@@ -21,7 +21,7 @@ func (e ghError) String() string {
 	return e.Error()
 }
 
-//Github errors
+// Github errors
 var (
 	ErrorRateLimit = &ghError{
 		ID:   "rate_limit",
@@ -36,7 +36,7 @@ var (
 	}
 )
 
-//errorAPI creates a new error
+// errorAPI creates a new error
 func errorAPI(body []byte) error {
 	res := map[string]string{}
 	sdk.JSONUnmarshal(body, &res)
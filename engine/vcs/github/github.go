@@ -23,7 +23,7 @@ type githubClient struct {
 	token                string
 }
 
-//GithubConsumer implements vcs.Server and it's used to instantiate a githubClient
+// GithubConsumer implements vcs.Server and it's used to instantiate a githubClient
 type githubConsumer struct {
 	ClientID             string `json:"client-id"`
 	ClientSecret         string `json:"-"`
@@ -39,7 +39,7 @@ type githubConsumer struct {
 	token                string
 }
 
-//New creates a new GithubConsumer
+// New creates a new GithubConsumer
 func New(githubURL, githubAPIURL, apiURL, uiURL, proxyURL string, store cache.Store) sdk.VCSServer {
 	//Github const
 	const (
@@ -14,18 +14,14 @@ import (
 
 	"github.com/rockbears/log"
 
-	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/engine/vcs/vcshttp"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/cdsclient"
 )
 
-//Github http var
+// Github http var
 var (
-	RateLimitLimit     = 5000
-	RateLimitRemaining = 5000
-	RateLimitReset     = int(time.Now().Unix())
-
-	httpClient = cdsclient.NewHTTPClient(time.Second*30, false)
+	httpClient = vcshttp.NewClient(context.Background(), cdsclient.NewHTTPClient(time.Second*30, false))
 )
 
 func (g *githubConsumer) postForm(path string, data url.Values, headers map[string][]string) (int, []byte, error) {
@@ -64,33 +60,6 @@ func (g *githubConsumer) postForm(path string, data url.Values, headers map[stri
 	return res.StatusCode, resBody, nil
 }
 
-func (c *githubClient) setETag(ctx context.Context, path string, headers http.Header) {
-	etag := headers.Get("ETag")
-
-	r, _ := regexp.Compile(".*\"(.*)\".*")
-	s := r.FindStringSubmatch(etag)
-	if len(s) == 2 {
-		etag = s[1]
-	}
-
-	if etag != "" {
-		//Put etag for this path in cache for 15 minutes
-		k := cache.Key("vcs", "github", "etag", sdk.Hash512(c.OAuthToken+c.username), strings.Replace(path, "https://", "", -1))
-		if err := c.Cache.SetWithTTL(k, etag, 15*60); err != nil {
-			log.Error(ctx, "cannot SetWithTTL: %s: %v", k, err)
-		}
-	}
-}
-
-func (c *githubClient) getETag(ctx context.Context, path string) string {
-	var s string
-	k := cache.Key("vcs", "github", "etag", sdk.Hash512(c.OAuthToken+c.username), strings.Replace(path, "https://", "", -1))
-	if _, err := c.Cache.Get(k, &s); err != nil {
-		log.Error(ctx, "cannot get from cache %s: %v", k, err)
-	}
-	return s
-}
-
 func getNextPage(headers http.Header) string {
 	linkHeader := headers.Get("Link")
 	if linkHeader != "" {
@@ -111,13 +80,10 @@ func getNextPage(headers http.Header) string {
 
 type getArgFunc func(ctx context.Context, c *githubClient, req *http.Request, path string)
 
-func withETag(ctx context.Context, c *githubClient, req *http.Request, path string) {
-	etag := c.getETag(ctx, path)
-	if etag != "" {
-		req.Header.Add("If-None-Match", fmt.Sprintf("W/\"%s\"", etag))
-	}
+// withoutETag forces the vcs http layer to get a fresh response
+func withoutETag(ctx context.Context, c *githubClient, req *http.Request, path string) {
+	req.Header.Set("Cache-Control", "no-cache")
 }
-func withoutETag(ctx context.Context, c *githubClient, req *http.Request, path string) {}
 
 type postOptions struct {
 	skipDefaultBaseURL bool
@@ -132,7 +98,7 @@ func (c *githubClient) post(ctx context.Context, path string, bodyType string, b
 		path = c.GitHubAPIURL + path
 	}
 
-	req, err := http.NewRequest(http.MethodPost, path, body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, path, body)
 	if err != nil {
 		return nil, err
 	}
@@ -171,7 +137,7 @@ func (c *githubClient) patch(ctx context.Context, path string, bodyType string,
 		path = c.GitHubAPIURL + path
 	}
 
-	req, err := http.NewRequest(http.MethodPatch, path, body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, path, body)
 	if err != nil {
 		return nil, err
 	}
@@ -213,7 +179,7 @@ func (c *githubClient) put(ctx context.Context, path string, bodyType string, bo
 		path = c.GitHubAPIURL + path
 	}
 
-	req, err := http.NewRequest(http.MethodPut, path, body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, path, body)
 	if err != nil {
 		return nil, err
 	}
@@ -230,10 +196,6 @@ func (c *githubClient) put(ctx context.Context, path string, bodyType string, bo
 }
 
 func (c *githubClient) get(ctx context.Context, path string, opts ...getArgFunc) (int, []byte, http.Header, error) {
-	if isRateLimitReached() {
-		return 0, nil, nil, ErrorRateLimit
-	}
-
 	if !strings.HasPrefix(path, c.GitHubAPIURL) {
 		path = c.GitHubAPIURL + path
 	}
@@ -243,7 +205,7 @@ func (c *githubClient) get(ctx context.Context, path string, opts ...getArgFunc)
 		return 0, nil, nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodGet, callURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, callURL.String(), nil)
 	if err != nil {
 		return 0, nil, nil, err
 	}
@@ -255,12 +217,8 @@ func (c *githubClient) get(ctx context.Context, path string, opts ...getArgFunc)
 		return 0, nil, nil, err
 	}
 
-	if opts == nil {
-		withETag(ctx, c, req, path)
-	} else {
-		for _, o := range opts {
-			o(ctx, c, req, path)
-		}
+	for _, o := range opts {
+		o(ctx, c, req, path)
 	}
 
 	res, err := httpClient.Do(req)
@@ -270,8 +228,6 @@ func (c *githubClient) get(ctx context.Context, path string, opts ...getArgFunc)
 	defer res.Body.Close()
 
 	switch res.StatusCode {
-	case http.StatusNotModified:
-		return res.StatusCode, nil, res.Header, nil
 	case http.StatusMovedPermanently, http.StatusTemporaryRedirect, http.StatusFound:
 		location := res.Header.Get("Location")
 		if location != "" {
@@ -287,31 +243,15 @@ func (c *githubClient) get(ctx context.Context, path string, opts ...getArgFunc)
 		return res.StatusCode, nil, nil, err
 	}
 
-	c.setETag(ctx, path, res.Header)
-
-	rateLimitLimit := res.Header.Get("X-RateLimit-Limit")
-	rateLimitRemaining := res.Header.Get("X-RateLimit-Remaining")
-	rateLimitReset := res.Header.Get("X-RateLimit-Reset")
-
-	if rateLimitLimit != "" && rateLimitRemaining != "" && rateLimitReset != "" {
-		RateLimitLimit, _ = strconv.Atoi(rateLimitLimit)
-		RateLimitRemaining, _ = strconv.Atoi(rateLimitRemaining)
-		RateLimitReset, _ = strconv.Atoi(rateLimitReset)
-	}
-
 	return res.StatusCode, resBody, res.Header, nil
 }
 
 func (c *githubClient) delete(ctx context.Context, path string) error {
-	if isRateLimitReached() {
-		return ErrorRateLimit
-	}
-
 	if !strings.HasPrefix(path, c.GitHubAPIURL) {
 		path = c.GitHubAPIURL + path
 	}
 
-	req, err := http.NewRequest(http.MethodDelete, path, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
@@ -327,15 +267,6 @@ func (c *githubClient) delete(ctx context.Context, path string) error {
 		return sdk.WrapError(err, "Cannot do delete request")
 	}
 
-	rateLimitLimit := res.Header.Get("X-RateLimit-Limit")
-	rateLimitRemaining := res.Header.Get("X-RateLimit-Remaining")
-	rateLimitReset := res.Header.Get("X-RateLimit-Reset")
-
-	if rateLimitLimit != "" && rateLimitRemaining != "" && rateLimitReset != "" {
-		RateLimitRemaining, _ = strconv.Atoi(rateLimitRemaining)
-		RateLimitReset, _ = strconv.Atoi(rateLimitReset)
-	}
-
 	if res.StatusCode != 204 {
 		return fmt.Errorf("github>delete wrong status code %d on url %s", res.StatusCode, path)
 	}
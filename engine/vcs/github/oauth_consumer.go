@@ -11,13 +11,13 @@ import (
 	"github.com/rockbears/log"
 )
 
-//Github const
+// Github const
 var (
 	requestedScope = []string{"user:email", "repo", "admin:repo_hook"} //https://developer.github.com/v3/oauth/#scopes
 )
 
-//AuthorizeRedirect returns the request token, the Authorize GitHubURL
-//doc: https://developer.github.com/v3/oauth/#web-application-flow
+// AuthorizeRedirect returns the request token, the Authorize GitHubURL
+// doc: https://developer.github.com/v3/oauth/#web-application-flow
 func (g *githubConsumer) AuthorizeRedirect(ctx context.Context) (string, string, error) {
 	_, end := telemetry.Span(ctx, "github.AuthorizeRedirect")
 	defer end()
@@ -39,8 +39,8 @@ func (g *githubConsumer) AuthorizeRedirect(ctx context.Context) (string, string,
 	return requestToken, authorizeURL, nil
 }
 
-//AuthorizeToken returns the authorized token (and its secret)
-//from the request token and the verifier got on authorize url
+// AuthorizeToken returns the authorized token (and its secret)
+// from the request token and the verifier got on authorize url
 func (g *githubConsumer) AuthorizeToken(ctx context.Context, state, code string) (string, string, error) {
 	_, end := telemetry.Span(ctx, "github.AuthorizeToken")
 	defer end()
@@ -82,7 +82,7 @@ func (g *githubConsumer) AuthorizeToken(ctx context.Context, state, code string)
 	return ghResponse["access_token"], state, nil
 }
 
-//GetAuthorized returns an authorized client
+// GetAuthorized returns an authorized client
 func (g *githubConsumer) GetAuthorizedClient(ctx context.Context, vcsAuth sdk.VCSAuth) (sdk.VCSAuthorizedClient, error) {
 	if vcsAuth.Type != "" {
 		c := &githubClient{
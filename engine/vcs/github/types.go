@@ -353,7 +353,7 @@ type TreeEntry struct {
 	Content *string `json:"content,omitempty"`
 }
 
-//Events represent repository events
+// Events represent repository events
 type Events []Event
 
 // Event represent a repository event
@@ -404,7 +404,7 @@ type Event struct {
 	} `json:"org"`
 }
 
-//CreateStatus represents create a Status API Payload
+// CreateStatus represents create a Status API Payload
 type CreateStatus struct {
 	State       string `json:"state"`
 	TargetURL   string `json:"target_url"`
@@ -412,7 +412,7 @@ type CreateStatus struct {
 	Context     string `json:"context"`
 }
 
-//Status represents Create a Status from API
+// Status represents Create a Status from API
 type Status struct {
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
@@ -443,7 +443,7 @@ type Status struct {
 	} `json:"creator"`
 }
 
-//RateLimit represents Rate Limit API
+// RateLimit represents Rate Limit API
 type RateLimit struct {
 	Resources struct {
 		Core struct {
@@ -514,6 +514,19 @@ type PullRequest struct {
 	Additions           int       `json:"additions"`
 	Deletions           int       `json:"deletions"`
 	ChangedFiles        int       `json:"changed_files"`
+	Labels              []Label   `json:"labels"`
+}
+
+// Label represents a label of an issue or a pull request
+type Label struct {
+	Name string `json:"name"`
+}
+
+// PullRequestMerge is the request sent to Github to merge a pull request
+type PullRequestMerge struct {
+	CommitTitle string `json:"commit_title,omitempty"`
+	SHA         string `json:"sha,omitempty"`
+	MergeMethod string `json:"merge_method,omitempty"`
 }
 
 // ReleaseRequest Request sent to Github to create a release
@@ -682,16 +695,17 @@ type DiffCommits struct {
 	TotalCommits int      `json:"total_commits"`
 	Commits      []Commit `json:"commits"`
 	Files        []struct {
-		Sha         string `json:"sha"`
-		Filename    string `json:"filename"`
-		Status      string `json:"status"`
-		Additions   int    `json:"additions"`
-		Deletions   int    `json:"deletions"`
-		Changes     int    `json:"changes"`
-		BlobURL     string `json:"blob_url"`
-		RawURL      string `json:"raw_url"`
-		ContentsURL string `json:"contents_url"`
-		Patch       string `json:"patch"`
+		Sha              string `json:"sha"`
+		Filename         string `json:"filename"`
+		PreviousFilename string `json:"previous_filename"`
+		Status           string `json:"status"`
+		Additions        int    `json:"additions"`
+		Deletions        int    `json:"deletions"`
+		Changes          int    `json:"changes"`
+		BlobURL          string `json:"blob_url"`
+		RawURL           string `json:"raw_url"`
+		ContentsURL      string `json:"contents_url"`
+		Patch            string `json:"patch"`
 	} `json:"files"`
 }
 
@@ -728,3 +742,32 @@ type Content struct {
 		Html string `json:"html"`
 	} `json:"_links"`
 }
+
+// CheckRun represents the input of the checks API
+// https://docs.github.com/en/rest/checks/runs#create-a-check-run
+type CheckRun struct {
+	ID         int64          `json:"id,omitempty"`
+	Name       string         `json:"name,omitempty"`
+	HeadSHA    string         `json:"head_sha,omitempty"`
+	DetailsURL string         `json:"details_url,omitempty"`
+	Status     string         `json:"status,omitempty"`
+	Conclusion string         `json:"conclusion,omitempty"`
+	Output     CheckRunOutput `json:"output"`
+}
+
+// CheckRunOutput is the report of a check run
+type CheckRunOutput struct {
+	Title       string               `json:"title"`
+	Summary     string               `json:"summary"`
+	Annotations []CheckRunAnnotation `json:"annotations,omitempty"`
+}
+
+// CheckRunAnnotation is a message on a line range of a file
+type CheckRunAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title,omitempty"`
+	Message         string `json:"message"`
+}
@@ -105,3 +105,23 @@ func (c *gitlabClient) CommitsBetweenRefs(ctx context.Context, repo, base, head
 
 	return vcscommits, nil
 }
+
+// ChangedFiles returns the paths of the files changed between base and head, renamed files are returned with their
+// previous and their new path.
+func (c *gitlabClient) ChangedFiles(ctx context.Context, repo, base, head string) ([]string, error) {
+	compare, _, err := c.client.Repositories.Compare(repo, &gitlab.CompareOptions{From: &base, To: &head})
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	if compare == nil {
+		return nil, nil
+	}
+	files := make([]string, 0, len(compare.Diffs))
+	for _, d := range compare.Diffs {
+		files = append(files, d.NewPath)
+		if d.OldPath != "" && d.OldPath != d.NewPath {
+			files = append(files, d.OldPath)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,50 @@
+package gitlab
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *gitlabClient) CreateDeployKey(ctx context.Context, repo string, key sdk.VCSDeployKey) (sdk.VCSDeployKey, error) {
+	k, _, err := c.client.DeployKeys.AddDeployKey(repo, &gitlab.AddDeployKeyOptions{
+		Title:   gitlab.String(key.Title),
+		Key:     gitlab.String(key.Key),
+		CanPush: gitlab.Bool(!key.ReadOnly),
+	})
+	if err != nil {
+		return key, sdk.WrapError(err, "cannot add gitlab deploy key on project %s", repo)
+	}
+	key.ID = strconv.Itoa(k.ID)
+	return key, nil
+}
+
+func (c *gitlabClient) DeleteDeployKey(ctx context.Context, repo string, key sdk.VCSDeployKey) error {
+	if key.ID == "" {
+		keys, _, err := c.client.DeployKeys.ListProjectDeployKeys(repo, nil)
+		if err != nil {
+			return sdk.WrapError(err, "cannot list gitlab deploy keys on project %s", repo)
+		}
+		for _, k := range keys {
+			if (sdk.VCSDeployKey{Key: k.Key}).Match(key.Key) {
+				key.ID = strconv.Itoa(k.ID)
+				break
+			}
+		}
+		if key.ID == "" {
+			return nil
+		}
+	}
+	id, err := strconv.Atoi(key.ID)
+	if err != nil {
+		return sdk.WrapError(sdk.ErrInvalidID, "wrong gitlab deploy key ID: %s", key.ID)
+	}
+	res, err := c.client.DeployKeys.DeleteDeployKey(repo, id)
+	if err != nil && (res == nil || res.StatusCode != 404) {
+		return sdk.WrapError(err, "cannot delete gitlab deploy key %s on project %s", key.ID, repo)
+	}
+	return nil
+}
@@ -64,6 +64,25 @@ func (c *gitlabClient) PullRequestCreate(ctx context.Context, repo string, pr sd
 	return toSDKPullRequest(repo, *mr), nil
 }
 
+// PullRequestMerge merges a merge request, the merge method of the project is used
+func (c *gitlabClient) PullRequestMerge(ctx context.Context, repo string, id string, req sdk.VCSPullRequestMergeRequest) error {
+	gitlabPRID, err := strconv.Atoi(id)
+	if err != nil {
+		return sdk.WrapError(sdk.ErrWrongRequest, "invalid merge request identifier: %s", id)
+	}
+	opts := &gitlab.AcceptMergeRequestOptions{}
+	if req.Message != "" {
+		opts.MergeCommitMessage = &req.Message
+	}
+	if req.Commit != "" {
+		opts.SHA = &req.Commit
+	}
+	if _, _, err := c.client.MergeRequests.AcceptMergeRequest(repo, gitlabPRID, opts); err != nil {
+		return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to merge merge request %d", gitlabPRID))
+	}
+	return nil
+}
+
 func toSDKPullRequest(repo string, mr gitlab.MergeRequest) sdk.VCSPullRequest {
 	pr := sdk.VCSPullRequest{
 		ID: mr.IID,
@@ -88,6 +107,7 @@ func toSDKPullRequest(repo string, mr gitlab.MergeRequest) sdk.VCSPullRequest {
 		},
 		Closed: mr.State == "closed",
 		Merged: mr.State == "merged",
+		Labels: mr.Labels,
 	}
 	if mr.UpdatedAt != nil {
 		pr.Updated = *mr.UpdatedAt
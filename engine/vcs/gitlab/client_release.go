@@ -2,19 +2,66 @@ package gitlab
 
 import (
 	"context"
-	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/xanzy/go-gitlab"
 
 	"github.com/ovh/cds/sdk"
 )
 
-// Release on gitlab
-// TODO: https://docs.gitlab.com/ee/api/tags.html#create-a-new-release
+// Release adds release notes to the given tag. GitLab releases are identified by their tag so the tag name is
+// returned as upload URL for UploadReleaseFile.
 func (c *gitlabClient) Release(ctx context.Context, repo string, tagName string, title string, releaseNote string) (*sdk.VCSRelease, error) {
-	return nil, fmt.Errorf("not implemented")
+	description := releaseNote
+	if title != "" && title != tagName {
+		description = "# " + title + "\n\n" + releaseNote
+	}
+	if _, _, err := c.client.Tags.CreateRelease(repo, tagName, &gitlab.CreateReleaseOptions{Description: &description}); err != nil {
+		return nil, sdk.WrapError(err, "unable to create release %s on repository %s", tagName, repo)
+	}
+	return &sdk.VCSRelease{UploadURL: tagName}, nil
 }
 
-// UploadReleaseFile upload a release file project
-func (c *gitlabClient) UploadReleaseFile(ctx context.Context, repo string, releaseName string, uploadURL string, artifactName string, r io.Reader, fileLength int) error {
-	return fmt.Errorf("not implemented")
+// UploadReleaseFile uploads the file on the project and links it in the description of the release of the tag given
+// as upload URL.
+func (c *gitlabClient) UploadReleaseFile(ctx context.Context, repo string, _ string, uploadURL string, artifactName string, r io.Reader, _ int) error {
+	tmpDir, err := os.MkdirTemp("", "cds-gitlab-release")
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer os.RemoveAll(tmpDir) // nolint
+
+	path := filepath.Join(tmpDir, filepath.Base(artifactName))
+	f, err := os.Create(path)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close() // nolint
+		return sdk.WithStack(err)
+	}
+	if err := f.Close(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	file, _, err := c.client.Projects.UploadFile(repo, path)
+	if err != nil {
+		return sdk.WrapError(err, "unable to upload file %s on repository %s", artifactName, repo)
+	}
+
+	tag, _, err := c.client.Tags.GetTag(repo, uploadURL)
+	if err != nil {
+		return sdk.WrapError(err, "unable to get tag %s on repository %s", uploadURL, repo)
+	}
+	var description string
+	if tag.Release != nil {
+		description = tag.Release.Description + "\n\n"
+	}
+	description += file.Markdown
+	if _, _, err := c.client.Tags.UpdateRelease(repo, uploadURL, &gitlab.UpdateReleaseOptions{Description: &description}); err != nil {
+		return sdk.WrapError(err, "unable to link file %s to release %s", artifactName, uploadURL)
+	}
+	return nil
 }
@@ -18,6 +18,7 @@ type statusData struct {
 	desc         string
 	repoFullName string
 	hash         string
+	checkRun     *sdk.VCSCheckRun
 }
 
 func getGitlabStateFromStatus(s string) gitlab.BuildStateValue {
@@ -107,6 +108,52 @@ func (c *gitlabClient) SetStatus(ctx context.Context, event sdk.Event, disabledS
 		if _, _, err := c.client.Commits.SetCommitStatus(data.repoFullName, data.hash, opt); err != nil {
 			return sdk.WrapError(err, "cannot process event %v - repo:%s hash:%s", event, data.repoFullName, data.hash)
 		}
+		if data.checkRun != nil && sdk.StatusIsTerminated(data.status) {
+			if err := c.postCheckRunDiscussion(ctx, data, *data.checkRun); err != nil {
+				log.Warn(ctx, "gitlabClient.SetStatus> unable to post check run discussion, only the status is set: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// maxCheckRunLineComments limits the number of line comments posted on a commit for a check run
+const maxCheckRunLineComments = 20
+
+// postCheckRunDiscussion comments the commit with the summary of the check run, then comments the lines with failure
+// annotations. It does nothing if the check run contains neither failed tests nor annotations.
+func (c *gitlabClient) postCheckRunDiscussion(ctx context.Context, data statusData, checkRun sdk.VCSCheckRun) error {
+	if checkRun.Tests.TotalKO == 0 && len(checkRun.Annotations) == 0 {
+		return nil
+	}
+	summary := checkRun.Summary
+	if _, _, err := c.client.Commits.PostCommitComment(data.repoFullName, data.hash, &gitlab.PostCommitCommentOptions{Note: &summary}); err != nil {
+		return sdk.WrapError(err, "unable to comment commit %s", data.hash)
+	}
+
+	lineType := "new"
+	var count int
+	for _, a := range checkRun.Annotations {
+		if a.Level != sdk.VCSCheckRunAnnotationLevelFailure || a.Path == "" {
+			continue
+		}
+		if count >= maxCheckRunLineComments {
+			break
+		}
+		count++
+		note := fmt.Sprintf("**%s** %s", checkRun.Name, a.Message)
+		if a.Title != "" {
+			note = fmt.Sprintf("**%s** `%s`: %s", checkRun.Name, a.Title, a.Message)
+		}
+		path, line := a.Path, a.StartLine
+		if _, _, err := c.client.Commits.PostCommitComment(data.repoFullName, data.hash, &gitlab.PostCommitCommentOptions{
+			Note:     &note,
+			Path:     &path,
+			Line:     &line,
+			LineType: &lineType,
+		}); err != nil {
+			log.Warn(ctx, "gitlabClient.postCheckRunDiscussion> unable to comment %s:%d on commit %s: %v", path, line, data.hash, err)
+		}
 	}
 	return nil
 }
@@ -165,5 +212,6 @@ func processWorkflowNodeRunEvent(event sdk.Event, uiURL string) (statusData, err
 	data.repoFullName = eventNR.RepositoryFullName
 	data.status = eventNR.Status
 	data.branchName = eventNR.BranchName
+	data.checkRun = eventNR.CheckRun
 	return data, nil
 }
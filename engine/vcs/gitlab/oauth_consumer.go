@@ -12,6 +12,7 @@ import (
 	"github.com/rockbears/log"
 	"github.com/xanzy/go-gitlab"
 
+	"github.com/ovh/cds/engine/vcs/vcshttp"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/telemetry"
 )
@@ -121,9 +122,9 @@ func (g *gitlabConsumer) AuthorizeToken(ctx context.Context, state, code string)
 
 //GetAuthorized returns an authorized client
 func (g *gitlabConsumer) GetAuthorizedClient(ctx context.Context, vcsAuth sdk.VCSAuth) (sdk.VCSAuthorizedClient, error) {
-	httpClient := &http.Client{
+	httpClient := vcshttp.NewClient(ctx, &http.Client{
 		Timeout: 60 * time.Second,
-	}
+	})
 	if vcsAuth.Type != "" {
 		gclient := gitlab.NewClient(httpClient, vcsAuth.Token)
 		c := &gitlabClient{
@@ -3,10 +3,13 @@ package vcs
 import (
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/ovh/cds/engine/api"
 	"github.com/ovh/cds/engine/cache"
 	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/engine/vcs/vcshttp"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -21,6 +24,12 @@ type Service struct {
 			URL string
 		}
 	}
+	gitMirror struct {
+		sync.Mutex
+		url    string
+		access string
+		expire time.Time
+	}
 }
 
 // Configuration is the vcs configuration structure
@@ -39,6 +48,7 @@ type Configuration struct {
 	} `toml:"cache" comment:"######################\n CDS VCS Cache Settings \n######################" json:"cache"`
 	ProxyWebhook string                         `toml:"proxyWebhook" default:"" commented:"true" comment:"If you want to have a reverse proxy url for your repository webhook, for example if you put https://myproxy.com it will generate a webhook URL like this https://myproxy.com/UUID_OF_YOUR_WEBHOOK" json:"proxy_webhook"`
 	Servers      map[string]ServerConfiguration `toml:"servers" comment:"######################\n CDS VCS Server Settings \n######################" json:"servers"`
+	Governor     vcshttp.Configuration          `toml:"governor" comment:"######################\n CDS VCS Requests Settings: cache of the responses and budgets of the VCS servers \n######################" json:"governor"`
 }
 
 // ServerConfiguration is the configuration for a VCS server
@@ -15,11 +15,14 @@ import (
 	"github.com/ovh/cds/engine/vcs/bitbucketcloud"
 	"github.com/ovh/cds/engine/vcs/bitbucketserver"
 	"github.com/ovh/cds/engine/vcs/gerrit"
+	"github.com/ovh/cds/engine/vcs/git"
 	"github.com/ovh/cds/engine/vcs/gitea"
 	"github.com/ovh/cds/engine/vcs/github"
 	"github.com/ovh/cds/engine/vcs/gitlab"
+	"github.com/ovh/cds/engine/vcs/vcshttp"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/telemetry"
 )
 
 // New returns a new service
@@ -138,6 +141,12 @@ func (s *Service) getConsumer(name string, vcsAuth sdk.VCSAuth) (sdk.VCSServer,
 				vcsAuth.Username,
 				vcsAuth.Token,
 			), nil
+		case sdk.VCSTypeGit:
+			return git.New(
+				strings.TrimSuffix(vcsAuth.URL, "/"),
+				&gitMirrors{s: s},
+				s.Cache,
+			), nil
 		}
 		return nil, sdk.WithStack(sdk.ErrNotFound)
 	}
@@ -230,6 +239,10 @@ func (s *Service) Serve(c context.Context) error {
 	if errCache != nil {
 		return fmt.Errorf("Cannot connect to redis instance : %v", errCache)
 	}
+	vcshttp.Configure(s.Cfg.Governor, s.Cache)
+	if err := telemetry.RegisterView(c, vcshttp.Views()...); err != nil {
+		return sdk.WrapError(err, "unable to register vcs metrics")
+	}
 
 	//Init the http server
 	s.initRouter(c)
@@ -8,6 +8,7 @@ import (
 	"strconv"
 
 	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/engine/vcs/vcshttp"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -20,6 +21,9 @@ var (
 	contextKeyVCSType            contextKey = "vcs-type"
 	contextKeyVCSUsername        contextKey = "vcs-username"
 	contextKeyVCSToken           contextKey = "vcs-token"
+	contextKeyVCSSSHUsername     contextKey = "vcs-ssh-username"
+	contextKeyVCSSSHPort         contextKey = "vcs-ssh-port"
+	contextKeyVCSSSHPrivateKey   contextKey = "vcs-ssh-private-key"
 	contextKeyAccessToken        contextKey = "access-token"         // DEPRECATED VCS
 	contextKeyAccessTokenCreated contextKey = "access-token-created" // DEPRECATED VCS
 	contextKeyAccessTokenSecret  contextKey = "access-token-secret"  // DEPRECATED VCS
@@ -46,12 +50,28 @@ func (s *Service) authMiddleware(ctx context.Context, w http.ResponseWriter, req
 	if err != nil {
 		return nil, sdk.WrapError(err, "bad header syntax for HeaderXVCSToken")
 	}
+	vcsSSHUsername, err := base64.StdEncoding.DecodeString(req.Header.Get(sdk.HeaderXVCSSSHUsername))
+	if err != nil {
+		return nil, sdk.WrapError(err, "bad header syntax for HeaderXVCSSSHUsername")
+	}
+	vcsSSHPort, err := base64.StdEncoding.DecodeString(req.Header.Get(sdk.HeaderXVCSSSHPort))
+	if err != nil {
+		return nil, sdk.WrapError(err, "bad header syntax for HeaderXVCSSSHPort")
+	}
+	vcsSSHPrivateKey, err := base64.StdEncoding.DecodeString(req.Header.Get(sdk.HeaderXVCSSSHPrivateKey))
+	if err != nil {
+		return nil, sdk.WrapError(err, "bad header syntax for HeaderXVCSSSHPrivateKey")
+	}
+	ctx = vcshttp.ContextWithPriority(ctx, req.Header.Get(sdk.HeaderXVCSPriority))
 	if string(vcsType) != "" {
 		ctx = context.WithValue(ctx, contextKeyVCSURL, string(vcsURL))
 		ctx = context.WithValue(ctx, contextKeyVCSURLApi, string(vcsURLApi))
 		ctx = context.WithValue(ctx, contextKeyVCSType, string(vcsType))
 		ctx = context.WithValue(ctx, contextKeyVCSUsername, string(vcsUsername))
 		ctx = context.WithValue(ctx, contextKeyVCSToken, string(vcsToken))
+		ctx = context.WithValue(ctx, contextKeyVCSSSHUsername, string(vcsSSHUsername))
+		ctx = context.WithValue(ctx, contextKeyVCSSSHPort, string(vcsSSHPort))
+		ctx = context.WithValue(ctx, contextKeyVCSSSHPrivateKey, string(vcsSSHPrivateKey))
 		return ctx, nil
 	}
 
@@ -104,6 +124,20 @@ func getVCSAuth(ctx context.Context) (sdk.VCSAuth, error) {
 		token, _ := ctx.Value(contextKeyVCSToken).(string)
 		vcsAuth.Token = token
 
+		sshUsername, _ := ctx.Value(contextKeyVCSSSHUsername).(string)
+		vcsAuth.SSHUsername = sshUsername
+
+		if sshPort, _ := ctx.Value(contextKeyVCSSSHPort).(string); sshPort != "" {
+			port, err := strconv.Atoi(sshPort)
+			if err != nil {
+				return sdk.VCSAuth{}, sdk.WrapError(sdk.ErrWrongRequest, "invalid ssh port header: %v err:%v", sshPort, err)
+			}
+			vcsAuth.SSHPort = port
+		}
+
+		sshPrivateKey, _ := ctx.Value(contextKeyVCSSSHPrivateKey).(string)
+		vcsAuth.SSHPrivateKey = sshPrivateKey
+
 		return vcsAuth, nil
 	}
 
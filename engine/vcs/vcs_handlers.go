@@ -15,6 +15,7 @@ import (
 
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/engine/vcs/github"
+	"github.com/ovh/cds/engine/vcs/vcshttp"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -499,6 +500,41 @@ func (s *Service) getCommitsBetweenRefsHandler() service.Handler {
 	}
 }
 
+func (s *Service) getChangedFilesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := muxVar(r, "name")
+		owner := muxVar(r, "owner")
+		repo := muxVar(r, "repo")
+		base := r.URL.Query().Get("base")
+		head := r.URL.Query().Get("head")
+
+		vcsAuth, err := getVCSAuth(ctx)
+		if err != nil {
+			return sdk.WrapError(sdk.ErrUnauthorized, "unable to get access token header")
+		}
+
+		consumer, err := s.getConsumer(name, vcsAuth)
+		if err != nil {
+			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
+		}
+
+		client, err := consumer.GetAuthorizedClient(ctx, vcsAuth)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
+		}
+		// Check if access token has been refreshed
+		if vcsAuth.AccessToken != client.GetAccessToken(ctx) {
+			w.Header().Set(sdk.HeaderXAccessToken, client.GetAccessToken(ctx))
+		}
+
+		files, err := client.ChangedFiles(ctx, fmt.Sprintf("%s/%s", owner, repo), base, head)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get files of %s/%s changed between %s and %s", owner, repo, base, head)
+		}
+		return service.WriteJSON(w, files, http.StatusOK)
+	}
+}
+
 func (s *Service) getCommitHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		name := muxVar(r, "name")
@@ -603,6 +639,45 @@ func (s *Service) getPullRequestHandler() service.Handler {
 	}
 }
 
+func (s *Service) postPullRequestMergeHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := muxVar(r, "name")
+		owner := muxVar(r, "owner")
+		repo := muxVar(r, "repo")
+		id := muxVar(r, "id")
+
+		vcsAuth, err := getVCSAuth(ctx)
+		if err != nil {
+			return sdk.WrapError(sdk.ErrUnauthorized, "unable to get access token header")
+		}
+
+		consumer, err := s.getConsumer(name, vcsAuth)
+		if err != nil {
+			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
+		}
+
+		client, err := consumer.GetAuthorizedClient(ctx, vcsAuth)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
+		}
+
+		// Check if access token has been refreshed
+		if vcsAuth.AccessToken != client.GetAccessToken(ctx) {
+			w.Header().Set(sdk.HeaderXAccessToken, client.GetAccessToken(ctx))
+		}
+
+		var body sdk.VCSPullRequestMergeRequest
+		if err := service.UnmarshalBody(r, &body); err != nil {
+			return sdk.WrapError(err, "unable to read body %s %s/%s", name, owner, repo)
+		}
+
+		if err := client.PullRequestMerge(ctx, fmt.Sprintf("%s/%s", owner, repo), id, body); err != nil {
+			return sdk.WrapError(err, "cannot merge pull request %s on %s/%s", id, owner, repo)
+		}
+		return nil
+	}
+}
+
 func (s *Service) getPullRequestsHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		name := muxVar(r, "name")
@@ -1131,6 +1206,81 @@ func (s *Service) deleteHookHandler() service.Handler {
 	}
 }
 
+func (s *Service) postDeployKeyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := muxVar(r, "name")
+		owner := muxVar(r, "owner")
+		repo := muxVar(r, "repo")
+
+		vcsAuth, err := getVCSAuth(ctx)
+		if err != nil {
+			return sdk.WrapError(sdk.ErrUnauthorized, "unable to get access token header")
+		}
+
+		consumer, err := s.getConsumer(name, vcsAuth)
+		if err != nil {
+			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
+		}
+
+		client, err := consumer.GetAuthorizedClient(ctx, vcsAuth)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
+		}
+		// Check if access token has been refreshed
+		if vcsAuth.AccessToken != client.GetAccessToken(ctx) {
+			w.Header().Set(sdk.HeaderXAccessToken, client.GetAccessToken(ctx))
+		}
+
+		var body sdk.VCSDeployKey
+		if err := service.UnmarshalBody(r, &body); err != nil {
+			return sdk.WrapError(err, "unable to read body %s %s/%s", name, owner, repo)
+		}
+
+		key, err := client.CreateDeployKey(ctx, fmt.Sprintf("%s/%s", owner, repo), body)
+		if err != nil {
+			return sdk.WrapError(err, "cannot create deploy key on %s for repository %s/%s", name, owner, repo)
+		}
+		return service.WriteJSON(w, key, http.StatusOK)
+	}
+}
+
+func (s *Service) postDeleteDeployKeyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := muxVar(r, "name")
+		owner := muxVar(r, "owner")
+		repo := muxVar(r, "repo")
+
+		vcsAuth, err := getVCSAuth(ctx)
+		if err != nil {
+			return sdk.WrapError(sdk.ErrUnauthorized, "unable to get access token header")
+		}
+
+		consumer, err := s.getConsumer(name, vcsAuth)
+		if err != nil {
+			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
+		}
+
+		client, err := consumer.GetAuthorizedClient(ctx, vcsAuth)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
+		}
+		// Check if access token has been refreshed
+		if vcsAuth.AccessToken != client.GetAccessToken(ctx) {
+			w.Header().Set(sdk.HeaderXAccessToken, client.GetAccessToken(ctx))
+		}
+
+		var body sdk.VCSDeployKey
+		if err := service.UnmarshalBody(r, &body); err != nil {
+			return sdk.WrapError(err, "unable to read body %s %s/%s", name, owner, repo)
+		}
+
+		if err := client.DeleteDeployKey(ctx, fmt.Sprintf("%s/%s", owner, repo), body); err != nil {
+			return sdk.WrapError(err, "cannot delete deploy key on %s for repository %s/%s", name, owner, repo)
+		}
+		return nil
+	}
+}
+
 func (s *Service) getListForks() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		name := muxVar(r, "name")
@@ -1168,11 +1318,7 @@ func (s *Service) getListForks() service.Handler {
 // Status returns sdk.MonitoringStatus, implements interface service.Service
 func (s *Service) Status(ctx context.Context) *sdk.MonitoringStatus {
 	m := s.NewMonitoringStatus()
-
-	if s.Cfg.Servers["github"].URL != "" {
-		m.AddLine(github.GetStatus()...)
-	}
-
+	m.AddLine(vcshttp.DefaultGovernor().MonitoringStatus()...)
 	return m
 }
 
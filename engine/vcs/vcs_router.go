@@ -39,6 +39,7 @@ func (s *Service) initRouter(ctx context.Context) {
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/branches/commits", nil, r.GET(s.getCommitsHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/tags", nil, r.GET(s.getTagsHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/commits", nil, r.GET(s.getCommitsBetweenRefsHandler))
+	r.Handle("/vcs/{name}/repos/{owner}/{repo}/changes", nil, r.GET(s.getChangedFilesHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/commits/{commit}", nil, r.GET(s.getCommitHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/commits/{commit}/statuses", nil, r.GET(s.getCommitStatusHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/contents/{filePath}", nil, r.GET(s.getListContentsHandler))
@@ -48,8 +49,11 @@ func (s *Service) initRouter(ctx context.Context) {
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/pullrequests", nil, r.GET(s.getPullRequestsHandler), r.POST(s.postPullRequestsHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/pullrequests/comments", nil, r.POST(s.postPullRequestCommentHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/pullrequests/{id}", nil, r.GET(s.getPullRequestHandler))
+	r.Handle("/vcs/{name}/repos/{owner}/{repo}/pullrequests/{id}/merge", nil, r.POST(s.postPullRequestMergeHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/events", nil, r.GET(s.getEventsHandler), r.POST(s.postFilterEventsHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/hooks", nil, r.GET(s.getHookHandler), r.POST(s.postHookHandler), r.PUT(s.putHookHandler), r.DELETE(s.deleteHookHandler))
+	r.Handle("/vcs/{name}/repos/{owner}/{repo}/keys", nil, r.POST(s.postDeployKeyHandler))
+	r.Handle("/vcs/{name}/repos/{owner}/{repo}/keys/delete", nil, r.POST(s.postDeleteDeployKeyHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/releases", nil, r.POST(s.postReleaseHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/releases/{release}/artifacts/{artifactName}", nil, r.POST(s.postUploadReleaseFileHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/forks", nil, r.GET(s.getListForks))
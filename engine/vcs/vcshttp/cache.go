@@ -0,0 +1,131 @@
+package vcshttp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+// maxCachedBodySize is the maximum size of a response kept in cache, bigger responses (archives...) are streamed.
+const maxCachedBodySize = 1 << 20
+
+var keyResponse = cache.Key("vcs", "http", "response")
+
+// cachedResponse is a response kept in cache with its validators.
+type cachedResponse struct {
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+}
+
+// response returns the cached response with the headers of the not modified response, rate limit headers are up to
+// date for the caller.
+func (c cachedResponse) response(req *http.Request, notModified *http.Response) *http.Response {
+	_, _ = io.Copy(io.Discard, notModified.Body)
+	notModified.Body.Close()
+
+	header := c.Header.Clone()
+	for k, v := range notModified.Header {
+		header[k] = v
+	}
+	return &http.Response{
+		Status:        http.StatusText(c.StatusCode),
+		StatusCode:    c.StatusCode,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}
+}
+
+// identity returns the credentials of a request: authorization header, gitlab token, basic auth of the URL and
+// cookies. Only the token of OAuth1 authorization headers is kept, the whole header changes on each request with
+// OAuth1 signatures.
+func identity(req *http.Request) string {
+	auth := req.Header.Get("Authorization")
+	if strings.HasPrefix(auth, "OAuth ") {
+		for _, p := range strings.Split(strings.TrimPrefix(auth, "OAuth "), ",") {
+			p = strings.TrimSpace(p)
+			if strings.HasPrefix(p, "oauth_token=") {
+				auth = p
+				break
+			}
+		}
+	}
+	var userInfo string
+	if req.URL.User != nil {
+		userInfo = req.URL.User.String()
+	}
+	return strings.Join([]string{auth, req.Header.Get("PRIVATE-TOKEN"), userInfo, req.Header.Get("Cookie")}, "\n")
+}
+
+// cachedResponse returns the cached response of a request and its cache key. Only GET requests without conditional
+// headers set by the caller are cached, the key is empty for other requests.
+func (g *Governor) cachedResponse(ctx context.Context, req *http.Request) (*cachedResponse, string) {
+	if g.cache == nil || g.cfg.CacheTTL <= 0 || req.Method != http.MethodGet {
+		return nil, ""
+	}
+	if req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" || req.Header.Get("Range") != "" ||
+		strings.Contains(req.Header.Get("Cache-Control"), "no-cache") {
+		return nil, ""
+	}
+
+	key := cache.Key(keyResponse, sdk.Hash512(identity(req)+"\n"+req.Header.Get("Accept")+"\n"+req.URL.String()))
+	var entry cachedResponse
+	find, err := g.cache.Get(key, &entry)
+	if err != nil {
+		log.Error(ctx, "vcshttp> cannot get from cache %s: %v", key, err)
+	}
+	if !find || (entry.ETag == "" && entry.LastModified == "") {
+		return nil, key
+	}
+	return &entry, key
+}
+
+// storeResponse keeps a successful response in cache if it has validators, the body is buffered up to
+// maxCachedBodySize.
+func (g *Governor) storeResponse(ctx context.Context, key string, res *http.Response) *http.Response {
+	etag, lastModified := res.Header.Get("ETag"), res.Header.Get("Last-Modified")
+	if res.StatusCode != http.StatusOK || (etag == "" && lastModified == "") ||
+		strings.Contains(res.Header.Get("Cache-Control"), "no-store") {
+		return res
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(res.Body, maxCachedBodySize+1))
+	if err != nil || len(buf) > maxCachedBodySize {
+		res.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(buf), res.Body), Closer: res.Body}
+		return res
+	}
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(buf))
+
+	entry := cachedResponse{
+		ETag:         etag,
+		LastModified: lastModified,
+		StatusCode:   res.StatusCode,
+		Header:       res.Header.Clone(),
+		Body:         buf,
+	}
+	if err := g.cache.SetWithDuration(key, entry, time.Duration(g.cfg.CacheTTL)*time.Second); err != nil {
+		log.Error(ctx, "vcshttp> cannot set in cache %s: %v", key, err)
+	}
+	return res
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
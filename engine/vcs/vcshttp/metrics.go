@@ -0,0 +1,79 @@
+package vcshttp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/telemetry"
+)
+
+const (
+	requestStatusSent     = "sent"
+	requestStatusCacheHit = "cache_hit"
+	requestStatusRejected = "rejected"
+	requestStatusError    = "error"
+)
+
+var (
+	metricsRequests       = stats.Int64("vcs/http/requests", "number of requests to the VCS servers by status and priority", stats.UnitDimensionless)
+	metricsQuotaLimit     = stats.Int64("vcs/ratelimit/limit", "rate limit quota of the VCS servers", stats.UnitDimensionless)
+	metricsQuotaRemaining = stats.Int64("vcs/ratelimit/remaining", "remaining rate limit quota of the VCS servers", stats.UnitDimensionless)
+)
+
+// Views returns the views of the metrics of the VCS servers.
+func Views() []*view.View {
+	tagVCSServer := telemetry.MustNewKey(telemetry.TagVCSServer)
+	tagPriority := telemetry.MustNewKey(telemetry.TagPriority)
+	tagStatus := telemetry.MustNewKey(telemetry.TagStatus)
+	return []*view.View{
+		telemetry.NewViewCount(metricsRequests.Name(), metricsRequests, []tag.Key{tagVCSServer, tagPriority, tagStatus}),
+		telemetry.NewViewLast(metricsQuotaLimit.Name(), metricsQuotaLimit, []tag.Key{tagVCSServer}),
+		telemetry.NewViewLast(metricsQuotaRemaining.Name(), metricsQuotaRemaining, []tag.Key{tagVCSServer}),
+	}
+}
+
+func recordRequest(ctx context.Context, server, priority, status string) {
+	ctx = telemetry.ContextWithTag(ctx, telemetry.TagVCSServer, server, telemetry.TagPriority, priority, telemetry.TagStatus, status)
+	telemetry.Record(ctx, metricsRequests, 1)
+}
+
+func recordQuota(ctx context.Context, server string, quota Quota) {
+	ctx = telemetry.ContextWithTag(ctx, telemetry.TagVCSServer, server)
+	telemetry.Record(ctx, metricsQuotaLimit, int64(quota.Limit))
+	telemetry.Record(ctx, metricsQuotaRemaining, int64(quota.Remaining))
+}
+
+// MonitoringStatus returns the rate limit quota of each VCS server called by the governor.
+func (g *Governor) MonitoringStatus() []sdk.MonitoringStatusLine {
+	quotas := g.Quotas()
+	names := make([]string, 0, len(quotas))
+	for name := range quotas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var lines []sdk.MonitoringStatusLine
+	for _, name := range names {
+		q := quotas[name]
+		status := sdk.MonitoringStatusOK
+		switch {
+		case time.Now().Before(q.BlockedUntil):
+			status = sdk.MonitoringStatusAlert
+		case q.Limit > 0 && q.Remaining*10 < q.Limit:
+			status = sdk.MonitoringStatusWarn
+		}
+		value := "no quota"
+		if q.Limit > 0 {
+			value = fmt.Sprintf("%d/%d", q.Remaining, q.Limit)
+		}
+		lines = append(lines, sdk.MonitoringStatusLine{Component: "RateLimit/" + name, Value: value, Status: status})
+	}
+	return lines
+}
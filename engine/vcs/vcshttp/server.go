@@ -0,0 +1,172 @@
+package vcshttp
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rockbears/log"
+	"golang.org/x/time/rate"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const defaultMaxWait = 10 * time.Second
+
+// Quota is the rate limit quota of a VCS server as reported in its responses headers.
+type Quota struct {
+	Limit        int       `json:"limit"`
+	Remaining    int       `json:"remaining"`
+	Reset        time.Time `json:"reset"`
+	BlockedUntil time.Time `json:"blocked_until"`
+}
+
+// server is the budget of a VCS server: all requests consume a token of the limiter, background requests also
+// consume a token of the background limiter that only refills with a share of the budget.
+type server struct {
+	name              string
+	limiter           *rate.Limiter
+	backgroundLimiter *rate.Limiter
+	quotaReserve      int
+	maxWait           time.Duration
+
+	mutex sync.Mutex
+	quota Quota
+}
+
+func newServer(name string, cfg Configuration) *server {
+	requestsPerMinute, burst := cfg.RequestsPerMinute, cfg.Burst
+	if s, ok := cfg.Servers[name]; ok {
+		requestsPerMinute, burst = s.RequestsPerMinute, s.Burst
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+
+	srv := &server{
+		name:         name,
+		limiter:      rate.NewLimiter(rate.Inf, burst),
+		quotaReserve: cfg.QuotaReserve,
+		maxWait:      time.Duration(cfg.MaxWait) * time.Second,
+	}
+	if srv.maxWait <= 0 {
+		srv.maxWait = defaultMaxWait
+	}
+	if requestsPerMinute > 0 {
+		srv.limiter = rate.NewLimiter(rate.Limit(float64(requestsPerMinute)/60), burst)
+		if cfg.BackgroundShare > 0 && cfg.BackgroundShare < 100 {
+			backgroundBurst := burst * cfg.BackgroundShare / 100
+			if backgroundBurst <= 0 {
+				backgroundBurst = 1
+			}
+			srv.backgroundLimiter = rate.NewLimiter(rate.Limit(float64(requestsPerMinute*cfg.BackgroundShare)/100/60), backgroundBurst)
+		}
+	}
+	return srv
+}
+
+// wait blocks until the budget of the server allows the request. Background requests are rejected as soon as the
+// server is blocked or its quota reaches the reserve, interactive requests wait up to maxWait.
+func (s *server) wait(ctx context.Context, priority string) error {
+	now := time.Now()
+	s.mutex.Lock()
+	quota := s.quota
+	s.mutex.Unlock()
+
+	if now.Before(quota.BlockedUntil) {
+		if priority == sdk.VCSPriorityBackground || quota.BlockedUntil.Sub(now) > s.maxWait {
+			return sdk.NewErrorFrom(sdk.ErrRateLimitReached, "%s is rate limited until %s", s.name, quota.BlockedUntil.Format(time.RFC3339))
+		}
+		select {
+		case <-ctx.Done():
+			return sdk.WithStack(ctx.Err())
+		case <-time.After(quota.BlockedUntil.Sub(now)):
+		}
+	} else if priority == sdk.VCSPriorityBackground && quota.Limit > 0 && now.Before(quota.Reset) &&
+		quota.Remaining*100 <= quota.Limit*s.quotaReserve {
+		return sdk.NewErrorFrom(sdk.ErrRateLimitReached, "%s quota is reserved to interactive requests until %s", s.name, quota.Reset.Format(time.RFC3339))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.maxWait)
+	defer cancel()
+	if priority == sdk.VCSPriorityBackground && s.backgroundLimiter != nil {
+		if err := s.backgroundLimiter.Wait(ctx); err != nil {
+			return sdk.NewErrorFrom(sdk.ErrRateLimitReached, "budget of %s exhausted for background requests", s.name)
+		}
+	}
+	if err := s.limiter.Wait(ctx); err != nil {
+		return sdk.NewErrorFrom(sdk.ErrRateLimitReached, "budget of %s exhausted", s.name)
+	}
+	return nil
+}
+
+// updateQuota reads the rate limit headers sent by GitHub, GitLab, Gitea and Bitbucket, and blocks the server when
+// the quota is exhausted or when the server asks to retry later.
+func (s *server) updateQuota(ctx context.Context, res *http.Response) {
+	now := time.Now()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	limit, okLimit := headerInt(res.Header, "X-RateLimit-Limit", "RateLimit-Limit")
+	remaining, okRemaining := headerInt(res.Header, "X-RateLimit-Remaining", "RateLimit-Remaining")
+	if okLimit && okRemaining {
+		s.quota.Limit = limit
+		s.quota.Remaining = remaining
+		if reset, ok := headerInt(res.Header, "X-RateLimit-Reset", "RateLimit-Reset"); ok {
+			// Reset is an epoch on most servers and a number of seconds on some others
+			if reset > 1000000000 {
+				s.quota.Reset = time.Unix(int64(reset), 0)
+			} else {
+				s.quota.Reset = now.Add(time.Duration(reset) * time.Second)
+			}
+		}
+		recordQuota(ctx, s.name, s.quota)
+	}
+
+	limited := res.StatusCode == http.StatusTooManyRequests ||
+		(res.StatusCode == http.StatusForbidden && okRemaining && remaining == 0)
+	if !limited {
+		return
+	}
+	blockedUntil := s.quota.Reset
+	if retryAfter, ok := headerInt(res.Header, "Retry-After"); ok {
+		blockedUntil = now.Add(time.Duration(retryAfter) * time.Second)
+	}
+	if !blockedUntil.After(now) {
+		blockedUntil = now.Add(time.Minute)
+	}
+	s.quota.BlockedUntil = blockedUntil
+	log.Warn(ctx, "vcshttp> %s is rate limited until %s", s.name, blockedUntil.Format(time.RFC3339))
+}
+
+func headerInt(h http.Header, keys ...string) (int, bool) {
+	for _, k := range keys {
+		if v := h.Get(k); v != "" {
+			i, err := strconv.Atoi(v)
+			if err == nil {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Quotas returns the rate limit quota of each VCS server called by the governor.
+func (g *Governor) Quotas() map[string]Quota {
+	g.mutex.Lock()
+	servers := make([]*server, 0, len(g.servers))
+	for _, srv := range g.servers {
+		servers = append(servers, srv)
+	}
+	g.mutex.Unlock()
+
+	res := make(map[string]Quota, len(servers))
+	for _, srv := range servers {
+		srv.mutex.Lock()
+		res[srv.name] = srv.quota
+		srv.mutex.Unlock()
+	}
+	return res
+}
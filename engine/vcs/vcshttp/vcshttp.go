@@ -0,0 +1,174 @@
+package vcshttp
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Configuration of the http layer shared by the VCS drivers
+type Configuration struct {
+	CacheTTL          int                            `toml:"cacheTTL" default:"3600" comment:"Time to live in seconds of the responses kept for conditional requests, 0 to disable the cache" json:"cacheTTL"`
+	RequestsPerMinute int                            `toml:"requestsPerMinute" default:"0" comment:"Budget of requests per minute for each VCS server, 0 for no budget" json:"requestsPerMinute"`
+	Burst             int                            `toml:"burst" default:"50" comment:"Number of requests that can be sent at once to a VCS server when its budget is full" json:"burst"`
+	BackgroundShare   int                            `toml:"backgroundShare" default:"50" comment:"Percentage of the budget of a VCS server that can be used by background requests (polling, repository analysis), 0 for no restriction" json:"backgroundShare"`
+	QuotaReserve      int                            `toml:"quotaReserve" default:"10" comment:"Percentage of the rate limit quota of a VCS server reserved to interactive requests" json:"quotaReserve"`
+	MaxWait           int                            `toml:"maxWait" default:"10" comment:"Maximum time in seconds an interactive request waits for the budget of a VCS server" json:"maxWait"`
+	Servers           map[string]ServerConfiguration `toml:"servers" comment:"Budgets by VCS server host, overriding the default budget" json:"servers,omitempty"`
+}
+
+// ServerConfiguration overrides the budget of a VCS server
+type ServerConfiguration struct {
+	RequestsPerMinute int `toml:"requestsPerMinute" json:"requestsPerMinute"`
+	Burst             int `toml:"burst" json:"burst"`
+}
+
+// Cache stores the responses of the conditional requests, it is implemented by cache.Store.
+type Cache interface {
+	Get(key string, value interface{}) (bool, error)
+	SetWithDuration(key string, value interface{}, duration time.Duration) error
+}
+
+type contextKey string
+
+const contextKeyPriority contextKey = "vcs-priority"
+
+// ContextWithPriority returns a context for requests of the given priority.
+func ContextWithPriority(ctx context.Context, priority string) context.Context {
+	if priority != sdk.VCSPriorityBackground {
+		priority = sdk.VCSPriorityInteractive
+	}
+	return context.WithValue(ctx, contextKeyPriority, priority)
+}
+
+func priorityFromContext(ctx context.Context) (string, bool) {
+	priority, ok := ctx.Value(contextKeyPriority).(string)
+	return priority, ok
+}
+
+// Governor applies the budgets of the VCS servers and caches the responses of their APIs.
+type Governor struct {
+	cfg     Configuration
+	cache   Cache
+	mutex   sync.Mutex
+	servers map[string]*server
+}
+
+// NewGovernor returns a governor with the given configuration, the cache can be nil.
+func NewGovernor(cfg Configuration, cache Cache) *Governor {
+	return &Governor{
+		cfg:     cfg,
+		cache:   cache,
+		servers: make(map[string]*server),
+	}
+}
+
+var (
+	defaultGovernor   = NewGovernor(Configuration{}, nil)
+	defaultGovernorMu sync.RWMutex
+)
+
+// Configure sets the configuration and the cache of the governor used by the clients of the VCS drivers.
+func Configure(cfg Configuration, cache Cache) {
+	defaultGovernorMu.Lock()
+	defer defaultGovernorMu.Unlock()
+	defaultGovernor = NewGovernor(cfg, cache)
+}
+
+// DefaultGovernor returns the governor used by the clients of the VCS drivers.
+func DefaultGovernor() *Governor {
+	defaultGovernorMu.RLock()
+	defer defaultGovernorMu.RUnlock()
+	return defaultGovernor
+}
+
+// NewClient replaces the transport of an http client with a transport going through the default governor. The
+// priority of a request is read from its context, or from the given context if not set.
+func NewClient(ctx context.Context, c *http.Client) *http.Client {
+	priority, _ := priorityFromContext(ctx)
+	c.Transport = &Transport{
+		Base:     c.Transport,
+		priority: priority,
+	}
+	return c
+}
+
+// Transport is an http.RoundTripper that applies the budget of the VCS servers, tracks their rate limit quota and
+// sends conditional requests for the responses kept in cache.
+type Transport struct {
+	Base     http.RoundTripper
+	Governor *Governor
+	priority string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	g := t.Governor
+	if g == nil {
+		g = DefaultGovernor()
+	}
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	priority, ok := priorityFromContext(req.Context())
+	if !ok {
+		priority = t.priority
+	}
+	if priority != sdk.VCSPriorityBackground {
+		priority = sdk.VCSPriorityInteractive
+	}
+	return g.roundTrip(base, req, priority)
+}
+
+func (g *Governor) roundTrip(base http.RoundTripper, req *http.Request, priority string) (*http.Response, error) {
+	ctx := req.Context()
+	srv := g.server(req.URL.Host)
+
+	if err := srv.wait(ctx, priority); err != nil {
+		recordRequest(ctx, srv.name, priority, requestStatusRejected)
+		return nil, err
+	}
+
+	entry, key := g.cachedResponse(ctx, req)
+	if entry != nil {
+		req = req.Clone(ctx)
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	res, err := base.RoundTrip(req)
+	if err != nil {
+		recordRequest(ctx, srv.name, priority, requestStatusError)
+		return nil, err
+	}
+	srv.updateQuota(ctx, res)
+
+	if entry != nil && res.StatusCode == http.StatusNotModified {
+		recordRequest(ctx, srv.name, priority, requestStatusCacheHit)
+		return entry.response(req, res), nil
+	}
+	recordRequest(ctx, srv.name, priority, requestStatusSent)
+	if key != "" {
+		return g.storeResponse(ctx, key, res), nil
+	}
+	return res, nil
+}
+
+func (g *Governor) server(host string) *server {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	srv, ok := g.servers[host]
+	if !ok {
+		srv = newServer(host, g.cfg)
+		g.servers[host] = srv
+	}
+	return srv
+}
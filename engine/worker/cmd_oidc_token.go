@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/sdk"
+)
+
+var cmdOIDCTokenAudience string
+
+func cmdOIDCToken() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "oidc-token",
+		Short: "worker oidc-token [--audience=<audience>]",
+		Long: `
+Inside a job, print a short-lived OpenID Connect ID token signed by CDS.
+
+The token identifies the project, workflow, run, branch, environment and region of the current job.
+It can be exchanged against temporary credentials by cloud providers that trust the CDS API as an OIDC identity provider.
+
+	# Get a token for AWS STS
+	TOKEN=$(worker oidc-token --audience sts.amazonaws.com)
+		`,
+		Run: oidcTokenCmd(),
+	}
+	c.Flags().StringVar(&cmdOIDCTokenAudience, "audience", "", "Audience of the token, if empty the default audience defined by CDS administrators is used")
+	return c
+}
+
+func oidcTokenCmd() func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		portS := os.Getenv(internal.WorkerServerPort)
+		if portS == "" {
+			sdk.Exit("%s not found, are you running inside a CDS worker job?", internal.WorkerServerPort)
+		}
+
+		port, err := strconv.Atoi(portS)
+		if err != nil {
+			sdk.Exit("cannot parse '%s' as a port number", portS)
+		}
+
+		data, err := json.Marshal(sdk.JobIDTokenRequest{Audience: cmdOIDCTokenAudience})
+		if err != nil {
+			sdk.Exit("%v", err)
+		}
+
+		req, err := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/oidc/token", port), bytes.NewReader(data))
+		if err != nil {
+			sdk.Exit("cannot post oidc token (Request): %s", err)
+		}
+
+		client := http.DefaultClient
+		client.Timeout = 5 * time.Minute
+
+		resp, err := client.Do(req)
+		if err != nil {
+			sdk.Exit("command failed: %v", err)
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			sdk.Exit("oidc token failed: unable to read body %v", err)
+		}
+		if resp.StatusCode >= 300 {
+			sdk.Exit("oidc token failed: %s", string(body))
+		}
+
+		var token sdk.JobIDToken
+		if err := sdk.JSONUnmarshal(body, &token); err != nil {
+			sdk.Exit("cannot unmarshal oidc token: %v", err)
+		}
+		fmt.Print(token.Token)
+	}
+}
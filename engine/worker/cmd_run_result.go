@@ -8,7 +8,9 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -34,6 +36,7 @@ func cdmAddRunResult() *cobra.Command {
 	}
 	c.AddCommand(cmdRunResultAddArtifactIntegration())
 	c.AddCommand(cmdRunResultAddStaticFile())
+	c.AddCommand(cmdRunResultAddCodeQuality())
 	return c
 }
 
@@ -78,6 +81,73 @@ func addStaticFileRunResultCmd() func(cmd *cobra.Command, args []string) {
 	}
 }
 
+var cmdRunResultCodeQualityFormat string
+
+func cmdRunResultAddCodeQuality() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "code-quality",
+		Short: "worker run-result add code-quality <name> <path> [--format sarif|checkstyle]",
+		Long: `Inside a job, add a run result of type code-quality from a SARIF or checkstyle report.
+The annotations of the report are published on the commit by the repository manager.
+Absolute file paths are made relative to the current directory.
+
+Worker Command:
+
+	worker run-result add code-quality <name> <path> --format sarif
+
+Example:
+
+	worker run-result add code-quality golangci-lint ./report.xml --format checkstyle
+`,
+		Run: addCodeQualityRunResultCmd(),
+	}
+	c.Flags().StringVar(&cmdRunResultCodeQualityFormat, "format", sdk.CodeQualityFormatSARIF, "Report format: sarif or checkstyle")
+	return c
+}
+
+func addCodeQualityRunResultCmd() func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if len(args) != 2 {
+			sdk.Exit("missing arguments. Cmd: worker run-result add code-quality <name> <path> [--format sarif|checkstyle]")
+		}
+
+		report, err := os.ReadFile(args[1])
+		if err != nil {
+			sdk.Exit("cannot read report %s: %v", args[1], err)
+		}
+		annotations, err := sdk.ParseCodeQualityReport(cmdRunResultCodeQualityFormat, report)
+		if err != nil {
+			sdk.Exit("cannot parse report %s: %v", args[1], err)
+		}
+		if len(annotations) > sdk.VCSCheckRunMaxAnnotations {
+			annotations = annotations[:sdk.VCSCheckRunMaxAnnotations]
+		}
+
+		wd, err := os.Getwd()
+		if err != nil {
+			sdk.Exit("cannot get current directory: %v", err)
+		}
+		for i := range annotations {
+			if !filepath.IsAbs(annotations[i].Path) {
+				continue
+			}
+			if rel, err := filepath.Rel(wd, annotations[i].Path); err == nil && !strings.HasPrefix(rel, "..") {
+				annotations[i].Path = filepath.ToSlash(rel)
+			}
+		}
+
+		payload := sdk.WorkflowRunResultCodeQuality{
+			WorkflowRunResultArtifactCommon: sdk.WorkflowRunResultArtifactCommon{
+				Name: args[0],
+			},
+			Format:      cmdRunResultCodeQualityFormat,
+			Annotations: annotations,
+		}
+		data, _ := json.Marshal(payload)
+		addRunResult(data, sdk.WorkflowRunResultTypeCodeQuality)
+	}
+}
+
 func cmdRunResultAddArtifactIntegration() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "artifact-manager",
@@ -64,5 +64,9 @@ func RunCheckoutApplication(ctx context.Context, wk workerruntime.Runtime, a sdk
 	if x, ok := wk.BaseDir().(*afero.BasePathFs); ok {
 		workdirPath, _ = x.RealPath(workdirPath)
 	}
-	return gitClone(ctx, wk, wk.Parameters(), gitURL, workdirPath, dir, auth, opts)
+	var keyName string
+	if sdk.ParameterValue(wk.Parameters(), "git.connection.type") == "ssh" {
+		keyName = sdk.ParameterValue(wk.Parameters(), "git.ssh.key")
+	}
+	return gitClone(ctx, wk, wk.Parameters(), gitURL, workdirPath, dir, auth, previousKey(wk.Parameters(), secrets, keyName), opts)
 }
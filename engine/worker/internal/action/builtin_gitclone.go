@@ -3,8 +3,10 @@ package action
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"regexp"
 	"strconv"
@@ -33,7 +35,9 @@ func RunGitClone(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, se
 	submodules := sdk.ParameterFind(a.Parameters, "submodules")
 
 	var key *vcs.SSHKey
+	var keyName string
 	if privateKey != nil && privateKey.Value != "" {
+		keyName = privateKey.Value
 		// The private key parameter, contains the name of the private key to use.
 		// Let's look up in the secret list to find the content of the private key
 		privateKeyContent := sdk.VariableFind(secrets, "cds.key."+privateKey.Value+".priv")
@@ -90,6 +94,9 @@ func RunGitClone(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, se
 			return sdk.Result{}, fmt.Errorf("Could not use VCS Auth Strategy from application: %v", err)
 		}
 		key = &auth.PrivateKey
+		if sdk.ParameterValue(wk.Parameters(), "git.connection.type") == "ssh" {
+			keyName = sdk.ParameterValue(wk.Parameters(), "git.ssh.key")
+		}
 	}
 
 	if gitURL == "" {
@@ -158,10 +165,26 @@ func RunGitClone(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, se
 		workdirPath, _ = x.RealPath(workdirPath)
 	}
 
-	return gitClone(ctx, wk, wk.Parameters(), gitURL, workdirPath, dir, auth, opts)
+	return gitClone(ctx, wk, wk.Parameters(), gitURL, workdirPath, dir, auth, previousKey(wk.Parameters(), secrets, keyName), opts)
+}
+
+// previousKey returns the previous private key of given key if it is being rotated. Jobs receive both keys during the
+// grace period of a rotation, the previous one is used if the repository can't be cloned with the new one.
+func previousKey(params []sdk.Parameter, secrets []sdk.Variable, keyName string) *sdk.Variable {
+	if keyName == "" {
+		return nil
+	}
+	name := "cds.key." + keyName + sdk.KeyRotationPreviousSuffix + ".priv"
+	if v := sdk.VariableFind(secrets, name); v != nil && v.Value != "" {
+		return &sdk.Variable{Name: name, Value: v.Value, Type: string(sdk.KeyTypeSSH)}
+	}
+	if p := sdk.ParameterFind(params, name); p != nil && p.Value != "" {
+		return &sdk.Variable{Name: name, Value: p.Value, Type: string(sdk.KeyTypeSSH)}
+	}
+	return nil
 }
 
-func gitClone(ctx context.Context, w workerruntime.Runtime, params []sdk.Parameter, url, basedir, dir string, auth *git.AuthOpts, clone *git.CloneOpts) (sdk.Result, error) {
+func gitClone(ctx context.Context, w workerruntime.Runtime, params []sdk.Parameter, url, basedir, dir string, auth *git.AuthOpts, previousKey *sdk.Variable, clone *git.CloneOpts) (sdk.Result, error) {
 	//Prepare all options - logs
 	stdErr := new(bytes.Buffer)
 	stdOut := new(bytes.Buffer)
@@ -172,8 +195,35 @@ func gitClone(ctx context.Context, w workerruntime.Runtime, params []sdk.Paramet
 	}
 
 	//git.LogFunc = log.InfoWithoutCtx
-	//Perform the git clone
-	userLogCommand, err := git.Clone(url, basedir, dir, auth, clone, output)
+	//Perform the git clone, from the git mirror of the repositories service if available
+	userLogCommand, usedMirror, err := gitCloneFromMirror(ctx, w, url, basedir, dir, auth, clone, output)
+	if !usedMirror {
+		userLogCommand, err = git.Clone(url, basedir, dir, auth, clone, output)
+	} else if err != nil {
+		if len(stdErr.Bytes()) > 0 {
+			w.SendLog(ctx, workerruntime.LevelWarn, stdErr.String())
+		}
+		w.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("Unable to git clone from mirror, cloning from %s: %v", url, err))
+		stdErr.Reset()
+		stdOut.Reset()
+		userLogCommand, err = git.Clone(url, basedir, dir, auth, clone, output)
+	}
+	if err != nil && auth != nil && len(auth.PrivateKey.Content) > 0 && previousKey != nil {
+		w.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("Unable to git clone, retrying with the previous key of the rotated key: %v", err))
+		installedKey, errK := w.InstallKey(*previousKey)
+		if errK != nil {
+			return sdk.Result{}, errK
+		}
+		previousAuth := *auth
+		previousAuth.PrivateKey = vcs.SSHKey{
+			Filename: installedKey.PKey,
+			Content:  installedKey.Content,
+		}
+		auth = &previousAuth
+		stdErr.Reset()
+		stdOut.Reset()
+		userLogCommand, err = git.Clone(url, basedir, dir, auth, clone, output)
+	}
 
 	w.SendLog(ctx, workerruntime.LevelInfo, userLogCommand)
 
@@ -221,6 +271,62 @@ func gitClone(ctx context.Context, w workerruntime.Runtime, params []sdk.Paramet
 	return sdk.Result{Status: sdk.StatusSuccess, NewVariables: vars}, nil
 }
 
+// gitCloneFromMirror clones the repository from the git mirror of the repositories service. Only the repository of the
+// job is served by the mirror, with the access signed by CDS API. The credentials of the repository are sent to the
+// mirror that checks them against the remote repository. If the clone fails, the target directory is removed if it
+// did not exist before. The returned boolean is false if the mirror was not used, then nothing was cloned.
+func gitCloneFromMirror(ctx context.Context, w workerruntime.Runtime, url, basedir, dir string, auth *git.AuthOpts, clone *git.CloneOpts, output *git.OutputOpts) (string, bool, error) {
+	mirrorURL := w.GitMirrorURL()
+	isJobRepository := url == sdk.ParameterValue(w.Parameters(), "git.url") || url == sdk.ParameterValue(w.Parameters(), "git.http_url")
+	if mirrorURL == "" || !isJobRepository {
+		return "", false, nil
+	}
+
+	jobID, err := workerruntime.JobID(ctx)
+	if err != nil {
+		w.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("unable to get git mirror access, clone from the remote repository: %v", err))
+		return "", false, nil
+	}
+	access, err := w.Client().QueueJobGitMirrorAccess(ctx, jobID)
+	if err != nil {
+		w.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("unable to get git mirror access, clone from the remote repository: %v", err))
+		return "", false, nil
+	}
+
+	mirror := git.MirrorOpts{
+		URL: mirrorURL,
+		Headers: []string{
+			"Authorization: " + w.Client().GitMirrorAuthorization(),
+			git.HeaderMirrorAccess + ": " + access.Token,
+		},
+	}
+	if auth != nil {
+		if auth.Username != "" || auth.Password != "" {
+			basic := base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password))
+			mirror.Headers = append(mirror.Headers, git.HeaderMirrorUpstreamAuthorization+": Basic "+basic)
+		}
+		if len(auth.PrivateKey.Content) > 0 {
+			mirror.Headers = append(mirror.Headers, git.HeaderMirrorUpstreamSSHKey+": "+base64.StdEncoding.EncodeToString(auth.PrivateKey.Content))
+		}
+	}
+
+	target := dir
+	if target == "" {
+		t := strings.Split(strings.TrimSuffix(url, "/"), "/")
+		target = strings.TrimSuffix(t[len(t)-1], ".git")
+	}
+	if !sdk.PathIsAbs(target) {
+		target = filepath.Join(basedir, target)
+	}
+	_, errStat := os.Stat(target)
+
+	userLogCommand, err := git.CloneFromMirror(url, basedir, dir, mirror, clone, output)
+	if err != nil && os.IsNotExist(errStat) {
+		_ = os.RemoveAll(target)
+	}
+	return userLogCommand, true, err
+}
+
 func extractInfo(ctx context.Context, w workerruntime.Runtime, basedir, dir string, params []sdk.Parameter, tag, branch, commit string, opts *git.CloneOpts) ([]sdk.Variable, error) {
 	var res []sdk.Variable
 	author := sdk.ParameterValue(params, "git.author")
@@ -1,12 +1,15 @@
 package action
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/ovh/cds/engine/test"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/vcs/git"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRunGitCloneInSSHWithoutVCSStrategyShouldRaiseError(t *testing.T) {
@@ -286,3 +289,38 @@ func TestRunGitCloneWithSecret(t *testing.T) {
 
 	assert.DirExists(t, filepath.Join(wk.workingDirectory.File.Name(), ".git"))
 }
+
+func TestPreviousKey(t *testing.T) {
+	secrets := []sdk.Variable{
+		{Name: "cds.key.proj-ssh-foo.priv", Value: "new"},
+		{Name: "cds.key.proj-ssh-foo.previous.priv", Value: "previous"},
+	}
+	assert.Nil(t, previousKey(nil, secrets, ""))
+	assert.Nil(t, previousKey(nil, secrets, "proj-ssh-bar"))
+
+	k := previousKey(nil, secrets, "proj-ssh-foo")
+	if assert.NotNil(t, k) {
+		assert.Equal(t, "cds.key.proj-ssh-foo.previous.priv", k.Name)
+		assert.Equal(t, "previous", k.Value)
+		assert.Equal(t, string(sdk.KeyTypeSSH), k.Type)
+	}
+
+	params := []sdk.Parameter{{Name: "cds.key.proj-ssh-bar.previous.priv", Value: "previous"}}
+	assert.NotNil(t, previousKey(params, nil, "proj-ssh-bar"))
+}
+
+func TestGitCloneFromMirrorWithoutMirror(t *testing.T) {
+	wk, ctx := SetupTest(t)
+	wk.Params = append(wk.Params, sdk.Parameter{Name: "git.url", Value: "git@github.com:fsamin/dummy-empty-repo.git"})
+
+	basedir := t.TempDir()
+	userLogCommand, usedMirror, err := gitCloneFromMirror(ctx, wk, "git@github.com:fsamin/dummy-empty-repo.git", basedir, "", nil, &git.CloneOpts{}, &git.OutputOpts{})
+	require.NoError(t, err)
+	require.False(t, usedMirror)
+	require.Empty(t, userLogCommand)
+
+	// Nothing is cloned when the mirror is not used, the caller clones from the remote repository
+	entries, err := os.ReadDir(basedir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
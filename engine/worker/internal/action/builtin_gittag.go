@@ -35,16 +35,6 @@ func RunGitTag(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secr
 		return sdk.Result{}, errors.New("tag level is mandatory. It must be: 'major' or 'minor' or 'patch'")
 	}
 
-	gitURL, auth, err := vcsStrategy(ctx, wk, wk.Parameters(), secrets)
-	if err != nil {
-		return sdk.Result{}, err
-	}
-
-	var msg = ""
-	if tagMessage != nil {
-		msg = tagMessage.Value
-	}
-
 	cdsSemver := sdk.ParameterFind(wk.Parameters(), "cds.semver")
 	if cdsSemver == nil || cdsSemver.Value == "" {
 		return sdk.Result{}, errors.New("cds.semver is empty")
@@ -87,6 +77,43 @@ func RunGitTag(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secr
 		smver.Build = []string{tagMetadata.Value}
 	}
 
+	name := smver.String()
+	if prefix != nil && prefix.Value != "" {
+		name = fmt.Sprintf("%s%s", prefix.Value, name)
+	}
+
+	var msg, dir string
+	if tagMessage != nil {
+		msg = tagMessage.Value
+	}
+	if path != nil {
+		dir = path.Value
+	}
+
+	if err := createGitTag(ctx, wk, secrets, name, msg, dir); err != nil {
+		return sdk.Result{}, err
+	}
+
+	semverVar := sdk.Variable{
+		Name:  "cds.release.version",
+		Type:  sdk.StringVariable,
+		Value: name,
+	}
+
+	time.Sleep(5 * time.Second) // TODO: write here why we wait for 5 seconds
+	return sdk.Result{
+		Status:       sdk.StatusSuccess,
+		NewVariables: []sdk.Variable{semverVar},
+	}, nil
+}
+
+// createGitTag creates the tag in the given git directory and pushes it with the vcs config of the application.
+func createGitTag(ctx context.Context, wk workerruntime.Runtime, secrets []sdk.Variable, name, message, path string) error {
+	gitURL, auth, err := vcsStrategy(ctx, wk, wk.Parameters(), secrets)
+	if err != nil {
+		return err
+	}
+
 	var userTag string
 	userTrig := sdk.ParameterFind(wk.Parameters(), "cds.triggered_by.username")
 	if userTrig != nil && userTrig.Value != "" {
@@ -99,18 +126,15 @@ func RunGitTag(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secr
 	}
 
 	if userTag == "" {
-		return sdk.Result{}, fmt.Errorf("No user find to perform tag")
+		return fmt.Errorf("No user find to perform tag")
 	}
 
 	//Prepare all options - tag options
 	var tagOpts = &git.TagOpts{
-		Message:  msg,
-		Name:     smver.String(),
+		Message:  message,
+		Name:     name,
 		Username: userTag,
-	}
-
-	if prefix != nil && prefix.Value != "" {
-		tagOpts.Name = fmt.Sprintf("%s%s", prefix.Value, tagOpts.Name)
+		Path:     path,
 	}
 
 	if auth.SignKey.ID != "" {
@@ -118,15 +142,13 @@ func RunGitTag(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secr
 		tagOpts.SignID = auth.SignKey.ID
 
 		if err := os.WriteFile("pgp.pub.key", []byte(auth.SignKey.Public), 0600); err != nil {
-			return sdk.Result{}, fmt.Errorf("Cannot create pgp pub key file")
+			return fmt.Errorf("Cannot create pgp pub key file")
 		}
 		if err := os.WriteFile("pgp.key", []byte(tagOpts.SignKey), 0600); err != nil {
-			return sdk.Result{}, fmt.Errorf("Cannot create pgp key file")
+			return fmt.Errorf("Cannot create pgp key file")
 		}
 	}
 
-	// Run Git command
-
 	//Prepare all options - logs
 	stdErr := new(bytes.Buffer)
 	stdOut := new(bytes.Buffer)
@@ -136,12 +158,6 @@ func RunGitTag(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secr
 		Stdout: stdOut,
 	}
 
-	//git.LogFunc = log.InfoWithoutCtx
-
-	if path != nil {
-		tagOpts.Path = path.Value
-	}
-
 	//Perform the git tag
 	err = git.TagCreate(gitURL, auth, tagOpts, output)
 
@@ -154,18 +170,7 @@ func RunGitTag(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secr
 	}
 
 	if err != nil {
-		return sdk.Result{}, fmt.Errorf("Unable to git tag: %v", err)
-	}
-
-	semverVar := sdk.Variable{
-		Name:  "cds.release.version",
-		Type:  sdk.StringVariable,
-		Value: tagOpts.Name,
+		return fmt.Errorf("Unable to git tag: %v", err)
 	}
-
-	time.Sleep(5 * time.Second) // TODO: write here why we wait for 5 seconds
-	return sdk.Result{
-		Status:       sdk.StatusSuccess,
-		NewVariables: []sdk.Variable{semverVar},
-	}, nil
+	return nil
 }
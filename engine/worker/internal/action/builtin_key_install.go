@@ -29,11 +29,14 @@ func RunInstallKey(ctx context.Context, wk workerruntime.Runtime, a sdk.Action,
 		return res, fmt.Errorf("Cannot find any keys for your job")
 	}
 
-	var key *sdk.Variable
-	for _, k := range secrets {
-		if k.Name == ("cds.key." + keyName.Value + ".priv") {
-			key = &k
-			break
+	var key, previousKey *sdk.Variable
+	for i := range secrets {
+		switch secrets[i].Name {
+		case "cds.key." + keyName.Value + ".priv":
+			key = &secrets[i]
+		case "cds.key." + keyName.Value + sdk.KeyRotationPreviousSuffix + ".priv":
+			// The key is being rotated, the previous one stays valid during the grace period
+			previousKey = &secrets[i]
 		}
 	}
 
@@ -87,6 +90,22 @@ func RunInstallKey(ctx context.Context, wk workerruntime.Runtime, a sdk.Action,
 		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Your PGP key '%s' is imported with success (%s)", keyName.Value, response.PKey))
 	}
 
+	if previousKey != nil {
+		previousResponse, err := wk.InstallKeyTo(*previousKey, fpath+sdk.KeyRotationPreviousSuffix)
+		if err != nil {
+			return res, fmt.Errorf("Error: cannot install previous key for %s: %v", keyName.Value, err)
+		}
+		switch previousResponse.Type {
+		case sdk.KeyTypeSSH:
+			if err := os.Setenv("PKEY_PREVIOUS", previousResponse.PKey); err != nil {
+				return res, fmt.Errorf("Error: cannot export PKEY_PREVIOUS environment variable : %v", err)
+			}
+			wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Key '%s' is being rotated, previous SSH key is imported with success (%s)", keyName.Value, previousResponse.PKey))
+		case sdk.KeyTypePGP:
+			wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Key '%s' is being rotated, previous PGP key is imported with success (%s)", keyName.Value, previousResponse.PKey))
+		}
+	}
+
 	return sdk.Result{
 		Status: sdk.StatusSuccess,
 	}, nil
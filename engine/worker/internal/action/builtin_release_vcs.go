@@ -25,6 +25,8 @@ func RunReleaseVCS(ctx context.Context, wk workerruntime.Runtime, a sdk.Action,
 	tag := sdk.ParameterFind(a.Parameters, "tag")
 	title := sdk.ParameterFind(a.Parameters, "title")
 	releaseNote := sdk.ParameterFind(a.Parameters, "releaseNote")
+	generateReleaseNotes := sdk.ParameterFind(a.Parameters, "generateReleaseNotes")
+	previousTag := sdk.ParameterFind(a.Parameters, "previousTag")
 
 	pkey := sdk.ParameterFind(wk.Parameters(), "cds.project")
 	wName := sdk.ParameterFind(wk.Parameters(), "cds.workflow")
@@ -50,7 +52,8 @@ func RunReleaseVCS(ctx context.Context, wk workerruntime.Runtime, a sdk.Action,
 		return res, errors.New("release title is not set")
 	}
 
-	if releaseNote == nil || releaseNote.Value == "" {
+	generate := generateReleaseNotes != nil && generateReleaseNotes.Value == "true"
+	if !generate && (releaseNote == nil || releaseNote.Value == "") {
 		return res, errors.New("release note is not set")
 	}
 
@@ -59,12 +62,21 @@ func RunReleaseVCS(ctx context.Context, wk workerruntime.Runtime, a sdk.Action,
 		return res, fmt.Errorf("Workflow number is not a number. Got %s: %s", workflowNum.Value, errI)
 	}
 
-	artSplitted := strings.Split(artifactList.Value, ",")
+	var artSplitted []string
+	if artifactList != nil && artifactList.Value != "" {
+		artSplitted = strings.Split(artifactList.Value, ",")
+	}
 	req := sdk.WorkflowNodeRunRelease{
-		ReleaseContent: releaseNote.Value,
-		ReleaseTitle:   title.Value,
-		TagName:        tag.Value,
-		Artifacts:      artSplitted,
+		ReleaseTitle:         title.Value,
+		TagName:              tag.Value,
+		Artifacts:            artSplitted,
+		GenerateReleaseNotes: generate,
+	}
+	if releaseNote != nil {
+		req.ReleaseContent = releaseNote.Value
+	}
+	if previousTag != nil {
+		req.PreviousTag = previousTag.Value
 	}
 
 	jobrun, err := wk.Client().QueueJobInfo(ctx, jobID)
@@ -331,3 +331,75 @@ func TestRunReleaseMissingWorkflowRunNumber(t *testing.T) {
 	assert.Contains(t, "cds.run.number variable not found", err.Error())
 	assert.Equal(t, sdk.StatusFail, res.Status)
 }
+
+func TestRunReleaseGenerateReleaseNotes(t *testing.T) {
+	defer gock.Off()
+
+	wk, ctx := SetupTest(t)
+
+	gock.New("http://cds-api.local").Get("/queue/workflows/666/infos").
+		Reply(200).JSON(
+		sdk.WorkflowNodeJobRun{
+			WorkflowNodeRunID: 6,
+		})
+	gock.New("http://cds-api.local").Post("/project/projKey/workflows/workflowName/runs/999/nodes/6/release").
+		Reply(200)
+
+	var checkRequest gock.ObserverFunc = func(request *http.Request, mock gock.Mock) {
+		bodyContent, err := io.ReadAll(request.Body)
+		assert.NoError(t, err)
+		request.Body = io.NopCloser(bytes.NewReader(bodyContent))
+		if mock != nil && mock.Request().URLStruct.String() == "http://cds-api.local/project/projKey/workflows/workflowName/runs/999/nodes/6/release" {
+			var releaseRequest sdk.WorkflowNodeRunRelease
+			assert.NoError(t, json.Unmarshal(bodyContent, &releaseRequest))
+			require.Equal(t, "1.1.1", releaseRequest.TagName)
+			require.Empty(t, releaseRequest.ReleaseContent)
+			require.True(t, releaseRequest.GenerateReleaseNotes)
+			require.Equal(t, "1.0.0", releaseRequest.PreviousTag)
+			require.Empty(t, releaseRequest.Artifacts)
+		}
+	}
+	gock.Observe(checkRequest)
+
+	gock.InterceptClient(wk.Client().(cdsclient.Raw).HTTPClient())
+	gock.InterceptClient(wk.Client().(cdsclient.Raw).HTTPNoTimeoutClient())
+
+	wk.Params = append(wk.Params, []sdk.Parameter{
+		{
+			Name:  "cds.project",
+			Value: "projKey",
+		},
+		{
+			Name:  "cds.workflow",
+			Value: "workflowName",
+		},
+		{
+			Name:  "cds.run.number",
+			Value: "999",
+		},
+	}...)
+	res, err := RunReleaseVCS(ctx, wk,
+		sdk.Action{
+			Parameters: []sdk.Parameter{
+				{
+					Name:  "tag",
+					Value: "1.1.1",
+				},
+				{
+					Name:  "title",
+					Value: "My Title",
+				},
+				{
+					Name:  "generateReleaseNotes",
+					Value: "true",
+				},
+				{
+					Name:  "previousTag",
+					Value: "1.0.0",
+				},
+			},
+		}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, sdk.StatusSuccess, res.Status)
+	assert.True(t, gock.IsDone())
+}
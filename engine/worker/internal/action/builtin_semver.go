@@ -0,0 +1,149 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/vcs/git"
+)
+
+func RunSemver(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secrets []sdk.Variable) (sdk.Result, error) {
+	var res sdk.Result
+	res.Status = sdk.StatusFail
+	jobID, err := workerruntime.JobID(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	var path, prefix, tagMessage string
+	if p := sdk.ParameterFind(a.Parameters, "path"); p != nil {
+		path = p.Value
+	}
+	if p := sdk.ParameterFind(a.Parameters, "prefix"); p != nil {
+		prefix = p.Value
+	}
+	if p := sdk.ParameterFind(a.Parameters, "tagMessage"); p != nil {
+		tagMessage = p.Value
+	}
+	createTag := sdk.ParameterFind(a.Parameters, "createTag")
+
+	initialVersion := "0.1.0"
+	if p := sdk.ParameterFind(a.Parameters, "initialVersion"); p != nil && p.Value != "" {
+		initialVersion = p.Value
+	}
+	var releaseBranches []string
+	if p := sdk.ParameterFind(a.Parameters, "releaseBranches"); p != nil {
+		releaseBranches = strings.FieldsFunc(p.Value, func(r rune) bool { return r == ',' || r == ' ' })
+	}
+
+	runNumber := sdk.ParameterFind(wk.Parameters(), "cds.run.number")
+	if runNumber == nil || runNumber.Value == "" {
+		return res, fmt.Errorf("cds.run.number variable not found")
+	}
+
+	var branch string
+	if p := sdk.ParameterFind(wk.Parameters(), "git.branch"); p != nil && p.Value != "" {
+		branch = p.Value
+	} else {
+		info, err := git.ExtractInfo(ctx, path, &git.CloneOpts{})
+		if err != nil {
+			return res, err
+		}
+		branch = info.Branch
+	}
+
+	v, releasedTag, err := nextSemver(path, prefix, initialVersion)
+	if err != nil {
+		return res, err
+	}
+	tagName := prefix + v.String()
+
+	if releasedTag != "" {
+		// HEAD is already released, its tag is reused on any branch
+		tagName = releasedTag
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("No commit since tag %s", tagName))
+	} else if branch != "" && !sdk.IsInArray(branch, releaseBranches) {
+		v.Pre = []semver.PRVersion{{VersionStr: sdk.SemverIdentifier(branch)}}
+	}
+	v.Build = []string{"cds", runNumber.Value}
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Computed version %s on branch %s", v.String(), branch))
+
+	if err := wk.Client().QueueJobSetVersion(ctx, jobID, sdk.WorkflowRunVersion{Value: v.String()}); err != nil {
+		return res, fmt.Errorf("unable to set cds.version: %v", err)
+	}
+	// Override cds.version value in params to allow usage of this value in others steps
+	params := wk.Parameters()
+	for i := range params {
+		if params[i].Name == "cds.version" {
+			params[i].Value = v.String()
+			break
+		}
+	}
+
+	res.Status = sdk.StatusSuccess
+	if createTag == nil || createTag.Value != "true" {
+		return res, nil
+	}
+	if len(v.Pre) > 0 {
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Tag not created on branch %s, only release branches are tagged", branch))
+		return res, nil
+	}
+	if releasedTag != "" {
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Tag %s already exists, it is not created again", tagName))
+	} else if err := createGitTag(ctx, wk, secrets, tagName, tagMessage, path); err != nil {
+		res.Status = sdk.StatusFail
+		return res, err
+	}
+	res.NewVariables = []sdk.Variable{{
+		Name:  "cds.release.version",
+		Type:  sdk.StringVariable,
+		Value: tagName,
+	}}
+	return res, nil
+}
+
+// nextSemver returns the version of the latest release tag reachable from HEAD, incremented from the Conventional
+// Commits since this tag. Tags that don't start with the prefix, aren't semver or are pre-releases are ignored.
+// If there is no commit since the latest release tag, its version is returned unchanged with the name of the tag.
+func nextSemver(path, prefix, initialVersion string) (semver.Version, string, error) {
+	tags, err := git.TagsMerged(path)
+	if err != nil {
+		return semver.Version{}, "", err
+	}
+	var latestTag string
+	var latest semver.Version
+	for _, t := range tags {
+		if !strings.HasPrefix(t, prefix) {
+			continue
+		}
+		v, err := semver.ParseTolerant(strings.TrimPrefix(t, prefix))
+		if err != nil || len(v.Pre) > 0 {
+			continue
+		}
+		if latestTag == "" || v.GT(latest) {
+			latestTag, latest = t, v
+		}
+	}
+
+	if latestTag == "" {
+		v, err := semver.ParseTolerant(initialVersion)
+		if err != nil {
+			return v, "", fmt.Errorf("initial version '%s' is not semver compatible", initialVersion)
+		}
+		return v, "", nil
+	}
+
+	messages, err := git.CommitMessages(path, latestTag)
+	if err != nil {
+		return latest, "", err
+	}
+	if len(messages) == 0 {
+		return latest, latestTag, nil
+	}
+	return sdk.SemverBump(latest, sdk.ConventionalCommitsLevel(messages)), "", nil
+}
@@ -0,0 +1,121 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+func setupSemverRepository(t *testing.T, commands ...[]string) string {
+	dir := t.TempDir()
+	for _, args := range append([][]string{
+		{"init", "-b", "main"},
+		{"config", "user.name", "cds"},
+		{"config", "user.email", "cds@localhost"},
+	}, commands...) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	return dir
+}
+
+func TestRunSemver(t *testing.T) {
+	dir := setupSemverRepository(t,
+		[]string{"commit", "--allow-empty", "-m", "chore: init"},
+		[]string{"tag", "v1.0.0"},
+		[]string{"commit", "--allow-empty", "-m", "fix: a bug"},
+		[]string{"tag", "v1.0.1-rc1"},
+		[]string{"tag", "other"},
+		[]string{"commit", "--allow-empty", "-m", "feat(api): a route"},
+		[]string{"checkout", "-b", "feat/login"},
+		[]string{"commit", "--allow-empty", "-m", "fix: a bug on login"},
+		[]string{"tag", "rel-2.0"},
+	)
+
+	tests := []struct {
+		name           string
+		branch         string
+		prefix         string
+		createTag      bool
+		expected       string
+		releaseVersion string
+	}{
+		{name: "feature branch", branch: "feat/login", prefix: "v", expected: "1.1.0-feat-login+cds.42"},
+		{name: "release branch", branch: "main", prefix: "v", expected: "1.1.0+cds.42"},
+		{name: "no release tag", branch: "main", prefix: "release-", expected: "0.1.0+cds.42"},
+		// The repository has no remote, the existing tag must not be pushed again
+		{name: "no commit since release tag", branch: "main", prefix: "rel-", createTag: true, expected: "2.0.0+cds.42", releaseVersion: "rel-2.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+
+			wk, ctx := SetupTest(t)
+			gock.New("http://cds-api.local").Post("/queue/workflows/666/version").Reply(200)
+
+			var version sdk.WorkflowRunVersion
+			gock.Observe(func(request *http.Request, mock gock.Mock) {
+				if mock != nil && mock.Request().URLStruct.String() == "http://cds-api.local/queue/workflows/666/version" {
+					body, err := io.ReadAll(request.Body)
+					require.NoError(t, err)
+					require.NoError(t, json.Unmarshal(body, &version))
+				}
+			})
+			gock.InterceptClient(wk.Client().(cdsclient.Raw).HTTPClient())
+
+			wk.Params = append(wk.Params, []sdk.Parameter{
+				{Name: "cds.run.number", Value: "42"},
+				{Name: "cds.version", Value: "42"},
+				{Name: "git.branch", Value: tt.branch},
+			}...)
+			res, err := RunSemver(ctx, wk, sdk.Action{
+				Parameters: []sdk.Parameter{
+					{Name: "path", Value: dir},
+					{Name: "prefix", Value: tt.prefix},
+					{Name: "releaseBranches", Value: "master,main"},
+					{Name: "createTag", Value: fmt.Sprintf("%t", tt.createTag)},
+				},
+			}, nil)
+			require.NoError(t, err)
+			assert.Equal(t, sdk.StatusSuccess, res.Status)
+			assert.True(t, gock.IsDone())
+			assert.Equal(t, tt.expected, version.Value)
+			assert.Equal(t, tt.expected, sdk.ParameterValue(wk.Params, "cds.version"))
+			var releaseVersion string
+			for _, v := range res.NewVariables {
+				if v.Name == "cds.release.version" {
+					releaseVersion = v.Value
+				}
+			}
+			assert.Equal(t, tt.releaseVersion, releaseVersion)
+		})
+	}
+}
+
+func TestRunSemverBreakingChange(t *testing.T) {
+	dir := setupSemverRepository(t,
+		[]string{"commit", "--allow-empty", "-m", "chore: init"},
+		[]string{"tag", "1.4.2"},
+		[]string{"commit", "--allow-empty", "-m", "refactor!: drop old route"},
+	)
+
+	v, releasedTag, err := nextSemver(dir, "", "0.1.0")
+	require.NoError(t, err)
+	require.Equal(t, "2.0.0", v.String())
+	require.Empty(t, releasedTag)
+
+	_, _, err = nextSemver(dir, "v", "invalid")
+	require.Error(t, err)
+}
@@ -50,6 +50,10 @@ func (w *TestWorker) CDNHttpURL() string {
 	return "http://cds-cdn.local"
 }
 
+func (w *TestWorker) GitMirrorURL() string {
+	return ""
+}
+
 func (w *TestWorker) WorkingDirectory() *afero.BasePathFile {
 	return w.workingDirectory
 }
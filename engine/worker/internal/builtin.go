@@ -20,6 +20,7 @@ func init() {
 	mapBuiltinActions[sdk.JUnitAction] = action.RunParseJunitTestResultAction
 	mapBuiltinActions[sdk.GitCloneAction] = action.RunGitClone
 	mapBuiltinActions[sdk.GitTagAction] = action.RunGitTag
+	mapBuiltinActions[sdk.SemverAction] = action.RunSemver
 	mapBuiltinActions[sdk.ReleaseVCSAction] = action.RunReleaseVCS
 	mapBuiltinActions[sdk.ReleaseAction] = action.RunRelease
 	mapBuiltinActions[sdk.PromoteAction] = action.RunPromote
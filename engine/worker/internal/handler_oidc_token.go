@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+func oidcTokenHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := workerruntime.SetJobID(ctx, wk.currentJob.wJob.ID)
+		ctx = workerruntime.SetStepOrder(ctx, wk.currentJob.currentStepIndex)
+		ctx = workerruntime.SetStepName(ctx, wk.currentJob.currentStepName)
+
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+			return
+		}
+		defer r.Body.Close()
+
+		var req sdk.JobIDTokenRequest
+		if err := sdk.JSONUnmarshal(data, &req); err != nil {
+			writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+			return
+		}
+
+		token, err := wk.client.QueueJobIDToken(ctx, wk.currentJob.wJob.ID, req)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, token, http.StatusOK)
+	}
+}
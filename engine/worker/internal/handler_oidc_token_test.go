@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient/mock_cdsclient"
+)
+
+func Test_oidcTokenHandler(t *testing.T) {
+	// Setup test worker
+	wk := &CurrentWorker{}
+	wk.currentJob.wJob = &sdk.WorkflowNodeJobRun{
+		ID: 1,
+	}
+
+	// Prepare mock client for cds workers
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() { ctrl.Finish() })
+	m := mock_cdsclient.NewMockWorkerInterface(ctrl)
+	wk.client = m
+
+	m.EXPECT().QueueJobIDToken(gomock.Any(), int64(1), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, jobID int64, req sdk.JobIDTokenRequest) (sdk.JobIDToken, error) {
+			assert.Equal(t, "sts.amazonaws.com", req.Audience)
+			return sdk.JobIDToken{Token: "my-token", ExpiresAt: 1234}, nil
+		},
+	).Times(1)
+
+	buf, err := json.Marshal(sdk.JobIDTokenRequest{
+		Audience: "sts.amazonaws.com",
+	})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, "", bytes.NewBuffer(buf))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	oidcTokenHandler(context.Background(), wk)(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var token sdk.JobIDToken
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &token))
+	assert.Equal(t, "my-token", token.Token)
+}
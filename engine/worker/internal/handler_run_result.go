@@ -48,6 +48,12 @@ func addRunResultStaticFileHandler(ctx context.Context, wk *CurrentWorker) http.
 	}
 }
 
+func addRunResultCodeQualityHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		addRunResult(ctx, wk, w, r, sdk.WorkflowRunResultTypeCodeQuality)
+	}
+}
+
 func addRunResult(ctx context.Context, wk *CurrentWorker, w http.ResponseWriter, r *http.Request, stype sdk.WorkflowRunResultType) {
 	ctx = workerruntime.SetJobID(ctx, wk.currentJob.wJob.ID)
 	ctx = workerruntime.SetStepOrder(ctx, wk.currentJob.currentStepIndex)
@@ -79,6 +85,18 @@ func addRunResult(ctx context.Context, wk *CurrentWorker, w http.ResponseWriter,
 			return
 		}
 		name = reqArgs.Name
+	case sdk.WorkflowRunResultTypeCodeQuality:
+		var reqArgs sdk.WorkflowRunResultCodeQuality
+		if err := sdk.JSONUnmarshal(data, &reqArgs); err != nil {
+			newError := sdk.NewError(sdk.ErrWrongRequest, err)
+			writeError(w, r, newError)
+			return
+		}
+		if err := reqArgs.IsValid(); err != nil {
+			writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+			return
+		}
+		name = reqArgs.Name
 	}
 
 	runID, runNodeID, runJobID := wk.GetJobIdentifiers()
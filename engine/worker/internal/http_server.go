@@ -51,6 +51,7 @@ func (w *CurrentWorker) Serve(c context.Context) error {
 	r.HandleFunc("/download", LogMiddleware(downloadHandler(c, w)))
 	r.HandleFunc("/exit", LogMiddleware(exitHandler(c, w)))
 	r.HandleFunc("/key/{key}/install", LogMiddleware(keyInstallHandler(c, w)))
+	r.HandleFunc("/oidc/token", LogMiddleware(oidcTokenHandler(c, w)))
 	r.HandleFunc("/tag", LogMiddleware(tagHandler(c, w)))
 	r.HandleFunc("/tmpl", LogMiddleware(tmplHandler(c, w)))
 	r.HandleFunc("/upload", LogMiddleware(uploadHandler(c, w)))
@@ -59,6 +60,7 @@ func (w *CurrentWorker) Serve(c context.Context) error {
 	r.HandleFunc("/run-result", LogMiddleware(getRunResultHandler(c, w)))
 	r.HandleFunc("/run-result/add/artifact-manager", LogMiddleware(addRunResultArtifactManagerHandler(c, w)))
 	r.HandleFunc("/run-result/add/static-file", LogMiddleware(addRunResultStaticFileHandler(c, w)))
+	r.HandleFunc("/run-result/add/code-quality", LogMiddleware(addRunResultCodeQualityHandler(c, w)))
 	r.HandleFunc("/vulnerability", LogMiddleware(vulnerabilityHandler(c, w)))
 	r.HandleFunc("/version", LogMiddleware(setVersionHandler(c, w)))
 
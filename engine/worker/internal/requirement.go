@@ -27,6 +27,8 @@ var requirementCheckFuncs = map[string]func(w *CurrentWorker, r sdk.Requirement)
 	sdk.OSArchRequirement:   checkOSArchRequirement,
 	sdk.RegionRequirement:   checkRegionRequirement,
 	sdk.SecretRequirement:   checkSecretRequirement,
+
+	sdk.RegionPreferenceRequirement: checkRegionRequirement,
 }
 
 func checkRequirements(ctx context.Context, w *CurrentWorker, a *sdk.Action) (bool, []sdk.Requirement) {
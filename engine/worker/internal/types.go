@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
@@ -20,6 +21,7 @@ import (
 	"github.com/ovh/cds/sdk/jws"
 	cdslog "github.com/ovh/cds/sdk/log"
 	loghook "github.com/ovh/cds/sdk/log/hook"
+	"github.com/ovh/cds/sdk/telemetry"
 )
 
 const (
@@ -67,9 +69,13 @@ type CurrentWorker struct {
 		Name   string `json:"name"`
 		Status string `json:"status"`
 	}
-	client cdsclient.WorkerInterface
-	blur   *sdk.Blur
-	hooks  []workerHook
+	client    cdsclient.WorkerInterface
+	blur      *sdk.Blur
+	hooks     []workerHook
+	gitMirror struct {
+		once sync.Once
+		url  string
+	}
 }
 
 type workerHook struct {
@@ -197,6 +203,19 @@ func (wk *CurrentWorker) CDNHttpURL() string {
 	return wk.cfg.CDNEndpoint
 }
 
+// GitMirrorURL returns the url of the git mirrors served by the repositories service, empty if mirrors are disabled.
+func (wk *CurrentWorker) GitMirrorURL() string {
+	wk.gitMirror.once.Do(func() {
+		cfg, err := wk.Client().ConfigRepositories()
+		if err != nil {
+			log.Warn(context.Background(), "unable to get repositories configuration: %v", err)
+			return
+		}
+		wk.gitMirror.url = cfg.MirrorURL
+	})
+	return wk.gitMirror.url
+}
+
 func (wk *CurrentWorker) prepareLog(ctx context.Context, level workerruntime.Level, s string) (cdslog.Message, string, error) {
 	var ts = time.Now().UnixNano()
 	var res cdslog.Message
@@ -287,6 +306,12 @@ func (wk *CurrentWorker) Environ() []string {
 		data := []byte(wk.currentJob.wJob.Job.Job.Action.Name)
 		suffix := fmt.Sprintf("%x", md5.Sum(data))
 		newEnv = append(newEnv, "BASEDIR="+wk.cfg.Basedir+"/"+suffix)
+
+		// Allow tools launched by the steps to attach their spans to the job span of the workflow run trace
+		if traceID, ok := telemetry.TraceIDFromHeaders(wk.currentJob.wJob.Header); ok {
+			spanID := telemetry.WorkflowSpanID(traceID, telemetry.WorkflowSpanJob, wk.currentJob.wJob.ID)
+			newEnv = append(newEnv, telemetry.TraceParentEnv+"="+telemetry.TraceParent(traceID, spanID))
+		}
 	} else {
 		newEnv = append(newEnv, "BASEDIR="+wk.cfg.Basedir)
 	}
@@ -22,6 +22,7 @@ func main() {
 	cmd.AddCommand(cmdJunitParser())
 	cmd.AddCommand(cmdCDSVersionSet())
 	cmd.AddCommand(cmdRunResult())
+	cmd.AddCommand(cmdOIDCToken())
 
 	// last command: doc, this command is hidden
 	cmd.AddCommand(cmdDoc(cmd))
@@ -104,6 +104,7 @@ type Runtime interface {
 	GetPlugin(pluginType string) *sdk.GRPCPlugin
 	GetJobIdentifiers() (int64, int64, int64)
 	CDNHttpURL() string
+	GitMirrorURL() string
 	InstallKey(key sdk.Variable) (*KeyResponse, error)
 	InstallKeyTo(key sdk.Variable, destinationPath string) (*KeyResponse, error)
 	Unregister(ctx context.Context) error
@@ -21,6 +21,7 @@ const (
 	CoverageAction            = "Coverage"
 	GitCloneAction            = "GitClone"
 	GitTagAction              = "GitTag"
+	SemverAction              = "Semver"
 	ReleaseVCSAction          = "ReleaseVCS"
 	CheckoutApplicationAction = "CheckoutApplication"
 	DeployApplicationAction   = "DeployApplication"
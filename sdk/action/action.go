@@ -25,6 +25,7 @@ var List = []Manifest{
 	ReleaseVCS,
 	Release,
 	Script,
+	Semver,
 }
 
 // Manifest for a action.
@@ -9,7 +9,10 @@ import (
 var ReleaseVCS = Manifest{
 	Action: sdk.Action{
 		Name:        sdk.ReleaseVCSAction,
-		Description: "This action creates a release on the git repository linked to the application, if repository manager implements it.",
+		Description: `This action creates a release on the git repository linked to the application.
+On GitHub, GitLab and Gitea the release is created on the tag and the artifacts are attached to it.
+Bitbucket has no release API: the tag is created as an annotated tag, on the latest commit of the default branch, whose message holds the title and the release note, so the tag must not exist yet.
+On Bitbucket Cloud the artifacts are uploaded to the downloads of the repository, on Bitbucket Server they can't be attached.`,
 		Parameters: []sdk.Parameter{
 			{
 				Name:        "tag",
@@ -25,9 +28,20 @@ var ReleaseVCS = Manifest{
 			},
 			{
 				Name:        "releaseNote",
-				Description: "(optional) Set a release note for the release.",
+				Description: "(optional) Set a release note for the release. If release notes are generated, it is added before the generated content.",
 				Type:        sdk.TextParameter,
 			},
+			{
+				Name:        "generateReleaseNotes",
+				Description: "(optional) Generate the release notes from the commits since the previous tag, grouped by Conventional Commit type, with the linked pull requests, the contributors and the uploaded artifacts. The Go template can be overridden with the project variable " + sdk.ReleaseNotesTemplateVariable + ".",
+				Value:       "false",
+				Type:        sdk.BooleanParameter,
+			},
+			{
+				Name:        "previousTag",
+				Description: "(optional) Tag of the previous release used to generate the release notes. By default the greatest semver tag lower than the released tag.",
+				Type:        sdk.StringParameter,
+			},
 			{
 				Name:        "artifacts",
 				Description: "(optional) Set a list of artifacts, separate by ','. You can also use regexp.",
@@ -69,10 +83,11 @@ var ReleaseVCS = Manifest{
 				},
 				{
 					ReleaseVCS: &exportentities.StepReleaseVCS{
-						Artifacts:   "{{.cds.workspace}}/myFile",
-						Title:       "{{.cds.build.tag}}",
-						ReleaseNote: "My release {{.cds.build.tag}}",
-						Tag:         "{{.cds.build.tag}}",
+						Artifacts:            "{{.cds.workspace}}/myFile",
+						Title:                "{{.cds.build.tag}}",
+						ReleaseNote:          "My release {{.cds.build.tag}}",
+						Tag:                  "{{.cds.build.tag}}",
+						GenerateReleaseNotes: "true",
 					},
 				},
 			},
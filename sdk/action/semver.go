@@ -0,0 +1,92 @@
+package action
+
+import (
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// Semver action definition.
+var Semver = Manifest{
+	Action: sdk.Action{
+		Name: sdk.SemverAction,
+		Description: `Compute the next semantic version from the git history and set it as {{.cds.version}}.
+The latest release tag reachable from HEAD is incremented from the Conventional Commits since this tag: major for a breaking change, minor for a feature, patch otherwise.
+A pre-release with the branch name is added on branches that are not release branches, and the build metadata contains the run number. Example: 1.3.0-feat-login+cds.42.
+`,
+		Parameters: []sdk.Parameter{
+			{
+				Name:        "path",
+				Description: "(optional) The path to your git directory.",
+				Value:       "{{.cds.workspace}}",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "prefix",
+				Description: "(optional) Prefix of the release tags. Example: v.",
+				Value:       "",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "initialVersion",
+				Description: "(optional) Version used if there is no release tag.",
+				Value:       "0.1.0",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "releaseBranches",
+				Description: "(optional) Branches without pre-release version, separated by ','.",
+				Value:       "master,main",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "createTag",
+				Description: "(optional) Create and push the tag of the computed version on release branches, without the build metadata. If there is no commit since the latest release tag, this tag is reused instead. The tag is exported as {{.cds.release.version}}.",
+				Value:       "false",
+				Type:        sdk.BooleanParameter,
+			},
+			{
+				Name:        "tagMessage",
+				Description: "(optional) Set a message for the tag.",
+				Value:       "",
+				Type:        sdk.StringParameter,
+			},
+		},
+		Requirements: []sdk.Requirement{
+			{
+				Name:  "git",
+				Type:  sdk.BinaryRequirement,
+				Value: "git",
+			},
+		},
+	},
+	Example: exportentities.PipelineV1{
+		Version: exportentities.PipelineVersion1,
+		Name:    "Pipeline1",
+		Stages:  []string{"Stage1"},
+		Jobs: []exportentities.Job{{
+			Name:  "Job1",
+			Stage: "Stage1",
+			Steps: []exportentities.Step{
+				{
+					Checkout: &checkoutExample,
+				},
+				{
+					Semver: &exportentities.StepSemver{
+						Path:       "{{.cds.workspace}}",
+						Prefix:     "v",
+						CreateTag:  "true",
+						TagMessage: "Release from CDS run {{.cds.version}}",
+					},
+				},
+				{
+					ReleaseVCS: &exportentities.StepReleaseVCS{
+						Tag:                  "{{.cds.release.version}}",
+						Title:                "{{.cds.release.version}}",
+						GenerateReleaseNotes: "true",
+					},
+				},
+			},
+		}},
+	},
+}
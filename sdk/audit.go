@@ -63,3 +63,75 @@ type AuditAction struct {
 	DataBefore string `json:"data_before" db:"data_before"`
 	DataAfter  string `json:"data_after" db:"data_after"`
 }
+
+// Audit log target types.
+const (
+	AuditTargetRBAC         = "rbac"
+	AuditTargetRegion       = "region"
+	AuditTargetHatchery     = "hatchery"
+	AuditTargetOrganization = "organization"
+	AuditTargetConsumer     = "consumer"
+	AuditTargetProjectKey   = "project_key"
+	AuditTargetAppKey       = "application_key"
+	AuditTargetUser         = "user"
+	AuditTargetGroup        = "group"
+)
+
+// Audit log actions, formatted as <target_type>.<operation>.
+const (
+	AuditActionRBACImport         = AuditTargetRBAC + ".import"
+	AuditActionRegionAdd          = AuditTargetRegion + "." + AuditAdd
+	AuditActionRegionDelete       = AuditTargetRegion + "." + AuditDelete
+	AuditActionHatcheryAdd        = AuditTargetHatchery + "." + AuditAdd
+	AuditActionHatcheryDelete     = AuditTargetHatchery + "." + AuditDelete
+	AuditActionOrganizationAdd    = AuditTargetOrganization + "." + AuditAdd
+	AuditActionOrganizationDelete = AuditTargetOrganization + "." + AuditDelete
+	AuditActionConsumerAdd        = AuditTargetConsumer + "." + AuditAdd
+	AuditActionConsumerDelete     = AuditTargetConsumer + "." + AuditDelete
+	AuditActionConsumerRegen      = AuditTargetConsumer + ".regen"
+	AuditActionProjectKeyAdd      = AuditTargetProjectKey + "." + AuditAdd
+	AuditActionProjectKeyDelete   = AuditTargetProjectKey + "." + AuditDelete
+	AuditActionProjectKeyRotate   = AuditTargetProjectKey + ".rotate"
+	AuditActionProjectKeyRetire   = AuditTargetProjectKey + ".retire"
+	AuditActionProjectKeyPolicy   = AuditTargetProjectKey + ".policy"
+	AuditActionAppKeyRotate       = AuditTargetAppKey + ".rotate"
+	AuditActionAppKeyRetire       = AuditTargetAppKey + ".retire"
+	AuditActionUserAdd            = AuditTargetUser + "." + AuditAdd
+	AuditActionUserUpdate         = AuditTargetUser + "." + AuditUpdate
+	AuditActionUserDelete         = AuditTargetUser + "." + AuditDelete
+	AuditActionUserMFAReset       = AuditTargetUser + ".mfa_reset"
+	AuditActionGroupAdd           = AuditTargetGroup + "." + AuditAdd
+	AuditActionGroupUpdate        = AuditTargetGroup + "." + AuditUpdate
+	AuditActionGroupDelete        = AuditTargetGroup + "." + AuditDelete
+)
+
+// AuditLog is an entry of the unified audit trail. It records who did a security sensitive action
+// and the state of the target before and after the action.
+type AuditLog struct {
+	ID           int64     `json:"id" db:"id" cli:"id,key"`
+	Created      time.Time `json:"created" db:"created" cli:"created"`
+	Action       string    `json:"action" db:"action" cli:"action"`
+	TargetType   string    `json:"target_type" db:"target_type" cli:"target_type"`
+	TargetName   string    `json:"target_name" db:"target_name" cli:"target_name"`
+	ProjectKey   string    `json:"project_key,omitempty" db:"project_key" cli:"project_key"`
+	UserID       string    `json:"user_id,omitempty" db:"user_id"`
+	Username     string    `json:"username,omitempty" db:"username" cli:"username"`
+	ConsumerID   string    `json:"consumer_id,omitempty" db:"consumer_id"`
+	ConsumerName string    `json:"consumer_name,omitempty" db:"consumer_name" cli:"consumer"`
+	IPAddress    string    `json:"ip_address,omitempty" db:"ip_address" cli:"ip_address"`
+	DataBefore   string    `json:"data_before,omitempty" db:"data_before"`
+	DataAfter    string    `json:"data_after,omitempty" db:"data_after"`
+}
+
+// AuditLogFilter contains the criteria to search audit logs, empty values are ignored.
+type AuditLogFilter struct {
+	Action     string
+	TargetType string
+	TargetName string
+	ProjectKey string
+	Username   string
+	Since      time.Time
+	Until      time.Time
+	Offset     int64
+	Limit      int64
+}
@@ -0,0 +1,133 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// AuthLocalFactorType is the type of a second factor for the local auth driver.
+type AuthLocalFactorType string
+
+// Second factor types.
+const (
+	AuthLocalFactorTOTP          AuthLocalFactorType = "totp"
+	AuthLocalFactorWebAuthn      AuthLocalFactorType = "webauthn"
+	AuthLocalFactorRecoveryCodes AuthLocalFactorType = "recovery_codes"
+)
+
+// AuthLocalFactor is a second factor enrolled by a local user. Secrets are never returned by the API.
+type AuthLocalFactor struct {
+	ID                 string              `json:"id" cli:"id,key" db:"id"`
+	AuthentifiedUserID string              `json:"authentified_user_id" cli:"-" db:"authentified_user_id"`
+	Type               AuthLocalFactorType `json:"type" cli:"type" db:"type"`
+	Name               string              `json:"name" cli:"name" db:"name"`
+	Created            time.Time           `json:"created" cli:"created" db:"created"`
+	LastUsed           *time.Time          `json:"last_used,omitempty" cli:"last_used" db:"last_used"`
+	Verified           bool                `json:"verified" cli:"verified" db:"verified"`
+}
+
+// AuthLocalFactorData contains the secrets of a second factor.
+type AuthLocalFactorData struct {
+	// TOTP shared secret encoded in base32 and last counter used to prevent replay
+	TOTPSecret      string `json:"totp_secret,omitempty"`
+	TOTPLastCounter uint64 `json:"totp_last_counter,omitempty"`
+	// WebAuthn credential with its COSE encoded public key
+	CredentialID []byte `json:"credential_id,omitempty"`
+	PublicKey    []byte `json:"public_key,omitempty"`
+	SignCount    uint32 `json:"sign_count,omitempty"`
+	// Hashes of the remaining recovery codes
+	RecoveryCodeHashes []string `json:"recovery_code_hashes,omitempty"`
+}
+
+// Value returns driver.Value from AuthLocalFactorData.
+func (d AuthLocalFactorData) Value() (driver.Value, error) {
+	j, err := json.Marshal(d)
+	return j, WrapError(err, "cannot marshal AuthLocalFactorData")
+}
+
+// Scan AuthLocalFactorData.
+func (d *AuthLocalFactorData) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(fmt.Errorf("type assertion .([]byte) failed (%T)", src))
+	}
+	return WrapError(JSONUnmarshal(source, d), "cannot unmarshal AuthLocalFactorData")
+}
+
+// AuthLocalTOTPEnrollment is returned when a TOTP enrollment starts, the factor should then be verified with a code.
+type AuthLocalTOTPEnrollment struct {
+	FactorID string `json:"factor_id"`
+	Secret   string `json:"secret"`
+	URI      string `json:"uri"`
+}
+
+// AuthLocalRecoveryCodes contains new recovery codes, they are only returned once.
+type AuthLocalRecoveryCodes struct {
+	Codes []string `json:"codes"`
+}
+
+// WebAuthnRelyingParty identifies CDS for the authenticator.
+type WebAuthnRelyingParty struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// WebAuthnUser identifies the user for the authenticator, ID is base64url encoded.
+type WebAuthnUser struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// WebAuthnCredentialParameter is a supported public key algorithm (COSE identifier).
+type WebAuthnCredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+// WebAuthnCredentialDescriptor identifies a registered credential, ID is base64url encoded.
+type WebAuthnCredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// WebAuthnCreationOptions are given to navigator.credentials.create(), binary values are base64url encoded.
+type WebAuthnCreationOptions struct {
+	Challenge          string                         `json:"challenge"`
+	RelyingParty       WebAuthnRelyingParty           `json:"rp"`
+	User               WebAuthnUser                   `json:"user"`
+	PubKeyCredParams   []WebAuthnCredentialParameter  `json:"pubKeyCredParams"`
+	Timeout            int64                          `json:"timeout"`
+	Attestation        string                         `json:"attestation"`
+	ExcludeCredentials []WebAuthnCredentialDescriptor `json:"excludeCredentials,omitempty"`
+}
+
+// WebAuthnRequestOptions are given to navigator.credentials.get(), binary values are base64url encoded.
+type WebAuthnRequestOptions struct {
+	Challenge        string                         `json:"challenge"`
+	RelyingPartyID   string                         `json:"rpId"`
+	Timeout          int64                          `json:"timeout"`
+	UserVerification string                         `json:"userVerification"`
+	AllowCredentials []WebAuthnCredentialDescriptor `json:"allowCredentials"`
+}
+
+// WebAuthnCredential is the public key credential returned by the authenticator at registration or assertion,
+// binary values are base64url encoded.
+type WebAuthnCredential struct {
+	Name     string `json:"name,omitempty"`
+	ID       string `json:"id"`
+	RawID    string `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string `json:"clientDataJSON"`
+		AttestationObject string `json:"attestationObject,omitempty"`
+		AuthenticatorData string `json:"authenticatorData,omitempty"`
+		Signature         string `json:"signature,omitempty"`
+		UserHandle        string `json:"userHandle,omitempty"`
+	} `json:"response"`
+}
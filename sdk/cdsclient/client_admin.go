@@ -8,12 +8,46 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/ovh/cds/cli"
 	"github.com/ovh/cds/sdk"
 )
 
+func (c *client) AdminAuditLogs(ctx context.Context, filter sdk.AuditLogFilter) ([]sdk.AuditLog, error) {
+	var mods []RequestModifier
+	for k, v := range map[string]string{
+		"action":     filter.Action,
+		"targetType": filter.TargetType,
+		"target":     filter.TargetName,
+		"project":    filter.ProjectKey,
+		"username":   filter.Username,
+	} {
+		if v != "" {
+			mods = append(mods, WithQueryParameter(k, v))
+		}
+	}
+	if !filter.Since.IsZero() {
+		mods = append(mods, WithQueryParameter("since", filter.Since.Format(time.RFC3339)))
+	}
+	if !filter.Until.IsZero() {
+		mods = append(mods, WithQueryParameter("until", filter.Until.Format(time.RFC3339)))
+	}
+	if filter.Limit > 0 {
+		mods = append(mods, WithQueryParameter("limit", strconv.FormatInt(filter.Limit, 10)))
+	}
+	if filter.Offset > 0 {
+		mods = append(mods, WithQueryParameter("offset", strconv.FormatInt(filter.Offset, 10)))
+	}
+
+	var logs []sdk.AuditLog
+	if _, err := c.GetJSON(ctx, "/admin/audit", &logs, mods...); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
 func (c *client) AdminOrganizationMigrateUser(ctx context.Context, orgaIdentifier string) error {
 	if _, err := c.PostJSON(ctx, fmt.Sprintf("/admin/organization/%s/migrate-user", orgaIdentifier), nil, nil); err != nil {
 		return err
@@ -385,3 +419,55 @@ func (c *client) AdminWorkflowUpdateMaxRuns(projectKey string, workflowName stri
 	}
 	return nil
 }
+
+func (c *client) AdminDatabaseRollJobCreate(service string, req sdk.DatabaseRollJobRequest) ([]sdk.DatabaseRollJob, error) {
+	var res []sdk.DatabaseRollJob
+	var f = c.switchServiceCallFunc(service, http.MethodPost, "/admin/database/roll", req, &res)
+	_, err := f()
+	return res, err
+}
+
+func (c *client) AdminDatabaseRollJobList(service string) ([]sdk.DatabaseRollJob, error) {
+	var res []sdk.DatabaseRollJob
+	var f = c.switchServiceCallFunc(service, http.MethodGet, "/admin/database/roll", nil, &res)
+	_, err := f()
+	return res, err
+}
+
+func (c *client) AdminDatabaseRollJobGet(service string, id int64) (*sdk.DatabaseRollJob, error) {
+	var res sdk.DatabaseRollJob
+	var f = c.switchServiceCallFunc(service, http.MethodGet, fmt.Sprintf("/admin/database/roll/%d", id), nil, &res)
+	if _, err := f(); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *client) AdminDatabaseRollJobCancel(service string, id int64) error {
+	var f = c.switchServiceCallFunc(service, http.MethodPost, fmt.Sprintf("/admin/database/roll/%d/cancel", id), nil, nil)
+	_, err := f()
+	return err
+}
+
+func (c *client) AdminQueueQuotaList(ctx context.Context) ([]sdk.QueueQuota, error) {
+	var res []sdk.QueueQuota
+	if _, err := c.GetJSON(ctx, "/admin/queue/quota", &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) AdminQueueQuotaCreate(ctx context.Context, q *sdk.QueueQuota) error {
+	_, err := c.PostJSON(ctx, "/admin/queue/quota", q, q)
+	return err
+}
+
+func (c *client) AdminQueueQuotaUpdate(ctx context.Context, q *sdk.QueueQuota) error {
+	_, err := c.PutJSON(ctx, fmt.Sprintf("/admin/queue/quota/%d", q.ID), q, q)
+	return err
+}
+
+func (c *client) AdminQueueQuotaDelete(ctx context.Context, id int64) error {
+	_, err := c.DeleteJSON(ctx, fmt.Sprintf("/admin/queue/quota/%d", id), nil)
+	return err
+}
@@ -24,3 +24,11 @@ func (c *client) ApplicationKeysDelete(projectKey string, appName string, keyNam
 	_, _, _, err := c.Request(context.Background(), "DELETE", "/project/"+projectKey+"/application/"+appName+"/keys/"+url.QueryEscape(keyName), nil)
 	return err
 }
+
+func (c *client) ApplicationKeyRotate(projectKey string, appName string, keyName string, req sdk.KeyRotationRequest) (*sdk.KeyRotation, error) {
+	var r sdk.KeyRotation
+	if _, err := c.PostJSON(context.Background(), "/project/"+projectKey+"/application/"+appName+"/keys/"+url.QueryEscape(keyName)+"/rotate", req, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
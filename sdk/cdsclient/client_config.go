@@ -21,3 +21,11 @@ func (c *client) ConfigCDN() (sdk.CDNConfig, error) {
 	}
 	return res, nil
 }
+
+func (c *client) ConfigRepositories() (sdk.RepositoriesConfig, error) {
+	var res sdk.RepositoriesConfig
+	if _, err := c.GetJSON(context.Background(), "/config/repositories", &res); err != nil {
+		return res, err
+	}
+	return res, nil
+}
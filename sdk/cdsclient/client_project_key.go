@@ -24,3 +24,32 @@ func (c *client) ProjectKeysDelete(projectKey string, keyName string) error {
 	_, _, _, err := c.Request(context.Background(), "DELETE", "/project/"+projectKey+"/keys/"+url.QueryEscape(keyName), nil)
 	return err
 }
+
+func (c *client) ProjectKeyRotate(projectKey string, keyName string, req sdk.KeyRotationRequest) (*sdk.KeyRotation, error) {
+	var r sdk.KeyRotation
+	if _, err := c.PostJSON(context.Background(), "/project/"+projectKey+"/keys/"+url.QueryEscape(keyName)+"/rotate", req, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (c *client) ProjectKeyRotationsList(projectKey string) ([]sdk.KeyRotation, error) {
+	var rs []sdk.KeyRotation
+	if _, err := c.GetJSON(context.Background(), "/project/"+projectKey+"/keys/rotation", &rs); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+func (c *client) ProjectKeyRotationPolicyGet(projectKey string) (*sdk.KeyRotationPolicy, error) {
+	var p sdk.KeyRotationPolicy
+	if _, err := c.GetJSON(context.Background(), "/project/"+projectKey+"/keys/rotation/policy", &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (c *client) ProjectKeyRotationPolicyUpdate(projectKey string, policy *sdk.KeyRotationPolicy) error {
+	_, err := c.PutJSON(context.Background(), "/project/"+projectKey+"/keys/rotation/policy", policy, policy)
+	return err
+}
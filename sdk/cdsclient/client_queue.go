@@ -26,7 +26,7 @@ func shrinkQueue(queue *sdk.WorkflowQueue, nbJobsToKeep int) time.Time {
 	// we keep 2x this number
 	nbJobsToKeep = nbJobsToKeep * 2
 
-	queue.Sort()
+	// The queue is already in the fair-share order computed by the API
 
 	if len(*queue) > nbJobsToKeep {
 		newQueue := (*queue)[:nbJobsToKeep]
@@ -41,6 +41,10 @@ func shrinkQueue(queue *sdk.WorkflowQueue, nbJobsToKeep int) time.Time {
 	return t0
 }
 
+// queuePollingWebsocketDelay is the delay between a waiting job event and the poll of the queue it triggers, events
+// received during this delay trigger the same poll.
+var queuePollingWebsocketDelay = time.Second
+
 func (c *client) QueuePolling(ctx context.Context, goRoutines *sdk.GoRoutines, jobs chan<- sdk.WorkflowNodeJobRun, errs chan<- error, delay time.Duration, ms ...RequestModifier) error {
 	jobsTicker := time.NewTicker(delay)
 
@@ -54,6 +58,10 @@ func (c *client) QueuePolling(ctx context.Context, goRoutines *sdk.GoRoutines, j
 		Type: sdk.WebsocketFilterTypeQueue,
 	}}
 
+	// A waiting job event triggers a poll of the queue instead of giving the job directly, so jobs are always given
+	// in the fair-share order computed by the API
+	var wsPoll <-chan time.Time
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -66,28 +74,12 @@ func (c *client) QueuePolling(ctx context.Context, goRoutines *sdk.GoRoutines, j
 			if jobs == nil {
 				continue
 			}
-			if wsEvent.Event.EventType == "sdk.EventRunWorkflowJob" && wsEvent.Event.Status == sdk.StatusWaiting {
-				var jobEvent sdk.EventRunWorkflowJob
-				if err := sdk.JSONUnmarshal(wsEvent.Event.Payload, &jobEvent); err != nil {
-					errs <- newError(fmt.Errorf("unable to unmarshal job %v: %v", wsEvent.Event.Payload, err))
-					continue
-				}
-				job, err := c.QueueJobInfo(ctx, jobEvent.ID)
-				// Do not log the error if the job does not exist
-				if sdk.ErrorIs(err, sdk.ErrWorkflowNodeRunJobNotFound) {
-					continue
-				}
-
-				if err != nil {
-					errs <- newError(fmt.Errorf("unable to get job %v info: %v", jobEvent.ID, err))
-					continue
-				}
-				// push the job in the channel
-				if job.Status == sdk.StatusWaiting && job.BookedBy.Name == "" {
-					job.Header["WS"] = "true"
-					jobs <- *job
-				}
+			if wsEvent.Event.EventType == "sdk.EventRunWorkflowJob" && wsEvent.Event.Status == sdk.StatusWaiting && wsPoll == nil {
+				wsPoll = time.After(queuePollingWebsocketDelay)
 			}
+		case <-wsPoll:
+			wsPoll = nil
+			c.queuePoll(ctx, jobs, errs, true, ms...)
 		case <-jobsTicker.C:
 			if c.config.Verbose {
 				fmt.Println("jobsTicker")
@@ -96,29 +88,37 @@ func (c *client) QueuePolling(ctx context.Context, goRoutines *sdk.GoRoutines, j
 			if jobs == nil {
 				continue
 			}
+			c.queuePoll(ctx, jobs, errs, false, ms...)
+		}
+	}
+}
 
-			ctxt, cancel := context.WithTimeout(ctx, 10*time.Second)
-			queue := sdk.WorkflowQueue{}
-			if _, err := c.GetJSON(ctxt, "/queue/workflows", &queue, ms...); err != nil && !sdk.ErrorIs(err, sdk.ErrUnauthorized) {
-				errs <- newError(fmt.Errorf("unable to load jobs: %v", err))
-				cancel()
-				continue
-			} else if sdk.ErrorIs(err, sdk.ErrUnauthorized) {
-				cancel()
-				continue
-			}
-
-			cancel()
+// queuePoll loads the waiting jobs from the queue and pushes them in the channel, fromWS marks the jobs of a poll
+// triggered by a websocket event.
+func (c *client) queuePoll(ctx context.Context, jobs chan<- sdk.WorkflowNodeJobRun, errs chan<- error, fromWS bool, ms ...RequestModifier) {
+	ctxt, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	queue := sdk.WorkflowQueue{}
+	if _, err := c.GetJSON(ctxt, "/queue/workflows", &queue, ms...); err != nil {
+		if !sdk.ErrorIs(err, sdk.ErrUnauthorized) {
+			errs <- newError(fmt.Errorf("unable to load jobs: %v", err))
+		}
+		return
+	}
 
-			if c.config.Verbose {
-				fmt.Println("Jobs Queue size: ", len(queue))
-			}
+	if c.config.Verbose {
+		fmt.Println("Jobs Queue size: ", len(queue))
+	}
 
-			shrinkQueue(&queue, cap(jobs))
-			for _, j := range queue {
-				jobs <- j
+	shrinkQueue(&queue, cap(jobs))
+	for _, j := range queue {
+		if fromWS {
+			if j.Header == nil {
+				j.Header = sdk.WorkflowRunHeaders{}
 			}
+			j.Header["WS"] = "true"
 		}
+		jobs <- j
 	}
 }
 
@@ -131,6 +131,14 @@ func (c *client) QueueWorkflowNodeJobRun(ms ...RequestModifier) ([]sdk.WorkflowN
 	return wJobs, nil
 }
 
+func (c *client) QueueQuotaUsages(ctx context.Context) ([]sdk.QueueQuotaUsage, error) {
+	var res []sdk.QueueQuotaUsage
+	if _, err := c.GetJSON(ctx, "/queue/workflows/quota", &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func (c *client) QueueCountWorkflowNodeJobRun(since *time.Time, until *time.Time, modelType string) (sdk.WorkflowNodeJobRunCount, error) {
 	if since == nil {
 		since = new(time.Time)
@@ -264,6 +272,20 @@ func (c *client) QueueJobSetVersion(ctx context.Context, jobID int64, version sd
 	return err
 }
 
+func (c *client) QueueJobIDToken(ctx context.Context, jobID int64, req sdk.JobIDTokenRequest) (sdk.JobIDToken, error) {
+	var token sdk.JobIDToken
+	path := fmt.Sprintf("/queue/workflows/%d/oidc/token", jobID)
+	_, err := c.PostJSON(ctx, path, req, &token)
+	return token, err
+}
+
+func (c *client) QueueJobGitMirrorAccess(ctx context.Context, jobID int64) (sdk.GitMirrorAccessToken, error) {
+	var token sdk.GitMirrorAccessToken
+	path := fmt.Sprintf("/queue/workflows/%d/mirror/access", jobID)
+	_, err := c.GetJSON(ctx, path, &token)
+	return token, err
+}
+
 func (c *client) QueueWorkflowRunResultsRelease(ctx context.Context, permJobID int64, runResultIDs []string, to string) error {
 	req := sdk.WorkflowRunResultPromotionRequest{
 		IDs:        runResultIDs,
@@ -25,3 +25,9 @@ func (c *client) RBACImport(ctx context.Context, content io.Reader, mods ...Requ
 	_, err = c.PostJSON(ctx, path, &rbacRule, &rbacRule, mods...)
 	return rbacRule, err
 }
+
+func (c *client) RBACExplain(ctx context.Context, req sdk.RBACExplainRequest) (sdk.RBACExplanation, error) {
+	var explanation sdk.RBACExplanation
+	_, err := c.PostJSON(ctx, "/v2/rbac/explain", &req, &explanation)
+	return explanation, err
+}
@@ -23,3 +23,11 @@ func (c *client) ServiceConfigurationGet(ctx context.Context, t string) ([]sdk.S
 	}
 	return servicesConf, nil
 }
+
+func (c *client) ServiceGitMirrorAccess(ctx context.Context) (sdk.GitMirrorAccessToken, error) {
+	var token sdk.GitMirrorAccessToken
+	if _, err := c.GetJSON(ctx, "/services/git/mirror/access", &token); err != nil {
+		return token, err
+	}
+	return token, nil
+}
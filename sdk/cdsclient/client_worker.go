@@ -105,3 +105,8 @@ func (c *client) WorkerSetStatus(ctx context.Context, status string) error {
 
 	return nil
 }
+
+// GitMirrorAuthorization returns the authorization header value used by the worker to clone from git mirrors.
+func (c *client) GitMirrorAuthorization() string {
+	return "Bearer " + c.config.SessionToken
+}
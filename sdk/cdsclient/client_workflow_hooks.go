@@ -24,3 +24,10 @@ func (c *client) WorkflowAllHooksExecutions() ([]string, error) {
 	}
 	return res, nil
 }
+
+func (c *client) WorkflowHookChatOps(ctx context.Context, cmd sdk.ChatOpsCommand) error {
+	if _, err := c.PostJSON(ctx, "/workflow/hook/chatops", &cmd, nil); err != nil {
+		return err
+	}
+	return nil
+}
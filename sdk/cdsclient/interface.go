@@ -45,14 +45,23 @@ type Admin interface {
 	AdminDatabaseListEncryptedEntities(service string) ([]string, error)
 	AdminDatabaseRollEncryptedEntity(service string, e string, idx *int64) error
 	AdminDatabaseRollAllEncryptedEntities(service string) error
+	AdminDatabaseRollJobCreate(service string, req sdk.DatabaseRollJobRequest) ([]sdk.DatabaseRollJob, error)
+	AdminDatabaseRollJobList(service string) ([]sdk.DatabaseRollJob, error)
+	AdminDatabaseRollJobGet(service string, id int64) (*sdk.DatabaseRollJob, error)
+	AdminDatabaseRollJobCancel(service string, id int64) error
 	AdminCDSMigrationList() ([]sdk.Migration, error)
 	AdminCDSMigrationCancel(id int64) error
 	AdminCDSMigrationReset(id int64) error
 	AdminWorkflowUpdateMaxRuns(projectKey string, workflowName string, maxRuns int64) error
+	AdminAuditLogs(ctx context.Context, filter sdk.AuditLogFilter) ([]sdk.AuditLog, error)
 	AdminOrganizationCreate(ctx context.Context, orga sdk.Organization) error
 	AdminOrganizationList(ctx context.Context) ([]sdk.Organization, error)
 	AdminOrganizationDelete(ctx context.Context, orgaIdentifier string) error
 	AdminOrganizationMigrateUser(ctx context.Context, orgaIdentifier string) error
+	AdminQueueQuotaList(ctx context.Context) ([]sdk.QueueQuota, error)
+	AdminQueueQuotaCreate(ctx context.Context, q *sdk.QueueQuota) error
+	AdminQueueQuotaUpdate(ctx context.Context, q *sdk.QueueQuota) error
+	AdminQueueQuotaDelete(ctx context.Context, id int64) error
 	Features() ([]sdk.Feature, error)
 	FeatureCreate(f sdk.Feature) error
 	FeatureDelete(name sdk.FeatureName) error
@@ -113,6 +122,7 @@ type ApplicationKeysClient interface {
 	ApplicationKeysList(projectKey string, appName string) ([]sdk.ApplicationKey, error)
 	ApplicationKeyCreate(projectKey string, appName string, keyApp *sdk.ApplicationKey) error
 	ApplicationKeysDelete(projectKey string, appName string, KeyAppName string) error
+	ApplicationKeyRotate(projectKey string, appName string, keyName string, req sdk.KeyRotationRequest) (*sdk.KeyRotation, error)
 }
 
 // ApplicationVariableClient exposes application variables related functions
@@ -262,6 +272,7 @@ type ProjectClient interface {
 
 type RBACClient interface {
 	RBACImport(ctx context.Context, content io.Reader, mods ...RequestModifier) (sdk.RBAC, error)
+	RBACExplain(ctx context.Context, req sdk.RBACExplainRequest) (sdk.RBACExplanation, error)
 }
 
 // ProjectKeysClient exposes project keys related functions
@@ -269,6 +280,10 @@ type ProjectKeysClient interface {
 	ProjectKeysList(projectKey string) ([]sdk.ProjectKey, error)
 	ProjectKeyCreate(projectKey string, key *sdk.ProjectKey) error
 	ProjectKeysDelete(projectKey string, keyProjectName string) error
+	ProjectKeyRotate(projectKey string, keyName string, req sdk.KeyRotationRequest) (*sdk.KeyRotation, error)
+	ProjectKeyRotationsList(projectKey string) ([]sdk.KeyRotation, error)
+	ProjectKeyRotationPolicyGet(projectKey string) (*sdk.KeyRotationPolicy, error)
+	ProjectKeyRotationPolicyUpdate(projectKey string, policy *sdk.KeyRotationPolicy) error
 }
 
 // ProjectVariablesClient exposes project variables related functions
@@ -287,6 +302,7 @@ type ProjectVariablesClient interface {
 type QueueClient interface {
 	QueueWorkflowNodeJobRun(mods ...RequestModifier) ([]sdk.WorkflowNodeJobRun, error)
 	QueueCountWorkflowNodeJobRun(since *time.Time, until *time.Time, modelType string) (sdk.WorkflowNodeJobRunCount, error)
+	QueueQuotaUsages(ctx context.Context) ([]sdk.QueueQuotaUsage, error)
 	QueuePolling(ctx context.Context, goRoutines *sdk.GoRoutines, jobs chan<- sdk.WorkflowNodeJobRun, errs chan<- error, delay time.Duration, ms ...RequestModifier) error
 	QueueTakeJob(ctx context.Context, job sdk.WorkflowNodeJobRun) (*sdk.WorkflowNodeJobRunData, error)
 	QueueJobBook(ctx context.Context, id int64) (sdk.WorkflowNodeJobRunBooked, error)
@@ -299,6 +315,8 @@ type QueueClient interface {
 	QueueSendResult(ctx context.Context, id int64, res sdk.Result) error
 	QueueJobTag(ctx context.Context, jobID int64, tags []sdk.WorkflowRunTag) error
 	QueueJobSetVersion(ctx context.Context, jobID int64, version sdk.WorkflowRunVersion) error
+	QueueJobIDToken(ctx context.Context, jobID int64, req sdk.JobIDTokenRequest) (sdk.JobIDToken, error)
+	QueueJobGitMirrorAccess(ctx context.Context, jobID int64) (sdk.GitMirrorAccessToken, error)
 	QueueWorkerCacheLink(ctx context.Context, jobID int64, tag string) (sdk.CDNItemLinks, error)
 	QueueWorkflowRunResultsAdd(ctx context.Context, jobID int64, addRequest sdk.WorkflowRunResult) error
 	QueueWorkflowRunResultCheck(ctx context.Context, jobID int64, runResultCheck sdk.WorkflowRunResultCheck) (int, error)
@@ -341,6 +359,8 @@ type WorkerClient interface {
 
 	WorkerModelv2List(ctx context.Context, projKey string, vcsIdentifier string, repoIdentifier string, filter *WorkerModelV2Filter) ([]sdk.V2WorkerModel, error)
 	WorkerModelTemplateList(ctx context.Context, projKey string, vcsIdentifier string, repoIdentifier string, filter *WorkerModelTemplateFilter) ([]sdk.WorkerModelTemplate, error)
+	ConfigRepositories() (sdk.RepositoriesConfig, error)
+	GitMirrorAuthorization() string
 	CDNClient
 }
 
@@ -362,6 +382,7 @@ type HookClient interface {
 // ServiceClient exposes functions used for services
 type ServiceClient interface {
 	ServiceConfigurationGet(context.Context, string) ([]sdk.ServiceConfiguration, error)
+	ServiceGitMirrorAccess(context.Context) (sdk.GitMirrorAccessToken, error)
 }
 
 // WorkflowClient exposes workflows functions
@@ -397,6 +418,7 @@ type WorkflowClient interface {
 	WorkflowNodeRunRelease(projectKey string, workflowName string, runNumber int64, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error
 	WorkflowAllHooksList() ([]sdk.NodeHook, error)
 	WorkflowAllHooksExecutions() ([]string, error)
+	WorkflowHookChatOps(ctx context.Context, cmd sdk.ChatOpsCommand) error
 	WorkflowTransformAsCode(projectKey, workflowName, branch, message string) (*sdk.Operation, error)
 }
 
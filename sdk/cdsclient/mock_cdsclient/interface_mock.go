@@ -17,7 +17,6 @@ import (
 	websocket "github.com/gorilla/websocket"
 	sdk "github.com/ovh/cds/sdk"
 	cdsclient "github.com/ovh/cds/sdk/cdsclient"
-	coverage "github.com/sguiheux/go-coverage"
 	afero "github.com/spf13/afero"
 )
 
@@ -221,6 +220,21 @@ func (m *MockAdmin) EXPECT() *MockAdminMockRecorder {
 	return m.recorder
 }
 
+// AdminAuditLogs mocks base method.
+func (m *MockAdmin) AdminAuditLogs(ctx context.Context, filter sdk.AuditLogFilter) ([]sdk.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminAuditLogs", ctx, filter)
+	ret0, _ := ret[0].([]sdk.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminAuditLogs indicates an expected call of AdminAuditLogs.
+func (mr *MockAdminMockRecorder) AdminAuditLogs(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminAuditLogs", reflect.TypeOf((*MockAdmin)(nil).AdminAuditLogs), ctx, filter)
+}
+
 // AdminCDSMigrationCancel mocks base method.
 func (m *MockAdmin) AdminCDSMigrationCancel(id int64) error {
 	m.ctrl.T.Helper()
@@ -350,6 +364,65 @@ func (mr *MockAdminMockRecorder) AdminDatabaseRollEncryptedEntity(service, e, id
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRollEncryptedEntity", reflect.TypeOf((*MockAdmin)(nil).AdminDatabaseRollEncryptedEntity), service, e, idx)
 }
 
+// AdminDatabaseRollJobCancel mocks base method.
+func (m *MockAdmin) AdminDatabaseRollJobCancel(service string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseRollJobCancel", service, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminDatabaseRollJobCancel indicates an expected call of AdminDatabaseRollJobCancel.
+func (mr *MockAdminMockRecorder) AdminDatabaseRollJobCancel(service, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRollJobCancel", reflect.TypeOf((*MockAdmin)(nil).AdminDatabaseRollJobCancel), service, id)
+}
+
+// AdminDatabaseRollJobCreate mocks base method.
+func (m *MockAdmin) AdminDatabaseRollJobCreate(service string, req sdk.DatabaseRollJobRequest) ([]sdk.DatabaseRollJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseRollJobCreate", service, req)
+	ret0, _ := ret[0].([]sdk.DatabaseRollJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminDatabaseRollJobCreate indicates an expected call of AdminDatabaseRollJobCreate.
+func (mr *MockAdminMockRecorder) AdminDatabaseRollJobCreate(service, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRollJobCreate", reflect.TypeOf((*MockAdmin)(nil).AdminDatabaseRollJobCreate), service, req)
+}
+
+// AdminDatabaseRollJobGet mocks base method.
+func (m *MockAdmin) AdminDatabaseRollJobGet(service string, id int64) (*sdk.DatabaseRollJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseRollJobGet", service, id)
+	ret0, _ := ret[0].(*sdk.DatabaseRollJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminDatabaseRollJobGet indicates an expected call of AdminDatabaseRollJobGet.
+func (mr *MockAdminMockRecorder) AdminDatabaseRollJobGet(service, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRollJobGet", reflect.TypeOf((*MockAdmin)(nil).AdminDatabaseRollJobGet), service, id)
+}
+
+// AdminDatabaseRollJobList mocks base method.
+func (m *MockAdmin) AdminDatabaseRollJobList(service string) ([]sdk.DatabaseRollJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseRollJobList", service)
+	ret0, _ := ret[0].([]sdk.DatabaseRollJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminDatabaseRollJobList indicates an expected call of AdminDatabaseRollJobList.
+func (mr *MockAdminMockRecorder) AdminDatabaseRollJobList(service interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRollJobList", reflect.TypeOf((*MockAdmin)(nil).AdminDatabaseRollJobList), service)
+}
+
 // AdminDatabaseSignaturesResume mocks base method.
 func (m *MockAdmin) AdminDatabaseSignaturesResume(service string) (sdk.CanonicalFormUsageResume, error) {
 	m.ctrl.T.Helper()
@@ -450,6 +523,63 @@ func (mr *MockAdminMockRecorder) AdminOrganizationMigrateUser(ctx, orgaIdentifie
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminOrganizationMigrateUser", reflect.TypeOf((*MockAdmin)(nil).AdminOrganizationMigrateUser), ctx, orgaIdentifier)
 }
 
+// AdminQueueQuotaCreate mocks base method.
+func (m *MockAdmin) AdminQueueQuotaCreate(ctx context.Context, q *sdk.QueueQuota) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQueueQuotaCreate", ctx, q)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminQueueQuotaCreate indicates an expected call of AdminQueueQuotaCreate.
+func (mr *MockAdminMockRecorder) AdminQueueQuotaCreate(ctx, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQueueQuotaCreate", reflect.TypeOf((*MockAdmin)(nil).AdminQueueQuotaCreate), ctx, q)
+}
+
+// AdminQueueQuotaDelete mocks base method.
+func (m *MockAdmin) AdminQueueQuotaDelete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQueueQuotaDelete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminQueueQuotaDelete indicates an expected call of AdminQueueQuotaDelete.
+func (mr *MockAdminMockRecorder) AdminQueueQuotaDelete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQueueQuotaDelete", reflect.TypeOf((*MockAdmin)(nil).AdminQueueQuotaDelete), ctx, id)
+}
+
+// AdminQueueQuotaList mocks base method.
+func (m *MockAdmin) AdminQueueQuotaList(ctx context.Context) ([]sdk.QueueQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQueueQuotaList", ctx)
+	ret0, _ := ret[0].([]sdk.QueueQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminQueueQuotaList indicates an expected call of AdminQueueQuotaList.
+func (mr *MockAdminMockRecorder) AdminQueueQuotaList(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQueueQuotaList", reflect.TypeOf((*MockAdmin)(nil).AdminQueueQuotaList), ctx)
+}
+
+// AdminQueueQuotaUpdate mocks base method.
+func (m *MockAdmin) AdminQueueQuotaUpdate(ctx context.Context, q *sdk.QueueQuota) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQueueQuotaUpdate", ctx, q)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminQueueQuotaUpdate indicates an expected call of AdminQueueQuotaUpdate.
+func (mr *MockAdminMockRecorder) AdminQueueQuotaUpdate(ctx, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQueueQuotaUpdate", reflect.TypeOf((*MockAdmin)(nil).AdminQueueQuotaUpdate), ctx, q)
+}
+
 // AdminWorkflowUpdateMaxRuns mocks base method.
 func (m *MockAdmin) AdminWorkflowUpdateMaxRuns(projectKey, workflowName string, maxRuns int64) error {
 	m.ctrl.T.Helper()
@@ -1143,6 +1273,21 @@ func (mr *MockApplicationClientMockRecorder) ApplicationKeyCreate(projectKey, ap
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationKeyCreate", reflect.TypeOf((*MockApplicationClient)(nil).ApplicationKeyCreate), projectKey, appName, keyApp)
 }
 
+// ApplicationKeyRotate mocks base method.
+func (m *MockApplicationClient) ApplicationKeyRotate(projectKey, appName, keyName string, req sdk.KeyRotationRequest) (*sdk.KeyRotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationKeyRotate", projectKey, appName, keyName, req)
+	ret0, _ := ret[0].(*sdk.KeyRotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationKeyRotate indicates an expected call of ApplicationKeyRotate.
+func (mr *MockApplicationClientMockRecorder) ApplicationKeyRotate(projectKey, appName, keyName, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationKeyRotate", reflect.TypeOf((*MockApplicationClient)(nil).ApplicationKeyRotate), projectKey, appName, keyName, req)
+}
+
 // ApplicationKeysDelete mocks base method.
 func (m *MockApplicationClient) ApplicationKeysDelete(projectKey, appName, KeyAppName string) error {
 	m.ctrl.T.Helper()
@@ -1310,6 +1455,21 @@ func (mr *MockApplicationKeysClientMockRecorder) ApplicationKeyCreate(projectKey
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationKeyCreate", reflect.TypeOf((*MockApplicationKeysClient)(nil).ApplicationKeyCreate), projectKey, appName, keyApp)
 }
 
+// ApplicationKeyRotate mocks base method.
+func (m *MockApplicationKeysClient) ApplicationKeyRotate(projectKey, appName, keyName string, req sdk.KeyRotationRequest) (*sdk.KeyRotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationKeyRotate", projectKey, appName, keyName, req)
+	ret0, _ := ret[0].(*sdk.KeyRotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationKeyRotate indicates an expected call of ApplicationKeyRotate.
+func (mr *MockApplicationKeysClientMockRecorder) ApplicationKeyRotate(projectKey, appName, keyName, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationKeyRotate", reflect.TypeOf((*MockApplicationKeysClient)(nil).ApplicationKeyRotate), projectKey, appName, keyName, req)
+}
+
 // ApplicationKeysDelete mocks base method.
 func (m *MockApplicationKeysClient) ApplicationKeysDelete(projectKey, appName, KeyAppName string) error {
 	m.ctrl.T.Helper()
@@ -2876,6 +3036,65 @@ func (mr *MockProjectClientMockRecorder) ProjectKeyCreate(projectKey, key interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyCreate", reflect.TypeOf((*MockProjectClient)(nil).ProjectKeyCreate), projectKey, key)
 }
 
+// ProjectKeyRotate mocks base method.
+func (m *MockProjectClient) ProjectKeyRotate(projectKey, keyName string, req sdk.KeyRotationRequest) (*sdk.KeyRotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeyRotate", projectKey, keyName, req)
+	ret0, _ := ret[0].(*sdk.KeyRotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectKeyRotate indicates an expected call of ProjectKeyRotate.
+func (mr *MockProjectClientMockRecorder) ProjectKeyRotate(projectKey, keyName, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyRotate", reflect.TypeOf((*MockProjectClient)(nil).ProjectKeyRotate), projectKey, keyName, req)
+}
+
+// ProjectKeyRotationPolicyGet mocks base method.
+func (m *MockProjectClient) ProjectKeyRotationPolicyGet(projectKey string) (*sdk.KeyRotationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeyRotationPolicyGet", projectKey)
+	ret0, _ := ret[0].(*sdk.KeyRotationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectKeyRotationPolicyGet indicates an expected call of ProjectKeyRotationPolicyGet.
+func (mr *MockProjectClientMockRecorder) ProjectKeyRotationPolicyGet(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyRotationPolicyGet", reflect.TypeOf((*MockProjectClient)(nil).ProjectKeyRotationPolicyGet), projectKey)
+}
+
+// ProjectKeyRotationPolicyUpdate mocks base method.
+func (m *MockProjectClient) ProjectKeyRotationPolicyUpdate(projectKey string, policy *sdk.KeyRotationPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeyRotationPolicyUpdate", projectKey, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectKeyRotationPolicyUpdate indicates an expected call of ProjectKeyRotationPolicyUpdate.
+func (mr *MockProjectClientMockRecorder) ProjectKeyRotationPolicyUpdate(projectKey, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyRotationPolicyUpdate", reflect.TypeOf((*MockProjectClient)(nil).ProjectKeyRotationPolicyUpdate), projectKey, policy)
+}
+
+// ProjectKeyRotationsList mocks base method.
+func (m *MockProjectClient) ProjectKeyRotationsList(projectKey string) ([]sdk.KeyRotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeyRotationsList", projectKey)
+	ret0, _ := ret[0].([]sdk.KeyRotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectKeyRotationsList indicates an expected call of ProjectKeyRotationsList.
+func (mr *MockProjectClientMockRecorder) ProjectKeyRotationsList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyRotationsList", reflect.TypeOf((*MockProjectClient)(nil).ProjectKeyRotationsList), projectKey)
+}
+
 // ProjectKeysDelete mocks base method.
 func (m *MockProjectClient) ProjectKeysDelete(projectKey, keyProjectName string) error {
 	m.ctrl.T.Helper()
@@ -3274,6 +3493,21 @@ func (m *MockRBACClient) EXPECT() *MockRBACClientMockRecorder {
 	return m.recorder
 }
 
+// RBACExplain mocks base method.
+func (m *MockRBACClient) RBACExplain(ctx context.Context, req sdk.RBACExplainRequest) (sdk.RBACExplanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACExplain", ctx, req)
+	ret0, _ := ret[0].(sdk.RBACExplanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACExplain indicates an expected call of RBACExplain.
+func (mr *MockRBACClientMockRecorder) RBACExplain(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACExplain", reflect.TypeOf((*MockRBACClient)(nil).RBACExplain), ctx, req)
+}
+
 // RBACImport mocks base method.
 func (m *MockRBACClient) RBACImport(ctx context.Context, content io.Reader, mods ...cdsclient.RequestModifier) (sdk.RBAC, error) {
 	m.ctrl.T.Helper()
@@ -3331,6 +3565,65 @@ func (mr *MockProjectKeysClientMockRecorder) ProjectKeyCreate(projectKey, key in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyCreate", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeyCreate), projectKey, key)
 }
 
+// ProjectKeyRotate mocks base method.
+func (m *MockProjectKeysClient) ProjectKeyRotate(projectKey, keyName string, req sdk.KeyRotationRequest) (*sdk.KeyRotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeyRotate", projectKey, keyName, req)
+	ret0, _ := ret[0].(*sdk.KeyRotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectKeyRotate indicates an expected call of ProjectKeyRotate.
+func (mr *MockProjectKeysClientMockRecorder) ProjectKeyRotate(projectKey, keyName, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyRotate", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeyRotate), projectKey, keyName, req)
+}
+
+// ProjectKeyRotationPolicyGet mocks base method.
+func (m *MockProjectKeysClient) ProjectKeyRotationPolicyGet(projectKey string) (*sdk.KeyRotationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeyRotationPolicyGet", projectKey)
+	ret0, _ := ret[0].(*sdk.KeyRotationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectKeyRotationPolicyGet indicates an expected call of ProjectKeyRotationPolicyGet.
+func (mr *MockProjectKeysClientMockRecorder) ProjectKeyRotationPolicyGet(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyRotationPolicyGet", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeyRotationPolicyGet), projectKey)
+}
+
+// ProjectKeyRotationPolicyUpdate mocks base method.
+func (m *MockProjectKeysClient) ProjectKeyRotationPolicyUpdate(projectKey string, policy *sdk.KeyRotationPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeyRotationPolicyUpdate", projectKey, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectKeyRotationPolicyUpdate indicates an expected call of ProjectKeyRotationPolicyUpdate.
+func (mr *MockProjectKeysClientMockRecorder) ProjectKeyRotationPolicyUpdate(projectKey, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyRotationPolicyUpdate", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeyRotationPolicyUpdate), projectKey, policy)
+}
+
+// ProjectKeyRotationsList mocks base method.
+func (m *MockProjectKeysClient) ProjectKeyRotationsList(projectKey string) ([]sdk.KeyRotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeyRotationsList", projectKey)
+	ret0, _ := ret[0].([]sdk.KeyRotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectKeyRotationsList indicates an expected call of ProjectKeyRotationsList.
+func (mr *MockProjectKeysClientMockRecorder) ProjectKeyRotationsList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyRotationsList", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeyRotationsList), projectKey)
+}
+
 // ProjectKeysDelete mocks base method.
 func (m *MockProjectKeysClient) ProjectKeysDelete(projectKey, keyProjectName string) error {
 	m.ctrl.T.Helper()
@@ -3552,6 +3845,36 @@ func (mr *MockQueueClientMockRecorder) QueueJobBook(ctx, id interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobBook", reflect.TypeOf((*MockQueueClient)(nil).QueueJobBook), ctx, id)
 }
 
+// QueueJobGitMirrorAccess mocks base method.
+func (m *MockQueueClient) QueueJobGitMirrorAccess(ctx context.Context, jobID int64) (sdk.GitMirrorAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobGitMirrorAccess", ctx, jobID)
+	ret0, _ := ret[0].(sdk.GitMirrorAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobGitMirrorAccess indicates an expected call of QueueJobGitMirrorAccess.
+func (mr *MockQueueClientMockRecorder) QueueJobGitMirrorAccess(ctx, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobGitMirrorAccess", reflect.TypeOf((*MockQueueClient)(nil).QueueJobGitMirrorAccess), ctx, jobID)
+}
+
+// QueueJobIDToken mocks base method.
+func (m *MockQueueClient) QueueJobIDToken(ctx context.Context, jobID int64, req sdk.JobIDTokenRequest) (sdk.JobIDToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobIDToken", ctx, jobID, req)
+	ret0, _ := ret[0].(sdk.JobIDToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobIDToken indicates an expected call of QueueJobIDToken.
+func (mr *MockQueueClientMockRecorder) QueueJobIDToken(ctx, jobID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobIDToken", reflect.TypeOf((*MockQueueClient)(nil).QueueJobIDToken), ctx, jobID, req)
+}
+
 // QueueJobInfo mocks base method.
 func (m *MockQueueClient) QueueJobInfo(ctx context.Context, id int64) (*sdk.WorkflowNodeJobRun, error) {
 	m.ctrl.T.Helper()
@@ -3642,18 +3965,19 @@ func (mr *MockQueueClientMockRecorder) QueuePolling(ctx, goRoutines, jobs, errs,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueuePolling", reflect.TypeOf((*MockQueueClient)(nil).QueuePolling), varargs...)
 }
 
-// QueueSendCoverage mocks base method.
-func (m *MockQueueClient) QueueSendCoverage(ctx context.Context, id int64, report coverage.Report) error {
+// QueueQuotaUsages mocks base method.
+func (m *MockQueueClient) QueueQuotaUsages(ctx context.Context) ([]sdk.QueueQuotaUsage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueSendCoverage", ctx, id, report)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "QueueQuotaUsages", ctx)
+	ret0, _ := ret[0].([]sdk.QueueQuotaUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// QueueSendCoverage indicates an expected call of QueueSendCoverage.
-func (mr *MockQueueClientMockRecorder) QueueSendCoverage(ctx, id, report interface{}) *gomock.Call {
+// QueueQuotaUsages indicates an expected call of QueueQuotaUsages.
+func (mr *MockQueueClientMockRecorder) QueueQuotaUsages(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendCoverage", reflect.TypeOf((*MockQueueClient)(nil).QueueSendCoverage), ctx, id, report)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueQuotaUsages", reflect.TypeOf((*MockQueueClient)(nil).QueueQuotaUsages), ctx)
 }
 
 // QueueSendResult mocks base method.
@@ -4071,6 +4395,35 @@ func (mr *MockWorkerClientMockRecorder) CDNItemUpload(ctx, cdnAddr, signature, f
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CDNItemUpload", reflect.TypeOf((*MockWorkerClient)(nil).CDNItemUpload), ctx, cdnAddr, signature, fs, path)
 }
 
+// ConfigRepositories mocks base method.
+func (m *MockWorkerClient) ConfigRepositories() (sdk.RepositoriesConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigRepositories")
+	ret0, _ := ret[0].(sdk.RepositoriesConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfigRepositories indicates an expected call of ConfigRepositories.
+func (mr *MockWorkerClientMockRecorder) ConfigRepositories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigRepositories", reflect.TypeOf((*MockWorkerClient)(nil).ConfigRepositories))
+}
+
+// GitMirrorAuthorization mocks base method.
+func (m *MockWorkerClient) GitMirrorAuthorization() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GitMirrorAuthorization")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GitMirrorAuthorization indicates an expected call of GitMirrorAuthorization.
+func (mr *MockWorkerClientMockRecorder) GitMirrorAuthorization() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GitMirrorAuthorization", reflect.TypeOf((*MockWorkerClient)(nil).GitMirrorAuthorization))
+}
+
 // WorkerDisable mocks base method.
 func (m *MockWorkerClient) WorkerDisable(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
@@ -4529,6 +4882,21 @@ func (mr *MockServiceClientMockRecorder) ServiceConfigurationGet(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceConfigurationGet", reflect.TypeOf((*MockServiceClient)(nil).ServiceConfigurationGet), arg0, arg1)
 }
 
+// ServiceGitMirrorAccess mocks base method.
+func (m *MockServiceClient) ServiceGitMirrorAccess(arg0 context.Context) (sdk.GitMirrorAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceGitMirrorAccess", arg0)
+	ret0, _ := ret[0].(sdk.GitMirrorAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServiceGitMirrorAccess indicates an expected call of ServiceGitMirrorAccess.
+func (mr *MockServiceClientMockRecorder) ServiceGitMirrorAccess(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceGitMirrorAccess", reflect.TypeOf((*MockServiceClient)(nil).ServiceGitMirrorAccess), arg0)
+}
+
 // MockWorkflowClient is a mock of WorkflowClient interface.
 type MockWorkflowClient struct {
 	ctrl     *gomock.Controller
@@ -4663,6 +5031,20 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowGroupDelete(projectKey, name,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowGroupDelete", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowGroupDelete), projectKey, name, groupName)
 }
 
+// WorkflowHookChatOps mocks base method.
+func (m *MockWorkflowClient) WorkflowHookChatOps(ctx context.Context, cmd sdk.ChatOpsCommand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowHookChatOps", ctx, cmd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowHookChatOps indicates an expected call of WorkflowHookChatOps.
+func (mr *MockWorkflowClientMockRecorder) WorkflowHookChatOps(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowHookChatOps", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowHookChatOps), ctx, cmd)
+}
+
 // WorkflowLabelAdd mocks base method.
 func (m *MockWorkflowClient) WorkflowLabelAdd(projectKey, name, labelName string) error {
 	m.ctrl.T.Helper()
@@ -5431,6 +5813,21 @@ func (mr *MockInterfaceMockRecorder) ActionUsage(groupName, name interface{}, mo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionUsage", reflect.TypeOf((*MockInterface)(nil).ActionUsage), varargs...)
 }
 
+// AdminAuditLogs mocks base method.
+func (m *MockInterface) AdminAuditLogs(ctx context.Context, filter sdk.AuditLogFilter) ([]sdk.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminAuditLogs", ctx, filter)
+	ret0, _ := ret[0].([]sdk.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminAuditLogs indicates an expected call of AdminAuditLogs.
+func (mr *MockInterfaceMockRecorder) AdminAuditLogs(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminAuditLogs", reflect.TypeOf((*MockInterface)(nil).AdminAuditLogs), ctx, filter)
+}
+
 // AdminCDSMigrationCancel mocks base method.
 func (m *MockInterface) AdminCDSMigrationCancel(id int64) error {
 	m.ctrl.T.Helper()
@@ -5560,6 +5957,65 @@ func (mr *MockInterfaceMockRecorder) AdminDatabaseRollEncryptedEntity(service, e
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRollEncryptedEntity", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseRollEncryptedEntity), service, e, idx)
 }
 
+// AdminDatabaseRollJobCancel mocks base method.
+func (m *MockInterface) AdminDatabaseRollJobCancel(service string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseRollJobCancel", service, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminDatabaseRollJobCancel indicates an expected call of AdminDatabaseRollJobCancel.
+func (mr *MockInterfaceMockRecorder) AdminDatabaseRollJobCancel(service, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRollJobCancel", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseRollJobCancel), service, id)
+}
+
+// AdminDatabaseRollJobCreate mocks base method.
+func (m *MockInterface) AdminDatabaseRollJobCreate(service string, req sdk.DatabaseRollJobRequest) ([]sdk.DatabaseRollJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseRollJobCreate", service, req)
+	ret0, _ := ret[0].([]sdk.DatabaseRollJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminDatabaseRollJobCreate indicates an expected call of AdminDatabaseRollJobCreate.
+func (mr *MockInterfaceMockRecorder) AdminDatabaseRollJobCreate(service, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRollJobCreate", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseRollJobCreate), service, req)
+}
+
+// AdminDatabaseRollJobGet mocks base method.
+func (m *MockInterface) AdminDatabaseRollJobGet(service string, id int64) (*sdk.DatabaseRollJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseRollJobGet", service, id)
+	ret0, _ := ret[0].(*sdk.DatabaseRollJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminDatabaseRollJobGet indicates an expected call of AdminDatabaseRollJobGet.
+func (mr *MockInterfaceMockRecorder) AdminDatabaseRollJobGet(service, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRollJobGet", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseRollJobGet), service, id)
+}
+
+// AdminDatabaseRollJobList mocks base method.
+func (m *MockInterface) AdminDatabaseRollJobList(service string) ([]sdk.DatabaseRollJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseRollJobList", service)
+	ret0, _ := ret[0].([]sdk.DatabaseRollJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminDatabaseRollJobList indicates an expected call of AdminDatabaseRollJobList.
+func (mr *MockInterfaceMockRecorder) AdminDatabaseRollJobList(service interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRollJobList", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseRollJobList), service)
+}
+
 // AdminDatabaseSignaturesResume mocks base method.
 func (m *MockInterface) AdminDatabaseSignaturesResume(service string) (sdk.CanonicalFormUsageResume, error) {
 	m.ctrl.T.Helper()
@@ -5660,6 +6116,63 @@ func (mr *MockInterfaceMockRecorder) AdminOrganizationMigrateUser(ctx, orgaIdent
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminOrganizationMigrateUser", reflect.TypeOf((*MockInterface)(nil).AdminOrganizationMigrateUser), ctx, orgaIdentifier)
 }
 
+// AdminQueueQuotaCreate mocks base method.
+func (m *MockInterface) AdminQueueQuotaCreate(ctx context.Context, q *sdk.QueueQuota) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQueueQuotaCreate", ctx, q)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminQueueQuotaCreate indicates an expected call of AdminQueueQuotaCreate.
+func (mr *MockInterfaceMockRecorder) AdminQueueQuotaCreate(ctx, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQueueQuotaCreate", reflect.TypeOf((*MockInterface)(nil).AdminQueueQuotaCreate), ctx, q)
+}
+
+// AdminQueueQuotaDelete mocks base method.
+func (m *MockInterface) AdminQueueQuotaDelete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQueueQuotaDelete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminQueueQuotaDelete indicates an expected call of AdminQueueQuotaDelete.
+func (mr *MockInterfaceMockRecorder) AdminQueueQuotaDelete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQueueQuotaDelete", reflect.TypeOf((*MockInterface)(nil).AdminQueueQuotaDelete), ctx, id)
+}
+
+// AdminQueueQuotaList mocks base method.
+func (m *MockInterface) AdminQueueQuotaList(ctx context.Context) ([]sdk.QueueQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQueueQuotaList", ctx)
+	ret0, _ := ret[0].([]sdk.QueueQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminQueueQuotaList indicates an expected call of AdminQueueQuotaList.
+func (mr *MockInterfaceMockRecorder) AdminQueueQuotaList(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQueueQuotaList", reflect.TypeOf((*MockInterface)(nil).AdminQueueQuotaList), ctx)
+}
+
+// AdminQueueQuotaUpdate mocks base method.
+func (m *MockInterface) AdminQueueQuotaUpdate(ctx context.Context, q *sdk.QueueQuota) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQueueQuotaUpdate", ctx, q)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminQueueQuotaUpdate indicates an expected call of AdminQueueQuotaUpdate.
+func (mr *MockInterfaceMockRecorder) AdminQueueQuotaUpdate(ctx, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQueueQuotaUpdate", reflect.TypeOf((*MockInterface)(nil).AdminQueueQuotaUpdate), ctx, q)
+}
+
 // AdminWorkflowUpdateMaxRuns mocks base method.
 func (m *MockInterface) AdminWorkflowUpdateMaxRuns(projectKey, workflowName string, maxRuns int64) error {
 	m.ctrl.T.Helper()
@@ -5790,6 +6303,21 @@ func (mr *MockInterfaceMockRecorder) ApplicationKeyCreate(projectKey, appName, k
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationKeyCreate", reflect.TypeOf((*MockInterface)(nil).ApplicationKeyCreate), projectKey, appName, keyApp)
 }
 
+// ApplicationKeyRotate mocks base method.
+func (m *MockInterface) ApplicationKeyRotate(projectKey, appName, keyName string, req sdk.KeyRotationRequest) (*sdk.KeyRotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationKeyRotate", projectKey, appName, keyName, req)
+	ret0, _ := ret[0].(*sdk.KeyRotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationKeyRotate indicates an expected call of ApplicationKeyRotate.
+func (mr *MockInterfaceMockRecorder) ApplicationKeyRotate(projectKey, appName, keyName, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationKeyRotate", reflect.TypeOf((*MockInterface)(nil).ApplicationKeyRotate), projectKey, appName, keyName, req)
+}
+
 // ApplicationKeysDelete mocks base method.
 func (m *MockInterface) ApplicationKeysDelete(projectKey, appName, KeyAppName string) error {
 	m.ctrl.T.Helper()
@@ -6229,6 +6757,21 @@ func (mr *MockInterfaceMockRecorder) ConfigCDN() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigCDN", reflect.TypeOf((*MockInterface)(nil).ConfigCDN))
 }
 
+// ConfigRepositories mocks base method.
+func (m *MockInterface) ConfigRepositories() (sdk.RepositoriesConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigRepositories")
+	ret0, _ := ret[0].(sdk.RepositoriesConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfigRepositories indicates an expected call of ConfigRepositories.
+func (mr *MockInterfaceMockRecorder) ConfigRepositories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigRepositories", reflect.TypeOf((*MockInterface)(nil).ConfigRepositories))
+}
+
 // ConfigUser mocks base method.
 func (m *MockInterface) ConfigUser() (sdk.ConfigUser, error) {
 	m.ctrl.T.Helper()
@@ -6618,6 +7161,20 @@ func (mr *MockInterfaceMockRecorder) GetJSON(ctx, path, out interface{}, mods ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJSON", reflect.TypeOf((*MockInterface)(nil).GetJSON), varargs...)
 }
 
+// GitMirrorAuthorization mocks base method.
+func (m *MockInterface) GitMirrorAuthorization() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GitMirrorAuthorization")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GitMirrorAuthorization indicates an expected call of GitMirrorAuthorization.
+func (mr *MockInterfaceMockRecorder) GitMirrorAuthorization() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GitMirrorAuthorization", reflect.TypeOf((*MockInterface)(nil).GitMirrorAuthorization))
+}
+
 // GroupCreate mocks base method.
 func (m *MockInterface) GroupCreate(group *sdk.Group) error {
 	m.ctrl.T.Helper()
@@ -7508,6 +8065,65 @@ func (mr *MockInterfaceMockRecorder) ProjectKeyCreate(projectKey, key interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyCreate", reflect.TypeOf((*MockInterface)(nil).ProjectKeyCreate), projectKey, key)
 }
 
+// ProjectKeyRotate mocks base method.
+func (m *MockInterface) ProjectKeyRotate(projectKey, keyName string, req sdk.KeyRotationRequest) (*sdk.KeyRotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeyRotate", projectKey, keyName, req)
+	ret0, _ := ret[0].(*sdk.KeyRotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectKeyRotate indicates an expected call of ProjectKeyRotate.
+func (mr *MockInterfaceMockRecorder) ProjectKeyRotate(projectKey, keyName, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyRotate", reflect.TypeOf((*MockInterface)(nil).ProjectKeyRotate), projectKey, keyName, req)
+}
+
+// ProjectKeyRotationPolicyGet mocks base method.
+func (m *MockInterface) ProjectKeyRotationPolicyGet(projectKey string) (*sdk.KeyRotationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeyRotationPolicyGet", projectKey)
+	ret0, _ := ret[0].(*sdk.KeyRotationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectKeyRotationPolicyGet indicates an expected call of ProjectKeyRotationPolicyGet.
+func (mr *MockInterfaceMockRecorder) ProjectKeyRotationPolicyGet(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyRotationPolicyGet", reflect.TypeOf((*MockInterface)(nil).ProjectKeyRotationPolicyGet), projectKey)
+}
+
+// ProjectKeyRotationPolicyUpdate mocks base method.
+func (m *MockInterface) ProjectKeyRotationPolicyUpdate(projectKey string, policy *sdk.KeyRotationPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeyRotationPolicyUpdate", projectKey, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectKeyRotationPolicyUpdate indicates an expected call of ProjectKeyRotationPolicyUpdate.
+func (mr *MockInterfaceMockRecorder) ProjectKeyRotationPolicyUpdate(projectKey, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyRotationPolicyUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectKeyRotationPolicyUpdate), projectKey, policy)
+}
+
+// ProjectKeyRotationsList mocks base method.
+func (m *MockInterface) ProjectKeyRotationsList(projectKey string) ([]sdk.KeyRotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeyRotationsList", projectKey)
+	ret0, _ := ret[0].([]sdk.KeyRotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectKeyRotationsList indicates an expected call of ProjectKeyRotationsList.
+func (mr *MockInterfaceMockRecorder) ProjectKeyRotationsList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyRotationsList", reflect.TypeOf((*MockInterface)(nil).ProjectKeyRotationsList), projectKey)
+}
+
 // ProjectKeysDelete mocks base method.
 func (m *MockInterface) ProjectKeysDelete(projectKey, keyProjectName string) error {
 	m.ctrl.T.Helper()
@@ -7889,6 +8505,36 @@ func (mr *MockInterfaceMockRecorder) QueueJobBook(ctx, id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobBook", reflect.TypeOf((*MockInterface)(nil).QueueJobBook), ctx, id)
 }
 
+// QueueJobGitMirrorAccess mocks base method.
+func (m *MockInterface) QueueJobGitMirrorAccess(ctx context.Context, jobID int64) (sdk.GitMirrorAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobGitMirrorAccess", ctx, jobID)
+	ret0, _ := ret[0].(sdk.GitMirrorAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobGitMirrorAccess indicates an expected call of QueueJobGitMirrorAccess.
+func (mr *MockInterfaceMockRecorder) QueueJobGitMirrorAccess(ctx, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobGitMirrorAccess", reflect.TypeOf((*MockInterface)(nil).QueueJobGitMirrorAccess), ctx, jobID)
+}
+
+// QueueJobIDToken mocks base method.
+func (m *MockInterface) QueueJobIDToken(ctx context.Context, jobID int64, req sdk.JobIDTokenRequest) (sdk.JobIDToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobIDToken", ctx, jobID, req)
+	ret0, _ := ret[0].(sdk.JobIDToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobIDToken indicates an expected call of QueueJobIDToken.
+func (mr *MockInterfaceMockRecorder) QueueJobIDToken(ctx, jobID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobIDToken", reflect.TypeOf((*MockInterface)(nil).QueueJobIDToken), ctx, jobID, req)
+}
+
 // QueueJobInfo mocks base method.
 func (m *MockInterface) QueueJobInfo(ctx context.Context, id int64) (*sdk.WorkflowNodeJobRun, error) {
 	m.ctrl.T.Helper()
@@ -7979,18 +8625,19 @@ func (mr *MockInterfaceMockRecorder) QueuePolling(ctx, goRoutines, jobs, errs, d
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueuePolling", reflect.TypeOf((*MockInterface)(nil).QueuePolling), varargs...)
 }
 
-// QueueSendCoverage mocks base method.
-func (m *MockInterface) QueueSendCoverage(ctx context.Context, id int64, report coverage.Report) error {
+// QueueQuotaUsages mocks base method.
+func (m *MockInterface) QueueQuotaUsages(ctx context.Context) ([]sdk.QueueQuotaUsage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueSendCoverage", ctx, id, report)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "QueueQuotaUsages", ctx)
+	ret0, _ := ret[0].([]sdk.QueueQuotaUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// QueueSendCoverage indicates an expected call of QueueSendCoverage.
-func (mr *MockInterfaceMockRecorder) QueueSendCoverage(ctx, id, report interface{}) *gomock.Call {
+// QueueQuotaUsages indicates an expected call of QueueQuotaUsages.
+func (mr *MockInterfaceMockRecorder) QueueQuotaUsages(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendCoverage", reflect.TypeOf((*MockInterface)(nil).QueueSendCoverage), ctx, id, report)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueQuotaUsages", reflect.TypeOf((*MockInterface)(nil).QueueQuotaUsages), ctx)
 }
 
 // QueueSendResult mocks base method.
@@ -8155,6 +8802,21 @@ func (mr *MockInterfaceMockRecorder) QueueWorkflowRunResultsRelease(ctx, permJob
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueWorkflowRunResultsRelease", reflect.TypeOf((*MockInterface)(nil).QueueWorkflowRunResultsRelease), ctx, permJobID, runResultIDs, to)
 }
 
+// RBACExplain mocks base method.
+func (m *MockInterface) RBACExplain(ctx context.Context, req sdk.RBACExplainRequest) (sdk.RBACExplanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACExplain", ctx, req)
+	ret0, _ := ret[0].(sdk.RBACExplanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACExplain indicates an expected call of RBACExplain.
+func (mr *MockInterfaceMockRecorder) RBACExplain(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACExplain", reflect.TypeOf((*MockInterface)(nil).RBACExplain), ctx, req)
+}
+
 // RBACImport mocks base method.
 func (m *MockInterface) RBACImport(ctx context.Context, content io.Reader, mods ...cdsclient.RequestModifier) (sdk.RBAC, error) {
 	m.ctrl.T.Helper()
@@ -8425,6 +9087,21 @@ func (mr *MockInterfaceMockRecorder) ServiceConfigurationGet(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceConfigurationGet", reflect.TypeOf((*MockInterface)(nil).ServiceConfigurationGet), arg0, arg1)
 }
 
+// ServiceGitMirrorAccess mocks base method.
+func (m *MockInterface) ServiceGitMirrorAccess(arg0 context.Context) (sdk.GitMirrorAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceGitMirrorAccess", arg0)
+	ret0, _ := ret[0].(sdk.GitMirrorAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServiceGitMirrorAccess indicates an expected call of ServiceGitMirrorAccess.
+func (mr *MockInterfaceMockRecorder) ServiceGitMirrorAccess(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceGitMirrorAccess", reflect.TypeOf((*MockInterface)(nil).ServiceGitMirrorAccess), arg0)
+}
+
 // ServiceDelete mocks base method.
 func (m *MockInterface) ServiceDelete(name string) error {
 	m.ctrl.T.Helper()
@@ -9423,6 +10100,20 @@ func (mr *MockInterfaceMockRecorder) WorkflowGroupDelete(projectKey, name, group
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowGroupDelete", reflect.TypeOf((*MockInterface)(nil).WorkflowGroupDelete), projectKey, name, groupName)
 }
 
+// WorkflowHookChatOps mocks base method.
+func (m *MockInterface) WorkflowHookChatOps(ctx context.Context, cmd sdk.ChatOpsCommand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowHookChatOps", ctx, cmd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowHookChatOps indicates an expected call of WorkflowHookChatOps.
+func (mr *MockInterfaceMockRecorder) WorkflowHookChatOps(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowHookChatOps", reflect.TypeOf((*MockInterface)(nil).WorkflowHookChatOps), ctx, cmd)
+}
+
 // WorkflowImport mocks base method.
 func (m *MockInterface) WorkflowImport(projectKey string, content io.Reader, mods ...cdsclient.RequestModifier) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -9953,6 +10644,35 @@ func (mr *MockWorkerInterfaceMockRecorder) CDNItemUpload(ctx, cdnAddr, signature
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CDNItemUpload", reflect.TypeOf((*MockWorkerInterface)(nil).CDNItemUpload), ctx, cdnAddr, signature, fs, path)
 }
 
+// ConfigRepositories mocks base method.
+func (m *MockWorkerInterface) ConfigRepositories() (sdk.RepositoriesConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigRepositories")
+	ret0, _ := ret[0].(sdk.RepositoriesConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfigRepositories indicates an expected call of ConfigRepositories.
+func (mr *MockWorkerInterfaceMockRecorder) ConfigRepositories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigRepositories", reflect.TypeOf((*MockWorkerInterface)(nil).ConfigRepositories))
+}
+
+// GitMirrorAuthorization mocks base method.
+func (m *MockWorkerInterface) GitMirrorAuthorization() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GitMirrorAuthorization")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GitMirrorAuthorization indicates an expected call of GitMirrorAuthorization.
+func (mr *MockWorkerInterfaceMockRecorder) GitMirrorAuthorization() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GitMirrorAuthorization", reflect.TypeOf((*MockWorkerInterface)(nil).GitMirrorAuthorization))
+}
+
 // PluginAdd mocks base method.
 func (m *MockWorkerInterface) PluginAdd(arg0 *sdk.GRPCPlugin) error {
 	m.ctrl.T.Helper()
@@ -10142,6 +10862,36 @@ func (mr *MockWorkerInterfaceMockRecorder) QueueJobBook(ctx, id interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobBook", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobBook), ctx, id)
 }
 
+// QueueJobGitMirrorAccess mocks base method.
+func (m *MockWorkerInterface) QueueJobGitMirrorAccess(ctx context.Context, jobID int64) (sdk.GitMirrorAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobGitMirrorAccess", ctx, jobID)
+	ret0, _ := ret[0].(sdk.GitMirrorAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobGitMirrorAccess indicates an expected call of QueueJobGitMirrorAccess.
+func (mr *MockWorkerInterfaceMockRecorder) QueueJobGitMirrorAccess(ctx, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobGitMirrorAccess", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobGitMirrorAccess), ctx, jobID)
+}
+
+// QueueJobIDToken mocks base method.
+func (m *MockWorkerInterface) QueueJobIDToken(ctx context.Context, jobID int64, req sdk.JobIDTokenRequest) (sdk.JobIDToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobIDToken", ctx, jobID, req)
+	ret0, _ := ret[0].(sdk.JobIDToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobIDToken indicates an expected call of QueueJobIDToken.
+func (mr *MockWorkerInterfaceMockRecorder) QueueJobIDToken(ctx, jobID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobIDToken", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobIDToken), ctx, jobID, req)
+}
+
 // QueueJobInfo mocks base method.
 func (m *MockWorkerInterface) QueueJobInfo(ctx context.Context, id int64) (*sdk.WorkflowNodeJobRun, error) {
 	m.ctrl.T.Helper()
@@ -10232,18 +10982,19 @@ func (mr *MockWorkerInterfaceMockRecorder) QueuePolling(ctx, goRoutines, jobs, e
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueuePolling", reflect.TypeOf((*MockWorkerInterface)(nil).QueuePolling), varargs...)
 }
 
-// QueueSendCoverage mocks base method.
-func (m *MockWorkerInterface) QueueSendCoverage(ctx context.Context, id int64, report coverage.Report) error {
+// QueueQuotaUsages mocks base method.
+func (m *MockWorkerInterface) QueueQuotaUsages(ctx context.Context) ([]sdk.QueueQuotaUsage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueSendCoverage", ctx, id, report)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "QueueQuotaUsages", ctx)
+	ret0, _ := ret[0].([]sdk.QueueQuotaUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// QueueSendCoverage indicates an expected call of QueueSendCoverage.
-func (mr *MockWorkerInterfaceMockRecorder) QueueSendCoverage(ctx, id, report interface{}) *gomock.Call {
+// QueueQuotaUsages indicates an expected call of QueueQuotaUsages.
+func (mr *MockWorkerInterfaceMockRecorder) QueueQuotaUsages(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendCoverage", reflect.TypeOf((*MockWorkerInterface)(nil).QueueSendCoverage), ctx, id, report)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueQuotaUsages", reflect.TypeOf((*MockWorkerInterface)(nil).QueueQuotaUsages), ctx)
 }
 
 // QueueSendResult mocks base method.
@@ -10438,6 +11189,21 @@ func (mr *MockWorkerInterfaceMockRecorder) ServiceConfigurationGet(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceConfigurationGet", reflect.TypeOf((*MockWorkerInterface)(nil).ServiceConfigurationGet), arg0, arg1)
 }
 
+// ServiceGitMirrorAccess mocks base method.
+func (m *MockWorkerInterface) ServiceGitMirrorAccess(arg0 context.Context) (sdk.GitMirrorAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceGitMirrorAccess", arg0)
+	ret0, _ := ret[0].(sdk.GitMirrorAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServiceGitMirrorAccess indicates an expected call of ServiceGitMirrorAccess.
+func (mr *MockWorkerInterfaceMockRecorder) ServiceGitMirrorAccess(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceGitMirrorAccess", reflect.TypeOf((*MockWorkerInterface)(nil).ServiceGitMirrorAccess), arg0)
+}
+
 // WorkerDisable mocks base method.
 func (m *MockWorkerInterface) WorkerDisable(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
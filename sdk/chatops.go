@@ -0,0 +1,131 @@
+package sdk
+
+import (
+	"strings"
+)
+
+// ChatOpsCommandPrefix starts the lines of a pull request comment that are commands for CDS.
+const ChatOpsCommandPrefix = "/cds"
+
+// ChatOps commands that can be posted in pull request comments.
+const (
+	// ChatOpsCommandRetry restarts the failed pipelines of the last workflow run of the pull request.
+	ChatOpsCommandRetry = "retry"
+	// ChatOpsCommandRun starts a workflow on the pull request head commit.
+	ChatOpsCommandRun = "run"
+	// ChatOpsCommandApprove starts the manual pipelines waiting in the last workflow run of the pull request.
+	ChatOpsCommandApprove = "approve"
+	// ChatOpsCommandVerify links the author of the comment to the CDS user that requested the verification code.
+	ChatOpsCommandVerify = "verify"
+)
+
+// ChatOpsCommand is a command posted in a pull request comment, sent by the hooks service to the API with the
+// repository webhook that received the comment.
+type ChatOpsCommand struct {
+	HookUUID      string            `json:"hook_uuid"`
+	Repository    string            `json:"repository"`
+	PullRequestID int64             `json:"pull_request_id"`
+	Author        string            `json:"author"`
+	Name          string            `json:"name"`
+	Workflow      string            `json:"workflow,omitempty"`
+	Params        map[string]string `json:"params,omitempty"`
+	Code          string            `json:"code,omitempty"`
+}
+
+// IsValid returns an error if the command is not a known command with valid arguments.
+func (c ChatOpsCommand) IsValid() error {
+	if c.HookUUID == "" || c.Repository == "" || c.PullRequestID == 0 || c.Author == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid command: missing hook, repository, pull request or author")
+	}
+	switch c.Name {
+	case ChatOpsCommandRetry, ChatOpsCommandApprove:
+	case ChatOpsCommandRun:
+		if c.Workflow == "" {
+			return NewErrorFrom(ErrWrongRequest, "invalid command: missing workflow name")
+		}
+		for k := range c.Params {
+			if err := checkChatOpsParam(k); err != nil {
+				return err
+			}
+		}
+	case ChatOpsCommandVerify:
+		if c.Code == "" {
+			return NewErrorFrom(ErrWrongRequest, "invalid command: missing verification code")
+		}
+	default:
+		return NewErrorFrom(ErrWrongRequest, "unknown command %q", c.Name)
+	}
+	return nil
+}
+
+// checkChatOpsParam returns an error for the git parameters, they are set from the pull request and can't be
+// overridden.
+func checkChatOpsParam(key string) error {
+	if strings.HasPrefix(key, "git.") {
+		return NewErrorFrom(ErrWrongRequest, "parameter %s can't be overridden", key)
+	}
+	return nil
+}
+
+func (c ChatOpsCommand) String() string {
+	s := ChatOpsCommandPrefix + " " + c.Name
+	if c.Workflow != "" {
+		s += " " + c.Workflow
+	}
+	return s
+}
+
+// ParseChatOpsCommands returns the commands of a pull request comment, one per line starting with /cds:
+//
+//	/cds retry
+//	/cds run <workflow> [key=value...]
+//	/cds approve
+//	/cds verify <code>
+func ParseChatOpsCommands(comment string) ([]ChatOpsCommand, error) {
+	var cmds []ChatOpsCommand
+	for _, line := range strings.Split(comment, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != ChatOpsCommandPrefix {
+			continue
+		}
+		if len(fields) == 1 {
+			return nil, NewErrorFrom(ErrWrongRequest, "missing command after %s", ChatOpsCommandPrefix)
+		}
+
+		cmd := ChatOpsCommand{Name: fields[1]}
+		args := fields[2:]
+		switch cmd.Name {
+		case ChatOpsCommandRetry, ChatOpsCommandApprove:
+			if len(args) > 0 {
+				return nil, NewErrorFrom(ErrWrongRequest, "command %s takes no argument", cmd.Name)
+			}
+		case ChatOpsCommandRun:
+			if len(args) == 0 {
+				return nil, NewErrorFrom(ErrWrongRequest, "missing workflow name: %s %s <workflow> [key=value...]", ChatOpsCommandPrefix, cmd.Name)
+			}
+			cmd.Workflow = args[0]
+			for _, a := range args[1:] {
+				k, v, ok := strings.Cut(a, "=")
+				if !ok || k == "" {
+					return nil, NewErrorFrom(ErrWrongRequest, "invalid parameter %q, expected key=value", a)
+				}
+				if err := checkChatOpsParam(k); err != nil {
+					return nil, err
+				}
+				if cmd.Params == nil {
+					cmd.Params = make(map[string]string)
+				}
+				cmd.Params[k] = v
+			}
+		case ChatOpsCommandVerify:
+			if len(args) != 1 {
+				return nil, NewErrorFrom(ErrWrongRequest, "missing verification code: %s %s <code>", ChatOpsCommandPrefix, cmd.Name)
+			}
+			cmd.Code = args[0]
+		default:
+			return nil, NewErrorFrom(ErrWrongRequest, "unknown command %q", cmd.Name)
+		}
+		cmds = append(cmds, cmd)
+	}
+	return cmds, nil
+}
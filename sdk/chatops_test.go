@@ -0,0 +1,61 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChatOpsCommands(t *testing.T) {
+	cmds, err := ParseChatOpsCommands("Looks flaky\n/cds retry\n  /cds run deploy-preview env=staging version=1.2\n")
+	require.NoError(t, err)
+	require.Equal(t, []ChatOpsCommand{
+		{Name: ChatOpsCommandRetry},
+		{Name: ChatOpsCommandRun, Workflow: "deploy-preview", Params: map[string]string{"env": "staging", "version": "1.2"}},
+	}, cmds)
+
+	cmds, err = ParseChatOpsCommands("LGTM, /cds approve is not at the start of the line")
+	require.NoError(t, err)
+	require.Empty(t, cmds)
+
+	_, err = ParseChatOpsCommands("/cds")
+	require.Error(t, err)
+	_, err = ParseChatOpsCommands("/cds deploy")
+	require.Error(t, err)
+	_, err = ParseChatOpsCommands("/cds run")
+	require.Error(t, err)
+	_, err = ParseChatOpsCommands("/cds run deploy-preview staging")
+	require.Error(t, err)
+	_, err = ParseChatOpsCommands("/cds approve now")
+	require.Error(t, err)
+	_, err = ParseChatOpsCommands("/cds run deploy-preview git.hash=abcdef")
+	require.Error(t, err)
+
+	cmds, err = ParseChatOpsCommands("/cds verify 0123456789abcdef")
+	require.NoError(t, err)
+	require.Equal(t, []ChatOpsCommand{{Name: ChatOpsCommandVerify, Code: "0123456789abcdef"}}, cmds)
+	_, err = ParseChatOpsCommands("/cds verify")
+	require.Error(t, err)
+}
+
+func TestChatOpsCommandIsValid(t *testing.T) {
+	cmd := ChatOpsCommand{HookUUID: "uuid", Repository: "ovh/cds", PullRequestID: 42, Author: "octocat", Name: ChatOpsCommandApprove}
+	require.NoError(t, cmd.IsValid())
+
+	cmd.Name = ChatOpsCommandRun
+	require.Error(t, cmd.IsValid())
+	cmd.Workflow = "deploy-preview"
+	require.NoError(t, cmd.IsValid())
+	require.Equal(t, "/cds run deploy-preview", cmd.String())
+	cmd.Params = map[string]string{"git.branch": "master"}
+	require.Error(t, cmd.IsValid())
+	cmd.Params = nil
+
+	cmd.Name = ChatOpsCommandVerify
+	require.Error(t, cmd.IsValid())
+	cmd.Code = "0123456789abcdef"
+	require.NoError(t, cmd.IsValid())
+
+	cmd.Author = ""
+	require.Error(t, cmd.IsValid())
+}
@@ -20,3 +20,66 @@ type CanonicalFormUsage struct {
 }
 
 type CanonicalFormUsageResume map[string][]CanonicalFormUsage
+
+const (
+	DatabaseRollJobTypeSignature  = "signature"
+	DatabaseRollJobTypeEncryption = "encryption"
+
+	DatabaseRollJobStatusPending  = "pending"
+	DatabaseRollJobStatusRunning  = "running"
+	DatabaseRollJobStatusDone     = "done"
+	DatabaseRollJobStatusFail     = "fail"
+	DatabaseRollJobStatusCanceled = "canceled"
+
+	DefaultDatabaseRollJobBatchSize = 100
+)
+
+// DatabaseRollJobRequest asks for the roll of all the tuples of given entities (or all entities if empty).
+// Delay is the pause in milliseconds between two batches.
+type DatabaseRollJobRequest struct {
+	Type      string   `json:"type"`
+	Entities  []string `json:"entities,omitempty"`
+	BatchSize int64    `json:"batch_size,omitempty"`
+	Delay     int64    `json:"delay_ms,omitempty"`
+}
+
+// IsValid returns an error if the request is invalid.
+func (r DatabaseRollJobRequest) IsValid() error {
+	if r.Type != DatabaseRollJobTypeSignature && r.Type != DatabaseRollJobTypeEncryption {
+		return NewErrorFrom(ErrWrongRequest, "invalid roll job type %q", r.Type)
+	}
+	if r.BatchSize < 0 || r.Delay < 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid batch size or delay")
+	}
+	return nil
+}
+
+// DatabaseRollJob is a server side job that rolls all the tuples of an entity to the latest signature or encryption key.
+// LastPK is the primary key of the last rolled tuple, it is used to resume the job.
+type DatabaseRollJob struct {
+	ID           int64      `json:"id" db:"id" cli:"id,key"`
+	Type         string     `json:"type" db:"type" cli:"type"`
+	Entity       string     `json:"entity" db:"entity" cli:"entity"`
+	Status       string     `json:"status" db:"status" cli:"status"`
+	BatchSize    int64      `json:"batch_size" db:"batch_size" cli:"-"`
+	Delay        int64      `json:"delay_ms" db:"delay_ms" cli:"-"`
+	Total        int64      `json:"total" db:"total" cli:"total"`
+	Processed    int64      `json:"processed" db:"processed" cli:"processed"`
+	LastPK       *string    `json:"last_pk,omitempty" db:"last_pk" cli:"-"`
+	Error        string     `json:"error,omitempty" db:"error" cli:"error"`
+	Created      time.Time  `json:"created" db:"created" cli:"created"`
+	LastModified time.Time  `json:"last_modified" db:"last_modified" cli:"last_modified"`
+	Done         *time.Time `json:"done,omitempty" db:"done" cli:"-"`
+}
+
+// Progress returns the percentage of rolled tuples.
+func (j DatabaseRollJob) Progress() float64 {
+	if j.Total == 0 {
+		return 100
+	}
+	p := float64(j.Processed) * 100 / float64(j.Total)
+	if p > 100 {
+		return 100
+	}
+	return p
+}
@@ -0,0 +1,22 @@
+package sdk_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestDatabaseRollJobRequestIsValid(t *testing.T) {
+	require.NoError(t, sdk.DatabaseRollJobRequest{Type: sdk.DatabaseRollJobTypeSignature}.IsValid())
+	require.NoError(t, sdk.DatabaseRollJobRequest{Type: sdk.DatabaseRollJobTypeEncryption, BatchSize: 10, Delay: 100}.IsValid())
+	require.Error(t, sdk.DatabaseRollJobRequest{Type: "unknown"}.IsValid())
+	require.Error(t, sdk.DatabaseRollJobRequest{Type: sdk.DatabaseRollJobTypeSignature, BatchSize: -1}.IsValid())
+}
+
+func TestDatabaseRollJobProgress(t *testing.T) {
+	require.Equal(t, float64(100), sdk.DatabaseRollJob{}.Progress())
+	require.Equal(t, float64(25), sdk.DatabaseRollJob{Total: 200, Processed: 50}.Progress())
+	require.Equal(t, float64(100), sdk.DatabaseRollJob{Total: 10, Processed: 12}.Progress())
+}
@@ -200,6 +200,9 @@ var (
 	ErrMFARequired                                   = Error{ID: 194, Status: http.StatusForbidden}
 	ErrHatcheryNoResourceAvailable                   = Error{ID: 195, Status: http.StatusInternalServerError}
 	ErrRegionNotAllowed                              = Error{ID: 196, Status: http.StatusInternalServerError}
+	ErrRateLimitReached                              = Error{ID: 197, Status: http.StatusTooManyRequests}
+	ErrMFATooManyAttempts                            = Error{ID: 198, Status: http.StatusTooManyRequests}
+	ErrQueueQuotaReached                             = Error{ID: 199, Status: http.StatusTooManyRequests}
 )
 
 var errorsAmericanEnglish = map[int]string{
@@ -383,6 +386,9 @@ var errorsAmericanEnglish = map[int]string{
 	ErrMFARequired.ID:                                   "Multi factor authentication is required",
 	ErrHatcheryNoResourceAvailable.ID:                   "No enough resource available to start worker",
 	ErrRegionNotAllowed.ID:                              "Region not allowed",
+	ErrRateLimitReached.ID:                              "Rate limit of the repository manager reached, retry later",
+	ErrMFATooManyAttempts.ID:                            "Too many invalid second factor attempts, retry later",
+	ErrQueueQuotaReached.ID:                             "Max concurrent jobs per model of the queue quota reached",
 }
 
 // Error type.
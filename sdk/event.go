@@ -80,16 +80,18 @@ type EventRunWorkflowNode struct {
 	NodeType              string                    `json:"node_type,omitempty"`
 	GerritChange          *GerritChangeEvent        `json:"gerrit_change,omitempty"`
 	EventIntegrations     []int64                   `json:"event_integrations_id,omitempty"`
+	CheckRun              *VCSCheckRun              `json:"check_run,omitempty"`
 }
 
 // GerritChangeEvent Gerrit information that are needed on event
 type GerritChangeEvent struct {
-	ID         string `json:"id,omitempty"`
-	Project    string `json:"project,omitempty"`
-	DestBranch string `json:"dest_branch,omitempty"`
-	Revision   string `json:"revision,omitempty"`
-	Report     string `json:"report,omitempty"`
-	URL        string `json:"url,omitempty"`
+	ID         string        `json:"id,omitempty"`
+	Project    string        `json:"project,omitempty"`
+	DestBranch string        `json:"dest_branch,omitempty"`
+	Revision   string        `json:"revision,omitempty"`
+	Report     string        `json:"report,omitempty"`
+	URL        string        `json:"url,omitempty"`
+	Labels     []GerritLabel `json:"labels,omitempty"`
 }
 
 // EventRunWorkflowOutgoingHook contains event data for a workflow outgoing hook run
@@ -61,6 +61,17 @@ type EventApplicationKeyDelete struct {
 	Key ApplicationKey `json:"key"`
 }
 
+// EventApplicationKeyRotate represents the event when rotating an application key
+type EventApplicationKeyRotate struct {
+	Key      ApplicationKey `json:"key"`
+	Rotation KeyRotation    `json:"rotation"`
+}
+
+// EventApplicationKeyRetire represents the event when retiring a rotated application key at the end of its grace period
+type EventApplicationKeyRetire struct {
+	Rotation KeyRotation `json:"rotation"`
+}
+
 // EventApplicationRepositoryAdd represents the event when adding a repository to an application
 type EventApplicationRepositoryAdd struct {
 	VCSServer  string `json:"vcs_server"`
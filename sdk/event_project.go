@@ -62,6 +62,22 @@ type EventProjectKeyDelete struct {
 	Key ProjectKey `json:"key"`
 }
 
+// EventProjectKeyRotate represents the event when rotating a project key
+type EventProjectKeyRotate struct {
+	Key      ProjectKey  `json:"key"`
+	Rotation KeyRotation `json:"rotation"`
+}
+
+// EventProjectKeyRetire represents the event when retiring a rotated project key at the end of its grace period
+type EventProjectKeyRetire struct {
+	Rotation KeyRotation `json:"rotation"`
+}
+
+// EventProjectKeyRotationPolicyUpdate represents the event when updating the key rotation policy of a project
+type EventProjectKeyRotationPolicyUpdate struct {
+	Policy KeyRotationPolicy `json:"policy"`
+}
+
 // EventProjectVCSServerAdd represents the event when adding a project vcs server
 type EventProjectVCSServerAdd struct {
 	VCSServerName string `json:"vcs_server"`
@@ -48,6 +48,7 @@ type Job struct {
 	Requirements   []Requirement `json:"requirements,omitempty" yaml:"requirements,omitempty" jsonschema_description:"The list of requirements for the jobs."`
 	Optional       *bool         `json:"optional,omitempty" yaml:"optional,omitempty" jsonschema_description:"Set this option to ignore job's errors."`
 	AlwaysExecuted *bool         `json:"always_executed,omitempty" yaml:"always_executed,omitempty" jsonschema_description:"Set this option to execute the job even if a previous step failed."`
+	PriorityClass  string        `json:"priority_class,omitempty" yaml:"priority_class,omitempty" jsonschema_description:"The priority class of the job in the queue: high, normal or low."`
 }
 
 // Requirement represents an exported sdk.Requirement
@@ -62,6 +63,7 @@ type Requirement struct {
 	OSArchRequirement string             `json:"os-architecture,omitempty" yaml:"os-architecture,omitempty"`
 	RegionRequirement string             `json:"region,omitempty" yaml:"region,omitempty"`
 	SecretRequirement string             `json:"secret,omitempty" yaml:"secret,omitempty"`
+	RegionPreference  string             `json:"region-preference,omitempty" yaml:"region-preference,omitempty"`
 }
 
 // ServiceRequirement represents an exported sdk.Requirement of type ServiceRequirement
@@ -153,6 +155,8 @@ func NewRequirements(req []sdk.Requirement) []Requirement {
 			res = append(res, Requirement{OSArchRequirement: r.Value})
 		case sdk.RegionRequirement:
 			res = append(res, Requirement{RegionRequirement: r.Value})
+		case sdk.RegionPreferenceRequirement:
+			res = append(res, Requirement{RegionPreference: r.Value})
 		case sdk.MemoryRequirement:
 			res = append(res, Requirement{Memory: r.Value})
 		case sdk.SecretRequirement:
@@ -172,6 +176,7 @@ func newJob(j sdk.Job) Job {
 	jo.Steps = newSteps(j.Action)
 	jo.Description = j.Action.Description
 	jo.Requirements = NewRequirements(j.Action.Requirements)
+	jo.PriorityClass = j.PriorityClass
 	return jo
 }
 
@@ -228,6 +233,10 @@ func computeJobRequirements(req []Requirement) []sdk.Requirement {
 			name = "region"
 			val = r.RegionRequirement
 			tpe = sdk.RegionRequirement
+		} else if r.RegionPreference != "" {
+			name = "region-preference"
+			val = r.RegionPreference
+			tpe = sdk.RegionPreferenceRequirement
 		} else if r.Plugin != "" {
 			name = r.Plugin
 			val = r.Plugin
@@ -261,6 +270,7 @@ func computeJob(name string, j Job) (*sdk.Job, error) {
 			Description: j.Description,
 			Type:        sdk.JoinedAction,
 		},
+		PriorityClass: j.PriorityClass,
 	}
 	if j.Enabled != nil {
 		job.Enabled = *j.Enabled
@@ -280,6 +290,9 @@ func computeJob(name string, j Job) (*sdk.Job, error) {
 	if err := job.Action.Requirements.IsValid(); err != nil {
 		return nil, err
 	}
+	if !sdk.JobPriorityClassValidate(job.PriorityClass) {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid priority class %q on job %s", job.PriorityClass, name)
+	}
 
 	return &job, nil
 }
@@ -137,6 +137,32 @@ func NewStep(act sdk.Action) Step {
 			if prefix != nil {
 				s.GitTag.Prefix = prefix.Value
 			}
+		case sdk.SemverAction:
+			s.Semver = &StepSemver{}
+			path := sdk.ParameterFind(act.Parameters, "path")
+			if path != nil {
+				s.Semver.Path = path.Value
+			}
+			prefix := sdk.ParameterFind(act.Parameters, "prefix")
+			if prefix != nil {
+				s.Semver.Prefix = prefix.Value
+			}
+			initialVersion := sdk.ParameterFind(act.Parameters, "initialVersion")
+			if initialVersion != nil {
+				s.Semver.InitialVersion = initialVersion.Value
+			}
+			releaseBranches := sdk.ParameterFind(act.Parameters, "releaseBranches")
+			if releaseBranches != nil {
+				s.Semver.ReleaseBranches = releaseBranches.Value
+			}
+			createTag := sdk.ParameterFind(act.Parameters, "createTag")
+			if createTag != nil && createTag.Value == "true" {
+				s.Semver.CreateTag = createTag.Value
+			}
+			tagMessage := sdk.ParameterFind(act.Parameters, "tagMessage")
+			if tagMessage != nil {
+				s.Semver.TagMessage = tagMessage.Value
+			}
 		case sdk.PromoteAction:
 			s.Promote = &StepPromote{}
 			artifacts := sdk.ParameterFind(act.Parameters, "artifacts")
@@ -195,6 +221,14 @@ func NewStep(act sdk.Action) Step {
 			if title != nil {
 				s.ReleaseVCS.Title = title.Value
 			}
+			generateReleaseNotes := sdk.ParameterFind(act.Parameters, "generateReleaseNotes")
+			if generateReleaseNotes != nil && generateReleaseNotes.Value == "true" {
+				s.ReleaseVCS.GenerateReleaseNotes = generateReleaseNotes.Value
+			}
+			previousTag := sdk.ParameterFind(act.Parameters, "previousTag")
+			if previousTag != nil {
+				s.ReleaseVCS.PreviousTag = previousTag.Value
+			}
 		case sdk.JUnitAction:
 			var step StepJUnitReport
 			path := sdk.ParameterFind(act.Parameters, "path")
@@ -310,12 +344,24 @@ type StepRelease struct {
 	SetProperties string `json:"setProperties,omitempty" yaml:"setProperties,omitempty"`
 }
 
+// StepSemver represents exported semver step.
+type StepSemver struct {
+	Path            string `json:"path,omitempty" yaml:"path,omitempty"`
+	Prefix          string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	InitialVersion  string `json:"initialVersion,omitempty" yaml:"initialVersion,omitempty"`
+	ReleaseBranches string `json:"releaseBranches,omitempty" yaml:"releaseBranches,omitempty"`
+	CreateTag       string `json:"createTag,omitempty" yaml:"createTag,omitempty"`
+	TagMessage      string `json:"tagMessage,omitempty" yaml:"tagMessage,omitempty"`
+}
+
 // StepReleaseVCS represents exported release step.
 type StepReleaseVCS struct {
-	Artifacts   string `json:"artifacts,omitempty" yaml:"artifacts,omitempty"`
-	ReleaseNote string `json:"releaseNote,omitempty" yaml:"releaseNote,omitempty"`
-	Tag         string `json:"tag,omitempty" yaml:"tag,omitempty" jsonschema:"required"`
-	Title       string `json:"title,omitempty" yaml:"title,omitempty" jsonschema:"required"`
+	Artifacts            string `json:"artifacts,omitempty" yaml:"artifacts,omitempty"`
+	ReleaseNote          string `json:"releaseNote,omitempty" yaml:"releaseNote,omitempty"`
+	Tag                  string `json:"tag,omitempty" yaml:"tag,omitempty" jsonschema:"required"`
+	Title                string `json:"title,omitempty" yaml:"title,omitempty" jsonschema:"required"`
+	GenerateReleaseNotes string `json:"generateReleaseNotes,omitempty" yaml:"generateReleaseNotes,omitempty"`
+	PreviousTag          string `json:"previousTag,omitempty" yaml:"previousTag,omitempty"`
 }
 
 // StepGitTag represents exported git tag step.
@@ -356,6 +402,7 @@ type Step struct {
 	ArtifactUpload   *StepArtifactUpload   `json:"artifactUpload,omitempty" yaml:"artifactUpload,omitempty" jsonschema:"oneof_required=actionArtifactUpload" jsonschema_description:"Upload artifacts from workspace.\nhttps://ovh.github.io/cds/docs/actions/builtin-artifact-upload"`
 	GitClone         *StepGitClone         `json:"gitClone,omitempty" yaml:"gitClone,omitempty" jsonschema:"oneof_required=actionGitClone" jsonschema_description:"Clone a git repository.\nhttps://ovh.github.io/cds/docs/actions/builtin-gitclone"`
 	GitTag           *StepGitTag           `json:"gitTag,omitempty" yaml:"gitTag,omitempty" jsonschema:"oneof_required=actionGitTag" jsonschema_description:"Create a git tag.\nhttps://ovh.github.io/cds/docs/actions/builtin-gittag"`
+	Semver           *StepSemver           `json:"semver,omitempty" yaml:"semver,omitempty" jsonschema:"oneof_required=actionSemver" jsonschema_description:"Compute the next semantic version from git history.\nhttps://ovh.github.io/cds/docs/actions/builtin-semver"`
 	ReleaseVCS       *StepReleaseVCS       `json:"releaseVCS,omitempty" yaml:"releaseVCS,omitempty" jsonschema:"oneof_required=actionReleaseVCS" jsonschema_description:"Release an application.\nhttps://ovh.github.io/cds/docs/actions/builtin-releasevcs"`
 	Release          *StepRelease          `json:"release,omitempty" yaml:"release,omitempty" jsonschema:"oneof_required=actionRelease" jsonschema_description:"Release an application.\nhttps://ovh.github.io/cds/docs/actions/builtin-release"`
 	Promote          *StepPromote          `json:"promote,omitempty" yaml:"promote,omitempty" jsonschema:"oneof_required=actionPromote" jsonschema_description:"Promote artifacts.\nhttps://ovh.github.io/cds/docs/actions/builtin-promote"`
@@ -461,6 +508,9 @@ func (s Step) IsValid() bool {
 	if s.isGitTag() {
 		count++
 	}
+	if s.isSemver() {
+		count++
+	}
 	if s.isReleaseVCS() {
 		count++
 	}
@@ -507,6 +557,8 @@ func (s Step) toAction() (*sdk.Action, error) {
 		a, err = s.asGitClone()
 	} else if s.isGitTag() {
 		a, err = s.asGitTag()
+	} else if s.isSemver() {
+		a, err = s.asSemver()
 	} else if s.isReleaseVCS() {
 		a, err = s.asReleaseVCS()
 	} else if s.isPromote() {
@@ -721,6 +773,22 @@ func (s Step) asRelease() (sdk.Action, error) {
 	return a, nil
 }
 
+func (s Step) isSemver() bool { return s.Semver != nil }
+
+func (s Step) asSemver() (sdk.Action, error) {
+	var a sdk.Action
+	m, err := stepToMap(s.Semver)
+	if err != nil {
+		return a, err
+	}
+	a = sdk.Action{
+		Name:       sdk.SemverAction,
+		Type:       sdk.BuiltinAction,
+		Parameters: sdk.ParametersFromMap(m),
+	}
+	return a, nil
+}
+
 func (s Step) isReleaseVCS() bool { return s.ReleaseVCS != nil }
 
 func (s Step) asReleaseVCS() (sdk.Action, error) {
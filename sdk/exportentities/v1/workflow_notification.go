@@ -68,7 +68,7 @@ func craftNotificationEntry(ctx context.Context, w sdk.Workflow, notif sdk.Workf
 			entry.Settings.Template.Body = ""
 		}
 		if entry.Settings.Template.Body == "" && entry.Settings.Template.Subject == "" {
-			if entry.Settings.Template.DisableComment == nil || !*entry.Settings.Template.DisableComment {
+			if (entry.Settings.Template.DisableComment == nil || !*entry.Settings.Template.DisableComment) && len(entry.Settings.Template.GerritLabels) == 0 {
 				entry.Settings.Template = nil
 			}
 		}
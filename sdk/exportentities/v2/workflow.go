@@ -59,6 +59,7 @@ type NodeEntry struct {
 	EnvironmentName        string                 `json:"environment,omitempty" yaml:"environment,omitempty" jsonschema_description:"The environment to use in the context of the node.\nhttps://ovh.github.io/cds/docs/concepts/workflow/pipeline-context"`
 	ProjectIntegrationName string                 `json:"integration,omitempty" yaml:"integration,omitempty" jsonschema_description:"The integration to use in the context of the node.\nhttps://ovh.github.io/cds/docs/concepts/workflow/pipeline-context"`
 	OneAtATime             *bool                  `json:"one_at_a_time,omitempty" yaml:"one_at_a_time,omitempty" jsonschema_description:"Set to true if you want to limit the execution of this node to one at a time."`
+	PathFilter             *sdk.PathFilter        `json:"path_filter,omitempty" yaml:"path_filter,omitempty" jsonschema_description:"Globs of the files changed by a push that trigger this node.\nhttps://ovh.github.io/cds/docs/concepts/workflow/path-filters"`
 	Payload                map[string]interface{} `json:"payload,omitempty" yaml:"payload,omitempty"`
 	Parameters             map[string]string      `json:"parameters,omitempty" yaml:"parameters,omitempty" jsonschema_description:"List of parameters for the workflow."`
 	OutgoingHookModelName  string                 `json:"trigger,omitempty" yaml:"trigger,omitempty"`
@@ -299,6 +300,9 @@ func craftNodeEntry(w sdk.Workflow, n sdk.Node) (NodeEntry, error) {
 		if n.Context.Mutex {
 			entry.OneAtATime = &n.Context.Mutex
 		}
+		if !n.Context.PathFilter.IsEmpty() {
+			entry.PathFilter = &n.Context.PathFilter
+		}
 
 		if n.Context.HasDefaultPayload() {
 			enc := dump.NewDefaultEncoder()
@@ -766,6 +770,13 @@ func (e *NodeEntry) getNode(name string) (*sdk.Node, error) {
 		node.Context.Mutex = *e.OneAtATime
 	}
 
+	if e.PathFilter != nil {
+		if err := e.PathFilter.IsValid(); err != nil {
+			return nil, err
+		}
+		node.Context.PathFilter = *e.PathFilter
+	}
+
 	if e.OutgoingHookModelName != "" {
 		node.Type = sdk.NodeTypeOutGoingHook
 		config := sdk.WorkflowNodeHookConfig{}
@@ -148,7 +148,7 @@ func Create(ctx context.Context, h Interface) error {
 					continue
 				}
 
-				currentCtx, currentCancel := context.WithTimeout(context.Background(), 10*time.Minute)
+				currentCtx, currentCancel := context.WithTimeout(telemetry.ContextWithTelemetry(ctx, context.Background()), 10*time.Minute)
 				fields := log.FieldValues(ctx)
 				for k, v := range fields {
 					currentCtx = context.WithValue(currentCtx, k, v)
@@ -233,6 +233,11 @@ func Create(ctx context.Context, h Interface) error {
 					hostname:          hostname,
 					timestamp:         time.Now().Unix(),
 					workflowNodeRunID: j.WorkflowNodeRunID,
+					header:            j.Header,
+				}
+				// Jobs with a region preference can move from a region to another, the API sets the current one on the job
+				if j.Region != nil {
+					workerRequest.region = *j.Region
 				}
 
 				// Check at least one worker model can match
@@ -243,7 +248,7 @@ func Create(ctx context.Context, h Interface) error {
 				var workerModelV2 string
 				for _, r := range workerRequest.requirements {
 					switch r.Type {
-					case sdk.RegionRequirement:
+					case sdk.RegionRequirement, sdk.RegionPreferenceRequirement:
 						containsRegionRequirement = true
 					case sdk.ModelV2Requirement:
 						workerModelV2 = r.Value
@@ -353,6 +358,11 @@ func canRunJob(ctx context.Context, h Interface, j workerStarterRequest) bool {
 			return false
 		}
 
+		if r.Type == sdk.RegionPreferenceRequirement && j.region != h.Configuration().Provision.Region {
+			log.Debug(ctx, "canRunJob> %d - job %d - job with region preference requirement: cannot spawn. hatchery-region:%s job-region:%s", j.timestamp, j.id, h.Configuration().Provision.Region, j.region)
+			return false
+		}
+
 		// Skip others requirement as we can't check it
 		if r.Type == sdk.PluginRequirement || r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement {
 			log.Debug(ctx, "canRunJob> %d - job %d - job with service, plugin or memory requirement. Skip these check as we can't check it on hatchery routine", j.timestamp, j.id)
@@ -538,6 +548,12 @@ func canRunJobWithModel(ctx context.Context, h InterfaceWithModels, j workerStar
 			return false
 		}
 
+		if r.Type == sdk.RegionPreferenceRequirement && j.region != h.Configuration().Provision.Region {
+			log.Debug(ctx, "canRunJobWithModel> %d - job %d - job with region preference requirement: cannot spawn. hatchery-region:%s job-region:%s", j.timestamp, j.id, h.Configuration().Provision.Region, j.region)
+			next()
+			return false
+		}
+
 		if !containsModelRequirement && !containsHostnameRequirement {
 			if r.Type == sdk.BinaryRequirement {
 				found := false
@@ -21,10 +21,12 @@ type workerStarterRequest struct {
 	model               *sdk.Model
 	execGroups          []sdk.Group
 	requirements        []sdk.Requirement
+	region              string
 	hostname            string
 	timestamp           int64
 	workflowNodeRunID   int64
 	registerWorkerModel *sdk.Model
+	header              sdk.WorkflowRunHeaders
 }
 
 // Start all goroutines which manage the hatchery worker spawning routine.
@@ -218,6 +220,18 @@ func spawnWorkerForJob(ctx context.Context, h Interface, j workerStarterRequest)
 		return false
 	}
 
+	if traceID, ok := telemetry.TraceIDFromHeaders(j.header); ok {
+		telemetry.ExportSpan(ctx, telemetry.WorkflowSpanData(traceID,
+			telemetry.WorkflowSpanID(traceID, telemetry.WorkflowSpanSpawn, j.id),
+			telemetry.WorkflowSpanID(traceID, telemetry.WorkflowSpanJobQueue, j.id),
+			telemetry.WorkflowSpanSpawn, start, time.Now(), false, map[string]interface{}{
+				telemetry.TagServiceName:        h.Service().Name,
+				telemetry.TagWorker:             workerName,
+				telemetry.TagWorkerModel:        modelName,
+				telemetry.TagWorkflowNodeJobRun: j.id,
+			}))
+	}
+
 	if j.model != nil && j.model.IsDeprecated {
 		ctxSendSpawnInfo, next = telemetry.Span(ctx, "hatchery.SendSpawnInfo", telemetry.Tag("msg", sdk.MsgSpawnInfoDeprecatedModel.ID))
 		SendSpawnInfo(ctxSendSpawnInfo, h, j.id, sdk.SpawnMsg{
@@ -25,6 +25,8 @@ const (
 	HookConfigVCSType             = "vcsType"
 	HookConfigVCSServer           = "vcsServer"
 	HookConfigEventFilter         = "eventFilter"
+	HookConfigPathFilter          = "pathFilter"
+	HookConfigPathExcludeFilter   = "pathExcludeFilter"
 	HookConfigRepoFullName        = "repoFullName"
 	HookConfigModelType           = "model_type"
 	HookConfigModelName           = "model_name"
@@ -156,6 +158,16 @@ var (
 				Configurable: true,
 				Type:         HookConfigTypeMultiChoice,
 			},
+			HookConfigPathFilter: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			HookConfigPathExcludeFilter: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
 		},
 	}
 
@@ -15,6 +15,7 @@ const (
 	AWSIntegrationModel             = "AWS"
 	DefaultStorageIntegrationName   = "shared.infra"
 	ArtifactoryIntegrationModelName = "Artifactory"
+	SecretProviderIntegrationModel  = "SecretProvider"
 
 	ArtifactoryConfigPlatform              = "platform"
 	ArtifactoryConfigURL                   = "url"
@@ -28,6 +29,15 @@ const (
 	ArtifactoryConfigPromotionHighMaturity = "promotion.maturity.high"
 	ArtifactoryConfigBuildInfoPrefix       = "build.info.prefix"
 	ArtifactoryConfigRepositoryPrefix      = "repo.prefix"
+
+	SecretProviderConfigType      = "type"
+	SecretProviderConfigURL       = "url"
+	SecretProviderConfigToken     = "token"
+	SecretProviderConfigNamespace = "namespace"
+	SecretProviderConfigMount     = "kv.mount"
+
+	SecretProviderTypeVault = "vault"
+	SecretProviderTypeHTTP  = "http"
 )
 
 // Here are the default plateform models
@@ -38,6 +48,7 @@ var (
 		&OpenstackIntegration,
 		&AWSIntegration,
 		&ArtifactoryIntegration,
+		&SecretProviderIntegration,
 	}
 	// KafkaIntegration represents a kafka integration
 	KafkaIntegration = IntegrationModel{
@@ -166,6 +177,36 @@ var (
 		},
 		ArtifactManager: true,
 	}
+	// SecretProviderIntegration represents an external secret store (HashiCorp Vault KV v2 or a generic HTTP API)
+	// used to resolve secret-ref variables when a job is taken
+	SecretProviderIntegration = IntegrationModel{
+		Name:       SecretProviderIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/secret-provider",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			SecretProviderConfigType: IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Value:       SecretProviderTypeVault,
+				Description: "vault or http",
+			},
+			SecretProviderConfigURL: IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			SecretProviderConfigToken: IntegrationConfigValue{
+				Type: IntegrationConfigTypePassword,
+			},
+			SecretProviderConfigNamespace: IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Vault namespace, optional",
+			},
+			SecretProviderConfigMount: IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Value:       "secret",
+				Description: "Vault KV v2 mount path",
+			},
+		},
+	}
 	// AWSIntegration represents an aws integration
 	AWSIntegration = IntegrationModel{
 		Name:       AWSIntegrationModel,
@@ -7,6 +7,7 @@ type Job struct {
 	Enabled          bool                   `json:"enabled"`
 	LastModified     int64                  `json:"last_modified"`
 	Action           Action                 `json:"action"`
+	PriorityClass    string                 `json:"priority_class,omitempty"`
 	Warnings         []PipelineBuildWarning `json:"warnings"`
 }
 
@@ -15,6 +16,9 @@ func (j Job) IsValid() error {
 	if j.PipelineStageID == 0 {
 		return NewErrorFrom(ErrWrongRequest, "invalid given stage id")
 	}
+	if !JobPriorityClassValidate(j.PriorityClass) {
+		return NewErrorFrom(ErrWrongRequest, "invalid given priority class %q", j.PriorityClass)
+	}
 
 	return j.Action.IsValid()
 }
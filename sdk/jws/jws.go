@@ -2,9 +2,11 @@ package jws
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
@@ -127,3 +129,51 @@ func UnsafeParse(s string, i interface{}) error {
 	output := object.UnsafePayloadWithoutVerification()
 	return sdk.WithStack(sdk.JSONUnmarshal(output, i))
 }
+
+// NewJWTSigner instantiates a signer using RSASSA-PKCS1-v1_5 (SHA256) with the given private key.
+// The signed objects are typed as JWT and carry the key id computed by NewPublicJSONWebKey,
+// which makes them verifiable by any relying party that fetches the issuer JWKS.
+func NewJWTSigner(privateKey *rsa.PrivateKey) (jose.Signer, error) {
+	jwk, err := NewPublicJSONWebKey(&privateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	opts := (&jose.SignerOptions{}).WithType("JWT")
+	sign, err := jose.NewSigner(jose.SigningKey{
+		Algorithm: jose.RS256,
+		Key:       jose.JSONWebKey{Key: privateKey, KeyID: jwk.KeyID, Algorithm: jwk.Algorithm},
+	}, opts)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return sign, nil
+}
+
+// NewPublicJSONWebKey returns the JSON Web Key for given public key. The key id is the
+// base64url encoded SHA256 thumbprint of the key (RFC 7638).
+func NewPublicJSONWebKey(publicKey *rsa.PublicKey) (jose.JSONWebKey, error) {
+	jwk := jose.JSONWebKey{
+		Key:       publicKey,
+		Algorithm: string(jose.RS256),
+		Use:       "sig",
+	}
+	thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return jwk, sdk.WithStack(err)
+	}
+	jwk.KeyID = base64.RawURLEncoding.EncodeToString(thumbprint)
+	return jwk, nil
+}
+
+// NewPublicJSONWebKeySet returns a JSON Web Key Set containing given public keys.
+func NewPublicJSONWebKeySet(publicKeys ...*rsa.PublicKey) (jose.JSONWebKeySet, error) {
+	var set jose.JSONWebKeySet
+	for _, k := range publicKeys {
+		jwk, err := NewPublicJSONWebKey(k)
+		if err != nil {
+			return set, err
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set, nil
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
 )
 
 func TestNewRandomRSAKey(t *testing.T) {
@@ -29,3 +30,30 @@ func TestHMacSignAndVerify(t *testing.T) {
 	require.NoError(t, Verify(secret, messageSigned, &unsigned))
 	require.Equal(t, message, unsigned)
 }
+
+func TestJWTSignAndVerifyWithJSONWebKeySet(t *testing.T) {
+	k, err := NewRandomRSAKey()
+	require.NoError(t, err)
+	signer, err := NewJWTSigner(k)
+	require.NoError(t, err)
+
+	claims := map[string]interface{}{"sub": "project:MYPROJ", "aud": "sts.amazonaws.com"}
+	token, err := Sign(signer, claims)
+	require.NoError(t, err)
+
+	object, err := jose.ParseSigned(token)
+	require.NoError(t, err)
+	require.Len(t, object.Signatures, 1)
+	header := object.Signatures[0].Header
+	require.Equal(t, "RS256", header.Algorithm)
+	require.Equal(t, "JWT", header.ExtraHeaders[jose.HeaderType])
+
+	set, err := NewPublicJSONWebKeySet(&k.PublicKey)
+	require.NoError(t, err)
+	keys := set.Key(header.KeyID)
+	require.Len(t, keys, 1)
+
+	var res map[string]interface{}
+	require.NoError(t, Verify(keys[0].Key, token, &res))
+	require.Equal(t, claims, res)
+}
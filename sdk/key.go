@@ -2,10 +2,13 @@ package sdk
 
 import (
 	"bytes"
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 )
 
 type KeyType string
@@ -20,6 +23,19 @@ const (
 	KeyTypePGP KeyType = "pgp"
 )
 
+// KeyRotationPreviousSuffix is appended to the name of a rotated key during its grace period.
+// Jobs receive both keys, ex: cds.key.proj-ssh-foo.priv and cds.key.proj-ssh-foo.previous.priv.
+const KeyRotationPreviousSuffix = ".previous"
+
+// Key rotation status.
+const (
+	KeyRotationStatusGrace   = "grace"
+	KeyRotationStatusRetired = "retired"
+)
+
+// DefaultKeyRotationGracePeriod is used when no grace period is given for a rotation.
+const DefaultKeyRotationGracePeriod = 24
+
 func GenerateProjectDefaultKeyName(projectKey string, t KeyType) string {
 	return fmt.Sprintf("proj-%s-%s", t, strings.ToLower(projectKey))
 }
@@ -68,6 +84,93 @@ type EnvironmentKey struct {
 	EnvironmentID int64   `json:"environment_id" db:"environment_id"`
 }
 
+// KeyRotationRequest asks for the rotation of a key. The previous key is kept during the grace period (in hours).
+// If PushToVCS is set the new public key is added as deploy key on the repositories of the applications that use the key,
+// and the previous one is removed from them at the end of the grace period.
+type KeyRotationRequest struct {
+	GracePeriod int64 `json:"grace_period_hours"`
+	PushToVCS   bool  `json:"push_to_vcs"`
+}
+
+// IsValid returns an error if the request is invalid.
+func (r KeyRotationRequest) IsValid() error {
+	if r.GracePeriod < 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid grace period")
+	}
+	return nil
+}
+
+// KeyRotation is the history of a key rotation. ApplicationID is 0 for a project key.
+type KeyRotation struct {
+	ID             int64                   `json:"id" db:"id" cli:"id,key"`
+	ProjectID      int64                   `json:"project_id" db:"project_id" cli:"-"`
+	ApplicationID  int64                   `json:"application_id,omitempty" db:"application_id" cli:"-"`
+	KeyName        string                  `json:"key_name" db:"key_name" cli:"key_name"`
+	KeyType        KeyType                 `json:"key_type" db:"key_type" cli:"key_type"`
+	PreviousPublic string                  `json:"previous_public" db:"previous_public" cli:"-"`
+	Repositories   KeyRotationRepositories `json:"repositories" db:"repositories" cli:"-"`
+	Status         string                  `json:"status" db:"status" cli:"status"`
+	TriggeredBy    string                  `json:"triggered_by" db:"triggered_by" cli:"triggered_by"`
+	Created        time.Time               `json:"created" db:"created" cli:"created"`
+	ExpireAt       time.Time               `json:"expire_at" db:"expire_at" cli:"expire_at"`
+	Retired        *time.Time              `json:"retired,omitempty" db:"retired" cli:"retired"`
+	Error          string                  `json:"error,omitempty" db:"error" cli:"error"`
+}
+
+// KeyRotationRepository is a repository on which a rotated key was pushed as deploy key.
+type KeyRotationRepository struct {
+	VCSServer  string `json:"vcs_server"`
+	Repository string `json:"repository"`
+}
+
+// KeyRotationRepositories is a list of repositories stored as JSON.
+type KeyRotationRepositories []KeyRotationRepository
+
+// Value returns driver.Value from KeyRotationRepositories.
+func (r KeyRotationRepositories) Value() (driver.Value, error) {
+	if r == nil {
+		r = KeyRotationRepositories{}
+	}
+	j, err := json.Marshal(r)
+	return j, WrapError(err, "cannot marshal KeyRotationRepositories")
+}
+
+// Scan KeyRotationRepositories.
+func (r *KeyRotationRepositories) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(fmt.Errorf("type assertion .([]byte) failed (%T)", src))
+	}
+	return WrapError(JSONUnmarshal(source, r), "cannot unmarshal KeyRotationRepositories")
+}
+
+// KeyRotationPolicy schedules the rotation of all the keys of a project and its applications.
+type KeyRotationPolicy struct {
+	ProjectID   int64     `json:"project_id" db:"project_id"`
+	Enabled     bool      `json:"enabled" db:"enabled"`
+	Interval    int64     `json:"interval_days" db:"interval_days"`
+	GracePeriod int64     `json:"grace_period_hours" db:"grace_period_hours"`
+	PushToVCS   bool      `json:"push_to_vcs" db:"push_to_vcs"`
+	Created     time.Time `json:"created" db:"created"`
+}
+
+// IsValid returns an error if the policy is invalid.
+func (p KeyRotationPolicy) IsValid() error {
+	if p.Interval <= 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid rotation interval")
+	}
+	if p.GracePeriod <= 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid grace period")
+	}
+	if p.GracePeriod >= p.Interval*24 {
+		return NewErrorFrom(ErrWrongRequest, "grace period should be shorter than rotation interval")
+	}
+	return nil
+}
+
 func ImportGPGKey(dir string, keyName string, publicKey string) (string, []byte, error) {
 	gpg2Found := false
 
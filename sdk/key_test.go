@@ -0,0 +1,23 @@
+package sdk_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestKeyRotationPolicyIsValid(t *testing.T) {
+	require.NoError(t, sdk.KeyRotationPolicy{Interval: 30, GracePeriod: 24}.IsValid())
+	require.Error(t, sdk.KeyRotationPolicy{Interval: 0, GracePeriod: 24}.IsValid())
+	require.Error(t, sdk.KeyRotationPolicy{Interval: 30, GracePeriod: 0}.IsValid())
+	require.Error(t, sdk.KeyRotationPolicy{Interval: 1, GracePeriod: 24}.IsValid())
+}
+
+func TestVCSDeployKeyMatch(t *testing.T) {
+	k := sdk.VCSDeployKey{Key: "ssh-rsa AAAAB3NzaC1yc2E"}
+	require.True(t, k.Match("ssh-rsa AAAAB3NzaC1yc2E cds@mykey\n"))
+	require.False(t, k.Match("ssh-rsa AAAAB3NzaC1yc2F"))
+	require.False(t, sdk.VCSDeployKey{}.Match(""))
+}
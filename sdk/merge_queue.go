@@ -0,0 +1,146 @@
+package sdk
+
+import (
+	"fmt"
+	"time"
+)
+
+// Merge methods of the pull requests validated by a merge queue.
+const (
+	MergeQueueMethodMerge  = "merge"
+	MergeQueueMethodSquash = "squash"
+	MergeQueueMethodRebase = "rebase"
+)
+
+// Status of a pull request in a merge queue.
+const (
+	MergeQueueEntryStatusQueued  = "Queued"
+	MergeQueueEntryStatusBatched = "Batched"
+	MergeQueueEntryStatusMerged  = "Merged"
+	MergeQueueEntryStatusFailed  = "Failed"
+	MergeQueueEntryStatusRemoved = "Removed"
+)
+
+// Status of a batch of pull requests in a merge queue.
+const (
+	// MergeQueueBatchStatusPending is a batch waiting for its speculative merge.
+	MergeQueueBatchStatusPending = "Pending"
+	// MergeQueueBatchStatusMerging is a batch whose speculative merge is done by the repositories service.
+	MergeQueueBatchStatusMerging = "Merging"
+	// MergeQueueBatchStatusValidating is a batch whose speculative merge is validated by a workflow run.
+	MergeQueueBatchStatusValidating = "Validating"
+	MergeQueueBatchStatusSuccess    = "Success"
+	MergeQueueBatchStatusFail       = "Fail"
+	// MergeQueueBatchStatusCancelled is a batch whose pull requests are validated again in a new batch.
+	MergeQueueBatchStatusCancelled = "Cancelled"
+)
+
+// MergeQueue validates the pull requests of a repository against the latest state of their target branch before
+// merging them. Queued pull requests are merged by batches on a speculative branch validated by a workflow, a failing
+// batch is bisected to find the pull requests that break the target branch.
+type MergeQueue struct {
+	ID            int64  `json:"id" db:"id" cli:"id,key"`
+	ProjectID     int64  `json:"project_id" db:"project_id" cli:"-"`
+	ApplicationID int64  `json:"application_id" db:"application_id" cli:"-"`
+	WorkflowID    int64  `json:"workflow_id" db:"workflow_id" cli:"-"`
+	Branch        string `json:"branch" db:"branch" cli:"branch"`
+	// Label enqueues the open pull requests of the target branch that have it, no pull request is enqueued
+	// automatically if empty.
+	Label          string    `json:"label,omitempty" db:"label" cli:"label"`
+	MaxBatchSize   int64     `json:"max_batch_size" db:"max_batch_size" cli:"max_batch_size"`
+	MergeMethod    string    `json:"merge_method" db:"merge_method" cli:"merge_method"`
+	Enabled        bool      `json:"enabled" db:"enabled" cli:"enabled"`
+	AuthConsumerID string    `json:"-" db:"auth_consumer_id" cli:"-"`
+	Created        time.Time `json:"created" db:"created" cli:"-"`
+	LastModified   time.Time `json:"last_modified" db:"last_modified" cli:"-"`
+
+	ApplicationName string            `json:"application_name" db:"-" cli:"application"`
+	WorkflowName    string            `json:"workflow_name" db:"-" cli:"workflow"`
+	Entries         []MergeQueueEntry `json:"entries,omitempty" db:"-" cli:"-"`
+	Batches         []MergeQueueBatch `json:"batches,omitempty" db:"-" cli:"-"`
+}
+
+// IsValid returns an error if the merge queue is not valid.
+func (q MergeQueue) IsValid() error {
+	if q.ApplicationID == 0 || q.WorkflowID == 0 {
+		return NewErrorFrom(ErrWrongRequest, "merge queue application and workflow are required")
+	}
+	if q.Branch == "" {
+		return NewErrorFrom(ErrWrongRequest, "merge queue target branch is required")
+	}
+	if q.MaxBatchSize < 1 {
+		return NewErrorFrom(ErrWrongRequest, "merge queue max batch size should be greater than 0")
+	}
+	switch q.MergeMethod {
+	case MergeQueueMethodMerge, MergeQueueMethodSquash, MergeQueueMethodRebase:
+	default:
+		return NewErrorFrom(ErrWrongRequest, "invalid merge method %q", q.MergeMethod)
+	}
+	return nil
+}
+
+// SpeculativeBranch returns the branch where the batches of the queue are merged before their validation.
+func (q MergeQueue) SpeculativeBranch() string {
+	return fmt.Sprintf("cds-merge-queue/%d/%s", q.ID, q.Branch)
+}
+
+// MergeQueueEntry is a pull request in a merge queue.
+type MergeQueueEntry struct {
+	ID             int64     `json:"id" db:"id" cli:"-"`
+	MergeQueueID   int64     `json:"merge_queue_id" db:"merge_queue_id" cli:"-"`
+	PullRequestID  int64     `json:"pull_request_id" db:"pull_request_id" cli:"pull_request,key"`
+	PullRequestURL string    `json:"pull_request_url" db:"pull_request_url" cli:"url"`
+	Title          string    `json:"title" db:"title" cli:"title"`
+	HeadBranch     string    `json:"head_branch" db:"head_branch" cli:"branch"`
+	HeadCommit     string    `json:"head_commit" db:"head_commit" cli:"commit"`
+	Status         string    `json:"status" db:"status" cli:"status"`
+	BatchID        int64     `json:"batch_id,omitempty" db:"batch_id" cli:"batch"`
+	EnqueuedBy     string    `json:"enqueued_by" db:"enqueued_by" cli:"enqueued_by"`
+	Message        string    `json:"message,omitempty" db:"message" cli:"message"`
+	Created        time.Time `json:"created" db:"created" cli:"created"`
+	LastModified   time.Time `json:"last_modified" db:"last_modified" cli:"-"`
+}
+
+// IsActive returns true if the pull request is still waiting to be merged.
+func (e MergeQueueEntry) IsActive() bool {
+	return e.Status == MergeQueueEntryStatusQueued || e.Status == MergeQueueEntryStatusBatched
+}
+
+// MergeQueueBatch is a set of pull requests of a merge queue validated together.
+type MergeQueueBatch struct {
+	ID                int64     `json:"id" db:"id"`
+	MergeQueueID      int64     `json:"merge_queue_id" db:"merge_queue_id"`
+	Status            string    `json:"status" db:"status"`
+	BaseCommit        string    `json:"base_commit,omitempty" db:"base_commit"`
+	Commit            string    `json:"commit,omitempty" db:"merge_commit"`
+	OperationUUID     string    `json:"operation_uuid,omitempty" db:"operation_uuid"`
+	WorkflowRunID     int64     `json:"workflow_run_id,omitempty" db:"workflow_run_id"`
+	WorkflowRunNumber int64     `json:"workflow_run_number,omitempty" db:"workflow_run_number"`
+	Message           string    `json:"message,omitempty" db:"message"`
+	Created           time.Time `json:"created" db:"created"`
+	LastModified      time.Time `json:"last_modified" db:"last_modified"`
+}
+
+// IsActive returns true if the batch is not terminated.
+func (b MergeQueueBatch) IsActive() bool {
+	switch b.Status {
+	case MergeQueueBatchStatusPending, MergeQueueBatchStatusMerging, MergeQueueBatchStatusValidating:
+		return true
+	}
+	return false
+}
+
+// MergeQueueEnqueueRequest enqueues a pull request in a merge queue.
+type MergeQueueEnqueueRequest struct {
+	PullRequestID int64 `json:"pull_request_id"`
+}
+
+// BisectMergeQueueEntries splits the pull requests of a failing batch in two halves validated one after the other,
+// the first half keeps the oldest pull requests.
+func BisectMergeQueueEntries(entries []MergeQueueEntry) ([]MergeQueueEntry, []MergeQueueEntry) {
+	if len(entries) < 2 {
+		return entries, nil
+	}
+	middle := (len(entries) + 1) / 2
+	return entries[:middle], entries[middle:]
+}
@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeQueueIsValid(t *testing.T) {
+	q := MergeQueue{
+		ID:            12,
+		ApplicationID: 1,
+		WorkflowID:    2,
+		Branch:        "main",
+		MaxBatchSize:  4,
+		MergeMethod:   MergeQueueMethodSquash,
+	}
+	require.NoError(t, q.IsValid())
+	require.Equal(t, "cds-merge-queue/12/main", q.SpeculativeBranch())
+
+	q.MaxBatchSize = 0
+	require.Error(t, q.IsValid())
+	q.MaxBatchSize = 1
+	q.MergeMethod = "octopus"
+	require.Error(t, q.IsValid())
+	q.MergeMethod = MergeQueueMethodMerge
+	q.Branch = ""
+	require.Error(t, q.IsValid())
+}
+
+func TestBisectMergeQueueEntries(t *testing.T) {
+	entries := []MergeQueueEntry{{PullRequestID: 1}, {PullRequestID: 2}, {PullRequestID: 3}}
+
+	first, second := BisectMergeQueueEntries(entries)
+	require.Equal(t, []MergeQueueEntry{{PullRequestID: 1}, {PullRequestID: 2}}, first)
+	require.Equal(t, []MergeQueueEntry{{PullRequestID: 3}}, second)
+
+	first, second = BisectMergeQueueEntries(entries[:1])
+	require.Len(t, first, 1)
+	require.Empty(t, second)
+}
@@ -101,6 +101,7 @@ var (
 	MsgSpawnInfoWorkerHookSetup             = &Message{"MsgSpawnInfoWorkerHookSetup", trad{EN: "Setting up worker hook %q"}, nil, RunInfoTypInfo}
 	MsgSpawnInfoWorkerHookRun               = &Message{"MsgSpawnInfoWorkerHookRun", trad{EN: "Running worker hook %q"}, nil, RunInfoTypInfo}
 	MsgSpawnInfoWorkerHookRunTeardown       = &Message{"MsgSpawnInfoWorkerHookRunTeardown", trad{EN: "Running worker hook %q teardown"}, nil, RunInfoTypInfo}
+	MsgSpawnInfoRegionFailover              = &Message{"MsgSpawnInfoRegionFailover", trad{FR: "Le job n'a pas été pris en charge dans la région %s, il est maintenant visible dans la région %s", EN: "Job was not booked in region %s, it is now visible in region %s"}, nil, RunInfoTypeWarning}
 )
 
 // Messages contains all sdk Messages
@@ -186,6 +187,7 @@ var Messages = map[string]*Message{
 	MsgSpawnInfoWorkerHookSetup.ID:             MsgSpawnInfoWorkerHookSetup,
 	MsgSpawnInfoWorkerHookRun.ID:               MsgSpawnInfoWorkerHookRun,
 	MsgSpawnInfoWorkerHookRunTeardown.ID:       MsgSpawnInfoWorkerHookRunTeardown,
+	MsgSpawnInfoRegionFailover.ID:              MsgSpawnInfoRegionFailover,
 }
 
 // Message represent a struc format translated messages
@@ -70,8 +70,54 @@ type UserNotificationTemplate struct {
 	Body    string `json:"body,omitempty" yaml:"body,omitempty"`
 
 	// For VCS
-	DisableComment *bool `json:"disable_comment,omitempty" yaml:"disable_comment,omitempty"`
-	DisableStatus  *bool `json:"disable_status,omitempty" yaml:"disable_status,omitempty"`
+	DisableComment *bool         `json:"disable_comment,omitempty" yaml:"disable_comment,omitempty"`
+	DisableStatus  *bool         `json:"disable_status,omitempty" yaml:"disable_status,omitempty"`
+	GerritLabels   []GerritLabel `json:"gerrit_labels,omitempty" yaml:"gerrit_labels,omitempty"`
+}
+
+// IsValid returns an error if the template is invalid.
+func (t UserNotificationTemplate) IsValid() error {
+	names := make(map[string]struct{}, len(t.GerritLabels))
+	for _, l := range t.GerritLabels {
+		if err := l.IsValid(); err != nil {
+			return err
+		}
+		if _, has := names[l.Name]; has {
+			return NewErrorFrom(ErrWrongRequest, "gerrit label %s is defined twice", l.Name)
+		}
+		names[l.Name] = struct{}{}
+	}
+	return nil
+}
+
+// GerritLabel is a label voted on a Gerrit change with the result of a workflow node, labels replace the default
+// Verified vote. A score of 0 means no vote.
+type GerritLabel struct {
+	Name      string `json:"name" yaml:"name"`
+	OnSuccess int    `json:"on_success,omitempty" yaml:"on_success,omitempty"`
+	OnFailure int    `json:"on_failure,omitempty" yaml:"on_failure,omitempty"`
+}
+
+// IsValid returns an error if the label has no name or a score out of the Gerrit range.
+func (l GerritLabel) IsValid() error {
+	if l.Name == "" {
+		return NewErrorFrom(ErrWrongRequest, "gerrit label name is required")
+	}
+	if l.OnSuccess < -2 || l.OnSuccess > 2 || l.OnFailure < -2 || l.OnFailure > 2 {
+		return NewErrorFrom(ErrWrongRequest, "gerrit label %s scores must be between -2 and +2", l.Name)
+	}
+	return nil
+}
+
+// Score returns the score of the label for the status of a node run, and false if there is no vote.
+func (l GerritLabel) Score(status string) (int, bool) {
+	switch status {
+	case StatusSuccess:
+		return l.OnSuccess, l.OnSuccess != 0
+	case StatusFail, StatusStopped:
+		return l.OnFailure, l.OnFailure != 0
+	}
+	return 0, false
 }
 
 //Default template values
@@ -0,0 +1,35 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserNotificationTemplateGerritLabels(t *testing.T) {
+	tmpl := UserNotificationTemplate{
+		GerritLabels: []GerritLabel{
+			{Name: "Code-Style", OnSuccess: 1, OnFailure: -1},
+			{Name: "Verified", OnFailure: -1},
+		},
+	}
+	require.NoError(t, tmpl.IsValid())
+
+	score, ok := tmpl.GerritLabels[0].Score(StatusSuccess)
+	require.True(t, ok)
+	require.Equal(t, 1, score)
+	score, ok = tmpl.GerritLabels[0].Score(StatusStopped)
+	require.True(t, ok)
+	require.Equal(t, -1, score)
+	_, ok = tmpl.GerritLabels[0].Score(StatusBuilding)
+	require.False(t, ok)
+	_, ok = tmpl.GerritLabels[1].Score(StatusSuccess)
+	require.False(t, ok)
+
+	tmpl.GerritLabels = append(tmpl.GerritLabels, GerritLabel{Name: "Verified", OnSuccess: 1})
+	require.Error(t, tmpl.IsValid())
+	tmpl.GerritLabels = []GerritLabel{{Name: "Verified", OnSuccess: 3}}
+	require.Error(t, tmpl.IsValid())
+	tmpl.GerritLabels = []GerritLabel{{OnSuccess: 1}}
+	require.Error(t, tmpl.IsValid())
+}
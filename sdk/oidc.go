@@ -0,0 +1,94 @@
+package sdk
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// OIDCDiscoveryPath is the path of the OpenID Connect discovery document, relative to the issuer URL.
+	OIDCDiscoveryPath = "/.well-known/openid-configuration"
+	// OIDCJWKSPath is the path of the JSON Web Key Set used to verify job ID tokens, relative to the issuer URL.
+	OIDCJWKSPath = "/.well-known/jwks.json"
+)
+
+// OIDCDiscoveryDocument is the OpenID Connect provider metadata exposed by the API
+// so that cloud providers can verify job ID tokens.
+type OIDCDiscoveryDocument struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	SubjectTypesSupported            []string `json:"subject_types_supported"`
+	ResponseTypesSupported           []string `json:"response_types_supported"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                  []string `json:"scopes_supported"`
+	ClaimsSupported                  []string `json:"claims_supported"`
+}
+
+// JobIDTokenRequest is sent by a worker to get an ID token for its current job.
+type JobIDTokenRequest struct {
+	Audience string `json:"audience,omitempty"`
+}
+
+// JobIDToken contains a signed ID token for a job.
+type JobIDToken struct {
+	Token     string `json:"token"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// JobIDTokenClaims are the claims of an ID token issued for a job.
+type JobIDTokenClaims struct {
+	Issuer      string `json:"iss"`
+	Subject     string `json:"sub"`
+	Audience    string `json:"aud"`
+	ExpiresAt   int64  `json:"exp"`
+	IssuedAt    int64  `json:"iat"`
+	NotBefore   int64  `json:"nbf"`
+	ID          string `json:"jti"`
+	ProjectKey  string `json:"project_key"`
+	Workflow    string `json:"workflow"`
+	RunNumber   int64  `json:"run_number"`
+	NodeRunID   int64  `json:"node_run_id"`
+	JobRunID    int64  `json:"job_run_id"`
+	JobName     string `json:"job_name"`
+	GitBranch   string `json:"git_branch,omitempty"`
+	Environment string `json:"environment,omitempty"`
+	Region      string `json:"region,omitempty"`
+}
+
+// JobIDTokenClaimsSupported lists the claims that can be found in a job ID token.
+var JobIDTokenClaimsSupported = []string{
+	"iss", "sub", "aud", "exp", "iat", "nbf", "jti",
+	"project_key", "workflow", "run_number", "node_run_id", "job_run_id", "job_name",
+	"git_branch", "environment", "region",
+}
+
+// NewJobIDTokenClaims returns the claims that identify given job run. Time based claims,
+// issuer and audience are not set.
+func NewJobIDTokenClaims(jobRun WorkflowNodeJobRun) JobIDTokenClaims {
+	c := JobIDTokenClaims{
+		ProjectKey:  ParameterValue(jobRun.Parameters, "cds.project"),
+		Workflow:    ParameterValue(jobRun.Parameters, "cds.workflow"),
+		NodeRunID:   jobRun.WorkflowNodeRunID,
+		JobRunID:    jobRun.ID,
+		JobName:     jobRun.Job.Action.Name,
+		GitBranch:   ParameterValue(jobRun.Parameters, "git.branch"),
+		Environment: ParameterValue(jobRun.Parameters, "cds.environment"),
+	}
+	c.RunNumber, _ = strconv.ParseInt(ParameterValue(jobRun.Parameters, "cds.run.number"), 10, 64)
+	if jobRun.Region != nil {
+		c.Region = *jobRun.Region
+	}
+	c.Subject = c.ComputeSubject()
+	return c
+}
+
+// ComputeSubject returns the subject of the token. Its format is stable so that it can be used
+// in cloud providers trust policies, ex: project:MYPROJ:workflow:build:branch:master:environment:prod.
+func (c JobIDTokenClaims) ComputeSubject() string {
+	return strings.Join([]string{
+		"project", c.ProjectKey,
+		"workflow", c.Workflow,
+		"branch", c.GitBranch,
+		"environment", c.Environment,
+	}, ":")
+}
@@ -258,7 +258,8 @@ func ApplicationVariablesToParameters(prefix string, variables []ApplicationVari
 func ProjectVariablesToParameters(prefix string, variables []ProjectVariable) []Parameter {
 	res := make([]Parameter, 0, len(variables))
 	for _, t := range variables {
-		if NeedPlaceholder(t.Type) {
+		// Secret references are resolved as secrets when the job is taken
+		if NeedPlaceholder(t.Type) || t.Type == SecretRefVariable {
 			continue
 		}
 		if prefix != "" {
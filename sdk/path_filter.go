@@ -0,0 +1,108 @@
+package sdk
+
+import (
+	"path"
+	"strings"
+)
+
+// PathFilter triggers a workflow node only when the files changed by a push match its globs. A glob is a slash
+// separated path where ** matches any number of directories, other elements follow path.Match syntax.
+type PathFilter struct {
+	Include []string `json:"include,omitempty" yaml:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty" yaml:"exclude,omitempty"`
+}
+
+// NewPathFilter returns a path filter from include and exclude globs separated by semicolons, as stored in hooks
+// configuration.
+func NewPathFilter(include, exclude string) PathFilter {
+	var f PathFilter
+	for _, s := range strings.Split(include, ";") {
+		if s = strings.TrimSpace(s); s != "" {
+			f.Include = append(f.Include, s)
+		}
+	}
+	for _, s := range strings.Split(exclude, ";") {
+		if s = strings.TrimSpace(s); s != "" {
+			f.Exclude = append(f.Exclude, s)
+		}
+	}
+	return f
+}
+
+// IsEmpty returns true if the filter has no glob.
+func (f PathFilter) IsEmpty() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// IsValid returns an error if a glob is malformed.
+func (f PathFilter) IsValid() error {
+	for _, globs := range [][]string{f.Include, f.Exclude} {
+		for _, g := range globs {
+			if g == "" {
+				return NewErrorFrom(ErrWrongRequest, "empty path filter")
+			}
+			for _, elem := range strings.Split(g, "/") {
+				if _, err := path.Match(elem, ""); err != nil {
+					return NewErrorFrom(ErrWrongRequest, "invalid path filter %q", g)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Match returns true if one of the files is included and not excluded. Without include globs, all the files are
+// included.
+func (f PathFilter) Match(files []string) bool {
+	for _, file := range files {
+		if len(f.Include) > 0 && !matchOneGlob(f.Include, file) {
+			continue
+		}
+		if matchOneGlob(f.Exclude, file) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func matchOneGlob(globs []string, file string) bool {
+	for _, g := range globs {
+		if MatchPathGlob(g, file) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchPathGlob returns true if the file path matches the glob. A glob ending with a slash matches all the files of
+// the directory.
+func MatchPathGlob(glob, file string) bool {
+	glob = strings.TrimPrefix(glob, "/")
+	if strings.HasSuffix(glob, "/") {
+		glob += "**"
+	}
+	return matchPathElems(strings.Split(glob, "/"), strings.Split(strings.TrimPrefix(file, "/"), "/"))
+}
+
+func matchPathElems(globs, elems []string) bool {
+	for len(globs) > 0 {
+		if globs[0] == "**" {
+			// Try to match the rest of the glob from each remaining element
+			for i := 0; i <= len(elems); i++ {
+				if matchPathElems(globs[1:], elems[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(elems) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(globs[0], elems[0]); !ok {
+			return false
+		}
+		globs, elems = globs[1:], elems[1:]
+	}
+	return len(elems) == 0
+}
@@ -0,0 +1,49 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchPathGlob(t *testing.T) {
+	tests := []struct {
+		glob  string
+		file  string
+		match bool
+	}{
+		{"services/api/**", "services/api/main.go", true},
+		{"services/api/**", "services/api/internal/db/db.go", true},
+		{"services/api/**", "services/apigw/main.go", false},
+		{"services/api/", "services/api/internal/db/db.go", true},
+		{"**/*.md", "README.md", true},
+		{"**/*.md", "docs/guide/install.md", true},
+		{"**/*.md", "docs/guide/install.go", false},
+		{"services/*/go.mod", "services/api/go.mod", true},
+		{"services/*/go.mod", "services/api/v2/go.mod", false},
+		{"/Makefile", "Makefile", true},
+		{"libs/**/testdata/**", "libs/a/b/testdata/x.json", true},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.match, MatchPathGlob(tt.glob, tt.file), "%s on %s", tt.glob, tt.file)
+	}
+}
+
+func TestPathFilterMatch(t *testing.T) {
+	f := NewPathFilter("services/api/**; libs/**", "**/*.md")
+	require.NoError(t, f.IsValid())
+	require.Equal(t, []string{"services/api/**", "libs/**"}, f.Include)
+
+	require.True(t, f.Match([]string{"services/web/index.ts", "libs/log/log.go"}))
+	require.False(t, f.Match([]string{"services/web/index.ts"}))
+	require.False(t, f.Match([]string{"services/api/README.md"}))
+	require.False(t, f.Match(nil))
+
+	// Without include globs, any file that is not excluded matches
+	f = NewPathFilter("", "docs/**")
+	require.True(t, f.Match([]string{"docs/index.md", "main.go"}))
+	require.False(t, f.Match([]string{"docs/index.md"}))
+
+	require.True(t, NewPathFilter("", "").IsEmpty())
+	require.Error(t, PathFilter{Include: []string{"services/[a"}}.IsValid())
+}
@@ -0,0 +1,242 @@
+package sdk
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Priority classes of a job, jobs of a higher class are given first to the hatcheries.
+const (
+	JobPriorityClassHigh   = "high"
+	JobPriorityClassNormal = "normal"
+	JobPriorityClassLow    = "low"
+)
+
+// JobPriorityClasses lists the available priority classes, from the highest to the lowest.
+var JobPriorityClasses = []string{JobPriorityClassHigh, JobPriorityClassNormal, JobPriorityClassLow}
+
+// JobPriorityClassValidate returns true if given priority class is known, an empty class is the normal one.
+func JobPriorityClassValidate(class string) bool {
+	return class == "" || IsInArray(class, JobPriorityClasses)
+}
+
+func jobPriorityRank(class string) int {
+	for i := range JobPriorityClasses {
+		if JobPriorityClasses[i] == class {
+			return i
+		}
+	}
+	return 1
+}
+
+// QueueQuota sets the share of the queue given to a project or to all the projects of an organization.
+type QueueQuota struct {
+	ID           int64  `json:"id" db:"id" cli:"id,key"`
+	ProjectKey   string `json:"project_key,omitempty" db:"project_key" cli:"project_key"`
+	Organization string `json:"organization,omitempty" db:"organization" cli:"organization"`
+	Weight       int64  `json:"weight" db:"weight" cli:"weight"`
+	// MaxConcurrentJobsPerModel limits the building jobs on a same worker model, 0 means no limit.
+	MaxConcurrentJobsPerModel int64     `json:"max_concurrent_jobs_per_model" db:"max_concurrent_jobs_per_model" cli:"max_concurrent_jobs_per_model"`
+	Created                   time.Time `json:"created" db:"created" cli:"-"`
+	LastModified              time.Time `json:"last_modified" db:"last_modified" cli:"-"`
+}
+
+// IsValid returns an error if the quota is not valid.
+func (q QueueQuota) IsValid() error {
+	if (q.ProjectKey == "") == (q.Organization == "") {
+		return NewErrorFrom(ErrWrongRequest, "queue quota should be set on a project or on an organization")
+	}
+	if q.Weight < 1 {
+		return NewErrorFrom(ErrWrongRequest, "queue quota weight should be greater than 0")
+	}
+	if q.MaxConcurrentJobsPerModel < 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid max concurrent jobs per model")
+	}
+	return nil
+}
+
+// QueueQuotaUsage contains the current usage of the queue for a quota.
+type QueueQuotaUsage struct {
+	ProjectKey                string           `json:"project_key,omitempty" cli:"project_key,key"`
+	Organization              string           `json:"organization,omitempty" cli:"organization,key"`
+	Weight                    int64            `json:"weight" cli:"weight"`
+	MaxConcurrentJobsPerModel int64            `json:"max_concurrent_jobs_per_model" cli:"max_concurrent_jobs_per_model"`
+	Waiting                   int64            `json:"waiting" cli:"waiting"`
+	Building                  int64            `json:"building" cli:"building"`
+	BuildingByModel           map[string]int64 `json:"building_by_model,omitempty" cli:"-"`
+}
+
+// QueueJobModel returns the name of the worker model used by a job. The model is set when the job is taken, for a
+// waiting job the model requirement is used.
+func QueueJobModel(j WorkflowNodeJobRun) string {
+	if j.Model != "" {
+		return j.Model
+	}
+	for _, r := range j.Job.Action.Requirements {
+		if r.Type == ModelRequirement {
+			name := strings.Split(r.Value, " ")[0]
+			return name[strings.LastIndex(name, "/")+1:]
+		}
+	}
+	return ""
+}
+
+// QueueJobRegion returns the region where a job is visible, empty if the job has no region.
+func QueueJobRegion(j WorkflowNodeJobRun) string {
+	if j.Region == nil {
+		return ""
+	}
+	return *j.Region
+}
+
+// QueueShare contains what is needed to compute the fair-share order of the queue for a project or an organization.
+type QueueShare struct {
+	Weight                    int64
+	MaxConcurrentJobsPerModel int64
+	BuildingByModel           map[string]int64
+}
+
+func (s QueueShare) building() int64 {
+	var n int64
+	for _, c := range s.BuildingByModel {
+		n += c
+	}
+	return n
+}
+
+// FairShareQueue returns the waiting jobs in the order they should be given to the hatcheries. Jobs are sorted by
+// priority class, then the shares are served one job at a time, the share with the lowest number of building jobs
+// relative to its weight first. Jobs that would exceed the max concurrent jobs per model of their share are held.
+// Jobs without share, or with a share without weight, are given a weight of 1.
+func FairShareQueue(jobs []WorkflowNodeJobRun, shareKey func(WorkflowNodeJobRun) string, shares map[string]QueueShare) []WorkflowNodeJobRun {
+	sorted := make([]WorkflowNodeJobRun, len(jobs))
+	copy(sorted, jobs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Queued.Before(sorted[j].Queued)
+	})
+
+	type state struct {
+		weight          int64
+		max             int64
+		scheduled       int64
+		scheduledModels map[string]int64
+	}
+	states := make(map[string]*state)
+	getState := func(key string) *state {
+		if s, ok := states[key]; ok {
+			return s
+		}
+		s := &state{weight: 1, scheduledModels: make(map[string]int64)}
+		if share, ok := shares[key]; ok {
+			if share.Weight > 0 {
+				s.weight = share.Weight
+			}
+			s.max = share.MaxConcurrentJobsPerModel
+			s.scheduled = share.building()
+			for m, c := range share.BuildingByModel {
+				s.scheduledModels[m] = c
+			}
+		}
+		states[key] = s
+		return s
+	}
+
+	res := make([]WorkflowNodeJobRun, 0, len(sorted))
+	for rank := range JobPriorityClasses {
+		// Waiting jobs of the current priority class by share, in arrival order
+		var keys []string
+		pending := make(map[string][]WorkflowNodeJobRun)
+		for _, j := range sorted {
+			if jobPriorityRank(j.Job.PriorityClass) != rank {
+				continue
+			}
+			k := shareKey(j)
+			if _, ok := pending[k]; !ok {
+				keys = append(keys, k)
+			}
+			pending[k] = append(pending[k], j)
+		}
+
+		for len(keys) > 0 {
+			// Serve the share with the lowest usage, the one with the oldest job first on equality
+			next := 0
+			for i := 1; i < len(keys); i++ {
+				si, sn := getState(keys[i]), getState(keys[next])
+				ui, un := si.scheduled*sn.weight, sn.scheduled*si.weight
+				if ui < un || (ui == un && pending[keys[i]][0].Queued.Before(pending[keys[next]][0].Queued)) {
+					next = i
+				}
+			}
+			k := keys[next]
+			s := getState(k)
+			j := pending[k][0]
+			pending[k] = pending[k][1:]
+			if len(pending[k]) == 0 {
+				keys = append(keys[:next], keys[next+1:]...)
+			}
+
+			model := QueueJobModel(j)
+			if s.max > 0 && model != "" && s.scheduledModels[model] >= s.max {
+				continue
+			}
+			s.scheduled++
+			s.scheduledModels[model]++
+			res = append(res, j)
+		}
+	}
+	return res
+}
+
+// Reasons why a job is still waiting in the queue.
+const (
+	QueueWaitingReasonQueued                = "queued"
+	QueueWaitingReasonBooked                = "booked"
+	QueueWaitingReasonNoHatchery            = "no_hatchery"
+	QueueWaitingReasonModelError            = "model_error"
+	QueueWaitingReasonQuotaReached          = "quota_reached"
+	QueueWaitingReasonRequirementsUnmatched = "requirements_unmatched"
+)
+
+// WorkflowNodeJobRunQueueInfo contains the position of a waiting job among the jobs eligible for the same worker model
+// and region, its estimated start and why it is waiting.
+type WorkflowNodeJobRunQueueInfo struct {
+	Position      int        `json:"position"`
+	Total         int        `json:"total"`
+	Model         string     `json:"model,omitempty"`
+	Region        string     `json:"region,omitempty"`
+	ETA           *time.Time `json:"eta,omitempty"`
+	Reason        string     `json:"reason"`
+	ReasonDetails string     `json:"reason_details,omitempty"`
+}
+
+// QueueStats contains the recent spawn delay and job duration for a worker model, as moving averages.
+type QueueStats struct {
+	SpawnDelay  time.Duration `json:"spawn_delay"`
+	JobDuration time.Duration `json:"job_duration"`
+	Samples     int64         `json:"samples"`
+}
+
+// QueueStatsSmoothing is the weight of a new sample in the moving averages.
+const QueueStatsSmoothing = 0.2
+
+// Add adds the spawn delay and the duration of a finished job to the stats.
+func (s *QueueStats) Add(spawnDelay, jobDuration time.Duration) {
+	if s.Samples == 0 {
+		s.SpawnDelay, s.JobDuration = spawnDelay, jobDuration
+	} else {
+		s.SpawnDelay = time.Duration(float64(s.SpawnDelay)*(1-QueueStatsSmoothing) + float64(spawnDelay)*QueueStatsSmoothing)
+		s.JobDuration = time.Duration(float64(s.JobDuration)*(1-QueueStatsSmoothing) + float64(jobDuration)*QueueStatsSmoothing)
+	}
+	s.Samples++
+}
+
+// EstimatedWait returns the estimated time before a job at given position starts, if the jobs ahead of it are run by
+// given number of concurrent workers.
+func (s QueueStats) EstimatedWait(position, concurrency int) time.Duration {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	rounds := (position - 1) / concurrency
+	return s.SpawnDelay + time.Duration(rounds)*s.JobDuration
+}
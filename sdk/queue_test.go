@@ -0,0 +1,127 @@
+package sdk
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFairShareQueue(t *testing.T) {
+	t0 := time.Now()
+	job := func(id, projectID int64, queued int, priority, model string) WorkflowNodeJobRun {
+		j := WorkflowNodeJobRun{ID: id, ProjectID: projectID, Queued: t0.Add(time.Duration(queued) * time.Second)}
+		j.Job.PriorityClass = priority
+		if model != "" {
+			j.Job.Action.Requirements = RequirementList{{Name: "model", Type: ModelRequirement, Value: model + " --privileged"}}
+		}
+		return j
+	}
+	key := func(j WorkflowNodeJobRun) string {
+		if j.ProjectID == 3 {
+			return "organization/org"
+		}
+		return fmt.Sprintf("project/%d", j.ProjectID)
+	}
+	ids := func(jobs []WorkflowNodeJobRun) []int64 {
+		res := make([]int64, len(jobs))
+		for i := range jobs {
+			res[i] = jobs[i].ID
+		}
+		return res
+	}
+
+	// Project 1 pushed a lot of jobs before project 2
+	jobs := []WorkflowNodeJobRun{
+		job(1, 1, 1, "", ""),
+		job(2, 1, 2, "", ""),
+		job(3, 1, 3, "", ""),
+		job(4, 1, 4, "", ""),
+		job(5, 2, 5, "", ""),
+		job(6, 2, 6, "", ""),
+	}
+	require.Equal(t, []int64{1, 5, 2, 6, 3, 4}, ids(FairShareQueue(jobs, key, nil)))
+
+	// Project 2 has a double weight
+	shares := map[string]QueueShare{"project/2": {Weight: 2}}
+	require.Equal(t, []int64{1, 5, 6, 2, 3, 4}, ids(FairShareQueue(jobs, key, shares)))
+
+	// Building jobs are taken into account
+	shares = map[string]QueueShare{"project/1": {BuildingByModel: map[string]int64{"go": 2}}}
+	require.Equal(t, []int64{5, 6, 1, 2, 3, 4}, ids(FairShareQueue(jobs, key, shares)))
+
+	// High priority jobs first, low priority jobs last
+	jobs = []WorkflowNodeJobRun{
+		job(1, 1, 1, JobPriorityClassLow, ""),
+		job(2, 1, 2, "", ""),
+		job(3, 2, 3, JobPriorityClassHigh, ""),
+		job(4, 2, 4, JobPriorityClassNormal, ""),
+	}
+	require.Equal(t, []int64{3, 2, 4, 1}, ids(FairShareQueue(jobs, key, nil)))
+
+	// Jobs exceeding the max concurrent jobs per model are held, organization share all their projects jobs
+	jobs = []WorkflowNodeJobRun{
+		job(1, 3, 1, "", "shared.infra/go"),
+		job(2, 3, 2, "", "shared.infra/go"),
+		job(3, 3, 3, "", "shared.infra/node"),
+		job(4, 3, 4, "", ""),
+	}
+	shares = map[string]QueueShare{"organization/org": {MaxConcurrentJobsPerModel: 1}}
+	require.Equal(t, []int64{1, 3, 4}, ids(FairShareQueue(jobs, key, shares)))
+	shares = map[string]QueueShare{"organization/org": {MaxConcurrentJobsPerModel: 1, BuildingByModel: map[string]int64{"go": 1}}}
+	require.Equal(t, []int64{3, 4}, ids(FairShareQueue(jobs, key, shares)))
+}
+
+func TestQueueQuotaIsValid(t *testing.T) {
+	require.NoError(t, QueueQuota{ProjectKey: "PROJ", Weight: 1}.IsValid())
+	require.NoError(t, QueueQuota{Organization: "org", Weight: 3, MaxConcurrentJobsPerModel: 10}.IsValid())
+	require.Error(t, QueueQuota{Weight: 1}.IsValid())
+	require.Error(t, QueueQuota{ProjectKey: "PROJ", Organization: "org", Weight: 1}.IsValid())
+	require.Error(t, QueueQuota{ProjectKey: "PROJ"}.IsValid())
+	require.Error(t, QueueQuota{ProjectKey: "PROJ", Weight: 1, MaxConcurrentJobsPerModel: -1}.IsValid())
+}
+
+func TestQueueJobModel(t *testing.T) {
+	var j WorkflowNodeJobRun
+	require.Equal(t, "", QueueJobModel(j))
+	j.Job.Action.Requirements = RequirementList{{Name: "model", Type: ModelRequirement, Value: "shared.infra/go --privileged"}}
+	require.Equal(t, "go", QueueJobModel(j))
+	j.Model = "node"
+	require.Equal(t, "node", QueueJobModel(j))
+}
+
+func TestQueueStats(t *testing.T) {
+	var s QueueStats
+	s.Add(10*time.Second, 100*time.Second)
+	require.Equal(t, 10*time.Second, s.SpawnDelay)
+	require.Equal(t, 100*time.Second, s.JobDuration)
+
+	s.Add(20*time.Second, 200*time.Second)
+	require.Equal(t, int64(2), s.Samples)
+	require.Equal(t, 12*time.Second, s.SpawnDelay)
+	require.Equal(t, 120*time.Second, s.JobDuration)
+
+	require.Equal(t, 12*time.Second, s.EstimatedWait(1, 0))
+	require.Equal(t, 12*time.Second, s.EstimatedWait(2, 2))
+	require.Equal(t, 132*time.Second, s.EstimatedWait(3, 2))
+	require.Equal(t, 252*time.Second, s.EstimatedWait(3, 1))
+}
+
+func TestModelMatchRequirements(t *testing.T) {
+	os, arch := "linux", "amd64"
+	m := Model{
+		Name:                   "go",
+		Group:                  &Group{Name: "shared.infra"},
+		RegisteredOS:           &os,
+		RegisteredArch:         &arch,
+		RegisteredCapabilities: RequirementList{{Name: "git", Type: BinaryRequirement, Value: "git"}},
+	}
+	require.True(t, m.MatchRequirements(nil))
+	require.True(t, m.MatchRequirements(RequirementList{{Type: ModelRequirement, Value: "shared.infra/go --privileged"}}))
+	require.True(t, m.MatchRequirements(RequirementList{{Type: ModelRequirement, Value: "go"}, {Type: BinaryRequirement, Value: "git"}}))
+	require.True(t, m.MatchRequirements(RequirementList{{Type: OSArchRequirement, Value: "linux/amd64"}}))
+	require.False(t, m.MatchRequirements(RequirementList{{Type: ModelRequirement, Value: "other/go"}}))
+	require.False(t, m.MatchRequirements(RequirementList{{Type: BinaryRequirement, Value: "docker"}}))
+	require.False(t, m.MatchRequirements(RequirementList{{Type: OSArchRequirement, Value: "windows/amd64"}}))
+}
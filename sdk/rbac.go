@@ -13,8 +13,14 @@ const (
 	GlobalRoleProjectCreate      = "create-project"
 
 	// Project Role
-	ProjectRoleRead   = "read"
-	ProjectRoleManage = "manage"
+	ProjectRoleRead              = "read"
+	ProjectRoleManage            = "manage"
+	ProjectRoleManageVCS         = "manage-vcs"
+	ProjectRoleManageRepository  = "manage-repository"
+	ProjectRoleManageWorkerModel = "manage-worker-model"
+	ProjectRoleTriggerRun        = "trigger-run"
+	ProjectRoleManageSecret      = "manage-secret"
+	ProjectRoleViewSecret        = "view-secret"
 
 	// Hatchery Role
 	HatcheryRoleSpawn = "start-worker"
@@ -0,0 +1,80 @@
+package sdk
+
+// RBAC rule types used in permission explanations.
+const (
+	RBACTypeGlobal   = "global"
+	RBACTypeProject  = "project"
+	RBACTypeRegion   = "region"
+	RBACTypeHatchery = "hatchery"
+)
+
+// RBACExplainRequest asks why a user is allowed or not on a v2 route or for a role on a target.
+// If WhatIf is set the given permission is evaluated as if it was imported, replacing the existing one with the same name.
+type RBACExplainRequest struct {
+	Username   string `json:"username,omitempty"`
+	ConsumerID string `json:"consumer_id,omitempty"`
+
+	// Route to explain, ex: DELETE /v2/project/MYPROJ/vcs/my-vcs
+	Method string `json:"method,omitempty"`
+	Path   string `json:"path,omitempty"`
+
+	// Role to explain on given target, ignored if a route is given
+	Role       string `json:"role,omitempty"`
+	Project    string `json:"project,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Entity     string `json:"entity,omitempty"`
+	Region     string `json:"region,omitempty"`
+	Hatchery   string `json:"hatchery,omitempty"`
+
+	WhatIf *RBAC `json:"what_if,omitempty"`
+}
+
+// IsValid returns an error if the request contains neither a route nor a role.
+func (r RBACExplainRequest) IsValid() error {
+	if r.Path == "" && r.Role == "" {
+		return NewErrorFrom(ErrWrongRequest, "a route or a role is required")
+	}
+	if r.Path != "" && r.Method == "" {
+		return NewErrorFrom(ErrWrongRequest, "missing method for route %s", r.Path)
+	}
+	if r.Username != "" && r.ConsumerID != "" {
+		return NewErrorFrom(ErrWrongRequest, "username and consumer can't be both set")
+	}
+	return nil
+}
+
+// RBACExplanation is the decision for a user with the permissions that granted or would grant it.
+type RBACExplanation struct {
+	Username     string                   `json:"username"`
+	Allowed      bool                     `json:"allowed"`
+	Admin        bool                     `json:"admin"`
+	Route        string                   `json:"route,omitempty"`
+	Requirements []RBACExplainRequirement `json:"requirements"`
+}
+
+// RBACExplainRequirement is a permission check, all requirements of a route should be allowed.
+// One of the roles is enough to satisfy a requirement.
+type RBACExplainRequirement struct {
+	Checker    string             `json:"checker,omitempty" cli:"checker"`
+	Type       string             `json:"type,omitempty" cli:"type"`
+	Roles      []string           `json:"roles,omitempty" cli:"roles"`
+	Project    string             `json:"project,omitempty" cli:"project"`
+	Repository string             `json:"repository,omitempty" cli:"repository"`
+	Entity     string             `json:"entity,omitempty" cli:"entity"`
+	Region     string             `json:"region,omitempty" cli:"region"`
+	Hatchery   string             `json:"hatchery,omitempty" cli:"hatchery"`
+	Allowed    bool               `json:"allowed" cli:"allowed"`
+	Message    string             `json:"message,omitempty" cli:"message"`
+	Grants     []RBACExplainGrant `json:"grants,omitempty" cli:"-"`
+}
+
+// RBACExplainGrant is a permission that targets the requirement's resource with one of its roles.
+// Granted is true if the permission applies to the user, Via tells how (user, group or organization).
+type RBACExplainGrant struct {
+	RBAC    string   `json:"rbac" cli:"rbac"`
+	Role    string   `json:"role" cli:"role"`
+	Granted bool     `json:"granted" cli:"granted"`
+	Via     []string `json:"via,omitempty" cli:"via"`
+	Reason  string   `json:"reason,omitempty" cli:"reason"`
+	WhatIf  bool     `json:"what_if,omitempty" cli:"what_if"`
+}
@@ -1,7 +1,10 @@
 package sdk
 
+import "path"
+
 var (
-	ProjectRoles = []string{ProjectRoleRead, ProjectRoleManage}
+	ProjectRoles = []string{ProjectRoleRead, ProjectRoleManage, ProjectRoleManageVCS, ProjectRoleManageRepository,
+		ProjectRoleManageWorkerModel, ProjectRoleTriggerRun, ProjectRoleManageSecret, ProjectRoleViewSecret}
 )
 
 type RBACProject struct {
@@ -11,6 +14,10 @@ type RBACProject struct {
 	RBACUsersName   []string `json:"users" db:"-"`
 	RBACGroupsName  []string `json:"groups" db:"-"`
 
+	// Optional patterns (ex: my-org/*) restricting the permission to some repositories or entity names
+	RBACRepositories StringSlice `json:"repositories,omitempty" db:"repositories"`
+	RBACEntities     StringSlice `json:"entities,omitempty" db:"entities"`
+
 	RBACUsersIDs  []string `json:"-" db:"-"`
 	RBACGroupsIDs []int64  `json:"-" db:"-"`
 }
@@ -43,5 +50,43 @@ func isValidRBACProject(rbacName string, rbacProject RBACProject) error {
 	if len(rbacProject.RBACProjectKeys) > 0 && rbacProject.All {
 		return NewErrorFrom(ErrInvalidData, "rbac %s: you can't have a list of project and the all flag checked on a project permission", rbacName)
 	}
+
+	// Check repositories and entities patterns
+	for _, p := range append(append([]string{}, rbacProject.RBACRepositories...), rbacProject.RBACEntities...) {
+		if _, err := path.Match(p, ""); p == "" || err != nil {
+			return NewErrorFrom(ErrInvalidData, "rbac %s: invalid pattern %q on a project permission", rbacName, p)
+		}
+	}
 	return nil
 }
+
+// IsRestricted returns true if the permission only applies to some repositories or entities.
+func (rbacProject RBACProject) IsRestricted() bool {
+	return len(rbacProject.RBACRepositories) > 0 || len(rbacProject.RBACEntities) > 0
+}
+
+// MatchTarget returns true if the permission applies on given repository and entity names.
+// An empty name never matches a restricted permission.
+func (rbacProject RBACProject) MatchTarget(repository, entity string) bool {
+	return matchRBACPatterns(rbacProject.RBACRepositories, repository) && matchRBACPatterns(rbacProject.RBACEntities, entity)
+}
+
+// MatchRepository returns true if the permission applies on some entities of given repository.
+func (rbacProject RBACProject) MatchRepository(repository string) bool {
+	return matchRBACPatterns(rbacProject.RBACRepositories, repository)
+}
+
+func matchRBACPatterns(patterns []string, name string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	if name == "" {
+		return false
+	}
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -67,3 +67,35 @@ func TestRBACProjectInvalidAllAndListOfProject(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "rbac myRule: you can't have a list of project and the all flag checked on a project permission")
 }
+
+func TestRBACProjectInvalidPattern(t *testing.T) {
+	rb := RBACProject{
+		RBACProjectKeys:  []string{"foo"},
+		Role:             ProjectRoleManageRepository,
+		RBACUsersIDs:     []string{"aa-aa-aa"},
+		RBACRepositories: []string{"my-org/["},
+	}
+	err := isValidRBACProject("myRule", rb)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `rbac myRule: invalid pattern "my-org/[" on a project permission`)
+}
+
+func TestRBACProjectMatchTarget(t *testing.T) {
+	rb := RBACProject{Role: ProjectRoleManageWorkerModel}
+	require.False(t, rb.IsRestricted())
+	require.True(t, rb.MatchTarget("", ""))
+
+	rb.RBACRepositories = []string{"my-org/*", "other/repo"}
+	require.True(t, rb.IsRestricted())
+	require.True(t, rb.MatchTarget("my-org/my-repo", ""))
+	require.True(t, rb.MatchTarget("other/repo", ""))
+	require.False(t, rb.MatchTarget("other/repo2", ""))
+	require.False(t, rb.MatchTarget("", ""))
+
+	rb.RBACEntities = []string{"docker-*"}
+	require.True(t, rb.MatchTarget("my-org/my-repo", "docker-debian"))
+	require.False(t, rb.MatchTarget("my-org/my-repo", "openstack-debian"))
+	require.False(t, rb.MatchTarget("my-org/my-repo", ""))
+	require.True(t, rb.MatchRepository("my-org/my-repo"))
+	require.False(t, rb.MatchRepository("other/repo2"))
+}
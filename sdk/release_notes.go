@@ -0,0 +1,218 @@
+package sdk
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/blang/semver"
+)
+
+// ReleaseNotesTemplateVariable is the name of the project variable that overrides the default release notes template.
+const ReleaseNotesTemplateVariable = "release_notes_template"
+
+// DefaultReleaseNotesTemplate is the Go template used to generate the release notes if the project does not override it.
+const DefaultReleaseNotesTemplate = `{{- if .Introduction }}{{ .Introduction }}
+
+{{ end -}}
+{{- if .PreviousTag }}## Changes since {{ .PreviousTag }}
+{{ else }}## Changes
+{{ end -}}
+{{- range .Sections }}
+### {{ .Title }}
+{{ range .Commits }}
+- {{ if .Scope }}**{{ .Scope }}:** {{ end }}{{ .Subject }} ({{ .ShortHash }}){{ if .Breaking }} **BREAKING**{{ end }}
+{{- end }}
+{{ end -}}
+{{- if .PullRequests }}
+### Pull requests
+{{ range .PullRequests }}
+- {{ . }}
+{{- end }}
+{{ end -}}
+{{- if .Contributors }}
+### Contributors
+{{ range .Contributors }}
+- {{ . }}
+{{- end }}
+{{ end -}}
+{{- if .Assets }}
+### Assets
+{{ range .Assets }}
+- {{ . }}
+{{- end }}
+{{ end -}}
+`
+
+var (
+	conventionalCommitRegexp = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+	pullRequestRefRegexp     = regexp.MustCompile(`(?:^|[\s(])((?:[\w.-]+/[\w.-]+)?[#!]\d+)\b`)
+)
+
+// releaseNotesSectionTitles gives the order and the title of the sections for Conventional Commit types.
+var releaseNotesSectionTitles = []struct {
+	Type  string
+	Title string
+}{
+	{"feat", "Features"},
+	{"fix", "Bug fixes"},
+	{"perf", "Performance improvements"},
+	{"refactor", "Code refactoring"},
+	{"docs", "Documentation"},
+	{"test", "Tests"},
+	{"build", "Build system"},
+	{"ci", "Continuous integration"},
+	{"chore", "Chores"},
+	{"revert", "Reverts"},
+}
+
+// ReleaseNotesOtherSection is the title of the section for commits that don't follow Conventional Commits.
+const ReleaseNotesOtherSection = "Other changes"
+
+// ReleaseNotes is the data given to the release notes template.
+type ReleaseNotes struct {
+	Tag          string
+	PreviousTag  string
+	Introduction string
+	Sections     []ReleaseNotesSection
+	PullRequests []string
+	Contributors []string
+	Assets       []string
+}
+
+// ReleaseNotesSection groups the commits of a Conventional Commit type.
+type ReleaseNotesSection struct {
+	Type    string
+	Title   string
+	Commits []ReleaseNotesCommit
+}
+
+// ReleaseNotesCommit is a commit parsed as a Conventional Commit.
+type ReleaseNotesCommit struct {
+	Hash      string
+	ShortHash string
+	Type      string
+	Scope     string
+	Subject   string
+	Breaking  bool
+	Author    string
+	URL       string
+}
+
+// NewReleaseNotes groups the commits between two tags by Conventional Commit type, and collects the pull requests
+// referenced by commit messages and the commit authors.
+func NewReleaseNotes(tag, previousTag string, commits []VCSCommit, assets []string) ReleaseNotes {
+	notes := ReleaseNotes{
+		Tag:         tag,
+		PreviousTag: previousTag,
+		Assets:      assets,
+	}
+
+	commitsByType := make(map[string][]ReleaseNotesCommit)
+	pullRequests := make(map[string]struct{})
+	contributors := make(map[string]struct{})
+	for _, c := range commits {
+		rc := parseReleaseNotesCommit(c)
+		commitsByType[rc.Type] = append(commitsByType[rc.Type], rc)
+		for _, m := range pullRequestRefRegexp.FindAllStringSubmatch(c.Message, -1) {
+			if _, ok := pullRequests[m[1]]; !ok {
+				pullRequests[m[1]] = struct{}{}
+				notes.PullRequests = append(notes.PullRequests, m[1])
+			}
+		}
+		if rc.Author != "" {
+			if _, ok := contributors[rc.Author]; !ok {
+				contributors[rc.Author] = struct{}{}
+				notes.Contributors = append(notes.Contributors, rc.Author)
+			}
+		}
+	}
+	sort.Strings(notes.Contributors)
+
+	for _, s := range releaseNotesSectionTitles {
+		if cs, ok := commitsByType[s.Type]; ok {
+			notes.Sections = append(notes.Sections, ReleaseNotesSection{Type: s.Type, Title: s.Title, Commits: cs})
+		}
+	}
+	if cs, ok := commitsByType[""]; ok {
+		notes.Sections = append(notes.Sections, ReleaseNotesSection{Title: ReleaseNotesOtherSection, Commits: cs})
+	}
+	return notes
+}
+
+func parseReleaseNotesCommit(c VCSCommit) ReleaseNotesCommit {
+	rc := ReleaseNotesCommit{
+		Hash:      c.Hash,
+		ShortHash: c.Hash,
+		Author:    c.Author.Name,
+		URL:       c.URL,
+	}
+	if len(rc.ShortHash) > 7 {
+		rc.ShortHash = rc.ShortHash[:7]
+	}
+	if rc.Author == "" {
+		rc.Author = c.Author.DisplayName
+	}
+
+	lines := strings.Split(strings.TrimSpace(c.Message), "\n")
+	rc.Subject = strings.TrimSpace(lines[0])
+	m := conventionalCommitRegexp.FindStringSubmatch(rc.Subject)
+	if m == nil {
+		return rc
+	}
+	t := strings.ToLower(m[1])
+	for _, s := range releaseNotesSectionTitles {
+		if s.Type == t {
+			rc.Type = t
+			break
+		}
+	}
+	if rc.Type == "" {
+		return rc
+	}
+	rc.Scope, rc.Subject, rc.Breaking = m[2], m[4], m[3] == "!"
+	for _, l := range lines[1:] {
+		if strings.HasPrefix(l, "BREAKING CHANGE") {
+			rc.Breaking = true
+		}
+	}
+	return rc
+}
+
+// Render executes the given Go template on the release notes, the default template is used if the given one is empty.
+func (r ReleaseNotes) Render(tmpl string) (string, error) {
+	if strings.TrimSpace(tmpl) == "" {
+		tmpl = DefaultReleaseNotesTemplate
+	}
+	t, err := template.New("release_notes").Parse(tmpl)
+	if err != nil {
+		return "", NewErrorFrom(ErrWrongRequest, "invalid release notes template: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, r); err != nil {
+		return "", NewErrorFrom(ErrWrongRequest, "unable to execute release notes template: %v", err)
+	}
+	return buf.String(), nil
+}
+
+// PreviousReleaseTag returns the greatest semver tag lower than the given tag, or an empty string if there is none.
+func PreviousReleaseTag(tags []VCSTag, tag string) string {
+	current, err := semver.ParseTolerant(tag)
+	if err != nil {
+		return ""
+	}
+	var previous string
+	var previousVersion semver.Version
+	for _, t := range tags {
+		v, err := semver.ParseTolerant(t.Tag)
+		if err != nil || !v.LT(current) {
+			continue
+		}
+		if previous == "" || v.GT(previousVersion) {
+			previous, previousVersion = t.Tag, v
+		}
+	}
+	return previous
+}
@@ -0,0 +1,53 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReleaseNotes(t *testing.T) {
+	commits := []VCSCommit{
+		{Hash: "1111111111", Author: VCSAuthor{Name: "bob"}, Message: "feat(api): add release notes (#12)"},
+		{Hash: "2222222222", Author: VCSAuthor{Name: "alice"}, Message: "fix!: remove deprecated route\n\nSee merge request ovh/cds!7"},
+		{Hash: "3333333333", Author: VCSAuthor{DisplayName: "Bob"}, Message: "Update README"},
+		{Hash: "4444444444", Author: VCSAuthor{Name: "alice"}, Message: "feat: new action\n\nBREAKING CHANGE: params renamed"},
+		{Hash: "5555555555", Author: VCSAuthor{Name: "bob"}, Message: "wip: not a known type"},
+	}
+
+	notes := NewReleaseNotes("v1.1.0", "v1.0.0", commits, []string{"cds-linux-amd64"})
+	require.Len(t, notes.Sections, 3)
+	require.Equal(t, "Features", notes.Sections[0].Title)
+	require.Equal(t, []ReleaseNotesCommit{
+		{Hash: "1111111111", ShortHash: "1111111", Type: "feat", Scope: "api", Subject: "add release notes (#12)", Author: "bob"},
+		{Hash: "4444444444", ShortHash: "4444444", Type: "feat", Subject: "new action", Breaking: true, Author: "alice"},
+	}, notes.Sections[0].Commits)
+	require.Equal(t, "Bug fixes", notes.Sections[1].Title)
+	require.True(t, notes.Sections[1].Commits[0].Breaking)
+	require.Equal(t, ReleaseNotesOtherSection, notes.Sections[2].Title)
+	require.Len(t, notes.Sections[2].Commits, 2)
+	require.Equal(t, "wip: not a known type", notes.Sections[2].Commits[1].Subject)
+	require.Equal(t, []string{"#12", "ovh/cds!7"}, notes.PullRequests)
+	require.Equal(t, []string{"Bob", "alice", "bob"}, notes.Contributors)
+
+	content, err := notes.Render("")
+	require.NoError(t, err)
+	require.Contains(t, content, "## Changes since v1.0.0")
+	require.Contains(t, content, "### Features\n\n- **api:** add release notes (#12) (1111111)\n- new action (4444444) **BREAKING**\n")
+	require.Contains(t, content, "### Assets\n\n- cds-linux-amd64\n")
+
+	content, err = notes.Render("{{ .Tag }}:{{ range .Sections }} {{ .Type }}={{ len .Commits }}{{ end }}")
+	require.NoError(t, err)
+	require.Equal(t, "v1.1.0: feat=2 fix=1 =2", content)
+
+	_, err = notes.Render("{{ .Unknown }}")
+	require.Error(t, err)
+}
+
+func TestPreviousReleaseTag(t *testing.T) {
+	tags := []VCSTag{{Tag: "v1.0.0"}, {Tag: "v1.2.0"}, {Tag: "latest"}, {Tag: "v0.9.1"}, {Tag: "v1.1.0"}}
+	require.Equal(t, "v1.1.0", PreviousReleaseTag(tags, "v1.2.0"))
+	require.Equal(t, "v1.2.0", PreviousReleaseTag(tags, "1.3.0"))
+	require.Equal(t, "", PreviousReleaseTag(tags, "v0.9.1"))
+	require.Equal(t, "", PreviousReleaseTag(tags, "latest"))
+}
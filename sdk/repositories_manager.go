@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"strings"
 	"time"
 )
 
@@ -98,6 +99,7 @@ type VCSPullRequest struct {
 	Closed   bool         `json:"closed"`
 	Revision string       `json:"revision"`
 	Updated  time.Time    `json:"updated"`
+	Labels   []string     `json:"labels,omitempty"`
 }
 
 type VCSContent struct {
@@ -128,6 +130,14 @@ func (s VCSPullRequestState) IsValid() bool {
 	return false
 }
 
+// VCSPullRequestMergeRequest merges a pull request, the merge is refused if the head of the pull request is not the
+// given commit.
+type VCSPullRequestMergeRequest struct {
+	Method  string `json:"method"`
+	Commit  string `json:"commit,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
 type VCSPullRequestCommentRequest struct {
 	VCSPullRequest
 	Message string `json:"message"`
@@ -173,6 +183,20 @@ type VCSHook struct {
 	InsecureSSL bool     `json:"insecure_ssl"`
 }
 
+// VCSDeployKey is a SSH public key allowed to access a repository.
+type VCSDeployKey struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Key      string `json:"key"`
+	ReadOnly bool   `json:"read_only"`
+}
+
+// Match returns true if given public key is the deploy key, comments are ignored.
+func (k VCSDeployKey) Match(public string) bool {
+	a, b := strings.Fields(k.Key), strings.Fields(public)
+	return len(a) >= 2 && len(b) >= 2 && a[0] == b[0] && a[1] == b[1]
+}
+
 // VCSCommitStatus represents a status on a VCS repository
 type VCSCommitStatus struct {
 	Ref        string    `json:"ref"`
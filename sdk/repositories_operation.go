@@ -66,6 +66,7 @@ func (opError *OperationError) ToError() error {
 type OperationSetup struct {
 	Checkout OperationCheckout `json:"checkout,omitempty"`
 	Push     OperationPush     `json:"push,omitempty"`
+	Merge    OperationMerge    `json:"merge,omitempty"`
 }
 
 // OperationRepositoryInfo represents global information about the repository
@@ -103,6 +104,25 @@ type OperationPush struct {
 	Update     bool   `json:"update,omitempty"`
 }
 
+// OperationMerge represents a speculative merge of branches on a target branch, pushed to a new branch
+type OperationMerge struct {
+	FromBranch string                 `json:"from_branch,omitempty"`
+	ToBranch   string                 `json:"to_branch,omitempty"`
+	Sources    []OperationMergeSource `json:"sources,omitempty"`
+	Result     struct {
+		BaseCommit string   `json:"base_commit,omitempty"`
+		Commit     string   `json:"commit,omitempty"`
+		Conflicts  []string `json:"conflicts,omitempty"`
+	} `json:"result"`
+}
+
+// OperationMergeSource is a commit merged by a merge operation, conflicting sources are skipped
+type OperationMergeSource struct {
+	Branch  string `json:"branch"`
+	Commit  string `json:"commit"`
+	Message string `json:"message,omitempty"`
+}
+
 // OperationStatus is the status of an operation
 type OperationStatus int
 
@@ -1,6 +1,10 @@
 package sdk
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+	"time"
+)
 
 const (
 	//BinaryRequirement refers to the need to a specific binary on host running the action
@@ -21,6 +25,8 @@ const (
 	OSArchRequirement = "os-architecture"
 	// RegionRequirement lets a use to force a job running in a hatchery's region
 	RegionRequirement = "region"
+	// RegionPreferenceRequirement lets a user give an ordered list of regions for a job with failover, ex: "eu-west,eu-central;10m"
+	RegionPreferenceRequirement = "region-preference"
 	// SecretRequirement is needed to ask for a project's secret when it's not automatically injected (ex: when using SkipProjectSecretsOnRegion)
 	SecretRequirement = "secret"
 )
@@ -74,14 +80,18 @@ func (l RequirementList) IsValid() error {
 		}
 	}
 
-	// check that only one model requirement and hostname exists
-	nbModel, nbHostname := 0, 0
+	// check that only one model requirement, hostname and region preference exists
+	nbModel, nbHostname, nbRegion, nbRegionPreference := 0, 0, 0, 0
 	for i := range l {
 		switch l[i].Type {
 		case ModelRequirement:
 			nbModel++
 		case HostnameRequirement:
 			nbHostname++
+		case RegionRequirement:
+			nbRegion++
+		case RegionPreferenceRequirement:
+			nbRegionPreference++
 		}
 	}
 	if nbModel > 1 {
@@ -90,6 +100,9 @@ func (l RequirementList) IsValid() error {
 	if nbHostname > 1 {
 		return WithStack(ErrInvalidJobRequirementDuplicateHostname)
 	}
+	if nbRegionPreference > 1 || (nbRegionPreference > 0 && nbRegion > 0) {
+		return NewErrorFrom(ErrInvalidJobRequirement, "cannot have multiple region-preference requirements or both region and region-preference requirements")
+	}
 
 	// check that secret requirements are valid regexp
 	for i := range l {
@@ -98,6 +111,11 @@ func (l RequirementList) IsValid() error {
 				return NewErrorFrom(ErrInvalidJobRequirement, "requirement of type secret with value %q is not a valid regex: %v", l[i].Value, err)
 			}
 		}
+		if l[i].Type == RegionPreferenceRequirement {
+			if _, err := ParseRegionPreference(l[i].Value); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -113,6 +131,7 @@ var (
 		OSArchRequirement,
 		PluginRequirement,
 		RegionRequirement,
+		RegionPreferenceRequirement,
 		ServiceRequirement,
 		SecretRequirement,
 	}
@@ -192,3 +211,58 @@ func (a *Action) Requirement(name string, t string, value string) *Action {
 	a.Requirements = append(a.Requirements, r)
 	return a
 }
+
+// DefaultRegionFailoverDelay is the delay after which a job with a region preference is moved to the next region.
+const DefaultRegionFailoverDelay = 5 * time.Minute
+
+// RegionPreference is the value of a region-preference requirement: "<region>[,<region>...][;<failover delay>]".
+type RegionPreference struct {
+	Regions       []string
+	FailoverDelay time.Duration
+}
+
+// ParseRegionPreference parses the value of a region-preference requirement.
+func ParseRegionPreference(value string) (RegionPreference, error) {
+	p := RegionPreference{FailoverDelay: DefaultRegionFailoverDelay}
+	regions := value
+	if i := strings.LastIndex(value, ";"); i >= 0 {
+		regions = value[:i]
+		d, err := time.ParseDuration(strings.TrimSpace(value[i+1:]))
+		if err != nil || d <= 0 {
+			return p, NewErrorFrom(ErrInvalidJobRequirement, "invalid failover delay in region-preference requirement %q", value)
+		}
+		p.FailoverDelay = d
+	}
+	for _, r := range strings.Split(regions, ",") {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			return p, NewErrorFrom(ErrInvalidJobRequirement, "invalid region-preference requirement %q", value)
+		}
+		if IsInArray(r, p.Regions) {
+			return p, NewErrorFrom(ErrInvalidJobRequirement, "duplicate region %q in region-preference requirement", r)
+		}
+		p.Regions = append(p.Regions, r)
+	}
+	return p, nil
+}
+
+// Region returns the region where a job should be visible after being queued for given duration.
+// Each failover delay without the job being booked makes it go to the next region. Regions with no healthy hatchery are
+// skipped, if none of the remaining regions is healthy the job goes back to the first healthy one.
+func (p RegionPreference) Region(queued time.Duration, healthyRegions []string) string {
+	reached := len(p.Regions) - 1
+	if p.FailoverDelay > 0 && int64(queued/p.FailoverDelay) < int64(reached) {
+		reached = int(queued / p.FailoverDelay)
+	}
+	for _, r := range p.Regions[reached:] {
+		if IsInArray(r, healthyRegions) {
+			return r
+		}
+	}
+	for _, r := range p.Regions[:reached] {
+		if IsInArray(r, healthyRegions) {
+			return r
+		}
+	}
+	return p.Regions[reached]
+}
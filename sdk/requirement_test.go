@@ -2,6 +2,9 @@ package sdk
 
 import (
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestRequirementListDeduplicate(t *testing.T) {
@@ -100,3 +103,46 @@ func TestRequirementListDeduplicate(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRegionPreference(t *testing.T) {
+	p, err := ParseRegionPreference("eu-west, eu-central")
+	require.NoError(t, err)
+	require.Equal(t, []string{"eu-west", "eu-central"}, p.Regions)
+	require.Equal(t, DefaultRegionFailoverDelay, p.FailoverDelay)
+
+	p, err = ParseRegionPreference("eu-west,eu-central;10m")
+	require.NoError(t, err)
+	require.Equal(t, []string{"eu-west", "eu-central"}, p.Regions)
+	require.Equal(t, 10*time.Minute, p.FailoverDelay)
+
+	for _, v := range []string{"", "eu-west,", "eu-west;foo", "eu-west;-1m", "eu-west,eu-west"} {
+		_, err := ParseRegionPreference(v)
+		require.Error(t, err, v)
+	}
+}
+
+func TestRegionPreferenceRegion(t *testing.T) {
+	p := RegionPreference{Regions: []string{"a", "b", "c"}, FailoverDelay: 5 * time.Minute}
+	all := []string{"a", "b", "c"}
+
+	require.Equal(t, "a", p.Region(time.Minute, all))
+	require.Equal(t, "b", p.Region(6*time.Minute, all))
+	require.Equal(t, "c", p.Region(time.Hour, all))
+
+	// Unhealthy regions are skipped
+	require.Equal(t, "b", p.Region(time.Minute, []string{"b", "c"}))
+	require.Equal(t, "c", p.Region(6*time.Minute, []string{"a", "c"}))
+	// Back to the first healthy region when the next ones are down
+	require.Equal(t, "a", p.Region(time.Hour, []string{"a"}))
+	// No healthy region at all
+	require.Equal(t, "b", p.Region(6*time.Minute, nil))
+}
+
+func TestRequirementListIsValidRegionPreference(t *testing.T) {
+	require.NoError(t, RequirementList{{Name: "region-preference", Type: RegionPreferenceRequirement, Value: "a,b;1m"}}.IsValid())
+	require.Error(t, RequirementList{{Name: "region-preference", Type: RegionPreferenceRequirement, Value: "a;x"}}.IsValid())
+	require.Error(t, RequirementList{
+		{Name: "region", Type: RegionRequirement, Value: "a"},
+		{Name: "region-preference", Type: RegionPreferenceRequirement, Value: "a,b"},
+	}.IsValid())
+}
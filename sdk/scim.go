@@ -0,0 +1,335 @@
+package sdk
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// SCIM 2.0 schemas, see RFC 7643 and RFC 7644.
+const (
+	SCIMSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCIMSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SCIMSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIMSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCIMSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// SCIM patch operations.
+const (
+	SCIMPatchOpAdd     = "add"
+	SCIMPatchOpRemove  = "remove"
+	SCIMPatchOpReplace = "replace"
+)
+
+// SCIMMeta contains resource metadata.
+type SCIMMeta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+// SCIMName is the name of a SCIM user.
+type SCIMName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// SCIMMultiValued is a value of a multi-valued attribute like emails, groups or members.
+type SCIMMultiValued struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// SCIMUser is the SCIM representation of a CDS user.
+type SCIMUser struct {
+	Schemas     []string          `json:"schemas"`
+	ID          string            `json:"id,omitempty"`
+	ExternalID  string            `json:"externalId,omitempty"`
+	UserName    string            `json:"userName"`
+	Name        *SCIMName         `json:"name,omitempty"`
+	DisplayName string            `json:"displayName,omitempty"`
+	Active      *bool             `json:"active,omitempty"`
+	Emails      []SCIMMultiValued `json:"emails,omitempty"`
+	Groups      []SCIMMultiValued `json:"groups,omitempty"`
+	Meta        *SCIMMeta         `json:"meta,omitempty"`
+}
+
+// Fullname returns the user's fullname computed from display name or name attributes.
+func (u SCIMUser) Fullname() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name != nil {
+		if u.Name.Formatted != "" {
+			return u.Name.Formatted
+		}
+		if n := strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName); n != "" {
+			return n
+		}
+	}
+	return u.UserName
+}
+
+// PrimaryEmail returns the primary email of the user or the first one if no primary is set.
+func (u SCIMUser) PrimaryEmail() string {
+	for _, e := range u.Emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// IsActive returns false only if the user was explicitly set as inactive.
+func (u SCIMUser) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// IsValid returns an error if given user is not valid for provisioning.
+func (u SCIMUser) IsValid() error {
+	if err := IsValidUsername(u.UserName); err != nil {
+		return err
+	}
+	if u.PrimaryEmail() == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid given user, an email is required")
+	}
+	return nil
+}
+
+// SCIMGroup is the SCIM representation of a CDS group.
+type SCIMGroup struct {
+	Schemas     []string          `json:"schemas"`
+	ID          string            `json:"id,omitempty"`
+	ExternalID  string            `json:"externalId,omitempty"`
+	DisplayName string            `json:"displayName"`
+	Members     []SCIMMultiValued `json:"members,omitempty"`
+	Meta        *SCIMMeta         `json:"meta,omitempty"`
+}
+
+// SCIMListResponse is returned when querying a list of resources.
+type SCIMListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// SCIMError is returned when a request fails.
+type SCIMError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	SCIMType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// SCIMPatchRequest contains the operations to apply on a resource.
+type SCIMPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []SCIMPatchOperation `json:"Operations"`
+}
+
+// SCIMPatchOperation is a patch operation, if path is empty value contains the attributes to patch.
+type SCIMPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// IsValid returns an error if the operation is not supported.
+func (o SCIMPatchOperation) IsValid() error {
+	switch strings.ToLower(o.Op) {
+	case SCIMPatchOpAdd, SCIMPatchOpReplace:
+		if len(o.Value) == 0 {
+			return NewErrorFrom(ErrWrongRequest, "missing value for %s operation", o.Op)
+		}
+	case SCIMPatchOpRemove:
+		if o.Path == "" {
+			return NewErrorFrom(ErrWrongRequest, "missing path for remove operation")
+		}
+	default:
+		return NewErrorFrom(ErrWrongRequest, "invalid patch operation %q", o.Op)
+	}
+	return nil
+}
+
+// SCIMSupported is used in service provider configuration to describe a feature.
+type SCIMSupported struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults,omitempty"`
+}
+
+// SCIMServiceProviderConfig describes the features of the SCIM server.
+type SCIMServiceProviderConfig struct {
+	Schemas               []string                 `json:"schemas"`
+	Patch                 SCIMSupported            `json:"patch"`
+	Bulk                  SCIMSupported            `json:"bulk"`
+	Filter                SCIMSupported            `json:"filter"`
+	ChangePassword        SCIMSupported            `json:"changePassword"`
+	Sort                  SCIMSupported            `json:"sort"`
+	ETag                  SCIMSupported            `json:"etag"`
+	AuthenticationSchemes []map[string]interface{} `json:"authenticationSchemes"`
+}
+
+// SCIMFilterExpression is a attribute expression of a SCIM filter, ex: userName eq "john".
+type SCIMFilterExpression struct {
+	Attribute string
+	Operator  string
+	Value     string
+}
+
+// SCIMFilter is a list of attribute expressions joined by "and". Other logical operators and grouping are not supported.
+type SCIMFilter []SCIMFilterExpression
+
+// ParseSCIMFilter parses given filter, ex: userName eq "john" and active eq true.
+func ParseSCIMFilter(s string) (SCIMFilter, error) {
+	tokens, err := scimFilterTokens(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var f SCIMFilter
+	for i := 0; i < len(tokens); {
+		if len(f) > 0 {
+			if !strings.EqualFold(tokens[i], "and") {
+				return nil, NewErrorFrom(ErrWrongRequest, "unsupported filter %q, only 'and' logical operator is supported", s)
+			}
+			i++
+		}
+		if i+1 >= len(tokens) {
+			return nil, NewErrorFrom(ErrWrongRequest, "invalid filter %q", s)
+		}
+		e := SCIMFilterExpression{
+			Attribute: tokens[i],
+			Operator:  strings.ToLower(tokens[i+1]),
+		}
+		i += 2
+		switch e.Operator {
+		case "pr":
+		case "eq", "ne", "co", "sw", "ew":
+			if i >= len(tokens) {
+				return nil, NewErrorFrom(ErrWrongRequest, "invalid filter %q, missing value for attribute %s", s, e.Attribute)
+			}
+			e.Value = tokens[i]
+			i++
+		default:
+			return nil, NewErrorFrom(ErrWrongRequest, "unsupported filter operator %q", e.Operator)
+		}
+		f = append(f, e)
+	}
+	return f, nil
+}
+
+func scimFilterTokens(s string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	var inQuotes, escaped, quoted bool
+	for _, r := range s {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case inQuotes && r == '\\':
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+			quoted = true
+		case !inQuotes && (r == '(' || r == ')' || r == '[' || r == ']'):
+			return nil, NewErrorFrom(ErrWrongRequest, "unsupported filter %q, grouping is not supported", s)
+		case !inQuotes && (r == ' ' || r == '\t'):
+			if current.Len() > 0 || quoted {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				quoted = false
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inQuotes {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid filter %q, unterminated string", s)
+	}
+	if current.Len() > 0 || quoted {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// Match returns true if all expressions match the values returned for their attribute.
+// Attribute names and values are compared case insensitively.
+func (f SCIMFilter) Match(values func(attribute string) []string) bool {
+	for _, e := range f {
+		vs := values(strings.ToLower(e.Attribute))
+		var match bool
+		switch e.Operator {
+		case "pr":
+			for _, v := range vs {
+				match = match || v != ""
+			}
+		case "ne":
+			match = true
+			for _, v := range vs {
+				match = match && !strings.EqualFold(v, e.Value)
+			}
+		default:
+			for _, v := range vs {
+				v, expected := strings.ToLower(v), strings.ToLower(e.Value)
+				switch e.Operator {
+				case "eq":
+					match = match || v == expected
+				case "co":
+					match = match || strings.Contains(v, expected)
+				case "sw":
+					match = match || strings.HasPrefix(v, expected)
+				case "ew":
+					match = match || strings.HasSuffix(v, expected)
+				}
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	return true
+}
+
+// SCIMPath is a parsed patch operation path, ex: members[value eq "id"] or emails[type eq "work"].value.
+type SCIMPath struct {
+	Attribute    string
+	Filter       SCIMFilter
+	SubAttribute string
+}
+
+// ParseSCIMPath parses given patch operation path.
+func ParseSCIMPath(s string) (SCIMPath, error) {
+	var p SCIMPath
+	attr := s
+	if i := strings.Index(s, "["); i >= 0 {
+		j := strings.LastIndex(s, "]")
+		if j < i {
+			return p, NewErrorFrom(ErrWrongRequest, "invalid path %q", s)
+		}
+		f, err := ParseSCIMFilter(s[i+1 : j])
+		if err != nil {
+			return p, err
+		}
+		p.Filter = f
+		attr = s[:i]
+		p.SubAttribute = strings.TrimPrefix(s[j+1:], ".")
+	} else if i := strings.Index(s, "."); i >= 0 {
+		attr = s[:i]
+		p.SubAttribute = s[i+1:]
+	}
+	p.Attribute = strings.ToLower(attr)
+	p.SubAttribute = strings.ToLower(p.SubAttribute)
+	return p, nil
+}
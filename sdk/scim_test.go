@@ -0,0 +1,78 @@
+package sdk_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestParseSCIMFilter(t *testing.T) {
+	f, err := sdk.ParseSCIMFilter(`userName eq "john.doe" and emails.value co "@Corp" and externalId pr`)
+	require.NoError(t, err)
+	require.Equal(t, sdk.SCIMFilter{
+		{Attribute: "userName", Operator: "eq", Value: "john.doe"},
+		{Attribute: "emails.value", Operator: "co", Value: "@Corp"},
+		{Attribute: "externalId", Operator: "pr"},
+	}, f)
+
+	f, err = sdk.ParseSCIMFilter(`displayName EQ "my \"group\""`)
+	require.NoError(t, err)
+	require.Equal(t, sdk.SCIMFilter{{Attribute: "displayName", Operator: "eq", Value: `my "group"`}}, f)
+
+	for _, s := range []string{
+		`userName eq "john" or userName eq "jane"`,
+		`(userName eq "john")`,
+		`userName eq`,
+		`userName gt "a"`,
+		`userName eq "john`,
+	} {
+		_, err := sdk.ParseSCIMFilter(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestSCIMFilterMatch(t *testing.T) {
+	values := map[string][]string{
+		"username":     {"john.doe"},
+		"emails.value": {"john.doe@corp.com", "john@perso.com"},
+		"externalid":   {""},
+	}
+	getter := func(attr string) []string { return values[attr] }
+
+	cases := map[string]bool{
+		`userName eq "JOHN.DOE"`:                         true,
+		`userName ne "john.doe"`:                         false,
+		`emails.value ew "@perso.com"`:                   true,
+		`emails.value sw "jane"`:                         false,
+		`userName sw "john" and emails.value co "corp"`:  true,
+		`userName sw "john" and emails.value co "other"`: false,
+		`externalId pr`:                                  false,
+		`unknown eq "value"`:                             false,
+		`userName eq "john.doe" and userName ne "jane"`:  true,
+		`emails.value eq "john@perso.com" and active pr`: false,
+	}
+	for s, expected := range cases {
+		f, err := sdk.ParseSCIMFilter(s)
+		require.NoError(t, err, s)
+		require.Equal(t, expected, f.Match(getter), s)
+	}
+}
+
+func TestParseSCIMPath(t *testing.T) {
+	p, err := sdk.ParseSCIMPath(`members[value eq "123"]`)
+	require.NoError(t, err)
+	require.Equal(t, "members", p.Attribute)
+	require.Equal(t, sdk.SCIMFilter{{Attribute: "value", Operator: "eq", Value: "123"}}, p.Filter)
+
+	p, err = sdk.ParseSCIMPath(`emails[type eq "work"].value`)
+	require.NoError(t, err)
+	require.Equal(t, "emails", p.Attribute)
+	require.Equal(t, "value", p.SubAttribute)
+
+	p, err = sdk.ParseSCIMPath(`name.givenName`)
+	require.NoError(t, err)
+	require.Equal(t, "name", p.Attribute)
+	require.Equal(t, "givenname", p.SubAttribute)
+}
@@ -0,0 +1,58 @@
+package sdk
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver"
+)
+
+// Semver levels
+const (
+	SemverLevelMajor = "major"
+	SemverLevelMinor = "minor"
+	SemverLevelPatch = "patch"
+)
+
+var semverIdentifierRegexp = regexp.MustCompile(`[^0-9A-Za-z-]+`)
+
+// ConventionalCommitsLevel returns the semver level to bump for the given commit messages: major for a breaking
+// change, minor for a feature and patch for any other commit. It is empty if there is no commit.
+func ConventionalCommitsLevel(messages []string) string {
+	var level string
+	for _, m := range messages {
+		c := parseReleaseNotesCommit(VCSCommit{Message: m})
+		switch {
+		case c.Breaking:
+			return SemverLevelMajor
+		case c.Type == "feat":
+			level = SemverLevelMinor
+		case level == "":
+			level = SemverLevelPatch
+		}
+	}
+	return level
+}
+
+// SemverBump returns the given version incremented at the given level, without pre-release and build metadata.
+func SemverBump(v semver.Version, level string) semver.Version {
+	v.Pre, v.Build = nil, nil
+	switch level {
+	case SemverLevelMajor:
+		v.Major++
+		v.Minor = 0
+		v.Patch = 0
+	case SemverLevelMinor:
+		v.Minor++
+		v.Patch = 0
+	case SemverLevelPatch:
+		v.Patch++
+	}
+	return v
+}
+
+// SemverIdentifier returns the given value as a valid pre-release or build metadata identifier, for example
+// feat/my_branch gives feat-my-branch.
+func SemverIdentifier(value string) string {
+	return strings.Trim(semverIdentifierRegexp.ReplaceAllString(value, "-"), "-")
+}
@@ -0,0 +1,29 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConventionalCommitsLevel(t *testing.T) {
+	require.Equal(t, "", ConventionalCommitsLevel(nil))
+	require.Equal(t, SemverLevelPatch, ConventionalCommitsLevel([]string{"fix: a bug", "update README"}))
+	require.Equal(t, SemverLevelMinor, ConventionalCommitsLevel([]string{"fix: a bug", "feat(api): new route"}))
+	require.Equal(t, SemverLevelMajor, ConventionalCommitsLevel([]string{"feat: new route", "refactor!: drop old route"}))
+	require.Equal(t, SemverLevelMajor, ConventionalCommitsLevel([]string{"fix: a bug\n\nBREAKING CHANGE: the route has moved"}))
+}
+
+func TestSemverBump(t *testing.T) {
+	v := semver.MustParse("1.2.3-beta+cds.1")
+	require.Equal(t, "2.0.0", SemverBump(v, SemverLevelMajor).String())
+	require.Equal(t, "1.3.0", SemverBump(v, SemverLevelMinor).String())
+	require.Equal(t, "1.2.4", SemverBump(v, SemverLevelPatch).String())
+	require.Equal(t, "1.2.3", SemverBump(v, "").String())
+}
+
+func TestSemverIdentifier(t *testing.T) {
+	require.Equal(t, "feat-my-branch", SemverIdentifier("feat/my_branch"))
+	require.Equal(t, "release-1-0", SemverIdentifier("/release/1.0/"))
+}
@@ -99,3 +99,13 @@ type CDNConfig struct {
 	TCPURLEnableTLS bool   `json:"tcp_url_enable_tls"`
 	HTTPURL         string `json:"http_url"`
 }
+
+// RepositoriesConfig contains the public url of the git mirrors served by the repositories service, empty if disabled.
+type RepositoriesConfig struct {
+	MirrorURL string `json:"mirror_url"`
+}
+
+// GitMirrorAccessToken is a signed list of the repositories that a job can read from the git mirrors.
+type GitMirrorAccessToken struct {
+	Token string `json:"token"`
+}
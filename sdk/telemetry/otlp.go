@@ -0,0 +1,258 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rockbears/log"
+	"go.opencensus.io/trace"
+)
+
+var _ trace.Exporter = new(OTLPExporter)
+
+// OTLPOptions contains the options of an OTLPExporter.
+type OTLPOptions struct {
+	// Endpoint is the base URL of the OTLP/HTTP receiver, ex: http://localhost:4318
+	Endpoint    string
+	ServiceName string
+	Headers     map[string]string
+	// BatchSize is the maximum number of spans sent in one request
+	BatchSize int
+	// FlushInterval is the maximum delay before sending buffered spans
+	FlushInterval time.Duration
+	HTTPClient    *http.Client
+}
+
+// OTLPExporter exports OpenCensus spans to an OpenTelemetry collector with the OTLP/HTTP protocol using JSON encoding.
+type OTLPExporter struct {
+	opts    OTLPOptions
+	mutex   sync.Mutex
+	buffer  []*trace.SpanData
+	flushCh chan struct{}
+}
+
+// NewOTLPExporter returns an exporter that sends spans in background until given context is done.
+func NewOTLPExporter(ctx context.Context, opts OTLPOptions) (*OTLPExporter, error) {
+	if opts.Endpoint == "" {
+		return nil, errors.New("missing OTLP endpoint")
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 512
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = 5 * time.Second
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	e := &OTLPExporter{
+		opts:    opts,
+		flushCh: make(chan struct{}, 1),
+	}
+	go e.run(ctx)
+	return e, nil
+}
+
+// ExportSpan buffers the span, it will be sent with the next batch.
+func (e *OTLPExporter) ExportSpan(s *trace.SpanData) {
+	e.mutex.Lock()
+	e.buffer = append(e.buffer, s)
+	full := len(e.buffer) >= e.opts.BatchSize
+	e.mutex.Unlock()
+	if full {
+		select {
+		case e.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (e *OTLPExporter) run(ctx context.Context) {
+	ticker := time.NewTicker(e.opts.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// Send remaining spans with a fresh context
+			if err := e.Flush(context.Background()); err != nil {
+				log.Error(ctx, "otlp exporter: %v", err)
+			}
+			return
+		case <-ticker.C:
+		case <-e.flushCh:
+		}
+		if err := e.Flush(ctx); err != nil {
+			log.Error(ctx, "otlp exporter: %v", err)
+		}
+	}
+}
+
+// Flush sends all the buffered spans.
+func (e *OTLPExporter) Flush(ctx context.Context) error {
+	e.mutex.Lock()
+	spans := e.buffer
+	e.buffer = nil
+	e.mutex.Unlock()
+
+	for len(spans) > 0 {
+		n := e.opts.BatchSize
+		if n > len(spans) {
+			n = len(spans)
+		}
+		if err := e.send(ctx, spans[:n]); err != nil {
+			return err
+		}
+		spans = spans[n:]
+	}
+	return nil
+}
+
+func (e *OTLPExporter) send(ctx context.Context, spans []*trace.SpanData) error {
+	btes, err := json.Marshal(newOTLPTracesRequest(e.opts.ServiceName, spans))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(e.opts.Endpoint, "/")+"/v1/traces", bytes.NewReader(btes))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.opts.HTTPClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "unable to send %d spans", len(spans))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return errors.Errorf("unable to send %d spans: collector returned status %d", len(spans), resp.StatusCode)
+	}
+	return nil
+}
+
+// Types below are the JSON representation of the OTLP ExportTraceServiceRequest.
+// See https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/trace/v1/trace.proto
+
+type otlpTracesRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+func newOTLPTracesRequest(serviceName string, spans []*trace.SpanData) otlpTracesRequest {
+	res := otlpResourceSpans{
+		Resource: otlpResource{
+			Attributes: []otlpKeyValue{newOTLPKeyValue("service.name", serviceName)},
+		},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: "github.com/ovh/cds"}}},
+	}
+	for _, s := range spans {
+		res.ScopeSpans[0].Spans = append(res.ScopeSpans[0].Spans, newOTLPSpan(s))
+	}
+	return otlpTracesRequest{ResourceSpans: []otlpResourceSpans{res}}
+}
+
+func newOTLPSpan(s *trace.SpanData) otlpSpan {
+	span := otlpSpan{
+		TraceID:           hex.EncodeToString(s.TraceID[:]),
+		SpanID:            hex.EncodeToString(s.SpanID[:]),
+		Name:              s.Name,
+		StartTimeUnixNano: strconv.FormatInt(s.StartTime.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.EndTime.UnixNano(), 10),
+		// OTLP status codes: 1 is OK, 2 is ERROR
+		Status: otlpStatus{Code: 1},
+	}
+	if s.ParentSpanID != (trace.SpanID{}) {
+		span.ParentSpanID = hex.EncodeToString(s.ParentSpanID[:])
+	}
+	// OTLP span kinds: 1 is INTERNAL, 2 is SERVER, 3 is CLIENT
+	switch s.SpanKind {
+	case trace.SpanKindServer:
+		span.Kind = 2
+	case trace.SpanKindClient:
+		span.Kind = 3
+	default:
+		span.Kind = 1
+	}
+	if s.Status.Code != trace.StatusCodeOK {
+		span.Status = otlpStatus{Code: 2, Message: s.Status.Message}
+	}
+	for k, v := range s.Attributes {
+		span.Attributes = append(span.Attributes, newOTLPKeyValue(k, v))
+	}
+	return span
+}
+
+func newOTLPKeyValue(k string, v interface{}) otlpKeyValue {
+	kv := otlpKeyValue{Key: k}
+	switch x := v.(type) {
+	case bool:
+		kv.Value.BoolValue = &x
+	case int64:
+		i := strconv.FormatInt(x, 10)
+		kv.Value.IntValue = &i
+	case float64:
+		kv.Value.DoubleValue = &x
+	default:
+		s := fmt.Sprintf("%v", x)
+		kv.Value.StringValue = &s
+	}
+	return kv
+}
@@ -0,0 +1,86 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+)
+
+func TestOTLPExporter(t *testing.T) {
+	var received []otlpTracesRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/traces", r.URL.Path)
+		require.Equal(t, "secret", r.Header.Get("X-Token"))
+		var req otlpTracesRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		received = append(received, req)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	e, err := NewOTLPExporter(ctx, OTLPOptions{
+		Endpoint:      srv.URL,
+		ServiceName:   "cds-api",
+		Headers:       map[string]string{"X-Token": "secret"},
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+	})
+	require.NoError(t, err)
+
+	traceID := NewTraceID()
+	runSpanID := WorkflowSpanID(traceID, WorkflowSpanRun, 1)
+	jobSpanID := WorkflowSpanID(traceID, WorkflowSpanJob, 42)
+	require.Equal(t, runSpanID, WorkflowSpanID(traceID, WorkflowSpanRun, 1))
+	require.NotEqual(t, runSpanID, jobSpanID)
+
+	start := time.Now().Add(-time.Minute)
+	e.ExportSpan(WorkflowSpanData(traceID, runSpanID, trace.SpanID{}, "my-workflow", start, time.Now(), false, map[string]interface{}{"project_key": "PROJ"}))
+	e.ExportSpan(WorkflowSpanData(traceID, jobSpanID, runSpanID, "my-job", start, time.Now(), true, map[string]interface{}{"job_run_id": int64(42)}))
+	e.ExportSpan(WorkflowSpanData(traceID, WorkflowSpanID(traceID, WorkflowSpanStep, 42, 0), jobSpanID, "step", start, time.Now(), false, nil))
+	require.NoError(t, e.Flush(ctx))
+
+	// Spans are sent by batches of 2
+	require.Len(t, received, 2)
+	spans := append(received[0].ResourceSpans[0].ScopeSpans[0].Spans, received[1].ResourceSpans[0].ScopeSpans[0].Spans...)
+	require.Len(t, spans, 3)
+	require.Equal(t, "cds-api", *received[0].ResourceSpans[0].Resource.Attributes[0].Value.StringValue)
+
+	require.Equal(t, traceID.String(), spans[0].TraceID)
+	require.Equal(t, runSpanID.String(), spans[0].SpanID)
+	require.Empty(t, spans[0].ParentSpanID)
+	require.Equal(t, 1, spans[0].Status.Code)
+	require.Equal(t, "PROJ", *spans[0].Attributes[0].Value.StringValue)
+
+	require.Equal(t, runSpanID.String(), spans[1].ParentSpanID)
+	require.Equal(t, 2, spans[1].Status.Code)
+	require.Equal(t, "42", *spans[1].Attributes[0].Value.IntValue)
+}
+
+func TestTraceParent(t *testing.T) {
+	traceID := NewTraceID()
+	spanID := WorkflowSpanID(traceID, WorkflowSpanJob, 1)
+
+	tp := TraceParent(traceID, spanID)
+	require.Len(t, tp, 55)
+
+	tid, sid, ok := ParseTraceParent(tp)
+	require.True(t, ok)
+	require.Equal(t, traceID, tid)
+	require.Equal(t, spanID, sid)
+
+	_, _, ok = ParseTraceParent("invalid")
+	require.False(t, ok)
+
+	tid, ok = TraceIDFromHeaders(map[string]string{SampledHeader: "1", TraceIDHeader: traceID.String()})
+	require.True(t, ok)
+	require.Equal(t, traceID, tid)
+	_, ok = TraceIDFromHeaders(map[string]string{TraceIDHeader: traceID.String()})
+	require.False(t, ok)
+}
@@ -36,6 +36,15 @@ func TraceExporter(ctx context.Context) trace.Exporter {
 	return nil
 }
 
+// multiExporter forwards spans to all the configured trace exporters.
+type multiExporter []trace.Exporter
+
+func (m multiExporter) ExportSpan(s *trace.SpanData) {
+	for _, e := range m {
+		e.ExportSpan(s)
+	}
+}
+
 func ContextWithTelemetry(from, to context.Context) context.Context {
 	se := StatsExporter(from)
 	te := TraceExporter(from)
@@ -61,20 +70,48 @@ func Init(ctx context.Context, cfg Configuration, s Service) (context.Context, e
 				DefaultSampler: trace.ProbabilitySampler(cfg.Exporters.Jaeger.SamplingProbability),
 			},
 		)
-		var svcName = cfg.Exporters.Jaeger.ServiceName
-		if svcName == "" {
-			svcName = serviceName(s)
+		var exporters multiExporter
+		if cfg.Exporters.Jaeger.CollectorEndpoint != "" {
+			var svcName = cfg.Exporters.Jaeger.ServiceName
+			if svcName == "" {
+				svcName = serviceName(s)
+			}
+			log.Info(ctx, "observability> initializing jaeger exporter for %q on %q", svcName, cfg.Exporters.Jaeger.CollectorEndpoint)
+			e, err := jaeger.NewExporter(jaeger.Options{
+				CollectorEndpoint: cfg.Exporters.Jaeger.CollectorEndpoint,
+				ServiceName:       svcName,
+			})
+			if err != nil {
+				return ctx, errors.WithStack(err)
+			}
+			exporters = append(exporters, e)
 		}
-		log.Info(ctx, "observability> initializing jaeger exporter for %q on %q", svcName, cfg.Exporters.Jaeger.CollectorEndpoint)
-		e, err := jaeger.NewExporter(jaeger.Options{
-			CollectorEndpoint: cfg.Exporters.Jaeger.CollectorEndpoint,
-			ServiceName:       svcName,
-		})
-		if err != nil {
-			return ctx, errors.WithStack(err)
+		if cfg.Exporters.OTLP.Endpoint != "" {
+			var svcName = cfg.Exporters.OTLP.ServiceName
+			if svcName == "" {
+				svcName = serviceName(s)
+			}
+			log.Info(ctx, "observability> initializing otlp exporter for %q on %q", svcName, cfg.Exporters.OTLP.Endpoint)
+			e, err := NewOTLPExporter(ctx, OTLPOptions{
+				Endpoint:    cfg.Exporters.OTLP.Endpoint,
+				ServiceName: svcName,
+				Headers:     cfg.Exporters.OTLP.Headers,
+			})
+			if err != nil {
+				return ctx, err
+			}
+			exporters = append(exporters, e)
+		}
+		for _, e := range exporters {
+			trace.RegisterExporter(e)
+		}
+		switch len(exporters) {
+		case 0:
+		case 1:
+			ctx = context.WithValue(ctx, contextTraceExporter, exporters[0])
+		default:
+			ctx = context.WithValue(ctx, contextTraceExporter, exporters)
 		}
-		trace.RegisterExporter(e)
-		ctx = context.WithValue(ctx, contextTraceExporter, e)
 	}
 
 	if cfg.Exporters.Prometheus.ReporteringPeriod == 0 {
@@ -108,13 +145,17 @@ const (
 	TagPipeline           = "pipeline"
 	TagPipelineDeep       = "pipeline_deep"
 	TagPipelineID         = "pipeline_id"
+	TagPriority           = "priority"
 	TagProjectKey         = "project_key"
+	TagRegion             = "region"
 	TagServiceName        = "service_name"
 	TagServiceType        = "service_type"
 	TagStatus             = "status"
 	TagStorage            = "storage"
 	TagType               = "type"
+	TagVCSServer          = "vcs_server"
 	TagWorker             = "worker"
+	TagWorkerModel        = "worker_model"
 	TagWorkflow           = "workflow"
 	TagWorkflowNode       = "workflow_node"
 	TagWorkflowNodeJobRun = "workflow_node_job_run"
@@ -31,6 +31,11 @@ type Configuration struct {
 			CollectorEndpoint   string  `toml:"collectorEndpoint" default:"http://localhost:14268/api/traces" json:"collectorEndpoint"`
 			SamplingProbability float64 `toml:"samplingProbability" json:"metricSamplingProbability"`
 		} `json:"jaeger"`
+		OTLP struct {
+			ServiceName string            `toml:"serviceName" default:"" json:"serviceName"`
+			Endpoint    string            `toml:"endpoint" default:"" json:"endpoint"`
+			Headers     map[string]string `toml:"headers" json:"headers,omitempty"`
+		} `json:"otlp"`
 		Prometheus struct {
 			ReporteringPeriod int `toml:"ReporteringPeriod" default:"10" json:"reporteringPeriod"`
 		} `json:"prometheus"`
@@ -42,6 +47,8 @@ var (
 	DefaultSizeDistribution = view.Distribution(25*1024, 100*1024, 250*1024, 500*1024, 1024*1024, 1.5*1024*1024, 5*1024*1024, 10*1024*1024)
 	// DefaultLatencyDistribution 100ms, ...
 	DefaultLatencyDistribution = view.Distribution(100, 200, 300, 400, 500, 750, 1000, 2000, 5000)
+	// DefaultLongDurationDistribution 1s, 5s, ..., 4h
+	DefaultLongDurationDistribution = view.Distribution(1, 5, 10, 30, 60, 120, 300, 600, 900, 1800, 3600, 7200, 14400)
 )
 
 const (
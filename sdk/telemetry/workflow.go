@@ -0,0 +1,109 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opencensus.io/trace"
+)
+
+// Span kinds of a workflow run trace. A workflow run is one trace, its node runs, jobs and steps are child spans.
+const (
+	WorkflowSpanRun      = "workflow.run"
+	WorkflowSpanNodeRun  = "workflow.node_run"
+	WorkflowSpanJobQueue = "workflow.job_queue"
+	WorkflowSpanJob      = "workflow.job"
+	WorkflowSpanStep     = "workflow.step"
+	WorkflowSpanSpawn    = "hatchery.spawn"
+	WorkflowSpanRegister = "worker.register"
+)
+
+// TraceParentEnv is the environment variable containing the W3C trace context given to job steps.
+const TraceParentEnv = "TRACEPARENT"
+
+// NewTraceID returns a random trace ID.
+func NewTraceID() trace.TraceID {
+	var tid trace.TraceID
+	_, _ = rand.Read(tid[:])
+	return tid
+}
+
+// WorkflowSpanID computes the span ID for given span kind and identifiers. Span IDs of a workflow run trace are
+// deterministic so that any service (api, hatchery, worker) can attach a child span without sharing state.
+func WorkflowSpanID(traceID trace.TraceID, kind string, ids ...int64) trace.SpanID {
+	h := sha256.New()
+	h.Write(traceID[:])
+	h.Write([]byte(kind))
+	for _, id := range ids {
+		_ = binary.Write(h, binary.BigEndian, id)
+	}
+	var sid trace.SpanID
+	copy(sid[:], h.Sum(nil))
+	return sid
+}
+
+// WorkflowSpanData returns the data of a span that was measured afterwards, i.e. from timestamps stored in database.
+func WorkflowSpanData(traceID trace.TraceID, spanID, parentSpanID trace.SpanID, name string, start, end time.Time, failed bool, attributes map[string]interface{}) *trace.SpanData {
+	s := &trace.SpanData{
+		SpanContext: trace.SpanContext{
+			TraceID:      traceID,
+			SpanID:       spanID,
+			TraceOptions: trace.TraceOptions(1),
+		},
+		ParentSpanID: parentSpanID,
+		SpanKind:     trace.SpanKindServer,
+		Name:         name,
+		StartTime:    start,
+		EndTime:      end,
+		Attributes:   attributes,
+	}
+	if failed {
+		s.Status = trace.Status{Code: trace.StatusCodeUnknown, Message: "failed"}
+	}
+	return s
+}
+
+// ExportSpan sends given span to the trace exporter of the context, if any.
+func ExportSpan(ctx context.Context, s *trace.SpanData) {
+	exp := TraceExporter(ctx)
+	if exp == nil || s == nil {
+		return
+	}
+	exp.ExportSpan(s)
+}
+
+// TraceParent formats a W3C traceparent value, ex: 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01.
+func TraceParent(traceID trace.TraceID, spanID trace.SpanID) string {
+	return fmt.Sprintf("00-%s-%s-01", hex.EncodeToString(traceID[:]), hex.EncodeToString(spanID[:]))
+}
+
+// ParseTraceParent parses a W3C traceparent value.
+func ParseTraceParent(s string) (trace.TraceID, trace.SpanID, bool) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return trace.TraceID{}, trace.SpanID{}, false
+	}
+	traceID, ok := ParseTraceID(parts[1])
+	if !ok {
+		return trace.TraceID{}, trace.SpanID{}, false
+	}
+	spanID, ok := ParseSpanID(parts[2])
+	if !ok {
+		return trace.TraceID{}, trace.SpanID{}, false
+	}
+	return traceID, spanID, true
+}
+
+// TraceIDFromHeaders returns the trace ID stored in workflow run headers if the run is sampled.
+func TraceIDFromHeaders(headers map[string]string) (trace.TraceID, bool) {
+	if headers[SampledHeader] != "1" {
+		return trace.TraceID{}, false
+	}
+	return ParseTraceID(headers[TraceIDHeader])
+}
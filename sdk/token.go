@@ -121,6 +121,7 @@ const (
 	AuthConsumerScopeWorkerModel  AuthConsumerScope = "WorkerModel"
 	AuthConsumerScopeHatchery     AuthConsumerScope = "Hatchery"
 	AuthConsumerScopeService      AuthConsumerScope = "Service"
+	AuthConsumerScopeSCIM         AuthConsumerScope = "SCIM"
 )
 
 // AuthConsumerScopes list.
@@ -138,6 +139,7 @@ var AuthConsumerScopes = []AuthConsumerScope{
 	AuthConsumerScopeWorkerModel,
 	AuthConsumerScopeHatchery,
 	AuthConsumerScopeService,
+	AuthConsumerScopeSCIM,
 }
 
 func NewAuthConsumerScopeDetails(scopes ...AuthConsumerScope) AuthConsumerScopeDetails {
@@ -171,14 +173,14 @@ func (s AuthConsumerScopeDetails) IsValid() error {
 			}
 			mRoute[endpoint.Route] = struct{}{}
 
-			// Check that each method is unique for scope and match GET, POST, PUT or DELETE
+			// Check that each method is unique for scope and match GET, POST, PUT, PATCH or DELETE
 			mMethod := map[string]struct{}{}
 			for _, method := range endpoint.Methods {
 				if _, ok := mMethod[method]; ok {
 					return NewErrorFrom(ErrWrongRequest, "duplicated method %s for route %s and scope %s in given details", method, endpoint.Route, detail.Scope)
 				}
 				mMethod[method] = struct{}{}
-				if !(method == http.MethodGet || method == http.MethodPost || method == http.MethodPut || method == http.MethodDelete) {
+				if !(method == http.MethodGet || method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch || method == http.MethodDelete) {
 					return NewErrorFrom(ErrWrongRequest, "invalid method %s for route %s and scope %s in given details", method, endpoint.Route, detail.Scope)
 				}
 			}
@@ -345,6 +347,7 @@ const (
 	WarningGroupInvalid     AuthConsumerWarningType = "group-invalid"
 	WarningGroupRemoved     AuthConsumerWarningType = "group-removed"
 	WarningLastGroupRemoved AuthConsumerWarningType = "last-group-removed"
+	WarningUserDeactivated  AuthConsumerWarningType = "user-deactivated"
 )
 
 // AuthConsumerWarnings contains specific information from the auth driver.
@@ -373,6 +376,21 @@ func NewConsumerWarningLastGroupRemoved() AuthConsumerWarning {
 	return AuthConsumerWarning{Type: WarningLastGroupRemoved}
 }
 
+// NewConsumerWarningUserDeactivated returns a new warning.
+func NewConsumerWarningUserDeactivated() AuthConsumerWarning {
+	return AuthConsumerWarning{Type: WarningUserDeactivated}
+}
+
+// Contains returns true if a warning exists for given type.
+func (w AuthConsumerWarnings) Contains(t AuthConsumerWarningType) bool {
+	for i := range w {
+		if w[i].Type == t {
+			return true
+		}
+	}
+	return false
+}
+
 // AuthConsumerWarning contains info about a warning.
 type AuthConsumerWarning struct {
 	Type      AuthConsumerWarningType `json:"type"`
@@ -437,6 +455,7 @@ type AuthUserConsumerData struct {
 	ServiceType                  *string                  `json:"service_type,omitempty" db:"service_type"`
 	ServiceRegion                *string                  `json:"service_region,omitempty" db:"service_region"`
 	ServiceIgnoreJobWithNoRegion *bool                    `json:"service_ignore_job_with_no_region,omitempty" db:"service_ignore_job_with_no_region"`
+	Restrictions                 AuthConsumerRestrictions `json:"restrictions" cli:"-" db:"restrictions"`
 	// aggregates
 	AuthentifiedUser *AuthentifiedUser `json:"user,omitempty" db:"-"`
 	Groups           Groups            `json:"groups,omitempty" db:"-"`
@@ -506,6 +525,9 @@ func (c AuthUserConsumer) IsValid(scopeDetails AuthConsumerScopeDetails) error {
 	if err := c.AuthConsumerUser.ScopeDetails.IsValid(); err != nil {
 		return err
 	}
+	if err := c.AuthConsumerUser.Restrictions.IsValid(); err != nil {
+		return err
+	}
 
 	mEndpoints := scopeDetails.ToEndpointsMap()
 
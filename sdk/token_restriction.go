@@ -0,0 +1,197 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net"
+	"path"
+	"strings"
+)
+
+// AuthConsumerRestrictions limits what a consumer can do in addition to its scopes and its user's permissions.
+// A consumer restricted to projects can only read non project routes.
+type AuthConsumerRestrictions struct {
+	Projects     []AuthConsumerProjectRestriction `json:"projects,omitempty"`
+	AllowedCIDRs []string                         `json:"allowed_cidrs,omitempty"`
+}
+
+// AuthConsumerProjectRestriction allows a consumer on a project, optionally only on some workflows (patterns like
+// deploy-* are allowed). Read only consumers can't write anything, run only consumers can only trigger and manage runs.
+type AuthConsumerProjectRestriction struct {
+	ProjectKey string   `json:"project_key"`
+	Workflows  []string `json:"workflows,omitempty"`
+	ReadOnly   bool     `json:"read_only,omitempty"`
+	RunOnly    bool     `json:"run_only,omitempty"`
+}
+
+// Value returns driver.Value from AuthConsumerRestrictions.
+func (r AuthConsumerRestrictions) Value() (driver.Value, error) {
+	j, err := json.Marshal(r)
+	return j, WrapError(err, "cannot marshal AuthConsumerRestrictions")
+}
+
+// Scan AuthConsumerRestrictions.
+func (r *AuthConsumerRestrictions) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(fmt.Errorf("type assertion .([]byte) failed (%T)", src))
+	}
+	return WrapError(JSONUnmarshal(source, r), "cannot unmarshal AuthConsumerRestrictions")
+}
+
+// IsValid returns an error if a restriction is invalid.
+func (r AuthConsumerRestrictions) IsValid() error {
+	for _, c := range r.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(c); err != nil {
+			return NewErrorFrom(ErrWrongRequest, "invalid given CIDR %q", c)
+		}
+	}
+	keys := make(map[string]struct{}, len(r.Projects))
+	for _, p := range r.Projects {
+		if p.ProjectKey == "" {
+			return NewErrorFrom(ErrWrongRequest, "missing project key in consumer restrictions")
+		}
+		if _, has := keys[p.ProjectKey]; has {
+			return NewErrorFrom(ErrWrongRequest, "duplicated project %s in consumer restrictions", p.ProjectKey)
+		}
+		keys[p.ProjectKey] = struct{}{}
+		if p.ReadOnly && p.RunOnly {
+			return NewErrorFrom(ErrWrongRequest, "project %s restriction can't be both read only and run only", p.ProjectKey)
+		}
+		for _, w := range p.Workflows {
+			if _, err := path.Match(w, ""); err != nil {
+				return NewErrorFrom(ErrWrongRequest, "invalid workflow pattern %q for project %s", w, p.ProjectKey)
+			}
+		}
+	}
+	return nil
+}
+
+// IsRestricted returns true if the consumer is restricted to some projects.
+func (r AuthConsumerRestrictions) IsRestricted() bool {
+	return len(r.Projects) > 0
+}
+
+// ProjectRestriction returns the restriction for given project, nil if the consumer is not allowed on it.
+func (r AuthConsumerRestrictions) ProjectRestriction(projectKey string) *AuthConsumerProjectRestriction {
+	for i := range r.Projects {
+		if r.Projects[i].ProjectKey == projectKey {
+			return &r.Projects[i]
+		}
+	}
+	return nil
+}
+
+// IsIPAllowed returns true if given address is in allowed CIDRs or if there is no CIDR restriction.
+// The address can contain a port.
+func (r AuthConsumerRestrictions) IsIPAllowed(address string) bool {
+	if len(r.AllowedCIDRs) == 0 {
+		return true
+	}
+	ip := parseIPAddress(address)
+	if ip == nil {
+		return false
+	}
+	return ipInCIDRs(ip, r.AllowedCIDRs)
+}
+
+// RequestClientIP returns the address of the client from the remote address of the request and its forwarded for
+// header value. The header is only used if the remote address is a trusted proxy, the rightmost address that is not a
+// trusted proxy is returned as it is the last one that was added by a trusted proxy.
+func RequestClientIP(remoteAddr, forwardedFor string, trustedProxies []string) string {
+	address := remoteAddr
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		address = host
+	}
+	if forwardedFor == "" || !isTrustedProxy(address, trustedProxies) {
+		return address
+	}
+	entries := strings.Split(forwardedFor, ",")
+	for i := len(entries) - 1; i >= 0; i-- {
+		address = strings.TrimSpace(entries[i])
+		if !isTrustedProxy(address, trustedProxies) {
+			break
+		}
+	}
+	return address
+}
+
+func isTrustedProxy(address string, trustedProxies []string) bool {
+	ip := parseIPAddress(address)
+	return ip != nil && ipInCIDRs(ip, trustedProxies)
+}
+
+func parseIPAddress(address string) net.IP {
+	address = strings.TrimSpace(address)
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+	return net.ParseIP(address)
+}
+
+func ipInCIDRs(ip net.IP, cidrs []string) bool {
+	for _, c := range cidrs {
+		_, ipNet, err := net.ParseCIDR(c)
+		if err == nil && ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSubsetOf returns true if r doesn't allow anything more than given restrictions.
+func (r AuthConsumerRestrictions) IsSubsetOf(parent AuthConsumerRestrictions) bool {
+	if len(parent.AllowedCIDRs) > 0 {
+		if len(r.AllowedCIDRs) == 0 {
+			return false
+		}
+		for _, c := range r.AllowedCIDRs {
+			if !IsInArray(c, parent.AllowedCIDRs) {
+				return false
+			}
+		}
+	}
+	if !parent.IsRestricted() {
+		return true
+	}
+	if !r.IsRestricted() {
+		return false
+	}
+	for _, p := range r.Projects {
+		pp := parent.ProjectRestriction(p.ProjectKey)
+		if pp == nil {
+			return false
+		}
+		if (pp.ReadOnly && !p.ReadOnly) || (pp.RunOnly && !p.RunOnly && !p.ReadOnly) {
+			return false
+		}
+		if len(pp.Workflows) > 0 {
+			if len(p.Workflows) == 0 {
+				return false
+			}
+			for _, w := range p.Workflows {
+				if !IsInArray(w, pp.Workflows) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// MatchWorkflow returns true if the restriction allows given workflow.
+func (p AuthConsumerProjectRestriction) MatchWorkflow(workflowName string) bool {
+	if len(p.Workflows) == 0 {
+		return true
+	}
+	for _, w := range p.Workflows {
+		if ok, _ := path.Match(w, workflowName); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package sdk_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestAuthConsumerRestrictionsIsValid(t *testing.T) {
+	require.NoError(t, sdk.AuthConsumerRestrictions{}.IsValid())
+	require.NoError(t, sdk.AuthConsumerRestrictions{
+		AllowedCIDRs: []string{"10.0.0.0/8", "2001:db8::/32"},
+		Projects:     []sdk.AuthConsumerProjectRestriction{{ProjectKey: "PROJ", Workflows: []string{"deploy-*"}}},
+	}.IsValid())
+	require.Error(t, sdk.AuthConsumerRestrictions{AllowedCIDRs: []string{"10.0.0.1"}}.IsValid())
+	require.Error(t, sdk.AuthConsumerRestrictions{Projects: []sdk.AuthConsumerProjectRestriction{{ProjectKey: "PROJ", ReadOnly: true, RunOnly: true}}}.IsValid())
+	require.Error(t, sdk.AuthConsumerRestrictions{Projects: []sdk.AuthConsumerProjectRestriction{{ProjectKey: "PROJ"}, {ProjectKey: "PROJ"}}}.IsValid())
+}
+
+func TestAuthConsumerRestrictionsIsIPAllowed(t *testing.T) {
+	r := sdk.AuthConsumerRestrictions{AllowedCIDRs: []string{"10.0.0.0/8"}}
+	require.True(t, r.IsIPAllowed("10.1.2.3"))
+	require.True(t, r.IsIPAllowed("10.1.2.3:1234"))
+	require.False(t, r.IsIPAllowed("10.1.2.3, 192.168.1.1"))
+	require.False(t, r.IsIPAllowed("192.168.1.1"))
+	require.False(t, r.IsIPAllowed(""))
+	require.True(t, sdk.AuthConsumerRestrictions{}.IsIPAllowed(""))
+}
+
+func TestRequestClientIP(t *testing.T) {
+	proxies := []string{"172.16.0.0/12"}
+	require.Equal(t, "192.168.1.1", sdk.RequestClientIP("192.168.1.1:1234", "", proxies))
+	// The header is ignored if the request doesn't come from a trusted proxy
+	require.Equal(t, "192.168.1.1", sdk.RequestClientIP("192.168.1.1:1234", "10.1.2.3", proxies))
+	require.Equal(t, "192.168.1.1", sdk.RequestClientIP("192.168.1.1:1234", "10.1.2.3", nil))
+	// The rightmost address that is not a trusted proxy is used
+	require.Equal(t, "10.1.2.3", sdk.RequestClientIP("172.16.0.1:1234", "10.1.2.3", proxies))
+	require.Equal(t, "10.1.2.3", sdk.RequestClientIP("172.16.0.1:1234", "10.1.2.3, 172.16.0.2", proxies))
+
+	// A client can prepend a spoofed address to the header, it should not be used
+	r := sdk.AuthConsumerRestrictions{AllowedCIDRs: []string{"10.0.0.0/8"}}
+	ip := sdk.RequestClientIP("172.16.0.1:1234", "10.1.2.3, 192.168.1.1", proxies)
+	require.Equal(t, "192.168.1.1", ip)
+	require.False(t, r.IsIPAllowed(ip))
+}
+
+func TestAuthConsumerRestrictionsIsSubsetOf(t *testing.T) {
+	parent := sdk.AuthConsumerRestrictions{
+		Projects: []sdk.AuthConsumerProjectRestriction{{ProjectKey: "PROJ", Workflows: []string{"deploy"}, RunOnly: true}},
+	}
+	require.True(t, sdk.AuthConsumerRestrictions{}.IsSubsetOf(sdk.AuthConsumerRestrictions{}))
+	require.True(t, parent.IsSubsetOf(sdk.AuthConsumerRestrictions{}))
+	require.True(t, parent.IsSubsetOf(parent))
+	require.False(t, sdk.AuthConsumerRestrictions{}.IsSubsetOf(parent))
+	require.False(t, sdk.AuthConsumerRestrictions{Projects: []sdk.AuthConsumerProjectRestriction{{ProjectKey: "PROJ", RunOnly: true}}}.IsSubsetOf(parent))
+	require.False(t, sdk.AuthConsumerRestrictions{Projects: []sdk.AuthConsumerProjectRestriction{{ProjectKey: "PROJ", Workflows: []string{"deploy"}}}}.IsSubsetOf(parent))
+	require.True(t, sdk.AuthConsumerRestrictions{Projects: []sdk.AuthConsumerProjectRestriction{{ProjectKey: "PROJ", Workflows: []string{"deploy"}, ReadOnly: true}}}.IsSubsetOf(parent))
+	require.False(t, sdk.AuthConsumerRestrictions{Projects: []sdk.AuthConsumerProjectRestriction{{ProjectKey: "OTHER"}}}.IsSubsetOf(parent))
+}
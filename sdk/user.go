@@ -158,6 +158,20 @@ type UserContact struct {
 
 const UserContactTypeEmail = "email"
 
+// UserContactTypeVCS returns the type of the contacts that link a user to its username on a repository manager.
+func UserContactTypeVCS(vcsServer string) string {
+	return "vcs:" + vcsServer
+}
+
+// UserContactVerification is returned when a user asks to link its username on a repository manager. The contact is
+// added when the command is posted in a pull request comment by this username, before the expiration date.
+type UserContactVerification struct {
+	Type     string    `json:"type"`
+	Value    string    `json:"value"`
+	Command  string    `json:"command"`
+	ExpireAt time.Time `json:"expire_at"`
+}
+
 type UserContacts []UserContact
 
 func (u UserContacts) Filter(t string) UserContacts {
@@ -133,6 +133,7 @@ const (
 	RepositoryVariable = "repository"
 	SSHKeyVariable     = "ssh"
 	PGPKeyVariable     = "pgp"
+	SecretRefVariable  = "secret-ref"
 )
 
 var (
@@ -190,6 +191,30 @@ func NeedPlaceholder(t string) bool {
 	}
 }
 
+// SecretRef is the value of a secret-ref variable: <integration>:<path>#<key>.
+type SecretRef struct {
+	Integration string
+	Path        string
+	Key         string
+}
+
+// ParseSecretRef parses a secret-ref variable value, ex: my-vault:app/prod#db_password.
+func ParseSecretRef(value string) (SecretRef, error) {
+	var ref SecretRef
+	i := strings.Index(value, ":")
+	j := strings.LastIndex(value, "#")
+	if i <= 0 || j <= i+1 || j == len(value)-1 {
+		return ref, NewErrorFrom(ErrWrongRequest, "invalid secret reference %q, expected <integration>:<path>#<key>", value)
+	}
+	ref.Integration = value[:i]
+	ref.Path = strings.Trim(value[i+1:j], "/")
+	ref.Key = value[j+1:]
+	if ref.Path == "" {
+		return ref, NewErrorFrom(ErrWrongRequest, "invalid secret reference %q, missing path", value)
+	}
+	return ref, nil
+}
+
 // VariableFind return a variable given its name if it exists in array
 func VariableFind(vars []Variable, s string) *Variable {
 	for _, v := range vars {
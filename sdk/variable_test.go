@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/ovh/cds/sdk"
 )
 
@@ -70,3 +72,14 @@ func Test_EnvVartoENV(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSecretRef(t *testing.T) {
+	ref, err := sdk.ParseSecretRef("my-vault:/app/prod/#db_password")
+	require.NoError(t, err)
+	require.Equal(t, sdk.SecretRef{Integration: "my-vault", Path: "app/prod", Key: "db_password"}, ref)
+
+	for _, v := range []string{"", "app/prod#key", "my-vault:app/prod", "my-vault:#key", "my-vault:app/prod#"} {
+		_, err := sdk.ParseSecretRef(v)
+		require.Error(t, err, v)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/go-gorp/gorp"
@@ -22,6 +23,7 @@ const (
 	HeaderXVCSSSHUsername   = "X-CDS-VCS-SSH-USERNAME"
 	HeaderXVCSSSHPort       = "X-CDS-VCS-SSH-PORT"
 	HeaderXVCSSSHPrivateKey = "X-CDS-VCS-SSH-PRIVATE-KEY"
+	HeaderXVCSPriority      = "X-CDS-VCS-PRIORITY"
 
 	HeaderXAccessToken        = "X-CDS-ACCESS-TOKEN"         // DEPRECATED
 	HeaderXAccessTokenCreated = "X-CDS-ACCESS-TOKEN-CREATED" // DEPRECATED
@@ -33,6 +35,14 @@ const (
 	VCSTypeBitbucketServer = "bitbucketserver"
 	VCSTypeBitbucketCloud  = "bitbucketcloud"
 	VCSTypeGithub          = "github"
+	VCSTypeGit             = "git"
+)
+
+// Priorities of the calls to the repository managers, interactive calls are served before background ones
+// (polling, repository analysis) when the budget of a repository manager is low.
+const (
+	VCSPriorityInteractive = "interactive"
+	VCSPriorityBackground  = "background"
 )
 
 var (
@@ -233,6 +243,12 @@ type VCSOptionsProject struct {
 }
 
 func (v VCSProject) Lint(prj Project) error {
+	if v.Type == VCSTypeGit {
+		if err := CheckGitServerURL(v.URL); err != nil {
+			return err
+		}
+	}
+
 	// If it's not a gerrit vcs
 	if v.Auth.SSHUsername == "" {
 		if v.Auth.Username == "" {
@@ -261,6 +277,16 @@ func (v VCSProject) Lint(prj Project) error {
 	return nil
 }
 
+// CheckGitServerURL returns an error if the URL of a plain git server doesn't use https, ssh or git protocol. The vcs
+// service runs git on this URL, local paths and other transports are not allowed.
+func CheckGitServerURL(serverURL string) error {
+	u, err := url.Parse(serverURL)
+	if err != nil || u.Host == "" || !IsInArray(u.Scheme, []string{"https", "ssh", "git"}) {
+		return NewErrorFrom(ErrInvalidData, "invalid git server url %q, allowed schemes are https, ssh and git", serverURL)
+	}
+	return nil
+}
+
 func (v VCSOptionsProject) Value() (driver.Value, error) {
 	j, err := json.Marshal(v)
 	return j, WrapError(err, "cannot marshal VCSOptionsProject")
@@ -303,8 +329,9 @@ type VCSAuth struct {
 	Username string
 	Token    string
 
-	SSHUsername string
-	SSHPort     int
+	SSHUsername   string
+	SSHPort       int
+	SSHPrivateKey string
 
 	AccessToken        string // DEPRECATED
 	AccessTokenSecret  string // DEPRECATED
@@ -354,11 +381,13 @@ type VCSAuthorizedClientCommon interface {
 	Commits(ctx context.Context, repo, branch, since, until string) ([]VCSCommit, error)
 	Commit(ctx context.Context, repo, hash string) (VCSCommit, error)
 	CommitsBetweenRefs(ctx context.Context, repo, base, head string) ([]VCSCommit, error)
+	ChangedFiles(ctx context.Context, repo, base, head string) ([]string, error)
 
 	// PullRequests
 	PullRequest(ctx context.Context, repo string, id string) (VCSPullRequest, error)
 	PullRequestComment(ctx context.Context, repo string, c VCSPullRequestCommentRequest) error
 	PullRequestCreate(ctx context.Context, repo string, pr VCSPullRequest) (VCSPullRequest, error)
+	PullRequestMerge(ctx context.Context, repo string, id string, req VCSPullRequestMergeRequest) error
 
 	//Hooks
 	CreateHook(ctx context.Context, repo string, hook *VCSHook) error
@@ -366,6 +395,10 @@ type VCSAuthorizedClientCommon interface {
 	GetHook(ctx context.Context, repo, url string) (VCSHook, error)
 	DeleteHook(ctx context.Context, repo string, hook VCSHook) error
 
+	// Deploy keys
+	CreateDeployKey(ctx context.Context, repo string, key VCSDeployKey) (VCSDeployKey, error)
+	DeleteDeployKey(ctx context.Context, repo string, key VCSDeployKey) error
+
 	//Events
 	GetEvents(ctx context.Context, repo string, dateRef time.Time) ([]interface{}, time.Duration, error)
 	PushEvents(context.Context, string, []interface{}) ([]VCSPushEvent, error)
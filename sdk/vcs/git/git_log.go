@@ -0,0 +1,54 @@
+package git
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// commitSeparator ends each commit message in the output of git log
+const commitSeparator = "\x1e"
+
+// TagsMerged returns the tags reachable from HEAD in the given git directory.
+func TagsMerged(dir string) ([]string, error) {
+	out, err := gitLocalCommand(dir, "tag", "--merged", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(out), nil
+}
+
+// CommitMessages returns the messages of the commits reachable from HEAD but not from the given revision, the whole
+// history if the revision is empty.
+func CommitMessages(dir, from string) ([]string, error) {
+	rev := "HEAD"
+	if from != "" {
+		rev = from + "..HEAD"
+	}
+	out, err := gitLocalCommand(dir, "log", "--format=%B"+commitSeparator, rev)
+	if err != nil {
+		return nil, err
+	}
+	var messages []string
+	for _, m := range strings.Split(out, commitSeparator) {
+		if m = strings.TrimSpace(m); m != "" {
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
+
+func gitLocalCommand(dir string, args ...string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	stdErr := new(bytes.Buffer)
+	stdOut := new(bytes.Buffer)
+	if err := runGitCommandRaw(cmds{{workdir: dir, cmd: "git", args: args}}, &OutputOpts{Stdout: stdOut, Stderr: stdErr}); err != nil {
+		return "", sdk.WrapError(err, "git %s: %s", strings.Join(args, " "), stdErr.String())
+	}
+	return stdOut.String(), nil
+}
@@ -0,0 +1,272 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// mirrorAllowedProtocols are the only transports allowed to reach the remote repository of a mirror, local paths and
+// helpers like ext:: are rejected.
+const mirrorAllowedProtocols = "https:ssh:git"
+
+var mirrorLocks sync.Map
+
+// Mirror is a bare copy of a remote repository on the local filesystem, updated with fetch. Credentials are given to
+// git through the environment and are never written in the mirror configuration.
+type Mirror struct {
+	URL  string
+	Dir  string
+	Auth *AuthOpts
+}
+
+// RemoteRef is a reference of a remote repository, as returned by ls-remote.
+type RemoteRef struct {
+	Name string `json:"name"`
+	Hash string `json:"hash"`
+	// Peeled is the hash of the commit targeted by an annotated tag
+	Peeled string `json:"peeled,omitempty"`
+}
+
+// RemoteRefs contains the references of a remote repository and the branch targeted by its HEAD.
+type RemoteRefs struct {
+	Head string      `json:"head"`
+	Refs []RemoteRef `json:"refs"`
+}
+
+// Branches returns the references under refs/heads.
+func (r RemoteRefs) Branches() []RemoteRef {
+	return r.filter("refs/heads/")
+}
+
+// Tags returns the references under refs/tags.
+func (r RemoteRefs) Tags() []RemoteRef {
+	return r.filter("refs/tags/")
+}
+
+func (r RemoteRefs) filter(prefix string) []RemoteRef {
+	var res []RemoteRef
+	for _, ref := range r.Refs {
+		if strings.HasPrefix(ref.Name, prefix) {
+			res = append(res, ref)
+		}
+	}
+	return res
+}
+
+// LsRemote lists the references of the remote repository.
+func (m Mirror) LsRemote(ctx context.Context) (RemoteRefs, error) {
+	var res RemoteRefs
+	var out bytes.Buffer
+	if err := m.run(ctx, "", nil, &out, nil, "ls-remote", "--symref", "--", m.URL); err != nil {
+		return res, err
+	}
+
+	peeled := make(map[string]string)
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		t := strings.SplitN(scanner.Text(), "\t", 2)
+		if len(t) != 2 {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(t[0], "ref: ") && t[1] == "HEAD":
+			res.Head = strings.TrimPrefix(strings.TrimPrefix(t[0], "ref: "), "refs/heads/")
+		case t[1] == "HEAD":
+		case strings.HasSuffix(t[1], "^{}"):
+			peeled[strings.TrimSuffix(t[1], "^{}")] = t[0]
+		default:
+			res.Refs = append(res.Refs, RemoteRef{Name: t[1], Hash: t[0]})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return res, sdk.WithStack(err)
+	}
+	for i := range res.Refs {
+		res.Refs[i].Peeled = peeled[res.Refs[i].Name]
+	}
+	return res, nil
+}
+
+// Fetch creates the mirror if needed, then fetches all the branches and tags of the remote repository.
+func (m Mirror) Fetch(ctx context.Context) error {
+	refs, err := m.LsRemote(ctx)
+	if err != nil {
+		return err
+	}
+	return m.fetch(ctx, refs.Head)
+}
+
+// fetch fetches the remote repository and points the HEAD of the mirror to given default branch.
+func (m Mirror) fetch(ctx context.Context, head string) error {
+	lock, _ := mirrorLocks.LoadOrStore(m.Dir, new(sync.Mutex))
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	if _, err := os.Stat(filepath.Join(m.Dir, "HEAD")); os.IsNotExist(err) {
+		if err := os.MkdirAll(m.Dir, os.FileMode(0700)); err != nil {
+			return sdk.WithStack(err)
+		}
+		if err := m.run(ctx, "", nil, nil, nil, "init", "--bare", "--quiet", m.Dir); err != nil {
+			return err
+		}
+	}
+	if err := m.run(ctx, m.Dir, nil, nil, nil, "fetch", "--quiet", "--prune", "--force", "--", m.URL,
+		"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"); err != nil {
+		return err
+	}
+	if head == "" {
+		return nil
+	}
+	return m.run(ctx, m.Dir, nil, nil, nil, "symbolic-ref", "HEAD", "refs/heads/"+head)
+}
+
+// LocalRefs returns the branches and tags of the mirror.
+func (m Mirror) LocalRefs(ctx context.Context) (map[string]string, error) {
+	res := make(map[string]string)
+	if _, err := os.Stat(filepath.Join(m.Dir, "HEAD")); os.IsNotExist(err) {
+		return res, nil
+	}
+	var out bytes.Buffer
+	if err := m.Git(ctx, &out, "for-each-ref", "--format=%(objectname) %(refname)", "refs/heads", "refs/tags"); err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if t := strings.SplitN(line, " ", 2); len(t) == 2 {
+			res[t[1]] = t[0]
+		}
+	}
+	return res, nil
+}
+
+// FetchIfOutdated fetches the remote repository if the branches and tags of the mirror differ from given references.
+// It returns true if a fetch was done.
+func (m Mirror) FetchIfOutdated(ctx context.Context, remote RemoteRefs) (bool, error) {
+	local, err := m.LocalRefs(ctx)
+	if err != nil {
+		return false, err
+	}
+	remoteRefs := append(remote.Branches(), remote.Tags()...)
+	outdated := len(local) == 0 || len(local) != len(remoteRefs)
+	for _, r := range remoteRefs {
+		if local[r.Name] != r.Hash {
+			outdated = true
+			break
+		}
+	}
+	if !outdated && remote.Head != "" {
+		var head bytes.Buffer
+		if err := m.Git(ctx, &head, "symbolic-ref", "HEAD"); err != nil {
+			return false, err
+		}
+		outdated = strings.TrimSpace(head.String()) != "refs/heads/"+remote.Head
+	}
+	if !outdated {
+		return false, nil
+	}
+	return true, m.fetch(ctx, remote.Head)
+}
+
+// UploadPack runs the server side of a fetch from the mirror over the smart HTTP protocol. If advertise is true, only
+// the references are advertised. The protocol is the value of the Git-Protocol header sent by the client.
+func (m Mirror) UploadPack(ctx context.Context, protocol string, stdin io.Reader, stdout io.Writer, advertise bool) error {
+	args := []string{"upload-pack", "--stateless-rpc"}
+	if advertise {
+		args = append(args, "--advertise-refs")
+	}
+	var env []string
+	if protocol != "" {
+		env = append(env, "GIT_PROTOCOL="+protocol)
+	}
+	return m.run(ctx, "", stdin, stdout, env, append(args, m.Dir)...)
+}
+
+// CloneTo makes a working copy of the mirror in given directory, the origin of the working copy is the remote
+// repository.
+func (m Mirror) CloneTo(ctx context.Context, dir string) error {
+	// The mirror is a local repository, the file protocol is only allowed for this clone
+	if err := m.run(ctx, "", nil, nil, []string{"GIT_ALLOW_PROTOCOL=file"}, "clone", "--quiet", "--", m.Dir, dir); err != nil {
+		return err
+	}
+	return m.run(ctx, filepath.Join(dir, ".git"), nil, nil, nil, "remote", "set-url", "origin", m.URL)
+}
+
+// Git runs a git command in the mirror, the output of the command is written to given writer.
+func (m Mirror) Git(ctx context.Context, stdout io.Writer, args ...string) error {
+	return m.run(ctx, m.Dir, nil, stdout, nil, args...)
+}
+
+func (m Mirror) run(ctx context.Context, dir string, stdin io.Reader, stdout io.Writer, extraEnv []string, args ...string) error {
+	env, cleanup, err := m.env()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	env = append(env, extraEnv...)
+
+	if dir != "" {
+		args = append([]string{"--git-dir", dir}, args...)
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if verbose {
+		LogFunc("Executing Command git %s", strings.Join(args, " "))
+	}
+	if err := cmd.Run(); err != nil {
+		return sdk.WithStack(fmt.Errorf("git %s failed: %v: %s", args[0], err, strings.TrimSpace(stderr.String())))
+	}
+	return nil
+}
+
+func (m Mirror) env() ([]string, func(), error) {
+	env := []string{"GIT_TERMINAL_PROMPT=0", "GIT_ALLOW_PROTOCOL=" + mirrorAllowedProtocols}
+	cleanup := func() {}
+	if m.Auth == nil {
+		return env, cleanup, nil
+	}
+
+	if m.Auth.Username != "" || m.Auth.Password != "" {
+		basic := base64.StdEncoding.EncodeToString([]byte(m.Auth.Username + ":" + m.Auth.Password))
+		env = append(env,
+			"GIT_CONFIG_COUNT=1",
+			"GIT_CONFIG_KEY_0=http.extraHeader",
+			"GIT_CONFIG_VALUE_0=Authorization: Basic "+basic,
+		)
+	}
+
+	keyFile := m.Auth.PrivateKey.Filename
+	if keyFile == "" && len(m.Auth.PrivateKey.Content) > 0 {
+		dir, err := os.MkdirTemp("", "cds-git-mirror-")
+		if err != nil {
+			return nil, cleanup, sdk.WithStack(err)
+		}
+		cleanup = func() { _ = os.RemoveAll(dir) }
+		keyFile = filepath.Join(dir, "id_rsa")
+		content := m.Auth.PrivateKey.Content
+		if !bytes.HasSuffix(content, []byte("\n")) {
+			content = append(content, '\n')
+		}
+		if err := os.WriteFile(keyFile, content, os.FileMode(0600)); err != nil {
+			cleanup()
+			return nil, func() {}, sdk.WithStack(err)
+		}
+	}
+	if keyFile != "" {
+		env = append(env, "GIT_SSH_COMMAND=ssh -F /dev/null -o IdentitiesOnly=yes -o StrictHostKeyChecking=no -i "+keyFile)
+	}
+	return env, cleanup, nil
+}
@@ -0,0 +1,123 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// MirrorGitCommands are the read only git commands that a git mirror server runs on a mirror for a client.
+var MirrorGitCommands = []string{"log", "diff", "ls-tree", "cat-file", "archive"}
+
+// mirrorForbiddenOptions are the options of MirrorGitCommands that write files or reach other repositories.
+var mirrorForbiddenOptions = []string{"--output", "-o", "--remote", "--exec", "--ext-diff", "--textconv"}
+
+// MirrorCommand is a git command run by a git mirror server on the mirror of a remote repository.
+type MirrorCommand struct {
+	Args []string `json:"args"`
+}
+
+// Check returns an error if the command is not one of MirrorGitCommands or if it uses a forbidden option.
+func (c MirrorCommand) Check() error {
+	if len(c.Args) == 0 || !sdk.IsInArray(c.Args[0], MirrorGitCommands) {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "git command not allowed on mirrors")
+	}
+	for _, arg := range c.Args[1:] {
+		if arg == "--" {
+			break
+		}
+		for _, o := range mirrorForbiddenOptions {
+			if arg == o || strings.HasPrefix(arg, o+"=") || (!strings.HasPrefix(o, "--") && strings.HasPrefix(arg, o)) {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "git option %q not allowed on mirrors", arg)
+			}
+		}
+	}
+	return nil
+}
+
+// MirrorClient reads remote repositories through the mirrors of a git mirror server.
+type MirrorClient struct {
+	URL string
+	// Headers are sent with each request, they contain the authorization of the client and its mirror access
+	Headers    http.Header
+	HTTPClient *http.Client
+}
+
+// LsRemote lists the references of a remote repository, the credentials are checked by the mirror server.
+func (c MirrorClient) LsRemote(ctx context.Context, repo string, auth *AuthOpts) (RemoteRefs, error) {
+	var refs RemoteRefs
+	var out bytes.Buffer
+	if err := c.request(ctx, http.MethodGet, repo, "/refs", auth, nil, &out); err != nil {
+		return refs, err
+	}
+	if err := json.Unmarshal(out.Bytes(), &refs); err != nil {
+		return refs, sdk.WithStack(err)
+	}
+	return refs, nil
+}
+
+// Git runs a read only git command on the mirror of a remote repository, the mirror is updated before if its
+// references differ from the remote ones. The output of the command is written to given writer.
+func (c MirrorClient) Git(ctx context.Context, repo string, auth *AuthOpts, stdout io.Writer, args ...string) error {
+	cmd := MirrorCommand{Args: args}
+	if err := cmd.Check(); err != nil {
+		return err
+	}
+	return c.request(ctx, http.MethodPost, repo, "/command", auth, cmd, stdout)
+}
+
+func (c MirrorClient) request(ctx context.Context, method, repo, path string, auth *AuthOpts, in interface{}, out io.Writer) error {
+	var body io.Reader
+	if in != nil {
+		btes, err := json.Marshal(in)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		body = bytes.NewReader(btes)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, MirrorCloneURL(c.URL, repo)+path, body)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	for k, v := range c.Headers {
+		req.Header[k] = v
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if auth != nil {
+		if auth.Username != "" || auth.Password != "" {
+			req.Header.Set(HeaderMirrorUpstreamAuthorization, "Basic "+base64.StdEncoding.EncodeToString([]byte(auth.Username+":"+auth.Password)))
+		}
+		if len(auth.PrivateKey.Content) > 0 {
+			req.Header.Set(HeaderMirrorUpstreamSSHKey, base64.StdEncoding.EncodeToString(auth.PrivateKey.Content))
+		}
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		btes, _ := io.ReadAll(resp.Body)
+		if err := sdk.DecodeError(btes); err != nil {
+			return err
+		}
+		return sdk.WithStack(fmt.Errorf("git mirror server returned %d: %s", resp.StatusCode, strings.TrimSpace(string(btes))))
+	}
+	_, err = io.Copy(out, resp.Body)
+	return sdk.WithStack(err)
+}
@@ -0,0 +1,137 @@
+package git
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Headers sent to a git mirror server with the credentials of the remote repository, the server uses them to check
+// that the client is allowed to read the remote repository before serving its mirror.
+const (
+	HeaderMirrorUpstreamAuthorization = "X-Cds-Git-Upstream-Authorization"
+	HeaderMirrorUpstreamSSHKey        = "X-Cds-Git-Upstream-Ssh-Key"
+	// HeaderMirrorAccess contains the MirrorAccess signed by CDS API for the job of the client
+	HeaderMirrorAccess = "X-Cds-Git-Mirror-Access"
+)
+
+// MirrorAccess lists the remote repositories that a session can read from a git mirror server. It is signed by CDS
+// API with the repositories of the job for a worker, or with the urls of the git VCS servers for the VCS service.
+type MirrorAccess struct {
+	SessionID string   `json:"session_id"`
+	Upstreams []string `json:"upstreams"`
+	// ServerURLs gives access to all the repositories under these urls
+	ServerURLs []string `json:"server_urls,omitempty"`
+}
+
+// Allowed returns true if the given remote repository is in the access list or under one of its server urls.
+func (a MirrorAccess) Allowed(sessionID, upstream string) bool {
+	if a.SessionID == "" || a.SessionID != sessionID {
+		return false
+	}
+	if sdk.IsInArray(upstream, a.Upstreams) {
+		return true
+	}
+	for _, u := range a.ServerURLs {
+		if u != "" && strings.HasPrefix(upstream, strings.TrimSuffix(u, "/")+"/") && !strings.Contains(upstream, "..") {
+			return true
+		}
+	}
+	return false
+}
+
+var scpLikeURLRegexp = regexp.MustCompile(`^[A-Za-z0-9._~-]+@[A-Za-z0-9.-]+:[^/-]`)
+
+// CheckRemoteURL returns an error if the url of a remote repository doesn't use https, ssh or git protocols. Local paths
+// and other transports like file:// or ext:: are rejected.
+func CheckRemoteURL(repo string) error {
+	if strings.HasPrefix(repo, "-") {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid repository url %q", repo)
+	}
+	if u, err := url.Parse(repo); err == nil && strings.Contains(repo, "://") {
+		switch u.Scheme {
+		case "https", "ssh", "git":
+			if u.Host != "" {
+				return nil
+			}
+		}
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "repository url %q must use https, ssh or git protocol", repo)
+	}
+	if scpLikeURLRegexp.MatchString(repo) {
+		return nil
+	}
+	return sdk.NewErrorFrom(sdk.ErrWrongRequest, "repository url %q must use https, ssh or git protocol", repo)
+}
+
+// MirrorOpts contains the url of a git mirror server and the http headers to send to it.
+type MirrorOpts struct {
+	URL     string
+	Headers []string
+}
+
+// MirrorUpstreamID returns the identifier of a remote repository in the urls of a git mirror server.
+func MirrorUpstreamID(repo string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(repo))
+}
+
+// ParseMirrorUpstreamID returns the url of the remote repository from its identifier, only urls accepted by
+// CheckRemoteURL are valid.
+func ParseMirrorUpstreamID(id string) (string, error) {
+	btes, err := base64.RawURLEncoding.DecodeString(id)
+	if err != nil {
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid repository identifier")
+	}
+	if err := CheckRemoteURL(string(btes)); err != nil {
+		return "", err
+	}
+	return string(btes), nil
+}
+
+// MirrorCloneURL returns the url to use to clone a remote repository from a git mirror server.
+func MirrorCloneURL(mirrorURL, repo string) string {
+	return strings.TrimSuffix(mirrorURL, "/") + "/git/" + MirrorUpstreamID(repo)
+}
+
+// CloneFromMirror makes a git clone of a remote repository from a git mirror server, then sets the remote repository
+// as origin of the working copy.
+func CloneFromMirror(repo, workdirPath, path string, mirror MirrorOpts, opts *CloneOpts, output *OutputOpts) (string, error) {
+	if verbose {
+		defer func(start time.Time) {
+			LogFunc("Git clone %s from mirror (%v s)", path, int(time.Since(start).Seconds()))
+		}(time.Now())
+	}
+
+	if path == "" {
+		t := strings.Split(strings.TrimSuffix(repo, "/"), "/")
+		path = strings.TrimSuffix(t[len(t)-1], ".git")
+	}
+
+	userLogCommand, commands, err := prepareGitCloneCommands(MirrorCloneURL(mirror.URL, repo), workdirPath, path, opts)
+	if err != nil {
+		return "", err
+	}
+
+	setURLCmd := cmd{
+		cmd:     "git",
+		workdir: path,
+		args:    []string{"remote", "set-url", "origin", repo},
+	}
+	if !sdk.PathIsAbs(path) {
+		setURLCmd.workdir = filepath.Join(workdirPath, path)
+	}
+	commands = append(commands, setURLCmd)
+
+	envs := []string{"GIT_TERMINAL_PROMPT=0", "GIT_CONFIG_COUNT=" + strconv.Itoa(len(mirror.Headers))}
+	for i, h := range mirror.Headers {
+		envs = append(envs, fmt.Sprintf("GIT_CONFIG_KEY_%d=http.extraHeader", i), fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", i, h))
+	}
+
+	return userLogCommand, runGitCommandRaw(commands, output, envs...)
+}
@@ -0,0 +1,162 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func runTestGit(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=John Doe", "GIT_AUTHOR_EMAIL=john.doe@example.com",
+		"GIT_COMMITTER_NAME=John Doe", "GIT_COMMITTER_EMAIL=john.doe@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return string(bytes.TrimSpace(out))
+}
+
+// serveTestGitRepositories serves the bare repositories of given directory over TLS smart HTTP, as mirrors can only
+// reach remote repositories.
+func serveTestGitRepositories(t *testing.T, root string) string {
+	gitPath, err := exec.LookPath("git")
+	require.NoError(t, err)
+	srv := httptest.NewTLSServer(&cgi.Handler{
+		Path: gitPath,
+		Args: []string{"http-backend"},
+		Env:  []string{"GIT_PROJECT_ROOT=" + root, "GIT_HTTP_EXPORT_ALL=1"},
+	})
+	t.Cleanup(srv.Close)
+	t.Setenv("GIT_SSL_NO_VERIFY", "1")
+	return srv.URL
+}
+
+func TestCloneFromMirror(t *testing.T) {
+	root := t.TempDir()
+	upstream := filepath.Join(root, "project.git")
+	runTestGit(t, root, "init", "--bare", "--quiet", "--initial-branch=main", upstream)
+	work := filepath.Join(root, "work")
+	runTestGit(t, root, "clone", "--quiet", upstream, work)
+	runTestGit(t, work, "checkout", "--quiet", "-b", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(work, "README.md"), []byte("# project\n"), os.FileMode(0644)))
+	runTestGit(t, work, "add", ".")
+	runTestGit(t, work, "commit", "--quiet", "-m", "first commit")
+	runTestGit(t, work, "push", "--quiet", "origin", "main")
+
+	// Local paths can't be mirrored
+	ctx := context.TODO()
+	_, err := Mirror{URL: upstream, Dir: filepath.Join(root, "mirror")}.LsRemote(ctx)
+	require.Error(t, err)
+
+	upstream = serveTestGitRepositories(t, root) + "/project.git"
+	m := Mirror{URL: upstream, Dir: filepath.Join(root, "mirror")}
+	refs, err := m.LsRemote(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "main", refs.Head)
+	fetched, err := m.FetchIfOutdated(ctx, refs)
+	require.NoError(t, err)
+	require.True(t, fetched)
+	fetched, err = m.FetchIfOutdated(ctx, refs)
+	require.NoError(t, err)
+	require.False(t, fetched)
+
+	// Minimal smart HTTP server on the mirror
+	id := MirrorUpstreamID(upstream)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer my-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		protocol := r.Header.Get("Git-Protocol")
+		switch r.URL.Path {
+		case "/git/" + id + "/info/refs":
+			w.Header().Set("Content-Type", "application/x-git-upload-pack-advertisement")
+			if !strings.Contains(protocol, "version=2") {
+				s := "# service=git-upload-pack\n"
+				fmt.Fprintf(w, "%04x%s0000", len(s)+4, s)
+			}
+			require.NoError(t, m.UploadPack(r.Context(), protocol, nil, w, true))
+		case "/git/" + id + "/git-upload-pack":
+			w.Header().Set("Content-Type", "application/x-git-upload-pack-result")
+			require.NoError(t, m.UploadPack(r.Context(), protocol, r.Body, w, false))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	clone := filepath.Join(root, "clone")
+	require.NoError(t, os.MkdirAll(clone, os.FileMode(0755)))
+	_, err = CloneFromMirror(upstream, clone, "", MirrorOpts{URL: srv.URL, Headers: []string{"Authorization: Bearer my-token"}}, &CloneOpts{Quiet: true}, nil)
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(clone, "project", "README.md"))
+	require.NoError(t, err)
+	require.Equal(t, "# project\n", string(content))
+	require.Equal(t, upstream, runTestGit(t, filepath.Join(clone, "project"), "remote", "get-url", "origin"))
+
+	_, err = CloneFromMirror(upstream, clone, "other", MirrorOpts{URL: srv.URL}, &CloneOpts{Quiet: true}, nil)
+	require.Error(t, err)
+}
+
+func TestCheckRemoteURL(t *testing.T) {
+	for _, u := range []string{"https://github.com/ovh/cds.git", "ssh://git@github.com/ovh/cds.git", "git@github.com:ovh/cds.git", "git://github.com/ovh/cds.git"} {
+		require.NoError(t, CheckRemoteURL(u), u)
+	}
+	for _, u := range []string{"", "file:///tmp/repo", "/var/lib/mirror/repo", "./repo", "ext::sh -c id", "-uhelp", "http://github.com/ovh/cds.git", "https:///repo"} {
+		require.Error(t, CheckRemoteURL(u), u)
+	}
+
+	_, err := ParseMirrorUpstreamID(MirrorUpstreamID("file:///etc"))
+	require.Error(t, err)
+	u, err := ParseMirrorUpstreamID(MirrorUpstreamID("https://github.com/ovh/cds.git"))
+	require.NoError(t, err)
+	require.Equal(t, "https://github.com/ovh/cds.git", u)
+}
+
+func TestMirrorAccessAllowed(t *testing.T) {
+	a := MirrorAccess{SessionID: "session", Upstreams: []string{"https://github.com/ovh/cds.git"}}
+	require.True(t, a.Allowed("session", "https://github.com/ovh/cds.git"))
+	require.False(t, a.Allowed("other", "https://github.com/ovh/cds.git"))
+	require.False(t, a.Allowed("session", "https://github.com/ovh/other.git"))
+	require.False(t, MirrorAccess{}.Allowed("", ""))
+}
+
+func TestMirrorAccessAllowedServerURLs(t *testing.T) {
+	a := MirrorAccess{SessionID: "session", ServerURLs: []string{"ssh://git@git.example.com:2222/"}}
+	require.True(t, a.Allowed("session", "ssh://git@git.example.com:2222/team/project.git"))
+	require.False(t, a.Allowed("session", "ssh://git@git.example.com:2222"))
+	require.False(t, a.Allowed("session", "ssh://git@git.example.com:22222/team/project.git"))
+	require.False(t, a.Allowed("session", "ssh://git@git.example.com:2222/../other.git"))
+	require.False(t, a.Allowed("other", "ssh://git@git.example.com:2222/team/project.git"))
+}
+
+func TestMirrorCommandCheck(t *testing.T) {
+	for _, args := range [][]string{
+		{"log", "--format=%H", "--max-count=1", "main", "--"},
+		{"diff", "--name-only", "-z", "a", "b", "--"},
+		{"archive", "--format=tar", "main", "--", "-o"},
+	} {
+		require.NoError(t, MirrorCommand{Args: args}.Check(), args)
+	}
+	for _, args := range [][]string{
+		nil,
+		{"push", "origin"},
+		{"log", "--output=/tmp/file", "main"},
+		{"archive", "--format=tar", "-o/tmp/file", "main"},
+		{"archive", "--remote=ssh://host/repo", "main"},
+	} {
+		require.Error(t, MirrorCommand{Args: args}.Check(), args)
+	}
+}
@@ -0,0 +1,279 @@
+package sdk
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Annotation levels of a check run
+const (
+	VCSCheckRunAnnotationLevelNotice  = "notice"
+	VCSCheckRunAnnotationLevelWarning = "warning"
+	VCSCheckRunAnnotationLevelFailure = "failure"
+)
+
+// Limits applied on check runs, most repository managers reject bigger reports
+const (
+	VCSCheckRunMaxAnnotations = 1000
+	VCSCheckRunMaxFailedTests = 50
+)
+
+// Code quality report formats
+const (
+	CodeQualityFormatSARIF      = "sarif"
+	CodeQualityFormatCheckstyle = "checkstyle"
+)
+
+// VCSCheckRunAnnotation is a message on a line range of a file.
+type VCSCheckRunAnnotation struct {
+	Path      string `json:"path"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+	Level     string `json:"level"`
+	Title     string `json:"title,omitempty"`
+	Message   string `json:"message"`
+	Rule      string `json:"rule,omitempty"`
+}
+
+// VCSCheckRunFailedTest is a failed test case with its failure message.
+type VCSCheckRunFailedTest struct {
+	Name    string `json:"name"`
+	Message string `json:"message,omitempty"`
+}
+
+// VCSCheckRun is a detailed report of a workflow node run sent to the repository manager with its status. Repository
+// managers without check API only receive the status.
+type VCSCheckRun struct {
+	Name        string                  `json:"name"`
+	Title       string                  `json:"title"`
+	Summary     string                  `json:"summary"`
+	URL         string                  `json:"url,omitempty"`
+	Tests       TestsStats              `json:"tests"`
+	FailedTests []VCSCheckRunFailedTest `json:"failed_tests,omitempty"`
+	Annotations []VCSCheckRunAnnotation `json:"annotations,omitempty"`
+}
+
+// IsEmpty returns true if the check run contains neither tests nor annotations.
+func (c VCSCheckRun) IsEmpty() bool {
+	return c.Tests.Total == 0 && len(c.Annotations) == 0
+}
+
+// NewVCSCheckRun computes a check run from the tests results and the code quality annotations of a node run.
+func NewVCSCheckRun(name, status, url string, tests *TestsResults, annotations []VCSCheckRunAnnotation) VCSCheckRun {
+	c := VCSCheckRun{Name: name, URL: url}
+
+	if tests != nil {
+		c.Tests = tests.TestsStats
+		for _, ts := range tests.TestSuites {
+			for _, tc := range ts.TestCases {
+				failures := append(append([]JUnitTestFailure{}, tc.Errors...), tc.Failures...)
+				if len(failures) == 0 {
+					continue
+				}
+				if len(c.FailedTests) >= VCSCheckRunMaxFailedTests {
+					break
+				}
+				testName := tc.Name
+				if ts.Name != "" {
+					testName = ts.Name + " / " + tc.Name
+				}
+				msg := failures[0].Message
+				if msg == "" {
+					msg = failures[0].Value
+				}
+				c.FailedTests = append(c.FailedTests, VCSCheckRunFailedTest{Name: testName, Message: StringFirstN(strings.TrimSpace(msg), 200)})
+			}
+		}
+	}
+
+	c.Annotations = annotations
+	sort.SliceStable(c.Annotations, func(i, j int) bool {
+		return annotationLevelOrder(c.Annotations[i].Level) > annotationLevelOrder(c.Annotations[j].Level)
+	})
+	if len(c.Annotations) > VCSCheckRunMaxAnnotations {
+		c.Annotations = c.Annotations[:VCSCheckRunMaxAnnotations]
+	}
+
+	levels := make(map[string]int)
+	for _, a := range annotations {
+		levels[a.Level]++
+	}
+
+	var titles []string
+	if c.Tests.TotalKO > 0 {
+		titles = append(titles, fmt.Sprintf("%d/%d tests failed", c.Tests.TotalKO, c.Tests.Total))
+	} else if c.Tests.Total > 0 {
+		titles = append(titles, fmt.Sprintf("%d tests passed", c.Tests.TotalOK))
+	}
+	if len(annotations) > 0 {
+		titles = append(titles, fmt.Sprintf("%d code quality issues", len(annotations)))
+	}
+	if len(titles) == 0 {
+		titles = append(titles, status)
+	}
+	c.Title = strings.Join(titles, ", ")
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**%s**: %s\n", name, status)
+	if c.Tests.Total > 0 {
+		fmt.Fprintf(&sb, "\n### Tests\n\n| Total | Passed | Failed | Skipped |\n|---|---|---|---|\n| %d | %d | %d | %d |\n",
+			c.Tests.Total, c.Tests.TotalOK, c.Tests.TotalKO, c.Tests.TotalSkipped)
+		if len(c.FailedTests) > 0 {
+			sb.WriteString("\n#### Failed tests\n\n")
+			for _, t := range c.FailedTests {
+				if t.Message != "" {
+					fmt.Fprintf(&sb, "- `%s`: %s\n", t.Name, strings.ReplaceAll(t.Message, "\n", " "))
+				} else {
+					fmt.Fprintf(&sb, "- `%s`\n", t.Name)
+				}
+			}
+			if c.Tests.TotalKO > len(c.FailedTests) {
+				fmt.Fprintf(&sb, "- ... and %d more\n", c.Tests.TotalKO-len(c.FailedTests))
+			}
+		}
+	}
+	if len(annotations) > 0 {
+		fmt.Fprintf(&sb, "\n### Code quality\n\n| Failure | Warning | Notice |\n|---|---|---|\n| %d | %d | %d |\n",
+			levels[VCSCheckRunAnnotationLevelFailure], levels[VCSCheckRunAnnotationLevelWarning], levels[VCSCheckRunAnnotationLevelNotice])
+	}
+	if url != "" {
+		fmt.Fprintf(&sb, "\n[See details on CDS](%s)\n", url)
+	}
+	c.Summary = sb.String()
+
+	return c
+}
+
+func annotationLevelOrder(level string) int {
+	switch level {
+	case VCSCheckRunAnnotationLevelFailure:
+		return 2
+	case VCSCheckRunAnnotationLevelWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ParseCodeQualityReport reads the annotations of a SARIF or checkstyle report.
+func ParseCodeQualityReport(format string, data []byte) ([]VCSCheckRunAnnotation, error) {
+	switch format {
+	case CodeQualityFormatSARIF:
+		return parseSARIF(data)
+	case CodeQualityFormatCheckstyle:
+		return parseCheckstyle(data)
+	}
+	return nil, NewErrorFrom(ErrWrongRequest, "unsupported code quality format %q", format)
+}
+
+type sarifReport struct {
+	Runs []struct {
+		Results []struct {
+			RuleID  string `json:"ruleId"`
+			Level   string `json:"level"`
+			Message struct {
+				Text string `json:"text"`
+			} `json:"message"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Region struct {
+						StartLine int `json:"startLine"`
+						EndLine   int `json:"endLine"`
+					} `json:"region"`
+				} `json:"physicalLocation"`
+			} `json:"locations"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+func parseSARIF(data []byte) ([]VCSCheckRunAnnotation, error) {
+	var report sarifReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid SARIF report: %v", err)
+	}
+	var res []VCSCheckRunAnnotation
+	for _, run := range report.Runs {
+		for _, r := range run.Results {
+			a := VCSCheckRunAnnotation{
+				Message: r.Message.Text,
+				Rule:    r.RuleID,
+				Title:   r.RuleID,
+			}
+			switch r.Level {
+			case "error":
+				a.Level = VCSCheckRunAnnotationLevelFailure
+			case "note", "none":
+				a.Level = VCSCheckRunAnnotationLevelNotice
+			default:
+				a.Level = VCSCheckRunAnnotationLevelWarning
+			}
+			if len(r.Locations) > 0 {
+				loc := r.Locations[0].PhysicalLocation
+				a.Path = strings.TrimPrefix(loc.ArtifactLocation.URI, "file://")
+				a.StartLine = loc.Region.StartLine
+				a.EndLine = loc.Region.EndLine
+			}
+			res = append(res, a.normalize())
+		}
+	}
+	return res, nil
+}
+
+type checkstyleReport struct {
+	Files []struct {
+		Name   string `xml:"name,attr"`
+		Errors []struct {
+			Line     string `xml:"line,attr"`
+			Severity string `xml:"severity,attr"`
+			Message  string `xml:"message,attr"`
+			Source   string `xml:"source,attr"`
+		} `xml:"error"`
+	} `xml:"file"`
+}
+
+func parseCheckstyle(data []byte) ([]VCSCheckRunAnnotation, error) {
+	var report checkstyleReport
+	if err := xml.Unmarshal(data, &report); err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid checkstyle report: %v", err)
+	}
+	var res []VCSCheckRunAnnotation
+	for _, f := range report.Files {
+		for _, e := range f.Errors {
+			line, _ := strconv.Atoi(e.Line)
+			a := VCSCheckRunAnnotation{
+				Path:      f.Name,
+				StartLine: line,
+				Message:   e.Message,
+				Rule:      e.Source,
+				Title:     e.Source,
+			}
+			switch e.Severity {
+			case "error":
+				a.Level = VCSCheckRunAnnotationLevelFailure
+			case "info", "ignore":
+				a.Level = VCSCheckRunAnnotationLevelNotice
+			default:
+				a.Level = VCSCheckRunAnnotationLevelWarning
+			}
+			res = append(res, a.normalize())
+		}
+	}
+	return res, nil
+}
+
+func (a VCSCheckRunAnnotation) normalize() VCSCheckRunAnnotation {
+	if a.StartLine < 1 {
+		a.StartLine = 1
+	}
+	if a.EndLine < a.StartLine {
+		a.EndLine = a.StartLine
+	}
+	return a
+}
@@ -0,0 +1,62 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCodeQualityReport(t *testing.T) {
+	sarif := `{"runs":[{"results":[
+{"ruleId":"G101","level":"error","message":{"text":"Hardcoded credentials"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"main.go"},"region":{"startLine":12}}}]},
+{"ruleId":"S1000","level":"note","message":{"text":"Should use a simple channel send"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"file://pkg/foo.go"},"region":{"startLine":3,"endLine":5}}}]}
+]}]}`
+	annotations, err := ParseCodeQualityReport(CodeQualityFormatSARIF, []byte(sarif))
+	require.NoError(t, err)
+	require.Equal(t, []VCSCheckRunAnnotation{
+		{Path: "main.go", StartLine: 12, EndLine: 12, Level: VCSCheckRunAnnotationLevelFailure, Title: "G101", Message: "Hardcoded credentials", Rule: "G101"},
+		{Path: "pkg/foo.go", StartLine: 3, EndLine: 5, Level: VCSCheckRunAnnotationLevelNotice, Title: "S1000", Message: "Should use a simple channel send", Rule: "S1000"},
+	}, annotations)
+
+	checkstyle := `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="5.0">
+  <file name="main.go">
+    <error line="7" column="2" severity="warning" message="exported function should have comment" source="golint"></error>
+  </file>
+</checkstyle>`
+	annotations, err = ParseCodeQualityReport(CodeQualityFormatCheckstyle, []byte(checkstyle))
+	require.NoError(t, err)
+	require.Equal(t, []VCSCheckRunAnnotation{
+		{Path: "main.go", StartLine: 7, EndLine: 7, Level: VCSCheckRunAnnotationLevelWarning, Title: "golint", Message: "exported function should have comment", Rule: "golint"},
+	}, annotations)
+
+	_, err = ParseCodeQualityReport("unknown", nil)
+	require.Error(t, err)
+}
+
+func TestNewVCSCheckRun(t *testing.T) {
+	tests := &TestsResults{
+		JUnitTestsSuites: JUnitTestsSuites{TestSuites: []JUnitTestSuite{{
+			Name: "suite",
+			TestCases: []JUnitTestCase{
+				{Name: "TestOK"},
+				{Name: "TestKO", Failures: []JUnitTestFailure{{Message: "expected 1, got 2"}}},
+			},
+		}}},
+		TestsStats: TestsStats{Total: 2, TotalOK: 1, TotalKO: 1},
+	}
+	annotations := []VCSCheckRunAnnotation{
+		{Path: "a.go", StartLine: 1, EndLine: 1, Level: VCSCheckRunAnnotationLevelNotice, Message: "notice"},
+		{Path: "b.go", StartLine: 2, EndLine: 2, Level: VCSCheckRunAnnotationLevelFailure, Message: "failure"},
+	}
+
+	c := NewVCSCheckRun("build", StatusFail, "https://cds/run/1", tests, annotations)
+	require.False(t, c.IsEmpty())
+	require.Equal(t, "1/2 tests failed, 2 code quality issues", c.Title)
+	require.Equal(t, []VCSCheckRunFailedTest{{Name: "suite / TestKO", Message: "expected 1, got 2"}}, c.FailedTests)
+	require.Equal(t, VCSCheckRunAnnotationLevelFailure, c.Annotations[0].Level)
+	require.Contains(t, c.Summary, "- `suite / TestKO`: expected 1, got 2")
+	require.Contains(t, c.Summary, "[See details on CDS](https://cds/run/1)")
+
+	require.True(t, NewVCSCheckRun("build", StatusSuccess, "", nil, nil).IsEmpty())
+}
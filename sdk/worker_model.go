@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -296,3 +297,33 @@ func ModelsToGroupIDs(ms []*Model) []int64 {
 	}
 	return ids
 }
+
+// MatchRequirements returns true if the model can run a job with given requirements, checking the model, binary and
+// os-architecture requirements.
+func (m Model) MatchRequirements(reqs RequirementList) bool {
+	for _, r := range reqs {
+		switch r.Type {
+		case ModelRequirement:
+			name := strings.Split(r.Value, " ")[0]
+			if name != m.Name && (m.Group == nil || name != m.Group.Name+"/"+m.Name) {
+				return false
+			}
+		case BinaryRequirement:
+			var found bool
+			for _, c := range m.RegisteredCapabilities {
+				if r.Value == c.Value || r.Value == c.Name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		case OSArchRequirement:
+			if m.RegisteredOS != nil && m.RegisteredArch != nil && *m.RegisteredOS != "" && r.Value != *m.RegisteredOS+"/"+*m.RegisteredArch {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -54,6 +54,7 @@ type NodeContext struct {
 	DefaultPipelineParameters []Parameter            `json:"default_pipeline_parameters" db:"-"`
 	Conditions                WorkflowNodeConditions `json:"conditions" db:"-"`
 	Mutex                     bool                   `json:"mutex" db:"mutex"`
+	PathFilter                PathFilter             `json:"path_filter,omitempty" db:"-"`
 }
 
 // FilterHooksConfig filter all hooks configuration and remove somme configuration key
@@ -158,6 +158,9 @@ type WorkflowNodeRunRelease struct {
 	ReleaseTitle   string   `json:"release_title"`
 	ReleaseContent string   `json:"release_content"`
 	Artifacts      []string `json:"artifacts,omitempty"`
+	// GenerateReleaseNotes appends the release notes generated from the commits since PreviousTag to ReleaseContent
+	GenerateReleaseNotes bool   `json:"generate_release_notes,omitempty"`
+	PreviousTag          string `json:"previous_tag,omitempty"`
 }
 
 // WorkflowRunPostHandlerOption contains the body content for launch a workflow
@@ -499,6 +502,9 @@ type WorkflowNodeJobRun struct {
 	HatcheryName       string             `json:"hatchery_name,omitempty"`
 	WorkerName         string             `json:"worker_name,omitempty"`
 	IntegrationPlugins []GRPCPlugin       `json:"integration_plugin,omitempty"`
+
+	// QueueInfo is only computed for waiting jobs when requested
+	QueueInfo *WorkflowNodeJobRunQueueInfo `json:"queue_info,omitempty"`
 }
 
 type BookedBy struct {
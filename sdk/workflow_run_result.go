@@ -13,6 +13,7 @@ const (
 	WorkflowRunResultTypeCoverage        WorkflowRunResultType = "coverage"
 	WorkflowRunResultTypeArtifactManager WorkflowRunResultType = "artifact-manager"
 	WorkflowRunResultTypeStaticFile      WorkflowRunResultType = "static-file"
+	WorkflowRunResultTypeCodeQuality     WorkflowRunResultType = "code-quality"
 )
 
 type WorkflowRunResultType string
@@ -172,6 +173,14 @@ func (r *WorkflowRunResult) GetArtifactManager() (WorkflowRunResultArtifactManag
 	return data, nil
 }
 
+func (r *WorkflowRunResult) GetCodeQuality() (WorkflowRunResultCodeQuality, error) {
+	var data WorkflowRunResultCodeQuality
+	if err := JSONUnmarshal(r.DataRaw, &data); err != nil {
+		return data, WithStack(err)
+	}
+	return data, nil
+}
+
 func (r *WorkflowRunResult) GetStaticFile() (WorkflowRunResultStaticFile, error) {
 	var data WorkflowRunResultStaticFile
 	if err := JSONUnmarshal(r.DataRaw, &data); err != nil {
@@ -234,6 +243,25 @@ func (a *WorkflowRunResultStaticFile) IsValid() error {
 	return nil
 }
 
+type WorkflowRunResultCodeQuality struct {
+	WorkflowRunResultArtifactCommon
+	Format      string                  `json:"format"`
+	Annotations []VCSCheckRunAnnotation `json:"annotations"`
+}
+
+func (a *WorkflowRunResultCodeQuality) IsValid() error {
+	if a.Name == "" {
+		return WrapError(ErrInvalidData, "missing code quality report name")
+	}
+	if a.Format != CodeQualityFormatSARIF && a.Format != CodeQualityFormatCheckstyle {
+		return WrapError(ErrInvalidData, "invalid code quality report format %q", a.Format)
+	}
+	if len(a.Annotations) > VCSCheckRunMaxAnnotations {
+		return WrapError(ErrInvalidData, "too many annotations, maximum is %d", VCSCheckRunMaxAnnotations)
+	}
+	return nil
+}
+
 type WorkflowRunResultArtifact struct {
 	WorkflowRunResultArtifactCommon
 	Size       int64  `json:"size"`
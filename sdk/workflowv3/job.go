@@ -180,6 +180,7 @@ func ConvertJob(j sdk.Job, isFullExport bool) Job {
 			ArtifactUpload:   s.ArtifactUpload,
 			GitClone:         s.GitClone,
 			GitTag:           s.GitTag,
+			Semver:           s.Semver,
 			ReleaseVCS:       s.ReleaseVCS,
 			JUnitReport:      s.JUnitReport,
 			Checkout:         s.Checkout,
@@ -18,6 +18,7 @@ type Step struct {
 	ArtifactUpload            *exportentities.StepArtifactUpload   `json:"artifactUpload,omitempty" yaml:"artifactUpload,omitempty"`
 	GitClone                  *exportentities.StepGitClone         `json:"gitClone,omitempty" yaml:"gitClone,omitempty"`
 	GitTag                    *exportentities.StepGitTag           `json:"gitTag,omitempty" yaml:"gitTag,omitempty"`
+	Semver                    *exportentities.StepSemver           `json:"semver,omitempty" yaml:"semver,omitempty"`
 	ReleaseVCS                *exportentities.StepReleaseVCS       `json:"releaseVCS,omitempty" yaml:"releaseVCS,omitempty"`
 	Release                   *exportentities.StepRelease          `json:"release,omitempty" yaml:"release,omitempty"`
 	JUnitReport               *exportentities.StepJUnitReport      `json:"jUnitReport,omitempty" yaml:"jUnitReport,omitempty"`
@@ -132,6 +133,9 @@ func (s Step) Validate(w Workflow) (ExternalDependencies, error) {
 	if s.GitTag != nil {
 		actionTypes = append(actionTypes, "gitTag")
 	}
+	if s.Semver != nil {
+		actionTypes = append(actionTypes, "semver")
+	}
 	if s.ReleaseVCS != nil {
 		actionTypes = append(actionTypes, "releaseVCS")
 	}
@@ -739,4 +739,11 @@ export class UserNotificationTemplate {
     body: string;
     disable_comment: boolean;
     disable_status: boolean;
+    gerrit_labels: Array<GerritLabel>;
+}
+
+export class GerritLabel {
+    name: string;
+    on_success: number;
+    on_failure: number;
 }